@@ -21,10 +21,121 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type WatchEventType int32
+
+const (
+	WatchEventType_PUT    WatchEventType = 0
+	WatchEventType_DELETE WatchEventType = 1
+	WatchEventType_EXPIRE WatchEventType = 2
+	WatchEventType_EVICT  WatchEventType = 3
+)
+
+// Enum value maps for WatchEventType.
+var (
+	WatchEventType_name = map[int32]string{
+		0: "PUT",
+		1: "DELETE",
+		2: "EXPIRE",
+		3: "EVICT",
+	}
+	WatchEventType_value = map[string]int32{
+		"PUT":    0,
+		"DELETE": 1,
+		"EXPIRE": 2,
+		"EVICT":  3,
+	}
+)
+
+func (x WatchEventType) Enum() *WatchEventType {
+	p := new(WatchEventType)
+	*p = x
+	return p
+}
+
+func (x WatchEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_kv_proto_enumTypes[0].Descriptor()
+}
+
+func (WatchEventType) Type() protoreflect.EnumType {
+	return &file_api_proto_kv_proto_enumTypes[0]
+}
+
+func (x WatchEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchEventType.Descriptor instead.
+func (WatchEventType) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{0}
+}
+
+type PubSubRequest_Action int32
+
+const (
+	PubSubRequest_SUBSCRIBE    PubSubRequest_Action = 0
+	PubSubRequest_UNSUBSCRIBE  PubSubRequest_Action = 1 // channels 为空表示退订全部频道
+	PubSubRequest_PSUBSCRIBE   PubSubRequest_Action = 2
+	PubSubRequest_PUNSUBSCRIBE PubSubRequest_Action = 3 // channels 为空表示退订全部模式
+	PubSubRequest_PUBLISH      PubSubRequest_Action = 4 // 发布到 channels[0]
+	PubSubRequest_PING         PubSubRequest_Action = 5
+)
+
+// Enum value maps for PubSubRequest_Action.
+var (
+	PubSubRequest_Action_name = map[int32]string{
+		0: "SUBSCRIBE",
+		1: "UNSUBSCRIBE",
+		2: "PSUBSCRIBE",
+		3: "PUNSUBSCRIBE",
+		4: "PUBLISH",
+		5: "PING",
+	}
+	PubSubRequest_Action_value = map[string]int32{
+		"SUBSCRIBE":    0,
+		"UNSUBSCRIBE":  1,
+		"PSUBSCRIBE":   2,
+		"PUNSUBSCRIBE": 3,
+		"PUBLISH":      4,
+		"PING":         5,
+	}
+)
+
+func (x PubSubRequest_Action) Enum() *PubSubRequest_Action {
+	p := new(PubSubRequest_Action)
+	*p = x
+	return p
+}
+
+func (x PubSubRequest_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PubSubRequest_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_kv_proto_enumTypes[1].Descriptor()
+}
+
+func (PubSubRequest_Action) Type() protoreflect.EnumType {
+	return &file_api_proto_kv_proto_enumTypes[1]
+}
+
+func (x PubSubRequest_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PubSubRequest_Action.Descriptor instead.
+func (PubSubRequest_Action) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{10, 0}
+}
+
 type SetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Lease         int64                  `protobuf:"varint,3,opt,name=lease,proto3" json:"lease,omitempty"` // 非 0 时绑定到租约，租约到期或撤销时删除
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -73,6 +184,13 @@ func (x *SetRequest) GetValue() string {
 	return ""
 }
 
+func (x *SetRequest) GetLease() int64 {
+	if x != nil {
+		return x.Lease
+	}
+	return 0
+}
+
 type SetResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -301,85 +419,8970 @@ func (x *DelResponse) GetSuccess() bool {
 	return false
 }
 
-var File_api_proto_kv_proto protoreflect.FileDescriptor
+type WatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Prefix        bool                   `protobuf:"varint,2,opt,name=prefix,proto3" json:"prefix,omitempty"`                                    // true 表示订阅以 key 为前缀的所有 Key
+	StartRevision uint64                 `protobuf:"varint,3,opt,name=start_revision,json=startRevision,proto3" json:"start_revision,omitempty"` // > 0 时先回放该 revision 起的历史事件
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_proto_kv_proto_rawDesc = "" +
-	"\n" +
-	"\x12api/proto/kv.proto\x12\aservice\"4\n" +
-	"\n" +
-	"SetRequest\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value\"'\n" +
-	"\vSetResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x1e\n" +
-	"\n" +
-	"GetRequest\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\"9\n" +
-	"\vGetResponse\x12\x14\n" +
-	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
-	"\x05found\x18\x02 \x01(\bR\x05found\"\x1e\n" +
-	"\n" +
-	"DelRequest\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\"'\n" +
-	"\vDelResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xa1\x01\n" +
-	"\tKVService\x120\n" +
-	"\x03Set\x12\x13.service.SetRequest\x1a\x14.service.SetResponse\x120\n" +
-	"\x03Get\x12\x13.service.GetRequest\x1a\x14.service.GetResponse\x120\n" +
-	"\x03Del\x12\x13.service.DelRequest\x1a\x14.service.DelResponseB#Z!Flux-KV/api/proto;serviceb\x06proto3"
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var (
-	file_api_proto_kv_proto_rawDescOnce sync.Once
-	file_api_proto_kv_proto_rawDescData []byte
-)
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_api_proto_kv_proto_rawDescGZIP() []byte {
-	file_api_proto_kv_proto_rawDescOnce.Do(func() {
-		file_api_proto_kv_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_kv_proto_rawDesc), len(file_api_proto_kv_proto_rawDesc)))
-	})
-	return file_api_proto_kv_proto_rawDescData
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_api_proto_kv_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_api_proto_kv_proto_goTypes = []any{
-	(*SetRequest)(nil),  // 0: service.SetRequest
-	(*SetResponse)(nil), // 1: service.SetResponse
-	(*GetRequest)(nil),  // 2: service.GetRequest
-	(*GetResponse)(nil), // 3: service.GetResponse
-	(*DelRequest)(nil),  // 4: service.DelRequest
-	(*DelResponse)(nil), // 5: service.DelResponse
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{6}
 }
-var file_api_proto_kv_proto_depIdxs = []int32{
-	0, // 0: service.KVService.Set:input_type -> service.SetRequest
-	2, // 1: service.KVService.Get:input_type -> service.GetRequest
-	4, // 2: service.KVService.Del:input_type -> service.DelRequest
-	1, // 3: service.KVService.Set:output_type -> service.SetResponse
-	3, // 4: service.KVService.Get:output_type -> service.GetResponse
-	5, // 5: service.KVService.Del:output_type -> service.DelResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+func (x *WatchRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
 }
 
-func init() { file_api_proto_kv_proto_init() }
-func file_api_proto_kv_proto_init() {
-	if File_api_proto_kv_proto != nil {
-		return
+func (x *WatchRequest) GetPrefix() bool {
+	if x != nil {
+		return x.Prefix
+	}
+	return false
+}
+
+func (x *WatchRequest) GetStartRevision() uint64 {
+	if x != nil {
+		return x.StartRevision
+	}
+	return 0
+}
+
+type WatchEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Type            WatchEventType         `protobuf:"varint,1,opt,name=type,proto3,enum=service.WatchEventType" json:"type,omitempty"`
+	Key             string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value           string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"` // 仅 PUT 事件有值
+	Revision        uint64                 `protobuf:"varint,4,opt,name=revision,proto3" json:"revision,omitempty"`
+	TimestampUnixMs int64                  `protobuf:"varint,5,opt,name=timestamp_unix_ms,json=timestampUnixMs,proto3" json:"timestamp_unix_ms,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WatchEvent) Reset() {
+	*x = WatchEvent{}
+	mi := &file_api_proto_kv_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEvent) ProtoMessage() {}
+
+func (x *WatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEvent.ProtoReflect.Descriptor instead.
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WatchEvent) GetType() WatchEventType {
+	if x != nil {
+		return x.Type
+	}
+	return WatchEventType_PUT
+}
+
+func (x *WatchEvent) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *WatchEvent) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *WatchEvent) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *WatchEvent) GetTimestampUnixMs() int64 {
+	if x != nil {
+		return x.TimestampUnixMs
+	}
+	return 0
+}
+
+type PublishRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishRequest) Reset() {
+	*x = PublishRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishRequest) ProtoMessage() {}
+
+func (x *PublishRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishRequest.ProtoReflect.Descriptor instead.
+func (*PublishRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PublishRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *PublishRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type PublishResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receivers     int64                  `protobuf:"varint,1,opt,name=receivers,proto3" json:"receivers,omitempty"` // 收到消息的订阅者数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishResponse) Reset() {
+	*x = PublishResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishResponse) ProtoMessage() {}
+
+func (x *PublishResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishResponse.ProtoReflect.Descriptor instead.
+func (*PublishResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PublishResponse) GetReceivers() int64 {
+	if x != nil {
+		return x.Receivers
+	}
+	return 0
+}
+
+type PubSubRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        PubSubRequest_Action   `protobuf:"varint,1,opt,name=action,proto3,enum=service.PubSubRequest_Action" json:"action,omitempty"`
+	Channels      []string               `protobuf:"bytes,2,rep,name=channels,proto3" json:"channels,omitempty"` // 频道名或 glob 模式
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`   // 仅 PUBLISH 使用
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PubSubRequest) Reset() {
+	*x = PubSubRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubRequest) ProtoMessage() {}
+
+func (x *PubSubRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubRequest.ProtoReflect.Descriptor instead.
+func (*PubSubRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PubSubRequest) GetAction() PubSubRequest_Action {
+	if x != nil {
+		return x.Action
+	}
+	return PubSubRequest_SUBSCRIBE
+}
+
+func (x *PubSubRequest) GetChannels() []string {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *PubSubRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type PubSubMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// message / pmessage 为推送的消息，其余为对请求的确认:
+	// subscribe / unsubscribe / psubscribe / punsubscribe / publish / pong
+	Kind          string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Channel       string `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	Pattern       string `protobuf:"bytes,3,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Payload       string `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	Count         int64  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"` // 订阅确认时为当前订阅总数，publish 确认时为接收者数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PubSubMessage) Reset() {
+	*x = PubSubMessage{}
+	mi := &file_api_proto_kv_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubMessage) ProtoMessage() {}
+
+func (x *PubSubMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubMessage.ProtoReflect.Descriptor instead.
+func (*PubSubMessage) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PubSubMessage) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PubSubMessage) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *PubSubMessage) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *PubSubMessage) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *PubSubMessage) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type StreamField struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamField) Reset() {
+	*x = StreamField{}
+	mi := &file_api_proto_kv_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamField) ProtoMessage() {}
+
+func (x *StreamField) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamField.ProtoReflect.Descriptor instead.
+func (*StreamField) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StreamField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StreamField) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type StreamEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`         // 毫秒-序号，例如 1700000000000-0
+	Fields        []*StreamField         `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"` // 已被裁剪的消息（XREADGROUP 历史 / XCLAIM）为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEntry) Reset() {
+	*x = StreamEntry{}
+	mi := &file_api_proto_kv_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEntry) ProtoMessage() {}
+
+func (x *StreamEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEntry.ProtoReflect.Descriptor instead.
+func (*StreamEntry) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StreamEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StreamEntry) GetFields() []*StreamField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type XAddRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"` // 为空或 "*" 表示自动生成
+	Fields        []*StreamField         `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+	MaxLen        int64                  `protobuf:"varint,4,opt,name=max_len,json=maxLen,proto3" json:"max_len,omitempty"` // > 0 时追加后裁剪到最多 max_len 条
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XAddRequest) Reset() {
+	*x = XAddRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XAddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XAddRequest) ProtoMessage() {}
+
+func (x *XAddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XAddRequest.ProtoReflect.Descriptor instead.
+func (*XAddRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *XAddRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *XAddRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *XAddRequest) GetFields() []*StreamField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *XAddRequest) GetMaxLen() int64 {
+	if x != nil {
+		return x.MaxLen
+	}
+	return 0
+}
+
+type XAddResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XAddResponse) Reset() {
+	*x = XAddResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XAddResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XAddResponse) ProtoMessage() {}
+
+func (x *XAddResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XAddResponse.ProtoReflect.Descriptor instead.
+func (*XAddResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *XAddResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type XRangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Start         string                 `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`      // 为空表示 "-"
+	End           string                 `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`          // 为空表示 "+"
+	Count         int64                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`     // <= 0 表示不限
+	Reverse       bool                   `protobuf:"varint,5,opt,name=reverse,proto3" json:"reverse,omitempty"` // 按 ID 从大到小返回
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XRangeRequest) Reset() {
+	*x = XRangeRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XRangeRequest) ProtoMessage() {}
+
+func (x *XRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XRangeRequest.ProtoReflect.Descriptor instead.
+func (*XRangeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *XRangeRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *XRangeRequest) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *XRangeRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *XRangeRequest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *XRangeRequest) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+type XRangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*StreamEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XRangeResponse) Reset() {
+	*x = XRangeResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XRangeResponse) ProtoMessage() {}
+
+func (x *XRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XRangeResponse.ProtoReflect.Descriptor instead.
+func (*XRangeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *XRangeResponse) GetEntries() []*StreamEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type XTrimRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	MaxLen        int64                  `protobuf:"varint,2,opt,name=max_len,json=maxLen,proto3" json:"max_len,omitempty"` // 与 min_id 二选一
+	MinId         string                 `protobuf:"bytes,3,opt,name=min_id,json=minId,proto3" json:"min_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XTrimRequest) Reset() {
+	*x = XTrimRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XTrimRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XTrimRequest) ProtoMessage() {}
+
+func (x *XTrimRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XTrimRequest.ProtoReflect.Descriptor instead.
+func (*XTrimRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *XTrimRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *XTrimRequest) GetMaxLen() int64 {
+	if x != nil {
+		return x.MaxLen
+	}
+	return 0
+}
+
+func (x *XTrimRequest) GetMinId() string {
+	if x != nil {
+		return x.MinId
+	}
+	return ""
+}
+
+type XTrimResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Removed       int64                  `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XTrimResponse) Reset() {
+	*x = XTrimResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XTrimResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XTrimResponse) ProtoMessage() {}
+
+func (x *XTrimResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XTrimResponse.ProtoReflect.Descriptor instead.
+func (*XTrimResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *XTrimResponse) GetRemoved() int64 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+type XReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []string               `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Ids           []string               `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`      // 与 keys 一一对应，为空表示 "$"（只读新消息）
+	Count         int64                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"` // 每批最多读取的条数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XReadRequest) Reset() {
+	*x = XReadRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XReadRequest) ProtoMessage() {}
+
+func (x *XReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XReadRequest.ProtoReflect.Descriptor instead.
+func (*XReadRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *XReadRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *XReadRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *XReadRequest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type XReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Entry         *StreamEntry           `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XReadResponse) Reset() {
+	*x = XReadResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XReadResponse) ProtoMessage() {}
+
+func (x *XReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XReadResponse.ProtoReflect.Descriptor instead.
+func (*XReadResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *XReadResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *XReadResponse) GetEntry() *StreamEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type XGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Group         string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`              // 创建时组的起始位置，为空表示 "$"
+	Mkstream      bool                   `protobuf:"varint,4,opt,name=mkstream,proto3" json:"mkstream,omitempty"` // Stream 不存在时自动创建
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XGroupRequest) Reset() {
+	*x = XGroupRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XGroupRequest) ProtoMessage() {}
+
+func (x *XGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XGroupRequest.ProtoReflect.Descriptor instead.
+func (*XGroupRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *XGroupRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *XGroupRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *XGroupRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *XGroupRequest) GetMkstream() bool {
+	if x != nil {
+		return x.Mkstream
+	}
+	return false
+}
+
+type XGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XGroupResponse) Reset() {
+	*x = XGroupResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XGroupResponse) ProtoMessage() {}
+
+func (x *XGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XGroupResponse.ProtoReflect.Descriptor instead.
+func (*XGroupResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *XGroupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type XReadGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Consumer      string                 `protobuf:"bytes,2,opt,name=consumer,proto3" json:"consumer,omitempty"`
+	Keys          []string               `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
+	Count         int64                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	NoAck         bool                   `protobuf:"varint,5,opt,name=no_ack,json=noAck,proto3" json:"no_ack,omitempty"` // 不记录待确认列表（至多一次）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XReadGroupRequest) Reset() {
+	*x = XReadGroupRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XReadGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XReadGroupRequest) ProtoMessage() {}
+
+func (x *XReadGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XReadGroupRequest.ProtoReflect.Descriptor instead.
+func (*XReadGroupRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *XReadGroupRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *XReadGroupRequest) GetConsumer() string {
+	if x != nil {
+		return x.Consumer
+	}
+	return ""
+}
+
+func (x *XReadGroupRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *XReadGroupRequest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *XReadGroupRequest) GetNoAck() bool {
+	if x != nil {
+		return x.NoAck
+	}
+	return false
+}
+
+type XAckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Group         string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Ids           []string               `protobuf:"bytes,3,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XAckRequest) Reset() {
+	*x = XAckRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XAckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XAckRequest) ProtoMessage() {}
+
+func (x *XAckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XAckRequest.ProtoReflect.Descriptor instead.
+func (*XAckRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *XAckRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *XAckRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *XAckRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type XAckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Acked         int64                  `protobuf:"varint,1,opt,name=acked,proto3" json:"acked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XAckResponse) Reset() {
+	*x = XAckResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XAckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XAckResponse) ProtoMessage() {}
+
+func (x *XAckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XAckResponse.ProtoReflect.Descriptor instead.
+func (*XAckResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *XAckResponse) GetAcked() int64 {
+	if x != nil {
+		return x.Acked
+	}
+	return 0
+}
+
+type XPendingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Group string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	// 以下参数用于查询明细，count <= 0 时只返回概况
+	Start         string `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End           string `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	Count         int64  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	Consumer      string `protobuf:"bytes,6,opt,name=consumer,proto3" json:"consumer,omitempty"`
+	MinIdleMs     int64  `protobuf:"varint,7,opt,name=min_idle_ms,json=minIdleMs,proto3" json:"min_idle_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XPendingRequest) Reset() {
+	*x = XPendingRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XPendingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XPendingRequest) ProtoMessage() {}
+
+func (x *XPendingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XPendingRequest.ProtoReflect.Descriptor instead.
+func (*XPendingRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *XPendingRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *XPendingRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *XPendingRequest) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *XPendingRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *XPendingRequest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *XPendingRequest) GetConsumer() string {
+	if x != nil {
+		return x.Consumer
+	}
+	return ""
+}
+
+func (x *XPendingRequest) GetMinIdleMs() int64 {
+	if x != nil {
+		return x.MinIdleMs
+	}
+	return 0
+}
+
+type PendingEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Consumer      string                 `protobuf:"bytes,2,opt,name=consumer,proto3" json:"consumer,omitempty"`
+	IdleMs        int64                  `protobuf:"varint,3,opt,name=idle_ms,json=idleMs,proto3" json:"idle_ms,omitempty"`
+	Deliveries    int64                  `protobuf:"varint,4,opt,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingEntry) Reset() {
+	*x = PendingEntry{}
+	mi := &file_api_proto_kv_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingEntry) ProtoMessage() {}
+
+func (x *PendingEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingEntry.ProtoReflect.Descriptor instead.
+func (*PendingEntry) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PendingEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PendingEntry) GetConsumer() string {
+	if x != nil {
+		return x.Consumer
+	}
+	return ""
+}
+
+func (x *PendingEntry) GetIdleMs() int64 {
+	if x != nil {
+		return x.IdleMs
+	}
+	return 0
+}
+
+func (x *PendingEntry) GetDeliveries() int64 {
+	if x != nil {
+		return x.Deliveries
+	}
+	return 0
+}
+
+type XPendingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	MinId         string                 `protobuf:"bytes,2,opt,name=min_id,json=minId,proto3" json:"min_id,omitempty"`
+	MaxId         string                 `protobuf:"bytes,3,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	Consumers     map[string]int64       `protobuf:"bytes,4,rep,name=consumers,proto3" json:"consumers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Entries       []*PendingEntry        `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XPendingResponse) Reset() {
+	*x = XPendingResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XPendingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XPendingResponse) ProtoMessage() {}
+
+func (x *XPendingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XPendingResponse.ProtoReflect.Descriptor instead.
+func (*XPendingResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *XPendingResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *XPendingResponse) GetMinId() string {
+	if x != nil {
+		return x.MinId
+	}
+	return ""
+}
+
+func (x *XPendingResponse) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *XPendingResponse) GetConsumers() map[string]int64 {
+	if x != nil {
+		return x.Consumers
+	}
+	return nil
+}
+
+func (x *XPendingResponse) GetEntries() []*PendingEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type XClaimRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Group         string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Consumer      string                 `protobuf:"bytes,3,opt,name=consumer,proto3" json:"consumer,omitempty"`
+	MinIdleMs     int64                  `protobuf:"varint,4,opt,name=min_idle_ms,json=minIdleMs,proto3" json:"min_idle_ms,omitempty"`
+	Ids           []string               `protobuf:"bytes,5,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XClaimRequest) Reset() {
+	*x = XClaimRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XClaimRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XClaimRequest) ProtoMessage() {}
+
+func (x *XClaimRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XClaimRequest.ProtoReflect.Descriptor instead.
+func (*XClaimRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *XClaimRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *XClaimRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *XClaimRequest) GetConsumer() string {
+	if x != nil {
+		return x.Consumer
+	}
+	return ""
+}
+
+func (x *XClaimRequest) GetMinIdleMs() int64 {
+	if x != nil {
+		return x.MinIdleMs
+	}
+	return 0
+}
+
+func (x *XClaimRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type XClaimResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*StreamEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XClaimResponse) Reset() {
+	*x = XClaimResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XClaimResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XClaimResponse) ProtoMessage() {}
+
+func (x *XClaimResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XClaimResponse.ProtoReflect.Descriptor instead.
+func (*XClaimResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *XClaimResponse) GetEntries() []*StreamEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type PFAddRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Elements      []string               `protobuf:"bytes,2,rep,name=elements,proto3" json:"elements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PFAddRequest) Reset() {
+	*x = PFAddRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PFAddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PFAddRequest) ProtoMessage() {}
+
+func (x *PFAddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PFAddRequest.ProtoReflect.Descriptor instead.
+func (*PFAddRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PFAddRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *PFAddRequest) GetElements() []string {
+	if x != nil {
+		return x.Elements
+	}
+	return nil
+}
+
+type PFAddResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changed       bool                   `protobuf:"varint,1,opt,name=changed,proto3" json:"changed,omitempty"` // 估算值是否可能发生变化
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PFAddResponse) Reset() {
+	*x = PFAddResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PFAddResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PFAddResponse) ProtoMessage() {}
+
+func (x *PFAddResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PFAddResponse.ProtoReflect.Descriptor instead.
+func (*PFAddResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PFAddResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+type PFCountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []string               `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"` // 多个 Key 时返回并集的基数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PFCountRequest) Reset() {
+	*x = PFCountRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PFCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PFCountRequest) ProtoMessage() {}
+
+func (x *PFCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PFCountRequest.ProtoReflect.Descriptor instead.
+func (*PFCountRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PFCountRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type PFCountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         uint64                 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PFCountResponse) Reset() {
+	*x = PFCountResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PFCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PFCountResponse) ProtoMessage() {}
+
+func (x *PFCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PFCountResponse.ProtoReflect.Descriptor instead.
+func (*PFCountResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PFCountResponse) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type PFMergeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dest          string                 `protobuf:"bytes,1,opt,name=dest,proto3" json:"dest,omitempty"`
+	Sources       []string               `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PFMergeRequest) Reset() {
+	*x = PFMergeRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PFMergeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PFMergeRequest) ProtoMessage() {}
+
+func (x *PFMergeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PFMergeRequest.ProtoReflect.Descriptor instead.
+func (*PFMergeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PFMergeRequest) GetDest() string {
+	if x != nil {
+		return x.Dest
+	}
+	return ""
+}
+
+func (x *PFMergeRequest) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+type PFMergeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PFMergeResponse) Reset() {
+	*x = PFMergeResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PFMergeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PFMergeResponse) ProtoMessage() {}
+
+func (x *PFMergeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PFMergeResponse.ProtoReflect.Descriptor instead.
+func (*PFMergeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PFMergeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type BFReserveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	ErrorRate     float64                `protobuf:"fixed64,2,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"` // 误判率，(0, 1)
+	Capacity      uint64                 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`                     // 预期元素数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BFReserveRequest) Reset() {
+	*x = BFReserveRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BFReserveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BFReserveRequest) ProtoMessage() {}
+
+func (x *BFReserveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BFReserveRequest.ProtoReflect.Descriptor instead.
+func (*BFReserveRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *BFReserveRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *BFReserveRequest) GetErrorRate() float64 {
+	if x != nil {
+		return x.ErrorRate
+	}
+	return 0
+}
+
+func (x *BFReserveRequest) GetCapacity() uint64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+type BFReserveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BFReserveResponse) Reset() {
+	*x = BFReserveResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BFReserveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BFReserveResponse) ProtoMessage() {}
+
+func (x *BFReserveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BFReserveResponse.ProtoReflect.Descriptor instead.
+func (*BFReserveResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *BFReserveResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type BFItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Items         []string               `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BFItemsRequest) Reset() {
+	*x = BFItemsRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BFItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BFItemsRequest) ProtoMessage() {}
+
+func (x *BFItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BFItemsRequest.ProtoReflect.Descriptor instead.
+func (*BFItemsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *BFItemsRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *BFItemsRequest) GetItems() []string {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type BFItemsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// BFAdd: 元素此前是否不存在；BFExists: 元素是否可能存在
+	Results       []bool `protobuf:"varint,1,rep,packed,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BFItemsResponse) Reset() {
+	*x = BFItemsResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BFItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BFItemsResponse) ProtoMessage() {}
+
+func (x *BFItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BFItemsResponse.ProtoReflect.Descriptor instead.
+func (*BFItemsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *BFItemsResponse) GetResults() []bool {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type CMSInitRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// 直接指定尺寸，或者给出误差率和失败概率由服务端计算
+	Width         uint32  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Depth         uint32  `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`
+	ErrorRate     float64 `protobuf:"fixed64,4,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"`
+	Probability   float64 `protobuf:"fixed64,5,opt,name=probability,proto3" json:"probability,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CMSInitRequest) Reset() {
+	*x = CMSInitRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CMSInitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CMSInitRequest) ProtoMessage() {}
+
+func (x *CMSInitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CMSInitRequest.ProtoReflect.Descriptor instead.
+func (*CMSInitRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CMSInitRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CMSInitRequest) GetWidth() uint32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *CMSInitRequest) GetDepth() uint32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *CMSInitRequest) GetErrorRate() float64 {
+	if x != nil {
+		return x.ErrorRate
+	}
+	return 0
+}
+
+func (x *CMSInitRequest) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+type CMSInitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Width         uint32                 `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Depth         uint32                 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CMSInitResponse) Reset() {
+	*x = CMSInitResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CMSInitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CMSInitResponse) ProtoMessage() {}
+
+func (x *CMSInitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CMSInitResponse.ProtoReflect.Descriptor instead.
+func (*CMSInitResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CMSInitResponse) GetWidth() uint32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *CMSInitResponse) GetDepth() uint32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type CMSIncrement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          string                 `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Increment     uint32                 `protobuf:"varint,2,opt,name=increment,proto3" json:"increment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CMSIncrement) Reset() {
+	*x = CMSIncrement{}
+	mi := &file_api_proto_kv_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CMSIncrement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CMSIncrement) ProtoMessage() {}
+
+func (x *CMSIncrement) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CMSIncrement.ProtoReflect.Descriptor instead.
+func (*CMSIncrement) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CMSIncrement) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *CMSIncrement) GetIncrement() uint32 {
+	if x != nil {
+		return x.Increment
+	}
+	return 0
+}
+
+type CMSIncrByRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Increments    []*CMSIncrement        `protobuf:"bytes,2,rep,name=increments,proto3" json:"increments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CMSIncrByRequest) Reset() {
+	*x = CMSIncrByRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CMSIncrByRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CMSIncrByRequest) ProtoMessage() {}
+
+func (x *CMSIncrByRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CMSIncrByRequest.ProtoReflect.Descriptor instead.
+func (*CMSIncrByRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CMSIncrByRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CMSIncrByRequest) GetIncrements() []*CMSIncrement {
+	if x != nil {
+		return x.Increments
+	}
+	return nil
+}
+
+type CMSQueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Items         []string               `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CMSQueryRequest) Reset() {
+	*x = CMSQueryRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CMSQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CMSQueryRequest) ProtoMessage() {}
+
+func (x *CMSQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CMSQueryRequest.ProtoReflect.Descriptor instead.
+func (*CMSQueryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CMSQueryRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CMSQueryRequest) GetItems() []string {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CMSCountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        []uint32               `protobuf:"varint,1,rep,packed,name=counts,proto3" json:"counts,omitempty"` // 与请求中的元素一一对应
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CMSCountsResponse) Reset() {
+	*x = CMSCountsResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CMSCountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CMSCountsResponse) ProtoMessage() {}
+
+func (x *CMSCountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CMSCountsResponse.ProtoReflect.Descriptor instead.
+func (*CMSCountsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CMSCountsResponse) GetCounts() []uint32 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type GeoLocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        string                 `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoLocation) Reset() {
+	*x = GeoLocation{}
+	mi := &file_api_proto_kv_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoLocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoLocation) ProtoMessage() {}
+
+func (x *GeoLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoLocation.ProtoReflect.Descriptor instead.
+func (*GeoLocation) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GeoLocation) GetMember() string {
+	if x != nil {
+		return x.Member
+	}
+	return ""
+}
+
+func (x *GeoLocation) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *GeoLocation) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+type GeoAddRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Locations     []*GeoLocation         `protobuf:"bytes,2,rep,name=locations,proto3" json:"locations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoAddRequest) Reset() {
+	*x = GeoAddRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoAddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoAddRequest) ProtoMessage() {}
+
+func (x *GeoAddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoAddRequest.ProtoReflect.Descriptor instead.
+func (*GeoAddRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GeoAddRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GeoAddRequest) GetLocations() []*GeoLocation {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+type GeoAddResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Added         int64                  `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"` // 新增的成员数（不含更新）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoAddResponse) Reset() {
+	*x = GeoAddResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoAddResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoAddResponse) ProtoMessage() {}
+
+func (x *GeoAddResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoAddResponse.ProtoReflect.Descriptor instead.
+func (*GeoAddResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GeoAddResponse) GetAdded() int64 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+type GeoPosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Members       []string               `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoPosRequest) Reset() {
+	*x = GeoPosRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoPosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoPosRequest) ProtoMessage() {}
+
+func (x *GeoPosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoPosRequest.ProtoReflect.Descriptor instead.
+func (*GeoPosRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GeoPosRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GeoPosRequest) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type GeoPosition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exists        bool                   `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoPosition) Reset() {
+	*x = GeoPosition{}
+	mi := &file_api_proto_kv_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoPosition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoPosition) ProtoMessage() {}
+
+func (x *GeoPosition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoPosition.ProtoReflect.Descriptor instead.
+func (*GeoPosition) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GeoPosition) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+func (x *GeoPosition) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *GeoPosition) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+type GeoPosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Positions     []*GeoPosition         `protobuf:"bytes,1,rep,name=positions,proto3" json:"positions,omitempty"` // 与请求中的成员一一对应
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoPosResponse) Reset() {
+	*x = GeoPosResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoPosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoPosResponse) ProtoMessage() {}
+
+func (x *GeoPosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoPosResponse.ProtoReflect.Descriptor instead.
+func (*GeoPosResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GeoPosResponse) GetPositions() []*GeoPosition {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+type GeoDistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Member1       string                 `protobuf:"bytes,2,opt,name=member1,proto3" json:"member1,omitempty"`
+	Member2       string                 `protobuf:"bytes,3,opt,name=member2,proto3" json:"member2,omitempty"`
+	Unit          string                 `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"` // m / km / mi / ft，默认 m
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoDistRequest) Reset() {
+	*x = GeoDistRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoDistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoDistRequest) ProtoMessage() {}
+
+func (x *GeoDistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoDistRequest.ProtoReflect.Descriptor instead.
+func (*GeoDistRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GeoDistRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GeoDistRequest) GetMember1() string {
+	if x != nil {
+		return x.Member1
+	}
+	return ""
+}
+
+func (x *GeoDistRequest) GetMember2() string {
+	if x != nil {
+		return x.Member2
+	}
+	return ""
+}
+
+func (x *GeoDistRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+type GeoDistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exists        bool                   `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"` // 任一成员不存在时为 false
+	Distance      float64                `protobuf:"fixed64,2,opt,name=distance,proto3" json:"distance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoDistResponse) Reset() {
+	*x = GeoDistResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoDistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoDistResponse) ProtoMessage() {}
+
+func (x *GeoDistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoDistResponse.ProtoReflect.Descriptor instead.
+func (*GeoDistResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GeoDistResponse) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+func (x *GeoDistResponse) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type GeoSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// 中心点：from_member 非空时使用该成员的位置，否则使用 longitude / latitude
+	FromMember string  `protobuf:"bytes,2,opt,name=from_member,json=fromMember,proto3" json:"from_member,omitempty"`
+	Longitude  float64 `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Latitude   float64 `protobuf:"fixed64,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	// 区域：radius > 0 时按圆形搜索，否则按 width x height 的矩形搜索
+	Radius        float64 `protobuf:"fixed64,5,opt,name=radius,proto3" json:"radius,omitempty"`
+	Width         float64 `protobuf:"fixed64,6,opt,name=width,proto3" json:"width,omitempty"`
+	Height        float64 `protobuf:"fixed64,7,opt,name=height,proto3" json:"height,omitempty"`
+	Unit          string  `protobuf:"bytes,8,opt,name=unit,proto3" json:"unit,omitempty"` // m / km / mi / ft，默认 m，同时也是返回距离的单位
+	Sort          string  `protobuf:"bytes,9,opt,name=sort,proto3" json:"sort,omitempty"` // ASC / DESC，空表示不排序（指定 count 且 any 为 false 时默认 ASC）
+	Count         int64   `protobuf:"varint,10,opt,name=count,proto3" json:"count,omitempty"`
+	Any           bool    `protobuf:"varint,11,opt,name=any,proto3" json:"any,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoSearchRequest) Reset() {
+	*x = GeoSearchRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoSearchRequest) ProtoMessage() {}
+
+func (x *GeoSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoSearchRequest.ProtoReflect.Descriptor instead.
+func (*GeoSearchRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GeoSearchRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GeoSearchRequest) GetFromMember() string {
+	if x != nil {
+		return x.FromMember
+	}
+	return ""
+}
+
+func (x *GeoSearchRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *GeoSearchRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GeoSearchRequest) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+func (x *GeoSearchRequest) GetWidth() float64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *GeoSearchRequest) GetHeight() float64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GeoSearchRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *GeoSearchRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *GeoSearchRequest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GeoSearchRequest) GetAny() bool {
+	if x != nil {
+		return x.Any
+	}
+	return false
+}
+
+type GeoSearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        string                 `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	Distance      float64                `protobuf:"fixed64,2,opt,name=distance,proto3" json:"distance,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Hash          uint64                 `protobuf:"varint,5,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoSearchResult) Reset() {
+	*x = GeoSearchResult{}
+	mi := &file_api_proto_kv_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoSearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoSearchResult) ProtoMessage() {}
+
+func (x *GeoSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoSearchResult.ProtoReflect.Descriptor instead.
+func (*GeoSearchResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GeoSearchResult) GetMember() string {
+	if x != nil {
+		return x.Member
+	}
+	return ""
+}
+
+func (x *GeoSearchResult) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *GeoSearchResult) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *GeoSearchResult) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GeoSearchResult) GetHash() uint64 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+type GeoSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*GeoSearchResult     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoSearchResponse) Reset() {
+	*x = GeoSearchResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoSearchResponse) ProtoMessage() {}
+
+func (x *GeoSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoSearchResponse.ProtoReflect.Descriptor instead.
+func (*GeoSearchResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GeoSearchResponse) GetResults() []*GeoSearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type TSSample struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // 毫秒
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSSample) Reset() {
+	*x = TSSample{}
+	mi := &file_api_proto_kv_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSSample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSSample) ProtoMessage() {}
+
+func (x *TSSample) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSSample.ProtoReflect.Descriptor instead.
+func (*TSSample) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *TSSample) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *TSSample) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type TSCreateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	RetentionMs   int64                  `protobuf:"varint,2,opt,name=retention_ms,json=retentionMs,proto3" json:"retention_ms,omitempty"` // 0 表示永久保留
+	Labels        map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSCreateRequest) Reset() {
+	*x = TSCreateRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSCreateRequest) ProtoMessage() {}
+
+func (x *TSCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSCreateRequest.ProtoReflect.Descriptor instead.
+func (*TSCreateRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *TSCreateRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TSCreateRequest) GetRetentionMs() int64 {
+	if x != nil {
+		return x.RetentionMs
+	}
+	return 0
+}
+
+func (x *TSCreateRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type TSCreateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSCreateResponse) Reset() {
+	*x = TSCreateResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSCreateResponse) ProtoMessage() {}
+
+func (x *TSCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSCreateResponse.ProtoReflect.Descriptor instead.
+func (*TSCreateResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *TSCreateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type TSAddRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Key       string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Timestamp int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // 毫秒，0 表示使用服务端当前时间
+	Value     float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	// Key 不存在时用于创建
+	RetentionMs   int64             `protobuf:"varint,4,opt,name=retention_ms,json=retentionMs,proto3" json:"retention_ms,omitempty"`
+	Labels        map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSAddRequest) Reset() {
+	*x = TSAddRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSAddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSAddRequest) ProtoMessage() {}
+
+func (x *TSAddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSAddRequest.ProtoReflect.Descriptor instead.
+func (*TSAddRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *TSAddRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TSAddRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *TSAddRequest) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *TSAddRequest) GetRetentionMs() int64 {
+	if x != nil {
+		return x.RetentionMs
+	}
+	return 0
+}
+
+func (x *TSAddRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type TSAddResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // 实际写入的时间戳
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSAddResponse) Reset() {
+	*x = TSAddResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSAddResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSAddResponse) ProtoMessage() {}
+
+func (x *TSAddResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSAddResponse.ProtoReflect.Descriptor instead.
+func (*TSAddResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *TSAddResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type TSGetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSGetRequest) Reset() {
+	*x = TSGetRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSGetRequest) ProtoMessage() {}
+
+func (x *TSGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSGetRequest.ProtoReflect.Descriptor instead.
+func (*TSGetRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *TSGetRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type TSGetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exists        bool                   `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"` // 序列为空时为 false
+	Sample        *TSSample              `protobuf:"bytes,2,opt,name=sample,proto3" json:"sample,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSGetResponse) Reset() {
+	*x = TSGetResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSGetResponse) ProtoMessage() {}
+
+func (x *TSGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSGetResponse.ProtoReflect.Descriptor instead.
+func (*TSGetResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *TSGetResponse) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+func (x *TSGetResponse) GetSample() *TSSample {
+	if x != nil {
+		return x.Sample
+	}
+	return nil
+}
+
+type TSAggregation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // avg / sum / min / max / count / first / last
+	BucketMs      int64                  `protobuf:"varint,2,opt,name=bucket_ms,json=bucketMs,proto3" json:"bucket_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSAggregation) Reset() {
+	*x = TSAggregation{}
+	mi := &file_api_proto_kv_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSAggregation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSAggregation) ProtoMessage() {}
+
+func (x *TSAggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSAggregation.ProtoReflect.Descriptor instead.
+func (*TSAggregation) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *TSAggregation) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TSAggregation) GetBucketMs() int64 {
+	if x != nil {
+		return x.BucketMs
+	}
+	return 0
+}
+
+type TSRangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	From          int64                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`                  // 0 表示不限
+	Aggregation   *TSAggregation         `protobuf:"bytes,4,opt,name=aggregation,proto3" json:"aggregation,omitempty"` // 为空时返回原始样本
+	Count         int64                  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSRangeRequest) Reset() {
+	*x = TSRangeRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSRangeRequest) ProtoMessage() {}
+
+func (x *TSRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSRangeRequest.ProtoReflect.Descriptor instead.
+func (*TSRangeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *TSRangeRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TSRangeRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *TSRangeRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *TSRangeRequest) GetAggregation() *TSAggregation {
+	if x != nil {
+		return x.Aggregation
+	}
+	return nil
+}
+
+func (x *TSRangeRequest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type TSRangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Samples       []*TSSample            `protobuf:"bytes,1,rep,name=samples,proto3" json:"samples,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSRangeResponse) Reset() {
+	*x = TSRangeResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSRangeResponse) ProtoMessage() {}
+
+func (x *TSRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSRangeResponse.ProtoReflect.Descriptor instead.
+func (*TSRangeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *TSRangeResponse) GetSamples() []*TSSample {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+type TSMRangeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  int64                  `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    int64                  `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"` // 0 表示不限
+	// label=value / label!=value / label= / label!=，至少包含一个 label=value
+	Filters       []string       `protobuf:"bytes,3,rep,name=filters,proto3" json:"filters,omitempty"`
+	Aggregation   *TSAggregation `protobuf:"bytes,4,opt,name=aggregation,proto3" json:"aggregation,omitempty"`
+	Count         int64          `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSMRangeRequest) Reset() {
+	*x = TSMRangeRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSMRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSMRangeRequest) ProtoMessage() {}
+
+func (x *TSMRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSMRangeRequest.ProtoReflect.Descriptor instead.
+func (*TSMRangeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *TSMRangeRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *TSMRangeRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *TSMRangeRequest) GetFilters() []string {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *TSMRangeRequest) GetAggregation() *TSAggregation {
+	if x != nil {
+		return x.Aggregation
+	}
+	return nil
+}
+
+func (x *TSMRangeRequest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type TSSeries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Samples       []*TSSample            `protobuf:"bytes,3,rep,name=samples,proto3" json:"samples,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSSeries) Reset() {
+	*x = TSSeries{}
+	mi := &file_api_proto_kv_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSSeries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSSeries) ProtoMessage() {}
+
+func (x *TSSeries) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSSeries.ProtoReflect.Descriptor instead.
+func (*TSSeries) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *TSSeries) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TSSeries) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *TSSeries) GetSamples() []*TSSample {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+type TSMRangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Series        []*TSSeries            `protobuf:"bytes,1,rep,name=series,proto3" json:"series,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSMRangeResponse) Reset() {
+	*x = TSMRangeResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSMRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSMRangeResponse) ProtoMessage() {}
+
+func (x *TSMRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSMRangeResponse.ProtoReflect.Descriptor instead.
+func (*TSMRangeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *TSMRangeResponse) GetSeries() []*TSSeries {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+type TSRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Dest          string                 `protobuf:"bytes,2,opt,name=dest,proto3" json:"dest,omitempty"`
+	Aggregation   *TSAggregation         `protobuf:"bytes,3,opt,name=aggregation,proto3" json:"aggregation,omitempty"` // 仅 TSCreateRule 使用
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSRuleRequest) Reset() {
+	*x = TSRuleRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSRuleRequest) ProtoMessage() {}
+
+func (x *TSRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSRuleRequest.ProtoReflect.Descriptor instead.
+func (*TSRuleRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *TSRuleRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *TSRuleRequest) GetDest() string {
+	if x != nil {
+		return x.Dest
+	}
+	return ""
+}
+
+func (x *TSRuleRequest) GetAggregation() *TSAggregation {
+	if x != nil {
+		return x.Aggregation
+	}
+	return nil
+}
+
+type TSRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSRuleResponse) Reset() {
+	*x = TSRuleResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSRuleResponse) ProtoMessage() {}
+
+func (x *TSRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSRuleResponse.ProtoReflect.Descriptor instead.
+func (*TSRuleResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *TSRuleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type TSInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSInfoRequest) Reset() {
+	*x = TSInfoRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSInfoRequest) ProtoMessage() {}
+
+func (x *TSInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSInfoRequest.ProtoReflect.Descriptor instead.
+func (*TSInfoRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *TSInfoRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type TSRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dest          string                 `protobuf:"bytes,1,opt,name=dest,proto3" json:"dest,omitempty"`
+	Aggregation   *TSAggregation         `protobuf:"bytes,2,opt,name=aggregation,proto3" json:"aggregation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TSRule) Reset() {
+	*x = TSRule{}
+	mi := &file_api_proto_kv_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSRule) ProtoMessage() {}
+
+func (x *TSRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSRule.ProtoReflect.Descriptor instead.
+func (*TSRule) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *TSRule) GetDest() string {
+	if x != nil {
+		return x.Dest
+	}
+	return ""
+}
+
+func (x *TSRule) GetAggregation() *TSAggregation {
+	if x != nil {
+		return x.Aggregation
+	}
+	return nil
+}
+
+type TSInfoResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TotalSamples   int64                  `protobuf:"varint,1,opt,name=total_samples,json=totalSamples,proto3" json:"total_samples,omitempty"`
+	MemoryUsage    int64                  `protobuf:"varint,2,opt,name=memory_usage,json=memoryUsage,proto3" json:"memory_usage,omitempty"`
+	ChunkCount     int64                  `protobuf:"varint,3,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	FirstTimestamp int64                  `protobuf:"varint,4,opt,name=first_timestamp,json=firstTimestamp,proto3" json:"first_timestamp,omitempty"`
+	LastTimestamp  int64                  `protobuf:"varint,5,opt,name=last_timestamp,json=lastTimestamp,proto3" json:"last_timestamp,omitempty"`
+	RetentionMs    int64                  `protobuf:"varint,6,opt,name=retention_ms,json=retentionMs,proto3" json:"retention_ms,omitempty"`
+	Labels         map[string]string      `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Rules          []*TSRule              `protobuf:"bytes,8,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TSInfoResponse) Reset() {
+	*x = TSInfoResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TSInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TSInfoResponse) ProtoMessage() {}
+
+func (x *TSInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TSInfoResponse.ProtoReflect.Descriptor instead.
+func (*TSInfoResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *TSInfoResponse) GetTotalSamples() int64 {
+	if x != nil {
+		return x.TotalSamples
+	}
+	return 0
+}
+
+func (x *TSInfoResponse) GetMemoryUsage() int64 {
+	if x != nil {
+		return x.MemoryUsage
+	}
+	return 0
+}
+
+func (x *TSInfoResponse) GetChunkCount() int64 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+func (x *TSInfoResponse) GetFirstTimestamp() int64 {
+	if x != nil {
+		return x.FirstTimestamp
+	}
+	return 0
+}
+
+func (x *TSInfoResponse) GetLastTimestamp() int64 {
+	if x != nil {
+		return x.LastTimestamp
+	}
+	return 0
+}
+
+func (x *TSInfoResponse) GetRetentionMs() int64 {
+	if x != nil {
+		return x.RetentionMs
+	}
+	return 0
+}
+
+func (x *TSInfoResponse) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *TSInfoResponse) GetRules() []*TSRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// path 为 JSONPath 风格，例如 $、$.a.b、$.list[0]，空表示根节点
+type JSONSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"` // JSON 文本
+	Nx            bool                   `protobuf:"varint,4,opt,name=nx,proto3" json:"nx,omitempty"`      // 仅在路径不存在时写入
+	Xx            bool                   `protobuf:"varint,5,opt,name=xx,proto3" json:"xx,omitempty"`      // 仅在路径存在时写入
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONSetRequest) Reset() {
+	*x = JSONSetRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONSetRequest) ProtoMessage() {}
+
+func (x *JSONSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONSetRequest.ProtoReflect.Descriptor instead.
+func (*JSONSetRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *JSONSetRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *JSONSetRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *JSONSetRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *JSONSetRequest) GetNx() bool {
+	if x != nil {
+		return x.Nx
+	}
+	return false
+}
+
+func (x *JSONSetRequest) GetXx() bool {
+	if x != nil {
+		return x.Xx
+	}
+	return false
+}
+
+type JSONSetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // NX / XX 条件不满足时为 false
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONSetResponse) Reset() {
+	*x = JSONSetResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONSetResponse) ProtoMessage() {}
+
+func (x *JSONSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONSetResponse.ProtoReflect.Descriptor instead.
+func (*JSONSetResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *JSONSetResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type JSONGetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Paths         []string               `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"` // 多个路径时返回 {path: value} 对象
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONGetRequest) Reset() {
+	*x = JSONGetRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONGetRequest) ProtoMessage() {}
+
+func (x *JSONGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONGetRequest.ProtoReflect.Descriptor instead.
+func (*JSONGetRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *JSONGetRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *JSONGetRequest) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+type JSONGetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONGetResponse) Reset() {
+	*x = JSONGetResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONGetResponse) ProtoMessage() {}
+
+func (x *JSONGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONGetResponse.ProtoReflect.Descriptor instead.
+func (*JSONGetResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *JSONGetResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *JSONGetResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type JSONDelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONDelRequest) Reset() {
+	*x = JSONDelRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONDelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONDelRequest) ProtoMessage() {}
+
+func (x *JSONDelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONDelRequest.ProtoReflect.Descriptor instead.
+func (*JSONDelRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *JSONDelRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *JSONDelRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type JSONDelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONDelResponse) Reset() {
+	*x = JSONDelResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONDelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONDelResponse) ProtoMessage() {}
+
+func (x *JSONDelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONDelResponse.ProtoReflect.Descriptor instead.
+func (*JSONDelResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *JSONDelResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+type JSONArrAppendRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Values        []string               `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"` // 每个元素都是 JSON 文本
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONArrAppendRequest) Reset() {
+	*x = JSONArrAppendRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONArrAppendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONArrAppendRequest) ProtoMessage() {}
+
+func (x *JSONArrAppendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONArrAppendRequest.ProtoReflect.Descriptor instead.
+func (*JSONArrAppendRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *JSONArrAppendRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *JSONArrAppendRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *JSONArrAppendRequest) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type JSONArrAppendResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Length        int64                  `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONArrAppendResponse) Reset() {
+	*x = JSONArrAppendResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONArrAppendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONArrAppendResponse) ProtoMessage() {}
+
+func (x *JSONArrAppendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONArrAppendResponse.ProtoReflect.Descriptor instead.
+func (*JSONArrAppendResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *JSONArrAppendResponse) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type JSONNumIncrByRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Delta         string                 `protobuf:"bytes,3,opt,name=delta,proto3" json:"delta,omitempty"` // 数字文本，整数相加时结果保持整数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONNumIncrByRequest) Reset() {
+	*x = JSONNumIncrByRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONNumIncrByRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONNumIncrByRequest) ProtoMessage() {}
+
+func (x *JSONNumIncrByRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONNumIncrByRequest.ProtoReflect.Descriptor instead.
+func (*JSONNumIncrByRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *JSONNumIncrByRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *JSONNumIncrByRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *JSONNumIncrByRequest) GetDelta() string {
+	if x != nil {
+		return x.Delta
+	}
+	return ""
+}
+
+type JSONNumIncrByResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONNumIncrByResponse) Reset() {
+	*x = JSONNumIncrByResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONNumIncrByResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONNumIncrByResponse) ProtoMessage() {}
+
+func (x *JSONNumIncrByResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONNumIncrByResponse.ProtoReflect.Descriptor instead.
+func (*JSONNumIncrByResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *JSONNumIncrByResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// 命名空间是 Key 中第一个 ':' 之前的部分，例如 user:42 属于 user
+type JSONIndexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"` // tag（等值）或 numeric（等值 + 范围），删除时忽略
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONIndexRequest) Reset() {
+	*x = JSONIndexRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONIndexRequest) ProtoMessage() {}
+
+func (x *JSONIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONIndexRequest.ProtoReflect.Descriptor instead.
+func (*JSONIndexRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *JSONIndexRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *JSONIndexRequest) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *JSONIndexRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type JSONIndexResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONIndexResponse) Reset() {
+	*x = JSONIndexResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONIndexResponse) ProtoMessage() {}
+
+func (x *JSONIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONIndexResponse.ProtoReflect.Descriptor instead.
+func (*JSONIndexResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *JSONIndexResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type JSONListIndexesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"` // 空表示全部
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONListIndexesRequest) Reset() {
+	*x = JSONListIndexesRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONListIndexesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONListIndexesRequest) ProtoMessage() {}
+
+func (x *JSONListIndexesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONListIndexesRequest.ProtoReflect.Descriptor instead.
+func (*JSONListIndexesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *JSONListIndexesRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type JSONIndexInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Entries       int64                  `protobuf:"varint,4,opt,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONIndexInfo) Reset() {
+	*x = JSONIndexInfo{}
+	mi := &file_api_proto_kv_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONIndexInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONIndexInfo) ProtoMessage() {}
+
+func (x *JSONIndexInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONIndexInfo.ProtoReflect.Descriptor instead.
+func (*JSONIndexInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *JSONIndexInfo) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *JSONIndexInfo) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *JSONIndexInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *JSONIndexInfo) GetEntries() int64 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+type JSONListIndexesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Indexes       []*JSONIndexInfo       `protobuf:"bytes,1,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONListIndexesResponse) Reset() {
+	*x = JSONListIndexesResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONListIndexesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONListIndexesResponse) ProtoMessage() {}
+
+func (x *JSONListIndexesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONListIndexesResponse.ProtoReflect.Descriptor instead.
+func (*JSONListIndexesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *JSONListIndexesResponse) GetIndexes() []*JSONIndexInfo {
+	if x != nil {
+		return x.Indexes
+	}
+	return nil
+}
+
+type FindRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Op            string                 `protobuf:"bytes,3,opt,name=op,proto3" json:"op,omitempty"` // = < <= > >= between
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Max           string                 `protobuf:"bytes,5,opt,name=max,proto3" json:"max,omitempty"`      // between 的上界
+	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"` // 0 表示不限制
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindRequest) Reset() {
+	*x = FindRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindRequest) ProtoMessage() {}
+
+func (x *FindRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindRequest.ProtoReflect.Descriptor instead.
+func (*FindRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *FindRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *FindRequest) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FindRequest) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *FindRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FindRequest) GetMax() string {
+	if x != nil {
+		return x.Max
+	}
+	return ""
+}
+
+func (x *FindRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type JSONMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Doc           string                 `protobuf:"bytes,2,opt,name=doc,proto3" json:"doc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONMatch) Reset() {
+	*x = JSONMatch{}
+	mi := &file_api_proto_kv_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONMatch) ProtoMessage() {}
+
+func (x *JSONMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONMatch.ProtoReflect.Descriptor instead.
+func (*JSONMatch) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *JSONMatch) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *JSONMatch) GetDoc() string {
+	if x != nil {
+		return x.Doc
+	}
+	return ""
+}
+
+type FindResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*JSONMatch           `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindResponse) Reset() {
+	*x = FindResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindResponse) ProtoMessage() {}
+
+func (x *FindResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindResponse.ProtoReflect.Descriptor instead.
+func (*FindResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *FindResponse) GetMatches() []*JSONMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type LockAcquireRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`                  // 持有者标识，续期和释放时校验
+	TtlMs         int64                  `protobuf:"varint,3,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"`    // 租期，必须大于 0
+	WaitMs        int64                  `protobuf:"varint,4,opt,name=wait_ms,json=waitMs,proto3" json:"wait_ms,omitempty"` // 阻塞等待的最长时间，0 表示不等待
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockAcquireRequest) Reset() {
+	*x = LockAcquireRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockAcquireRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockAcquireRequest) ProtoMessage() {}
+
+func (x *LockAcquireRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockAcquireRequest.ProtoReflect.Descriptor instead.
+func (*LockAcquireRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *LockAcquireRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LockAcquireRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *LockAcquireRequest) GetTtlMs() int64 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+func (x *LockAcquireRequest) GetWaitMs() int64 {
+	if x != nil {
+		return x.WaitMs
+	}
+	return 0
+}
+
+type LockAcquireResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Acquired      bool                   `protobuf:"varint,1,opt,name=acquired,proto3" json:"acquired,omitempty"`
+	Token         uint64                 `protobuf:"varint,2,opt,name=token,proto3" json:"token,omitempty"`              // fencing token，单调递增；失败时为当前持有者的 token
+	Holder        string                 `protobuf:"bytes,3,opt,name=holder,proto3" json:"holder,omitempty"`             // 失败时为当前持有者，锁空闲但有人排队时为空
+	TtlMs         int64                  `protobuf:"varint,4,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"` // 剩余租期
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockAcquireResponse) Reset() {
+	*x = LockAcquireResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockAcquireResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockAcquireResponse) ProtoMessage() {}
+
+func (x *LockAcquireResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockAcquireResponse.ProtoReflect.Descriptor instead.
+func (*LockAcquireResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *LockAcquireResponse) GetAcquired() bool {
+	if x != nil {
+		return x.Acquired
+	}
+	return false
+}
+
+func (x *LockAcquireResponse) GetToken() uint64 {
+	if x != nil {
+		return x.Token
+	}
+	return 0
+}
+
+func (x *LockAcquireResponse) GetHolder() string {
+	if x != nil {
+		return x.Holder
+	}
+	return ""
+}
+
+func (x *LockAcquireResponse) GetTtlMs() int64 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+type LockRenewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	TtlMs         int64                  `protobuf:"varint,3,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockRenewRequest) Reset() {
+	*x = LockRenewRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockRenewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockRenewRequest) ProtoMessage() {}
+
+func (x *LockRenewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockRenewRequest.ProtoReflect.Descriptor instead.
+func (*LockRenewRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *LockRenewRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LockRenewRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *LockRenewRequest) GetTtlMs() int64 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+type LockRenewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // false 表示锁已过期或被他人持有
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockRenewResponse) Reset() {
+	*x = LockRenewResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockRenewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockRenewResponse) ProtoMessage() {}
+
+func (x *LockRenewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockRenewResponse.ProtoReflect.Descriptor instead.
+func (*LockRenewResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *LockRenewResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type LockReleaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockReleaseRequest) Reset() {
+	*x = LockReleaseRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockReleaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockReleaseRequest) ProtoMessage() {}
+
+func (x *LockReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockReleaseRequest.ProtoReflect.Descriptor instead.
+func (*LockReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *LockReleaseRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LockReleaseRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+type LockReleaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockReleaseResponse) Reset() {
+	*x = LockReleaseResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockReleaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockReleaseResponse) ProtoMessage() {}
+
+func (x *LockReleaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockReleaseResponse.ProtoReflect.Descriptor instead.
+func (*LockReleaseResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *LockReleaseResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type LeaseGrantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TtlMs         int64                  `protobuf:"varint,1,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"` // 0 表示由服务端分配
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseGrantRequest) Reset() {
+	*x = LeaseGrantRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseGrantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseGrantRequest) ProtoMessage() {}
+
+func (x *LeaseGrantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseGrantRequest.ProtoReflect.Descriptor instead.
+func (*LeaseGrantRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *LeaseGrantRequest) GetTtlMs() int64 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+func (x *LeaseGrantRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type LeaseGrantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TtlMs         int64                  `protobuf:"varint,2,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseGrantResponse) Reset() {
+	*x = LeaseGrantResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseGrantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseGrantResponse) ProtoMessage() {}
+
+func (x *LeaseGrantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseGrantResponse.ProtoReflect.Descriptor instead.
+func (*LeaseGrantResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *LeaseGrantResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LeaseGrantResponse) GetTtlMs() int64 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+type LeaseKeepAliveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseKeepAliveRequest) Reset() {
+	*x = LeaseKeepAliveRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseKeepAliveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseKeepAliveRequest) ProtoMessage() {}
+
+func (x *LeaseKeepAliveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseKeepAliveRequest.ProtoReflect.Descriptor instead.
+func (*LeaseKeepAliveRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *LeaseKeepAliveRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type LeaseKeepAliveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TtlMs         int64                  `protobuf:"varint,2,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"` // 0 表示租约已不存在
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseKeepAliveResponse) Reset() {
+	*x = LeaseKeepAliveResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseKeepAliveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseKeepAliveResponse) ProtoMessage() {}
+
+func (x *LeaseKeepAliveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseKeepAliveResponse.ProtoReflect.Descriptor instead.
+func (*LeaseKeepAliveResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *LeaseKeepAliveResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LeaseKeepAliveResponse) GetTtlMs() int64 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+type LeaseRevokeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseRevokeRequest) Reset() {
+	*x = LeaseRevokeRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseRevokeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseRevokeRequest) ProtoMessage() {}
+
+func (x *LeaseRevokeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseRevokeRequest.ProtoReflect.Descriptor instead.
+func (*LeaseRevokeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *LeaseRevokeRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type LeaseRevokeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"` // 被删除的 Key 数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseRevokeResponse) Reset() {
+	*x = LeaseRevokeResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseRevokeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseRevokeResponse) ProtoMessage() {}
+
+func (x *LeaseRevokeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseRevokeResponse.ProtoReflect.Descriptor instead.
+func (*LeaseRevokeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *LeaseRevokeResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+type LeaseTimeToLiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Keys          bool                   `protobuf:"varint,2,opt,name=keys,proto3" json:"keys,omitempty"` // 是否返回绑定的 Key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseTimeToLiveRequest) Reset() {
+	*x = LeaseTimeToLiveRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseTimeToLiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseTimeToLiveRequest) ProtoMessage() {}
+
+func (x *LeaseTimeToLiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseTimeToLiveRequest.ProtoReflect.Descriptor instead.
+func (*LeaseTimeToLiveRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *LeaseTimeToLiveRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LeaseTimeToLiveRequest) GetKeys() bool {
+	if x != nil {
+		return x.Keys
+	}
+	return false
+}
+
+type LeaseTimeToLiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TtlMs         int64                  `protobuf:"varint,2,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"` // 剩余时间
+	GrantedTtlMs  int64                  `protobuf:"varint,3,opt,name=granted_ttl_ms,json=grantedTtlMs,proto3" json:"granted_ttl_ms,omitempty"`
+	Keys          []string               `protobuf:"bytes,4,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseTimeToLiveResponse) Reset() {
+	*x = LeaseTimeToLiveResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseTimeToLiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseTimeToLiveResponse) ProtoMessage() {}
+
+func (x *LeaseTimeToLiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseTimeToLiveResponse.ProtoReflect.Descriptor instead.
+func (*LeaseTimeToLiveResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *LeaseTimeToLiveResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LeaseTimeToLiveResponse) GetTtlMs() int64 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+func (x *LeaseTimeToLiveResponse) GetGrantedTtlMs() int64 {
+	if x != nil {
+		return x.GrantedTtlMs
+	}
+	return 0
+}
+
+func (x *LeaseTimeToLiveResponse) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type ThrottleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Rate          float64                `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"`  // 每秒补充的令牌数
+	Burst         int64                  `protobuf:"varint,3,opt,name=burst,proto3" json:"burst,omitempty"` // 桶容量
+	Cost          int64                  `protobuf:"varint,4,opt,name=cost,proto3" json:"cost,omitempty"`   // 本次消耗的令牌数，0 表示只查询
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThrottleRequest) Reset() {
+	*x = ThrottleRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThrottleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThrottleRequest) ProtoMessage() {}
+
+func (x *ThrottleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThrottleRequest.ProtoReflect.Descriptor instead.
+func (*ThrottleRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *ThrottleRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ThrottleRequest) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *ThrottleRequest) GetBurst() int64 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+func (x *ThrottleRequest) GetCost() int64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+type ThrottleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Remaining     int64                  `protobuf:"varint,3,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	RetryAfterMs  int64                  `protobuf:"varint,4,opt,name=retry_after_ms,json=retryAfterMs,proto3" json:"retry_after_ms,omitempty"` // 被拒绝时多久后可以重试
+	ResetAfterMs  int64                  `protobuf:"varint,5,opt,name=reset_after_ms,json=resetAfterMs,proto3" json:"reset_after_ms,omitempty"` // 多久后桶恢复满
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThrottleResponse) Reset() {
+	*x = ThrottleResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThrottleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThrottleResponse) ProtoMessage() {}
+
+func (x *ThrottleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThrottleResponse.ProtoReflect.Descriptor instead.
+func (*ThrottleResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *ThrottleResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *ThrottleResponse) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ThrottleResponse) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *ThrottleResponse) GetRetryAfterMs() int64 {
+	if x != nil {
+		return x.RetryAfterMs
+	}
+	return 0
+}
+
+func (x *ThrottleResponse) GetResetAfterMs() int64 {
+	if x != nil {
+		return x.ResetAfterMs
+	}
+	return 0
+}
+
+// ScriptValue 脚本的返回值，value 都未设置时表示 nil
+type ScriptValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*ScriptValue_Int
+	//	*ScriptValue_Float
+	//	*ScriptValue_Str
+	//	*ScriptValue_Bool
+	//	*ScriptValue_List
+	Value         isScriptValue_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScriptValue) Reset() {
+	*x = ScriptValue{}
+	mi := &file_api_proto_kv_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScriptValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScriptValue) ProtoMessage() {}
+
+func (x *ScriptValue) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScriptValue.ProtoReflect.Descriptor instead.
+func (*ScriptValue) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *ScriptValue) GetValue() isScriptValue_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ScriptValue) GetInt() int64 {
+	if x != nil {
+		if x, ok := x.Value.(*ScriptValue_Int); ok {
+			return x.Int
+		}
+	}
+	return 0
+}
+
+func (x *ScriptValue) GetFloat() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*ScriptValue_Float); ok {
+			return x.Float
+		}
+	}
+	return 0
+}
+
+func (x *ScriptValue) GetStr() string {
+	if x != nil {
+		if x, ok := x.Value.(*ScriptValue_Str); ok {
+			return x.Str
+		}
+	}
+	return ""
+}
+
+func (x *ScriptValue) GetBool() bool {
+	if x != nil {
+		if x, ok := x.Value.(*ScriptValue_Bool); ok {
+			return x.Bool
+		}
+	}
+	return false
+}
+
+func (x *ScriptValue) GetList() *ScriptList {
+	if x != nil {
+		if x, ok := x.Value.(*ScriptValue_List); ok {
+			return x.List
+		}
+	}
+	return nil
+}
+
+type isScriptValue_Value interface {
+	isScriptValue_Value()
+}
+
+type ScriptValue_Int struct {
+	Int int64 `protobuf:"varint,1,opt,name=int,proto3,oneof"`
+}
+
+type ScriptValue_Float struct {
+	Float float64 `protobuf:"fixed64,2,opt,name=float,proto3,oneof"`
+}
+
+type ScriptValue_Str struct {
+	Str string `protobuf:"bytes,3,opt,name=str,proto3,oneof"`
+}
+
+type ScriptValue_Bool struct {
+	Bool bool `protobuf:"varint,4,opt,name=bool,proto3,oneof"`
+}
+
+type ScriptValue_List struct {
+	List *ScriptList `protobuf:"bytes,5,opt,name=list,proto3,oneof"`
+}
+
+func (*ScriptValue_Int) isScriptValue_Value() {}
+
+func (*ScriptValue_Float) isScriptValue_Value() {}
+
+func (*ScriptValue_Str) isScriptValue_Value() {}
+
+func (*ScriptValue_Bool) isScriptValue_Value() {}
+
+func (*ScriptValue_List) isScriptValue_Value() {}
+
+type ScriptList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ScriptValue         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScriptList) Reset() {
+	*x = ScriptList{}
+	mi := &file_api_proto_kv_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScriptList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScriptList) ProtoMessage() {}
+
+func (x *ScriptList) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScriptList.ProtoReflect.Descriptor instead.
+func (*ScriptList) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *ScriptList) GetItems() []*ScriptValue {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type EvalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Script        string                 `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
+	Keys          []string               `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"` // 脚本中的 KEYS，只能访问这些 Key
+	Args          []string               `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"` // 脚本中的 ARGV
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvalRequest) Reset() {
+	*x = EvalRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvalRequest) ProtoMessage() {}
+
+func (x *EvalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvalRequest.ProtoReflect.Descriptor instead.
+func (*EvalRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *EvalRequest) GetScript() string {
+	if x != nil {
+		return x.Script
+	}
+	return ""
+}
+
+func (x *EvalRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *EvalRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+type EvalShaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sha           string                 `protobuf:"bytes,1,opt,name=sha,proto3" json:"sha,omitempty"`
+	Keys          []string               `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Args          []string               `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvalShaRequest) Reset() {
+	*x = EvalShaRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvalShaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvalShaRequest) ProtoMessage() {}
+
+func (x *EvalShaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvalShaRequest.ProtoReflect.Descriptor instead.
+func (*EvalShaRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *EvalShaRequest) GetSha() string {
+	if x != nil {
+		return x.Sha
+	}
+	return ""
+}
+
+func (x *EvalShaRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *EvalShaRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+type EvalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *ScriptValue           `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvalResponse) Reset() {
+	*x = EvalResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvalResponse) ProtoMessage() {}
+
+func (x *EvalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvalResponse.ProtoReflect.Descriptor instead.
+func (*EvalResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *EvalResponse) GetResult() *ScriptValue {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type ScriptLoadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Script        string                 `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScriptLoadRequest) Reset() {
+	*x = ScriptLoadRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScriptLoadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScriptLoadRequest) ProtoMessage() {}
+
+func (x *ScriptLoadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScriptLoadRequest.ProtoReflect.Descriptor instead.
+func (*ScriptLoadRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ScriptLoadRequest) GetScript() string {
+	if x != nil {
+		return x.Script
+	}
+	return ""
+}
+
+type ScriptLoadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sha           string                 `protobuf:"bytes,1,opt,name=sha,proto3" json:"sha,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScriptLoadResponse) Reset() {
+	*x = ScriptLoadResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScriptLoadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScriptLoadResponse) ProtoMessage() {}
+
+func (x *ScriptLoadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScriptLoadResponse.ProtoReflect.Descriptor instead.
+func (*ScriptLoadResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *ScriptLoadResponse) GetSha() string {
+	if x != nil {
+		return x.Sha
+	}
+	return ""
+}
+
+type ScriptExistsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shas          []string               `protobuf:"bytes,1,rep,name=shas,proto3" json:"shas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScriptExistsRequest) Reset() {
+	*x = ScriptExistsRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScriptExistsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScriptExistsRequest) ProtoMessage() {}
+
+func (x *ScriptExistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScriptExistsRequest.ProtoReflect.Descriptor instead.
+func (*ScriptExistsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *ScriptExistsRequest) GetShas() []string {
+	if x != nil {
+		return x.Shas
+	}
+	return nil
+}
+
+type ScriptExistsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exists        []bool                 `protobuf:"varint,1,rep,packed,name=exists,proto3" json:"exists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScriptExistsResponse) Reset() {
+	*x = ScriptExistsResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScriptExistsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScriptExistsResponse) ProtoMessage() {}
+
+func (x *ScriptExistsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScriptExistsResponse.ProtoReflect.Descriptor instead.
+func (*ScriptExistsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *ScriptExistsResponse) GetExists() []bool {
+	if x != nil {
+		return x.Exists
+	}
+	return nil
+}
+
+type ScriptFlushRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScriptFlushRequest) Reset() {
+	*x = ScriptFlushRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScriptFlushRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScriptFlushRequest) ProtoMessage() {}
+
+func (x *ScriptFlushRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScriptFlushRequest.ProtoReflect.Descriptor instead.
+func (*ScriptFlushRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{120}
+}
+
+type ScriptFlushResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScriptFlushResponse) Reset() {
+	*x = ScriptFlushResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScriptFlushResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScriptFlushResponse) ProtoMessage() {}
+
+func (x *ScriptFlushResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScriptFlushResponse.ProtoReflect.Descriptor instead.
+func (*ScriptFlushResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{121}
+}
+
+type InfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"` // 文本输出的 section，空表示默认，"all" 表示全部
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InfoRequest) Reset() {
+	*x = InfoRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InfoRequest) ProtoMessage() {}
+
+func (x *InfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InfoRequest.ProtoReflect.Descriptor instead.
+func (*InfoRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *InfoRequest) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+type ShardInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Keys          int64                  `protobuf:"varint,2,opt,name=keys,proto3" json:"keys,omitempty"`
+	Expires       int64                  `protobuf:"varint,3,opt,name=expires,proto3" json:"expires,omitempty"`
+	MemoryBytes   int64                  `protobuf:"varint,4,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShardInfo) Reset() {
+	*x = ShardInfo{}
+	mi := &file_api_proto_kv_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShardInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShardInfo) ProtoMessage() {}
+
+func (x *ShardInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShardInfo.ProtoReflect.Descriptor instead.
+func (*ShardInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *ShardInfo) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ShardInfo) GetKeys() int64 {
+	if x != nil {
+		return x.Keys
+	}
+	return 0
+}
+
+func (x *ShardInfo) GetExpires() int64 {
+	if x != nil {
+		return x.Expires
+	}
+	return 0
+}
+
+func (x *ShardInfo) GetMemoryBytes() int64 {
+	if x != nil {
+		return x.MemoryBytes
+	}
+	return 0
+}
+
+type CommandInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Calls         uint64                 `protobuf:"varint,2,opt,name=calls,proto3" json:"calls,omitempty"`
+	TotalUsec     uint64                 `protobuf:"varint,3,opt,name=total_usec,json=totalUsec,proto3" json:"total_usec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandInfo) Reset() {
+	*x = CommandInfo{}
+	mi := &file_api_proto_kv_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandInfo) ProtoMessage() {}
+
+func (x *CommandInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandInfo.ProtoReflect.Descriptor instead.
+func (*CommandInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *CommandInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CommandInfo) GetCalls() uint64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *CommandInfo) GetTotalUsec() uint64 {
+	if x != nil {
+		return x.TotalUsec
+	}
+	return 0
+}
+
+type InfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UptimeSeconds int64                  `protobuf:"varint,1,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	Keys          int64                  `protobuf:"varint,2,opt,name=keys,proto3" json:"keys,omitempty"`
+	Expires       int64                  `protobuf:"varint,3,opt,name=expires,proto3" json:"expires,omitempty"`
+	MemoryBytes   int64                  `protobuf:"varint,4,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"` // 估算值
+	Hits          uint64                 `protobuf:"varint,5,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses        uint64                 `protobuf:"varint,6,opt,name=misses,proto3" json:"misses,omitempty"`
+	ExpiredKeys   uint64                 `protobuf:"varint,7,opt,name=expired_keys,json=expiredKeys,proto3" json:"expired_keys,omitempty"`
+	Shards        []*ShardInfo           `protobuf:"bytes,8,rep,name=shards,proto3" json:"shards,omitempty"`
+	Commands      []*CommandInfo         `protobuf:"bytes,9,rep,name=commands,proto3" json:"commands,omitempty"`
+	Text          string                 `protobuf:"bytes,10,opt,name=text,proto3" json:"text,omitempty"` // 与 TCP INFO 命令一致的文本格式
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InfoResponse) Reset() {
+	*x = InfoResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InfoResponse) ProtoMessage() {}
+
+func (x *InfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InfoResponse.ProtoReflect.Descriptor instead.
+func (*InfoResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *InfoResponse) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *InfoResponse) GetKeys() int64 {
+	if x != nil {
+		return x.Keys
+	}
+	return 0
+}
+
+func (x *InfoResponse) GetExpires() int64 {
+	if x != nil {
+		return x.Expires
+	}
+	return 0
+}
+
+func (x *InfoResponse) GetMemoryBytes() int64 {
+	if x != nil {
+		return x.MemoryBytes
+	}
+	return 0
+}
+
+func (x *InfoResponse) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *InfoResponse) GetMisses() uint64 {
+	if x != nil {
+		return x.Misses
+	}
+	return 0
+}
+
+func (x *InfoResponse) GetExpiredKeys() uint64 {
+	if x != nil {
+		return x.ExpiredKeys
+	}
+	return 0
+}
+
+func (x *InfoResponse) GetShards() []*ShardInfo {
+	if x != nil {
+		return x.Shards
+	}
+	return nil
+}
+
+func (x *InfoResponse) GetCommands() []*CommandInfo {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *InfoResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type KeyReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"` // 返回的条数，0 表示全部
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyReportRequest) Reset() {
+	*x = KeyReportRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyReportRequest) ProtoMessage() {}
+
+func (x *KeyReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyReportRequest.ProtoReflect.Descriptor instead.
+func (*KeyReportRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *KeyReportRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type KeyStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Count         uint64                 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                          // 估算访问次数（HotKeys）
+	SizeBytes     int64                  `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"` // 估算字节数（BigKeys）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyStat) Reset() {
+	*x = KeyStat{}
+	mi := &file_api_proto_kv_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyStat) ProtoMessage() {}
+
+func (x *KeyStat) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyStat.ProtoReflect.Descriptor instead.
+func (*KeyStat) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *KeyStat) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KeyStat) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *KeyStat) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *KeyStat) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+type KeyReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*KeyStat             `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyReportResponse) Reset() {
+	*x = KeyReportResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyReportResponse) ProtoMessage() {}
+
+func (x *KeyReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyReportResponse.ProtoReflect.Descriptor instead.
+func (*KeyReportResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *KeyReportResponse) GetKeys() []*KeyStat {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type SlowLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"` // 返回最近的条数，0 表示全部
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SlowLogRequest) Reset() {
+	*x = SlowLogRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SlowLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SlowLogRequest) ProtoMessage() {}
+
+func (x *SlowLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SlowLogRequest.ProtoReflect.Descriptor instead.
+func (*SlowLogRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *SlowLogRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type SlowLogEntry struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TimestampUnixMs int64                  `protobuf:"varint,2,opt,name=timestamp_unix_ms,json=timestampUnixMs,proto3" json:"timestamp_unix_ms,omitempty"`
+	DurationUsec    int64                  `protobuf:"varint,3,opt,name=duration_usec,json=durationUsec,proto3" json:"duration_usec,omitempty"`
+	Command         string                 `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"`
+	Key             string                 `protobuf:"bytes,5,opt,name=key,proto3" json:"key,omitempty"`
+	ClientAddr      string                 `protobuf:"bytes,6,opt,name=client_addr,json=clientAddr,proto3" json:"client_addr,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SlowLogEntry) Reset() {
+	*x = SlowLogEntry{}
+	mi := &file_api_proto_kv_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SlowLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SlowLogEntry) ProtoMessage() {}
+
+func (x *SlowLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SlowLogEntry.ProtoReflect.Descriptor instead.
+func (*SlowLogEntry) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *SlowLogEntry) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SlowLogEntry) GetTimestampUnixMs() int64 {
+	if x != nil {
+		return x.TimestampUnixMs
+	}
+	return 0
+}
+
+func (x *SlowLogEntry) GetDurationUsec() int64 {
+	if x != nil {
+		return x.DurationUsec
+	}
+	return 0
+}
+
+func (x *SlowLogEntry) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *SlowLogEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SlowLogEntry) GetClientAddr() string {
+	if x != nil {
+		return x.ClientAddr
+	}
+	return ""
+}
+
+type SlowLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*SlowLogEntry        `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"` // 最新的在前
+	Len           int64                  `protobuf:"varint,2,opt,name=len,proto3" json:"len,omitempty"`        // 当前缓冲区中的总条数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SlowLogResponse) Reset() {
+	*x = SlowLogResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SlowLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SlowLogResponse) ProtoMessage() {}
+
+func (x *SlowLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SlowLogResponse.ProtoReflect.Descriptor instead.
+func (*SlowLogResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *SlowLogResponse) GetEntries() []*SlowLogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *SlowLogResponse) GetLen() int64 {
+	if x != nil {
+		return x.Len
+	}
+	return 0
+}
+
+type SlowLogResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SlowLogResetRequest) Reset() {
+	*x = SlowLogResetRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SlowLogResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SlowLogResetRequest) ProtoMessage() {}
+
+func (x *SlowLogResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SlowLogResetRequest.ProtoReflect.Descriptor instead.
+func (*SlowLogResetRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{132}
+}
+
+type SlowLogResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SlowLogResetResponse) Reset() {
+	*x = SlowLogResetResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[133]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SlowLogResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SlowLogResetResponse) ProtoMessage() {}
+
+func (x *SlowLogResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[133]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SlowLogResetResponse.ProtoReflect.Descriptor instead.
+func (*SlowLogResetResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *SlowLogResetResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type LatencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []string               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // 为空表示全部事件（aof-write / gc-cycle / lock-wait）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LatencyRequest) Reset() {
+	*x = LatencyRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[134]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyRequest) ProtoMessage() {}
+
+func (x *LatencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[134]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyRequest.ProtoReflect.Descriptor instead.
+func (*LatencyRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *LatencyRequest) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type LatencyBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpperUsec     uint64                 `protobuf:"varint,1,opt,name=upper_usec,json=upperUsec,proto3" json:"upper_usec,omitempty"` // 0 表示无上界
+	Count         uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LatencyBucket) Reset() {
+	*x = LatencyBucket{}
+	mi := &file_api_proto_kv_proto_msgTypes[135]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatencyBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyBucket) ProtoMessage() {}
+
+func (x *LatencyBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[135]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyBucket.ProtoReflect.Descriptor instead.
+func (*LatencyBucket) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *LatencyBucket) GetUpperUsec() uint64 {
+	if x != nil {
+		return x.UpperUsec
+	}
+	return 0
+}
+
+func (x *LatencyBucket) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type LatencyStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         string                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Count         uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	AvgUsec       float64                `protobuf:"fixed64,3,opt,name=avg_usec,json=avgUsec,proto3" json:"avg_usec,omitempty"`
+	MaxUsec       uint64                 `protobuf:"varint,4,opt,name=max_usec,json=maxUsec,proto3" json:"max_usec,omitempty"`
+	P50Usec       uint64                 `protobuf:"varint,5,opt,name=p50_usec,json=p50Usec,proto3" json:"p50_usec,omitempty"`
+	P99Usec       uint64                 `protobuf:"varint,6,opt,name=p99_usec,json=p99Usec,proto3" json:"p99_usec,omitempty"`
+	Buckets       []*LatencyBucket       `protobuf:"bytes,7,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LatencyStats) Reset() {
+	*x = LatencyStats{}
+	mi := &file_api_proto_kv_proto_msgTypes[136]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatencyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyStats) ProtoMessage() {}
+
+func (x *LatencyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[136]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyStats.ProtoReflect.Descriptor instead.
+func (*LatencyStats) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{136}
+}
+
+func (x *LatencyStats) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *LatencyStats) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *LatencyStats) GetAvgUsec() float64 {
+	if x != nil {
+		return x.AvgUsec
+	}
+	return 0
+}
+
+func (x *LatencyStats) GetMaxUsec() uint64 {
+	if x != nil {
+		return x.MaxUsec
+	}
+	return 0
+}
+
+func (x *LatencyStats) GetP50Usec() uint64 {
+	if x != nil {
+		return x.P50Usec
+	}
+	return 0
+}
+
+func (x *LatencyStats) GetP99Usec() uint64 {
+	if x != nil {
+		return x.P99Usec
+	}
+	return 0
+}
+
+func (x *LatencyStats) GetBuckets() []*LatencyBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type LatencyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*LatencyStats        `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LatencyResponse) Reset() {
+	*x = LatencyResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[137]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyResponse) ProtoMessage() {}
+
+func (x *LatencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[137]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyResponse.ProtoReflect.Descriptor instead.
+func (*LatencyResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{137}
+}
+
+func (x *LatencyResponse) GetEvents() []*LatencyStats {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type MonitorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pattern       string                 `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"` // Key 的 glob 过滤条件，为空表示全部
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MonitorRequest) Reset() {
+	*x = MonitorRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[138]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonitorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonitorRequest) ProtoMessage() {}
+
+func (x *MonitorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[138]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonitorRequest.ProtoReflect.Descriptor instead.
+func (*MonitorRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{138}
+}
+
+func (x *MonitorRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+type MonitorEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TimestampUnixUs int64                  `protobuf:"varint,1,opt,name=timestamp_unix_us,json=timestampUnixUs,proto3" json:"timestamp_unix_us,omitempty"`
+	Client          string                 `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`
+	Command         string                 `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Key             string                 `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	Value           string                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"` // 过长的值会被截断
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MonitorEvent) Reset() {
+	*x = MonitorEvent{}
+	mi := &file_api_proto_kv_proto_msgTypes[139]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonitorEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonitorEvent) ProtoMessage() {}
+
+func (x *MonitorEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[139]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonitorEvent.ProtoReflect.Descriptor instead.
+func (*MonitorEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{139}
+}
+
+func (x *MonitorEvent) GetTimestampUnixUs() int64 {
+	if x != nil {
+		return x.TimestampUnixUs
+	}
+	return 0
+}
+
+func (x *MonitorEvent) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *MonitorEvent) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *MonitorEvent) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MonitorEvent) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// AclUser 设置时 passwords / tokens 为明文或 "sha256:<hex>"；查询时不返回，只返回个数
+type AclUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Passwords     []string               `protobuf:"bytes,2,rep,name=passwords,proto3" json:"passwords,omitempty"`
+	Tokens        []string               `protobuf:"bytes,3,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	Nopass        bool                   `protobuf:"varint,4,opt,name=nopass,proto3" json:"nopass,omitempty"`
+	Categories    []string               `protobuf:"bytes,5,rep,name=categories,proto3" json:"categories,omitempty"` // read / write / admin / all
+	Keys          []string               `protobuf:"bytes,6,rep,name=keys,proto3" json:"keys,omitempty"`             // Key 的 glob 模式
+	PasswordCount int32                  `protobuf:"varint,7,opt,name=password_count,json=passwordCount,proto3" json:"password_count,omitempty"`
+	TokenCount    int32                  `protobuf:"varint,8,opt,name=token_count,json=tokenCount,proto3" json:"token_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AclUser) Reset() {
+	*x = AclUser{}
+	mi := &file_api_proto_kv_proto_msgTypes[140]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AclUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AclUser) ProtoMessage() {}
+
+func (x *AclUser) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[140]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AclUser.ProtoReflect.Descriptor instead.
+func (*AclUser) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{140}
+}
+
+func (x *AclUser) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AclUser) GetPasswords() []string {
+	if x != nil {
+		return x.Passwords
+	}
+	return nil
+}
+
+func (x *AclUser) GetTokens() []string {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *AclUser) GetNopass() bool {
+	if x != nil {
+		return x.Nopass
+	}
+	return false
+}
+
+func (x *AclUser) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *AclUser) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *AclUser) GetPasswordCount() int32 {
+	if x != nil {
+		return x.PasswordCount
+	}
+	return 0
+}
+
+func (x *AclUser) GetTokenCount() int32 {
+	if x != nil {
+		return x.TokenCount
+	}
+	return 0
+}
+
+type AclSetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *AclUser               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"` // 整体替换同名用户
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AclSetUserRequest) Reset() {
+	*x = AclSetUserRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[141]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AclSetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AclSetUserRequest) ProtoMessage() {}
+
+func (x *AclSetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[141]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AclSetUserRequest.ProtoReflect.Descriptor instead.
+func (*AclSetUserRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{141}
+}
+
+func (x *AclSetUserRequest) GetUser() *AclUser {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type AclSetUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AclSetUserResponse) Reset() {
+	*x = AclSetUserResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[142]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AclSetUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AclSetUserResponse) ProtoMessage() {}
+
+func (x *AclSetUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[142]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AclSetUserResponse.ProtoReflect.Descriptor instead.
+func (*AclSetUserResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{142}
+}
+
+func (x *AclSetUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type AclDelUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AclDelUserRequest) Reset() {
+	*x = AclDelUserRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[143]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AclDelUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AclDelUserRequest) ProtoMessage() {}
+
+func (x *AclDelUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[143]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AclDelUserRequest.ProtoReflect.Descriptor instead.
+func (*AclDelUserRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{143}
+}
+
+func (x *AclDelUserRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type AclDelUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AclDelUserResponse) Reset() {
+	*x = AclDelUserResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[144]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AclDelUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AclDelUserResponse) ProtoMessage() {}
+
+func (x *AclDelUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[144]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AclDelUserResponse.ProtoReflect.Descriptor instead.
+func (*AclDelUserResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{144}
+}
+
+func (x *AclDelUserResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+type AclListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AclListRequest) Reset() {
+	*x = AclListRequest{}
+	mi := &file_api_proto_kv_proto_msgTypes[145]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AclListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AclListRequest) ProtoMessage() {}
+
+func (x *AclListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[145]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AclListRequest.ProtoReflect.Descriptor instead.
+func (*AclListRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{145}
+}
+
+type AclListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*AclUser             `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AclListResponse) Reset() {
+	*x = AclListResponse{}
+	mi := &file_api_proto_kv_proto_msgTypes[146]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AclListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AclListResponse) ProtoMessage() {}
+
+func (x *AclListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kv_proto_msgTypes[146]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AclListResponse.ProtoReflect.Descriptor instead.
+func (*AclListResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_kv_proto_rawDescGZIP(), []int{146}
+}
+
+func (x *AclListResponse) GetUsers() []*AclUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+var File_api_proto_kv_proto protoreflect.FileDescriptor
+
+const file_api_proto_kv_proto_rawDesc = "" +
+	"\n" +
+	"\x12api/proto/kv.proto\x12\aservice\"J\n" +
+	"\n" +
+	"SetRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x14\n" +
+	"\x05lease\x18\x03 \x01(\x03R\x05lease\"'\n" +
+	"\vSetResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x1e\n" +
+	"\n" +
+	"GetRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"9\n" +
+	"\vGetResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\"\x1e\n" +
+	"\n" +
+	"DelRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"'\n" +
+	"\vDelResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"_\n" +
+	"\fWatchRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\bR\x06prefix\x12%\n" +
+	"\x0estart_revision\x18\x03 \x01(\x04R\rstartRevision\"\xa9\x01\n" +
+	"\n" +
+	"WatchEvent\x12+\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x17.service.WatchEventTypeR\x04type\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x1a\n" +
+	"\brevision\x18\x04 \x01(\x04R\brevision\x12*\n" +
+	"\x11timestamp_unix_ms\x18\x05 \x01(\x03R\x0ftimestampUnixMs\"D\n" +
+	"\x0ePublishRequest\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"/\n" +
+	"\x0fPublishResponse\x12\x1c\n" +
+	"\treceivers\x18\x01 \x01(\x03R\treceivers\"\xdf\x01\n" +
+	"\rPubSubRequest\x125\n" +
+	"\x06action\x18\x01 \x01(\x0e2\x1d.service.PubSubRequest.ActionR\x06action\x12\x1a\n" +
+	"\bchannels\x18\x02 \x03(\tR\bchannels\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"a\n" +
+	"\x06Action\x12\r\n" +
+	"\tSUBSCRIBE\x10\x00\x12\x0f\n" +
+	"\vUNSUBSCRIBE\x10\x01\x12\x0e\n" +
+	"\n" +
+	"PSUBSCRIBE\x10\x02\x12\x10\n" +
+	"\fPUNSUBSCRIBE\x10\x03\x12\v\n" +
+	"\aPUBLISH\x10\x04\x12\b\n" +
+	"\x04PING\x10\x05\"\x87\x01\n" +
+	"\rPubSubMessage\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x18\n" +
+	"\achannel\x18\x02 \x01(\tR\achannel\x12\x18\n" +
+	"\apattern\x18\x03 \x01(\tR\apattern\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\tR\apayload\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x03R\x05count\"7\n" +
+	"\vStreamField\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"K\n" +
+	"\vStreamEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12,\n" +
+	"\x06fields\x18\x02 \x03(\v2\x14.service.StreamFieldR\x06fields\"v\n" +
+	"\vXAddRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12,\n" +
+	"\x06fields\x18\x03 \x03(\v2\x14.service.StreamFieldR\x06fields\x12\x17\n" +
+	"\amax_len\x18\x04 \x01(\x03R\x06maxLen\"\x1e\n" +
+	"\fXAddResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"y\n" +
+	"\rXRangeRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\tR\x03end\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x03R\x05count\x12\x18\n" +
+	"\areverse\x18\x05 \x01(\bR\areverse\"@\n" +
+	"\x0eXRangeResponse\x12.\n" +
+	"\aentries\x18\x01 \x03(\v2\x14.service.StreamEntryR\aentries\"P\n" +
+	"\fXTrimRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x17\n" +
+	"\amax_len\x18\x02 \x01(\x03R\x06maxLen\x12\x15\n" +
+	"\x06min_id\x18\x03 \x01(\tR\x05minId\")\n" +
+	"\rXTrimResponse\x12\x18\n" +
+	"\aremoved\x18\x01 \x01(\x03R\aremoved\"J\n" +
+	"\fXReadRequest\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\tR\x03ids\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x03R\x05count\"M\n" +
+	"\rXReadResponse\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05entry\x18\x02 \x01(\v2\x14.service.StreamEntryR\x05entry\"c\n" +
+	"\rXGroupRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12\x1a\n" +
+	"\bmkstream\x18\x04 \x01(\bR\bmkstream\"*\n" +
+	"\x0eXGroupResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x86\x01\n" +
+	"\x11XReadGroupRequest\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12\x1a\n" +
+	"\bconsumer\x18\x02 \x01(\tR\bconsumer\x12\x12\n" +
+	"\x04keys\x18\x03 \x03(\tR\x04keys\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x03R\x05count\x12\x15\n" +
+	"\x06no_ack\x18\x05 \x01(\bR\x05noAck\"G\n" +
+	"\vXAckRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x10\n" +
+	"\x03ids\x18\x03 \x03(\tR\x03ids\"$\n" +
+	"\fXAckResponse\x12\x14\n" +
+	"\x05acked\x18\x01 \x01(\x03R\x05acked\"\xb3\x01\n" +
+	"\x0fXPendingRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x14\n" +
+	"\x05start\x18\x03 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x04 \x01(\tR\x03end\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x03R\x05count\x12\x1a\n" +
+	"\bconsumer\x18\x06 \x01(\tR\bconsumer\x12\x1e\n" +
+	"\vmin_idle_ms\x18\a \x01(\x03R\tminIdleMs\"s\n" +
+	"\fPendingEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bconsumer\x18\x02 \x01(\tR\bconsumer\x12\x17\n" +
+	"\aidle_ms\x18\x03 \x01(\x03R\x06idleMs\x12\x1e\n" +
+	"\n" +
+	"deliveries\x18\x04 \x01(\x03R\n" +
+	"deliveries\"\x8d\x02\n" +
+	"\x10XPendingResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\x12\x15\n" +
+	"\x06min_id\x18\x02 \x01(\tR\x05minId\x12\x15\n" +
+	"\x06max_id\x18\x03 \x01(\tR\x05maxId\x12F\n" +
+	"\tconsumers\x18\x04 \x03(\v2(.service.XPendingResponse.ConsumersEntryR\tconsumers\x12/\n" +
+	"\aentries\x18\x05 \x03(\v2\x15.service.PendingEntryR\aentries\x1a<\n" +
+	"\x0eConsumersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x85\x01\n" +
+	"\rXClaimRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x1a\n" +
+	"\bconsumer\x18\x03 \x01(\tR\bconsumer\x12\x1e\n" +
+	"\vmin_idle_ms\x18\x04 \x01(\x03R\tminIdleMs\x12\x10\n" +
+	"\x03ids\x18\x05 \x03(\tR\x03ids\"@\n" +
+	"\x0eXClaimResponse\x12.\n" +
+	"\aentries\x18\x01 \x03(\v2\x14.service.StreamEntryR\aentries\"<\n" +
+	"\fPFAddRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1a\n" +
+	"\belements\x18\x02 \x03(\tR\belements\")\n" +
+	"\rPFAddResponse\x12\x18\n" +
+	"\achanged\x18\x01 \x01(\bR\achanged\"$\n" +
+	"\x0ePFCountRequest\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys\"'\n" +
+	"\x0fPFCountResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count\">\n" +
+	"\x0ePFMergeRequest\x12\x12\n" +
+	"\x04dest\x18\x01 \x01(\tR\x04dest\x12\x18\n" +
+	"\asources\x18\x02 \x03(\tR\asources\"+\n" +
+	"\x0fPFMergeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"_\n" +
+	"\x10BFReserveRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1d\n" +
+	"\n" +
+	"error_rate\x18\x02 \x01(\x01R\terrorRate\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x04R\bcapacity\"-\n" +
+	"\x11BFReserveResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"8\n" +
+	"\x0eBFItemsRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05items\x18\x02 \x03(\tR\x05items\"+\n" +
+	"\x0fBFItemsResponse\x12\x18\n" +
+	"\aresults\x18\x01 \x03(\bR\aresults\"\x8f\x01\n" +
+	"\x0eCMSInitRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\rR\x05width\x12\x14\n" +
+	"\x05depth\x18\x03 \x01(\rR\x05depth\x12\x1d\n" +
+	"\n" +
+	"error_rate\x18\x04 \x01(\x01R\terrorRate\x12 \n" +
+	"\vprobability\x18\x05 \x01(\x01R\vprobability\"=\n" +
+	"\x0fCMSInitResponse\x12\x14\n" +
+	"\x05width\x18\x01 \x01(\rR\x05width\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\rR\x05depth\"@\n" +
+	"\fCMSIncrement\x12\x12\n" +
+	"\x04item\x18\x01 \x01(\tR\x04item\x12\x1c\n" +
+	"\tincrement\x18\x02 \x01(\rR\tincrement\"[\n" +
+	"\x10CMSIncrByRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x125\n" +
+	"\n" +
+	"increments\x18\x02 \x03(\v2\x15.service.CMSIncrementR\n" +
+	"increments\"9\n" +
+	"\x0fCMSQueryRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05items\x18\x02 \x03(\tR\x05items\"+\n" +
+	"\x11CMSCountsResponse\x12\x16\n" +
+	"\x06counts\x18\x01 \x03(\rR\x06counts\"_\n" +
+	"\vGeoLocation\x12\x16\n" +
+	"\x06member\x18\x01 \x01(\tR\x06member\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\"U\n" +
+	"\rGeoAddRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x122\n" +
+	"\tlocations\x18\x02 \x03(\v2\x14.service.GeoLocationR\tlocations\"&\n" +
+	"\x0eGeoAddResponse\x12\x14\n" +
+	"\x05added\x18\x01 \x01(\x03R\x05added\";\n" +
+	"\rGeoPosRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x18\n" +
+	"\amembers\x18\x02 \x03(\tR\amembers\"_\n" +
+	"\vGeoPosition\x12\x16\n" +
+	"\x06exists\x18\x01 \x01(\bR\x06exists\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\"D\n" +
+	"\x0eGeoPosResponse\x122\n" +
+	"\tpositions\x18\x01 \x03(\v2\x14.service.GeoPositionR\tpositions\"j\n" +
+	"\x0eGeoDistRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x18\n" +
+	"\amember1\x18\x02 \x01(\tR\amember1\x12\x18\n" +
+	"\amember2\x18\x03 \x01(\tR\amember2\x12\x12\n" +
+	"\x04unit\x18\x04 \x01(\tR\x04unit\"E\n" +
+	"\x0fGeoDistResponse\x12\x16\n" +
+	"\x06exists\x18\x01 \x01(\bR\x06exists\x12\x1a\n" +
+	"\bdistance\x18\x02 \x01(\x01R\bdistance\"\x95\x02\n" +
+	"\x10GeoSearchRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1f\n" +
+	"\vfrom_member\x18\x02 \x01(\tR\n" +
+	"fromMember\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\blatitude\x18\x04 \x01(\x01R\blatitude\x12\x16\n" +
+	"\x06radius\x18\x05 \x01(\x01R\x06radius\x12\x14\n" +
+	"\x05width\x18\x06 \x01(\x01R\x05width\x12\x16\n" +
+	"\x06height\x18\a \x01(\x01R\x06height\x12\x12\n" +
+	"\x04unit\x18\b \x01(\tR\x04unit\x12\x12\n" +
+	"\x04sort\x18\t \x01(\tR\x04sort\x12\x14\n" +
+	"\x05count\x18\n" +
+	" \x01(\x03R\x05count\x12\x10\n" +
+	"\x03any\x18\v \x01(\bR\x03any\"\x93\x01\n" +
+	"\x0fGeoSearchResult\x12\x16\n" +
+	"\x06member\x18\x01 \x01(\tR\x06member\x12\x1a\n" +
+	"\bdistance\x18\x02 \x01(\x01R\bdistance\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\blatitude\x18\x04 \x01(\x01R\blatitude\x12\x12\n" +
+	"\x04hash\x18\x05 \x01(\x04R\x04hash\"G\n" +
+	"\x11GeoSearchResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.service.GeoSearchResultR\aresults\">\n" +
+	"\bTSSample\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\"\xbf\x01\n" +
+	"\x0fTSCreateRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12!\n" +
+	"\fretention_ms\x18\x02 \x01(\x03R\vretentionMs\x12<\n" +
+	"\x06labels\x18\x03 \x03(\v2$.service.TSCreateRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\",\n" +
+	"\x10TSCreateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xed\x01\n" +
+	"\fTSAddRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x01R\x05value\x12!\n" +
+	"\fretention_ms\x18\x04 \x01(\x03R\vretentionMs\x129\n" +
+	"\x06labels\x18\x05 \x03(\v2!.service.TSAddRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"-\n" +
+	"\rTSAddResponse\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\" \n" +
+	"\fTSGetRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"R\n" +
+	"\rTSGetResponse\x12\x16\n" +
+	"\x06exists\x18\x01 \x01(\bR\x06exists\x12)\n" +
+	"\x06sample\x18\x02 \x01(\v2\x11.service.TSSampleR\x06sample\"@\n" +
+	"\rTSAggregation\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1b\n" +
+	"\tbucket_ms\x18\x02 \x01(\x03R\bbucketMs\"\x96\x01\n" +
+	"\x0eTSRangeRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\x128\n" +
+	"\vaggregation\x18\x04 \x01(\v2\x16.service.TSAggregationR\vaggregation\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x03R\x05count\">\n" +
+	"\x0fTSRangeResponse\x12+\n" +
+	"\asamples\x18\x01 \x03(\v2\x11.service.TSSampleR\asamples\"\x9f\x01\n" +
+	"\x0fTSMRangeRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x03R\x02to\x12\x18\n" +
+	"\afilters\x18\x03 \x03(\tR\afilters\x128\n" +
+	"\vaggregation\x18\x04 \x01(\v2\x16.service.TSAggregationR\vaggregation\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x03R\x05count\"\xbb\x01\n" +
+	"\bTSSeries\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x125\n" +
+	"\x06labels\x18\x02 \x03(\v2\x1d.service.TSSeries.LabelsEntryR\x06labels\x12+\n" +
+	"\asamples\x18\x03 \x03(\v2\x11.service.TSSampleR\asamples\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"=\n" +
+	"\x10TSMRangeResponse\x12)\n" +
+	"\x06series\x18\x01 \x03(\v2\x11.service.TSSeriesR\x06series\"u\n" +
+	"\rTSRuleRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x12\n" +
+	"\x04dest\x18\x02 \x01(\tR\x04dest\x128\n" +
+	"\vaggregation\x18\x03 \x01(\v2\x16.service.TSAggregationR\vaggregation\"*\n" +
+	"\x0eTSRuleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"!\n" +
+	"\rTSInfoRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"V\n" +
+	"\x06TSRule\x12\x12\n" +
+	"\x04dest\x18\x01 \x01(\tR\x04dest\x128\n" +
+	"\vaggregation\x18\x02 \x01(\v2\x16.service.TSAggregationR\vaggregation\"\x8b\x03\n" +
+	"\x0eTSInfoResponse\x12#\n" +
+	"\rtotal_samples\x18\x01 \x01(\x03R\ftotalSamples\x12!\n" +
+	"\fmemory_usage\x18\x02 \x01(\x03R\vmemoryUsage\x12\x1f\n" +
+	"\vchunk_count\x18\x03 \x01(\x03R\n" +
+	"chunkCount\x12'\n" +
+	"\x0ffirst_timestamp\x18\x04 \x01(\x03R\x0efirstTimestamp\x12%\n" +
+	"\x0elast_timestamp\x18\x05 \x01(\x03R\rlastTimestamp\x12!\n" +
+	"\fretention_ms\x18\x06 \x01(\x03R\vretentionMs\x12;\n" +
+	"\x06labels\x18\a \x03(\v2#.service.TSInfoResponse.LabelsEntryR\x06labels\x12%\n" +
+	"\x05rules\x18\b \x03(\v2\x0f.service.TSRuleR\x05rules\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"l\n" +
+	"\x0eJSONSetRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x0e\n" +
+	"\x02nx\x18\x04 \x01(\bR\x02nx\x12\x0e\n" +
+	"\x02xx\x18\x05 \x01(\bR\x02xx\"+\n" +
+	"\x0fJSONSetResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"8\n" +
+	"\x0eJSONGetRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05paths\x18\x02 \x03(\tR\x05paths\"=\n" +
+	"\x0fJSONGetResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"6\n" +
+	"\x0eJSONDelRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\"+\n" +
+	"\x0fJSONDelResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\"T\n" +
+	"\x14JSONArrAppendRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x16\n" +
+	"\x06values\x18\x03 \x03(\tR\x06values\"/\n" +
+	"\x15JSONArrAppendResponse\x12\x16\n" +
+	"\x06length\x18\x01 \x01(\x03R\x06length\"R\n" +
+	"\x14JSONNumIncrByRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\tR\x05delta\"-\n" +
+	"\x15JSONNumIncrByResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\"Z\n" +
+	"\x10JSONIndexRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\"-\n" +
+	"\x11JSONIndexResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"6\n" +
+	"\x16JSONListIndexesRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"q\n" +
+	"\rJSONIndexInfo\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x18\n" +
+	"\aentries\x18\x04 \x01(\x03R\aentries\"K\n" +
+	"\x17JSONListIndexesResponse\x120\n" +
+	"\aindexes\x18\x01 \x03(\v2\x16.service.JSONIndexInfoR\aindexes\"\x8f\x01\n" +
+	"\vFindRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x0e\n" +
+	"\x02op\x18\x03 \x01(\tR\x02op\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x12\x10\n" +
+	"\x03max\x18\x05 \x01(\tR\x03max\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"/\n" +
+	"\tJSONMatch\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x10\n" +
+	"\x03doc\x18\x02 \x01(\tR\x03doc\"<\n" +
+	"\fFindResponse\x12,\n" +
+	"\amatches\x18\x01 \x03(\v2\x12.service.JSONMatchR\amatches\"n\n" +
+	"\x12LockAcquireRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x15\n" +
+	"\x06ttl_ms\x18\x03 \x01(\x03R\x05ttlMs\x12\x17\n" +
+	"\await_ms\x18\x04 \x01(\x03R\x06waitMs\"v\n" +
+	"\x13LockAcquireResponse\x12\x1a\n" +
+	"\bacquired\x18\x01 \x01(\bR\bacquired\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\x04R\x05token\x12\x16\n" +
+	"\x06holder\x18\x03 \x01(\tR\x06holder\x12\x15\n" +
+	"\x06ttl_ms\x18\x04 \x01(\x03R\x05ttlMs\"S\n" +
+	"\x10LockRenewRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x15\n" +
+	"\x06ttl_ms\x18\x03 \x01(\x03R\x05ttlMs\"-\n" +
+	"\x11LockRenewResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\">\n" +
+	"\x12LockReleaseRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\"/\n" +
+	"\x13LockReleaseResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\":\n" +
+	"\x11LeaseGrantRequest\x12\x15\n" +
+	"\x06ttl_ms\x18\x01 \x01(\x03R\x05ttlMs\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\";\n" +
+	"\x12LeaseGrantResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06ttl_ms\x18\x02 \x01(\x03R\x05ttlMs\"'\n" +
+	"\x15LeaseKeepAliveRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"?\n" +
+	"\x16LeaseKeepAliveResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06ttl_ms\x18\x02 \x01(\x03R\x05ttlMs\"$\n" +
+	"\x12LeaseRevokeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"/\n" +
+	"\x13LeaseRevokeResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\"<\n" +
+	"\x16LeaseTimeToLiveRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04keys\x18\x02 \x01(\bR\x04keys\"z\n" +
+	"\x17LeaseTimeToLiveResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06ttl_ms\x18\x02 \x01(\x03R\x05ttlMs\x12$\n" +
+	"\x0egranted_ttl_ms\x18\x03 \x01(\x03R\fgrantedTtlMs\x12\x12\n" +
+	"\x04keys\x18\x04 \x03(\tR\x04keys\"a\n" +
+	"\x0fThrottleRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04rate\x18\x02 \x01(\x01R\x04rate\x12\x14\n" +
+	"\x05burst\x18\x03 \x01(\x03R\x05burst\x12\x12\n" +
+	"\x04cost\x18\x04 \x01(\x03R\x04cost\"\xac\x01\n" +
+	"\x10ThrottleResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x1c\n" +
+	"\tremaining\x18\x03 \x01(\x03R\tremaining\x12$\n" +
+	"\x0eretry_after_ms\x18\x04 \x01(\x03R\fretryAfterMs\x12$\n" +
+	"\x0ereset_after_ms\x18\x05 \x01(\x03R\fresetAfterMs\"\x97\x01\n" +
+	"\vScriptValue\x12\x12\n" +
+	"\x03int\x18\x01 \x01(\x03H\x00R\x03int\x12\x16\n" +
+	"\x05float\x18\x02 \x01(\x01H\x00R\x05float\x12\x12\n" +
+	"\x03str\x18\x03 \x01(\tH\x00R\x03str\x12\x14\n" +
+	"\x04bool\x18\x04 \x01(\bH\x00R\x04bool\x12)\n" +
+	"\x04list\x18\x05 \x01(\v2\x13.service.ScriptListH\x00R\x04listB\a\n" +
+	"\x05value\"8\n" +
+	"\n" +
+	"ScriptList\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.service.ScriptValueR\x05items\"M\n" +
+	"\vEvalRequest\x12\x16\n" +
+	"\x06script\x18\x01 \x01(\tR\x06script\x12\x12\n" +
+	"\x04keys\x18\x02 \x03(\tR\x04keys\x12\x12\n" +
+	"\x04args\x18\x03 \x03(\tR\x04args\"J\n" +
+	"\x0eEvalShaRequest\x12\x10\n" +
+	"\x03sha\x18\x01 \x01(\tR\x03sha\x12\x12\n" +
+	"\x04keys\x18\x02 \x03(\tR\x04keys\x12\x12\n" +
+	"\x04args\x18\x03 \x03(\tR\x04args\"<\n" +
+	"\fEvalResponse\x12,\n" +
+	"\x06result\x18\x01 \x01(\v2\x14.service.ScriptValueR\x06result\"+\n" +
+	"\x11ScriptLoadRequest\x12\x16\n" +
+	"\x06script\x18\x01 \x01(\tR\x06script\"&\n" +
+	"\x12ScriptLoadResponse\x12\x10\n" +
+	"\x03sha\x18\x01 \x01(\tR\x03sha\")\n" +
+	"\x13ScriptExistsRequest\x12\x12\n" +
+	"\x04shas\x18\x01 \x03(\tR\x04shas\".\n" +
+	"\x14ScriptExistsResponse\x12\x16\n" +
+	"\x06exists\x18\x01 \x03(\bR\x06exists\"\x14\n" +
+	"\x12ScriptFlushRequest\"\x15\n" +
+	"\x13ScriptFlushResponse\"'\n" +
+	"\vInfoRequest\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\"l\n" +
+	"\tShardInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04keys\x18\x02 \x01(\x03R\x04keys\x12\x18\n" +
+	"\aexpires\x18\x03 \x01(\x03R\aexpires\x12!\n" +
+	"\fmemory_bytes\x18\x04 \x01(\x03R\vmemoryBytes\"V\n" +
+	"\vCommandInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05calls\x18\x02 \x01(\x04R\x05calls\x12\x1d\n" +
+	"\n" +
+	"total_usec\x18\x03 \x01(\x04R\ttotalUsec\"\xc7\x02\n" +
+	"\fInfoResponse\x12%\n" +
+	"\x0euptime_seconds\x18\x01 \x01(\x03R\ruptimeSeconds\x12\x12\n" +
+	"\x04keys\x18\x02 \x01(\x03R\x04keys\x12\x18\n" +
+	"\aexpires\x18\x03 \x01(\x03R\aexpires\x12!\n" +
+	"\fmemory_bytes\x18\x04 \x01(\x03R\vmemoryBytes\x12\x12\n" +
+	"\x04hits\x18\x05 \x01(\x04R\x04hits\x12\x16\n" +
+	"\x06misses\x18\x06 \x01(\x04R\x06misses\x12!\n" +
+	"\fexpired_keys\x18\a \x01(\x04R\vexpiredKeys\x12*\n" +
+	"\x06shards\x18\b \x03(\v2\x12.service.ShardInfoR\x06shards\x120\n" +
+	"\bcommands\x18\t \x03(\v2\x14.service.CommandInfoR\bcommands\x12\x12\n" +
+	"\x04text\x18\n" +
+	" \x01(\tR\x04text\"(\n" +
+	"\x10KeyReportRequest\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\"d\n" +
+	"\aKeyStat\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x04R\x05count\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x04 \x01(\x03R\tsizeBytes\"9\n" +
+	"\x11KeyReportResponse\x12$\n" +
+	"\x04keys\x18\x01 \x03(\v2\x10.service.KeyStatR\x04keys\"&\n" +
+	"\x0eSlowLogRequest\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\"\xbc\x01\n" +
+	"\fSlowLogEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12*\n" +
+	"\x11timestamp_unix_ms\x18\x02 \x01(\x03R\x0ftimestampUnixMs\x12#\n" +
+	"\rduration_usec\x18\x03 \x01(\x03R\fdurationUsec\x12\x18\n" +
+	"\acommand\x18\x04 \x01(\tR\acommand\x12\x10\n" +
+	"\x03key\x18\x05 \x01(\tR\x03key\x12\x1f\n" +
+	"\vclient_addr\x18\x06 \x01(\tR\n" +
+	"clientAddr\"T\n" +
+	"\x0fSlowLogResponse\x12/\n" +
+	"\aentries\x18\x01 \x03(\v2\x15.service.SlowLogEntryR\aentries\x12\x10\n" +
+	"\x03len\x18\x02 \x01(\x03R\x03len\"\x15\n" +
+	"\x13SlowLogResetRequest\"0\n" +
+	"\x14SlowLogResetResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"(\n" +
+	"\x0eLatencyRequest\x12\x16\n" +
+	"\x06events\x18\x01 \x03(\tR\x06events\"D\n" +
+	"\rLatencyBucket\x12\x1d\n" +
+	"\n" +
+	"upper_usec\x18\x01 \x01(\x04R\tupperUsec\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\"\xd8\x01\n" +
+	"\fLatencyStats\x12\x14\n" +
+	"\x05event\x18\x01 \x01(\tR\x05event\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\x12\x19\n" +
+	"\bavg_usec\x18\x03 \x01(\x01R\aavgUsec\x12\x19\n" +
+	"\bmax_usec\x18\x04 \x01(\x04R\amaxUsec\x12\x19\n" +
+	"\bp50_usec\x18\x05 \x01(\x04R\ap50Usec\x12\x19\n" +
+	"\bp99_usec\x18\x06 \x01(\x04R\ap99Usec\x120\n" +
+	"\abuckets\x18\a \x03(\v2\x16.service.LatencyBucketR\abuckets\"@\n" +
+	"\x0fLatencyResponse\x12-\n" +
+	"\x06events\x18\x01 \x03(\v2\x15.service.LatencyStatsR\x06events\"*\n" +
+	"\x0eMonitorRequest\x12\x18\n" +
+	"\apattern\x18\x01 \x01(\tR\apattern\"\x94\x01\n" +
+	"\fMonitorEvent\x12*\n" +
+	"\x11timestamp_unix_us\x18\x01 \x01(\x03R\x0ftimestampUnixUs\x12\x16\n" +
+	"\x06client\x18\x02 \x01(\tR\x06client\x12\x18\n" +
+	"\acommand\x18\x03 \x01(\tR\acommand\x12\x10\n" +
+	"\x03key\x18\x04 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\"\xe7\x01\n" +
+	"\aAclUser\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tpasswords\x18\x02 \x03(\tR\tpasswords\x12\x16\n" +
+	"\x06tokens\x18\x03 \x03(\tR\x06tokens\x12\x16\n" +
+	"\x06nopass\x18\x04 \x01(\bR\x06nopass\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x05 \x03(\tR\n" +
+	"categories\x12\x12\n" +
+	"\x04keys\x18\x06 \x03(\tR\x04keys\x12%\n" +
+	"\x0epassword_count\x18\a \x01(\x05R\rpasswordCount\x12\x1f\n" +
+	"\vtoken_count\x18\b \x01(\x05R\n" +
+	"tokenCount\"9\n" +
+	"\x11AclSetUserRequest\x12$\n" +
+	"\x04user\x18\x01 \x01(\v2\x10.service.AclUserR\x04user\".\n" +
+	"\x12AclSetUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"'\n" +
+	"\x11AclDelUserRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\".\n" +
+	"\x12AclDelUserResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"\x10\n" +
+	"\x0eAclListRequest\"9\n" +
+	"\x0fAclListResponse\x12&\n" +
+	"\x05users\x18\x01 \x03(\v2\x10.service.AclUserR\x05users*<\n" +
+	"\x0eWatchEventType\x12\a\n" +
+	"\x03PUT\x10\x00\x12\n" +
+	"\n" +
+	"\x06DELETE\x10\x01\x12\n" +
+	"\n" +
+	"\x06EXPIRE\x10\x02\x12\t\n" +
+	"\x05EVICT\x10\x032\xf4\"\n" +
+	"\tKVService\x120\n" +
+	"\x03Set\x12\x13.service.SetRequest\x1a\x14.service.SetResponse\x120\n" +
+	"\x03Get\x12\x13.service.GetRequest\x1a\x14.service.GetResponse\x120\n" +
+	"\x03Del\x12\x13.service.DelRequest\x1a\x14.service.DelResponse\x125\n" +
+	"\x05Watch\x12\x15.service.WatchRequest\x1a\x13.service.WatchEvent0\x01\x12<\n" +
+	"\aPublish\x12\x17.service.PublishRequest\x1a\x18.service.PublishResponse\x12<\n" +
+	"\x06PubSub\x12\x16.service.PubSubRequest\x1a\x16.service.PubSubMessage(\x010\x01\x123\n" +
+	"\x04XAdd\x12\x14.service.XAddRequest\x1a\x15.service.XAddResponse\x129\n" +
+	"\x06XRange\x12\x16.service.XRangeRequest\x1a\x17.service.XRangeResponse\x126\n" +
+	"\x05XTrim\x12\x15.service.XTrimRequest\x1a\x16.service.XTrimResponse\x128\n" +
+	"\x05XRead\x12\x15.service.XReadRequest\x1a\x16.service.XReadResponse0\x01\x12?\n" +
+	"\fXGroupCreate\x12\x16.service.XGroupRequest\x1a\x17.service.XGroupResponse\x12@\n" +
+	"\rXGroupDestroy\x12\x16.service.XGroupRequest\x1a\x17.service.XGroupResponse\x12B\n" +
+	"\n" +
+	"XReadGroup\x12\x1a.service.XReadGroupRequest\x1a\x16.service.XReadResponse0\x01\x123\n" +
+	"\x04XAck\x12\x14.service.XAckRequest\x1a\x15.service.XAckResponse\x12?\n" +
+	"\bXPending\x12\x18.service.XPendingRequest\x1a\x19.service.XPendingResponse\x129\n" +
+	"\x06XClaim\x12\x16.service.XClaimRequest\x1a\x17.service.XClaimResponse\x126\n" +
+	"\x05PFAdd\x12\x15.service.PFAddRequest\x1a\x16.service.PFAddResponse\x12<\n" +
+	"\aPFCount\x12\x17.service.PFCountRequest\x1a\x18.service.PFCountResponse\x12<\n" +
+	"\aPFMerge\x12\x17.service.PFMergeRequest\x1a\x18.service.PFMergeResponse\x12B\n" +
+	"\tBFReserve\x12\x19.service.BFReserveRequest\x1a\x1a.service.BFReserveResponse\x12:\n" +
+	"\x05BFAdd\x12\x17.service.BFItemsRequest\x1a\x18.service.BFItemsResponse\x12=\n" +
+	"\bBFExists\x12\x17.service.BFItemsRequest\x1a\x18.service.BFItemsResponse\x12<\n" +
+	"\aCMSInit\x12\x17.service.CMSInitRequest\x1a\x18.service.CMSInitResponse\x12B\n" +
+	"\tCMSIncrBy\x12\x19.service.CMSIncrByRequest\x1a\x1a.service.CMSCountsResponse\x12@\n" +
+	"\bCMSQuery\x12\x18.service.CMSQueryRequest\x1a\x1a.service.CMSCountsResponse\x129\n" +
+	"\x06GeoAdd\x12\x16.service.GeoAddRequest\x1a\x17.service.GeoAddResponse\x129\n" +
+	"\x06GeoPos\x12\x16.service.GeoPosRequest\x1a\x17.service.GeoPosResponse\x12<\n" +
+	"\aGeoDist\x12\x17.service.GeoDistRequest\x1a\x18.service.GeoDistResponse\x12B\n" +
+	"\tGeoSearch\x12\x19.service.GeoSearchRequest\x1a\x1a.service.GeoSearchResponse\x12?\n" +
+	"\bTSCreate\x12\x18.service.TSCreateRequest\x1a\x19.service.TSCreateResponse\x126\n" +
+	"\x05TSAdd\x12\x15.service.TSAddRequest\x1a\x16.service.TSAddResponse\x126\n" +
+	"\x05TSGet\x12\x15.service.TSGetRequest\x1a\x16.service.TSGetResponse\x12<\n" +
+	"\aTSRange\x12\x17.service.TSRangeRequest\x1a\x18.service.TSRangeResponse\x12?\n" +
+	"\bTSMRange\x12\x18.service.TSMRangeRequest\x1a\x19.service.TSMRangeResponse\x12?\n" +
+	"\fTSCreateRule\x12\x16.service.TSRuleRequest\x1a\x17.service.TSRuleResponse\x12?\n" +
+	"\fTSDeleteRule\x12\x16.service.TSRuleRequest\x1a\x17.service.TSRuleResponse\x129\n" +
+	"\x06TSInfo\x12\x16.service.TSInfoRequest\x1a\x17.service.TSInfoResponse\x12<\n" +
+	"\aJSONSet\x12\x17.service.JSONSetRequest\x1a\x18.service.JSONSetResponse\x12<\n" +
+	"\aJSONGet\x12\x17.service.JSONGetRequest\x1a\x18.service.JSONGetResponse\x12<\n" +
+	"\aJSONDel\x12\x17.service.JSONDelRequest\x1a\x18.service.JSONDelResponse\x12N\n" +
+	"\rJSONArrAppend\x12\x1d.service.JSONArrAppendRequest\x1a\x1e.service.JSONArrAppendResponse\x12N\n" +
+	"\rJSONNumIncrBy\x12\x1d.service.JSONNumIncrByRequest\x1a\x1e.service.JSONNumIncrByResponse\x12H\n" +
+	"\x0fJSONCreateIndex\x12\x19.service.JSONIndexRequest\x1a\x1a.service.JSONIndexResponse\x12F\n" +
+	"\rJSONDropIndex\x12\x19.service.JSONIndexRequest\x1a\x1a.service.JSONIndexResponse\x12T\n" +
+	"\x0fJSONListIndexes\x12\x1f.service.JSONListIndexesRequest\x1a .service.JSONListIndexesResponse\x123\n" +
+	"\x04Find\x12\x14.service.FindRequest\x1a\x15.service.FindResponse\x12H\n" +
+	"\vLockAcquire\x12\x1b.service.LockAcquireRequest\x1a\x1c.service.LockAcquireResponse\x12B\n" +
+	"\tLockRenew\x12\x19.service.LockRenewRequest\x1a\x1a.service.LockRenewResponse\x12H\n" +
+	"\vLockRelease\x12\x1b.service.LockReleaseRequest\x1a\x1c.service.LockReleaseResponse\x12E\n" +
+	"\n" +
+	"LeaseGrant\x12\x1a.service.LeaseGrantRequest\x1a\x1b.service.LeaseGrantResponse\x12U\n" +
+	"\x0eLeaseKeepAlive\x12\x1e.service.LeaseKeepAliveRequest\x1a\x1f.service.LeaseKeepAliveResponse(\x010\x01\x12H\n" +
+	"\vLeaseRevoke\x12\x1b.service.LeaseRevokeRequest\x1a\x1c.service.LeaseRevokeResponse\x12T\n" +
+	"\x0fLeaseTimeToLive\x12\x1f.service.LeaseTimeToLiveRequest\x1a .service.LeaseTimeToLiveResponse\x12?\n" +
+	"\bThrottle\x12\x18.service.ThrottleRequest\x1a\x19.service.ThrottleResponse\x123\n" +
+	"\x04Eval\x12\x14.service.EvalRequest\x1a\x15.service.EvalResponse\x129\n" +
+	"\aEvalSha\x12\x17.service.EvalShaRequest\x1a\x15.service.EvalResponse\x12E\n" +
+	"\n" +
+	"ScriptLoad\x12\x1a.service.ScriptLoadRequest\x1a\x1b.service.ScriptLoadResponse\x12K\n" +
+	"\fScriptExists\x12\x1c.service.ScriptExistsRequest\x1a\x1d.service.ScriptExistsResponse\x12H\n" +
+	"\vScriptFlush\x12\x1b.service.ScriptFlushRequest\x1a\x1c.service.ScriptFlushResponse\x123\n" +
+	"\x04Info\x12\x14.service.InfoRequest\x1a\x15.service.InfoResponse\x12@\n" +
+	"\aHotKeys\x12\x19.service.KeyReportRequest\x1a\x1a.service.KeyReportResponse\x12@\n" +
+	"\aBigKeys\x12\x19.service.KeyReportRequest\x1a\x1a.service.KeyReportResponse\x12?\n" +
+	"\n" +
+	"SlowLogGet\x12\x17.service.SlowLogRequest\x1a\x18.service.SlowLogResponse\x12K\n" +
+	"\fSlowLogReset\x12\x1c.service.SlowLogResetRequest\x1a\x1d.service.SlowLogResetResponse\x12<\n" +
+	"\aLatency\x12\x17.service.LatencyRequest\x1a\x18.service.LatencyResponse\x12;\n" +
+	"\aMonitor\x12\x17.service.MonitorRequest\x1a\x15.service.MonitorEvent0\x01\x12E\n" +
+	"\n" +
+	"AclSetUser\x12\x1a.service.AclSetUserRequest\x1a\x1b.service.AclSetUserResponse\x12E\n" +
+	"\n" +
+	"AclDelUser\x12\x1a.service.AclDelUserRequest\x1a\x1b.service.AclDelUserResponse\x12<\n" +
+	"\aAclList\x12\x17.service.AclListRequest\x1a\x18.service.AclListResponseB\x1bZ\x19Flux-KV/api/proto;serviceb\x06proto3"
+
+var (
+	file_api_proto_kv_proto_rawDescOnce sync.Once
+	file_api_proto_kv_proto_rawDescData []byte
+)
+
+func file_api_proto_kv_proto_rawDescGZIP() []byte {
+	file_api_proto_kv_proto_rawDescOnce.Do(func() {
+		file_api_proto_kv_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_kv_proto_rawDesc), len(file_api_proto_kv_proto_rawDesc)))
+	})
+	return file_api_proto_kv_proto_rawDescData
+}
+
+var file_api_proto_kv_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_proto_kv_proto_msgTypes = make([]protoimpl.MessageInfo, 152)
+var file_api_proto_kv_proto_goTypes = []any{
+	(WatchEventType)(0),             // 0: service.WatchEventType
+	(PubSubRequest_Action)(0),       // 1: service.PubSubRequest.Action
+	(*SetRequest)(nil),              // 2: service.SetRequest
+	(*SetResponse)(nil),             // 3: service.SetResponse
+	(*GetRequest)(nil),              // 4: service.GetRequest
+	(*GetResponse)(nil),             // 5: service.GetResponse
+	(*DelRequest)(nil),              // 6: service.DelRequest
+	(*DelResponse)(nil),             // 7: service.DelResponse
+	(*WatchRequest)(nil),            // 8: service.WatchRequest
+	(*WatchEvent)(nil),              // 9: service.WatchEvent
+	(*PublishRequest)(nil),          // 10: service.PublishRequest
+	(*PublishResponse)(nil),         // 11: service.PublishResponse
+	(*PubSubRequest)(nil),           // 12: service.PubSubRequest
+	(*PubSubMessage)(nil),           // 13: service.PubSubMessage
+	(*StreamField)(nil),             // 14: service.StreamField
+	(*StreamEntry)(nil),             // 15: service.StreamEntry
+	(*XAddRequest)(nil),             // 16: service.XAddRequest
+	(*XAddResponse)(nil),            // 17: service.XAddResponse
+	(*XRangeRequest)(nil),           // 18: service.XRangeRequest
+	(*XRangeResponse)(nil),          // 19: service.XRangeResponse
+	(*XTrimRequest)(nil),            // 20: service.XTrimRequest
+	(*XTrimResponse)(nil),           // 21: service.XTrimResponse
+	(*XReadRequest)(nil),            // 22: service.XReadRequest
+	(*XReadResponse)(nil),           // 23: service.XReadResponse
+	(*XGroupRequest)(nil),           // 24: service.XGroupRequest
+	(*XGroupResponse)(nil),          // 25: service.XGroupResponse
+	(*XReadGroupRequest)(nil),       // 26: service.XReadGroupRequest
+	(*XAckRequest)(nil),             // 27: service.XAckRequest
+	(*XAckResponse)(nil),            // 28: service.XAckResponse
+	(*XPendingRequest)(nil),         // 29: service.XPendingRequest
+	(*PendingEntry)(nil),            // 30: service.PendingEntry
+	(*XPendingResponse)(nil),        // 31: service.XPendingResponse
+	(*XClaimRequest)(nil),           // 32: service.XClaimRequest
+	(*XClaimResponse)(nil),          // 33: service.XClaimResponse
+	(*PFAddRequest)(nil),            // 34: service.PFAddRequest
+	(*PFAddResponse)(nil),           // 35: service.PFAddResponse
+	(*PFCountRequest)(nil),          // 36: service.PFCountRequest
+	(*PFCountResponse)(nil),         // 37: service.PFCountResponse
+	(*PFMergeRequest)(nil),          // 38: service.PFMergeRequest
+	(*PFMergeResponse)(nil),         // 39: service.PFMergeResponse
+	(*BFReserveRequest)(nil),        // 40: service.BFReserveRequest
+	(*BFReserveResponse)(nil),       // 41: service.BFReserveResponse
+	(*BFItemsRequest)(nil),          // 42: service.BFItemsRequest
+	(*BFItemsResponse)(nil),         // 43: service.BFItemsResponse
+	(*CMSInitRequest)(nil),          // 44: service.CMSInitRequest
+	(*CMSInitResponse)(nil),         // 45: service.CMSInitResponse
+	(*CMSIncrement)(nil),            // 46: service.CMSIncrement
+	(*CMSIncrByRequest)(nil),        // 47: service.CMSIncrByRequest
+	(*CMSQueryRequest)(nil),         // 48: service.CMSQueryRequest
+	(*CMSCountsResponse)(nil),       // 49: service.CMSCountsResponse
+	(*GeoLocation)(nil),             // 50: service.GeoLocation
+	(*GeoAddRequest)(nil),           // 51: service.GeoAddRequest
+	(*GeoAddResponse)(nil),          // 52: service.GeoAddResponse
+	(*GeoPosRequest)(nil),           // 53: service.GeoPosRequest
+	(*GeoPosition)(nil),             // 54: service.GeoPosition
+	(*GeoPosResponse)(nil),          // 55: service.GeoPosResponse
+	(*GeoDistRequest)(nil),          // 56: service.GeoDistRequest
+	(*GeoDistResponse)(nil),         // 57: service.GeoDistResponse
+	(*GeoSearchRequest)(nil),        // 58: service.GeoSearchRequest
+	(*GeoSearchResult)(nil),         // 59: service.GeoSearchResult
+	(*GeoSearchResponse)(nil),       // 60: service.GeoSearchResponse
+	(*TSSample)(nil),                // 61: service.TSSample
+	(*TSCreateRequest)(nil),         // 62: service.TSCreateRequest
+	(*TSCreateResponse)(nil),        // 63: service.TSCreateResponse
+	(*TSAddRequest)(nil),            // 64: service.TSAddRequest
+	(*TSAddResponse)(nil),           // 65: service.TSAddResponse
+	(*TSGetRequest)(nil),            // 66: service.TSGetRequest
+	(*TSGetResponse)(nil),           // 67: service.TSGetResponse
+	(*TSAggregation)(nil),           // 68: service.TSAggregation
+	(*TSRangeRequest)(nil),          // 69: service.TSRangeRequest
+	(*TSRangeResponse)(nil),         // 70: service.TSRangeResponse
+	(*TSMRangeRequest)(nil),         // 71: service.TSMRangeRequest
+	(*TSSeries)(nil),                // 72: service.TSSeries
+	(*TSMRangeResponse)(nil),        // 73: service.TSMRangeResponse
+	(*TSRuleRequest)(nil),           // 74: service.TSRuleRequest
+	(*TSRuleResponse)(nil),          // 75: service.TSRuleResponse
+	(*TSInfoRequest)(nil),           // 76: service.TSInfoRequest
+	(*TSRule)(nil),                  // 77: service.TSRule
+	(*TSInfoResponse)(nil),          // 78: service.TSInfoResponse
+	(*JSONSetRequest)(nil),          // 79: service.JSONSetRequest
+	(*JSONSetResponse)(nil),         // 80: service.JSONSetResponse
+	(*JSONGetRequest)(nil),          // 81: service.JSONGetRequest
+	(*JSONGetResponse)(nil),         // 82: service.JSONGetResponse
+	(*JSONDelRequest)(nil),          // 83: service.JSONDelRequest
+	(*JSONDelResponse)(nil),         // 84: service.JSONDelResponse
+	(*JSONArrAppendRequest)(nil),    // 85: service.JSONArrAppendRequest
+	(*JSONArrAppendResponse)(nil),   // 86: service.JSONArrAppendResponse
+	(*JSONNumIncrByRequest)(nil),    // 87: service.JSONNumIncrByRequest
+	(*JSONNumIncrByResponse)(nil),   // 88: service.JSONNumIncrByResponse
+	(*JSONIndexRequest)(nil),        // 89: service.JSONIndexRequest
+	(*JSONIndexResponse)(nil),       // 90: service.JSONIndexResponse
+	(*JSONListIndexesRequest)(nil),  // 91: service.JSONListIndexesRequest
+	(*JSONIndexInfo)(nil),           // 92: service.JSONIndexInfo
+	(*JSONListIndexesResponse)(nil), // 93: service.JSONListIndexesResponse
+	(*FindRequest)(nil),             // 94: service.FindRequest
+	(*JSONMatch)(nil),               // 95: service.JSONMatch
+	(*FindResponse)(nil),            // 96: service.FindResponse
+	(*LockAcquireRequest)(nil),      // 97: service.LockAcquireRequest
+	(*LockAcquireResponse)(nil),     // 98: service.LockAcquireResponse
+	(*LockRenewRequest)(nil),        // 99: service.LockRenewRequest
+	(*LockRenewResponse)(nil),       // 100: service.LockRenewResponse
+	(*LockReleaseRequest)(nil),      // 101: service.LockReleaseRequest
+	(*LockReleaseResponse)(nil),     // 102: service.LockReleaseResponse
+	(*LeaseGrantRequest)(nil),       // 103: service.LeaseGrantRequest
+	(*LeaseGrantResponse)(nil),      // 104: service.LeaseGrantResponse
+	(*LeaseKeepAliveRequest)(nil),   // 105: service.LeaseKeepAliveRequest
+	(*LeaseKeepAliveResponse)(nil),  // 106: service.LeaseKeepAliveResponse
+	(*LeaseRevokeRequest)(nil),      // 107: service.LeaseRevokeRequest
+	(*LeaseRevokeResponse)(nil),     // 108: service.LeaseRevokeResponse
+	(*LeaseTimeToLiveRequest)(nil),  // 109: service.LeaseTimeToLiveRequest
+	(*LeaseTimeToLiveResponse)(nil), // 110: service.LeaseTimeToLiveResponse
+	(*ThrottleRequest)(nil),         // 111: service.ThrottleRequest
+	(*ThrottleResponse)(nil),        // 112: service.ThrottleResponse
+	(*ScriptValue)(nil),             // 113: service.ScriptValue
+	(*ScriptList)(nil),              // 114: service.ScriptList
+	(*EvalRequest)(nil),             // 115: service.EvalRequest
+	(*EvalShaRequest)(nil),          // 116: service.EvalShaRequest
+	(*EvalResponse)(nil),            // 117: service.EvalResponse
+	(*ScriptLoadRequest)(nil),       // 118: service.ScriptLoadRequest
+	(*ScriptLoadResponse)(nil),      // 119: service.ScriptLoadResponse
+	(*ScriptExistsRequest)(nil),     // 120: service.ScriptExistsRequest
+	(*ScriptExistsResponse)(nil),    // 121: service.ScriptExistsResponse
+	(*ScriptFlushRequest)(nil),      // 122: service.ScriptFlushRequest
+	(*ScriptFlushResponse)(nil),     // 123: service.ScriptFlushResponse
+	(*InfoRequest)(nil),             // 124: service.InfoRequest
+	(*ShardInfo)(nil),               // 125: service.ShardInfo
+	(*CommandInfo)(nil),             // 126: service.CommandInfo
+	(*InfoResponse)(nil),            // 127: service.InfoResponse
+	(*KeyReportRequest)(nil),        // 128: service.KeyReportRequest
+	(*KeyStat)(nil),                 // 129: service.KeyStat
+	(*KeyReportResponse)(nil),       // 130: service.KeyReportResponse
+	(*SlowLogRequest)(nil),          // 131: service.SlowLogRequest
+	(*SlowLogEntry)(nil),            // 132: service.SlowLogEntry
+	(*SlowLogResponse)(nil),         // 133: service.SlowLogResponse
+	(*SlowLogResetRequest)(nil),     // 134: service.SlowLogResetRequest
+	(*SlowLogResetResponse)(nil),    // 135: service.SlowLogResetResponse
+	(*LatencyRequest)(nil),          // 136: service.LatencyRequest
+	(*LatencyBucket)(nil),           // 137: service.LatencyBucket
+	(*LatencyStats)(nil),            // 138: service.LatencyStats
+	(*LatencyResponse)(nil),         // 139: service.LatencyResponse
+	(*MonitorRequest)(nil),          // 140: service.MonitorRequest
+	(*MonitorEvent)(nil),            // 141: service.MonitorEvent
+	(*AclUser)(nil),                 // 142: service.AclUser
+	(*AclSetUserRequest)(nil),       // 143: service.AclSetUserRequest
+	(*AclSetUserResponse)(nil),      // 144: service.AclSetUserResponse
+	(*AclDelUserRequest)(nil),       // 145: service.AclDelUserRequest
+	(*AclDelUserResponse)(nil),      // 146: service.AclDelUserResponse
+	(*AclListRequest)(nil),          // 147: service.AclListRequest
+	(*AclListResponse)(nil),         // 148: service.AclListResponse
+	nil,                             // 149: service.XPendingResponse.ConsumersEntry
+	nil,                             // 150: service.TSCreateRequest.LabelsEntry
+	nil,                             // 151: service.TSAddRequest.LabelsEntry
+	nil,                             // 152: service.TSSeries.LabelsEntry
+	nil,                             // 153: service.TSInfoResponse.LabelsEntry
+}
+var file_api_proto_kv_proto_depIdxs = []int32{
+	0,   // 0: service.WatchEvent.type:type_name -> service.WatchEventType
+	1,   // 1: service.PubSubRequest.action:type_name -> service.PubSubRequest.Action
+	14,  // 2: service.StreamEntry.fields:type_name -> service.StreamField
+	14,  // 3: service.XAddRequest.fields:type_name -> service.StreamField
+	15,  // 4: service.XRangeResponse.entries:type_name -> service.StreamEntry
+	15,  // 5: service.XReadResponse.entry:type_name -> service.StreamEntry
+	149, // 6: service.XPendingResponse.consumers:type_name -> service.XPendingResponse.ConsumersEntry
+	30,  // 7: service.XPendingResponse.entries:type_name -> service.PendingEntry
+	15,  // 8: service.XClaimResponse.entries:type_name -> service.StreamEntry
+	46,  // 9: service.CMSIncrByRequest.increments:type_name -> service.CMSIncrement
+	50,  // 10: service.GeoAddRequest.locations:type_name -> service.GeoLocation
+	54,  // 11: service.GeoPosResponse.positions:type_name -> service.GeoPosition
+	59,  // 12: service.GeoSearchResponse.results:type_name -> service.GeoSearchResult
+	150, // 13: service.TSCreateRequest.labels:type_name -> service.TSCreateRequest.LabelsEntry
+	151, // 14: service.TSAddRequest.labels:type_name -> service.TSAddRequest.LabelsEntry
+	61,  // 15: service.TSGetResponse.sample:type_name -> service.TSSample
+	68,  // 16: service.TSRangeRequest.aggregation:type_name -> service.TSAggregation
+	61,  // 17: service.TSRangeResponse.samples:type_name -> service.TSSample
+	68,  // 18: service.TSMRangeRequest.aggregation:type_name -> service.TSAggregation
+	152, // 19: service.TSSeries.labels:type_name -> service.TSSeries.LabelsEntry
+	61,  // 20: service.TSSeries.samples:type_name -> service.TSSample
+	72,  // 21: service.TSMRangeResponse.series:type_name -> service.TSSeries
+	68,  // 22: service.TSRuleRequest.aggregation:type_name -> service.TSAggregation
+	68,  // 23: service.TSRule.aggregation:type_name -> service.TSAggregation
+	153, // 24: service.TSInfoResponse.labels:type_name -> service.TSInfoResponse.LabelsEntry
+	77,  // 25: service.TSInfoResponse.rules:type_name -> service.TSRule
+	92,  // 26: service.JSONListIndexesResponse.indexes:type_name -> service.JSONIndexInfo
+	95,  // 27: service.FindResponse.matches:type_name -> service.JSONMatch
+	114, // 28: service.ScriptValue.list:type_name -> service.ScriptList
+	113, // 29: service.ScriptList.items:type_name -> service.ScriptValue
+	113, // 30: service.EvalResponse.result:type_name -> service.ScriptValue
+	125, // 31: service.InfoResponse.shards:type_name -> service.ShardInfo
+	126, // 32: service.InfoResponse.commands:type_name -> service.CommandInfo
+	129, // 33: service.KeyReportResponse.keys:type_name -> service.KeyStat
+	132, // 34: service.SlowLogResponse.entries:type_name -> service.SlowLogEntry
+	137, // 35: service.LatencyStats.buckets:type_name -> service.LatencyBucket
+	138, // 36: service.LatencyResponse.events:type_name -> service.LatencyStats
+	142, // 37: service.AclSetUserRequest.user:type_name -> service.AclUser
+	142, // 38: service.AclListResponse.users:type_name -> service.AclUser
+	2,   // 39: service.KVService.Set:input_type -> service.SetRequest
+	4,   // 40: service.KVService.Get:input_type -> service.GetRequest
+	6,   // 41: service.KVService.Del:input_type -> service.DelRequest
+	8,   // 42: service.KVService.Watch:input_type -> service.WatchRequest
+	10,  // 43: service.KVService.Publish:input_type -> service.PublishRequest
+	12,  // 44: service.KVService.PubSub:input_type -> service.PubSubRequest
+	16,  // 45: service.KVService.XAdd:input_type -> service.XAddRequest
+	18,  // 46: service.KVService.XRange:input_type -> service.XRangeRequest
+	20,  // 47: service.KVService.XTrim:input_type -> service.XTrimRequest
+	22,  // 48: service.KVService.XRead:input_type -> service.XReadRequest
+	24,  // 49: service.KVService.XGroupCreate:input_type -> service.XGroupRequest
+	24,  // 50: service.KVService.XGroupDestroy:input_type -> service.XGroupRequest
+	26,  // 51: service.KVService.XReadGroup:input_type -> service.XReadGroupRequest
+	27,  // 52: service.KVService.XAck:input_type -> service.XAckRequest
+	29,  // 53: service.KVService.XPending:input_type -> service.XPendingRequest
+	32,  // 54: service.KVService.XClaim:input_type -> service.XClaimRequest
+	34,  // 55: service.KVService.PFAdd:input_type -> service.PFAddRequest
+	36,  // 56: service.KVService.PFCount:input_type -> service.PFCountRequest
+	38,  // 57: service.KVService.PFMerge:input_type -> service.PFMergeRequest
+	40,  // 58: service.KVService.BFReserve:input_type -> service.BFReserveRequest
+	42,  // 59: service.KVService.BFAdd:input_type -> service.BFItemsRequest
+	42,  // 60: service.KVService.BFExists:input_type -> service.BFItemsRequest
+	44,  // 61: service.KVService.CMSInit:input_type -> service.CMSInitRequest
+	47,  // 62: service.KVService.CMSIncrBy:input_type -> service.CMSIncrByRequest
+	48,  // 63: service.KVService.CMSQuery:input_type -> service.CMSQueryRequest
+	51,  // 64: service.KVService.GeoAdd:input_type -> service.GeoAddRequest
+	53,  // 65: service.KVService.GeoPos:input_type -> service.GeoPosRequest
+	56,  // 66: service.KVService.GeoDist:input_type -> service.GeoDistRequest
+	58,  // 67: service.KVService.GeoSearch:input_type -> service.GeoSearchRequest
+	62,  // 68: service.KVService.TSCreate:input_type -> service.TSCreateRequest
+	64,  // 69: service.KVService.TSAdd:input_type -> service.TSAddRequest
+	66,  // 70: service.KVService.TSGet:input_type -> service.TSGetRequest
+	69,  // 71: service.KVService.TSRange:input_type -> service.TSRangeRequest
+	71,  // 72: service.KVService.TSMRange:input_type -> service.TSMRangeRequest
+	74,  // 73: service.KVService.TSCreateRule:input_type -> service.TSRuleRequest
+	74,  // 74: service.KVService.TSDeleteRule:input_type -> service.TSRuleRequest
+	76,  // 75: service.KVService.TSInfo:input_type -> service.TSInfoRequest
+	79,  // 76: service.KVService.JSONSet:input_type -> service.JSONSetRequest
+	81,  // 77: service.KVService.JSONGet:input_type -> service.JSONGetRequest
+	83,  // 78: service.KVService.JSONDel:input_type -> service.JSONDelRequest
+	85,  // 79: service.KVService.JSONArrAppend:input_type -> service.JSONArrAppendRequest
+	87,  // 80: service.KVService.JSONNumIncrBy:input_type -> service.JSONNumIncrByRequest
+	89,  // 81: service.KVService.JSONCreateIndex:input_type -> service.JSONIndexRequest
+	89,  // 82: service.KVService.JSONDropIndex:input_type -> service.JSONIndexRequest
+	91,  // 83: service.KVService.JSONListIndexes:input_type -> service.JSONListIndexesRequest
+	94,  // 84: service.KVService.Find:input_type -> service.FindRequest
+	97,  // 85: service.KVService.LockAcquire:input_type -> service.LockAcquireRequest
+	99,  // 86: service.KVService.LockRenew:input_type -> service.LockRenewRequest
+	101, // 87: service.KVService.LockRelease:input_type -> service.LockReleaseRequest
+	103, // 88: service.KVService.LeaseGrant:input_type -> service.LeaseGrantRequest
+	105, // 89: service.KVService.LeaseKeepAlive:input_type -> service.LeaseKeepAliveRequest
+	107, // 90: service.KVService.LeaseRevoke:input_type -> service.LeaseRevokeRequest
+	109, // 91: service.KVService.LeaseTimeToLive:input_type -> service.LeaseTimeToLiveRequest
+	111, // 92: service.KVService.Throttle:input_type -> service.ThrottleRequest
+	115, // 93: service.KVService.Eval:input_type -> service.EvalRequest
+	116, // 94: service.KVService.EvalSha:input_type -> service.EvalShaRequest
+	118, // 95: service.KVService.ScriptLoad:input_type -> service.ScriptLoadRequest
+	120, // 96: service.KVService.ScriptExists:input_type -> service.ScriptExistsRequest
+	122, // 97: service.KVService.ScriptFlush:input_type -> service.ScriptFlushRequest
+	124, // 98: service.KVService.Info:input_type -> service.InfoRequest
+	128, // 99: service.KVService.HotKeys:input_type -> service.KeyReportRequest
+	128, // 100: service.KVService.BigKeys:input_type -> service.KeyReportRequest
+	131, // 101: service.KVService.SlowLogGet:input_type -> service.SlowLogRequest
+	134, // 102: service.KVService.SlowLogReset:input_type -> service.SlowLogResetRequest
+	136, // 103: service.KVService.Latency:input_type -> service.LatencyRequest
+	140, // 104: service.KVService.Monitor:input_type -> service.MonitorRequest
+	143, // 105: service.KVService.AclSetUser:input_type -> service.AclSetUserRequest
+	145, // 106: service.KVService.AclDelUser:input_type -> service.AclDelUserRequest
+	147, // 107: service.KVService.AclList:input_type -> service.AclListRequest
+	3,   // 108: service.KVService.Set:output_type -> service.SetResponse
+	5,   // 109: service.KVService.Get:output_type -> service.GetResponse
+	7,   // 110: service.KVService.Del:output_type -> service.DelResponse
+	9,   // 111: service.KVService.Watch:output_type -> service.WatchEvent
+	11,  // 112: service.KVService.Publish:output_type -> service.PublishResponse
+	13,  // 113: service.KVService.PubSub:output_type -> service.PubSubMessage
+	17,  // 114: service.KVService.XAdd:output_type -> service.XAddResponse
+	19,  // 115: service.KVService.XRange:output_type -> service.XRangeResponse
+	21,  // 116: service.KVService.XTrim:output_type -> service.XTrimResponse
+	23,  // 117: service.KVService.XRead:output_type -> service.XReadResponse
+	25,  // 118: service.KVService.XGroupCreate:output_type -> service.XGroupResponse
+	25,  // 119: service.KVService.XGroupDestroy:output_type -> service.XGroupResponse
+	23,  // 120: service.KVService.XReadGroup:output_type -> service.XReadResponse
+	28,  // 121: service.KVService.XAck:output_type -> service.XAckResponse
+	31,  // 122: service.KVService.XPending:output_type -> service.XPendingResponse
+	33,  // 123: service.KVService.XClaim:output_type -> service.XClaimResponse
+	35,  // 124: service.KVService.PFAdd:output_type -> service.PFAddResponse
+	37,  // 125: service.KVService.PFCount:output_type -> service.PFCountResponse
+	39,  // 126: service.KVService.PFMerge:output_type -> service.PFMergeResponse
+	41,  // 127: service.KVService.BFReserve:output_type -> service.BFReserveResponse
+	43,  // 128: service.KVService.BFAdd:output_type -> service.BFItemsResponse
+	43,  // 129: service.KVService.BFExists:output_type -> service.BFItemsResponse
+	45,  // 130: service.KVService.CMSInit:output_type -> service.CMSInitResponse
+	49,  // 131: service.KVService.CMSIncrBy:output_type -> service.CMSCountsResponse
+	49,  // 132: service.KVService.CMSQuery:output_type -> service.CMSCountsResponse
+	52,  // 133: service.KVService.GeoAdd:output_type -> service.GeoAddResponse
+	55,  // 134: service.KVService.GeoPos:output_type -> service.GeoPosResponse
+	57,  // 135: service.KVService.GeoDist:output_type -> service.GeoDistResponse
+	60,  // 136: service.KVService.GeoSearch:output_type -> service.GeoSearchResponse
+	63,  // 137: service.KVService.TSCreate:output_type -> service.TSCreateResponse
+	65,  // 138: service.KVService.TSAdd:output_type -> service.TSAddResponse
+	67,  // 139: service.KVService.TSGet:output_type -> service.TSGetResponse
+	70,  // 140: service.KVService.TSRange:output_type -> service.TSRangeResponse
+	73,  // 141: service.KVService.TSMRange:output_type -> service.TSMRangeResponse
+	75,  // 142: service.KVService.TSCreateRule:output_type -> service.TSRuleResponse
+	75,  // 143: service.KVService.TSDeleteRule:output_type -> service.TSRuleResponse
+	78,  // 144: service.KVService.TSInfo:output_type -> service.TSInfoResponse
+	80,  // 145: service.KVService.JSONSet:output_type -> service.JSONSetResponse
+	82,  // 146: service.KVService.JSONGet:output_type -> service.JSONGetResponse
+	84,  // 147: service.KVService.JSONDel:output_type -> service.JSONDelResponse
+	86,  // 148: service.KVService.JSONArrAppend:output_type -> service.JSONArrAppendResponse
+	88,  // 149: service.KVService.JSONNumIncrBy:output_type -> service.JSONNumIncrByResponse
+	90,  // 150: service.KVService.JSONCreateIndex:output_type -> service.JSONIndexResponse
+	90,  // 151: service.KVService.JSONDropIndex:output_type -> service.JSONIndexResponse
+	93,  // 152: service.KVService.JSONListIndexes:output_type -> service.JSONListIndexesResponse
+	96,  // 153: service.KVService.Find:output_type -> service.FindResponse
+	98,  // 154: service.KVService.LockAcquire:output_type -> service.LockAcquireResponse
+	100, // 155: service.KVService.LockRenew:output_type -> service.LockRenewResponse
+	102, // 156: service.KVService.LockRelease:output_type -> service.LockReleaseResponse
+	104, // 157: service.KVService.LeaseGrant:output_type -> service.LeaseGrantResponse
+	106, // 158: service.KVService.LeaseKeepAlive:output_type -> service.LeaseKeepAliveResponse
+	108, // 159: service.KVService.LeaseRevoke:output_type -> service.LeaseRevokeResponse
+	110, // 160: service.KVService.LeaseTimeToLive:output_type -> service.LeaseTimeToLiveResponse
+	112, // 161: service.KVService.Throttle:output_type -> service.ThrottleResponse
+	117, // 162: service.KVService.Eval:output_type -> service.EvalResponse
+	117, // 163: service.KVService.EvalSha:output_type -> service.EvalResponse
+	119, // 164: service.KVService.ScriptLoad:output_type -> service.ScriptLoadResponse
+	121, // 165: service.KVService.ScriptExists:output_type -> service.ScriptExistsResponse
+	123, // 166: service.KVService.ScriptFlush:output_type -> service.ScriptFlushResponse
+	127, // 167: service.KVService.Info:output_type -> service.InfoResponse
+	130, // 168: service.KVService.HotKeys:output_type -> service.KeyReportResponse
+	130, // 169: service.KVService.BigKeys:output_type -> service.KeyReportResponse
+	133, // 170: service.KVService.SlowLogGet:output_type -> service.SlowLogResponse
+	135, // 171: service.KVService.SlowLogReset:output_type -> service.SlowLogResetResponse
+	139, // 172: service.KVService.Latency:output_type -> service.LatencyResponse
+	141, // 173: service.KVService.Monitor:output_type -> service.MonitorEvent
+	144, // 174: service.KVService.AclSetUser:output_type -> service.AclSetUserResponse
+	146, // 175: service.KVService.AclDelUser:output_type -> service.AclDelUserResponse
+	148, // 176: service.KVService.AclList:output_type -> service.AclListResponse
+	108, // [108:177] is the sub-list for method output_type
+	39,  // [39:108] is the sub-list for method input_type
+	39,  // [39:39] is the sub-list for extension type_name
+	39,  // [39:39] is the sub-list for extension extendee
+	0,   // [0:39] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_kv_proto_init() }
+func file_api_proto_kv_proto_init() {
+	if File_api_proto_kv_proto != nil {
+		return
+	}
+	file_api_proto_kv_proto_msgTypes[111].OneofWrappers = []any{
+		(*ScriptValue_Int)(nil),
+		(*ScriptValue_Float)(nil),
+		(*ScriptValue_Str)(nil),
+		(*ScriptValue_Bool)(nil),
+		(*ScriptValue_List)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_kv_proto_rawDesc), len(file_api_proto_kv_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   152,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_proto_kv_proto_goTypes,
 		DependencyIndexes: file_api_proto_kv_proto_depIdxs,
+		EnumInfos:         file_api_proto_kv_proto_enumTypes,
 		MessageInfos:      file_api_proto_kv_proto_msgTypes,
 	}.Build()
 	File_api_proto_kv_proto = out.File
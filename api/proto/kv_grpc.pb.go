@@ -19,9 +19,75 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	KVService_Set_FullMethodName = "/service.KVService/Set"
-	KVService_Get_FullMethodName = "/service.KVService/Get"
-	KVService_Del_FullMethodName = "/service.KVService/Del"
+	KVService_Set_FullMethodName             = "/service.KVService/Set"
+	KVService_Get_FullMethodName             = "/service.KVService/Get"
+	KVService_Del_FullMethodName             = "/service.KVService/Del"
+	KVService_Watch_FullMethodName           = "/service.KVService/Watch"
+	KVService_Publish_FullMethodName         = "/service.KVService/Publish"
+	KVService_PubSub_FullMethodName          = "/service.KVService/PubSub"
+	KVService_XAdd_FullMethodName            = "/service.KVService/XAdd"
+	KVService_XRange_FullMethodName          = "/service.KVService/XRange"
+	KVService_XTrim_FullMethodName           = "/service.KVService/XTrim"
+	KVService_XRead_FullMethodName           = "/service.KVService/XRead"
+	KVService_XGroupCreate_FullMethodName    = "/service.KVService/XGroupCreate"
+	KVService_XGroupDestroy_FullMethodName   = "/service.KVService/XGroupDestroy"
+	KVService_XReadGroup_FullMethodName      = "/service.KVService/XReadGroup"
+	KVService_XAck_FullMethodName            = "/service.KVService/XAck"
+	KVService_XPending_FullMethodName        = "/service.KVService/XPending"
+	KVService_XClaim_FullMethodName          = "/service.KVService/XClaim"
+	KVService_PFAdd_FullMethodName           = "/service.KVService/PFAdd"
+	KVService_PFCount_FullMethodName         = "/service.KVService/PFCount"
+	KVService_PFMerge_FullMethodName         = "/service.KVService/PFMerge"
+	KVService_BFReserve_FullMethodName       = "/service.KVService/BFReserve"
+	KVService_BFAdd_FullMethodName           = "/service.KVService/BFAdd"
+	KVService_BFExists_FullMethodName        = "/service.KVService/BFExists"
+	KVService_CMSInit_FullMethodName         = "/service.KVService/CMSInit"
+	KVService_CMSIncrBy_FullMethodName       = "/service.KVService/CMSIncrBy"
+	KVService_CMSQuery_FullMethodName        = "/service.KVService/CMSQuery"
+	KVService_GeoAdd_FullMethodName          = "/service.KVService/GeoAdd"
+	KVService_GeoPos_FullMethodName          = "/service.KVService/GeoPos"
+	KVService_GeoDist_FullMethodName         = "/service.KVService/GeoDist"
+	KVService_GeoSearch_FullMethodName       = "/service.KVService/GeoSearch"
+	KVService_TSCreate_FullMethodName        = "/service.KVService/TSCreate"
+	KVService_TSAdd_FullMethodName           = "/service.KVService/TSAdd"
+	KVService_TSGet_FullMethodName           = "/service.KVService/TSGet"
+	KVService_TSRange_FullMethodName         = "/service.KVService/TSRange"
+	KVService_TSMRange_FullMethodName        = "/service.KVService/TSMRange"
+	KVService_TSCreateRule_FullMethodName    = "/service.KVService/TSCreateRule"
+	KVService_TSDeleteRule_FullMethodName    = "/service.KVService/TSDeleteRule"
+	KVService_TSInfo_FullMethodName          = "/service.KVService/TSInfo"
+	KVService_JSONSet_FullMethodName         = "/service.KVService/JSONSet"
+	KVService_JSONGet_FullMethodName         = "/service.KVService/JSONGet"
+	KVService_JSONDel_FullMethodName         = "/service.KVService/JSONDel"
+	KVService_JSONArrAppend_FullMethodName   = "/service.KVService/JSONArrAppend"
+	KVService_JSONNumIncrBy_FullMethodName   = "/service.KVService/JSONNumIncrBy"
+	KVService_JSONCreateIndex_FullMethodName = "/service.KVService/JSONCreateIndex"
+	KVService_JSONDropIndex_FullMethodName   = "/service.KVService/JSONDropIndex"
+	KVService_JSONListIndexes_FullMethodName = "/service.KVService/JSONListIndexes"
+	KVService_Find_FullMethodName            = "/service.KVService/Find"
+	KVService_LockAcquire_FullMethodName     = "/service.KVService/LockAcquire"
+	KVService_LockRenew_FullMethodName       = "/service.KVService/LockRenew"
+	KVService_LockRelease_FullMethodName     = "/service.KVService/LockRelease"
+	KVService_LeaseGrant_FullMethodName      = "/service.KVService/LeaseGrant"
+	KVService_LeaseKeepAlive_FullMethodName  = "/service.KVService/LeaseKeepAlive"
+	KVService_LeaseRevoke_FullMethodName     = "/service.KVService/LeaseRevoke"
+	KVService_LeaseTimeToLive_FullMethodName = "/service.KVService/LeaseTimeToLive"
+	KVService_Throttle_FullMethodName        = "/service.KVService/Throttle"
+	KVService_Eval_FullMethodName            = "/service.KVService/Eval"
+	KVService_EvalSha_FullMethodName         = "/service.KVService/EvalSha"
+	KVService_ScriptLoad_FullMethodName      = "/service.KVService/ScriptLoad"
+	KVService_ScriptExists_FullMethodName    = "/service.KVService/ScriptExists"
+	KVService_ScriptFlush_FullMethodName     = "/service.KVService/ScriptFlush"
+	KVService_Info_FullMethodName            = "/service.KVService/Info"
+	KVService_HotKeys_FullMethodName         = "/service.KVService/HotKeys"
+	KVService_BigKeys_FullMethodName         = "/service.KVService/BigKeys"
+	KVService_SlowLogGet_FullMethodName      = "/service.KVService/SlowLogGet"
+	KVService_SlowLogReset_FullMethodName    = "/service.KVService/SlowLogReset"
+	KVService_Latency_FullMethodName         = "/service.KVService/Latency"
+	KVService_Monitor_FullMethodName         = "/service.KVService/Monitor"
+	KVService_AclSetUser_FullMethodName      = "/service.KVService/AclSetUser"
+	KVService_AclDelUser_FullMethodName      = "/service.KVService/AclDelUser"
+	KVService_AclList_FullMethodName         = "/service.KVService/AclList"
 )
 
 // KVServiceClient is the client API for KVService service.
@@ -34,6 +100,90 @@ type KVServiceClient interface {
 	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	Del(ctx context.Context, in *DelRequest, opts ...grpc.CallOption) (*DelResponse, error)
+	// 订阅 Key / 前缀的变更，支持从指定 revision 断点续订
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error)
+	// 发布/订阅：Publish 为单次发布，PubSub 为双向流（同一条流上订阅、退订和接收消息）
+	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
+	PubSub(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PubSubRequest, PubSubMessage], error)
+	// Stream：只追加的消息日志和消费者组
+	XAdd(ctx context.Context, in *XAddRequest, opts ...grpc.CallOption) (*XAddResponse, error)
+	XRange(ctx context.Context, in *XRangeRequest, opts ...grpc.CallOption) (*XRangeResponse, error)
+	XTrim(ctx context.Context, in *XTrimRequest, opts ...grpc.CallOption) (*XTrimResponse, error)
+	// XRead 持续推送新消息（相当于循环执行阻塞的 XREAD），直到客户端取消
+	XRead(ctx context.Context, in *XReadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[XReadResponse], error)
+	XGroupCreate(ctx context.Context, in *XGroupRequest, opts ...grpc.CallOption) (*XGroupResponse, error)
+	XGroupDestroy(ctx context.Context, in *XGroupRequest, opts ...grpc.CallOption) (*XGroupResponse, error)
+	// XReadGroup 持续以消费者身份领取组内的新消息，处理完后通过 XAck 确认
+	XReadGroup(ctx context.Context, in *XReadGroupRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[XReadResponse], error)
+	XAck(ctx context.Context, in *XAckRequest, opts ...grpc.CallOption) (*XAckResponse, error)
+	XPending(ctx context.Context, in *XPendingRequest, opts ...grpc.CallOption) (*XPendingResponse, error)
+	XClaim(ctx context.Context, in *XClaimRequest, opts ...grpc.CallOption) (*XClaimResponse, error)
+	// 概率数据结构：HyperLogLog / 布隆过滤器 / Count-Min Sketch
+	PFAdd(ctx context.Context, in *PFAddRequest, opts ...grpc.CallOption) (*PFAddResponse, error)
+	PFCount(ctx context.Context, in *PFCountRequest, opts ...grpc.CallOption) (*PFCountResponse, error)
+	PFMerge(ctx context.Context, in *PFMergeRequest, opts ...grpc.CallOption) (*PFMergeResponse, error)
+	BFReserve(ctx context.Context, in *BFReserveRequest, opts ...grpc.CallOption) (*BFReserveResponse, error)
+	BFAdd(ctx context.Context, in *BFItemsRequest, opts ...grpc.CallOption) (*BFItemsResponse, error)
+	BFExists(ctx context.Context, in *BFItemsRequest, opts ...grpc.CallOption) (*BFItemsResponse, error)
+	CMSInit(ctx context.Context, in *CMSInitRequest, opts ...grpc.CallOption) (*CMSInitResponse, error)
+	CMSIncrBy(ctx context.Context, in *CMSIncrByRequest, opts ...grpc.CallOption) (*CMSCountsResponse, error)
+	CMSQuery(ctx context.Context, in *CMSQueryRequest, opts ...grpc.CallOption) (*CMSCountsResponse, error)
+	// 地理位置：以 geohash 为分数的有序集合
+	GeoAdd(ctx context.Context, in *GeoAddRequest, opts ...grpc.CallOption) (*GeoAddResponse, error)
+	GeoPos(ctx context.Context, in *GeoPosRequest, opts ...grpc.CallOption) (*GeoPosResponse, error)
+	GeoDist(ctx context.Context, in *GeoDistRequest, opts ...grpc.CallOption) (*GeoDistResponse, error)
+	GeoSearch(ctx context.Context, in *GeoSearchRequest, opts ...grpc.CallOption) (*GeoSearchResponse, error)
+	// 时间序列：Gorilla 压缩存储，支持按标签批量查询和降采样规则
+	TSCreate(ctx context.Context, in *TSCreateRequest, opts ...grpc.CallOption) (*TSCreateResponse, error)
+	TSAdd(ctx context.Context, in *TSAddRequest, opts ...grpc.CallOption) (*TSAddResponse, error)
+	TSGet(ctx context.Context, in *TSGetRequest, opts ...grpc.CallOption) (*TSGetResponse, error)
+	TSRange(ctx context.Context, in *TSRangeRequest, opts ...grpc.CallOption) (*TSRangeResponse, error)
+	TSMRange(ctx context.Context, in *TSMRangeRequest, opts ...grpc.CallOption) (*TSMRangeResponse, error)
+	TSCreateRule(ctx context.Context, in *TSRuleRequest, opts ...grpc.CallOption) (*TSRuleResponse, error)
+	TSDeleteRule(ctx context.Context, in *TSRuleRequest, opts ...grpc.CallOption) (*TSRuleResponse, error)
+	TSInfo(ctx context.Context, in *TSInfoRequest, opts ...grpc.CallOption) (*TSInfoResponse, error)
+	// JSON 文档：按路径原子读写，命名空间内的字段可建二级索引
+	JSONSet(ctx context.Context, in *JSONSetRequest, opts ...grpc.CallOption) (*JSONSetResponse, error)
+	JSONGet(ctx context.Context, in *JSONGetRequest, opts ...grpc.CallOption) (*JSONGetResponse, error)
+	JSONDel(ctx context.Context, in *JSONDelRequest, opts ...grpc.CallOption) (*JSONDelResponse, error)
+	JSONArrAppend(ctx context.Context, in *JSONArrAppendRequest, opts ...grpc.CallOption) (*JSONArrAppendResponse, error)
+	JSONNumIncrBy(ctx context.Context, in *JSONNumIncrByRequest, opts ...grpc.CallOption) (*JSONNumIncrByResponse, error)
+	JSONCreateIndex(ctx context.Context, in *JSONIndexRequest, opts ...grpc.CallOption) (*JSONIndexResponse, error)
+	JSONDropIndex(ctx context.Context, in *JSONIndexRequest, opts ...grpc.CallOption) (*JSONIndexResponse, error)
+	JSONListIndexes(ctx context.Context, in *JSONListIndexesRequest, opts ...grpc.CallOption) (*JSONListIndexesResponse, error)
+	Find(ctx context.Context, in *FindRequest, opts ...grpc.CallOption) (*FindResponse, error)
+	// 分布式锁：带租期和 fencing token，支持阻塞排队
+	LockAcquire(ctx context.Context, in *LockAcquireRequest, opts ...grpc.CallOption) (*LockAcquireResponse, error)
+	LockRenew(ctx context.Context, in *LockRenewRequest, opts ...grpc.CallOption) (*LockRenewResponse, error)
+	LockRelease(ctx context.Context, in *LockReleaseRequest, opts ...grpc.CallOption) (*LockReleaseResponse, error)
+	// 租约：多个 Key 绑定到同一个 TTL，一起过期或撤销
+	LeaseGrant(ctx context.Context, in *LeaseGrantRequest, opts ...grpc.CallOption) (*LeaseGrantResponse, error)
+	LeaseKeepAlive(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[LeaseKeepAliveRequest, LeaseKeepAliveResponse], error)
+	LeaseRevoke(ctx context.Context, in *LeaseRevokeRequest, opts ...grpc.CallOption) (*LeaseRevokeResponse, error)
+	LeaseTimeToLive(ctx context.Context, in *LeaseTimeToLiveRequest, opts ...grpc.CallOption) (*LeaseTimeToLiveResponse, error)
+	// 限流：按 Key 原子地判定请求是否放行（GCRA）
+	Throttle(ctx context.Context, in *ThrottleRequest, opts ...grpc.CallOption) (*ThrottleResponse, error)
+	// 脚本：原子地执行多步逻辑，只能访问声明的 Key
+	Eval(ctx context.Context, in *EvalRequest, opts ...grpc.CallOption) (*EvalResponse, error)
+	EvalSha(ctx context.Context, in *EvalShaRequest, opts ...grpc.CallOption) (*EvalResponse, error)
+	ScriptLoad(ctx context.Context, in *ScriptLoadRequest, opts ...grpc.CallOption) (*ScriptLoadResponse, error)
+	ScriptExists(ctx context.Context, in *ScriptExistsRequest, opts ...grpc.CallOption) (*ScriptExistsResponse, error)
+	ScriptFlush(ctx context.Context, in *ScriptFlushRequest, opts ...grpc.CallOption) (*ScriptFlushResponse, error)
+	// 管理接口：节点统计信息
+	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+	// 管理接口：热点 Key / 大 Key 报告
+	HotKeys(ctx context.Context, in *KeyReportRequest, opts ...grpc.CallOption) (*KeyReportResponse, error)
+	BigKeys(ctx context.Context, in *KeyReportRequest, opts ...grpc.CallOption) (*KeyReportResponse, error)
+	// 管理接口：慢日志与内部事件耗时
+	SlowLogGet(ctx context.Context, in *SlowLogRequest, opts ...grpc.CallOption) (*SlowLogResponse, error)
+	SlowLogReset(ctx context.Context, in *SlowLogResetRequest, opts ...grpc.CallOption) (*SlowLogResetResponse, error)
+	Latency(ctx context.Context, in *LatencyRequest, opts ...grpc.CallOption) (*LatencyResponse, error)
+	// 管理接口：实时推送节点执行的每一条命令
+	Monitor(ctx context.Context, in *MonitorRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MonitorEvent], error)
+	// 管理接口：运行时修改 ACL 用户，只作用于当前节点，网关负责广播
+	AclSetUser(ctx context.Context, in *AclSetUserRequest, opts ...grpc.CallOption) (*AclSetUserResponse, error)
+	AclDelUser(ctx context.Context, in *AclDelUserRequest, opts ...grpc.CallOption) (*AclDelUserResponse, error)
+	AclList(ctx context.Context, in *AclListRequest, opts ...grpc.CallOption) (*AclListResponse, error)
 }
 
 type kVServiceClient struct {
@@ -74,6 +224,708 @@ func (c *kVServiceClient) Del(ctx context.Context, in *DelRequest, opts ...grpc.
 	return out, nil
 }
 
+func (c *kVServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KVService_ServiceDesc.Streams[0], KVService_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVService_WatchClient = grpc.ServerStreamingClient[WatchEvent]
+
+func (c *kVServiceClient) Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishResponse)
+	err := c.cc.Invoke(ctx, KVService_Publish_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) PubSub(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PubSubRequest, PubSubMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KVService_ServiceDesc.Streams[1], KVService_PubSub_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PubSubRequest, PubSubMessage]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVService_PubSubClient = grpc.BidiStreamingClient[PubSubRequest, PubSubMessage]
+
+func (c *kVServiceClient) XAdd(ctx context.Context, in *XAddRequest, opts ...grpc.CallOption) (*XAddResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(XAddResponse)
+	err := c.cc.Invoke(ctx, KVService_XAdd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) XRange(ctx context.Context, in *XRangeRequest, opts ...grpc.CallOption) (*XRangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(XRangeResponse)
+	err := c.cc.Invoke(ctx, KVService_XRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) XTrim(ctx context.Context, in *XTrimRequest, opts ...grpc.CallOption) (*XTrimResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(XTrimResponse)
+	err := c.cc.Invoke(ctx, KVService_XTrim_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) XRead(ctx context.Context, in *XReadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[XReadResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KVService_ServiceDesc.Streams[2], KVService_XRead_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[XReadRequest, XReadResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVService_XReadClient = grpc.ServerStreamingClient[XReadResponse]
+
+func (c *kVServiceClient) XGroupCreate(ctx context.Context, in *XGroupRequest, opts ...grpc.CallOption) (*XGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(XGroupResponse)
+	err := c.cc.Invoke(ctx, KVService_XGroupCreate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) XGroupDestroy(ctx context.Context, in *XGroupRequest, opts ...grpc.CallOption) (*XGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(XGroupResponse)
+	err := c.cc.Invoke(ctx, KVService_XGroupDestroy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) XReadGroup(ctx context.Context, in *XReadGroupRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[XReadResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KVService_ServiceDesc.Streams[3], KVService_XReadGroup_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[XReadGroupRequest, XReadResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVService_XReadGroupClient = grpc.ServerStreamingClient[XReadResponse]
+
+func (c *kVServiceClient) XAck(ctx context.Context, in *XAckRequest, opts ...grpc.CallOption) (*XAckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(XAckResponse)
+	err := c.cc.Invoke(ctx, KVService_XAck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) XPending(ctx context.Context, in *XPendingRequest, opts ...grpc.CallOption) (*XPendingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(XPendingResponse)
+	err := c.cc.Invoke(ctx, KVService_XPending_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) XClaim(ctx context.Context, in *XClaimRequest, opts ...grpc.CallOption) (*XClaimResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(XClaimResponse)
+	err := c.cc.Invoke(ctx, KVService_XClaim_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) PFAdd(ctx context.Context, in *PFAddRequest, opts ...grpc.CallOption) (*PFAddResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PFAddResponse)
+	err := c.cc.Invoke(ctx, KVService_PFAdd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) PFCount(ctx context.Context, in *PFCountRequest, opts ...grpc.CallOption) (*PFCountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PFCountResponse)
+	err := c.cc.Invoke(ctx, KVService_PFCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) PFMerge(ctx context.Context, in *PFMergeRequest, opts ...grpc.CallOption) (*PFMergeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PFMergeResponse)
+	err := c.cc.Invoke(ctx, KVService_PFMerge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) BFReserve(ctx context.Context, in *BFReserveRequest, opts ...grpc.CallOption) (*BFReserveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BFReserveResponse)
+	err := c.cc.Invoke(ctx, KVService_BFReserve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) BFAdd(ctx context.Context, in *BFItemsRequest, opts ...grpc.CallOption) (*BFItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BFItemsResponse)
+	err := c.cc.Invoke(ctx, KVService_BFAdd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) BFExists(ctx context.Context, in *BFItemsRequest, opts ...grpc.CallOption) (*BFItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BFItemsResponse)
+	err := c.cc.Invoke(ctx, KVService_BFExists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) CMSInit(ctx context.Context, in *CMSInitRequest, opts ...grpc.CallOption) (*CMSInitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CMSInitResponse)
+	err := c.cc.Invoke(ctx, KVService_CMSInit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) CMSIncrBy(ctx context.Context, in *CMSIncrByRequest, opts ...grpc.CallOption) (*CMSCountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CMSCountsResponse)
+	err := c.cc.Invoke(ctx, KVService_CMSIncrBy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) CMSQuery(ctx context.Context, in *CMSQueryRequest, opts ...grpc.CallOption) (*CMSCountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CMSCountsResponse)
+	err := c.cc.Invoke(ctx, KVService_CMSQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) GeoAdd(ctx context.Context, in *GeoAddRequest, opts ...grpc.CallOption) (*GeoAddResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GeoAddResponse)
+	err := c.cc.Invoke(ctx, KVService_GeoAdd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) GeoPos(ctx context.Context, in *GeoPosRequest, opts ...grpc.CallOption) (*GeoPosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GeoPosResponse)
+	err := c.cc.Invoke(ctx, KVService_GeoPos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) GeoDist(ctx context.Context, in *GeoDistRequest, opts ...grpc.CallOption) (*GeoDistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GeoDistResponse)
+	err := c.cc.Invoke(ctx, KVService_GeoDist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) GeoSearch(ctx context.Context, in *GeoSearchRequest, opts ...grpc.CallOption) (*GeoSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GeoSearchResponse)
+	err := c.cc.Invoke(ctx, KVService_GeoSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) TSCreate(ctx context.Context, in *TSCreateRequest, opts ...grpc.CallOption) (*TSCreateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TSCreateResponse)
+	err := c.cc.Invoke(ctx, KVService_TSCreate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) TSAdd(ctx context.Context, in *TSAddRequest, opts ...grpc.CallOption) (*TSAddResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TSAddResponse)
+	err := c.cc.Invoke(ctx, KVService_TSAdd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) TSGet(ctx context.Context, in *TSGetRequest, opts ...grpc.CallOption) (*TSGetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TSGetResponse)
+	err := c.cc.Invoke(ctx, KVService_TSGet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) TSRange(ctx context.Context, in *TSRangeRequest, opts ...grpc.CallOption) (*TSRangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TSRangeResponse)
+	err := c.cc.Invoke(ctx, KVService_TSRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) TSMRange(ctx context.Context, in *TSMRangeRequest, opts ...grpc.CallOption) (*TSMRangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TSMRangeResponse)
+	err := c.cc.Invoke(ctx, KVService_TSMRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) TSCreateRule(ctx context.Context, in *TSRuleRequest, opts ...grpc.CallOption) (*TSRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TSRuleResponse)
+	err := c.cc.Invoke(ctx, KVService_TSCreateRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) TSDeleteRule(ctx context.Context, in *TSRuleRequest, opts ...grpc.CallOption) (*TSRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TSRuleResponse)
+	err := c.cc.Invoke(ctx, KVService_TSDeleteRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) TSInfo(ctx context.Context, in *TSInfoRequest, opts ...grpc.CallOption) (*TSInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TSInfoResponse)
+	err := c.cc.Invoke(ctx, KVService_TSInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) JSONSet(ctx context.Context, in *JSONSetRequest, opts ...grpc.CallOption) (*JSONSetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JSONSetResponse)
+	err := c.cc.Invoke(ctx, KVService_JSONSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) JSONGet(ctx context.Context, in *JSONGetRequest, opts ...grpc.CallOption) (*JSONGetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JSONGetResponse)
+	err := c.cc.Invoke(ctx, KVService_JSONGet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) JSONDel(ctx context.Context, in *JSONDelRequest, opts ...grpc.CallOption) (*JSONDelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JSONDelResponse)
+	err := c.cc.Invoke(ctx, KVService_JSONDel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) JSONArrAppend(ctx context.Context, in *JSONArrAppendRequest, opts ...grpc.CallOption) (*JSONArrAppendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JSONArrAppendResponse)
+	err := c.cc.Invoke(ctx, KVService_JSONArrAppend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) JSONNumIncrBy(ctx context.Context, in *JSONNumIncrByRequest, opts ...grpc.CallOption) (*JSONNumIncrByResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JSONNumIncrByResponse)
+	err := c.cc.Invoke(ctx, KVService_JSONNumIncrBy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) JSONCreateIndex(ctx context.Context, in *JSONIndexRequest, opts ...grpc.CallOption) (*JSONIndexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JSONIndexResponse)
+	err := c.cc.Invoke(ctx, KVService_JSONCreateIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) JSONDropIndex(ctx context.Context, in *JSONIndexRequest, opts ...grpc.CallOption) (*JSONIndexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JSONIndexResponse)
+	err := c.cc.Invoke(ctx, KVService_JSONDropIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) JSONListIndexes(ctx context.Context, in *JSONListIndexesRequest, opts ...grpc.CallOption) (*JSONListIndexesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JSONListIndexesResponse)
+	err := c.cc.Invoke(ctx, KVService_JSONListIndexes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) Find(ctx context.Context, in *FindRequest, opts ...grpc.CallOption) (*FindResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindResponse)
+	err := c.cc.Invoke(ctx, KVService_Find_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) LockAcquire(ctx context.Context, in *LockAcquireRequest, opts ...grpc.CallOption) (*LockAcquireResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LockAcquireResponse)
+	err := c.cc.Invoke(ctx, KVService_LockAcquire_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) LockRenew(ctx context.Context, in *LockRenewRequest, opts ...grpc.CallOption) (*LockRenewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LockRenewResponse)
+	err := c.cc.Invoke(ctx, KVService_LockRenew_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) LockRelease(ctx context.Context, in *LockReleaseRequest, opts ...grpc.CallOption) (*LockReleaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LockReleaseResponse)
+	err := c.cc.Invoke(ctx, KVService_LockRelease_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) LeaseGrant(ctx context.Context, in *LeaseGrantRequest, opts ...grpc.CallOption) (*LeaseGrantResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaseGrantResponse)
+	err := c.cc.Invoke(ctx, KVService_LeaseGrant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) LeaseKeepAlive(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[LeaseKeepAliveRequest, LeaseKeepAliveResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KVService_ServiceDesc.Streams[4], KVService_LeaseKeepAlive_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LeaseKeepAliveRequest, LeaseKeepAliveResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVService_LeaseKeepAliveClient = grpc.BidiStreamingClient[LeaseKeepAliveRequest, LeaseKeepAliveResponse]
+
+func (c *kVServiceClient) LeaseRevoke(ctx context.Context, in *LeaseRevokeRequest, opts ...grpc.CallOption) (*LeaseRevokeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaseRevokeResponse)
+	err := c.cc.Invoke(ctx, KVService_LeaseRevoke_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) LeaseTimeToLive(ctx context.Context, in *LeaseTimeToLiveRequest, opts ...grpc.CallOption) (*LeaseTimeToLiveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaseTimeToLiveResponse)
+	err := c.cc.Invoke(ctx, KVService_LeaseTimeToLive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) Throttle(ctx context.Context, in *ThrottleRequest, opts ...grpc.CallOption) (*ThrottleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ThrottleResponse)
+	err := c.cc.Invoke(ctx, KVService_Throttle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) Eval(ctx context.Context, in *EvalRequest, opts ...grpc.CallOption) (*EvalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvalResponse)
+	err := c.cc.Invoke(ctx, KVService_Eval_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) EvalSha(ctx context.Context, in *EvalShaRequest, opts ...grpc.CallOption) (*EvalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvalResponse)
+	err := c.cc.Invoke(ctx, KVService_EvalSha_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) ScriptLoad(ctx context.Context, in *ScriptLoadRequest, opts ...grpc.CallOption) (*ScriptLoadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScriptLoadResponse)
+	err := c.cc.Invoke(ctx, KVService_ScriptLoad_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) ScriptExists(ctx context.Context, in *ScriptExistsRequest, opts ...grpc.CallOption) (*ScriptExistsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScriptExistsResponse)
+	err := c.cc.Invoke(ctx, KVService_ScriptExists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) ScriptFlush(ctx context.Context, in *ScriptFlushRequest, opts ...grpc.CallOption) (*ScriptFlushResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScriptFlushResponse)
+	err := c.cc.Invoke(ctx, KVService_ScriptFlush_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InfoResponse)
+	err := c.cc.Invoke(ctx, KVService_Info_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) HotKeys(ctx context.Context, in *KeyReportRequest, opts ...grpc.CallOption) (*KeyReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyReportResponse)
+	err := c.cc.Invoke(ctx, KVService_HotKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) BigKeys(ctx context.Context, in *KeyReportRequest, opts ...grpc.CallOption) (*KeyReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyReportResponse)
+	err := c.cc.Invoke(ctx, KVService_BigKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) SlowLogGet(ctx context.Context, in *SlowLogRequest, opts ...grpc.CallOption) (*SlowLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SlowLogResponse)
+	err := c.cc.Invoke(ctx, KVService_SlowLogGet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) SlowLogReset(ctx context.Context, in *SlowLogResetRequest, opts ...grpc.CallOption) (*SlowLogResetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SlowLogResetResponse)
+	err := c.cc.Invoke(ctx, KVService_SlowLogReset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) Latency(ctx context.Context, in *LatencyRequest, opts ...grpc.CallOption) (*LatencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LatencyResponse)
+	err := c.cc.Invoke(ctx, KVService_Latency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) Monitor(ctx context.Context, in *MonitorRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MonitorEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KVService_ServiceDesc.Streams[5], KVService_Monitor_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[MonitorRequest, MonitorEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVService_MonitorClient = grpc.ServerStreamingClient[MonitorEvent]
+
+func (c *kVServiceClient) AclSetUser(ctx context.Context, in *AclSetUserRequest, opts ...grpc.CallOption) (*AclSetUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AclSetUserResponse)
+	err := c.cc.Invoke(ctx, KVService_AclSetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) AclDelUser(ctx context.Context, in *AclDelUserRequest, opts ...grpc.CallOption) (*AclDelUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AclDelUserResponse)
+	err := c.cc.Invoke(ctx, KVService_AclDelUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVServiceClient) AclList(ctx context.Context, in *AclListRequest, opts ...grpc.CallOption) (*AclListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AclListResponse)
+	err := c.cc.Invoke(ctx, KVService_AclList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KVServiceServer is the server API for KVService service.
 // All implementations must embed UnimplementedKVServiceServer
 // for forward compatibility.
@@ -84,96 +936,1516 @@ type KVServiceServer interface {
 	Set(context.Context, *SetRequest) (*SetResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	Del(context.Context, *DelRequest) (*DelResponse, error)
+	// 订阅 Key / 前缀的变更，支持从指定 revision 断点续订
+	Watch(*WatchRequest, grpc.ServerStreamingServer[WatchEvent]) error
+	// 发布/订阅：Publish 为单次发布，PubSub 为双向流（同一条流上订阅、退订和接收消息）
+	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
+	PubSub(grpc.BidiStreamingServer[PubSubRequest, PubSubMessage]) error
+	// Stream：只追加的消息日志和消费者组
+	XAdd(context.Context, *XAddRequest) (*XAddResponse, error)
+	XRange(context.Context, *XRangeRequest) (*XRangeResponse, error)
+	XTrim(context.Context, *XTrimRequest) (*XTrimResponse, error)
+	// XRead 持续推送新消息（相当于循环执行阻塞的 XREAD），直到客户端取消
+	XRead(*XReadRequest, grpc.ServerStreamingServer[XReadResponse]) error
+	XGroupCreate(context.Context, *XGroupRequest) (*XGroupResponse, error)
+	XGroupDestroy(context.Context, *XGroupRequest) (*XGroupResponse, error)
+	// XReadGroup 持续以消费者身份领取组内的新消息，处理完后通过 XAck 确认
+	XReadGroup(*XReadGroupRequest, grpc.ServerStreamingServer[XReadResponse]) error
+	XAck(context.Context, *XAckRequest) (*XAckResponse, error)
+	XPending(context.Context, *XPendingRequest) (*XPendingResponse, error)
+	XClaim(context.Context, *XClaimRequest) (*XClaimResponse, error)
+	// 概率数据结构：HyperLogLog / 布隆过滤器 / Count-Min Sketch
+	PFAdd(context.Context, *PFAddRequest) (*PFAddResponse, error)
+	PFCount(context.Context, *PFCountRequest) (*PFCountResponse, error)
+	PFMerge(context.Context, *PFMergeRequest) (*PFMergeResponse, error)
+	BFReserve(context.Context, *BFReserveRequest) (*BFReserveResponse, error)
+	BFAdd(context.Context, *BFItemsRequest) (*BFItemsResponse, error)
+	BFExists(context.Context, *BFItemsRequest) (*BFItemsResponse, error)
+	CMSInit(context.Context, *CMSInitRequest) (*CMSInitResponse, error)
+	CMSIncrBy(context.Context, *CMSIncrByRequest) (*CMSCountsResponse, error)
+	CMSQuery(context.Context, *CMSQueryRequest) (*CMSCountsResponse, error)
+	// 地理位置：以 geohash 为分数的有序集合
+	GeoAdd(context.Context, *GeoAddRequest) (*GeoAddResponse, error)
+	GeoPos(context.Context, *GeoPosRequest) (*GeoPosResponse, error)
+	GeoDist(context.Context, *GeoDistRequest) (*GeoDistResponse, error)
+	GeoSearch(context.Context, *GeoSearchRequest) (*GeoSearchResponse, error)
+	// 时间序列：Gorilla 压缩存储，支持按标签批量查询和降采样规则
+	TSCreate(context.Context, *TSCreateRequest) (*TSCreateResponse, error)
+	TSAdd(context.Context, *TSAddRequest) (*TSAddResponse, error)
+	TSGet(context.Context, *TSGetRequest) (*TSGetResponse, error)
+	TSRange(context.Context, *TSRangeRequest) (*TSRangeResponse, error)
+	TSMRange(context.Context, *TSMRangeRequest) (*TSMRangeResponse, error)
+	TSCreateRule(context.Context, *TSRuleRequest) (*TSRuleResponse, error)
+	TSDeleteRule(context.Context, *TSRuleRequest) (*TSRuleResponse, error)
+	TSInfo(context.Context, *TSInfoRequest) (*TSInfoResponse, error)
+	// JSON 文档：按路径原子读写，命名空间内的字段可建二级索引
+	JSONSet(context.Context, *JSONSetRequest) (*JSONSetResponse, error)
+	JSONGet(context.Context, *JSONGetRequest) (*JSONGetResponse, error)
+	JSONDel(context.Context, *JSONDelRequest) (*JSONDelResponse, error)
+	JSONArrAppend(context.Context, *JSONArrAppendRequest) (*JSONArrAppendResponse, error)
+	JSONNumIncrBy(context.Context, *JSONNumIncrByRequest) (*JSONNumIncrByResponse, error)
+	JSONCreateIndex(context.Context, *JSONIndexRequest) (*JSONIndexResponse, error)
+	JSONDropIndex(context.Context, *JSONIndexRequest) (*JSONIndexResponse, error)
+	JSONListIndexes(context.Context, *JSONListIndexesRequest) (*JSONListIndexesResponse, error)
+	Find(context.Context, *FindRequest) (*FindResponse, error)
+	// 分布式锁：带租期和 fencing token，支持阻塞排队
+	LockAcquire(context.Context, *LockAcquireRequest) (*LockAcquireResponse, error)
+	LockRenew(context.Context, *LockRenewRequest) (*LockRenewResponse, error)
+	LockRelease(context.Context, *LockReleaseRequest) (*LockReleaseResponse, error)
+	// 租约：多个 Key 绑定到同一个 TTL，一起过期或撤销
+	LeaseGrant(context.Context, *LeaseGrantRequest) (*LeaseGrantResponse, error)
+	LeaseKeepAlive(grpc.BidiStreamingServer[LeaseKeepAliveRequest, LeaseKeepAliveResponse]) error
+	LeaseRevoke(context.Context, *LeaseRevokeRequest) (*LeaseRevokeResponse, error)
+	LeaseTimeToLive(context.Context, *LeaseTimeToLiveRequest) (*LeaseTimeToLiveResponse, error)
+	// 限流：按 Key 原子地判定请求是否放行（GCRA）
+	Throttle(context.Context, *ThrottleRequest) (*ThrottleResponse, error)
+	// 脚本：原子地执行多步逻辑，只能访问声明的 Key
+	Eval(context.Context, *EvalRequest) (*EvalResponse, error)
+	EvalSha(context.Context, *EvalShaRequest) (*EvalResponse, error)
+	ScriptLoad(context.Context, *ScriptLoadRequest) (*ScriptLoadResponse, error)
+	ScriptExists(context.Context, *ScriptExistsRequest) (*ScriptExistsResponse, error)
+	ScriptFlush(context.Context, *ScriptFlushRequest) (*ScriptFlushResponse, error)
+	// 管理接口：节点统计信息
+	Info(context.Context, *InfoRequest) (*InfoResponse, error)
+	// 管理接口：热点 Key / 大 Key 报告
+	HotKeys(context.Context, *KeyReportRequest) (*KeyReportResponse, error)
+	BigKeys(context.Context, *KeyReportRequest) (*KeyReportResponse, error)
+	// 管理接口：慢日志与内部事件耗时
+	SlowLogGet(context.Context, *SlowLogRequest) (*SlowLogResponse, error)
+	SlowLogReset(context.Context, *SlowLogResetRequest) (*SlowLogResetResponse, error)
+	Latency(context.Context, *LatencyRequest) (*LatencyResponse, error)
+	// 管理接口：实时推送节点执行的每一条命令
+	Monitor(*MonitorRequest, grpc.ServerStreamingServer[MonitorEvent]) error
+	// 管理接口：运行时修改 ACL 用户，只作用于当前节点，网关负责广播
+	AclSetUser(context.Context, *AclSetUserRequest) (*AclSetUserResponse, error)
+	AclDelUser(context.Context, *AclDelUserRequest) (*AclDelUserResponse, error)
+	AclList(context.Context, *AclListRequest) (*AclListResponse, error)
+	mustEmbedUnimplementedKVServiceServer()
+}
+
+// UnimplementedKVServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKVServiceServer struct{}
+
+func (UnimplementedKVServiceServer) Set(context.Context, *SetRequest) (*SetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Set not implemented")
+}
+func (UnimplementedKVServiceServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedKVServiceServer) Del(context.Context, *DelRequest) (*DelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Del not implemented")
+}
+func (UnimplementedKVServiceServer) Watch(*WatchRequest, grpc.ServerStreamingServer[WatchEvent]) error {
+	return status.Error(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedKVServiceServer) Publish(context.Context, *PublishRequest) (*PublishResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Publish not implemented")
+}
+func (UnimplementedKVServiceServer) PubSub(grpc.BidiStreamingServer[PubSubRequest, PubSubMessage]) error {
+	return status.Error(codes.Unimplemented, "method PubSub not implemented")
+}
+func (UnimplementedKVServiceServer) XAdd(context.Context, *XAddRequest) (*XAddResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method XAdd not implemented")
+}
+func (UnimplementedKVServiceServer) XRange(context.Context, *XRangeRequest) (*XRangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method XRange not implemented")
+}
+func (UnimplementedKVServiceServer) XTrim(context.Context, *XTrimRequest) (*XTrimResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method XTrim not implemented")
+}
+func (UnimplementedKVServiceServer) XRead(*XReadRequest, grpc.ServerStreamingServer[XReadResponse]) error {
+	return status.Error(codes.Unimplemented, "method XRead not implemented")
+}
+func (UnimplementedKVServiceServer) XGroupCreate(context.Context, *XGroupRequest) (*XGroupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method XGroupCreate not implemented")
+}
+func (UnimplementedKVServiceServer) XGroupDestroy(context.Context, *XGroupRequest) (*XGroupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method XGroupDestroy not implemented")
+}
+func (UnimplementedKVServiceServer) XReadGroup(*XReadGroupRequest, grpc.ServerStreamingServer[XReadResponse]) error {
+	return status.Error(codes.Unimplemented, "method XReadGroup not implemented")
+}
+func (UnimplementedKVServiceServer) XAck(context.Context, *XAckRequest) (*XAckResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method XAck not implemented")
+}
+func (UnimplementedKVServiceServer) XPending(context.Context, *XPendingRequest) (*XPendingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method XPending not implemented")
+}
+func (UnimplementedKVServiceServer) XClaim(context.Context, *XClaimRequest) (*XClaimResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method XClaim not implemented")
+}
+func (UnimplementedKVServiceServer) PFAdd(context.Context, *PFAddRequest) (*PFAddResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PFAdd not implemented")
+}
+func (UnimplementedKVServiceServer) PFCount(context.Context, *PFCountRequest) (*PFCountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PFCount not implemented")
+}
+func (UnimplementedKVServiceServer) PFMerge(context.Context, *PFMergeRequest) (*PFMergeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PFMerge not implemented")
+}
+func (UnimplementedKVServiceServer) BFReserve(context.Context, *BFReserveRequest) (*BFReserveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BFReserve not implemented")
+}
+func (UnimplementedKVServiceServer) BFAdd(context.Context, *BFItemsRequest) (*BFItemsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BFAdd not implemented")
+}
+func (UnimplementedKVServiceServer) BFExists(context.Context, *BFItemsRequest) (*BFItemsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BFExists not implemented")
+}
+func (UnimplementedKVServiceServer) CMSInit(context.Context, *CMSInitRequest) (*CMSInitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CMSInit not implemented")
+}
+func (UnimplementedKVServiceServer) CMSIncrBy(context.Context, *CMSIncrByRequest) (*CMSCountsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CMSIncrBy not implemented")
+}
+func (UnimplementedKVServiceServer) CMSQuery(context.Context, *CMSQueryRequest) (*CMSCountsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CMSQuery not implemented")
+}
+func (UnimplementedKVServiceServer) GeoAdd(context.Context, *GeoAddRequest) (*GeoAddResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GeoAdd not implemented")
+}
+func (UnimplementedKVServiceServer) GeoPos(context.Context, *GeoPosRequest) (*GeoPosResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GeoPos not implemented")
+}
+func (UnimplementedKVServiceServer) GeoDist(context.Context, *GeoDistRequest) (*GeoDistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GeoDist not implemented")
+}
+func (UnimplementedKVServiceServer) GeoSearch(context.Context, *GeoSearchRequest) (*GeoSearchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GeoSearch not implemented")
+}
+func (UnimplementedKVServiceServer) TSCreate(context.Context, *TSCreateRequest) (*TSCreateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TSCreate not implemented")
+}
+func (UnimplementedKVServiceServer) TSAdd(context.Context, *TSAddRequest) (*TSAddResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TSAdd not implemented")
+}
+func (UnimplementedKVServiceServer) TSGet(context.Context, *TSGetRequest) (*TSGetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TSGet not implemented")
+}
+func (UnimplementedKVServiceServer) TSRange(context.Context, *TSRangeRequest) (*TSRangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TSRange not implemented")
+}
+func (UnimplementedKVServiceServer) TSMRange(context.Context, *TSMRangeRequest) (*TSMRangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TSMRange not implemented")
+}
+func (UnimplementedKVServiceServer) TSCreateRule(context.Context, *TSRuleRequest) (*TSRuleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TSCreateRule not implemented")
+}
+func (UnimplementedKVServiceServer) TSDeleteRule(context.Context, *TSRuleRequest) (*TSRuleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TSDeleteRule not implemented")
+}
+func (UnimplementedKVServiceServer) TSInfo(context.Context, *TSInfoRequest) (*TSInfoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TSInfo not implemented")
+}
+func (UnimplementedKVServiceServer) JSONSet(context.Context, *JSONSetRequest) (*JSONSetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method JSONSet not implemented")
+}
+func (UnimplementedKVServiceServer) JSONGet(context.Context, *JSONGetRequest) (*JSONGetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method JSONGet not implemented")
+}
+func (UnimplementedKVServiceServer) JSONDel(context.Context, *JSONDelRequest) (*JSONDelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method JSONDel not implemented")
+}
+func (UnimplementedKVServiceServer) JSONArrAppend(context.Context, *JSONArrAppendRequest) (*JSONArrAppendResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method JSONArrAppend not implemented")
+}
+func (UnimplementedKVServiceServer) JSONNumIncrBy(context.Context, *JSONNumIncrByRequest) (*JSONNumIncrByResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method JSONNumIncrBy not implemented")
+}
+func (UnimplementedKVServiceServer) JSONCreateIndex(context.Context, *JSONIndexRequest) (*JSONIndexResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method JSONCreateIndex not implemented")
+}
+func (UnimplementedKVServiceServer) JSONDropIndex(context.Context, *JSONIndexRequest) (*JSONIndexResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method JSONDropIndex not implemented")
+}
+func (UnimplementedKVServiceServer) JSONListIndexes(context.Context, *JSONListIndexesRequest) (*JSONListIndexesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method JSONListIndexes not implemented")
+}
+func (UnimplementedKVServiceServer) Find(context.Context, *FindRequest) (*FindResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Find not implemented")
+}
+func (UnimplementedKVServiceServer) LockAcquire(context.Context, *LockAcquireRequest) (*LockAcquireResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LockAcquire not implemented")
+}
+func (UnimplementedKVServiceServer) LockRenew(context.Context, *LockRenewRequest) (*LockRenewResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LockRenew not implemented")
+}
+func (UnimplementedKVServiceServer) LockRelease(context.Context, *LockReleaseRequest) (*LockReleaseResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LockRelease not implemented")
+}
+func (UnimplementedKVServiceServer) LeaseGrant(context.Context, *LeaseGrantRequest) (*LeaseGrantResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LeaseGrant not implemented")
+}
+func (UnimplementedKVServiceServer) LeaseKeepAlive(grpc.BidiStreamingServer[LeaseKeepAliveRequest, LeaseKeepAliveResponse]) error {
+	return status.Error(codes.Unimplemented, "method LeaseKeepAlive not implemented")
+}
+func (UnimplementedKVServiceServer) LeaseRevoke(context.Context, *LeaseRevokeRequest) (*LeaseRevokeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LeaseRevoke not implemented")
+}
+func (UnimplementedKVServiceServer) LeaseTimeToLive(context.Context, *LeaseTimeToLiveRequest) (*LeaseTimeToLiveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LeaseTimeToLive not implemented")
+}
+func (UnimplementedKVServiceServer) Throttle(context.Context, *ThrottleRequest) (*ThrottleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Throttle not implemented")
+}
+func (UnimplementedKVServiceServer) Eval(context.Context, *EvalRequest) (*EvalResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Eval not implemented")
+}
+func (UnimplementedKVServiceServer) EvalSha(context.Context, *EvalShaRequest) (*EvalResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EvalSha not implemented")
+}
+func (UnimplementedKVServiceServer) ScriptLoad(context.Context, *ScriptLoadRequest) (*ScriptLoadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ScriptLoad not implemented")
+}
+func (UnimplementedKVServiceServer) ScriptExists(context.Context, *ScriptExistsRequest) (*ScriptExistsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ScriptExists not implemented")
+}
+func (UnimplementedKVServiceServer) ScriptFlush(context.Context, *ScriptFlushRequest) (*ScriptFlushResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ScriptFlush not implemented")
+}
+func (UnimplementedKVServiceServer) Info(context.Context, *InfoRequest) (*InfoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Info not implemented")
+}
+func (UnimplementedKVServiceServer) HotKeys(context.Context, *KeyReportRequest) (*KeyReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method HotKeys not implemented")
+}
+func (UnimplementedKVServiceServer) BigKeys(context.Context, *KeyReportRequest) (*KeyReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BigKeys not implemented")
+}
+func (UnimplementedKVServiceServer) SlowLogGet(context.Context, *SlowLogRequest) (*SlowLogResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SlowLogGet not implemented")
+}
+func (UnimplementedKVServiceServer) SlowLogReset(context.Context, *SlowLogResetRequest) (*SlowLogResetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SlowLogReset not implemented")
+}
+func (UnimplementedKVServiceServer) Latency(context.Context, *LatencyRequest) (*LatencyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Latency not implemented")
+}
+func (UnimplementedKVServiceServer) Monitor(*MonitorRequest, grpc.ServerStreamingServer[MonitorEvent]) error {
+	return status.Error(codes.Unimplemented, "method Monitor not implemented")
+}
+func (UnimplementedKVServiceServer) AclSetUser(context.Context, *AclSetUserRequest) (*AclSetUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AclSetUser not implemented")
+}
+func (UnimplementedKVServiceServer) AclDelUser(context.Context, *AclDelUserRequest) (*AclDelUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AclDelUser not implemented")
+}
+func (UnimplementedKVServiceServer) AclList(context.Context, *AclListRequest) (*AclListResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AclList not implemented")
+}
+func (UnimplementedKVServiceServer) mustEmbedUnimplementedKVServiceServer() {}
+func (UnimplementedKVServiceServer) testEmbeddedByValue()                   {}
+
+// UnsafeKVServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KVServiceServer will
+// result in compilation errors.
+type UnsafeKVServiceServer interface {
 	mustEmbedUnimplementedKVServiceServer()
 }
 
-// UnimplementedKVServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedKVServiceServer struct{}
+func RegisterKVServiceServer(s grpc.ServiceRegistrar, srv KVServiceServer) {
+	// If the following call panics, it indicates UnimplementedKVServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&KVService_ServiceDesc, srv)
+}
+
+func _KVService_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).Set(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_Set_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).Set(ctx, req.(*SetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_Del_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).Del(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_Del_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).Del(ctx, req.(*DelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KVServiceServer).Watch(m, &grpc.GenericServerStream[WatchRequest, WatchEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVService_WatchServer = grpc.ServerStreamingServer[WatchEvent]
+
+func _KVService_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_Publish_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).Publish(ctx, req.(*PublishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_PubSub_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(KVServiceServer).PubSub(&grpc.GenericServerStream[PubSubRequest, PubSubMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVService_PubSubServer = grpc.BidiStreamingServer[PubSubRequest, PubSubMessage]
+
+func _KVService_XAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(XAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).XAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_XAdd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).XAdd(ctx, req.(*XAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_XRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(XRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).XRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_XRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).XRange(ctx, req.(*XRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_XTrim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(XTrimRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).XTrim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_XTrim_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).XTrim(ctx, req.(*XTrimRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_XRead_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(XReadRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KVServiceServer).XRead(m, &grpc.GenericServerStream[XReadRequest, XReadResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVService_XReadServer = grpc.ServerStreamingServer[XReadResponse]
+
+func _KVService_XGroupCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(XGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).XGroupCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_XGroupCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).XGroupCreate(ctx, req.(*XGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_XGroupDestroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(XGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).XGroupDestroy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_XGroupDestroy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).XGroupDestroy(ctx, req.(*XGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_XReadGroup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(XReadGroupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KVServiceServer).XReadGroup(m, &grpc.GenericServerStream[XReadGroupRequest, XReadResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVService_XReadGroupServer = grpc.ServerStreamingServer[XReadResponse]
+
+func _KVService_XAck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(XAckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).XAck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_XAck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).XAck(ctx, req.(*XAckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_XPending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(XPendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).XPending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_XPending_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).XPending(ctx, req.(*XPendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_XClaim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(XClaimRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).XClaim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_XClaim_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).XClaim(ctx, req.(*XClaimRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_PFAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PFAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).PFAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_PFAdd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).PFAdd(ctx, req.(*PFAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_PFCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PFCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).PFCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_PFCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).PFCount(ctx, req.(*PFCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_PFMerge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PFMergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).PFMerge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_PFMerge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).PFMerge(ctx, req.(*PFMergeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_BFReserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BFReserveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).BFReserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_BFReserve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).BFReserve(ctx, req.(*BFReserveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_BFAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BFItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).BFAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_BFAdd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).BFAdd(ctx, req.(*BFItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_BFExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BFItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).BFExists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_BFExists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).BFExists(ctx, req.(*BFItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_CMSInit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CMSInitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).CMSInit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_CMSInit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).CMSInit(ctx, req.(*CMSInitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_CMSIncrBy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CMSIncrByRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).CMSIncrBy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_CMSIncrBy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).CMSIncrBy(ctx, req.(*CMSIncrByRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_CMSQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CMSQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).CMSQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_CMSQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).CMSQuery(ctx, req.(*CMSQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_GeoAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeoAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).GeoAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_GeoAdd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).GeoAdd(ctx, req.(*GeoAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_GeoPos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeoPosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).GeoPos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_GeoPos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).GeoPos(ctx, req.(*GeoPosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_GeoDist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeoDistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).GeoDist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_GeoDist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).GeoDist(ctx, req.(*GeoDistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_GeoSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeoSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).GeoSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_GeoSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).GeoSearch(ctx, req.(*GeoSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_TSCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TSCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).TSCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_TSCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).TSCreate(ctx, req.(*TSCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_TSAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TSAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).TSAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_TSAdd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).TSAdd(ctx, req.(*TSAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_TSGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TSGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).TSGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_TSGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).TSGet(ctx, req.(*TSGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_TSRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TSRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).TSRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_TSRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).TSRange(ctx, req.(*TSRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_TSMRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TSMRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).TSMRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_TSMRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).TSMRange(ctx, req.(*TSMRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_TSCreateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TSRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).TSCreateRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_TSCreateRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).TSCreateRule(ctx, req.(*TSRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_TSDeleteRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TSRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).TSDeleteRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_TSDeleteRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).TSDeleteRule(ctx, req.(*TSRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_TSInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TSInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).TSInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_TSInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).TSInfo(ctx, req.(*TSInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_JSONSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JSONSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).JSONSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_JSONSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).JSONSet(ctx, req.(*JSONSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_JSONGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JSONGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).JSONGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_JSONGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).JSONGet(ctx, req.(*JSONGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_JSONDel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JSONDelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).JSONDel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_JSONDel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).JSONDel(ctx, req.(*JSONDelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_JSONArrAppend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JSONArrAppendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).JSONArrAppend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_JSONArrAppend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).JSONArrAppend(ctx, req.(*JSONArrAppendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_JSONNumIncrBy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JSONNumIncrByRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).JSONNumIncrBy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_JSONNumIncrBy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).JSONNumIncrBy(ctx, req.(*JSONNumIncrByRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_JSONCreateIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JSONIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).JSONCreateIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_JSONCreateIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).JSONCreateIndex(ctx, req.(*JSONIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_JSONDropIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JSONIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).JSONDropIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_JSONDropIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).JSONDropIndex(ctx, req.(*JSONIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_JSONListIndexes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JSONListIndexesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).JSONListIndexes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_JSONListIndexes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).JSONListIndexes(ctx, req.(*JSONListIndexesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_Find_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).Find(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_Find_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).Find(ctx, req.(*FindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_LockAcquire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockAcquireRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).LockAcquire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_LockAcquire_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).LockAcquire(ctx, req.(*LockAcquireRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_LockRenew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockRenewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).LockRenew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_LockRenew_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).LockRenew(ctx, req.(*LockRenewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_LockRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).LockRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_LockRelease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).LockRelease(ctx, req.(*LockReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_LeaseGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseGrantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).LeaseGrant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_LeaseGrant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).LeaseGrant(ctx, req.(*LeaseGrantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_LeaseKeepAlive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(KVServiceServer).LeaseKeepAlive(&grpc.GenericServerStream[LeaseKeepAliveRequest, LeaseKeepAliveResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVService_LeaseKeepAliveServer = grpc.BidiStreamingServer[LeaseKeepAliveRequest, LeaseKeepAliveResponse]
+
+func _KVService_LeaseRevoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseRevokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).LeaseRevoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_LeaseRevoke_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).LeaseRevoke(ctx, req.(*LeaseRevokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_LeaseTimeToLive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseTimeToLiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).LeaseTimeToLive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_LeaseTimeToLive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).LeaseTimeToLive(ctx, req.(*LeaseTimeToLiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_Throttle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThrottleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).Throttle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_Throttle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).Throttle(ctx, req.(*ThrottleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_Eval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).Eval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_Eval_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).Eval(ctx, req.(*EvalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_EvalSha_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvalShaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).EvalSha(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_EvalSha_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).EvalSha(ctx, req.(*EvalShaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_ScriptLoad_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScriptLoadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).ScriptLoad(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_ScriptLoad_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).ScriptLoad(ctx, req.(*ScriptLoadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_ScriptExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScriptExistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).ScriptExists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_ScriptExists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).ScriptExists(ctx, req.(*ScriptExistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_ScriptFlush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScriptFlushRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).ScriptFlush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_ScriptFlush_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).ScriptFlush(ctx, req.(*ScriptFlushRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVService_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_Info_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).Info(ctx, req.(*InfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func (UnimplementedKVServiceServer) Set(context.Context, *SetRequest) (*SetResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method Set not implemented")
+func _KVService_HotKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).HotKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_HotKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).HotKeys(ctx, req.(*KeyReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedKVServiceServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method Get not implemented")
+
+func _KVService_BigKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).BigKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_BigKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).BigKeys(ctx, req.(*KeyReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedKVServiceServer) Del(context.Context, *DelRequest) (*DelResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method Del not implemented")
+
+func _KVService_SlowLogGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SlowLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).SlowLogGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_SlowLogGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).SlowLogGet(ctx, req.(*SlowLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedKVServiceServer) mustEmbedUnimplementedKVServiceServer() {}
-func (UnimplementedKVServiceServer) testEmbeddedByValue()                   {}
 
-// UnsafeKVServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to KVServiceServer will
-// result in compilation errors.
-type UnsafeKVServiceServer interface {
-	mustEmbedUnimplementedKVServiceServer()
+func _KVService_SlowLogReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SlowLogResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServiceServer).SlowLogReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_SlowLogReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).SlowLogReset(ctx, req.(*SlowLogResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterKVServiceServer(s grpc.ServiceRegistrar, srv KVServiceServer) {
-	// If the following call panics, it indicates UnimplementedKVServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _KVService_Latency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LatencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&KVService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(KVServiceServer).Latency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVService_Latency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServiceServer).Latency(ctx, req.(*LatencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _KVService_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetRequest)
+func _KVService_Monitor_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MonitorRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KVServiceServer).Monitor(m, &grpc.GenericServerStream[MonitorRequest, MonitorEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVService_MonitorServer = grpc.ServerStreamingServer[MonitorEvent]
+
+func _KVService_AclSetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AclSetUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KVServiceServer).Set(ctx, in)
+		return srv.(KVServiceServer).AclSetUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KVService_Set_FullMethodName,
+		FullMethod: KVService_AclSetUser_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KVServiceServer).Set(ctx, req.(*SetRequest))
+		return srv.(KVServiceServer).AclSetUser(ctx, req.(*AclSetUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KVService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRequest)
+func _KVService_AclDelUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AclDelUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KVServiceServer).Get(ctx, in)
+		return srv.(KVServiceServer).AclDelUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KVService_Get_FullMethodName,
+		FullMethod: KVService_AclDelUser_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KVServiceServer).Get(ctx, req.(*GetRequest))
+		return srv.(KVServiceServer).AclDelUser(ctx, req.(*AclDelUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KVService_Del_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DelRequest)
+func _KVService_AclList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AclListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KVServiceServer).Del(ctx, in)
+		return srv.(KVServiceServer).AclList(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KVService_Del_FullMethodName,
+		FullMethod: KVService_AclList_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KVServiceServer).Del(ctx, req.(*DelRequest))
+		return srv.(KVServiceServer).AclList(ctx, req.(*AclListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -197,7 +2469,280 @@ var KVService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Del",
 			Handler:    _KVService_Del_Handler,
 		},
+		{
+			MethodName: "Publish",
+			Handler:    _KVService_Publish_Handler,
+		},
+		{
+			MethodName: "XAdd",
+			Handler:    _KVService_XAdd_Handler,
+		},
+		{
+			MethodName: "XRange",
+			Handler:    _KVService_XRange_Handler,
+		},
+		{
+			MethodName: "XTrim",
+			Handler:    _KVService_XTrim_Handler,
+		},
+		{
+			MethodName: "XGroupCreate",
+			Handler:    _KVService_XGroupCreate_Handler,
+		},
+		{
+			MethodName: "XGroupDestroy",
+			Handler:    _KVService_XGroupDestroy_Handler,
+		},
+		{
+			MethodName: "XAck",
+			Handler:    _KVService_XAck_Handler,
+		},
+		{
+			MethodName: "XPending",
+			Handler:    _KVService_XPending_Handler,
+		},
+		{
+			MethodName: "XClaim",
+			Handler:    _KVService_XClaim_Handler,
+		},
+		{
+			MethodName: "PFAdd",
+			Handler:    _KVService_PFAdd_Handler,
+		},
+		{
+			MethodName: "PFCount",
+			Handler:    _KVService_PFCount_Handler,
+		},
+		{
+			MethodName: "PFMerge",
+			Handler:    _KVService_PFMerge_Handler,
+		},
+		{
+			MethodName: "BFReserve",
+			Handler:    _KVService_BFReserve_Handler,
+		},
+		{
+			MethodName: "BFAdd",
+			Handler:    _KVService_BFAdd_Handler,
+		},
+		{
+			MethodName: "BFExists",
+			Handler:    _KVService_BFExists_Handler,
+		},
+		{
+			MethodName: "CMSInit",
+			Handler:    _KVService_CMSInit_Handler,
+		},
+		{
+			MethodName: "CMSIncrBy",
+			Handler:    _KVService_CMSIncrBy_Handler,
+		},
+		{
+			MethodName: "CMSQuery",
+			Handler:    _KVService_CMSQuery_Handler,
+		},
+		{
+			MethodName: "GeoAdd",
+			Handler:    _KVService_GeoAdd_Handler,
+		},
+		{
+			MethodName: "GeoPos",
+			Handler:    _KVService_GeoPos_Handler,
+		},
+		{
+			MethodName: "GeoDist",
+			Handler:    _KVService_GeoDist_Handler,
+		},
+		{
+			MethodName: "GeoSearch",
+			Handler:    _KVService_GeoSearch_Handler,
+		},
+		{
+			MethodName: "TSCreate",
+			Handler:    _KVService_TSCreate_Handler,
+		},
+		{
+			MethodName: "TSAdd",
+			Handler:    _KVService_TSAdd_Handler,
+		},
+		{
+			MethodName: "TSGet",
+			Handler:    _KVService_TSGet_Handler,
+		},
+		{
+			MethodName: "TSRange",
+			Handler:    _KVService_TSRange_Handler,
+		},
+		{
+			MethodName: "TSMRange",
+			Handler:    _KVService_TSMRange_Handler,
+		},
+		{
+			MethodName: "TSCreateRule",
+			Handler:    _KVService_TSCreateRule_Handler,
+		},
+		{
+			MethodName: "TSDeleteRule",
+			Handler:    _KVService_TSDeleteRule_Handler,
+		},
+		{
+			MethodName: "TSInfo",
+			Handler:    _KVService_TSInfo_Handler,
+		},
+		{
+			MethodName: "JSONSet",
+			Handler:    _KVService_JSONSet_Handler,
+		},
+		{
+			MethodName: "JSONGet",
+			Handler:    _KVService_JSONGet_Handler,
+		},
+		{
+			MethodName: "JSONDel",
+			Handler:    _KVService_JSONDel_Handler,
+		},
+		{
+			MethodName: "JSONArrAppend",
+			Handler:    _KVService_JSONArrAppend_Handler,
+		},
+		{
+			MethodName: "JSONNumIncrBy",
+			Handler:    _KVService_JSONNumIncrBy_Handler,
+		},
+		{
+			MethodName: "JSONCreateIndex",
+			Handler:    _KVService_JSONCreateIndex_Handler,
+		},
+		{
+			MethodName: "JSONDropIndex",
+			Handler:    _KVService_JSONDropIndex_Handler,
+		},
+		{
+			MethodName: "JSONListIndexes",
+			Handler:    _KVService_JSONListIndexes_Handler,
+		},
+		{
+			MethodName: "Find",
+			Handler:    _KVService_Find_Handler,
+		},
+		{
+			MethodName: "LockAcquire",
+			Handler:    _KVService_LockAcquire_Handler,
+		},
+		{
+			MethodName: "LockRenew",
+			Handler:    _KVService_LockRenew_Handler,
+		},
+		{
+			MethodName: "LockRelease",
+			Handler:    _KVService_LockRelease_Handler,
+		},
+		{
+			MethodName: "LeaseGrant",
+			Handler:    _KVService_LeaseGrant_Handler,
+		},
+		{
+			MethodName: "LeaseRevoke",
+			Handler:    _KVService_LeaseRevoke_Handler,
+		},
+		{
+			MethodName: "LeaseTimeToLive",
+			Handler:    _KVService_LeaseTimeToLive_Handler,
+		},
+		{
+			MethodName: "Throttle",
+			Handler:    _KVService_Throttle_Handler,
+		},
+		{
+			MethodName: "Eval",
+			Handler:    _KVService_Eval_Handler,
+		},
+		{
+			MethodName: "EvalSha",
+			Handler:    _KVService_EvalSha_Handler,
+		},
+		{
+			MethodName: "ScriptLoad",
+			Handler:    _KVService_ScriptLoad_Handler,
+		},
+		{
+			MethodName: "ScriptExists",
+			Handler:    _KVService_ScriptExists_Handler,
+		},
+		{
+			MethodName: "ScriptFlush",
+			Handler:    _KVService_ScriptFlush_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _KVService_Info_Handler,
+		},
+		{
+			MethodName: "HotKeys",
+			Handler:    _KVService_HotKeys_Handler,
+		},
+		{
+			MethodName: "BigKeys",
+			Handler:    _KVService_BigKeys_Handler,
+		},
+		{
+			MethodName: "SlowLogGet",
+			Handler:    _KVService_SlowLogGet_Handler,
+		},
+		{
+			MethodName: "SlowLogReset",
+			Handler:    _KVService_SlowLogReset_Handler,
+		},
+		{
+			MethodName: "Latency",
+			Handler:    _KVService_Latency_Handler,
+		},
+		{
+			MethodName: "AclSetUser",
+			Handler:    _KVService_AclSetUser_Handler,
+		},
+		{
+			MethodName: "AclDelUser",
+			Handler:    _KVService_AclDelUser_Handler,
+		},
+		{
+			MethodName: "AclList",
+			Handler:    _KVService_AclList_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _KVService_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PubSub",
+			Handler:       _KVService_PubSub_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "XRead",
+			Handler:       _KVService_XRead_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "XReadGroup",
+			Handler:       _KVService_XReadGroup_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "LeaseKeepAlive",
+			Handler:       _KVService_LeaseKeepAlive_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Monitor",
+			Handler:       _KVService_Monitor_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/kv.proto",
 }
@@ -4,6 +4,7 @@ import (
 	"Flux-KV/pkg/client"
 	"Flux-KV/pkg/consistent"
 	"Flux-KV/pkg/discovery"
+	"Flux-KV/pkg/kverrors"
 	"bufio"
 	"flag"
 	"fmt"
@@ -147,7 +148,9 @@ func main() {
 
 		case "GET":
 			val, err := targetClient.Get(key)
-			if err != nil {
+			if kverrors.IsNotFound(err) {
+				fmt.Println("(nil)")
+			} else if err != nil {
 				fmt.Printf("❌ GET 错误: %v\n", err)
 			} else {
 				// 模仿 Redis，输出加上引号
@@ -3,10 +3,12 @@ package main
 import (
 	"Flux-KV/internal/config"
 	"Flux-KV/internal/gateway/handler"
+	"Flux-KV/internal/gateway/middleware"
 	"Flux-KV/internal/gateway/router"
 	"Flux-KV/pkg/client"
 	"Flux-KV/pkg/discovery"
 	"Flux-KV/pkg/logger"
+	"Flux-KV/pkg/tlsutil"
 	"Flux-KV/pkg/tracer"
 	"context"
 	"errors"
@@ -66,7 +68,37 @@ func main() {
 	serviceName := "kv-service"
 	log.Info("🔗 Initializing KV Client (Load Balanced)...", zap.String("service", serviceName))
 
-	kvClient, err := client.NewClient(disco, serviceName)
+	// 开启 TLS 时，网关以自己的证书作为客户端证书连接存储节点（mTLS），证书文件变化时自动重新加载
+	var clientOpts []client.Option
+	tlsCfg := config.GetConfig().TLS
+	var certs *tlsutil.Reloader
+	if tlsCfg.Enabled {
+		certs, err = tlsCfg.NewReloader()
+		if err != nil {
+			log.Fatal("❌ Failed to load TLS certificates", zap.Error(err))
+		}
+		defer certs.Close()
+		clientOpts = append(clientOpts, client.WithTransportCredentials(certs.TransportCredentials()))
+		log.Info("🔐 TLS enabled", zap.String("cert", tlsCfg.CertFile), zap.Bool("client_auth", tlsCfg.ClientAuth))
+	}
+
+	// 开启 ACL 时，网关用自己的用户表校验 HTTP 请求，并以 client_user / client_token 的身份访问存储节点
+	aclCfg := config.GetConfig().ACL
+	users, err := aclCfg.NewACL()
+	if err != nil {
+		log.Fatal("❌ Failed to load ACL", zap.Error(err))
+	}
+	if users != nil {
+		switch {
+		case aclCfg.ClientToken != "":
+			clientOpts = append(clientOpts, client.WithToken(aclCfg.ClientToken))
+		case aclCfg.ClientUser != "":
+			clientOpts = append(clientOpts, client.WithBasicAuth(aclCfg.ClientUser, aclCfg.ClientPassword))
+		}
+		log.Info("🛡️  ACL enabled", zap.Int("users", len(users.Users())))
+	}
+
+	kvClient, err := client.NewClient(disco, serviceName, clientOpts...)
 	if err != nil {
 		log.Fatal("❌ Failed to init KV client", zap.Error(err))
 	}
@@ -78,11 +110,35 @@ func main() {
 	}()
 
 	// 6. 初始化 Handlers (控制层)
-	kvHandler := handler.NewKVHandler(kvClient)
-	healthHandler := handler.NewHealthHandler()
+	handlers := router.Handlers{
+		KV:         handler.NewKVHandler(kvClient),
+		Health:     handler.NewHealthHandler(),
+		Admin:      handler.NewAdminHandler(kvClient),
+		PubSub:     handler.NewPubSubHandler(kvClient),
+		Sketch:     handler.NewSketchHandler(kvClient),
+		Geo:        handler.NewGeoHandler(kvClient),
+		TimeSeries: handler.NewTimeSeriesHandler(kvClient),
+		JSON:       handler.NewJSONHandler(kvClient),
+		Throttle:   handler.NewThrottleHandler(kvClient),
+		Script:     handler.NewScriptHandler(kvClient),
+		ACL:        handler.NewACLHandler(kvClient, users),
+	}
+
+	// 业务接口限流：集群模式下所有网关实例共享 Flux-KV 中的同一个令牌桶
+	var throttler middleware.Throttler
+	if viper.GetBool("gateway.ratelimit.cluster") {
+		throttler = kvClient
+		log.Info("🚦 Using cluster-wide rate limit", zap.String("key", viper.GetString("gateway.ratelimit.key")))
+	}
+	rateLimiter := middleware.GlobalRateLimiter(
+		viper.GetFloat64("gateway.ratelimit.qps"),
+		viper.GetInt("gateway.ratelimit.burst"),
+		throttler,
+		viper.GetString("gateway.ratelimit.key"),
+	)
 
 	// 7. 初始化 Router (路由层)
-	r := router.NewRouter(kvHandler, healthHandler)
+	r := router.NewRouter(handlers, rateLimiter, middleware.Auth(users))
 
 	// 8. 条件启动 Pprof 监控服务（通过环境变量/配置控制）
 	if viper.GetBool("pprof.enabled") {
@@ -114,7 +170,15 @@ func main() {
 
 	// 10. 启动服务
 	go func() {
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		var err error
+		if certs != nil {
+			// 证书由 TLSConfig.GetCertificate 提供，文件参数留空
+			srv.TLSConfig = certs.ServerConfig()
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Fatal("❌ Listen error", zap.Error(err))
 		}
 	}()
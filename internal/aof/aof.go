@@ -13,6 +13,7 @@ type Cmd struct {
 	Type string `json:"type"`	// 操作类型
 	Key string `json:"key"`		// 键
 	Value any `json:"value"` 	// 值
+	Args []string `json:"args,omitempty"`	// 附加参数（Stream 等复合类型的命令使用）
 }
 
 type AofHandler struct {
@@ -109,4 +110,34 @@ func (handler *AofHandler) Close() error {
 	}
 	
 	return handler.file.Close()
-}
\ No newline at end of file
+}
+
+// ScanFile 以只读方式逐条读取 AOF 文件，供离线分析工具使用
+// 与 ReadAll 不同，它不会把整个文件加载进内存，也不限制单行长度（大 Key 可能远超 64KB）
+func ScanFile(filename string, fn func(Cmd) error) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rd := bufio.NewReaderSize(f, 64*1024)
+	for {
+		line, err := rd.ReadBytes('\n')
+		if len(line) > 0 {
+			var cmd Cmd
+			// 跳过损坏的行（例如宕机时写了一半）
+			if json.Unmarshal(line, &cmd) == nil {
+				if err := fn(cmd); err != nil {
+					return err
+				}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -1,11 +1,14 @@
 package config
 
 import (
+	"Flux-KV/pkg/acl"
+	"Flux-KV/pkg/tlsutil"
 	"fmt"
 	"log"
 	"net"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -21,6 +24,15 @@ type Config struct {
 	Pprof    PprofConfig    `mapstructure:"pprof"`
 	CDC      CDCConfig      `mapstructure:"cdc"`
 	Log      LogConfig      `mapstructure:"log"`
+	HotKey   HotKeyConfig   `mapstructure:"hotkey"`
+	BigKey   BigKeyConfig   `mapstructure:"bigkey"`
+	SlowLog  SlowLogConfig  `mapstructure:"slowlog"`
+	Watch    WatchConfig    `mapstructure:"watch"`
+	Sketch   SketchConfig   `mapstructure:"sketch"`
+	Script   ScriptConfig   `mapstructure:"script"`
+	TCP      TCPConfig      `mapstructure:"tcp"`
+	TLS      TLSConfig      `mapstructure:"tls"`
+	ACL      ACLConfig      `mapstructure:"acl"`
 }
 
 type ServerConfig struct {
@@ -61,6 +73,111 @@ type LogConfig struct {
 	Encoding string `mapstructure:"encoding"`
 }
 
+type HotKeyConfig struct {
+	SampleRate    int           `mapstructure:"sample_rate"`    // 每 N 次访问采样 1 次
+	TopK          int           `mapstructure:"top_k"`          // 保留的热点 Key 数量
+	DecayInterval time.Duration `mapstructure:"decay_interval"` // 计数衰减（减半）周期
+}
+
+type BigKeyConfig struct {
+	ThresholdBytes int64         `mapstructure:"threshold_bytes"` // 超过该大小才记为大 Key
+	ScanInterval   time.Duration `mapstructure:"scan_interval"`   // 后台采样周期，0 表示关闭后台采样
+	TopK           int           `mapstructure:"top_k"`           // 保留的大 Key 数量
+}
+
+type SlowLogConfig struct {
+	Threshold time.Duration `mapstructure:"threshold"` // 超过该耗时的命令会被记录，负数表示关闭
+	MaxLen    int           `mapstructure:"max_len"`   // 环形缓冲区大小
+}
+
+type WatchConfig struct {
+	HistorySize int `mapstructure:"history_size"` // 保留的历史事件数（用于断点续订），0 表示关闭 Watch
+}
+
+// SketchConfig 概率数据结构在自动创建时使用的默认参数
+type SketchConfig struct {
+	BloomErrorRate float64 `mapstructure:"bloom_error_rate"` // 布隆过滤器误判率
+	BloomCapacity  int     `mapstructure:"bloom_capacity"`   // 布隆过滤器预期元素数
+	CMSWidth       int     `mapstructure:"cms_width"`        // Count-Min Sketch 列数
+	CMSDepth       int     `mapstructure:"cms_depth"`        // Count-Min Sketch 行数
+}
+
+type ScriptConfig struct {
+	Timeout time.Duration `mapstructure:"timeout"` // EVAL 脚本的最长执行时间，超时后丢弃脚本的所有写入
+}
+
+type TCPConfig struct {
+	MaxConns     int           `mapstructure:"max_conns"`      // 最大连接数，0 表示不限制
+	MaxFrameSize int           `mapstructure:"max_frame_size"` // 单个请求的最大字节数，超过后断开连接
+	IdleTimeout  time.Duration `mapstructure:"idle_timeout"`   // 连接空闲超过该时间后关闭，0 表示不限制
+	ReadTimeout  time.Duration `mapstructure:"read_timeout"`   // 收到请求的第一个字节后，读完整个请求的最长时间
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`  // 写回复的最长时间
+
+	EventLoop        bool `mapstructure:"event_loop"`         // 文本协议改用 epoll 事件循环（仅 Linux，不支持 TLS），空闲连接不占用 Goroutine
+	EventLoopWorkers int  `mapstructure:"event_loop_workers"` // 事件循环中读写连接、执行命令的 Worker 数，0 表示 CPU 核数
+}
+
+// TLSConfig gRPC、TCP 协议与网关 HTTP 监听共用的证书配置，证书文件变化时自动重新加载
+type TLSConfig struct {
+	Enabled      bool     `mapstructure:"enabled"`
+	CertFile     string   `mapstructure:"cert_file"`     // 本端证书，网关连接存储节点时也作为客户端证书
+	KeyFile      string   `mapstructure:"key_file"`      // 本端私钥
+	CAFile       string   `mapstructure:"ca_file"`       // 校验对端证书的 CA
+	ClientAuth   bool     `mapstructure:"client_auth"`   // 要求客户端出示 CA 签发的证书（mTLS）
+	ServerName   string   `mapstructure:"server_name"`   // 作为客户端时校验的服务端证书名称，为空时使用节点地址
+	AllowedNames []string `mapstructure:"allowed_names"` // 允许的对端证书 CN / DNS SAN，为空时接受 CA 签发的任意证书
+}
+
+// NewReloader 按配置加载证书并监听文件变化
+func (c TLSConfig) NewReloader() (*tlsutil.Reloader, error) {
+	return tlsutil.NewReloader(tlsutil.Config{
+		CertFile:     c.CertFile,
+		KeyFile:      c.KeyFile,
+		CAFile:       c.CAFile,
+		ClientAuth:   c.ClientAuth,
+		ServerName:   c.ServerName,
+		AllowedNames: c.AllowedNames,
+	})
+}
+
+// ACLConfig 用户与权限，存储节点（gRPC / TCP / RESP）和网关都按它检查请求
+type ACLConfig struct {
+	Enabled bool            `mapstructure:"enabled"`
+	Users   []ACLUserConfig `mapstructure:"users"`
+	// 网关等客户端调用存储节点时使用的身份，用户名为空时使用令牌
+	ClientUser     string `mapstructure:"client_user"`
+	ClientPassword string `mapstructure:"client_password"`
+	ClientToken    string `mapstructure:"client_token"`
+}
+
+type ACLUserConfig struct {
+	Name       string   `mapstructure:"name"`
+	Passwords  []string `mapstructure:"passwords"`  // 明文或 sha256:<hex>
+	Tokens     []string `mapstructure:"tokens"`     // Bearer 令牌，明文或 sha256:<hex>
+	NoPass     bool     `mapstructure:"nopass"`     // 不校验密码，通常只用于 default 用户
+	Categories []string `mapstructure:"categories"` // read / write / admin / all
+	Keys       []string `mapstructure:"keys"`       // 允许访问的 Key glob 模式
+}
+
+// NewACL 按配置创建用户表，未开启时返回 nil（不做任何检查）
+func (c ACLConfig) NewACL() (*acl.ACL, error) {
+	if !c.Enabled {
+		return nil, nil
+	}
+	specs := make([]acl.UserSpec, 0, len(c.Users))
+	for _, u := range c.Users {
+		specs = append(specs, acl.UserSpec{
+			Name:       u.Name,
+			Passwords:  u.Passwords,
+			Tokens:     u.Tokens,
+			NoPass:     u.NoPass,
+			Categories: u.Categories,
+			Keys:       u.Keys,
+		})
+	}
+	return acl.New(specs...)
+}
+
 // ===== 初始化函数 =====
 
 // InitConfig 初始化配置，支持环境变量覆盖
@@ -120,6 +237,58 @@ func setDefaults() {
 	// Log
 	viper.SetDefault("log.level", "info")
 	viper.SetDefault("log.encoding", "console")
+
+	// HotKey / BigKey
+	viper.SetDefault("hotkey.sample_rate", 10)
+	viper.SetDefault("hotkey.top_k", 32)
+	viper.SetDefault("hotkey.decay_interval", "1m")
+	viper.SetDefault("bigkey.threshold_bytes", 1<<20)
+	viper.SetDefault("bigkey.scan_interval", "1s")
+	viper.SetDefault("bigkey.top_k", 32)
+
+	// SlowLog
+	viper.SetDefault("slowlog.threshold", "10ms")
+	viper.SetDefault("slowlog.max_len", 128)
+
+	// Watch
+	viper.SetDefault("watch.history_size", 10000)
+
+	// Sketch
+	viper.SetDefault("sketch.bloom_error_rate", 0.01)
+	viper.SetDefault("sketch.bloom_capacity", 1000)
+	viper.SetDefault("sketch.cms_width", 2000)
+	viper.SetDefault("sketch.cms_depth", 5)
+
+	// Script
+	viper.SetDefault("script.timeout", "1s")
+
+	// TCP
+	viper.SetDefault("tcp.max_conns", 10000)
+	viper.SetDefault("tcp.max_frame_size", 64<<20)
+	viper.SetDefault("tcp.idle_timeout", "0s")
+	viper.SetDefault("tcp.read_timeout", "30s")
+	viper.SetDefault("tcp.write_timeout", "30s")
+
+	// TLS
+	viper.SetDefault("tls.enabled", false)
+	viper.SetDefault("tls.cert_file", "")
+	viper.SetDefault("tls.key_file", "")
+	viper.SetDefault("tls.ca_file", "")
+	viper.SetDefault("tls.client_auth", false)
+	viper.SetDefault("tls.server_name", "")
+	viper.SetDefault("tls.allowed_names", []string{})
+
+	// ACL
+	viper.SetDefault("acl.enabled", false)
+	viper.SetDefault("acl.client_user", "")
+	viper.SetDefault("acl.client_password", "")
+	viper.SetDefault("acl.client_token", "")
+
+	// Gateway 全局限流
+	viper.SetDefault("gateway.ratelimit.qps", 1000)
+	viper.SetDefault("gateway.ratelimit.burst", 2000)
+	viper.SetDefault("gateway.ratelimit.cluster", false)
+	viper.SetDefault("gateway.ratelimit.key", "gateway:ratelimit")
 }
 
 // ===== 工具函数 =====
@@ -241,6 +410,35 @@ func PrintConfig() {
 	fmt.Printf("📋 Log:\n")
 	fmt.Printf("   Level: %s\n", cfg.Log.Level)
 	fmt.Printf("   Encoding: %s\n\n", cfg.Log.Encoding)
+
+	fmt.Printf("🔥 HotKey / BigKey:\n")
+	fmt.Printf("   HotKey SampleRate: 1/%d, TopK: %d, Decay: %v\n", cfg.HotKey.SampleRate, cfg.HotKey.TopK, cfg.HotKey.DecayInterval)
+	fmt.Printf("   BigKey Threshold: %d bytes, ScanInterval: %v, TopK: %d\n\n", cfg.BigKey.ThresholdBytes, cfg.BigKey.ScanInterval, cfg.BigKey.TopK)
+
+	fmt.Printf("🐢 SlowLog:\n")
+	fmt.Printf("   Threshold: %v\n", cfg.SlowLog.Threshold)
+	fmt.Printf("   MaxLen: %d\n\n", cfg.SlowLog.MaxLen)
+
+	fmt.Printf("👀 Watch:\n")
+	fmt.Printf("   HistorySize: %d\n\n", cfg.Watch.HistorySize)
+
+	fmt.Printf("🎲 Sketch:\n")
+	fmt.Printf("   Bloom ErrorRate: %v, Capacity: %d\n", cfg.Sketch.BloomErrorRate, cfg.Sketch.BloomCapacity)
+	fmt.Printf("   CMS Width: %d, Depth: %d\n\n", cfg.Sketch.CMSWidth, cfg.Sketch.CMSDepth)
+
+	fmt.Printf("📜 Script:\n")
+	fmt.Printf("   Timeout: %v\n\n", cfg.Script.Timeout)
+
+	fmt.Printf("🔌 TCP:\n")
+	fmt.Printf("   MaxConns: %d, MaxFrameSize: %d bytes\n", cfg.TCP.MaxConns, cfg.TCP.MaxFrameSize)
+	fmt.Printf("   IdleTimeout: %v, ReadTimeout: %v, WriteTimeout: %v\n\n", cfg.TCP.IdleTimeout, cfg.TCP.ReadTimeout, cfg.TCP.WriteTimeout)
+
+	fmt.Printf("🔐 TLS:\n")
+	fmt.Printf("   Enabled: %v, ClientAuth: %v\n", cfg.TLS.Enabled, cfg.TLS.ClientAuth)
+	fmt.Printf("   Cert: %s, CA: %s\n\n", cfg.TLS.CertFile, cfg.TLS.CAFile)
+
+	fmt.Printf("🛡️  ACL:\n")
+	fmt.Printf("   Enabled: %v, Users: %d\n\n", cfg.ACL.Enabled, len(cfg.ACL.Users))
 }
 
 // maskSensitiveURL 隐藏 URL 中的密码（调试用）
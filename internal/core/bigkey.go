@@ -0,0 +1,153 @@
+package core
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// 大 Key 采样的默认参数（配置为 0 时使用）
+const (
+	defaultBigKeyThreshold = 1 << 20 // 1MB
+	defaultBigKeyTopK      = 32
+
+	// 后台采样每轮扫描的分片数，256 个分片约 16 轮覆盖一遍
+	bigKeyShardsPerRound = 16
+)
+
+// bigKeySampler 后台增量扫描分片，记录体积超过阈值的 Key
+type bigKeySampler struct {
+	threshold int64
+	topK      int
+
+	mu       sync.Mutex
+	next     int                // 下一轮从哪个分片开始
+	keys     map[string]KeyStat // 已发现的大 Key
+	keyShard map[string]int     // Key -> 所在分片，用于分片重扫时清理旧记录
+}
+
+func newBigKeySampler(threshold int64, topK int) *bigKeySampler {
+	if threshold <= 0 {
+		threshold = defaultBigKeyThreshold
+	}
+	if topK <= 0 {
+		topK = defaultBigKeyTopK
+	}
+	return &bigKeySampler{
+		threshold: threshold,
+		topK:      topK,
+		keys:      make(map[string]KeyStat),
+		keyShard:  make(map[string]int),
+	}
+}
+
+// scanShard 扫描单个分片，返回其中超过阈值的 Key
+func (b *bigKeySampler) scanShard(s *shard) []KeyStat {
+	now := time.Now().UnixNano()
+	var found []KeyStat
+
+	s.mu.RLock()
+	for key, item := range s.data {
+		if item.ExpireAt > 0 && now > item.ExpireAt {
+			continue
+		}
+		if size := valueSize(item.Val); size >= b.threshold {
+			found = append(found, KeyStat{Key: key, Type: typeName(item.Val), Size: size})
+		}
+	}
+	s.mu.RUnlock()
+
+	return found
+}
+
+// merge 用某个分片的最新扫描结果替换旧记录
+func (b *bigKeySampler) merge(shardID int, found []KeyStat) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// 1. 清理该分片的旧记录（Key 可能已被删除或变小）
+	for key, id := range b.keyShard {
+		if id == shardID {
+			delete(b.keys, key)
+			delete(b.keyShard, key)
+		}
+	}
+
+	// 2. 写入新记录
+	for _, ks := range found {
+		b.keys[ks.Key] = ks
+		b.keyShard[ks.Key] = shardID
+	}
+
+	// 3. 超出 Top-K 时淘汰最小的
+	for len(b.keys) > b.topK {
+		minKey, minSize := "", int64(-1)
+		for key, ks := range b.keys {
+			if minSize < 0 || ks.Size < minSize {
+				minKey, minSize = key, ks.Size
+			}
+		}
+		delete(b.keys, minKey)
+		delete(b.keyShard, minKey)
+	}
+}
+
+// sampleBigKeys 执行一轮增量扫描
+func (db *MemDB) sampleBigKeys() {
+	b := db.bigKeys
+
+	b.mu.Lock()
+	start := b.next
+	b.next = (b.next + bigKeyShardsPerRound) % ShardCount
+	b.mu.Unlock()
+
+	for i := 0; i < bigKeyShardsPerRound; i++ {
+		id := (start + i) % ShardCount
+		b.merge(id, b.scanShard(db.shards[id]))
+	}
+}
+
+// startBigKeySampler 启动后台大 Key 采样，随 Close 退出
+func (db *MemDB) startBigKeySampler(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				db.sampleBigKeys()
+			case <-db.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+// BigKeys 返回体积最大的 n 个 Key（n <= 0 表示全部）
+// 后台采样未开启时，会同步全量扫描一次
+func (db *MemDB) BigKeys(n int) []KeyStat {
+	b := db.bigKeys
+	if !db.bigKeyScanning {
+		for id, s := range db.shards {
+			b.merge(id, b.scanShard(s))
+		}
+	}
+
+	b.mu.Lock()
+	result := make([]KeyStat, 0, len(b.keys))
+	for _, ks := range b.keys {
+		result = append(result, ks)
+	}
+	b.mu.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Size != result[j].Size {
+			return result[i].Size > result[j].Size
+		}
+		return result[i].Key < result[j].Key
+	})
+	if n > 0 && len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
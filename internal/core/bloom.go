@@ -0,0 +1,266 @@
+package core
+
+import (
+	"Flux-KV/internal/aof"
+	"Flux-KV/pkg/kverrors"
+	"encoding/binary"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// 二进制编码的头部，便于下游区分类型
+const bloomMagic = "BLM1"
+
+var (
+	// ErrKeyExists Key 已存在（BF.RESERVE / CMS.INIT* 不覆盖已有的值）
+	ErrKeyExists = kverrors.New(kverrors.Conflict, "key already exists")
+	// ErrInvalidBloom 创建参数不合法
+	ErrInvalidBloom = kverrors.New(kverrors.InvalidArgument, "invalid bloom filter: error rate must be in (0, 1) and capacity must be positive")
+)
+
+// BloomFilter 布隆过滤器：判断为不存在时一定不存在，判断为存在时有 errorRate 的概率误判
+// 大小在创建时按 capacity 和 errorRate 固定，插入超过 capacity 后误判率会逐渐上升
+type BloomFilter struct {
+	bits      []uint64
+	m         uint64 // 位数
+	k         uint32 // 哈希函数个数
+	errorRate float64
+	capacity  uint64
+	count     uint64 // 已插入的元素数（只计新增）
+}
+
+func newBloomFilter(errorRate float64, capacity uint64) (*BloomFilter, error) {
+	if errorRate <= 0 || errorRate >= 1 || capacity == 0 {
+		return nil, ErrInvalidBloom
+	}
+	// m = -n·ln(p) / (ln2)², k = m/n·ln2
+	m := uint64(math.Ceil(-float64(capacity) * math.Log(errorRate) / (math.Ln2 * math.Ln2)))
+	m = (m + 63) / 64 * 64
+	k := uint32(math.Round(float64(m) / float64(capacity) * math.Ln2))
+	if k == 0 {
+		k = 1
+	}
+	return &BloomFilter{
+		bits:      make([]uint64, m/64),
+		m:         m,
+		k:         k,
+		errorRate: errorRate,
+		capacity:  capacity,
+	}, nil
+}
+
+// MemSize 估算占用的内存
+func (bf *BloomFilter) MemSize() int64 {
+	return int64(len(bf.bits)) * 8
+}
+
+// locations 双重哈希 h1 + i·h2 模拟 k 个独立哈希函数
+func (bf *BloomFilter) locations(item string, fn func(pos uint64) bool) {
+	h1 := hash64(item)
+	h2 := hash64(item+"\x00") | 1
+	for i := uint64(0); i < uint64(bf.k); i++ {
+		if !fn((h1 + i*h2) % bf.m) {
+			return
+		}
+	}
+}
+
+// Add 添加元素，返回元素此前是否（可能）不存在
+func (bf *BloomFilter) Add(item string) bool {
+	added := false
+	bf.locations(item, func(pos uint64) bool {
+		word, mask := pos/64, uint64(1)<<(pos%64)
+		if bf.bits[word]&mask == 0 {
+			bf.bits[word] |= mask
+			added = true
+		}
+		return true
+	})
+	if added {
+		bf.count++
+	}
+	return added
+}
+
+// Exists 判断元素是否可能存在
+func (bf *BloomFilter) Exists(item string) bool {
+	exists := true
+	bf.locations(item, func(pos uint64) bool {
+		if bf.bits[pos/64]&(uint64(1)<<(pos%64)) == 0 {
+			exists = false
+		}
+		return exists
+	})
+	return exists
+}
+
+// MarshalBinary 编码为 "BLM1" + errorRate + capacity + count + k + 位数组
+func (bf *BloomFilter) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, len(bloomMagic)+28+len(bf.bits)*8)
+	buf = append(buf, bloomMagic...)
+	buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(bf.errorRate))
+	buf = binary.BigEndian.AppendUint64(buf, bf.capacity)
+	buf = binary.BigEndian.AppendUint64(buf, bf.count)
+	buf = binary.BigEndian.AppendUint32(buf, bf.k)
+	for _, w := range bf.bits {
+		buf = binary.BigEndian.AppendUint64(buf, w)
+	}
+	return buf, nil
+}
+
+// BloomInfo 布隆过滤器的参数
+type BloomInfo struct {
+	ErrorRate float64
+	Capacity  uint64
+	Items     uint64
+	Bits      uint64
+	Hashes    uint32
+}
+
+// BFReserve 按指定误判率和容量创建布隆过滤器，Key 已存在时返回 ErrKeyExists
+func (db *MemDB) BFReserve(key string, errorRate float64, capacity uint64) error {
+	defer db.stats.record("bf.reserve", time.Now())
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, found, _ := lookupValue[any](s, key); found {
+		return ErrKeyExists
+	}
+	bf, err := newBloomFilter(errorRate, capacity)
+	if err != nil {
+		return err
+	}
+	s.data[key] = &Item{Val: bf}
+	db.writeBloomReserve(key, bf)
+	db.publishSnapshot(key, bf)
+	return nil
+}
+
+// BFAdd 添加元素，Key 不存在时按配置的默认参数创建；返回每个元素此前是否不存在
+func (db *MemDB) BFAdd(key string, items ...string) ([]bool, error) {
+	defer db.stats.record("bf.add", time.Now())
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bf, found, err := lookupValue[*BloomFilter](s, key)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if bf, err = newBloomFilter(db.bloomDefaults()); err != nil {
+			return nil, err
+		}
+		s.data[key] = &Item{Val: bf}
+		// 先记录创建参数，重放时不受默认配置变化的影响
+		db.writeBloomReserve(key, bf)
+	}
+
+	result := make([]bool, len(items))
+	changed := false
+	for i, item := range items {
+		result[i] = bf.Add(item)
+		changed = changed || result[i]
+	}
+	if changed {
+		db.notify(WatchPut, key, nil)
+		db.writeAof(aof.Cmd{Type: "bf.add", Key: key, Args: items})
+		db.publishSnapshot(key, bf)
+	}
+	db.hotKeys.touch(key)
+	return result, nil
+}
+
+// BFExists 判断元素是否可能存在，Key 不存在时全部为 false
+func (db *MemDB) BFExists(key string, items ...string) ([]bool, error) {
+	defer db.stats.record("bf.exists", time.Now())
+
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	bf, found, err := lookupValue[*BloomFilter](s, key)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]bool, len(items))
+	if found {
+		for i, item := range items {
+			result[i] = bf.Exists(item)
+		}
+	}
+	db.hotKeys.touch(key)
+	return result, nil
+}
+
+// BFInfo 返回布隆过滤器的参数，Key 不存在时返回 nil
+func (db *MemDB) BFInfo(key string) (*BloomInfo, error) {
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	bf, found, err := lookupValue[*BloomFilter](s, key)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &BloomInfo{
+		ErrorRate: bf.errorRate,
+		Capacity:  bf.capacity,
+		Items:     bf.count,
+		Bits:      bf.m,
+		Hashes:    bf.k,
+	}, nil
+}
+
+// bloomDefaults 自动创建时使用的参数，未配置时为 1% / 1000
+func (db *MemDB) bloomDefaults() (float64, uint64) {
+	errorRate, capacity := db.sketchCfg.BloomErrorRate, db.sketchCfg.BloomCapacity
+	if errorRate <= 0 || errorRate >= 1 {
+		errorRate = 0.01
+	}
+	if capacity <= 0 {
+		capacity = 1000
+	}
+	return errorRate, uint64(capacity)
+}
+
+func (db *MemDB) writeBloomReserve(key string, bf *BloomFilter) {
+	db.writeAof(aof.Cmd{
+		Type: "bf.reserve",
+		Key:  key,
+		Args: []string{strconv.FormatFloat(bf.errorRate, 'g', -1, 64), strconv.FormatUint(bf.capacity, 10)},
+	})
+}
+
+// replayBloom 重放 AOF 中的布隆过滤器命令，调用方持有分片写锁
+func (db *MemDB) replayBloom(s *shard, cmd aof.Cmd) error {
+	switch cmd.Type {
+	case "bf.reserve":
+		if len(cmd.Args) != 2 {
+			return ErrInvalidBloom
+		}
+		errorRate, err1 := strconv.ParseFloat(cmd.Args[0], 64)
+		capacity, err2 := strconv.ParseUint(cmd.Args[1], 10, 64)
+		if err1 != nil || err2 != nil {
+			return ErrInvalidBloom
+		}
+		bf, err := newBloomFilter(errorRate, capacity)
+		if err != nil {
+			return err
+		}
+		s.data[cmd.Key] = &Item{Val: bf}
+	case "bf.add":
+		bf, found, err := lookupValue[*BloomFilter](s, cmd.Key)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.New("bf.add before bf.reserve")
+		}
+		for _, item := range cmd.Args {
+			bf.Add(item)
+		}
+	}
+	return nil
+}
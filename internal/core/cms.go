@@ -0,0 +1,213 @@
+package core
+
+import (
+	"Flux-KV/internal/aof"
+	"Flux-KV/pkg/kverrors"
+	"encoding/binary"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// 二进制编码的头部，便于下游区分类型
+const cmsMagic = "CMS1"
+
+// ErrInvalidCMS 参数不合法
+var ErrInvalidCMS = kverrors.New(kverrors.InvalidArgument, "invalid count-min sketch: width and depth must be positive")
+
+// CountMinSketch 频率估算，只会高估、不会低估
+// 误差上界约为 总计数 × e / width，超出该上界的概率约为 e^-depth
+type CountMinSketch struct {
+	sketch *countMinSketch
+	total  uint64 // 所有 INCRBY 的累计值
+}
+
+func newCMS(width, depth uint32) (*CountMinSketch, error) {
+	if width == 0 || depth == 0 {
+		return nil, ErrInvalidCMS
+	}
+	return &CountMinSketch{sketch: newCountMinSketch(width, depth)}, nil
+}
+
+// MemSize 估算占用的内存
+func (c *CountMinSketch) MemSize() int64 {
+	return int64(c.sketch.width) * int64(c.sketch.depth) * 4
+}
+
+// MarshalBinary 编码为 "CMS1" + width + depth + total + 计数矩阵
+func (c *CountMinSketch) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, int64(len(cmsMagic)+16)+c.MemSize())
+	buf = append(buf, cmsMagic...)
+	buf = binary.BigEndian.AppendUint32(buf, c.sketch.width)
+	buf = binary.BigEndian.AppendUint32(buf, c.sketch.depth)
+	buf = binary.BigEndian.AppendUint64(buf, c.total)
+	for _, row := range c.sketch.counts {
+		for _, v := range row {
+			buf = binary.BigEndian.AppendUint32(buf, v)
+		}
+	}
+	return buf, nil
+}
+
+// CMSInfo Count-Min Sketch 的参数
+type CMSInfo struct {
+	Width uint32
+	Depth uint32
+	Count uint64
+}
+
+// CMSDimsByProb 按误差率和失败概率计算尺寸：width = ⌈e / errorRate⌉，depth = ⌈ln(1 / prob)⌉
+func CMSDimsByProb(errorRate, prob float64) (width, depth uint32, err error) {
+	if errorRate <= 0 || errorRate >= 1 || prob <= 0 || prob >= 1 {
+		return 0, 0, errors.New("error rate and probability must be in (0, 1)")
+	}
+	return uint32(math.Ceil(math.E / errorRate)), uint32(math.Ceil(math.Log(1 / prob))), nil
+}
+
+// CMSInit 按指定尺寸创建，Key 已存在时返回 ErrKeyExists
+func (db *MemDB) CMSInit(key string, width, depth uint32) error {
+	defer db.stats.record("cms.init", time.Now())
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, found, _ := lookupValue[any](s, key); found {
+		return ErrKeyExists
+	}
+	c, err := newCMS(width, depth)
+	if err != nil {
+		return err
+	}
+	s.data[key] = &Item{Val: c}
+	db.writeCMSInit(key, c)
+	db.publishSnapshot(key, c)
+	return nil
+}
+
+// CMSIncrBy 为每个元素增加计数，Key 不存在时按配置的默认尺寸创建；返回增加后的估算值
+func (db *MemDB) CMSIncrBy(key string, items []string, increments []uint32) ([]uint32, error) {
+	defer db.stats.record("cms.incrby", time.Now())
+	if len(items) != len(increments) {
+		return nil, errors.New("items and increments must have the same length")
+	}
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, found, err := lookupValue[*CountMinSketch](s, key)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if c, err = newCMS(db.cmsDefaults()); err != nil {
+			return nil, err
+		}
+		s.data[key] = &Item{Val: c}
+		// 先记录创建参数，重放时不受默认配置变化的影响
+		db.writeCMSInit(key, c)
+	}
+
+	result := make([]uint32, len(items))
+	args := make([]string, 0, len(items)*2)
+	for i, item := range items {
+		result[i] = c.sketch.Add(item, increments[i])
+		c.total += uint64(increments[i])
+		args = append(args, item, strconv.FormatUint(uint64(increments[i]), 10))
+	}
+	db.notify(WatchPut, key, nil)
+	db.writeAof(aof.Cmd{Type: "cms.incrby", Key: key, Args: args})
+	db.publishSnapshot(key, c)
+	db.hotKeys.touch(key)
+	return result, nil
+}
+
+// CMSQuery 查询估算值，Key 不存在时全部为 0
+func (db *MemDB) CMSQuery(key string, items ...string) ([]uint32, error) {
+	defer db.stats.record("cms.query", time.Now())
+
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	c, found, err := lookupValue[*CountMinSketch](s, key)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]uint32, len(items))
+	if found {
+		for i, item := range items {
+			result[i] = c.sketch.Estimate(item)
+		}
+	}
+	db.hotKeys.touch(key)
+	return result, nil
+}
+
+// CMSInfo 返回尺寸和累计计数，Key 不存在时返回 nil
+func (db *MemDB) CMSInfo(key string) (*CMSInfo, error) {
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	c, found, err := lookupValue[*CountMinSketch](s, key)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &CMSInfo{Width: c.sketch.width, Depth: c.sketch.depth, Count: c.total}, nil
+}
+
+// cmsDefaults 自动创建时使用的尺寸，未配置时为 2000 × 5
+func (db *MemDB) cmsDefaults() (uint32, uint32) {
+	width, depth := db.sketchCfg.CMSWidth, db.sketchCfg.CMSDepth
+	if width <= 0 {
+		width = 2000
+	}
+	if depth <= 0 {
+		depth = 5
+	}
+	return uint32(width), uint32(depth)
+}
+
+func (db *MemDB) writeCMSInit(key string, c *CountMinSketch) {
+	db.writeAof(aof.Cmd{
+		Type: "cms.init",
+		Key:  key,
+		Args: []string{strconv.FormatUint(uint64(c.sketch.width), 10), strconv.FormatUint(uint64(c.sketch.depth), 10)},
+	})
+}
+
+// replayCMS 重放 AOF 中的 Count-Min Sketch 命令，调用方持有分片写锁
+func (db *MemDB) replayCMS(s *shard, cmd aof.Cmd) error {
+	switch cmd.Type {
+	case "cms.init":
+		if len(cmd.Args) != 2 {
+			return ErrInvalidCMS
+		}
+		width, err1 := strconv.ParseUint(cmd.Args[0], 10, 32)
+		depth, err2 := strconv.ParseUint(cmd.Args[1], 10, 32)
+		if err1 != nil || err2 != nil {
+			return ErrInvalidCMS
+		}
+		c, err := newCMS(uint32(width), uint32(depth))
+		if err != nil {
+			return err
+		}
+		s.data[cmd.Key] = &Item{Val: c}
+	case "cms.incrby":
+		c, found, err := lookupValue[*CountMinSketch](s, cmd.Key)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.New("cms.incrby before cms.init")
+		}
+		for i := 0; i+1 < len(cmd.Args); i += 2 {
+			incr, err := strconv.ParseUint(cmd.Args[i+1], 10, 32)
+			if err != nil {
+				return err
+			}
+			c.sketch.Add(cmd.Args[i], uint32(incr))
+			c.total += incr
+		}
+	}
+	return nil
+}
@@ -0,0 +1,381 @@
+package core
+
+import (
+	"Flux-KV/internal/aof"
+	"Flux-KV/pkg/kverrors"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 与 Redis 一致：经纬度各 26 位交错编码为 52 位 geohash，可以无损存入 float64 分数
+const (
+	geoStep      = 26
+	geoLatMin    = -85.05112878
+	geoLatMax    = 85.05112878
+	geoLonMin    = -180.0
+	geoLonMax    = 180.0
+	earthRadiusM = 6372797.560856
+)
+
+var (
+	// ErrInvalidCoordinates 经纬度超出可编码的范围
+	ErrInvalidCoordinates = kverrors.New(kverrors.InvalidArgument, "invalid longitude,latitude pair")
+	// ErrInvalidGeoUnit 距离单位不是 m / km / mi / ft
+	ErrInvalidGeoUnit = kverrors.New(kverrors.InvalidArgument, "unsupported unit provided. please use m, km, ft, mi")
+	// ErrNoSuchMember FROMMEMBER 指定的成员不存在
+	ErrNoSuchMember = kverrors.New(kverrors.NotFound, "could not decode requested zset member")
+)
+
+// GeoPoint 一个带名字的坐标
+type GeoPoint struct {
+	Member    string
+	Longitude float64
+	Latitude  float64
+}
+
+// GeoSort 搜索结果的排序方式
+type GeoSort int
+
+const (
+	GeoSortNone GeoSort = iota
+	GeoSortAsc
+	GeoSortDesc
+)
+
+// GeoSearchQuery GEOSEARCH 的参数，距离均以米为单位
+type GeoSearchQuery struct {
+	Member    string  // FROMMEMBER，为空时使用 Longitude / Latitude
+	Longitude float64 // FROMLONLAT
+	Latitude  float64
+	Radius    float64 // BYRADIUS，大于 0 时按圆形搜索
+	Width     float64 // BYBOX，Radius 为 0 时按矩形搜索
+	Height    float64
+	Sort      GeoSort
+	Count     int  // 0 表示不限制
+	Any       bool // 找到 Count 个即返回，不保证是最近的
+}
+
+// GeoResult 搜索结果
+type GeoResult struct {
+	Member    string
+	Dist      float64 // 到中心的距离（米）
+	Longitude float64
+	Latitude  float64
+	Hash      uint64
+}
+
+// GeoUnitToMeters 返回单位对应的米数
+func GeoUnitToMeters(unit string) (float64, error) {
+	switch strings.ToLower(unit) {
+	case "", "m":
+		return 1, nil
+	case "km":
+		return 1000, nil
+	case "mi":
+		return 1609.34, nil
+	case "ft":
+		return 0.3048, nil
+	default:
+		return 0, ErrInvalidGeoUnit
+	}
+}
+
+func validCoordinates(lon, lat float64) bool {
+	return lon >= geoLonMin && lon <= geoLonMax && lat >= geoLatMin && lat <= geoLatMax
+}
+
+// spread 把 32 位整数的每一位间隔一位展开到 64 位
+func spread(v uint32) uint64 {
+	x := uint64(v)
+	x = (x | x<<16) & 0x0000FFFF0000FFFF
+	x = (x | x<<8) & 0x00FF00FF00FF00FF
+	x = (x | x<<4) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+	return x
+}
+
+// squash spread 的逆操作
+func squash(x uint64) uint32 {
+	x &= 0x5555555555555555
+	x = (x | x>>1) & 0x3333333333333333
+	x = (x | x>>2) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x>>4) & 0x00FF00FF00FF00FF
+	x = (x | x>>8) & 0x0000FFFF0000FFFF
+	x = (x | x>>16) & 0x00000000FFFFFFFF
+	return uint32(x)
+}
+
+// geoCells 把经纬度映射为 26 位的网格坐标
+func geoCells(lon, lat float64) (lonIdx, latIdx uint32) {
+	const n = 1 << geoStep
+	lonIdx = uint32(math.Min((lon-geoLonMin)/(geoLonMax-geoLonMin)*n, n-1))
+	latIdx = uint32(math.Min((lat-geoLatMin)/(geoLatMax-geoLatMin)*n, n-1))
+	return lonIdx, latIdx
+}
+
+// geohashEncode 纬度占偶数位、经度占奇数位
+func geohashEncode(lon, lat float64) uint64 {
+	lonIdx, latIdx := geoCells(lon, lat)
+	return spread(latIdx) | spread(lonIdx)<<1
+}
+
+// geohashDecode 返回网格中心的坐标
+func geohashDecode(hash uint64) (lon, lat float64) {
+	const n = 1 << geoStep
+	latIdx, lonIdx := squash(hash), squash(hash>>1)
+	lon = geoLonMin + (float64(lonIdx)+0.5)*(geoLonMax-geoLonMin)/n
+	lat = geoLatMin + (float64(latIdx)+0.5)*(geoLatMax-geoLatMin)/n
+	return lon, lat
+}
+
+func degRad(d float64) float64 {
+	return d * math.Pi / 180
+}
+
+// geoDistance Haversine 公式计算球面距离（米）
+func geoDistance(lon1, lat1, lon2, lat2 float64) float64 {
+	u := math.Sin(degRad(lat2-lat1) / 2)
+	v := math.Sin(degRad(lon2-lon1) / 2)
+	a := u*u + math.Cos(degRad(lat1))*math.Cos(degRad(lat2))*v*v
+	return 2 * earthRadiusM * math.Asin(math.Sqrt(a))
+}
+
+// geoSearchStep 选择网格精度：单个网格在南北和东西方向上都不小于搜索半径，
+// 这样中心所在网格加上周围 8 个网格一定能覆盖整个搜索区域
+func geoSearchStep(lat, radius float64) uint {
+	metersPerDeg := earthRadiusM * math.Pi / 180
+	maxLat := math.Min(math.Abs(lat)+radius/metersPerDeg, 89)
+	step := uint(geoStep)
+	for ; step > 1; step-- {
+		latCell := (geoLatMax - geoLatMin) / float64(uint64(1)<<step) * metersPerDeg
+		lonCell := (geoLonMax - geoLonMin) / float64(uint64(1)<<step) * metersPerDeg * math.Cos(degRad(maxLat))
+		if latCell >= radius && lonCell >= radius {
+			break
+		}
+	}
+	return step
+}
+
+// geoSearchRanges 返回需要扫描的 geohash 分数区间 [min, max)
+func geoSearchRanges(lon, lat, radius float64) [][2]float64 {
+	step := geoSearchStep(lat, radius)
+	shift := geoStep - step
+	lonIdx, latIdx := geoCells(lon, lat)
+	lonIdx, latIdx = lonIdx>>shift, latIdx>>shift
+	cells := int64(1) << step
+
+	seen := make(map[uint64]bool, 9)
+	ranges := make([][2]float64, 0, 9)
+	for dLat := int64(-1); dLat <= 1; dLat++ {
+		y := int64(latIdx) + dLat
+		if y < 0 || y >= cells {
+			continue
+		}
+		for dLon := int64(-1); dLon <= 1; dLon++ {
+			// 经度方向首尾相连
+			x := (int64(lonIdx) + dLon + cells) % cells
+			cell := spread(uint32(y)) | spread(uint32(x))<<1
+			if seen[cell] {
+				continue
+			}
+			seen[cell] = true
+			lo := cell << (2 * shift)
+			hi := (cell + 1) << (2 * shift)
+			ranges = append(ranges, [2]float64{float64(lo), float64(hi)})
+		}
+	}
+	return ranges
+}
+
+// geoMatch 判断坐标是否在搜索区域内，返回到中心的距离
+func geoMatch(q *GeoSearchQuery, lon, lat float64) (float64, bool) {
+	if q.Radius > 0 {
+		dist := geoDistance(q.Longitude, q.Latitude, lon, lat)
+		return dist, dist <= q.Radius
+	}
+	// 矩形：南北方向按经线距离，东西方向按目标所在纬线的距离
+	if geoDistance(lon, q.Latitude, lon, lat) > q.Height/2 {
+		return 0, false
+	}
+	if geoDistance(q.Longitude, lat, lon, lat) > q.Width/2 {
+		return 0, false
+	}
+	return geoDistance(q.Longitude, q.Latitude, lon, lat), true
+}
+
+// GeoAdd 添加或更新成员的坐标，Key 不存在时创建；返回新增的成员数
+func (db *MemDB) GeoAdd(key string, points []GeoPoint) (int, error) {
+	defer db.stats.record("geoadd", time.Now())
+
+	args := make([]string, 0, len(points)*3)
+	for _, p := range points {
+		if !validCoordinates(p.Longitude, p.Latitude) {
+			return 0, ErrInvalidCoordinates
+		}
+		args = append(args, p.Member,
+			strconv.FormatFloat(p.Longitude, 'g', -1, 64),
+			strconv.FormatFloat(p.Latitude, 'g', -1, 64))
+	}
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	z, found, err := lookupValue[*SortedSet](s, key)
+	if err != nil {
+		s.mu.Unlock()
+		return 0, err
+	}
+	if !found {
+		z = newSortedSet()
+		s.data[key] = &Item{Val: z}
+	}
+	added := 0
+	for _, p := range points {
+		if z.Add(p.Member, float64(geohashEncode(p.Longitude, p.Latitude))) {
+			added++
+		}
+	}
+	db.notify(WatchPut, key, nil)
+	db.writeAof(aof.Cmd{Type: "geoadd", Key: key, Args: args})
+	db.publishSnapshot(key, z)
+	s.mu.Unlock()
+
+	db.hotKeys.touch(key)
+	return added, nil
+}
+
+// GeoPos 返回成员的坐标（geohash 网格中心，与写入值有微小误差），不存在的成员为 nil
+func (db *MemDB) GeoPos(key string, members ...string) ([]*GeoPoint, error) {
+	defer db.stats.record("geopos", time.Now())
+
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	z, found, err := lookupValue[*SortedSet](s, key)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*GeoPoint, len(members))
+	if !found {
+		return result, nil
+	}
+	for i, m := range members {
+		if score, ok := z.Score(m); ok {
+			lon, lat := geohashDecode(uint64(score))
+			result[i] = &GeoPoint{Member: m, Longitude: lon, Latitude: lat}
+		}
+	}
+	db.hotKeys.touch(key)
+	return result, nil
+}
+
+// GeoDist 返回两个成员之间的距离（米），任一成员不存在时 ok 为 false
+func (db *MemDB) GeoDist(key, member1, member2 string) (dist float64, ok bool, err error) {
+	pos, err := db.GeoPos(key, member1, member2)
+	if err != nil || pos[0] == nil || pos[1] == nil {
+		return 0, false, err
+	}
+	return geoDistance(pos[0].Longitude, pos[0].Latitude, pos[1].Longitude, pos[1].Latitude), true, nil
+}
+
+// GeoSearch 按圆形或矩形区域搜索成员
+// 只扫描覆盖搜索区域的 9 个 geohash 网格对应的分数区间，再按实际距离过滤
+func (db *MemDB) GeoSearch(key string, q GeoSearchQuery) ([]GeoResult, error) {
+	defer db.stats.record("geosearch", time.Now())
+
+	if q.Radius <= 0 && (q.Width <= 0 || q.Height <= 0) {
+		return nil, errors.New("radius or width and height must be positive")
+	}
+
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	z, found, err := lookupValue[*SortedSet](s, key)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if q.Member != "" {
+			return nil, ErrNoSuchMember
+		}
+		return nil, nil
+	}
+
+	// 1. 确定中心点
+	if q.Member != "" {
+		score, ok := z.Score(q.Member)
+		if !ok {
+			return nil, ErrNoSuchMember
+		}
+		q.Longitude, q.Latitude = geohashDecode(uint64(score))
+	} else if !validCoordinates(q.Longitude, q.Latitude) {
+		return nil, ErrInvalidCoordinates
+	}
+
+	// 2. COUNT 不带 ANY 时需要最近的 N 个，默认升序
+	if q.Count > 0 && !q.Any && q.Sort == GeoSortNone {
+		q.Sort = GeoSortAsc
+	}
+
+	// 3. 扫描候选网格
+	radius := q.Radius
+	if radius <= 0 {
+		radius = math.Hypot(q.Width, q.Height) / 2
+	}
+	var results []GeoResult
+	for _, r := range geoSearchRanges(q.Longitude, q.Latitude, radius) {
+		z.RangeByScore(r[0], r[1], func(member string, score float64) bool {
+			lon, lat := geohashDecode(uint64(score))
+			if dist, ok := geoMatch(&q, lon, lat); ok {
+				results = append(results, GeoResult{
+					Member: member, Dist: dist, Longitude: lon, Latitude: lat, Hash: uint64(score),
+				})
+			}
+			return !q.Any || q.Count <= 0 || len(results) < q.Count
+		})
+		if q.Any && q.Count > 0 && len(results) >= q.Count {
+			break
+		}
+	}
+
+	// 4. 排序和截断
+	switch q.Sort {
+	case GeoSortAsc:
+		sort.Slice(results, func(i, j int) bool { return results[i].Dist < results[j].Dist })
+	case GeoSortDesc:
+		sort.Slice(results, func(i, j int) bool { return results[i].Dist > results[j].Dist })
+	}
+	if q.Count > 0 && len(results) > q.Count {
+		results = results[:q.Count]
+	}
+	db.hotKeys.touch(key)
+	return results, nil
+}
+
+// replayGeo 重放 AOF 中的 geoadd，调用方持有分片写锁
+func (db *MemDB) replayGeo(s *shard, cmd aof.Cmd) error {
+	if len(cmd.Args)%3 != 0 {
+		return ErrInvalidCoordinates
+	}
+	z, found, err := lookupValue[*SortedSet](s, cmd.Key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		z = newSortedSet()
+		s.data[cmd.Key] = &Item{Val: z}
+	}
+	for i := 0; i < len(cmd.Args); i += 3 {
+		lon, err1 := strconv.ParseFloat(cmd.Args[i+1], 64)
+		lat, err2 := strconv.ParseFloat(cmd.Args[i+2], 64)
+		if err1 != nil || err2 != nil || !validCoordinates(lon, lat) {
+			return ErrInvalidCoordinates
+		}
+		z.Add(cmd.Args[i], float64(geohashEncode(lon, lat)))
+	}
+	return nil
+}
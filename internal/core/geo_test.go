@@ -0,0 +1,151 @@
+package core
+
+import (
+	"Flux-KV/internal/config"
+	"errors"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+var testCouriers = []GeoPoint{
+	{Member: "palermo", Longitude: 13.361389, Latitude: 38.115556},
+	{Member: "catania", Longitude: 15.087269, Latitude: 37.502669},
+	{Member: "agrigento", Longitude: 13.583333, Latitude: 37.316667},
+	{Member: "tokyo", Longitude: 139.6917, Latitude: 35.6895},
+}
+
+// TestGeo_AddPosDist 验证编码精度和距离计算
+func TestGeo_AddPosDist(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	if n, err := db.GeoAdd("couriers", testCouriers); err != nil || n != 4 {
+		t.Fatalf("GeoAdd: want 4 added, got %d, %v", n, err)
+	}
+	// 更新已有成员不计入新增
+	if n, _ := db.GeoAdd("couriers", testCouriers[:1]); n != 0 {
+		t.Fatalf("want 0 added on update, got %d", n)
+	}
+	if _, err := db.GeoAdd("couriers", []GeoPoint{{Member: "x", Longitude: 0, Latitude: 86}}); !errors.Is(err, ErrInvalidCoordinates) {
+		t.Fatalf("want ErrInvalidCoordinates, got %v", err)
+	}
+
+	pos, _ := db.GeoPos("couriers", "palermo", "missing")
+	if pos[1] != nil {
+		t.Fatalf("want nil for missing member, got %+v", pos[1])
+	}
+	if math.Abs(pos[0].Longitude-13.361389) > 1e-5 || math.Abs(pos[0].Latitude-38.115556) > 1e-5 {
+		t.Fatalf("decoded position too far: %+v", pos[0])
+	}
+
+	// Redis 文档中 Palermo 到 Catania 为 166274.1516 米
+	dist, ok, _ := db.GeoDist("couriers", "palermo", "catania")
+	if !ok || math.Abs(dist-166274.15) > 1 {
+		t.Fatalf("unexpected distance %.2f (ok=%v)", dist, ok)
+	}
+}
+
+// TestGeo_Search 圆形和矩形搜索、排序与数量限制
+func TestGeo_Search(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	db.GeoAdd("couriers", testCouriers)
+
+	results, err := db.GeoSearch("couriers", GeoSearchQuery{
+		Longitude: 15, Latitude: 37, Radius: 200 * 1000, Sort: GeoSortAsc,
+	})
+	if err != nil {
+		t.Fatalf("GeoSearch failed: %v", err)
+	}
+	want := []string{"catania", "agrigento", "palermo"}
+	if len(results) != len(want) {
+		t.Fatalf("want %v, got %+v", want, results)
+	}
+	for i, r := range results {
+		if r.Member != want[i] {
+			t.Fatalf("result %d: want %s, got %s", i, want[i], r.Member)
+		}
+	}
+
+	// COUNT 不带 ANY 返回最近的
+	results, _ = db.GeoSearch("couriers", GeoSearchQuery{Member: "palermo", Radius: 500 * 1000, Count: 2, Sort: GeoSortDesc})
+	if len(results) != 2 || results[0].Member != "catania" || results[1].Member != "agrigento" {
+		t.Fatalf("unexpected desc results: %+v", results)
+	}
+
+	// 400km x 400km 的矩形只包含西西里的三个点
+	results, _ = db.GeoSearch("couriers", GeoSearchQuery{Longitude: 14, Latitude: 37.7, Width: 400 * 1000, Height: 400 * 1000})
+	if len(results) != 3 {
+		t.Fatalf("want 3 in box, got %+v", results)
+	}
+
+	// 半径覆盖半个地球时退化为全量扫描
+	results, _ = db.GeoSearch("couriers", GeoSearchQuery{Longitude: 13, Latitude: 38, Radius: 15000 * 1000})
+	if len(results) != 4 {
+		t.Fatalf("want 4 for huge radius, got %+v", results)
+	}
+
+	if _, err := db.GeoSearch("couriers", GeoSearchQuery{Member: "nobody", Radius: 1}); !errors.Is(err, ErrNoSuchMember) {
+		t.Fatalf("want ErrNoSuchMember, got %v", err)
+	}
+}
+
+// TestGeo_AofReplay 坐标可以从 AOF 恢复
+func TestGeo_AofReplay(t *testing.T) {
+	cfg := &config.Config{
+		AOF: config.AOFConfig{Filename: filepath.Join(t.TempDir(), "geo.aof")},
+	}
+	db, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("NewMemDB failed: %v", err)
+	}
+	db.GeoAdd("couriers", testCouriers)
+	db.GeoAdd("couriers", []GeoPoint{{Member: "palermo", Longitude: 13.5, Latitude: 38}})
+	db.Close()
+
+	db2, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	defer db2.Close()
+
+	pos, _ := db2.GeoPos("couriers", "palermo", "tokyo")
+	if pos[0] == nil || math.Abs(pos[0].Longitude-13.5) > 1e-5 || pos[1] == nil {
+		t.Fatalf("unexpected positions after replay: %+v %+v", pos[0], pos[1])
+	}
+}
+
+// TestGeo_SearchMatchesBruteForce 网格扫描的结果与逐个计算距离一致
+func TestGeo_SearchMatchesBruteForce(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	rng := rand.New(rand.NewSource(1))
+
+	points := make([]GeoPoint, 2000)
+	for i := range points {
+		points[i] = GeoPoint{
+			Member:    strconv.Itoa(i),
+			Longitude: rng.Float64()*360 - 180,
+			Latitude:  rng.Float64()*170 - 85,
+		}
+	}
+	db.GeoAdd("p", points)
+
+	for i := 0; i < 50; i++ {
+		center := points[rng.Intn(len(points))]
+		radius := math.Pow(10, 3+rng.Float64()*4) // 1km ~ 10000km
+		results, _ := db.GeoSearch("p", GeoSearchQuery{Member: center.Member, Radius: radius})
+
+		want := 0
+		pos, _ := db.GeoPos("p", center.Member)
+		for _, p := range points {
+			q, _ := db.GeoPos("p", p.Member)
+			if geoDistance(pos[0].Longitude, pos[0].Latitude, q[0].Longitude, q[0].Latitude) <= radius {
+				want++
+			}
+		}
+		if len(results) != want {
+			t.Fatalf("center %+v radius %.0f: want %d, got %d", center, radius, want, len(results))
+		}
+	}
+}
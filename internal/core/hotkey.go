@@ -0,0 +1,137 @@
+package core
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 热点 Key 追踪的默认参数（配置为 0 时使用）
+const (
+	defaultHotKeySampleRate    = 10
+	defaultHotKeyTopK          = 32
+	defaultHotKeyDecayInterval = time.Minute
+
+	sketchWidth = 4096
+	sketchDepth = 4
+)
+
+// KeyStat 热点/大 Key 报告中的单条记录
+type KeyStat struct {
+	Key   string
+	Type  string
+	Count uint64 // 估算访问次数（热点 Key）
+	Size  int64  // 估算字节数（大 Key）
+}
+
+// hotKeyTracker 基于采样 + Count-Min Sketch 的热点 Key 追踪器
+// 只有被采样到的访问才会加锁，避免拖慢读写热路径
+type hotKeyTracker struct {
+	sampleRate    uint64
+	topK          int
+	decayInterval time.Duration
+
+	seq atomic.Uint64 // 访问计数器，用于决定是否采样
+
+	mu        sync.Mutex
+	sketch    *countMinSketch
+	top       map[string]uint32 // 当前 Top-K 候选及其估算值
+	lastDecay time.Time
+}
+
+func newHotKeyTracker(sampleRate, topK int, decayInterval time.Duration) *hotKeyTracker {
+	if sampleRate <= 0 {
+		sampleRate = defaultHotKeySampleRate
+	}
+	if topK <= 0 {
+		topK = defaultHotKeyTopK
+	}
+	if decayInterval <= 0 {
+		decayInterval = defaultHotKeyDecayInterval
+	}
+	return &hotKeyTracker{
+		sampleRate:    uint64(sampleRate),
+		topK:          topK,
+		decayInterval: decayInterval,
+		sketch:        newCountMinSketch(sketchWidth, sketchDepth),
+		top:           make(map[string]uint32),
+		lastDecay:     time.Now(),
+	}
+}
+
+// touch 记录一次访问
+func (t *hotKeyTracker) touch(key string) {
+	// 1. 采样：每 sampleRate 次访问只记录一次
+	if t.seq.Add(1)%t.sampleRate != 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// 2. 到期则衰减
+	if time.Since(t.lastDecay) >= t.decayInterval {
+		t.sketch.Decay()
+		for k, v := range t.top {
+			if v >>= 1; v == 0 {
+				delete(t.top, k)
+			} else {
+				t.top[k] = v
+			}
+		}
+		t.lastDecay = time.Now()
+	}
+
+	// 3. 更新 Sketch，并尝试进入 Top-K
+	est := t.sketch.Add(key, 1)
+	if _, ok := t.top[key]; ok || len(t.top) < t.topK {
+		t.top[key] = est
+		return
+	}
+
+	// 4. Top-K 已满，替换掉最小的候选
+	minKey, minVal := "", ^uint32(0)
+	for k, v := range t.top {
+		if v < minVal {
+			minKey, minVal = k, v
+		}
+	}
+	if est > minVal {
+		delete(t.top, minKey)
+		t.top[key] = est
+	}
+}
+
+// report 返回按访问频率降序排列的前 n 个热点 Key
+// 返回的次数已按采样率放大，是对真实访问次数的估算
+func (t *hotKeyTracker) report(n int) []KeyStat {
+	t.mu.Lock()
+	result := make([]KeyStat, 0, len(t.top))
+	for k, v := range t.top {
+		result = append(result, KeyStat{Key: k, Count: uint64(v) * t.sampleRate})
+	}
+	t.mu.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Key < result[j].Key
+	})
+	if n > 0 && len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// HotKeys 返回访问最频繁的 n 个 Key（n <= 0 表示全部候选）
+func (db *MemDB) HotKeys(n int) []KeyStat {
+	result := db.hotKeys.report(n)
+	for i := range result {
+		if val, ok := db.peek(result[i].Key); ok {
+			result[i].Type = typeName(val)
+		}
+	}
+	return result
+}
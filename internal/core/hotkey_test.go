@@ -0,0 +1,56 @@
+package core
+
+import (
+	"Flux-KV/internal/config"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestMemDB_HotKeys 验证被频繁访问的 Key 排在热点报告的最前面
+func TestMemDB_HotKeys(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{
+		HotKey: config.HotKeyConfig{SampleRate: 1, TopK: 8},
+	})
+
+	db.Set("hot", "v", 0)
+	for i := 0; i < 1000; i++ {
+		db.Get("hot")
+	}
+	for i := 0; i < 100; i++ {
+		db.Get(fmt.Sprintf("cold-%d", i))
+	}
+
+	report := db.HotKeys(3)
+	if len(report) == 0 || report[0].Key != "hot" {
+		t.Fatalf("expected 'hot' to be the hottest key, got %+v", report)
+	}
+	if report[0].Count < 1000 {
+		t.Errorf("count should not be underestimated, got %d", report[0].Count)
+	}
+	if report[0].Type != "string" {
+		t.Errorf("type: want string, got %s", report[0].Type)
+	}
+}
+
+// TestMemDB_BigKeys 验证按需扫描能找到超过阈值的 Key，删除后会被移除
+func TestMemDB_BigKeys(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{
+		BigKey: config.BigKeyConfig{ThresholdBytes: 1024},
+	})
+
+	db.Set("small", "v", 0)
+	db.Set("big", strings.Repeat("x", 4096), 0)
+	db.Set("bigger", strings.Repeat("x", 8192), 0)
+
+	report := db.BigKeys(0)
+	if len(report) != 2 || report[0].Key != "bigger" || report[1].Key != "big" {
+		t.Fatalf("unexpected big keys: %+v", report)
+	}
+
+	db.Del("bigger")
+	report = db.BigKeys(0)
+	if len(report) != 1 || report[0].Key != "big" {
+		t.Fatalf("deleted key should be removed: %+v", report)
+	}
+}
@@ -0,0 +1,258 @@
+package core
+
+import (
+	"Flux-KV/internal/aof"
+	"Flux-KV/pkg/kverrors"
+	"encoding/base64"
+	"math"
+	"math/bits"
+	"time"
+)
+
+const (
+	hllPrecision = 14                // 用哈希的低 14 位选择寄存器
+	hllRegisters = 1 << hllPrecision // 16384 个寄存器，标准误差约 0.81%
+	hllBits      = 6                 // 每个寄存器 6 bit，足够存下 64 - 14 + 1
+	hllBytes     = hllRegisters*hllBits/8 + 1
+)
+
+// 二进制编码的头部，便于下游区分类型
+const hllMagic = "HLL1"
+
+// ErrInvalidHLL 恢复的数据不是合法的 HyperLogLog
+var ErrInvalidHLL = kverrors.New(kverrors.WrongType, "invalid HyperLogLog encoding")
+
+// HyperLogLog 基数估算，寄存器按 6 bit 紧凑存储，固定占用约 12KB
+type HyperLogLog struct {
+	regs []byte
+}
+
+func newHyperLogLog() *HyperLogLog {
+	return &HyperLogLog{regs: make([]byte, hllBytes)}
+}
+
+// MemSize 估算占用的内存
+func (h *HyperLogLog) MemSize() int64 {
+	return int64(len(h.regs))
+}
+
+// get 读取第 i 个寄存器（末尾多分配一个字节，跨字节读取不会越界）
+func (h *HyperLogLog) get(i int) uint8 {
+	pos := i * hllBits
+	b, off := pos/8, uint(pos%8)
+	v := uint16(h.regs[b]) | uint16(h.regs[b+1])<<8
+	return uint8(v>>off) & (1<<hllBits - 1)
+}
+
+// set 写入第 i 个寄存器
+func (h *HyperLogLog) set(i int, val uint8) {
+	pos := i * hllBits
+	b, off := pos/8, uint(pos%8)
+	v := uint16(h.regs[b]) | uint16(h.regs[b+1])<<8
+	v &^= (1<<hllBits - 1) << off
+	v |= uint16(val) << off
+	h.regs[b], h.regs[b+1] = byte(v), byte(v>>8)
+}
+
+// Add 添加元素，返回是否有寄存器被更新（即估算值可能变化）
+func (h *HyperLogLog) Add(elem string) bool {
+	x := hash64(elem)
+	idx := int(x & (hllRegisters - 1))
+	// 剩余 50 位中第一个 1 出现的位置
+	rank := uint8(bits.TrailingZeros64(x>>hllPrecision|1<<(64-hllPrecision)) + 1)
+	if rank > h.get(idx) {
+		h.set(idx, rank)
+		return true
+	}
+	return false
+}
+
+// Count 估算基数，小基数时使用线性计数修正
+func (h *HyperLogLog) Count() uint64 {
+	sum, zeros := 0.0, 0
+	for i := 0; i < hllRegisters; i++ {
+		r := h.get(i)
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	m := float64(hllRegisters)
+	alpha := 0.7213 / (1 + 1.079/m)
+	est := alpha * m * m / sum
+	if est <= 2.5*m && zeros > 0 {
+		est = m * math.Log(m/float64(zeros))
+	}
+	return uint64(est + 0.5)
+}
+
+// Merge 合并另一个 HyperLogLog（逐个寄存器取最大值）
+func (h *HyperLogLog) Merge(o *HyperLogLog) {
+	for i := 0; i < hllRegisters; i++ {
+		if r := o.get(i); r > h.get(i) {
+			h.set(i, r)
+		}
+	}
+}
+
+func (h *HyperLogLog) clone() *HyperLogLog {
+	return &HyperLogLog{regs: append([]byte(nil), h.regs...)}
+}
+
+// MarshalBinary 编码为 "HLL1" + 寄存器
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	return append([]byte(hllMagic), h.regs...), nil
+}
+
+// UnmarshalBinary 从 MarshalBinary 的结果恢复
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) != len(hllMagic)+hllBytes || string(data[:len(hllMagic)]) != hllMagic {
+		return ErrInvalidHLL
+	}
+	h.regs = append([]byte(nil), data[len(hllMagic):]...)
+	return nil
+}
+
+// hash64 64 位 FNV-1a 加 murmur3 的 fmix64 收尾，让低位也足够均匀
+func hash64(s string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// PFAdd 向 HyperLogLog 添加元素，Key 不存在时创建；返回估算值是否可能发生变化
+func (db *MemDB) PFAdd(key string, elems ...string) (bool, error) {
+	defer db.stats.record("pfadd", time.Now())
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	h, found, err := lookupValue[*HyperLogLog](s, key)
+	if err != nil {
+		s.mu.Unlock()
+		return false, err
+	}
+	changed := !found
+	if !found {
+		h = newHyperLogLog()
+		s.data[key] = &Item{Val: h}
+	}
+	for _, e := range elems {
+		if h.Add(e) {
+			changed = true
+		}
+	}
+	if changed {
+		db.notify(WatchPut, key, nil)
+		db.writeAof(aof.Cmd{Type: "pfadd", Key: key, Args: elems})
+		db.publishSnapshot(key, h)
+	}
+	s.mu.Unlock()
+
+	db.hotKeys.touch(key)
+	return changed, nil
+}
+
+// PFCount 估算基数，多个 Key 时返回并集的基数；不存在的 Key 视为空集
+func (db *MemDB) PFCount(keys ...string) (uint64, error) {
+	defer db.stats.record("pfcount", time.Now())
+
+	merged, err := db.mergeHLL(keys)
+	if err != nil || merged == nil {
+		return 0, err
+	}
+	return merged.Count(), nil
+}
+
+// PFMerge 把 sources 合并到 dest（dest 原有的数据也参与合并），dest 不存在时创建
+func (db *MemDB) PFMerge(dest string, sources ...string) error {
+	defer db.stats.record("pfmerge", time.Now())
+
+	merged, err := db.mergeHLL(sources)
+	if err != nil {
+		return err
+	}
+
+	s := db.getShard(dest)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, found, err := lookupValue[*HyperLogLog](s, dest)
+	if err != nil {
+		return err
+	}
+	if !found {
+		h = newHyperLogLog()
+		s.data[dest] = &Item{Val: h}
+	}
+	if merged != nil {
+		h.Merge(merged)
+	}
+	db.notify(WatchPut, dest, nil)
+
+	// 合并结果依赖其他 Key 当时的状态，AOF 记录最终寄存器而不是命令本身
+	data, _ := h.MarshalBinary()
+	db.writeAof(aof.Cmd{Type: "pfrestore", Key: dest, Args: []string{base64.StdEncoding.EncodeToString(data)}})
+	db.publishSnapshot(dest, h)
+	return nil
+}
+
+// mergeHLL 逐个读取并合并，所有 Key 都不存在时返回 nil
+func (db *MemDB) mergeHLL(keys []string) (*HyperLogLog, error) {
+	var merged *HyperLogLog
+	for _, key := range keys {
+		s := db.getShard(key)
+		s.mu.RLock()
+		h, found, err := lookupValue[*HyperLogLog](s, key)
+		if found {
+			if merged == nil {
+				merged = h.clone()
+			} else {
+				merged.Merge(h)
+			}
+		}
+		s.mu.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// replayHLL 重放 AOF 中的 HyperLogLog 命令，调用方持有分片写锁
+func (db *MemDB) replayHLL(s *shard, cmd aof.Cmd) error {
+	h, found, err := lookupValue[*HyperLogLog](s, cmd.Key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		h = newHyperLogLog()
+	}
+
+	switch cmd.Type {
+	case "pfadd":
+		for _, e := range cmd.Args {
+			h.Add(e)
+		}
+	case "pfrestore":
+		if len(cmd.Args) != 1 {
+			return ErrInvalidHLL
+		}
+		data, err := base64.StdEncoding.DecodeString(cmd.Args[0])
+		if err != nil {
+			return err
+		}
+		if err := h.UnmarshalBinary(data); err != nil {
+			return err
+		}
+	}
+	s.data[cmd.Key] = &Item{Val: h}
+	return nil
+}
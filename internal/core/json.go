@@ -0,0 +1,525 @@
+package core
+
+import (
+	"Flux-KV/internal/aof"
+	"Flux-KV/pkg/kverrors"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidJSON 值不是合法的 JSON
+	ErrInvalidJSON = kverrors.New(kverrors.InvalidArgument, "invalid JSON value")
+	// ErrInvalidJSONPath 路径格式错误
+	ErrInvalidJSONPath = kverrors.New(kverrors.InvalidArgument, "invalid JSON path")
+	// ErrJSONPath 路径不存在
+	ErrJSONPath = kverrors.New(kverrors.NotFound, "JSON path does not exist")
+	// ErrJSONType 路径上的值类型不符合操作要求
+	ErrJSONType = kverrors.New(kverrors.WrongType, "JSON value at path has the wrong type")
+)
+
+// jsonDelete 作为 updateJSON 回调的返回值，表示删除该节点
+var jsonDelete = &struct{}{}
+
+// pathSeg 路径中的一段：对象字段或数组下标
+type pathSeg struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parseJSONPath 解析 JSONPath 风格的路径，只支持确定的单个节点：
+//
+//	$ / .             根节点
+//	$.a.b / .a.b      对象字段
+//	$['a b']          带特殊字符的字段
+//	$.list[0] / [-1]  数组下标，负数从末尾计
+func parseJSONPath(path string) ([]pathSeg, error) {
+	p := strings.TrimPrefix(path, "$")
+	var segs []pathSeg
+	for len(p) > 0 {
+		switch p[0] {
+		case '.':
+			p = p[1:]
+			end := strings.IndexAny(p, ".[")
+			if end < 0 {
+				end = len(p)
+			}
+			if end == 0 {
+				if len(segs) == 0 && len(p) == 0 {
+					// 只有 "." 表示根节点
+					return nil, nil
+				}
+				return nil, fmt.Errorf("%w: empty field name in %q", ErrInvalidJSONPath, path)
+			}
+			segs = append(segs, pathSeg{key: p[:end]})
+			p = p[end:]
+		case '[':
+			end := strings.IndexByte(p, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unclosed bracket in %q", ErrInvalidJSONPath, path)
+			}
+			inner := p[1:end]
+			p = p[end+1:]
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				segs = append(segs, pathSeg{key: inner[1 : len(inner)-1]})
+				continue
+			}
+			i, err := strconv.Atoi(inner)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid index %q", ErrInvalidJSONPath, inner)
+			}
+			segs = append(segs, pathSeg{index: i, isIndex: true})
+		default:
+			if len(segs) == 0 && path != "" && path[0] != '$' {
+				// 兼容省略开头 "." 的写法：a.b
+				p = "." + p
+				continue
+			}
+			return nil, fmt.Errorf("%w: unexpected %q in %q", ErrInvalidJSONPath, p[0], path)
+		}
+	}
+	return segs, nil
+}
+
+// decodeJSON 解析 JSON，数字保留为 json.Number 以免丢失整数精度
+func decodeJSON(raw string) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	if dec.More() {
+		return nil, ErrInvalidJSON
+	}
+	return v, nil
+}
+
+func encodeJSON(v any) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// getJSON 读取路径上的值
+func getJSON(node any, segs []pathSeg) (any, bool) {
+	for _, seg := range segs {
+		switch n := node.(type) {
+		case map[string]any:
+			child, ok := n[seg.key]
+			if seg.isIndex || !ok {
+				return nil, false
+			}
+			node = child
+		case []any:
+			i, ok := arrayIndex(n, seg)
+			if !ok {
+				return nil, false
+			}
+			node = n[i]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+func arrayIndex(arr []any, seg pathSeg) (int, bool) {
+	if !seg.isIndex {
+		return 0, false
+	}
+	i := seg.index
+	if i < 0 {
+		i += len(arr)
+	}
+	return i, i >= 0 && i < len(arr)
+}
+
+// updateJSON 用 fn 的返回值替换路径上的节点，返回新的根节点
+// 对象中不存在的字段会以 exists=false 调用 fn（用于新增），数组越界和中间节点缺失返回 ErrJSONPath
+func updateJSON(node any, segs []pathSeg, fn func(old any, exists bool) (any, error)) (any, error) {
+	if len(segs) == 0 {
+		return fn(node, true)
+	}
+	seg, last := segs[0], len(segs) == 1
+
+	switch n := node.(type) {
+	case map[string]any:
+		if seg.isIndex {
+			return nil, ErrJSONPath
+		}
+		child, ok := n[seg.key]
+		if !ok && !last {
+			return nil, ErrJSONPath
+		}
+		var nv any
+		var err error
+		if last {
+			nv, err = fn(child, ok)
+		} else {
+			nv, err = updateJSON(child, segs[1:], fn)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if nv == jsonDelete {
+			delete(n, seg.key)
+		} else {
+			n[seg.key] = nv
+		}
+		return n, nil
+	case []any:
+		i, ok := arrayIndex(n, seg)
+		if !ok {
+			return nil, ErrJSONPath
+		}
+		var nv any
+		var err error
+		if last {
+			nv, err = fn(n[i], true)
+		} else {
+			nv, err = updateJSON(n[i], segs[1:], fn)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if nv == jsonDelete {
+			return append(n[:i], n[i+1:]...), nil
+		}
+		n[i] = nv
+		return n, nil
+	default:
+		return nil, ErrJSONPath
+	}
+}
+
+// JSONDoc JSON 文档类型的值
+type JSONDoc struct {
+	root any
+}
+
+// MemSize 估算占用的内存（按编码后的长度）
+func (d *JSONDoc) MemSize() int64 {
+	return int64(len(encodeJSON(d.root)))
+}
+
+// MarshalBinary 编码为 JSON 文本
+func (d *JSONDoc) MarshalBinary() ([]byte, error) {
+	return []byte(encodeJSON(d.root)), nil
+}
+
+// JSONSet 把 value（JSON 文本）写入 path；nx 只在路径不存在时写入，xx 只在存在时写入
+// 根路径可以创建新 Key，其他路径要求 Key 已存在；返回是否写入
+func (db *MemDB) JSONSet(key, path, value string, nx, xx bool) (bool, error) {
+	defer db.stats.record("json.set", time.Now())
+
+	segs, err := parseJSONPath(path)
+	if err != nil {
+		return false, err
+	}
+	v, err := decodeJSON(value)
+	if err != nil {
+		return false, err
+	}
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc, found, err := lookupValue[*JSONDoc](s, key)
+	if err != nil {
+		return false, err
+	}
+	if !found && len(segs) > 0 {
+		return false, ErrJSONPath
+	}
+	exists := false
+	if found {
+		_, exists = getJSON(doc.root, segs)
+	}
+	if (nx && exists) || (xx && !exists) {
+		return false, nil
+	}
+	if !found {
+		doc = &JSONDoc{}
+		s.data[key] = &Item{Val: doc}
+	}
+
+	root, err := updateJSON(doc.root, segs, func(any, bool) (any, error) { return v, nil })
+	if err != nil {
+		return false, err
+	}
+	doc.root = root
+	db.afterJSONWrite(key, doc, aof.Cmd{Type: "json.set", Key: key, Args: []string{path, value}})
+	return true, nil
+}
+
+// JSONGet 读取一个或多个路径；一个路径时返回该值，多个时返回 {path: value} 对象
+func (db *MemDB) JSONGet(key string, paths ...string) (string, bool, error) {
+	defer db.stats.record("json.get", time.Now())
+
+	if len(paths) == 0 {
+		paths = []string{"$"}
+	}
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	doc, found, err := lookupValue[*JSONDoc](s, key)
+	if err != nil || !found {
+		return "", false, err
+	}
+	db.hotKeys.touch(key)
+
+	results := make(map[string]any, len(paths))
+	for _, p := range paths {
+		segs, err := parseJSONPath(p)
+		if err != nil {
+			return "", false, err
+		}
+		v, ok := getJSON(doc.root, segs)
+		if !ok {
+			return "", false, ErrJSONPath
+		}
+		if len(paths) == 1 {
+			return encodeJSON(v), true, nil
+		}
+		results[p] = v
+	}
+	return encodeJSON(results), true, nil
+}
+
+// JSONDel 删除路径上的值，根路径删除整个 Key；返回删除的个数
+func (db *MemDB) JSONDel(key, path string) (int, error) {
+	defer db.stats.record("json.del", time.Now())
+
+	segs, err := parseJSONPath(path)
+	if err != nil {
+		return 0, err
+	}
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc, found, err := lookupValue[*JSONDoc](s, key)
+	if err != nil || !found {
+		return 0, err
+	}
+
+	if len(segs) == 0 {
+		delete(s.data, key)
+		db.notify(WatchDelete, key, nil)
+		db.jsonIndexes.update(key, nil)
+		db.writeAof(aof.Cmd{Type: "json.del", Key: key, Args: []string{path}})
+		return 1, nil
+	}
+	if _, ok := getJSON(doc.root, segs); !ok {
+		return 0, nil
+	}
+	root, err := updateJSON(doc.root, segs, func(any, bool) (any, error) { return jsonDelete, nil })
+	if err != nil {
+		return 0, err
+	}
+	doc.root = root
+	db.afterJSONWrite(key, doc, aof.Cmd{Type: "json.del", Key: key, Args: []string{path}})
+	return 1, nil
+}
+
+// JSONArrAppend 向数组末尾追加一个或多个值（JSON 文本），返回追加后的长度
+func (db *MemDB) JSONArrAppend(key, path string, values ...string) (int, error) {
+	defer db.stats.record("json.arrappend", time.Now())
+
+	segs, err := parseJSONPath(path)
+	if err != nil {
+		return 0, err
+	}
+	items := make([]any, len(values))
+	for i, raw := range values {
+		if items[i], err = decodeJSON(raw); err != nil {
+			return 0, err
+		}
+	}
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc, found, err := lookupValue[*JSONDoc](s, key)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, ErrJSONPath
+	}
+
+	var length int
+	root, err := updateJSON(doc.root, segs, func(old any, exists bool) (any, error) {
+		arr, ok := old.([]any)
+		if !exists || !ok {
+			return nil, ErrJSONType
+		}
+		arr = append(arr, items...)
+		length = len(arr)
+		return arr, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	doc.root = root
+	db.afterJSONWrite(key, doc, aof.Cmd{Type: "json.arrappend", Key: key, Args: append([]string{path}, values...)})
+	return length, nil
+}
+
+// JSONNumIncrBy 给数字加上 delta，返回新值；两者都是整数时结果保持整数
+func (db *MemDB) JSONNumIncrBy(key, path, delta string) (string, error) {
+	defer db.stats.record("json.numincrby", time.Now())
+
+	segs, err := parseJSONPath(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := strconv.ParseFloat(delta, 64); err != nil {
+		return "", fmt.Errorf("%w: delta must be a number", ErrJSONType)
+	}
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc, found, err := lookupValue[*JSONDoc](s, key)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", ErrJSONPath
+	}
+
+	var result json.Number
+	root, err := updateJSON(doc.root, segs, func(old any, exists bool) (any, error) {
+		n, ok := old.(json.Number)
+		if !exists || !ok {
+			return nil, ErrJSONType
+		}
+		sum, err := addJSONNumbers(n, json.Number(delta))
+		if err != nil {
+			return nil, err
+		}
+		result = sum
+		return sum, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	doc.root = root
+	db.afterJSONWrite(key, doc, aof.Cmd{Type: "json.numincrby", Key: key, Args: []string{path, delta}})
+	return result.String(), nil
+}
+
+func addJSONNumbers(a, b json.Number) (json.Number, error) {
+	ai, err1 := a.Int64()
+	bi, err2 := b.Int64()
+	if err1 == nil && err2 == nil {
+		sum := ai + bi
+		// 未溢出时保持整数
+		if (bi >= 0) == (sum >= ai) {
+			return json.Number(strconv.FormatInt(sum, 10)), nil
+		}
+	}
+	af, err1 := a.Float64()
+	bf, err2 := b.Float64()
+	if err1 != nil || err2 != nil {
+		return "", ErrJSONType
+	}
+	sum := af + bf
+	if math.IsInf(sum, 0) || math.IsNaN(sum) {
+		return "", fmt.Errorf("%w: result is not a finite number", ErrJSONType)
+	}
+	return json.Number(strconv.FormatFloat(sum, 'g', -1, 64)), nil
+}
+
+// afterJSONWrite 写入成功后的通用收尾：通知、索引、AOF，调用方持有分片写锁
+func (db *MemDB) afterJSONWrite(key string, doc *JSONDoc, cmd aof.Cmd) {
+	db.notify(WatchPut, key, nil)
+	db.jsonIndexes.update(key, doc)
+	db.writeAof(cmd)
+	db.publishSnapshot(key, doc)
+	db.hotKeys.touch(key)
+}
+
+// replayJSON 重放 AOF 中的 JSON 命令，调用方持有分片写锁
+// 重放期间不维护二级索引，全部重放完成后统一重建
+func (db *MemDB) replayJSON(s *shard, cmd aof.Cmd) error {
+	if len(cmd.Args) < 1 {
+		return ErrJSONPath
+	}
+	segs, err := parseJSONPath(cmd.Args[0])
+	if err != nil {
+		return err
+	}
+	doc, found, err := lookupValue[*JSONDoc](s, cmd.Key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		if cmd.Type != "json.set" || len(segs) > 0 {
+			return ErrJSONPath
+		}
+		doc = &JSONDoc{}
+		s.data[cmd.Key] = &Item{Val: doc}
+	}
+
+	var fn func(old any, exists bool) (any, error)
+	switch cmd.Type {
+	case "json.set":
+		if len(cmd.Args) != 2 {
+			return ErrInvalidJSON
+		}
+		v, err := decodeJSON(cmd.Args[1])
+		if err != nil {
+			return err
+		}
+		fn = func(any, bool) (any, error) { return v, nil }
+	case "json.del":
+		if len(segs) == 0 {
+			delete(s.data, cmd.Key)
+			return nil
+		}
+		fn = func(any, bool) (any, error) { return jsonDelete, nil }
+	case "json.arrappend":
+		fn = func(old any, _ bool) (any, error) {
+			arr, ok := old.([]any)
+			if !ok {
+				return nil, ErrJSONType
+			}
+			for _, raw := range cmd.Args[1:] {
+				v, err := decodeJSON(raw)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, v)
+			}
+			return arr, nil
+		}
+	case "json.numincrby":
+		if len(cmd.Args) != 2 {
+			return ErrJSONType
+		}
+		fn = func(old any, _ bool) (any, error) {
+			n, ok := old.(json.Number)
+			if !ok {
+				return nil, ErrJSONType
+			}
+			return addJSONNumbers(n, json.Number(cmd.Args[1]))
+		}
+	}
+
+	root, err := updateJSON(doc.root, segs, fn)
+	if err != nil {
+		return err
+	}
+	doc.root = root
+	return nil
+}
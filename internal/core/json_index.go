@@ -0,0 +1,445 @@
+package core
+
+import (
+	"Flux-KV/internal/aof"
+	"Flux-KV/pkg/kverrors"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrJSONIndexExists 同一命名空间的同一字段已经建过索引
+	ErrJSONIndexExists = kverrors.New(kverrors.Conflict, "JSON index already exists")
+	// ErrJSONNoIndex 查询的字段没有索引
+	ErrJSONNoIndex = kverrors.New(kverrors.NotFound, "no index on field")
+	// ErrJSONQuery 查询条件不合法
+	ErrJSONQuery = kverrors.New(kverrors.InvalidArgument, "invalid JSON query")
+)
+
+// 二级索引的类型
+const (
+	JSONIndexTag     = "tag"     // 等值索引：字符串、数字、布尔、null
+	JSONIndexNumeric = "numeric" // 数值索引：支持等值和范围查询
+)
+
+// JSONIndexInfo 索引的描述信息
+type JSONIndexInfo struct {
+	Namespace string
+	Field     string
+	Type      string
+	Entries   int // 已索引的 Key 数
+}
+
+// JSONQuery FIND 的查询条件
+//
+//	=        等于 Value（tag / numeric 索引均可）
+//	< <= > >=  与 Value 比较（仅 numeric 索引）
+//	between  Value <= 字段 <= Max（仅 numeric 索引）
+type JSONQuery struct {
+	Field string
+	Op    string
+	Value string
+	Max   string
+	Limit int // <= 0 表示不限制
+}
+
+// JSONMatch FIND 命中的文档
+type JSONMatch struct {
+	Key string
+	Doc string
+}
+
+// jsonNamespace 命名空间是 Key 中第一个 ':' 之前的部分，例如 user:42 属于 user
+func jsonNamespace(key string) (string, bool) {
+	ns, _, ok := strings.Cut(key, ":")
+	return ns, ok && ns != ""
+}
+
+// jsonTagValue 把标量转成等值比较用的规范形式，数字统一格式（1 和 1.0 相等）
+func jsonTagValue(v any) (string, bool) {
+	switch x := v.(type) {
+	case json.Number:
+		f, err := x.Float64()
+		if err != nil {
+			return "", false
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64), true
+	case string, bool, nil:
+		return encodeJSON(x), true
+	default:
+		// 对象和数组不参与索引
+		return "", false
+	}
+}
+
+// parseTagQueryValue 查询值是合法的 JSON 标量时按 JSON 解析，否则当作字符串
+// 因此 name = alice 与 name = "alice" 等价，查字符串 "42" 需要加引号
+func parseTagQueryValue(raw string) string {
+	if v, err := decodeJSON(raw); err == nil {
+		if tag, ok := jsonTagValue(v); ok {
+			return tag
+		}
+	}
+	return encodeJSON(raw)
+}
+
+// jsonFieldIndex 单个字段上的索引
+type jsonFieldIndex struct {
+	field string
+	segs  []pathSeg
+	kind  string
+
+	tags   map[string]map[string]struct{} // 规范值 -> Key 集合
+	values map[string]string              // Key -> 规范值，便于更新时移除旧值
+	nums   *SortedSet                     // Key 按字段数值排序
+}
+
+func newJSONFieldIndex(field, kind string) (*jsonFieldIndex, error) {
+	segs, err := parseJSONPath(field)
+	if err != nil {
+		return nil, err
+	}
+	if len(segs) == 0 {
+		return nil, fmt.Errorf("%w: cannot index the document root", ErrJSONQuery)
+	}
+	f := &jsonFieldIndex{field: field, segs: segs, kind: kind}
+	switch kind {
+	case JSONIndexTag:
+		f.tags = make(map[string]map[string]struct{})
+		f.values = make(map[string]string)
+	case JSONIndexNumeric:
+		f.nums = newSortedSet()
+	default:
+		return nil, fmt.Errorf("%w: unknown index type %q", ErrJSONQuery, kind)
+	}
+	return f, nil
+}
+
+func (f *jsonFieldIndex) len() int {
+	if f.nums != nil {
+		return f.nums.Len()
+	}
+	return len(f.values)
+}
+
+// set 用文档的最新内容更新 Key 的索引项，doc 为 nil 表示删除
+func (f *jsonFieldIndex) set(key string, doc *JSONDoc) {
+	// 1. 移除旧值
+	if f.nums != nil {
+		f.nums.Remove(key)
+	} else if old, ok := f.values[key]; ok {
+		delete(f.tags[old], key)
+		if len(f.tags[old]) == 0 {
+			delete(f.tags, old)
+		}
+		delete(f.values, key)
+	}
+	if doc == nil {
+		return
+	}
+
+	// 2. 写入新值，字段不存在或类型不符时不索引
+	v, ok := getJSON(doc.root, f.segs)
+	if !ok {
+		return
+	}
+	if f.nums != nil {
+		if n, ok := v.(json.Number); ok {
+			if score, err := n.Float64(); err == nil {
+				f.nums.Add(key, score)
+			}
+		}
+		return
+	}
+	if tag, ok := jsonTagValue(v); ok {
+		if f.tags[tag] == nil {
+			f.tags[tag] = make(map[string]struct{})
+		}
+		f.tags[tag][key] = struct{}{}
+		f.values[key] = tag
+	}
+}
+
+// jsonMatcher 校验候选文档是否仍满足条件（索引可能因 DEL / SET 覆盖而过期）
+type jsonMatcher func(v any) bool
+
+// plan 根据查询条件返回候选 Key 和校验函数，调用方持有索引读锁
+func (f *jsonFieldIndex) plan(q JSONQuery) ([]string, jsonMatcher, error) {
+	if f.tags != nil {
+		if q.Op != "=" {
+			return nil, nil, fmt.Errorf("%w: tag index on %s only supports =", ErrJSONQuery, f.field)
+		}
+		want := parseTagQueryValue(q.Value)
+		keys := make([]string, 0, len(f.tags[want]))
+		for k := range f.tags[want] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return keys, func(v any) bool {
+			tag, ok := jsonTagValue(v)
+			return ok && tag == want
+		}, nil
+	}
+
+	lo, hi, err := numericBounds(q)
+	if err != nil {
+		return nil, nil, err
+	}
+	var keys []string
+	// RangeByScore 是左闭右开区间，右边界取下一个可表示的浮点数
+	f.nums.RangeByScore(lo, math.Nextafter(hi, math.Inf(1)), func(member string, _ float64) bool {
+		keys = append(keys, member)
+		return true
+	})
+	return keys, func(v any) bool {
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		x, err := n.Float64()
+		return err == nil && x >= lo && x <= hi
+	}, nil
+}
+
+// numericBounds 把比较条件转换成闭区间 [lo, hi]
+func numericBounds(q JSONQuery) (float64, float64, error) {
+	v, err := strconv.ParseFloat(q.Value, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %q is not a number", ErrJSONQuery, q.Value)
+	}
+	inf := math.Inf(1)
+	switch strings.ToLower(q.Op) {
+	case "=":
+		return v, v, nil
+	case "<":
+		return math.Inf(-1), math.Nextafter(v, math.Inf(-1)), nil
+	case "<=":
+		return math.Inf(-1), v, nil
+	case ">":
+		return math.Nextafter(v, inf), inf, nil
+	case ">=":
+		return v, inf, nil
+	case "between":
+		hi, err := strconv.ParseFloat(q.Max, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: %q is not a number", ErrJSONQuery, q.Max)
+		}
+		return v, hi, nil
+	default:
+		return 0, 0, fmt.Errorf("%w: unknown operator %q", ErrJSONQuery, q.Op)
+	}
+}
+
+// jsonIndexes 所有命名空间的二级索引
+// 写入 JSON 文档时同步更新；DEL / SET / 过期不会通知索引，FIND 时校验并顺带清理
+type jsonIndexes struct {
+	mu     sync.RWMutex
+	fields map[string]map[string]*jsonFieldIndex // namespace -> field -> index
+}
+
+func newJSONIndexes() *jsonIndexes {
+	return &jsonIndexes{fields: make(map[string]map[string]*jsonFieldIndex)}
+}
+
+// update 更新 Key 在所属命名空间全部索引中的条目，调用方持有 Key 所在分片的锁
+func (idx *jsonIndexes) update(key string, doc *JSONDoc) {
+	ns, ok := jsonNamespace(key)
+	if !ok {
+		return
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, f := range idx.fields[ns] {
+		f.set(key, doc)
+	}
+}
+
+// define 注册索引定义，不回填数据
+func (idx *jsonIndexes) define(ns, field, kind string) (*jsonFieldIndex, error) {
+	f, err := newJSONFieldIndex(field, kind)
+	if err != nil {
+		return nil, err
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if _, exists := idx.fields[ns][field]; exists {
+		return nil, ErrJSONIndexExists
+	}
+	if idx.fields[ns] == nil {
+		idx.fields[ns] = make(map[string]*jsonFieldIndex)
+	}
+	idx.fields[ns][field] = f
+	return f, nil
+}
+
+func (idx *jsonIndexes) drop(ns, field string) bool {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if _, exists := idx.fields[ns][field]; !exists {
+		return false
+	}
+	delete(idx.fields[ns], field)
+	if len(idx.fields[ns]) == 0 {
+		delete(idx.fields, ns)
+	}
+	return true
+}
+
+// backfillJSONIndex 扫描全部分片，把命名空间下已有的文档写入索引
+// 持有分片读锁时更新索引，保证与并发写入的顺序一致
+func (db *MemDB) backfillJSONIndex(ns string, f *jsonFieldIndex) {
+	prefix := ns + ":"
+	for _, s := range db.shards {
+		s.mu.RLock()
+		db.jsonIndexes.mu.Lock()
+		for key, item := range s.data {
+			if doc, ok := item.Val.(*JSONDoc); ok && strings.HasPrefix(key, prefix) {
+				f.set(key, doc)
+			}
+		}
+		db.jsonIndexes.mu.Unlock()
+		s.mu.RUnlock()
+	}
+}
+
+// JSONCreateIndex 在命名空间的字段上创建索引，并回填已有文档
+func (db *MemDB) JSONCreateIndex(namespace, field, kind string) error {
+	defer db.stats.record("json.index.create", time.Now())
+
+	if namespace == "" || strings.Contains(namespace, ":") {
+		return fmt.Errorf("%w: invalid namespace %q", ErrJSONQuery, namespace)
+	}
+	kind = strings.ToLower(kind)
+	f, err := db.jsonIndexes.define(namespace, field, kind)
+	if err != nil {
+		return err
+	}
+	db.writeAof(aof.Cmd{Type: "json.index.create", Key: namespace, Args: []string{field, kind}})
+	db.backfillJSONIndex(namespace, f)
+	return nil
+}
+
+// JSONDropIndex 删除索引，返回是否存在
+func (db *MemDB) JSONDropIndex(namespace, field string) bool {
+	defer db.stats.record("json.index.drop", time.Now())
+
+	if !db.jsonIndexes.drop(namespace, field) {
+		return false
+	}
+	db.writeAof(aof.Cmd{Type: "json.index.drop", Key: namespace, Args: []string{field}})
+	return true
+}
+
+// JSONIndexes 列出索引，namespace 为空时返回全部
+func (db *MemDB) JSONIndexes(namespace string) []JSONIndexInfo {
+	db.jsonIndexes.mu.RLock()
+	defer db.jsonIndexes.mu.RUnlock()
+	var out []JSONIndexInfo
+	for ns, fields := range db.jsonIndexes.fields {
+		if namespace != "" && ns != namespace {
+			continue
+		}
+		for _, f := range fields {
+			out = append(out, JSONIndexInfo{Namespace: ns, Field: f.field, Type: f.kind, Entries: f.len()})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Namespace != out[j].Namespace {
+			return out[i].Namespace < out[j].Namespace
+		}
+		return out[i].Field < out[j].Field
+	})
+	return out
+}
+
+// JSONFind 按二级索引查询命名空间下的文档
+// tag 索引的结果按 Key 排序，numeric 索引的结果按字段数值升序
+func (db *MemDB) JSONFind(namespace string, q JSONQuery) ([]JSONMatch, error) {
+	defer db.stats.record("json.find", time.Now())
+
+	// 1. 在索引读锁内取出候选 Key
+	db.jsonIndexes.mu.RLock()
+	f, ok := db.jsonIndexes.fields[namespace][q.Field]
+	if !ok {
+		db.jsonIndexes.mu.RUnlock()
+		return nil, fmt.Errorf("%w %s in namespace %s", ErrJSONNoIndex, q.Field, namespace)
+	}
+	candidates, match, err := f.plan(q)
+	db.jsonIndexes.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 逐个读取文档并重新校验条件
+	var out []JSONMatch
+	for _, key := range candidates {
+		if q.Limit > 0 && len(out) >= q.Limit {
+			break
+		}
+		s := db.getShard(key)
+		s.mu.RLock()
+		doc, found, _ := lookupValue[*JSONDoc](s, key)
+		var v any
+		if found {
+			v, found = getJSON(doc.root, f.segs)
+		}
+		if !found || !match(v) {
+			// Key 已被删除、过期或覆盖，用当前内容修正索引
+			if !found {
+				doc = nil
+			}
+			db.jsonIndexes.mu.Lock()
+			f.set(key, doc)
+			db.jsonIndexes.mu.Unlock()
+			s.mu.RUnlock()
+			continue
+		}
+		out = append(out, JSONMatch{Key: key, Doc: encodeJSON(doc.root)})
+		s.mu.RUnlock()
+	}
+	return out, nil
+}
+
+// replayJSONIndex 重放索引定义，数据在 AOF 全部重放后统一回填
+func (db *MemDB) replayJSONIndex(cmd aof.Cmd) error {
+	if len(cmd.Args) < 1 {
+		return ErrJSONQuery
+	}
+	switch cmd.Type {
+	case "json.index.create":
+		if len(cmd.Args) != 2 {
+			return ErrJSONQuery
+		}
+		_, err := db.jsonIndexes.define(cmd.Key, cmd.Args[0], cmd.Args[1])
+		return err
+	case "json.index.drop":
+		db.jsonIndexes.drop(cmd.Key, cmd.Args[0])
+	}
+	return nil
+}
+
+// rebuildJSONIndexes 回填所有索引，AOF 重放结束后调用
+func (db *MemDB) rebuildJSONIndexes() {
+	db.jsonIndexes.mu.RLock()
+	type target struct {
+		ns string
+		f  *jsonFieldIndex
+	}
+	var targets []target
+	for ns, fields := range db.jsonIndexes.fields {
+		for _, f := range fields {
+			targets = append(targets, target{ns, f})
+		}
+	}
+	db.jsonIndexes.mu.RUnlock()
+
+	for _, t := range targets {
+		db.backfillJSONIndex(t.ns, t.f)
+	}
+}
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"Flux-KV/internal/config"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// TestJSON_PathOperations 路径读写、删除、追加和数值自增
+func TestJSON_PathOperations(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	if _, err := db.JSONSet("user:1", "$.name", `"alice"`, false, false); !errors.Is(err, ErrJSONPath) {
+		t.Fatalf("set on missing key: want ErrJSONPath, got %v", err)
+	}
+	if ok, err := db.JSONSet("user:1", "$", `{"name":"alice","age":30,"tags":["a"],"addr":{"city":"hz"}}`, false, false); !ok || err != nil {
+		t.Fatalf("set root failed: %v %v", ok, err)
+	}
+
+	cases := []struct {
+		path, want string
+	}{
+		{"$.name", `"alice"`},
+		{".addr.city", `"hz"`},
+		{"$['addr']['city']", `"hz"`},
+		{"tags[0]", `"a"`},
+		{"$.tags[-1]", `"a"`},
+	}
+	for _, tc := range cases {
+		got, found, err := db.JSONGet("user:1", tc.path)
+		if err != nil || !found || got != tc.want {
+			t.Fatalf("get %s: want %s, got %s %v %v", tc.path, tc.want, got, found, err)
+		}
+	}
+	if _, _, err := db.JSONGet("user:1", "$.missing"); !errors.Is(err, ErrJSONPath) {
+		t.Fatalf("want ErrJSONPath, got %v", err)
+	}
+	if _, _, err := db.JSONGet("user:1", "$.tags[x]"); !errors.Is(err, ErrInvalidJSONPath) {
+		t.Fatalf("want ErrInvalidJSONPath, got %v", err)
+	}
+
+	// NX / XX
+	if ok, _ := db.JSONSet("user:1", "$.name", `"bob"`, true, false); ok {
+		t.Fatal("NX should not overwrite an existing field")
+	}
+	if ok, _ := db.JSONSet("user:1", "$.email", `"a@x"`, false, true); ok {
+		t.Fatal("XX should not create a new field")
+	}
+	if ok, _ := db.JSONSet("user:1", "$.email", `"a@x"`, true, false); !ok {
+		t.Fatal("NX should create a new field")
+	}
+
+	if n, err := db.JSONArrAppend("user:1", "$.tags", `"b"`, `{"c":1}`); n != 3 || err != nil {
+		t.Fatalf("arrappend: want 3, got %d %v", n, err)
+	}
+	if _, err := db.JSONArrAppend("user:1", "$.name", `1`); !errors.Is(err, ErrJSONType) {
+		t.Fatalf("arrappend on string: want ErrJSONType, got %v", err)
+	}
+	if v, err := db.JSONNumIncrBy("user:1", "$.age", "2"); v != "32" || err != nil {
+		t.Fatalf("numincrby: want 32, got %s %v", v, err)
+	}
+	if v, _ := db.JSONNumIncrBy("user:1", "$.age", "0.5"); v != "32.5" {
+		t.Fatalf("numincrby float: want 32.5, got %s", v)
+	}
+
+	if n, _ := db.JSONDel("user:1", "$.tags[0]"); n != 1 {
+		t.Fatalf("del array element: want 1, got %d", n)
+	}
+	if n, _ := db.JSONDel("user:1", "$.nothing"); n != 0 {
+		t.Fatalf("del missing path: want 0, got %d", n)
+	}
+	got, _, _ := db.JSONGet("user:1", "$")
+	want := `{"addr":{"city":"hz"},"age":32.5,"email":"a@x","name":"alice","tags":["b",{"c":1}]}`
+	if got != want {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+
+	db.Set("plain", "x", 0)
+	if _, _, err := db.JSONGet("plain", "$"); !errors.Is(err, ErrWrongType) {
+		t.Fatalf("want ErrWrongType, got %v", err)
+	}
+}
+
+// TestJSON_Find 等值和数值范围查询，覆盖索引回填和过期条目的清理
+func TestJSON_Find(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	db.JSONSet("user:1", "$", `{"name":"alice","age":30,"city":"hz"}`, false, false)
+	db.JSONSet("user:2", "$", `{"name":"bob","age":17,"city":"sh"}`, false, false)
+
+	// 建索引时回填已有文档
+	if err := db.JSONCreateIndex("user", "$.city", JSONIndexTag); err != nil {
+		t.Fatalf("create index failed: %v", err)
+	}
+	if err := db.JSONCreateIndex("user", "$.age", JSONIndexNumeric); err != nil {
+		t.Fatalf("create index failed: %v", err)
+	}
+	if err := db.JSONCreateIndex("user", "$.age", JSONIndexNumeric); !errors.Is(err, ErrJSONIndexExists) {
+		t.Fatalf("want ErrJSONIndexExists, got %v", err)
+	}
+	db.JSONSet("user:3", "$", `{"name":"carol","age":45,"city":"hz"}`, false, false)
+	db.JSONSet("order:1", "$", `{"city":"hz"}`, false, false)
+
+	keys := func(q JSONQuery) []string {
+		t.Helper()
+		matches, err := db.JSONFind("user", q)
+		if err != nil {
+			t.Fatalf("find %+v failed: %v", q, err)
+		}
+		var out []string
+		for _, m := range matches {
+			out = append(out, m.Key)
+		}
+		return out
+	}
+	check := func(q JSONQuery, want ...string) {
+		t.Helper()
+		got := keys(q)
+		if len(got) != len(want) {
+			t.Fatalf("find %+v: want %v, got %v", q, want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("find %+v: want %v, got %v", q, want, got)
+			}
+		}
+	}
+
+	check(JSONQuery{Field: "$.city", Op: "=", Value: "hz"}, "user:1", "user:3")
+	check(JSONQuery{Field: "$.city", Op: "=", Value: `"sh"`}, "user:2")
+	check(JSONQuery{Field: "$.age", Op: ">=", Value: "18"}, "user:1", "user:3")
+	check(JSONQuery{Field: "$.age", Op: "<", Value: "30"}, "user:2")
+	check(JSONQuery{Field: "$.age", Op: "between", Value: "30", Max: "45"}, "user:1", "user:3")
+	check(JSONQuery{Field: "$.age", Op: ">", Value: "0", Limit: 1}, "user:2")
+
+	// 路径更新后索引跟随变化
+	db.JSONNumIncrBy("user:2", "$.age", "1")
+	db.JSONSet("user:1", "$.city", `"sh"`, false, false)
+	check(JSONQuery{Field: "$.age", Op: "=", Value: "18"}, "user:2")
+	check(JSONQuery{Field: "$.city", Op: "=", Value: "sh"}, "user:1", "user:2")
+
+	// 普通 DEL 不通知索引，查询时过滤
+	db.Del("user:2")
+	check(JSONQuery{Field: "$.city", Op: "=", Value: "sh"}, "user:1")
+
+	if _, err := db.JSONFind("user", JSONQuery{Field: "$.name", Op: "=", Value: "alice"}); !errors.Is(err, ErrJSONNoIndex) {
+		t.Fatalf("want ErrJSONNoIndex, got %v", err)
+	}
+	if _, err := db.JSONFind("user", JSONQuery{Field: "$.city", Op: ">", Value: "1"}); !errors.Is(err, ErrJSONQuery) {
+		t.Fatalf("range on tag index: want ErrJSONQuery, got %v", err)
+	}
+	if !db.JSONDropIndex("user", "$.city") || db.JSONDropIndex("user", "$.city") {
+		t.Fatal("drop index should succeed exactly once")
+	}
+}
+
+// TestJSON_AofReplay 文档和索引定义都可以从 AOF 恢复
+func TestJSON_AofReplay(t *testing.T) {
+	cfg := &config.Config{
+		AOF: config.AOFConfig{Filename: filepath.Join(t.TempDir(), "json.aof")},
+	}
+	db, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("NewMemDB failed: %v", err)
+	}
+	db.JSONSet("item:1", "$", `{"price":10,"tags":[]}`, false, false)
+	db.JSONCreateIndex("item", "$.price", JSONIndexNumeric)
+	db.JSONSet("item:2", "$", `{"price":99,"tags":[]}`, false, false)
+	db.JSONArrAppend("item:1", "$.tags", `"sale"`)
+	db.JSONNumIncrBy("item:1", "$.price", "5")
+	db.JSONSet("item:2", "$.price", `120`, false, false)
+	db.JSONDel("item:2", "$.tags")
+	db.Close()
+
+	db2, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	defer db2.Close()
+	if got, _, _ := db2.JSONGet("item:1", "$"); got != `{"price":15,"tags":["sale"]}` {
+		t.Fatalf("item:1 after replay: %s", got)
+	}
+	if got, _, _ := db2.JSONGet("item:2", "$"); got != `{"price":120}` {
+		t.Fatalf("item:2 after replay: %s", got)
+	}
+	matches, err := db2.JSONFind("item", JSONQuery{Field: "$.price", Op: ">", Value: "100"})
+	if err != nil || len(matches) != 1 || matches[0].Key != "item:2" {
+		t.Fatalf("find after replay: %+v %v", matches, err)
+	}
+}
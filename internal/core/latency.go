@@ -0,0 +1,168 @@
+package core
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// 内部事件名称
+const (
+	LatencyAofWrite = "aof-write" // 单次 AOF 写入
+	LatencyGCCycle  = "gc-cycle"  // 一轮过期清理
+	LatencyLockWait = "lock-wait" // 等待分片写锁
+)
+
+// latencyEvents 所有被追踪的事件，按输出顺序排列
+var latencyEvents = []string{LatencyAofWrite, LatencyGCCycle, LatencyLockWait}
+
+// 直方图桶数：第 i 个桶统计 <= 2^i 微秒的样本，最后一个桶收纳所有更大的样本
+const latencyBuckets = 21
+
+// LatencyOverflowUsec 溢出桶的下界（微秒，约 0.5s），超过它的样本都落入最后一个桶
+const LatencyOverflowUsec = uint64(1) << (latencyBuckets - 2)
+
+// latencyHistogram 无锁的指数分桶直方图
+type latencyHistogram struct {
+	buckets [latencyBuckets]atomic.Uint64
+	count   atomic.Uint64
+	sumUsec atomic.Uint64
+	maxUsec atomic.Uint64
+}
+
+// observe 记录一次耗时
+func (h *latencyHistogram) observe(d time.Duration) {
+	usec := uint64(d.Microseconds())
+
+	// 1. 定位桶：找到第一个 2^i >= usec 的 i
+	idx := 0
+	for idx < latencyBuckets-1 && uint64(1)<<idx < usec {
+		idx++
+	}
+	h.buckets[idx].Add(1)
+	h.count.Add(1)
+	h.sumUsec.Add(usec)
+
+	// 2. CAS 更新最大值
+	for {
+		old := h.maxUsec.Load()
+		if usec <= old || h.maxUsec.CompareAndSwap(old, usec) {
+			break
+		}
+	}
+}
+
+func (h *latencyHistogram) reset() {
+	for i := range h.buckets {
+		h.buckets[i].Store(0)
+	}
+	h.count.Store(0)
+	h.sumUsec.Store(0)
+	h.maxUsec.Store(0)
+}
+
+// LatencyBucket 直方图中的一个桶
+type LatencyBucket struct {
+	UpperUsec uint64 // 桶上界（微秒），最后一个桶为 0 表示无上界
+	Count     uint64
+}
+
+// LatencyStats 某个事件的耗时统计快照
+type LatencyStats struct {
+	Event   string
+	Count   uint64
+	AvgUsec float64
+	MaxUsec uint64
+	P50Usec uint64
+	P99Usec uint64
+	Buckets []LatencyBucket // 只包含非空的桶
+}
+
+func (h *latencyHistogram) snapshot(event string) LatencyStats {
+	st := LatencyStats{
+		Event:   event,
+		Count:   h.count.Load(),
+		MaxUsec: h.maxUsec.Load(),
+	}
+	if st.Count > 0 {
+		st.AvgUsec = float64(h.sumUsec.Load()) / float64(st.Count)
+	}
+
+	var counts [latencyBuckets]uint64
+	var total uint64
+	for i := range h.buckets {
+		counts[i] = h.buckets[i].Load()
+		total += counts[i]
+	}
+
+	var seen uint64
+	for i, c := range counts {
+		if c == 0 {
+			continue
+		}
+		upper := uint64(1) << i
+		if i == latencyBuckets-1 {
+			upper = 0
+		}
+		st.Buckets = append(st.Buckets, LatencyBucket{UpperUsec: upper, Count: c})
+
+		// 百分位数取所在桶的上界（最后一个桶用最大值）
+		seen += c
+		bound := upper
+		if bound == 0 {
+			bound = st.MaxUsec
+		}
+		if st.P50Usec == 0 && seen*100 >= total*50 {
+			st.P50Usec = bound
+		}
+		if st.P99Usec == 0 && seen*100 >= total*99 {
+			st.P99Usec = bound
+		}
+	}
+	return st
+}
+
+// latencyMonitor 按事件名管理直方图，事件集合固定，因此不需要加锁
+type latencyMonitor struct {
+	histograms map[string]*latencyHistogram
+}
+
+func newLatencyMonitor() *latencyMonitor {
+	m := &latencyMonitor{
+		histograms: make(map[string]*latencyHistogram, len(latencyEvents)),
+	}
+	for _, e := range latencyEvents {
+		m.histograms[e] = &latencyHistogram{}
+	}
+	return m
+}
+
+// observe 记录某个事件自 start 以来的耗时
+func (m *latencyMonitor) observe(event string, start time.Time) {
+	if h, ok := m.histograms[event]; ok {
+		h.observe(time.Since(start))
+	}
+}
+
+// Latency 返回指定事件的耗时直方图，不传参数表示全部事件
+// 未知事件会被忽略
+func (db *MemDB) Latency(events ...string) []LatencyStats {
+	if len(events) == 0 {
+		events = latencyEvents
+	}
+	result := make([]LatencyStats, 0, len(events))
+	for _, e := range events {
+		if h, ok := db.latency.histograms[e]; ok {
+			result = append(result, h.snapshot(e))
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Event < result[j].Event })
+	return result
+}
+
+// ResetLatency 清空所有事件的直方图
+func (db *MemDB) ResetLatency() {
+	for _, h := range db.latency.histograms {
+		h.reset()
+	}
+}
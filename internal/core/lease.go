@@ -0,0 +1,348 @@
+package core
+
+import (
+	"Flux-KV/internal/aof"
+	"Flux-KV/internal/event"
+	"Flux-KV/pkg/kverrors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrLeaseNotFound 租约不存在（从未创建、已撤销或已过期）
+	ErrLeaseNotFound = kverrors.New(kverrors.NotFound, "lease not found")
+	// ErrLeaseExists 指定的租约 ID 已被占用
+	ErrLeaseExists = kverrors.New(kverrors.Conflict, "lease already exists")
+	// ErrLeaseTTL 租约的 TTL 必须大于 0
+	ErrLeaseTTL = kverrors.New(kverrors.InvalidArgument, "lease ttl must be positive")
+)
+
+// LeaseInfo 租约的状态
+type LeaseInfo struct {
+	ID         int64
+	GrantedTTL time.Duration
+	TTL        time.Duration // 剩余时间
+	Keys       []string      // 仍绑定在租约上的 Key
+}
+
+// lease 一个租约：到期或撤销时删除所有绑定的 Key
+// keys 记录绑定时的 Item 指针，Key 之后被覆盖（包括不带租约的 SET）、删除或过期时指针不再相等：
+// 撤销时只删除指针仍相等的 Key，不会误删被其他写入接管的 Key；不再相等的记录由 pruneLeases 清理
+type lease struct {
+	id       int64
+	ttl      time.Duration
+	expireAt int64
+	keys     map[string]*Item
+	live     int // 上次清理后仍绑定的 Key 数
+}
+
+// leasePruneMin keys 超过 max(live, leasePruneMin) 的两倍时才清理，均摊到每次绑定是常数开销
+const leasePruneMin = 32
+
+// leaseTable 所有租约
+type leaseTable struct {
+	mu     sync.Mutex
+	leases map[int64]*lease
+	nextID int64
+}
+
+func newLeaseTable() *leaseTable {
+	return &leaseTable{leases: make(map[int64]*lease)}
+}
+
+// grant 创建租约，id 为 0 时自动分配
+func (t *leaseTable) grant(id int64, ttl time.Duration, now time.Time) (int64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if id == 0 {
+		t.nextID++
+		for t.leases[t.nextID] != nil {
+			t.nextID++
+		}
+		id = t.nextID
+	} else if _, exists := t.leases[id]; exists {
+		return 0, ErrLeaseExists
+	} else if id > t.nextID {
+		t.nextID = id
+	}
+	t.leases[id] = &lease{id: id, ttl: ttl, expireAt: now.Add(ttl).UnixNano(), keys: make(map[string]*Item)}
+	return id, nil
+}
+
+// attach 把 Key 绑定到租约，租约不存在或已到期时返回 false；调用方持有 Key 所在分片的写锁
+func (t *leaseTable) attach(id int64, key string, item *Item, now int64) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	l, ok := t.leases[id]
+	if !ok || l.expireAt <= now {
+		return false
+	}
+	l.keys[key] = item
+	return true
+}
+
+// remove 从表中移除租约并返回它，之后不能再绑定新的 Key
+func (t *leaseTable) remove(id int64) (*lease, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	l, ok := t.leases[id]
+	if ok {
+		delete(t.leases, id)
+	}
+	return l, ok
+}
+
+// LeaseGrant 创建租约，id 为 0 时自动分配；返回租约 ID
+func (db *MemDB) LeaseGrant(id int64, ttl time.Duration) (int64, error) {
+	defer db.stats.record("lease.grant", time.Now())
+
+	if ttl <= 0 {
+		return 0, ErrLeaseTTL
+	}
+	if id < 0 {
+		return 0, ErrLeaseNotFound
+	}
+	id, err := db.leases.grant(id, ttl, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	db.writeAof(aof.Cmd{Type: "lease.grant", Key: leaseKey(id), Args: []string{strconv.FormatInt(ttl.Milliseconds(), 10)}})
+	return id, nil
+}
+
+// LeaseKeepAlive 续期到完整的 TTL，返回 TTL
+// 续期不写 AOF：重启后所有租约都从完整的 TTL 重新计时（与 etcd 的 leader 切换行为一致）
+func (db *MemDB) LeaseKeepAlive(id int64) (time.Duration, error) {
+	defer db.stats.record("lease.keepalive", time.Now())
+
+	now := time.Now()
+	db.leases.mu.Lock()
+	defer db.leases.mu.Unlock()
+	l, ok := db.leases.leases[id]
+	if !ok || l.expireAt <= now.UnixNano() {
+		// 已过期但还没被后台清理的租约不能再续期
+		return 0, ErrLeaseNotFound
+	}
+	l.expireAt = now.Add(l.ttl).UnixNano()
+	return l.ttl, nil
+}
+
+// LeaseTimeToLive 查询租约的剩余时间，withKeys 为 true 时同时返回绑定的 Key
+func (db *MemDB) LeaseTimeToLive(id int64, withKeys bool) (LeaseInfo, error) {
+	now := time.Now().UnixNano()
+	db.leases.mu.Lock()
+	l, ok := db.leases.leases[id]
+	if !ok || l.expireAt <= now {
+		db.leases.mu.Unlock()
+		return LeaseInfo{}, ErrLeaseNotFound
+	}
+	info := LeaseInfo{ID: id, GrantedTTL: l.ttl, TTL: time.Duration(l.expireAt - now)}
+	keys := make(map[string]*Item, len(l.keys))
+	if withKeys {
+		for k, item := range l.keys {
+			keys[k] = item
+		}
+	}
+	db.leases.mu.Unlock()
+
+	// 只返回仍绑定在租约上的 Key
+	for key, item := range keys {
+		s := db.getShard(key)
+		s.mu.RLock()
+		if s.data[key] == item {
+			info.Keys = append(info.Keys, key)
+		}
+		s.mu.RUnlock()
+	}
+	sort.Strings(info.Keys)
+	return info, nil
+}
+
+// LeaseRevoke 撤销租约，原子地删除所有绑定的 Key，返回删除的 Key 数
+func (db *MemDB) LeaseRevoke(id int64) (int, error) {
+	defer db.stats.record("lease.revoke", time.Now())
+
+	l, ok := db.leases.remove(id)
+	if !ok {
+		return 0, ErrLeaseNotFound
+	}
+	return db.revokeLease(l, WatchDelete, false), nil
+}
+
+// revokeLease 删除租约上的 Key，ev 为通知 Watcher 的事件类型，重放时不通知也不写 AOF
+// 按分片序号加锁涉及的所有分片后一次性删除，其他客户端看不到只删了一部分的中间状态
+func (db *MemDB) revokeLease(l *lease, ev WatchEventType, replay bool) int {
+	// 1. 在租约表的锁内复制绑定：租约已移出表，但 pruneLeases 可能仍持有它并从 keys 中删除
+	db.leases.mu.Lock()
+	keys := make([]string, 0, len(l.keys))
+	items := make([]*Item, 0, len(l.keys))
+	for key, item := range l.keys {
+		keys = append(keys, key)
+		items = append(items, item)
+	}
+	db.leases.mu.Unlock()
+
+	// 2. 按分片序号排序加锁，避免多个撤销并发时死锁
+	order := shardOrder(keys)
+	for _, i := range order {
+		db.shards[i].mu.Lock()
+	}
+
+	// 3. 只删除仍然绑定在租约上的 Key
+	var deleted []string
+	for i, key := range keys {
+		s := db.getShard(key)
+		if s.data[key] == items[i] {
+			delete(s.data, key)
+			deleted = append(deleted, key)
+			if !replay {
+				db.notify(ev, key, nil)
+			}
+		}
+	}
+	if !replay {
+		db.writeAof(aof.Cmd{Type: "lease.revoke", Key: leaseKey(l.id)})
+	}
+
+	for i := len(order) - 1; i >= 0; i-- {
+		db.shards[order[i]].mu.Unlock()
+	}
+
+	if !replay && db.eventBus != nil {
+		for _, key := range deleted {
+			db.eventBus.Publish(event.Event{Type: event.EventDel, Key: key})
+		}
+	}
+	return len(deleted)
+}
+
+// expireLeases 撤销所有已到期的租约，由后台清理协程调用
+func (db *MemDB) expireLeases(now time.Time) {
+	var expired []*lease
+	db.leases.mu.Lock()
+	for id, l := range db.leases.leases {
+		if l.expireAt <= now.UnixNano() {
+			expired = append(expired, l)
+			delete(db.leases.leases, id)
+		}
+	}
+	db.leases.mu.Unlock()
+
+	for _, l := range expired {
+		n := db.revokeLease(l, WatchExpire, false)
+		db.stats.expiredKeys.Add(uint64(n))
+	}
+}
+
+// pruneLeases 从租约中移除已被覆盖、删除或过期的 Key，由后台清理协程调用，避免 keys 无限增长
+func (db *MemDB) pruneLeases() {
+	type binding struct {
+		l    *lease
+		key  string
+		item *Item
+	}
+
+	// 1. 复制需要清理的租约的绑定，检查时不持有租约表的锁（与 attach 的加锁顺序相反）
+	var bindings []binding
+	db.leases.mu.Lock()
+	for _, l := range db.leases.leases {
+		if len(l.keys) <= 2*max(l.live, leasePruneMin) {
+			continue
+		}
+		for key, item := range l.keys {
+			bindings = append(bindings, binding{l, key, item})
+		}
+	}
+	db.leases.mu.Unlock()
+	if len(bindings) == 0 {
+		return
+	}
+
+	// 2. 指针不再相等的绑定不会恢复：写入总是放入新的 Item
+	var stale []binding
+	for _, b := range bindings {
+		s := db.getShard(b.key)
+		s.mu.RLock()
+		if s.data[b.key] != b.item {
+			stale = append(stale, b)
+		}
+		s.mu.RUnlock()
+	}
+
+	// 3. 期间重新绑定的 Key 指针已变化，不会被移除
+	db.leases.mu.Lock()
+	for _, b := range stale {
+		if b.l.keys[b.key] == b.item {
+			delete(b.l.keys, b.key)
+		}
+	}
+	for _, b := range bindings {
+		b.l.live = len(b.l.keys)
+	}
+	db.leases.mu.Unlock()
+}
+
+// SetWithLease 写入 Key 并绑定到租约，租约到期或撤销时 Key 被删除
+// 之后不带租约的 Set 会解除绑定
+func (db *MemDB) SetWithLease(key string, val any, leaseID int64) error {
+	defer db.stats.record("set", time.Now())
+
+	s := db.getShard(key)
+	item := &Item{Val: val, Version: db.nextVersion()}
+	s.mu.Lock()
+	if !db.leases.attach(leaseID, key, item, time.Now().UnixNano()) {
+		s.mu.Unlock()
+		return ErrLeaseNotFound
+	}
+	s.data[key] = item
+	db.notify(WatchPut, key, val)
+	db.writeAof(aof.Cmd{Type: "set", Key: key, Value: val, Args: []string{strconv.FormatInt(leaseID, 10)}})
+	s.mu.Unlock()
+	db.hotKeys.touch(key)
+
+	if db.eventBus != nil {
+		db.eventBus.Publish(event.Event{
+			Type:  event.EventSet,
+			Key:   key,
+			Value: val,
+		})
+	}
+	return nil
+}
+
+// leaseKey 租约命令在 AOF 中的 Key
+func leaseKey(id int64) string {
+	return "lease:" + strconv.FormatInt(id, 10)
+}
+
+// replayLease 重放租约命令
+// 租约从重放时刻开始按完整的 TTL 计时，撤销（包括到期）按绑定时的 Item 删除 Key
+func (db *MemDB) replayLease(cmd aof.Cmd) error {
+	raw, _ := strings.CutPrefix(cmd.Key, "lease:")
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return err
+	}
+	switch cmd.Type {
+	case "lease.grant":
+		if len(cmd.Args) != 1 {
+			return ErrLeaseTTL
+		}
+		ms, err := strconv.ParseInt(cmd.Args[0], 10, 64)
+		if err != nil {
+			return err
+		}
+		_, err = db.leases.grant(id, time.Duration(ms)*time.Millisecond, time.Now())
+		return err
+	case "lease.revoke":
+		l, ok := db.leases.remove(id)
+		if !ok {
+			return ErrLeaseNotFound
+		}
+		db.revokeLease(l, WatchDelete, true)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"Flux-KV/internal/config"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// TestLease_Revoke 撤销租约删除所有仍绑定的 Key，被覆盖的 Key 不受影响
+func TestLease_Revoke(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	id, err := db.LeaseGrant(0, time.Minute)
+	if err != nil || id == 0 {
+		t.Fatalf("grant failed: %d %v", id, err)
+	}
+	if _, err := db.LeaseGrant(id, time.Minute); err != ErrLeaseExists {
+		t.Fatalf("want ErrLeaseExists, got %v", err)
+	}
+	for _, key := range []string{"sess:a", "sess:b", "sess:c"} {
+		if err := db.SetWithLease(key, "v", id); err != nil {
+			t.Fatalf("SetWithLease failed: %v", err)
+		}
+	}
+	// 不带租约的写入解除绑定
+	db.Set("sess:c", "kept", 0)
+	if err := db.SetWithLease("x", "v", 999); err != ErrLeaseNotFound {
+		t.Fatalf("want ErrLeaseNotFound, got %v", err)
+	}
+
+	info, err := db.LeaseTimeToLive(id, true)
+	if err != nil || info.GrantedTTL != time.Minute || info.TTL <= 0 {
+		t.Fatalf("ttl: %+v %v", info, err)
+	}
+	if len(info.Keys) != 2 || info.Keys[0] != "sess:a" || info.Keys[1] != "sess:b" {
+		t.Fatalf("attached keys: %v", info.Keys)
+	}
+
+	if n, err := db.LeaseRevoke(id); n != 2 || err != nil {
+		t.Fatalf("revoke: want 2, got %d %v", n, err)
+	}
+	for _, key := range []string{"sess:a", "sess:b"} {
+		if _, ok := db.Get(key); ok {
+			t.Fatalf("%s should be deleted", key)
+		}
+	}
+	if v, _ := db.Get("sess:c"); v != "kept" {
+		t.Fatalf("detached key should survive, got %v", v)
+	}
+	if _, err := db.LeaseRevoke(id); err != ErrLeaseNotFound {
+		t.Fatalf("want ErrLeaseNotFound, got %v", err)
+	}
+}
+
+// TestLease_Prune 被覆盖或删除的 Key 由后台清理从租约中移除，租约的 Key 集合不会无限增长
+func TestLease_Prune(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	id, _ := db.LeaseGrant(0, time.Minute)
+	for i := 0; i < 200; i++ {
+		db.SetWithLease("k"+strconv.Itoa(i), "v", id)
+	}
+	for i := 0; i < 100; i++ {
+		db.Del("k" + strconv.Itoa(i))
+	}
+	for i := 100; i < 150; i++ {
+		db.Set("k"+strconv.Itoa(i), "plain", 0)
+	}
+	db.activeCleanup()
+
+	db.leases.mu.Lock()
+	attached := len(db.leases.leases[id].keys)
+	db.leases.mu.Unlock()
+	if attached != 50 {
+		t.Fatalf("want 50 attached keys after prune, got %d", attached)
+	}
+	// 清理后重新绑定的 Key 仍随租约撤销
+	db.SetWithLease("k0", "v", id)
+	if n, _ := db.LeaseRevoke(id); n != 51 {
+		t.Fatalf("revoke: want 51, got %d", n)
+	}
+	if v, _ := db.Get("k100"); v != "plain" {
+		t.Fatalf("overwritten key should survive, got %v", v)
+	}
+}
+
+// TestLease_Expire 到期后由后台清理删除 Key，续期可以推迟到期
+func TestLease_Expire(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	short, _ := db.LeaseGrant(0, 30*time.Millisecond)
+	kept, _ := db.LeaseGrant(0, 30*time.Millisecond)
+	db.SetWithLease("a", "1", short)
+	db.SetWithLease("b", "2", kept)
+
+	time.Sleep(20 * time.Millisecond)
+	if ttl, err := db.LeaseKeepAlive(kept); ttl != 30*time.Millisecond || err != nil {
+		t.Fatalf("keepalive: %v %v", ttl, err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	db.activeCleanup()
+
+	if _, ok := db.Get("a"); ok {
+		t.Fatal("key on expired lease should be deleted")
+	}
+	if _, ok := db.Get("b"); !ok {
+		t.Fatal("key on renewed lease should survive")
+	}
+	if _, err := db.LeaseKeepAlive(short); err != ErrLeaseNotFound {
+		t.Fatalf("keepalive on expired lease: want ErrLeaseNotFound, got %v", err)
+	}
+}
+
+// TestLease_AofReplay 租约、绑定关系和撤销都可以从 AOF 恢复
+func TestLease_AofReplay(t *testing.T) {
+	cfg := &config.Config{
+		AOF: config.AOFConfig{Filename: filepath.Join(t.TempDir(), "lease.aof")},
+	}
+	db, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("NewMemDB failed: %v", err)
+	}
+	revoked, _ := db.LeaseGrant(0, time.Minute)
+	live, _ := db.LeaseGrant(0, time.Minute)
+	db.SetWithLease("r1", "v", revoked)
+	db.SetWithLease("l1", "v", live)
+	db.SetWithLease("l2", "v", live)
+	db.Set("l2", "plain", 0)
+	db.LeaseRevoke(revoked)
+	db.Close()
+
+	db2, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	defer db2.Close()
+	if _, ok := db2.Get("r1"); ok {
+		t.Fatal("revoked key came back after replay")
+	}
+	info, err := db2.LeaseTimeToLive(live, true)
+	if err != nil || len(info.Keys) != 1 || info.Keys[0] != "l1" {
+		t.Fatalf("lease after replay: %+v %v", info, err)
+	}
+	// 新分配的 ID 不与已有租约冲突
+	if id, _ := db2.LeaseGrant(0, time.Minute); id <= live {
+		t.Fatalf("new lease id %d should be greater than %d", id, live)
+	}
+	db2.LeaseRevoke(live)
+	if v, _ := db2.Get("l2"); v != "plain" {
+		t.Fatalf("detached key should survive revoke, got %v", v)
+	}
+}
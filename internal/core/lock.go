@@ -0,0 +1,317 @@
+package core
+
+import (
+	"Flux-KV/internal/aof"
+	"Flux-KV/pkg/kverrors"
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrLockOwner 加锁时没有指定持有者
+	ErrLockOwner = kverrors.New(kverrors.InvalidArgument, "lock owner is required")
+	// ErrLockTTL 锁的租期必须大于 0，避免持有者崩溃后永远无法释放
+	ErrLockTTL = kverrors.New(kverrors.InvalidArgument, "lock ttl must be positive")
+)
+
+// LockState 分布式锁的值：持有者和加锁时分配的 fencing token
+// 锁以普通 Key 的形式存放，租期就是 Key 的过期时间，过期后自动释放
+type LockState struct {
+	Owner string
+	Token uint64
+}
+
+// MemSize 估算占用的内存
+func (l *LockState) MemSize() int64 {
+	return int64(len(l.Owner)) + 8
+}
+
+// LockResult 加锁的结果
+// 失败时 Holder / Token / TTL 描述当前持有者；锁空闲但有排队者时 Holder 为空
+type LockResult struct {
+	Acquired bool
+	Token    uint64
+	Holder   string
+	TTL      time.Duration
+}
+
+// lockWaiter 阻塞加锁的排队者，轮到它时 ch 收到通知
+type lockWaiter struct {
+	ch chan struct{}
+}
+
+// lockQueue 每把锁的 FIFO 等待队列
+// 锁空闲时只有队首可以拿到锁，非阻塞加锁在有人排队时也会失败，避免插队导致排队者饿死
+type lockQueue struct {
+	mu sync.Mutex
+	m  map[string][]*lockWaiter
+}
+
+func newLockQueue() *lockQueue {
+	return &lockQueue{m: make(map[string][]*lockWaiter)}
+}
+
+func (q *lockQueue) enqueue(name string) *lockWaiter {
+	w := &lockWaiter{ch: make(chan struct{}, 1)}
+	q.mu.Lock()
+	q.m[name] = append(q.m[name], w)
+	q.mu.Unlock()
+	return w
+}
+
+// remove 出队，出队的是队首时通知新的队首
+func (q *lockQueue) remove(name string, w *lockWaiter) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	waiters := q.m[name]
+	for i, x := range waiters {
+		if x != w {
+			continue
+		}
+		waiters = append(waiters[:i], waiters[i+1:]...)
+		if len(waiters) == 0 {
+			delete(q.m, name)
+			return
+		}
+		q.m[name] = waiters
+		if i == 0 {
+			signal(waiters[0].ch)
+		}
+		return
+	}
+}
+
+// canAcquire w 为 nil 表示非阻塞加锁，只有队列为空时才允许
+func (q *lockQueue) canAcquire(name string, w *lockWaiter) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	waiters := q.m[name]
+	return len(waiters) == 0 || waiters[0] == w
+}
+
+// wakeHead 锁被释放时通知队首
+func (q *lockQueue) wakeHead(name string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if waiters := q.m[name]; len(waiters) > 0 {
+		signal(waiters[0].ch)
+	}
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+func validateLock(owner string, ttl time.Duration) error {
+	if owner == "" {
+		return ErrLockOwner
+	}
+	if ttl <= 0 {
+		return ErrLockTTL
+	}
+	return nil
+}
+
+// LockAcquire 非阻塞加锁，成功时返回单调递增的 fencing token
+// 持有者重复加锁视为续期，token 不变，便于客户端安全地重试
+func (db *MemDB) LockAcquire(name, owner string, ttl time.Duration) (LockResult, error) {
+	defer db.stats.record("lock.acquire", time.Now())
+
+	if err := validateLock(owner, ttl); err != nil {
+		return LockResult{}, err
+	}
+	return db.tryAcquire(name, owner, ttl, nil)
+}
+
+// LockAcquireWait 阻塞加锁，按调用顺序排队，直到拿到锁或 ctx 结束
+// ctx 超时返回 Acquired=false 且不返回错误，ctx 被取消时返回 ctx.Err()
+func (db *MemDB) LockAcquireWait(ctx context.Context, name, owner string, ttl time.Duration) (LockResult, error) {
+	defer db.stats.record("lock.acquire", time.Now())
+
+	if err := validateLock(owner, ttl); err != nil {
+		return LockResult{}, err
+	}
+	res, err := db.tryAcquire(name, owner, ttl, nil)
+	if err != nil || res.Acquired {
+		return res, err
+	}
+
+	w := db.lockQueue.enqueue(name)
+	defer db.lockQueue.remove(name, w)
+	for {
+		res, err = db.tryAcquire(name, owner, ttl, w)
+		if err != nil || res.Acquired {
+			return res, err
+		}
+
+		// 被释放时由 Release 唤醒；过期不会产生通知，因此按剩余租期定时重试
+		var expired <-chan time.Time
+		var timer *time.Timer
+		if res.TTL > 0 {
+			timer = time.NewTimer(res.TTL)
+			expired = timer.C
+		}
+		select {
+		case <-w.ch:
+		case <-expired:
+		case <-ctx.Done():
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if err := ctx.Err(); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return res, nil
+			}
+			return res, err
+		}
+	}
+}
+
+// tryAcquire 尝试加锁一次
+func (db *MemDB) tryAcquire(name, owner string, ttl time.Duration, w *lockWaiter) (LockResult, error) {
+	s := db.getShard(name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, found, err := lookupValue[*LockState](s, name)
+	if err != nil {
+		return LockResult{}, err
+	}
+	now := time.Now()
+	expireAt := now.Add(ttl).UnixNano()
+
+	if found {
+		item := s.data[name]
+		if state.Owner != owner {
+			return LockResult{Holder: state.Owner, Token: state.Token, TTL: time.Duration(item.ExpireAt - now.UnixNano())}, nil
+		}
+		// 重入：刷新租期；读取时不持有锁，不能原地修改
+		renewed := *item
+		renewed.ExpireAt = expireAt
+		s.data[name] = &renewed
+		db.writeAof(aof.Cmd{Type: "lock.renew", Key: name, Args: []string{owner, strconv.FormatInt(expireAt, 10)}})
+		return LockResult{Acquired: true, Token: state.Token, Holder: owner, TTL: ttl}, nil
+	}
+	if !db.lockQueue.canAcquire(name, w) {
+		return LockResult{}, nil
+	}
+
+	token := db.lockSeq.Add(1)
+	s.data[name] = &Item{Val: &LockState{Owner: owner, Token: token}, ExpireAt: expireAt}
+	db.notify(WatchPut, name, nil)
+	db.writeAof(aof.Cmd{Type: "lock.acquire", Key: name, Args: []string{
+		owner, strconv.FormatUint(token, 10), strconv.FormatInt(expireAt, 10),
+	}})
+	return LockResult{Acquired: true, Token: token, Holder: owner, TTL: ttl}, nil
+}
+
+// LockRenew 续期，只有当前持有者可以续期；返回 false 表示锁已丢失
+func (db *MemDB) LockRenew(name, owner string, ttl time.Duration) (bool, error) {
+	defer db.stats.record("lock.renew", time.Now())
+
+	if err := validateLock(owner, ttl); err != nil {
+		return false, err
+	}
+	s := db.getShard(name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, found, err := lookupValue[*LockState](s, name)
+	if err != nil || !found || state.Owner != owner {
+		return false, err
+	}
+	expireAt := time.Now().Add(ttl).UnixNano()
+	// 读取时不持有锁，不能原地修改
+	renewed := *s.data[name]
+	renewed.ExpireAt = expireAt
+	s.data[name] = &renewed
+	db.writeAof(aof.Cmd{Type: "lock.renew", Key: name, Args: []string{owner, strconv.FormatInt(expireAt, 10)}})
+	return true, nil
+}
+
+// LockRelease 释放锁，只有当前持有者可以释放；返回是否释放成功
+func (db *MemDB) LockRelease(name, owner string) (bool, error) {
+	defer db.stats.record("lock.release", time.Now())
+
+	s := db.getShard(name)
+	s.mu.Lock()
+	state, found, err := lookupValue[*LockState](s, name)
+	if err != nil || !found || state.Owner != owner {
+		s.mu.Unlock()
+		return false, err
+	}
+	delete(s.data, name)
+	db.notify(WatchDelete, name, nil)
+	db.writeAof(aof.Cmd{Type: "lock.release", Key: name, Args: []string{owner}})
+	s.mu.Unlock()
+
+	db.lockQueue.wakeHead(name)
+	return true, nil
+}
+
+// LockInfo 查询锁的当前持有者
+func (db *MemDB) LockInfo(name string) (LockResult, bool, error) {
+	s := db.getShard(name)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	state, found, err := lookupValue[*LockState](s, name)
+	if err != nil || !found {
+		return LockResult{}, false, err
+	}
+	ttl := time.Duration(s.data[name].ExpireAt - time.Now().UnixNano())
+	return LockResult{Token: state.Token, Holder: state.Owner, TTL: ttl}, true, nil
+}
+
+// replayLock 重放锁命令，调用方持有分片写锁
+// AOF 中记录的是绝对过期时间，重启时已过期的锁直接失效；token 计数器恢复为出现过的最大值
+func (db *MemDB) replayLock(s *shard, cmd aof.Cmd) error {
+	switch cmd.Type {
+	case "lock.acquire":
+		if len(cmd.Args) != 3 {
+			return ErrLockOwner
+		}
+		token, err := strconv.ParseUint(cmd.Args[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		expireAt, err := strconv.ParseInt(cmd.Args[2], 10, 64)
+		if err != nil {
+			return err
+		}
+		if token > db.lockSeq.Load() {
+			db.lockSeq.Store(token)
+		}
+		s.data[cmd.Key] = &Item{Val: &LockState{Owner: cmd.Args[0], Token: token}, ExpireAt: expireAt}
+	case "lock.renew":
+		if len(cmd.Args) != 2 {
+			return ErrLockOwner
+		}
+		expireAt, err := strconv.ParseInt(cmd.Args[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		if item, ok := s.data[cmd.Key]; ok {
+			if l, ok := item.Val.(*LockState); ok && l.Owner == cmd.Args[0] {
+				renewed := *item
+				renewed.ExpireAt = expireAt
+				s.data[cmd.Key] = &renewed
+			}
+		}
+	case "lock.release":
+		if len(cmd.Args) != 1 {
+			return ErrLockOwner
+		}
+		if state, ok := s.data[cmd.Key]; ok {
+			if l, ok := state.Val.(*LockState); ok && l.Owner == cmd.Args[0] {
+				delete(s.data, cmd.Key)
+			}
+		}
+	}
+	return nil
+}
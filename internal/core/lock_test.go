@@ -0,0 +1,178 @@
+package core
+
+import (
+	"Flux-KV/internal/config"
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestLock_AcquireRenewRelease 加锁、重入、续期和按持有者释放
+func TestLock_AcquireRenewRelease(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	r1, err := db.LockAcquire("job", "a", time.Second)
+	if err != nil || !r1.Acquired || r1.Token == 0 {
+		t.Fatalf("first acquire: %+v %v", r1, err)
+	}
+	r2, _ := db.LockAcquire("job", "b", time.Second)
+	if r2.Acquired || r2.Holder != "a" || r2.Token != r1.Token || r2.TTL <= 0 {
+		t.Fatalf("conflicting acquire should report holder: %+v", r2)
+	}
+	// 重入不会换 token
+	if r3, _ := db.LockAcquire("job", "a", time.Second); !r3.Acquired || r3.Token != r1.Token {
+		t.Fatalf("reentrant acquire: %+v", r3)
+	}
+
+	if ok, _ := db.LockRenew("job", "b", time.Second); ok {
+		t.Fatal("non-owner must not renew")
+	}
+	if ok, _ := db.LockRelease("job", "b"); ok {
+		t.Fatal("non-owner must not release")
+	}
+	if ok, _ := db.LockRenew("job", "a", time.Minute); !ok {
+		t.Fatal("owner renew failed")
+	}
+	if info, found, _ := db.LockInfo("job"); !found || info.TTL < 30*time.Second {
+		t.Fatalf("renew did not extend ttl: %+v", info)
+	}
+	if ok, _ := db.LockRelease("job", "a"); !ok {
+		t.Fatal("owner release failed")
+	}
+
+	// 重新加锁拿到更大的 token
+	r4, _ := db.LockAcquire("job", "b", time.Second)
+	if !r4.Acquired || r4.Token <= r1.Token {
+		t.Fatalf("token must increase: %d -> %+v", r1.Token, r4)
+	}
+
+	if _, err := db.LockAcquire("job", "", time.Second); err != ErrLockOwner {
+		t.Fatalf("want ErrLockOwner, got %v", err)
+	}
+	if _, err := db.LockAcquire("job", "a", 0); err != ErrLockTTL {
+		t.Fatalf("want ErrLockTTL, got %v", err)
+	}
+	db.Set("plain", "x", 0)
+	if _, err := db.LockAcquire("plain", "a", time.Second); err != ErrWrongType {
+		t.Fatalf("want ErrWrongType, got %v", err)
+	}
+}
+
+// TestLock_Expire 租期到了之后其他人可以拿到锁
+func TestLock_Expire(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	db.LockAcquire("job", "a", 20*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	if r, _ := db.LockAcquire("job", "b", time.Second); !r.Acquired {
+		t.Fatalf("lock should expire: %+v", r)
+	}
+	if ok, _ := db.LockRelease("job", "a"); ok {
+		t.Fatal("expired owner must not release")
+	}
+}
+
+// TestLock_RenewConcurrentRead 续期和重入与不加锁的读取并发执行，需要配合 -race 运行
+func TestLock_RenewConcurrentRead(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	db.LockAcquire("job", "a", time.Second)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			db.LockRenew("job", "a", time.Second)
+			db.LockAcquire("job", "a", time.Second)
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		db.Get("job")
+	}
+	wg.Wait()
+	if _, ok := db.Get("job"); !ok {
+		t.Fatal("lock should still be held")
+	}
+}
+
+// TestLock_WaitQueue 阻塞加锁按 FIFO 排队，释放和过期都能唤醒队首
+func TestLock_WaitQueue(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	db.LockAcquire("job", "holder", time.Minute)
+
+	order := make(chan string, 2)
+	for _, owner := range []string{"w1", "w2"} {
+		go func(owner string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			r, err := db.LockAcquireWait(ctx, "job", owner, 50*time.Millisecond)
+			if err != nil || !r.Acquired {
+				order <- "fail:" + owner
+				return
+			}
+			order <- owner
+			// w1 不释放，由过期唤醒 w2
+		}(owner)
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	db.LockRelease("job", "holder")
+	if first := <-order; first != "w1" {
+		t.Fatalf("want w1 first, got %s", first)
+	}
+	if second := <-order; second != "w2" {
+		t.Fatalf("want w2 second, got %s", second)
+	}
+
+	// 超时返回未拿到锁，不返回错误
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	r, err := db.LockAcquireWait(ctx, "job", "late", time.Second)
+	if err != nil || r.Acquired {
+		t.Fatalf("want timeout without error, got %+v %v", r, err)
+	}
+}
+
+// TestLock_QueueFairness 锁空闲但有人排队时非阻塞加锁失败
+func TestLock_QueueFairness(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	w := db.lockQueue.enqueue("job")
+	if r, _ := db.LockAcquire("job", "b", time.Second); r.Acquired {
+		t.Fatal("non-blocking acquire must not jump the queue")
+	}
+	db.lockQueue.remove("job", w)
+	if r, _ := db.LockAcquire("job", "b", time.Second); !r.Acquired {
+		t.Fatal("acquire should succeed once the queue is empty")
+	}
+}
+
+// TestLock_AofReplay 重启后锁状态和 token 计数器都能恢复
+func TestLock_AofReplay(t *testing.T) {
+	cfg := &config.Config{
+		AOF: config.AOFConfig{Filename: filepath.Join(t.TempDir(), "lock.aof")},
+	}
+	db, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("NewMemDB failed: %v", err)
+	}
+	db.LockAcquire("released", "a", time.Minute)
+	db.LockRelease("released", "a")
+	held, _ := db.LockAcquire("held", "b", time.Minute)
+	db.Close()
+
+	db2, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	defer db2.Close()
+	if info, found, _ := db2.LockInfo("held"); !found || info.Holder != "b" || info.Token != held.Token {
+		t.Fatalf("held lock after replay: %+v %v", info, found)
+	}
+	if _, found, _ := db2.LockInfo("released"); found {
+		t.Fatal("released lock should stay released")
+	}
+	if r, _ := db2.LockAcquire("released", "c", time.Second); r.Token <= held.Token {
+		t.Fatalf("token must keep increasing after restart: %d -> %d", held.Token, r.Token)
+	}
+}
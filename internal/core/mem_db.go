@@ -4,9 +4,14 @@ import (
 	"Flux-KV/internal/aof"
 	"Flux-KV/internal/config"
 	"Flux-KV/internal/event"
+	"Flux-KV/pkg/acl"
+	"encoding"
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -17,6 +22,8 @@ const ShardCount = 256
 type Item struct {
 	Val      any
 	ExpireAt int64
+	Version  uint64 // 整体写入字符串值时分配的版本号，作为 memcached 的 CAS 值
+	Flags    uint32 // memcached 客户端的 flags，其他写入方式为 0
 }
 
 // 定义分片结构
@@ -30,6 +37,31 @@ type MemDB struct {
 	shards     []*shard
 	aofHandler *aof.AofHandler // 持有AOF操作对象
 	eventBus   *event.EventBus // 持有 EventBus 指针
+	stats      *dbStats        // 运行统计（INFO）
+
+	hotKeys        *hotKeyTracker // 热点 Key 追踪
+	bigKeys        *bigKeySampler // 大 Key 采样
+	bigKeyScanning bool           // 是否开启了后台大 Key 采样
+
+	slowLog *SlowLog        // 慢日志
+	latency *latencyMonitor // 内部事件耗时直方图
+
+	monitors *monitorHub // MONITOR 订阅者
+	watches  *watchHub   // Key 变更通知（未开启时为 nil）
+	pubsub   *pubSubHub  // 发布/订阅
+
+	streamWaiters *streamWaiters      // XREAD / XREADGROUP 阻塞等待
+	sketchCfg     config.SketchConfig // 概率数据结构的默认参数
+	tsIndex       *tsLabelIndex       // 时间序列的标签索引
+	jsonIndexes   *jsonIndexes        // JSON 文档的二级索引
+	lockQueue     *lockQueue          // 分布式锁的阻塞等待队列
+	lockSeq       atomic.Uint64       // 分布式锁的 fencing token 计数器
+	leases        *leaseTable         // 租约
+	scripts       *scriptCache        // EVAL 脚本缓存
+	acl           *acl.ACL            // 用户与权限，未开启时为 nil
+	versionSeq    atomic.Uint64       // Item.Version 的计数器
+
+	closeCh chan struct{} // 关闭信号，通知后台协程退出
 }
 
 // FNV-1a hash constants
@@ -55,10 +87,51 @@ func (db *MemDB) getShard(key string) *shard {
 	return db.shards[hash%ShardCount]
 }
 
+// shardOrder 返回 Key 所在分片的序号，去重并排序
+func shardOrder(keys []string) []int {
+	seen := make(map[int]bool)
+	order := make([]int, 0, len(keys))
+	for _, key := range keys {
+		i := int(fnv32(key) % ShardCount)
+		if !seen[i] {
+			seen[i] = true
+			order = append(order, i)
+		}
+	}
+	sort.Ints(order)
+	return order
+}
+
 func NewMemDB(cfg *config.Config) (*MemDB, error) {
 	db := &MemDB{
 		shards: make([]*shard, ShardCount),
+		stats:  newDBStats(),
+
+		hotKeys: newHotKeyTracker(cfg.HotKey.SampleRate, cfg.HotKey.TopK, cfg.HotKey.DecayInterval),
+		bigKeys: newBigKeySampler(cfg.BigKey.ThresholdBytes, cfg.BigKey.TopK),
+		slowLog: NewSlowLog(cfg.SlowLog.Threshold, cfg.SlowLog.MaxLen),
+		latency: newLatencyMonitor(),
+
+		monitors: newMonitorHub(),
+		watches:  newWatchHub(cfg.Watch.HistorySize),
+		pubsub:   newPubSubHub(),
+		closeCh:  make(chan struct{}),
+
+		streamWaiters: newStreamWaiters(),
+		sketchCfg:     cfg.Sketch,
+		tsIndex:       newTSLabelIndex(),
+		jsonIndexes:   newJSONIndexes(),
+		lockQueue:     newLockQueue(),
+		leases:        newLeaseTable(),
+		scripts:       newScriptCache(cfg.Script.Timeout),
+	}
+
+	// 加载用户与权限
+	users, err := cfg.ACL.NewACL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ACL: %w", err)
 	}
+	db.acl = users
 
 	// 初始化所有分片
 	for i := 0; i < ShardCount; i++ {
@@ -67,6 +140,12 @@ func NewMemDB(cfg *config.Config) (*MemDB, error) {
 		}
 	}
 
+	// 启动后台大 Key 采样
+	if cfg.BigKey.ScanInterval > 0 {
+		db.bigKeyScanning = true
+		db.startBigKeySampler(cfg.BigKey.ScanInterval)
+	}
+
 	// 初始化 RabbitMQ EventBus
 	// 缓冲区设为 10000，足够应对瞬间的并发洪峰
 	if cfg.RabbitMQ.URL != "" {
@@ -98,6 +177,11 @@ func NewMemDB(cfg *config.Config) (*MemDB, error) {
 	return db, nil
 }
 
+// ACL 返回用户表，供 gRPC / TCP 层认证和授权，未开启时为 nil
+func (db *MemDB) ACL() *acl.ACL {
+	return db.acl
+}
+
 // loadFromAof 从 AOF 文件恢复数据
 func (db *MemDB) loadFromAof() error {
 	if db.aofHandler == nil {
@@ -112,25 +196,94 @@ func (db *MemDB) loadFromAof() error {
 
 	// 重放命令，针对每个 Key 找分片锁
 	for _, cmd := range cmds {
+		if cmd.Type == "lease.grant" || cmd.Type == "lease.revoke" {
+			// 撤销租约会同时锁住多个分片，不能在单个分片锁内执行
+			if err := db.replayLease(cmd); err != nil {
+				log.Printf("⚠️ [Warning] Skip AOF command %s %s: %v", cmd.Type, cmd.Key, err)
+			}
+			continue
+		}
 		s := db.getShard(cmd.Key)
 		s.mu.Lock()
+		var compactions []tsCompaction
 		switch cmd.Type {
 		case "set":
-			s.data[cmd.Key] = &Item{
+			item := &Item{
 				Val:      cmd.Value,
 				ExpireAt: 0,
+				Version:  db.nextVersion(),
+			}
+			s.data[cmd.Key] = item
+			// 带租约写入时 Args 为租约 ID
+			if len(cmd.Args) == 1 {
+				id, _ := strconv.ParseInt(cmd.Args[0], 10, 64)
+				db.leases.attach(id, cmd.Key, item, time.Now().UnixNano())
 			}
 		case "del":
 			delete(s.data, cmd.Key)
+		case "mc.set":
+			db.replayCache(s, cmd)
+		case "pexpireat":
+			// 脚本写入带过期时间的 Key 时紧跟在 set 之后
+			if item, ok := s.data[cmd.Key]; ok && len(cmd.Args) == 1 {
+				item.ExpireAt, _ = strconv.ParseInt(cmd.Args[0], 10, 64)
+			}
+		case "xadd", "xtrim", "xgroup-create", "xgroup-destroy", "xdeliver", "xack":
+			if err := db.replayStream(s, cmd); err != nil {
+				log.Printf("⚠️ [Warning] Skip AOF command %s %s: %v", cmd.Type, cmd.Key, err)
+			}
+		case "pfadd", "pfrestore":
+			if err := db.replayHLL(s, cmd); err != nil {
+				log.Printf("⚠️ [Warning] Skip AOF command %s %s: %v", cmd.Type, cmd.Key, err)
+			}
+		case "bf.reserve", "bf.add":
+			if err := db.replayBloom(s, cmd); err != nil {
+				log.Printf("⚠️ [Warning] Skip AOF command %s %s: %v", cmd.Type, cmd.Key, err)
+			}
+		case "cms.init", "cms.incrby":
+			if err := db.replayCMS(s, cmd); err != nil {
+				log.Printf("⚠️ [Warning] Skip AOF command %s %s: %v", cmd.Type, cmd.Key, err)
+			}
+		case "geoadd":
+			if err := db.replayGeo(s, cmd); err != nil {
+				log.Printf("⚠️ [Warning] Skip AOF command %s %s: %v", cmd.Type, cmd.Key, err)
+			}
+		case "ts.create", "ts.add", "ts.createrule", "ts.deleterule":
+			var err error
+			if compactions, err = db.replayTS(s, cmd); err != nil {
+				log.Printf("⚠️ [Warning] Skip AOF command %s %s: %v", cmd.Type, cmd.Key, err)
+			}
+		case "json.set", "json.del", "json.arrappend", "json.numincrby":
+			if err := db.replayJSON(s, cmd); err != nil {
+				log.Printf("⚠️ [Warning] Skip AOF command %s %s: %v", cmd.Type, cmd.Key, err)
+			}
+		case "lock.acquire", "lock.renew", "lock.release":
+			if err := db.replayLock(s, cmd); err != nil {
+				log.Printf("⚠️ [Warning] Skip AOF command %s %s: %v", cmd.Type, cmd.Key, err)
+			}
+		case "throttle":
+			if err := db.replayThrottle(s, cmd); err != nil {
+				log.Printf("⚠️ [Warning] Skip AOF command %s %s: %v", cmd.Type, cmd.Key, err)
+			}
+		case "json.index.create", "json.index.drop":
+			if err := db.replayJSONIndex(cmd); err != nil {
+				log.Printf("⚠️ [Warning] Skip AOF command %s %s: %v", cmd.Type, cmd.Key, err)
+			}
 		}
 		s.mu.Unlock()
+		// 降采样的结果不单独记录 AOF，由源序列的样本重新推导
+		db.applyCompactions(compactions)
 	}
+	// JSON 二级索引只记录定义，数据从重放后的文档回填
+	db.rebuildJSONIndexes()
 	return nil
 }
 
 // Set 写入数据，支持过期时间(ttl: time to live)
 // ttl = 0 表示永不过期
 func (db *MemDB) Set(key string, val any, ttl time.Duration) {
+	defer db.stats.record("set", time.Now())
+
 	// 1. 定位分片
 	s := db.getShard(key)
 
@@ -140,21 +293,20 @@ func (db *MemDB) Set(key string, val any, ttl time.Duration) {
 	}
 
 	// 2. 分片加锁（细粒度）
+	lockStart := time.Now()
 	s.mu.Lock()
-	s.data[key] = &Item{val, expireAt}
+	db.latency.observe(LatencyLockWait, lockStart)
+	s.data[key] = &Item{Val: val, ExpireAt: expireAt, Version: db.nextVersion()}
+	db.notify(WatchPut, key, val)
 	s.mu.Unlock()
+	db.hotKeys.touch(key)
 
 	// 3. 写 AOF
-	if db.aofHandler != nil {
-		cmd := aof.Cmd{
-			Type:  "set",
-			Key:   key,
-			Value: val,
-		}
-		if err := db.aofHandler.Write(cmd); err != nil {
-			log.Printf("❌ AOF Write Error: %v", err)
-		}
-	}
+	db.writeAof(aof.Cmd{
+		Type:  "set",
+		Key:   key,
+		Value: val,
+	})
 
 	// 4. 投递事件到 EventBus
 	if db.eventBus != nil {
@@ -166,8 +318,27 @@ func (db *MemDB) Set(key string, val any, ttl time.Duration) {
 	}
 }
 
+// nextVersion 分配一个新的版本号，全局递增，因此同一个 Key 的版本号不会重复
+func (db *MemDB) nextVersion() uint64 {
+	return db.versionSeq.Add(1)
+}
+
 // Get 获取数据（实现惰性删除）
 func (db *MemDB) Get(key string) (any, bool) {
+	defer db.stats.record("get", time.Now())
+
+	db.hotKeys.touch(key)
+	val, ok := db.get(key)
+	if ok {
+		db.stats.hits.Add(1)
+	} else {
+		db.stats.misses.Add(1)
+	}
+	return val, ok
+}
+
+// get 是不带统计的读取逻辑
+func (db *MemDB) get(key string) (any, bool) {
 	s := db.getShard(key)
 
 	// 1. 分片读锁
@@ -195,6 +366,8 @@ func (db *MemDB) Get(key string) (any, bool) {
 		// 依然存在，且依然是过期状态，真删
 		if newItem.ExpireAt > 0 && time.Now().UnixNano() > newItem.ExpireAt {
 			delete(s.data, key)
+			db.stats.expiredKeys.Add(1)
+			db.notify(WatchExpire, key, nil)
 			return nil, false
 		}
 
@@ -205,32 +378,119 @@ func (db *MemDB) Get(key string) (any, bool) {
 	return item.Val, true
 }
 
-// Del 手动删除数据
-func (db *MemDB) Del(key string) {
+// peek 只读地查看某个 Key，不触发惰性删除也不计入统计，供诊断类功能使用
+func (db *MemDB) peek(key string) (any, bool) {
 	s := db.getShard(key)
 
-	s.mu.Lock()
-	// 删内存
-	delete(s.data, key)
-	s.mu.Unlock()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	// 写 AOF
-	if db.aofHandler != nil {
-		cmd := aof.Cmd{
+	item, ok := s.data[key]
+	if !ok || (item.ExpireAt > 0 && time.Now().UnixNano() > item.ExpireAt) {
+		return nil, false
+	}
+	return item.Val, true
+}
+
+// Del 手动删除数据，返回删除前存在（未过期）的 Key 个数
+// 每个 Key 在分片锁内判断并删除，并发删除同一个 Key 时只有一个调用计数
+func (db *MemDB) Del(keys ...string) int {
+	defer db.stats.record("del", time.Now())
+
+	n := 0
+	for _, key := range keys {
+		s := db.getShard(key)
+
+		lockStart := time.Now()
+		s.mu.Lock()
+		db.latency.observe(LatencyLockWait, lockStart)
+		// 删内存，已过期但还没被清理的 Key 同样删除，但不计数
+		item, ok := s.data[key]
+		if ok {
+			if item.ExpireAt == 0 || time.Now().UnixNano() <= item.ExpireAt {
+				n++
+			}
+			delete(s.data, key)
+			db.notify(WatchDelete, key, nil)
+		}
+		s.mu.Unlock()
+
+		// 写 AOF
+		db.writeAof(aof.Cmd{
 			Type: "del",
 			Key:  key,
+		})
+
+		// 投递删除事件
+		if db.eventBus != nil {
+			db.eventBus.Publish(event.Event{
+				Type: event.EventDel,
+				Key:  key,
+			})
 		}
-		if err := db.aofHandler.Write(cmd); err != nil {
-			log.Printf("❌ AOF Write Error: %v", err)
+	}
+	return n
+}
+
+// Exists 返回存在（未过期）的 Key 个数，重复的 Key 重复计数
+// 只记录 exists 的命令统计，不计入读命中 / 未命中，也不计入热点 Key
+func (db *MemDB) Exists(keys ...string) int {
+	defer db.stats.record("exists", time.Now())
+
+	n := 0
+	now := time.Now().UnixNano()
+	for _, key := range keys {
+		s := db.getShard(key)
+		s.mu.RLock()
+		item, ok := s.data[key]
+		if ok && (item.ExpireAt == 0 || now <= item.ExpireAt) {
+			n++
 		}
+		s.mu.RUnlock()
 	}
+	return n
+}
 
-	// 投递删除事件
-	if db.eventBus != nil {
-		db.eventBus.Publish(event.Event{
-			Type: event.EventDel,
-			Key:  key,
-		})
+// lookupValue 在持有分片锁时查找指定类型的值，不存在或已过期时 found 为 false
+// Key 存在但类型不符时返回 ErrWrongType
+func lookupValue[T any](s *shard, key string) (v T, found bool, err error) {
+	item, ok := s.data[key]
+	if !ok || (item.ExpireAt > 0 && time.Now().UnixNano() > item.ExpireAt) {
+		return v, false, nil
+	}
+	v, ok = item.Val.(T)
+	if !ok {
+		return v, false, ErrWrongType
+	}
+	return v, true, nil
+}
+
+// publishSnapshot 把复合类型的最新快照作为 Set 事件投递到 EventBus，未开启时直接返回
+func (db *MemDB) publishSnapshot(key string, v encoding.BinaryMarshaler) {
+	if db.eventBus == nil {
+		return
+	}
+	data, err := v.MarshalBinary()
+	if err != nil {
+		log.Printf("❌ Marshal %s error: %v", key, err)
+		return
+	}
+	db.eventBus.Publish(event.Event{
+		Type:  event.EventSet,
+		Key:   key,
+		Value: data,
+	})
+}
+
+// writeAof 追加一条 AOF 记录并统计耗时，未开启 AOF 时直接返回
+func (db *MemDB) writeAof(cmd aof.Cmd) {
+	if db.aofHandler == nil {
+		return
+	}
+	defer db.latency.observe(LatencyAofWrite, time.Now())
+
+	if err := db.aofHandler.Write(cmd); err != nil {
+		log.Printf("❌ AOF Write Error: %v", err)
 	}
 }
 
@@ -238,6 +498,14 @@ func (db *MemDB) Del(key string) {
 func (db *MemDB) Close() error {
 	var errs []error
 
+	// 0. 通知后台协程退出，断开所有监视器、Watcher 和订阅者
+	close(db.closeCh)
+	db.monitors.closeAll()
+	if db.watches != nil {
+		db.watches.closeAll()
+	}
+	db.pubsub.closeAll()
+
 	// 1. 关闭 EventBus
 	if db.eventBus != nil {
 		if err := db.eventBus.Close(); err != nil {
@@ -271,32 +539,46 @@ func (db *MemDB) StartGC(interval time.Duration) {
 
 // activeCleanup 遍历 map 清理过期数据
 func (db *MemDB) activeCleanup() {
-	now := time.Now().UnixNano()
+	defer db.latency.observe(LatencyGCCycle, time.Now())
+	nowTime := time.Now()
+	now := nowTime.UnixNano()
 
 	// 遍历每一个分片
 	for _, s := range db.shards {
 		// 1. 快速读锁检查
 		s.mu.RLock()
-		var expireKeys []string
+		var expireKeys, trimKeys []string
 		for key, item := range s.data {
 			if item.ExpireAt > 0 && now > item.ExpireAt {
 				expireKeys = append(expireKeys, key)
+			} else if ts, ok := item.Val.(*TimeSeries); ok && ts.needsTrim(nowTime) {
+				// 时间序列按保留时间删除过期的 chunk
+				trimKeys = append(trimKeys, key)
 			}
 		}
 		s.mu.RUnlock()
 
+		if len(trimKeys) > 0 {
+			db.trimSeries(s, trimKeys, nowTime)
+		}
+
 		// 2. 如果有需要删除的 Key，再加写锁
 		if len(expireKeys) > 0 {
 			s.mu.Lock()
-			defer s.mu.Unlock()
-
 			for _, key := range expireKeys {
 				// Double Check
 				item, exists := s.data[key]
 				if exists && item.ExpireAt > 0 && time.Now().UnixNano() > item.ExpireAt {
 					delete(s.data, key)
+					db.stats.expiredKeys.Add(1)
+					db.notify(WatchExpire, key, nil)
 				}
 			}
+			s.mu.Unlock()
 		}
 	}
+
+	// 到期的租约连同绑定的 Key 一起删除，未到期的租约清理已解除绑定的 Key
+	db.expireLeases(nowTime)
+	db.pruneLeases()
 }
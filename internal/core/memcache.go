@@ -0,0 +1,216 @@
+package core
+
+import (
+	"Flux-KV/internal/aof"
+	"Flux-KV/internal/event"
+	"Flux-KV/pkg/kverrors"
+	"strconv"
+	"time"
+)
+
+// memcached 语义的字符串操作：值带 flags，CAS 值为 Item.Version
+// 与 SET / GET 操作同一份数据，memcached 写入的值可以被其他协议读取，反之亦然
+
+var (
+	// ErrNotStored add 时 Key 已存在，或 replace 时 Key 不存在
+	ErrNotStored = kverrors.New(kverrors.Conflict, "not stored")
+	// ErrCASMismatch cas 时 Key 已被其他写入修改
+	ErrCASMismatch = kverrors.New(kverrors.Conflict, "cas token mismatch")
+	// ErrCacheMiss cas / incr / decr / touch / delete 时 Key 不存在
+	ErrCacheMiss = kverrors.New(kverrors.NotFound, "key not found")
+	// ErrNotNumber incr / decr 的值不是 64 位无符号整数
+	ErrNotNumber = kverrors.New(kverrors.InvalidArgument, "cannot increment or decrement non-numeric value")
+)
+
+// StoreMode memcached 的存储命令
+type StoreMode int
+
+const (
+	StoreSet     StoreMode = iota // 无条件写入
+	StoreAdd                      // 仅当 Key 不存在时写入
+	StoreReplace                  // 仅当 Key 存在时写入
+	StoreCAS                      // 仅当版本号与 cas 一致时写入
+)
+
+// CacheItem memcached 读取到的值
+type CacheItem struct {
+	Value string
+	Flags uint32
+	CAS   uint64
+}
+
+// lookupCache 在持有分片锁时读取字符串值，其他类型返回 ErrWrongType
+func lookupCache(s *shard, key string) (*Item, error) {
+	item, ok := s.data[key]
+	if !ok || (item.ExpireAt > 0 && time.Now().UnixNano() > item.ExpireAt) {
+		return nil, nil
+	}
+	if _, ok := item.Val.(string); !ok {
+		return nil, ErrWrongType
+	}
+	return item, nil
+}
+
+// CacheGet 读取字符串值及其 flags 和 CAS
+func (db *MemDB) CacheGet(key string) (CacheItem, bool, error) {
+	defer db.stats.record("get", time.Now())
+	db.hotKeys.touch(key)
+
+	s := db.getShard(key)
+	s.mu.RLock()
+	item, err := lookupCache(s, key)
+	s.mu.RUnlock()
+	if err != nil {
+		return CacheItem{}, false, err
+	}
+	if item == nil {
+		db.stats.misses.Add(1)
+		return CacheItem{}, false, nil
+	}
+	db.stats.hits.Add(1)
+	return CacheItem{Value: item.Val.(string), Flags: item.Flags, CAS: item.Version}, true, nil
+}
+
+// CacheStore 按 mode 写入值，成功时返回新的 CAS
+// expireAt 为过期的纳秒时间戳，0 表示永不过期
+func (db *MemDB) CacheStore(mode StoreMode, key, value string, flags uint32, expireAt int64, cas uint64) (uint64, error) {
+	defer db.stats.record("set", time.Now())
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 1. 检查条件：其他类型的值视为存在，add 不覆盖，replace / cas 返回类型错误
+	old, err := lookupCache(s, key)
+	exists := old != nil || err != nil
+	switch mode {
+	case StoreAdd:
+		if exists {
+			return 0, ErrNotStored
+		}
+	case StoreReplace:
+		if err != nil {
+			return 0, err
+		}
+		if !exists {
+			return 0, ErrNotStored
+		}
+	case StoreCAS:
+		if err != nil {
+			return 0, err
+		}
+		if !exists {
+			return 0, ErrCacheMiss
+		}
+		if old.Version != cas {
+			return 0, ErrCASMismatch
+		}
+	}
+
+	// 2. 写入
+	item := &Item{Val: value, ExpireAt: expireAt, Version: db.nextVersion(), Flags: flags}
+	db.storeCacheLocked(s, key, item)
+	return item.Version, nil
+}
+
+// CacheIncr incr / decr：值必须是十进制的 64 位无符号整数，incr 溢出时回绕，decr 最小为 0
+// 保留原有的 flags 和过期时间
+func (db *MemDB) CacheIncr(key string, delta uint64, decr bool) (uint64, error) {
+	defer db.stats.record("incr", time.Now())
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, err := lookupCache(s, key)
+	if err != nil {
+		return 0, err
+	}
+	if old == nil {
+		return 0, ErrCacheMiss
+	}
+	n, err := strconv.ParseUint(old.Val.(string), 10, 64)
+	if err != nil {
+		return 0, ErrNotNumber
+	}
+	switch {
+	case !decr:
+		n += delta
+	case delta > n:
+		n = 0
+	default:
+		n -= delta
+	}
+
+	item := &Item{Val: strconv.FormatUint(n, 10), ExpireAt: old.ExpireAt, Version: db.nextVersion(), Flags: old.Flags}
+	db.storeCacheLocked(s, key, item)
+	return n, nil
+}
+
+// CacheTouch 修改过期时间，不改变 CAS
+func (db *MemDB) CacheTouch(key string, expireAt int64) (bool, error) {
+	defer db.stats.record("touch", time.Now())
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, err := lookupCache(s, key)
+	if err != nil || old == nil {
+		return false, err
+	}
+	// 读取时不持有锁，不能原地修改
+	item := *old
+	item.ExpireAt = expireAt
+	s.data[key] = &item
+	db.writeAof(aof.Cmd{Type: "pexpireat", Key: key, Args: []string{strconv.FormatInt(expireAt, 10)}})
+	return true, nil
+}
+
+// CacheDelete 删除 Key，返回删除前是否存在
+func (db *MemDB) CacheDelete(key string) bool {
+	defer db.stats.record("del", time.Now())
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	item, ok := s.data[key]
+	if !ok || (item.ExpireAt > 0 && time.Now().UnixNano() > item.ExpireAt) {
+		s.mu.Unlock()
+		return false
+	}
+	delete(s.data, key)
+	db.notify(WatchDelete, key, nil)
+	db.writeAof(aof.Cmd{Type: "del", Key: key})
+	s.mu.Unlock()
+
+	if db.eventBus != nil {
+		db.eventBus.Publish(event.Event{Type: event.EventDel, Key: key})
+	}
+	return true
+}
+
+// storeCacheLocked 在持有分片锁时写入，并记录 AOF、通知 Watch 和 EventBus
+func (db *MemDB) storeCacheLocked(s *shard, key string, item *Item) {
+	s.data[key] = item
+	db.notify(WatchPut, key, item.Val)
+	db.hotKeys.touch(key)
+	db.writeAof(aof.Cmd{
+		Type:  "mc.set",
+		Key:   key,
+		Value: item.Val,
+		Args:  []string{strconv.FormatUint(uint64(item.Flags), 10), strconv.FormatInt(item.ExpireAt, 10)},
+	})
+	if db.eventBus != nil {
+		db.eventBus.Publish(event.Event{Type: event.EventSet, Key: key, Value: item.Val})
+	}
+}
+
+// replayCache 重放 mc.set，Args 为 flags 和过期时间
+func (db *MemDB) replayCache(s *shard, cmd aof.Cmd) {
+	if len(cmd.Args) != 2 {
+		return
+	}
+	flags, _ := strconv.ParseUint(cmd.Args[0], 10, 32)
+	expireAt, _ := strconv.ParseInt(cmd.Args[1], 10, 64)
+	s.data[cmd.Key] = &Item{Val: cmd.Value, ExpireAt: expireAt, Version: db.nextVersion(), Flags: uint32(flags)}
+}
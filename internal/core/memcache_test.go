@@ -0,0 +1,122 @@
+package core
+
+import (
+	"Flux-KV/internal/config"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestCache_StoreModes add / replace / cas 的条件与 CAS 值的变化
+func TestCache_StoreModes(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	if _, err := db.CacheStore(StoreReplace, "k", "v", 0, 0, 0); err != ErrNotStored {
+		t.Fatalf("replace missing key: %v", err)
+	}
+	cas1, err := db.CacheStore(StoreAdd, "k", "v1", 7, 0, 0)
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if _, err := db.CacheStore(StoreAdd, "k", "v2", 0, 0, 0); err != ErrNotStored {
+		t.Fatalf("add existing key: %v", err)
+	}
+	item, found, _ := db.CacheGet("k")
+	if !found || item.Value != "v1" || item.Flags != 7 || item.CAS != cas1 {
+		t.Fatalf("get after add: %+v", item)
+	}
+
+	// cas 值不一致时拒绝，一致时写入并分配新的 CAS
+	cas2, err := db.CacheStore(StoreCAS, "k", "v2", 0, 0, cas1)
+	if err != nil || cas2 == cas1 {
+		t.Fatalf("cas: %d %v", cas2, err)
+	}
+	if _, err := db.CacheStore(StoreCAS, "k", "v3", 0, 0, cas1); err != ErrCASMismatch {
+		t.Fatalf("stale cas: %v", err)
+	}
+	if _, err := db.CacheStore(StoreCAS, "missing", "v", 0, 0, 1); err != ErrCacheMiss {
+		t.Fatalf("cas missing key: %v", err)
+	}
+
+	// 其他协议的 SET 同样改变 CAS，并清空 flags
+	db.Set("k", "v4", 0)
+	if item, _, _ := db.CacheGet("k"); item.CAS == cas2 || item.Flags != 0 || item.Value != "v4" {
+		t.Fatalf("SET should bump cas: %+v", item)
+	}
+
+	// 其他类型的值
+	db.PFAdd("hll", "a")
+	if _, _, err := db.CacheGet("hll"); err != ErrWrongType {
+		t.Fatalf("get non-string: %v", err)
+	}
+	if _, err := db.CacheStore(StoreAdd, "hll", "v", 0, 0, 0); err != ErrNotStored {
+		t.Fatalf("add over non-string: %v", err)
+	}
+}
+
+// TestCache_IncrTouchDelete incr / decr 的边界、touch 和 delete
+func TestCache_IncrTouchDelete(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	db.CacheStore(StoreSet, "n", "18446744073709551615", 3, 0, 0)
+	if n, err := db.CacheIncr("n", 2, false); err != nil || n != 1 {
+		t.Fatalf("incr should wrap: %d %v", n, err)
+	}
+	if n, err := db.CacheIncr("n", 5, true); err != nil || n != 0 {
+		t.Fatalf("decr should stop at 0: %d %v", n, err)
+	}
+	if item, _, _ := db.CacheGet("n"); item.Flags != 3 || item.Value != "0" {
+		t.Fatalf("incr should keep flags: %+v", item)
+	}
+	db.CacheStore(StoreSet, "s", "abc", 0, 0, 0)
+	if _, err := db.CacheIncr("s", 1, false); err != ErrNotNumber {
+		t.Fatalf("incr non-numeric: %v", err)
+	}
+	if _, err := db.CacheIncr("missing", 1, false); err != ErrCacheMiss {
+		t.Fatalf("incr missing: %v", err)
+	}
+
+	// touch 缩短过期时间，不改变 CAS
+	before, _, _ := db.CacheGet("s")
+	if ok, _ := db.CacheTouch("s", time.Now().Add(20*time.Millisecond).UnixNano()); !ok {
+		t.Fatal("touch existing key")
+	}
+	if after, _, _ := db.CacheGet("s"); after.CAS != before.CAS {
+		t.Fatalf("touch should keep cas: %d != %d", after.CAS, before.CAS)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, found, _ := db.CacheGet("s"); found {
+		t.Fatal("key should expire after touch")
+	}
+
+	if !db.CacheDelete("n") || db.CacheDelete("n") {
+		t.Fatal("delete should report whether the key existed")
+	}
+}
+
+// TestCache_AofReplay flags 和过期时间在重启后保留
+func TestCache_AofReplay(t *testing.T) {
+	cfg := &config.Config{
+		AOF: config.AOFConfig{Filename: filepath.Join(t.TempDir(), "memcache.aof")},
+	}
+	db, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("NewMemDB failed: %v", err)
+	}
+	db.CacheStore(StoreSet, "a", "1", 42, 0, 0)
+	db.CacheIncr("a", 9, false)
+	db.CacheStore(StoreSet, "b", "x", 0, time.Now().Add(-time.Second).UnixNano(), 0)
+	db.Close()
+
+	db2, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	defer db2.Close()
+	if item, found, _ := db2.CacheGet("a"); !found || item.Value != "10" || item.Flags != 42 || item.CAS == 0 {
+		t.Fatalf("replayed item: %+v", item)
+	}
+	if _, found, _ := db2.CacheGet("b"); found {
+		t.Fatal("expired item should stay expired after replay")
+	}
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"Flux-KV/pkg/glob"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// 推送给监视器的值最多保留的字节数，防止大 Value 拖慢推送
+	monitorValueLimit = 64
+	// 默认每个监视器的缓冲区大小
+	defaultMonitorBuffer = 1024
+)
+
+// MonitorEvent 一条被执行的命令
+type MonitorEvent struct {
+	Time    time.Time
+	Client  string
+	Command string
+	Key     string
+	Value   string // 已截断
+}
+
+// String 按 Redis MONITOR 的格式输出
+// 例如: 1760000000.123456 [127.0.0.1:5555] "set" "key" "value"
+func (e MonitorEvent) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d.%06d [%s] %s", e.Time.Unix(), e.Time.Nanosecond()/1000, e.Client, strconv.Quote(e.Command))
+	if e.Key != "" {
+		sb.WriteString(" " + strconv.Quote(e.Key))
+	}
+	if e.Value != "" {
+		sb.WriteString(" " + strconv.Quote(e.Value))
+	}
+	return sb.String()
+}
+
+// Monitor 一个监视器订阅
+// 调用方从 C 读取事件，用完后必须调用 MemDB.Unmonitor 释放
+type Monitor struct {
+	C <-chan MonitorEvent
+
+	id      uint64
+	pattern string // 为空表示不过滤
+	ch      chan MonitorEvent
+	dropped atomic.Uint64
+}
+
+// Dropped 因消费过慢被丢弃的事件数
+func (m *Monitor) Dropped() uint64 {
+	return m.dropped.Load()
+}
+
+// monitorHub 管理所有监视器，向它们广播命令
+type monitorHub struct {
+	active atomic.Int32 // 监视器数量，为 0 时 Feed 直接返回，不影响写路径
+
+	mu     sync.RWMutex
+	subs   map[uint64]*Monitor
+	nextID uint64
+}
+
+func newMonitorHub() *monitorHub {
+	return &monitorHub{
+		subs: make(map[uint64]*Monitor),
+	}
+}
+
+// Monitor 注册一个监视器
+// pattern 为 Key 的 glob 过滤条件（为空表示全部），buffer 为缓冲区大小（<= 0 使用默认值）
+func (db *MemDB) Monitor(pattern string, buffer int) *Monitor {
+	if buffer <= 0 {
+		buffer = defaultMonitorBuffer
+	}
+	ch := make(chan MonitorEvent, buffer)
+	m := &Monitor{
+		C:       ch,
+		pattern: pattern,
+		ch:      ch,
+	}
+
+	h := db.monitors
+	h.mu.Lock()
+	h.nextID++
+	m.id = h.nextID
+	h.subs[m.id] = m
+	h.mu.Unlock()
+	h.active.Add(1)
+
+	return m
+}
+
+// Unmonitor 注销监视器并关闭其通道
+func (db *MemDB) Unmonitor(m *Monitor) {
+	h := db.monitors
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subs[m.id]; !ok {
+		return
+	}
+	delete(h.subs, m.id)
+	h.active.Add(-1)
+	close(m.ch)
+}
+
+// closeAll 关闭所有监视器（数据库关闭时调用），让订阅方的读取循环退出
+func (h *monitorHub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for id, m := range h.subs {
+		delete(h.subs, id)
+		close(m.ch)
+	}
+	h.active.Store(0)
+}
+
+// FeedMonitor 由 gRPC / TCP 等入口在执行命令时调用，把命令广播给所有监视器
+// 监视器跟不上时直接丢弃事件，绝不阻塞调用方
+func (db *MemDB) FeedMonitor(client, cmd, key string, val any) {
+	h := db.monitors
+	if h.active.Load() == 0 {
+		return
+	}
+
+	e := MonitorEvent{
+		Time:    time.Now(),
+		Client:  client,
+		Command: cmd,
+		Key:     key,
+	}
+	if val != nil {
+		e.Value = truncateValue(fmt.Sprintf("%v", val), monitorValueLimit)
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, m := range h.subs {
+		if m.pattern != "" && !glob.Match(m.pattern, key) {
+			continue
+		}
+		select {
+		case m.ch <- e:
+		default:
+			m.dropped.Add(1)
+		}
+	}
+}
+
+// truncateValue 截断过长的值，并标注原始长度
+func truncateValue(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	return fmt.Sprintf("%s...(%d bytes)", s[:limit], len(s))
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"Flux-KV/internal/config"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+// TestHyperLogLog_Count 估算误差应在标准误差的几倍以内
+func TestHyperLogLog_Count(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	for _, n := range []int{10, 1000, 50000} {
+		key := fmt.Sprintf("hll:%d", n)
+		for i := 0; i < n; i++ {
+			db.PFAdd(key, fmt.Sprintf("user-%d", i))
+		}
+		// 重复添加不改变估算值
+		if changed, _ := db.PFAdd(key, "user-0"); changed {
+			t.Fatalf("re-adding an element should not change registers")
+		}
+		got, _ := db.PFCount(key)
+		if diff := math.Abs(float64(got)-float64(n)) / float64(n); diff > 0.03 {
+			t.Errorf("n=%d: estimate %d, error %.2f%%", n, got, diff*100)
+		}
+	}
+
+	// 并集
+	db.PFAdd("a", "x", "y")
+	db.PFAdd("b", "y", "z")
+	if n, _ := db.PFCount("a", "b", "missing"); n != 3 {
+		t.Fatalf("want union 3, got %d", n)
+	}
+	if err := db.PFMerge("c", "a", "b"); err != nil {
+		t.Fatalf("PFMerge failed: %v", err)
+	}
+	if n, _ := db.PFCount("c"); n != 3 {
+		t.Fatalf("want merged 3, got %d", n)
+	}
+
+	db.Set("str", "v", 0)
+	if _, err := db.PFAdd("str", "x"); !errors.Is(err, ErrWrongType) {
+		t.Fatalf("want ErrWrongType, got %v", err)
+	}
+}
+
+// TestBloomFilter_FalsePositiveRate 已添加的元素一定存在，误判率接近设定值
+func TestBloomFilter_FalsePositiveRate(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	if err := db.BFReserve("bf", 0.01, 10000); err != nil {
+		t.Fatalf("BFReserve failed: %v", err)
+	}
+	if err := db.BFReserve("bf", 0.01, 10000); !errors.Is(err, ErrKeyExists) {
+		t.Fatalf("want ErrKeyExists, got %v", err)
+	}
+
+	for i := 0; i < 10000; i++ {
+		db.BFAdd("bf", fmt.Sprintf("in-%d", i))
+	}
+	for i := 0; i < 10000; i += 97 {
+		if ok, _ := db.BFExists("bf", fmt.Sprintf("in-%d", i)); !ok[0] {
+			t.Fatalf("false negative for in-%d", i)
+		}
+	}
+
+	fp := 0
+	for i := 0; i < 10000; i++ {
+		if ok, _ := db.BFExists("bf", fmt.Sprintf("out-%d", i)); ok[0] {
+			fp++
+		}
+	}
+	if rate := float64(fp) / 10000; rate > 0.02 {
+		t.Errorf("false positive rate too high: %.4f", rate)
+	}
+
+	// 自动创建
+	if added, _ := db.BFAdd("auto", "a", "a"); !added[0] || added[1] {
+		t.Fatalf("unexpected add result: %v", added)
+	}
+}
+
+// TestCountMinSketch_IncrQuery 估算值不会低于真实值
+func TestCountMinSketch_IncrQuery(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	width, depth, _ := CMSDimsByProb(0.001, 0.01)
+	if err := db.CMSInit("cms", width, depth); err != nil {
+		t.Fatalf("CMSInit failed: %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		db.CMSIncrBy("cms", []string{fmt.Sprintf("k%d", i)}, []uint32{uint32(i + 1)})
+	}
+	got, _ := db.CMSQuery("cms", "k0", "k99", "never")
+	if got[0] < 1 || got[1] < 100 {
+		t.Fatalf("estimates must not underestimate: %v", got)
+	}
+	if got[2] > 5 {
+		t.Fatalf("unexpected estimate for unseen item: %d", got[2])
+	}
+	if info, _ := db.CMSInfo("cms"); info.Count != 5050 {
+		t.Fatalf("want total 5050, got %d", info.Count)
+	}
+}
+
+// TestProbabilistic_AofReplay 三种类型都能从 AOF 恢复
+func TestProbabilistic_AofReplay(t *testing.T) {
+	cfg := &config.Config{
+		AOF: config.AOFConfig{Filename: filepath.Join(t.TempDir(), "prob.aof")},
+	}
+	db, _ := NewMemDB(cfg)
+	db.PFAdd("src", "a", "b", "c")
+	db.PFMerge("hll", "src")
+	db.BFAdd("bf", "x")
+	db.CMSIncrBy("cms", []string{"x", "y"}, []uint32{3, 4})
+	db.Close()
+
+	db2, _ := NewMemDB(cfg)
+	defer db2.Close()
+	if n, _ := db2.PFCount("hll"); n != 3 {
+		t.Fatalf("want hll count 3, got %d", n)
+	}
+	if ok, _ := db2.BFExists("bf", "x"); !ok[0] {
+		t.Fatal("bloom filter lost after replay")
+	}
+	if got, _ := db2.CMSQuery("cms", "x", "y"); got[0] < 3 || got[1] < 4 {
+		t.Fatalf("unexpected cms after replay: %v", got)
+	}
+}
@@ -0,0 +1,267 @@
+package core
+
+import (
+	"Flux-KV/pkg/glob"
+	"Flux-KV/pkg/kverrors"
+	"sync"
+)
+
+// 默认每个订阅者的缓冲区大小
+const defaultSubscriberBuffer = 1024
+
+// ErrSlowConsumer 订阅者缓冲区已满，被服务端断开
+var ErrSlowConsumer = kverrors.New(kverrors.Unavailable, "subscriber is too slow and has been disconnected")
+
+// Message 一条发布/订阅消息
+type Message struct {
+	Channel string
+	Pattern string // 通过 PSUBSCRIBE 匹配时为对应的模式，否则为空
+	Payload string
+}
+
+// String 按 Redis 推送格式输出，例如: message news hello / pmessage news.* news.tech hello
+func (m Message) String() string {
+	if m.Pattern != "" {
+		return "pmessage " + m.Pattern + " " + m.Channel + " " + m.Payload
+	}
+	return "message " + m.Channel + " " + m.Payload
+}
+
+// pubSubHub 频道与模式的订阅关系
+type pubSubHub struct {
+	mu       sync.RWMutex
+	channels map[string]map[*Subscriber]struct{}
+	patterns map[string]map[*Subscriber]struct{}
+}
+
+func newPubSubHub() *pubSubHub {
+	return &pubSubHub{
+		channels: make(map[string]map[*Subscriber]struct{}),
+		patterns: make(map[string]map[*Subscriber]struct{}),
+	}
+}
+
+// Subscriber 一个订阅者，可同时订阅多个频道和模式
+// 调用方从 C 读取消息，C 被关闭后可通过 Err 判断原因；用完必须调用 Close
+type Subscriber struct {
+	C <-chan Message
+
+	hub *pubSubHub
+	ch  chan Message
+
+	mu       sync.Mutex
+	closed   bool
+	err      error
+	channels map[string]struct{}
+	patterns map[string]struct{}
+}
+
+// NewSubscriber 创建订阅者，buffer <= 0 使用默认缓冲区大小
+func (db *MemDB) NewSubscriber(buffer int) *Subscriber {
+	if buffer <= 0 {
+		buffer = defaultSubscriberBuffer
+	}
+	ch := make(chan Message, buffer)
+	return &Subscriber{
+		C:        ch,
+		hub:      db.pubsub,
+		ch:       ch,
+		channels: make(map[string]struct{}),
+		patterns: make(map[string]struct{}),
+	}
+}
+
+// Publish 向频道发布消息，返回收到消息的订阅者数量
+// 订阅者跟不上时会被断开，发布方永远不会被阻塞
+func (db *MemDB) Publish(channel, payload string) int {
+	h := db.pubsub
+	var slow []*Subscriber
+	receivers := 0
+
+	h.mu.RLock()
+	for sub := range h.channels[channel] {
+		if sub.deliver(Message{Channel: channel, Payload: payload}) {
+			receivers++
+		} else {
+			slow = append(slow, sub)
+		}
+	}
+	for pattern, subs := range h.patterns {
+		if !glob.Match(pattern, channel) {
+			continue
+		}
+		for sub := range subs {
+			if sub.deliver(Message{Channel: channel, Pattern: pattern, Payload: payload}) {
+				receivers++
+			} else {
+				slow = append(slow, sub)
+			}
+		}
+	}
+	h.mu.RUnlock()
+
+	// 读锁释放后再清理慢消费者的订阅关系
+	for _, sub := range slow {
+		sub.Close()
+	}
+	return receivers
+}
+
+// deliver 非阻塞投递，缓冲区满时标记为慢消费者并关闭通道
+func (sub *Subscriber) deliver(m Message) bool {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	if sub.closed {
+		return false
+	}
+	select {
+	case sub.ch <- m:
+		return true
+	default:
+		sub.err = ErrSlowConsumer
+		sub.closed = true
+		close(sub.ch)
+		return false
+	}
+}
+
+// Subscribe 订阅频道，返回当前订阅总数（频道 + 模式）
+func (sub *Subscriber) Subscribe(channels ...string) int {
+	return sub.update(channels, true, false)
+}
+
+// PSubscribe 按 glob 模式订阅
+func (sub *Subscriber) PSubscribe(patterns ...string) int {
+	return sub.update(patterns, true, true)
+}
+
+// Unsubscribe 取消订阅频道，不传参数表示取消全部频道
+func (sub *Subscriber) Unsubscribe(channels ...string) int {
+	if len(channels) == 0 {
+		channels = sub.Channels()
+	}
+	return sub.update(channels, false, false)
+}
+
+// PUnsubscribe 取消模式订阅，不传参数表示取消全部模式
+func (sub *Subscriber) PUnsubscribe(patterns ...string) int {
+	if len(patterns) == 0 {
+		patterns = sub.Patterns()
+	}
+	return sub.update(patterns, false, true)
+}
+
+// update 修改订阅关系，先改 hub 再改自身，锁顺序固定为 hub -> sub
+func (sub *Subscriber) update(names []string, add, isPattern bool) int {
+	h := sub.hub
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	if sub.closed {
+		return 0
+	}
+
+	index, own := h.channels, sub.channels
+	if isPattern {
+		index, own = h.patterns, sub.patterns
+	}
+
+	for _, name := range names {
+		if add {
+			if index[name] == nil {
+				index[name] = make(map[*Subscriber]struct{})
+			}
+			index[name][sub] = struct{}{}
+			own[name] = struct{}{}
+		} else {
+			delete(index[name], sub)
+			if len(index[name]) == 0 {
+				delete(index, name)
+			}
+			delete(own, name)
+		}
+	}
+	return len(sub.channels) + len(sub.patterns)
+}
+
+// Channels 返回当前订阅的频道
+func (sub *Subscriber) Channels() []string {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	result := make([]string, 0, len(sub.channels))
+	for name := range sub.channels {
+		result = append(result, name)
+	}
+	return result
+}
+
+// Patterns 返回当前订阅的模式
+func (sub *Subscriber) Patterns() []string {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	result := make([]string, 0, len(sub.patterns))
+	for name := range sub.patterns {
+		result = append(result, name)
+	}
+	return result
+}
+
+// Err 返回 C 被关闭的原因，主动 Close 时为 nil
+func (sub *Subscriber) Err() error {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	return sub.err
+}
+
+// Close 取消全部订阅并关闭通道，可重复调用
+func (sub *Subscriber) Close() {
+	h := sub.hub
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	for name := range sub.channels {
+		delete(h.channels[name], sub)
+		if len(h.channels[name]) == 0 {
+			delete(h.channels, name)
+		}
+	}
+	for name := range sub.patterns {
+		delete(h.patterns[name], sub)
+		if len(h.patterns[name]) == 0 {
+			delete(h.patterns, name)
+		}
+	}
+	sub.channels = make(map[string]struct{})
+	sub.patterns = make(map[string]struct{})
+
+	if !sub.closed {
+		sub.closed = true
+		close(sub.ch)
+	}
+}
+
+// closeAll 关闭所有订阅者（数据库关闭时调用）
+func (h *pubSubHub) closeAll() {
+	var subs []*Subscriber
+	h.mu.RLock()
+	for _, set := range h.channels {
+		for sub := range set {
+			subs = append(subs, sub)
+		}
+	}
+	for _, set := range h.patterns {
+		for sub := range set {
+			subs = append(subs, sub)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, sub := range subs {
+		sub.Close()
+	}
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"Flux-KV/internal/config"
+	"errors"
+	"testing"
+	"time"
+)
+
+// recvMessage 从订阅者读取一条消息，超时视为失败
+func recvMessage(t *testing.T, sub *Subscriber) Message {
+	t.Helper()
+	select {
+	case m, ok := <-sub.C:
+		if !ok {
+			t.Fatalf("subscriber closed: %v", sub.Err())
+		}
+		return m
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for message")
+	}
+	return Message{}
+}
+
+// TestPubSub_ChannelAndPattern 验证频道订阅、模式订阅和接收者计数
+func TestPubSub_ChannelAndPattern(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	sub := db.NewSubscriber(0)
+	defer sub.Close()
+	if n := sub.Subscribe("news"); n != 1 {
+		t.Fatalf("want 1 subscription, got %d", n)
+	}
+	if n := sub.PSubscribe("user.*"); n != 2 {
+		t.Fatalf("want 2 subscriptions, got %d", n)
+	}
+
+	if n := db.Publish("news", "hello"); n != 1 {
+		t.Fatalf("want 1 receiver, got %d", n)
+	}
+	if m := recvMessage(t, sub); m.String() != "message news hello" {
+		t.Fatalf("unexpected message: %q", m)
+	}
+
+	db.Publish("user.42", "login")
+	if m := recvMessage(t, sub); m.String() != "pmessage user.* user.42 login" {
+		t.Fatalf("unexpected message: %q", m)
+	}
+
+	if n := db.Publish("order.1", "ignored"); n != 0 {
+		t.Fatalf("want 0 receivers, got %d", n)
+	}
+
+	// 退订全部频道后只剩模式订阅
+	if n := sub.Unsubscribe(); n != 1 {
+		t.Fatalf("want 1 subscription after unsubscribe, got %d", n)
+	}
+	if n := db.Publish("news", "bye"); n != 0 {
+		t.Fatalf("want 0 receivers after unsubscribe, got %d", n)
+	}
+}
+
+// TestPubSub_SlowConsumer 缓冲区满的订阅者被断开，发布方不阻塞
+func TestPubSub_SlowConsumer(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	sub := db.NewSubscriber(2)
+	sub.Subscribe("ch")
+	for i := 0; i < 3; i++ {
+		db.Publish("ch", "x")
+	}
+
+	for range sub.C {
+	}
+	if !errors.Is(sub.Err(), ErrSlowConsumer) {
+		t.Fatalf("want ErrSlowConsumer, got %v", sub.Err())
+	}
+	if n := db.Publish("ch", "x"); n != 0 {
+		t.Fatalf("slow consumer should be unsubscribed, got %d receivers", n)
+	}
+}
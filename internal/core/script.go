@@ -0,0 +1,403 @@
+package core
+
+import (
+	"Flux-KV/internal/aof"
+	"Flux-KV/internal/event"
+	"Flux-KV/pkg/kverrors"
+	"Flux-KV/pkg/script"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNoScript EVALSHA 指定的脚本不在缓存中，需要先 SCRIPT LOAD 或改用 EVAL
+	ErrNoScript = kverrors.WithPrefix(kverrors.NotFound, "NOSCRIPT", "no matching script, use EVAL")
+	// ErrScriptKey 脚本访问了没有在 KEYS 中声明的 Key
+	ErrScriptKey = kverrors.New(kverrors.InvalidArgument, "script accessed an undeclared key")
+	// ErrScriptTimeout 脚本执行超时，已执行的写入全部丢弃
+	ErrScriptTimeout = script.ErrTimeout
+	// ErrNotInteger 值不是整数或自增后溢出
+	ErrNotInteger = kverrors.New(kverrors.InvalidArgument, "value is not an integer or out of range")
+)
+
+// 默认的脚本执行时间上限；执行期间持有声明的 Key 所在分片的写锁，不宜过长
+const defaultScriptTimeout = time.Second
+
+// scriptCache 按 SHA1 缓存编译好的脚本
+type scriptCache struct {
+	mu       sync.RWMutex
+	programs map[string]*script.Program
+	timeout  time.Duration
+}
+
+func newScriptCache(timeout time.Duration) *scriptCache {
+	if timeout <= 0 {
+		timeout = defaultScriptTimeout
+	}
+	return &scriptCache{programs: make(map[string]*script.Program), timeout: timeout}
+}
+
+// ScriptLoad 编译并缓存脚本，返回 SHA1（小写十六进制）
+func (db *MemDB) ScriptLoad(src string) (string, error) {
+	sum := sha1.Sum([]byte(src))
+	sha := hex.EncodeToString(sum[:])
+
+	db.scripts.mu.RLock()
+	_, ok := db.scripts.programs[sha]
+	db.scripts.mu.RUnlock()
+	if ok {
+		return sha, nil
+	}
+
+	prog, err := script.Compile(src)
+	if err != nil {
+		return "", err
+	}
+	db.scripts.mu.Lock()
+	db.scripts.programs[sha] = prog
+	db.scripts.mu.Unlock()
+	return sha, nil
+}
+
+// ScriptExists 判断脚本是否在缓存中
+func (db *MemDB) ScriptExists(shas ...string) []bool {
+	db.scripts.mu.RLock()
+	defer db.scripts.mu.RUnlock()
+	out := make([]bool, len(shas))
+	for i, sha := range shas {
+		_, out[i] = db.scripts.programs[strings.ToLower(sha)]
+	}
+	return out
+}
+
+// ScriptFlush 清空脚本缓存
+func (db *MemDB) ScriptFlush() {
+	db.scripts.mu.Lock()
+	db.scripts.programs = make(map[string]*script.Program)
+	db.scripts.mu.Unlock()
+}
+
+// Eval 编译（命中缓存时跳过）并执行脚本
+func (db *MemDB) Eval(src string, keys, args []string) (any, error) {
+	sha, err := db.ScriptLoad(src)
+	if err != nil {
+		return nil, err
+	}
+	return db.EvalSha(sha, keys, args)
+}
+
+// EvalSha 执行缓存中的脚本
+//
+// 脚本只能访问 keys 中声明的 Key，执行期间这些 Key 所在的分片全部加写锁，其他客户端看不到中间状态。
+// 写入先记录在事务中，脚本正常结束才一次性生效；出错或超时时已执行的写入全部丢弃。
+// AOF 记录的是生效的写入（set / del / pexpireat）而不是脚本本身，重放时不需要重新执行脚本。
+// 返回值为 nil / bool / int64 / float64 / string / []any（嵌套数组）
+func (db *MemDB) EvalSha(sha string, keys, args []string) (any, error) {
+	defer db.stats.record("eval", time.Now())
+
+	db.scripts.mu.RLock()
+	prog, ok := db.scripts.programs[strings.ToLower(sha)]
+	db.scripts.mu.RUnlock()
+	if !ok {
+		return nil, ErrNoScript
+	}
+
+	// 1. 按分片序号排序加锁，与租约撤销等多分片操作保持相同的加锁顺序
+	order := shardOrder(keys)
+	for _, i := range order {
+		db.shards[i].mu.Lock()
+	}
+	unlock := func() {
+		for i := len(order) - 1; i >= 0; i-- {
+			db.shards[order[i]].mu.Unlock()
+		}
+	}
+
+	// 2. 执行脚本
+	tx := newScriptTx(db, keys)
+	globals := map[string]script.Value{
+		"KEYS": script.Import(keys),
+		"ARGV": script.Import(args),
+		"kv":   script.Module{"call": script.Func(tx.call)},
+	}
+	ret, err := prog.Run(globals, time.Now().Add(db.scripts.timeout))
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+
+	// 3. 提交写入
+	events := tx.commit()
+	unlock()
+
+	for _, key := range tx.order {
+		db.hotKeys.touch(key)
+	}
+	if db.eventBus != nil {
+		for _, e := range events {
+			db.eventBus.Publish(e)
+		}
+	}
+	return exportScriptValue(ret), nil
+}
+
+// exportScriptValue 把 []script.Value 转成 []any，调用方不需要依赖 script 包
+func exportScriptValue(v script.Value) any {
+	if list, ok := v.([]script.Value); ok {
+		out := make([]any, len(list))
+		for i, item := range list {
+			out[i] = exportScriptValue(item)
+		}
+		return out
+	}
+	return v
+}
+
+// scriptTx 脚本执行期间的写入缓冲，调用方持有所有声明的 Key 所在分片的写锁
+type scriptTx struct {
+	db       *MemDB
+	declared map[string]bool
+	writes   map[string]*Item // nil 表示删除
+	order    []string         // 第一次写入的顺序，提交时按这个顺序写 AOF
+}
+
+func newScriptTx(db *MemDB, keys []string) *scriptTx {
+	tx := &scriptTx{db: db, declared: make(map[string]bool, len(keys)), writes: make(map[string]*Item)}
+	for _, key := range keys {
+		tx.declared[key] = true
+	}
+	return tx
+}
+
+// lookup 读取 Key 的当前值，已写入的以缓冲为准；不存在或已过期时返回 nil
+func (tx *scriptTx) lookup(key string) (*Item, error) {
+	if !tx.declared[key] {
+		return nil, fmt.Errorf("%w '%s'", ErrScriptKey, key)
+	}
+	if item, ok := tx.writes[key]; ok {
+		return item, nil
+	}
+	item, ok := tx.db.getShard(key).data[key]
+	if !ok || (item.ExpireAt > 0 && time.Now().UnixNano() > item.ExpireAt) {
+		return nil, nil
+	}
+	return item, nil
+}
+
+// lookupString 读取字符串值，其他类型返回 ErrWrongType
+func (tx *scriptTx) lookupString(key string) (string, *Item, error) {
+	item, err := tx.lookup(key)
+	if err != nil || item == nil {
+		return "", nil, err
+	}
+	switch v := item.Val.(type) {
+	case string:
+		return v, item, nil
+	case []byte:
+		return string(v), item, nil
+	}
+	return "", nil, ErrWrongType
+}
+
+func (tx *scriptTx) write(key string, item *Item) {
+	if _, ok := tx.writes[key]; !ok {
+		tx.order = append(tx.order, key)
+	}
+	tx.writes[key] = item
+}
+
+// call 脚本中的 kv.call(cmd, ...)，只开放字符串相关的命令
+func (tx *scriptTx) call(args []script.Value) (script.Value, error) {
+	if len(args) == 0 {
+		return nil, errors.New("kv.call requires a command name")
+	}
+	strs := make([]string, len(args))
+	for i, a := range args {
+		s, ok := script.ToString(a)
+		if !ok {
+			return nil, fmt.Errorf("kv.call arguments must be strings or numbers, got %s", script.TypeName(a))
+		}
+		strs[i] = s
+	}
+	cmd, argv := strings.ToUpper(strs[0]), strs[1:]
+	arity := func(min int) error {
+		if len(argv) < min {
+			return fmt.Errorf("wrong number of arguments for '%s'", strings.ToLower(cmd))
+		}
+		return nil
+	}
+
+	switch cmd {
+	case "GET":
+		if err := arity(1); err != nil {
+			return nil, err
+		}
+		val, item, err := tx.lookupString(argv[0])
+		if err != nil || item == nil {
+			return nil, err
+		}
+		return val, nil
+	case "SET":
+		if err := arity(2); err != nil {
+			return nil, err
+		}
+		return tx.set(argv)
+	case "DEL", "EXISTS":
+		if err := arity(1); err != nil {
+			return nil, err
+		}
+		var n int64
+		for _, key := range argv {
+			item, err := tx.lookup(key)
+			if err != nil {
+				return nil, err
+			}
+			if item != nil {
+				n++
+				if cmd == "DEL" {
+					tx.write(key, nil)
+				}
+			}
+		}
+		return n, nil
+	case "INCR", "DECR", "INCRBY", "DECRBY":
+		delta := int64(1)
+		if cmd == "INCRBY" || cmd == "DECRBY" {
+			if err := arity(2); err != nil {
+				return nil, err
+			}
+			d, err := strconv.ParseInt(argv[1], 10, 64)
+			if err != nil {
+				return nil, ErrNotInteger
+			}
+			delta = d
+		} else if err := arity(1); err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(cmd, "DECR") {
+			delta = -delta
+		}
+		return tx.incrBy(argv[0], delta)
+	case "PTTL":
+		if err := arity(1); err != nil {
+			return nil, err
+		}
+		item, err := tx.lookup(argv[0])
+		switch {
+		case err != nil:
+			return nil, err
+		case item == nil:
+			return int64(-2), nil
+		case item.ExpireAt == 0:
+			return int64(-1), nil
+		}
+		return (item.ExpireAt - time.Now().UnixNano()) / int64(time.Millisecond), nil
+	case "PEXPIRE":
+		if err := arity(2); err != nil {
+			return nil, err
+		}
+		ms, err := strconv.ParseInt(argv[1], 10, 64)
+		if err != nil {
+			return nil, ErrNotInteger
+		}
+		val, item, err := tx.lookupString(argv[0])
+		if err != nil || item == nil {
+			return int64(0), err
+		}
+		tx.write(argv[0], &Item{Val: val, ExpireAt: time.Now().Add(time.Duration(ms) * time.Millisecond).UnixNano()})
+		return int64(1), nil
+	}
+	return nil, fmt.Errorf("command '%s' is not allowed in scripts", strings.ToLower(cmd))
+}
+
+// set SET key value [PX ms] [NX|XX]，条件不满足时返回 nil
+func (tx *scriptTx) set(argv []string) (script.Value, error) {
+	key, val := argv[0], argv[1]
+	var expireAt int64
+	var nx, xx bool
+	for i := 2; i < len(argv); i++ {
+		switch strings.ToUpper(argv[i]) {
+		case "NX":
+			nx = true
+		case "XX":
+			xx = true
+		case "PX":
+			if i+1 >= len(argv) {
+				return nil, errors.New("syntax error")
+			}
+			ms, err := strconv.ParseInt(argv[i+1], 10, 64)
+			if err != nil || ms <= 0 {
+				return nil, errors.New("invalid expire time in 'set'")
+			}
+			expireAt = time.Now().Add(time.Duration(ms) * time.Millisecond).UnixNano()
+			i++
+		default:
+			return nil, errors.New("syntax error")
+		}
+	}
+
+	item, err := tx.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	if (nx && item != nil) || (xx && item == nil) {
+		return nil, nil
+	}
+	tx.write(key, &Item{Val: val, ExpireAt: expireAt})
+	return "OK", nil
+}
+
+func (tx *scriptTx) incrBy(key string, delta int64) (script.Value, error) {
+	val, item, err := tx.lookupString(key)
+	if err != nil {
+		return nil, err
+	}
+	var n, expireAt int64
+	if item != nil {
+		if n, err = strconv.ParseInt(val, 10, 64); err != nil {
+			return nil, ErrNotInteger
+		}
+		expireAt = item.ExpireAt
+	}
+	if (delta > 0 && n > (1<<63-1)-delta) || (delta < 0 && n < (-1<<63)-delta) {
+		return nil, ErrNotInteger
+	}
+	n += delta
+	tx.write(key, &Item{Val: strconv.FormatInt(n, 10), ExpireAt: expireAt})
+	return n, nil
+}
+
+// commit 把缓冲的写入应用到分片并写 AOF，返回需要投递的事件
+func (tx *scriptTx) commit() []event.Event {
+	db := tx.db
+	var events []event.Event
+	for _, key := range tx.order {
+		s := db.getShard(key)
+		item := tx.writes[key]
+		if item == nil {
+			if _, ok := s.data[key]; !ok {
+				continue
+			}
+			delete(s.data, key)
+			db.notify(WatchDelete, key, nil)
+			db.writeAof(aof.Cmd{Type: "del", Key: key})
+			events = append(events, event.Event{Type: event.EventDel, Key: key})
+			continue
+		}
+		item.Version = db.nextVersion()
+		s.data[key] = item
+		db.notify(WatchPut, key, item.Val)
+		db.writeAof(aof.Cmd{Type: "set", Key: key, Value: item.Val})
+		if item.ExpireAt > 0 {
+			db.writeAof(aof.Cmd{Type: "pexpireat", Key: key, Args: []string{strconv.FormatInt(item.ExpireAt, 10)}})
+		}
+		events = append(events, event.Event{Type: event.EventSet, Key: key, Value: item.Val})
+	}
+	return events
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"Flux-KV/internal/config"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestScript_Eval 读写声明的 Key，返回嵌套数组
+func TestScript_Eval(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	db.Set("stock", "3", 0)
+
+	// 扣库存：库存不足时返回 0，否则扣减并记录订单
+	src := `
+local stock = tonumber(kv.call("GET", KEYS[1]))
+if stock == nil or stock < tonumber(ARGV[1]) then
+  return 0
+end
+kv.call("DECRBY", KEYS[1], ARGV[1])
+kv.call("SET", KEYS[2], ARGV[2], "PX", 60000)
+return {1, kv.call("GET", KEYS[1]), kv.call("PTTL", KEYS[2]) > 0}
+`
+	got, err := db.Eval(src, []string{"stock", "order:1"}, []string{"2", "alice"})
+	if err != nil {
+		t.Fatalf("eval failed: %v", err)
+	}
+	if want := []any{int64(1), "1", true}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	if got, _ := db.Eval(src, []string{"stock", "order:2"}, []string{"2", "bob"}); got != int64(0) {
+		t.Fatalf("insufficient stock should return 0, got %v", got)
+	}
+	if v, _ := db.Get("order:1"); v != "alice" {
+		t.Fatalf("order:1 = %v", v)
+	}
+
+	sha, _ := db.ScriptLoad(src)
+	if exists := db.ScriptExists(sha, "missing"); !exists[0] || exists[1] {
+		t.Fatalf("ScriptExists: %v", exists)
+	}
+	if _, err := db.EvalSha("missing", nil, nil); !errors.Is(err, ErrNoScript) {
+		t.Fatalf("want ErrNoScript, got %v", err)
+	}
+	db.ScriptFlush()
+	if _, err := db.EvalSha(sha, []string{"stock", "order:3"}, []string{"1", "c"}); !errors.Is(err, ErrNoScript) {
+		t.Fatalf("flushed script: want ErrNoScript, got %v", err)
+	}
+}
+
+// TestScript_Rollback 出错、超时或访问未声明的 Key 时不留下任何写入
+func TestScript_Rollback(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{Script: config.ScriptConfig{Timeout: 20 * time.Millisecond}})
+	db.Set("a", "1", 0)
+
+	cases := []struct {
+		src string
+		err error
+	}{
+		{`kv.call("SET", KEYS[1], "x") kv.call("GET", "undeclared")`, ErrScriptKey},
+		{`kv.call("SET", KEYS[1], "x") while true do end`, ErrScriptTimeout},
+		{`kv.call("DEL", KEYS[1]) error("abort")`, nil},
+		{`kv.call("SET", KEYS[1], "x") kv.call("FLUSHALL")`, nil},
+	}
+	for _, tc := range cases {
+		_, err := db.Eval(tc.src, []string{"a"}, nil)
+		if err == nil || (tc.err != nil && !errors.Is(err, tc.err)) {
+			t.Fatalf("%q: want %v, got %v", tc.src, tc.err, err)
+		}
+		if v, _ := db.Get("a"); v != "1" {
+			t.Fatalf("%q: writes should be discarded, got %v", tc.src, v)
+		}
+	}
+
+	db.PFAdd("z", "m")
+	if _, err := db.Eval(`return kv.call("INCR", KEYS[1])`, []string{"z"}, nil); !errors.Is(err, ErrWrongType) {
+		t.Fatalf("want ErrWrongType, got %v", err)
+	}
+}
+
+// TestScript_Atomic 并发执行“读-改-写”脚本不会丢失更新
+func TestScript_Atomic(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	src := `local n = tonumber(kv.call("GET", KEYS[1]) or 0) kv.call("SET", KEYS[1], n + 1) return n + 1`
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			db.Eval(src, []string{"counter"}, nil)
+		}()
+	}
+	wg.Wait()
+	if v, _ := db.Get("counter"); v != "50" {
+		t.Fatalf("want 50, got %v", v)
+	}
+}
+
+// TestScript_AofReplay AOF 记录脚本的写入结果，重放时不需要脚本
+func TestScript_AofReplay(t *testing.T) {
+	cfg := &config.Config{
+		AOF: config.AOFConfig{Filename: filepath.Join(t.TempDir(), "script.aof")},
+	}
+	db, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("NewMemDB failed: %v", err)
+	}
+	db.Set("gone", "x", 0)
+	_, err = db.Eval(`
+kv.call("INCRBY", KEYS[1], 5)
+kv.call("SET", KEYS[2], "v", "PX", 1)
+kv.call("DEL", KEYS[3])
+`, []string{"n", "short", "gone"}, nil)
+	if err != nil {
+		t.Fatalf("eval failed: %v", err)
+	}
+	db.Close()
+	time.Sleep(5 * time.Millisecond)
+
+	db2, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	defer db2.Close()
+	if v, _ := db2.Get("n"); v != "5" {
+		t.Fatalf("n after replay: %v", v)
+	}
+	if _, ok := db2.Get("short"); ok {
+		t.Fatal("expire time should survive replay")
+	}
+	if _, ok := db2.Get("gone"); ok {
+		t.Fatal("deleted key should stay deleted")
+	}
+}
@@ -0,0 +1,62 @@
+package core
+
+// countMinSketch Count-Min Sketch 频率估算
+// 用 depth 行 width 列的计数矩阵近似统计每个元素的出现次数，只会高估、不会低估
+type countMinSketch struct {
+	width  uint32
+	depth  uint32
+	counts [][]uint32
+}
+
+func newCountMinSketch(width, depth uint32) *countMinSketch {
+	counts := make([][]uint32, depth)
+	for i := range counts {
+		counts[i] = make([]uint32, width)
+	}
+	return &countMinSketch{
+		width:  width,
+		depth:  depth,
+		counts: counts,
+	}
+}
+
+// index 计算元素在第 row 行的列下标
+// 使用双重哈希 h1 + row*h2 模拟 depth 个独立哈希函数
+func (c *countMinSketch) index(key string, row uint32) uint32 {
+	h1 := fnv32(key)
+	h2 := (h1 >> 16) | (h1 << 16) | 1
+	return (h1 + row*h2) % c.width
+}
+
+// Add 增加计数，返回增加后的估算值
+func (c *countMinSketch) Add(key string, delta uint32) uint32 {
+	var min uint32 = ^uint32(0)
+	for row := uint32(0); row < c.depth; row++ {
+		idx := c.index(key, row)
+		c.counts[row][idx] += delta
+		if c.counts[row][idx] < min {
+			min = c.counts[row][idx]
+		}
+	}
+	return min
+}
+
+// Estimate 查询估算值（所有行中的最小值）
+func (c *countMinSketch) Estimate(key string) uint32 {
+	var min uint32 = ^uint32(0)
+	for row := uint32(0); row < c.depth; row++ {
+		if v := c.counts[row][c.index(key, row)]; v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// Decay 所有计数减半，让历史热点随时间冷却
+func (c *countMinSketch) Decay() {
+	for _, row := range c.counts {
+		for i := range row {
+			row[i] >>= 1
+		}
+	}
+}
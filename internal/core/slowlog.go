@@ -0,0 +1,117 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// 慢日志默认参数（配置为 0 时使用）
+const (
+	defaultSlowLogThreshold = 10 * time.Millisecond
+	defaultSlowLogMaxLen    = 128
+)
+
+// SlowLogEntry 一条慢日志记录
+type SlowLogEntry struct {
+	ID         uint64
+	Time       time.Time
+	Duration   time.Duration
+	Command    string
+	Key        string
+	ClientAddr string
+}
+
+// SlowLog 记录执行时间超过阈值的命令，使用固定大小的环形缓冲区，写满后覆盖最旧的记录
+type SlowLog struct {
+	threshold time.Duration // < 0 表示关闭
+
+	mu      sync.Mutex
+	entries []SlowLogEntry // 环形缓冲区
+	next    int            // 下一条写入的位置
+	size    int            // 当前记录数
+	nextID  uint64         // 自增 ID，Reset 后也不回退
+}
+
+// NewSlowLog 创建慢日志
+// threshold 为 0 使用默认值，小于 0 表示关闭；maxLen 为 0 使用默认值
+func NewSlowLog(threshold time.Duration, maxLen int) *SlowLog {
+	if threshold == 0 {
+		threshold = defaultSlowLogThreshold
+	}
+	if maxLen <= 0 {
+		maxLen = defaultSlowLogMaxLen
+	}
+	return &SlowLog{
+		threshold: threshold,
+		entries:   make([]SlowLogEntry, maxLen),
+	}
+}
+
+// Observe 在命令执行结束时调用，超过阈值则记录
+// 推荐用法: defer slowLog.Observe("get", key, addr, time.Now())
+func (l *SlowLog) Observe(cmd, key, clientAddr string, start time.Time) {
+	if l.threshold < 0 {
+		return
+	}
+	d := time.Since(start)
+	if d < l.threshold {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries[l.next] = SlowLogEntry{
+		ID:         l.nextID,
+		Time:       start,
+		Duration:   d,
+		Command:    cmd,
+		Key:        key,
+		ClientAddr: clientAddr,
+	}
+	l.nextID++
+	l.next = (l.next + 1) % len(l.entries)
+	if l.size < len(l.entries) {
+		l.size++
+	}
+}
+
+// Get 返回最近的 n 条记录（最新的在前），n <= 0 表示全部
+func (l *SlowLog) Get(n int) []SlowLogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if n <= 0 || n > l.size {
+		n = l.size
+	}
+	result := make([]SlowLogEntry, 0, n)
+	for i := 1; i <= n; i++ {
+		idx := (l.next - i + len(l.entries)) % len(l.entries)
+		result = append(result, l.entries[idx])
+	}
+	return result
+}
+
+// Len 返回当前记录数
+func (l *SlowLog) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.size
+}
+
+// Reset 清空所有记录
+func (l *SlowLog) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i := range l.entries {
+		l.entries[i] = SlowLogEntry{}
+	}
+	l.next = 0
+	l.size = 0
+}
+
+// SlowLog 返回慢日志实例，供 gRPC / TCP 层记录命令耗时
+func (db *MemDB) SlowLog() *SlowLog {
+	return db.slowLog
+}
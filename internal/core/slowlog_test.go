@@ -0,0 +1,57 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestSlowLog_Ring 验证阈值过滤、环形覆盖和 Reset
+func TestSlowLog_Ring(t *testing.T) {
+	l := NewSlowLog(time.Millisecond, 3)
+
+	// 1. 未超过阈值的不记录
+	l.Observe("get", "fast", "", time.Now())
+	if l.Len() != 0 {
+		t.Fatalf("fast command should not be logged, len=%d", l.Len())
+	}
+
+	// 2. 写入 5 条慢命令，只保留最近 3 条
+	past := time.Now().Add(-10 * time.Millisecond)
+	for i := 0; i < 5; i++ {
+		l.Observe("set", fmt.Sprintf("k%d", i), "127.0.0.1:1", past)
+	}
+	if l.Len() != 3 {
+		t.Fatalf("len: want 3, got %d", l.Len())
+	}
+
+	entries := l.Get(0)
+	if entries[0].Key != "k4" || entries[2].Key != "k2" {
+		t.Errorf("entries should be newest first: %+v", entries)
+	}
+	if entries[0].ID != 4 {
+		t.Errorf("id: want 4, got %d", entries[0].ID)
+	}
+	if got := l.Get(1); len(got) != 1 || got[0].Key != "k4" {
+		t.Errorf("Get(1) mismatch: %+v", got)
+	}
+
+	// 3. Reset 后清空，但 ID 继续递增
+	l.Reset()
+	if l.Len() != 0 {
+		t.Fatalf("len after reset: %d", l.Len())
+	}
+	l.Observe("del", "k", "", past)
+	if e := l.Get(1); e[0].ID != 5 {
+		t.Errorf("id should keep increasing after reset, got %d", e[0].ID)
+	}
+}
+
+// TestSlowLog_Disabled 负数阈值表示关闭
+func TestSlowLog_Disabled(t *testing.T) {
+	l := NewSlowLog(-1, 3)
+	l.Observe("set", "k", "", time.Now().Add(-time.Second))
+	if l.Len() != 0 {
+		t.Errorf("disabled slowlog should not record")
+	}
+}
@@ -0,0 +1,299 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 每个 Key 的固定开销估算（map bucket + Item 结构体 + 指针），用于内存粗略统计
+const itemOverhead = 64
+
+// memSizer 由复杂值类型实现，用于估算占用内存
+type memSizer interface {
+	MemSize() int64
+}
+
+// commandStat 单个命令的调用统计
+type commandStat struct {
+	calls atomic.Uint64
+	usec  atomic.Uint64 // 累计耗时（微秒）
+}
+
+// dbStats 记录 MemDB 运行期间的计数器
+type dbStats struct {
+	startTime time.Time
+
+	hits        atomic.Uint64 // Get 命中次数
+	misses      atomic.Uint64 // Get 未命中次数
+	expiredKeys atomic.Uint64 // 因过期被删除的 Key 数
+
+	mu       sync.RWMutex
+	commands map[string]*commandStat
+}
+
+func newDBStats() *dbStats {
+	return &dbStats{
+		startTime: time.Now(),
+		commands:  make(map[string]*commandStat),
+	}
+}
+
+// record 记录一次命令调用及其耗时
+func (st *dbStats) record(cmd string, start time.Time) {
+	// 1. 读锁快速路径：命令已存在
+	st.mu.RLock()
+	cs, ok := st.commands[cmd]
+	st.mu.RUnlock()
+
+	// 2. 首次出现的命令，加写锁创建（Double Check）
+	if !ok {
+		st.mu.Lock()
+		if cs, ok = st.commands[cmd]; !ok {
+			cs = &commandStat{}
+			st.commands[cmd] = cs
+		}
+		st.mu.Unlock()
+	}
+
+	cs.calls.Add(1)
+	cs.usec.Add(uint64(time.Since(start).Microseconds()))
+}
+
+// ShardStats 单个分片的统计信息
+type ShardStats struct {
+	ID          int
+	Keys        int
+	Expires     int   // 设置了过期时间的 Key 数
+	MemoryBytes int64 // 估算内存
+}
+
+// CommandStats 单个命令的统计信息
+type CommandStats struct {
+	Name      string
+	Calls     uint64
+	TotalUsec uint64
+}
+
+// UsecPerCall 平均每次调用耗时（微秒）
+func (c CommandStats) UsecPerCall() float64 {
+	if c.Calls == 0 {
+		return 0
+	}
+	return float64(c.TotalUsec) / float64(c.Calls)
+}
+
+// Info 是某一时刻 MemDB 的统计快照
+type Info struct {
+	UptimeSeconds int64
+	Keys          int
+	Expires       int
+	MemoryBytes   int64
+	Hits          uint64
+	Misses        uint64
+	ExpiredKeys   uint64
+	Shards        []ShardStats
+	Commands      []CommandStats
+}
+
+// Info 采集当前的统计快照
+// 需要逐个分片加读锁遍历，属于管理类操作，不要在热路径上调用
+func (db *MemDB) Info() *Info {
+	info := &Info{
+		UptimeSeconds: int64(time.Since(db.stats.startTime).Seconds()),
+		Hits:          db.stats.hits.Load(),
+		Misses:        db.stats.misses.Load(),
+		ExpiredKeys:   db.stats.expiredKeys.Load(),
+		Shards:        make([]ShardStats, ShardCount),
+	}
+
+	// 1. 分片统计
+	for i, s := range db.shards {
+		ss := ShardStats{ID: i}
+		s.mu.RLock()
+		ss.Keys = len(s.data)
+		for key, item := range s.data {
+			if item.ExpireAt > 0 {
+				ss.Expires++
+			}
+			ss.MemoryBytes += int64(len(key)) + valueSize(item.Val) + itemOverhead
+		}
+		s.mu.RUnlock()
+
+		info.Shards[i] = ss
+		info.Keys += ss.Keys
+		info.Expires += ss.Expires
+		info.MemoryBytes += ss.MemoryBytes
+	}
+
+	// 2. 命令统计（按名字排序，保证输出稳定）
+	db.stats.mu.RLock()
+	for name, cs := range db.stats.commands {
+		info.Commands = append(info.Commands, CommandStats{
+			Name:      name,
+			Calls:     cs.calls.Load(),
+			TotalUsec: cs.usec.Load(),
+		})
+	}
+	db.stats.mu.RUnlock()
+	sort.Slice(info.Commands, func(i, j int) bool {
+		return info.Commands[i].Name < info.Commands[j].Name
+	})
+
+	return info
+}
+
+// valueSize 估算单个值占用的字节数
+func valueSize(val any) int64 {
+	switch v := val.(type) {
+	case string:
+		return int64(len(v))
+	case []byte:
+		return int64(len(v))
+	case memSizer:
+		return v.MemSize()
+	default:
+		return 16
+	}
+}
+
+// typeName 返回值的类型名（与 Redis TYPE 命令一致）
+func typeName(val any) string {
+	switch val.(type) {
+	case string, []byte:
+		return "string"
+	case *Stream:
+		return "stream"
+	case *HyperLogLog:
+		return "hyperloglog"
+	case *BloomFilter:
+		return "bloom"
+	case *CountMinSketch:
+		return "cms"
+	case *SortedSet:
+		return "zset"
+	case *TimeSeries:
+		return "timeseries"
+	case *JSONDoc:
+		return "json"
+	case *LockState:
+		return "lock"
+	case *ThrottleState:
+		return "throttle"
+	default:
+		return "unknown"
+	}
+}
+
+// HitRate 命中率（0~1）
+func (info *Info) HitRate() float64 {
+	total := info.Hits + info.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(info.Hits) / float64(total)
+}
+
+// ShardSkew 返回分片 Key 数的最小值、最大值和平均值，用于判断数据倾斜
+func (info *Info) ShardSkew() (min, max int, avg float64) {
+	if len(info.Shards) == 0 {
+		return 0, 0, 0
+	}
+	min = info.Shards[0].Keys
+	for _, s := range info.Shards {
+		if s.Keys < min {
+			min = s.Keys
+		}
+		if s.Keys > max {
+			max = s.Keys
+		}
+	}
+	avg = float64(info.Keys) / float64(len(info.Shards))
+	return min, max, avg
+}
+
+// infoSections 所有支持的 section，按输出顺序排列
+var infoSections = []string{"server", "keyspace", "memory", "stats", "commandstats", "shards"}
+
+// defaultSections 不指定 section 时输出的内容（与 Redis 一致，不包含明细类 section）
+var defaultSections = map[string]bool{"server": true, "keyspace": true, "memory": true, "stats": true}
+
+// Format 按 Redis INFO 风格输出文本
+// section 为空时输出默认 section，"all" 输出全部
+func (info *Info) Format(section string) (string, error) {
+	section = strings.ToLower(section)
+
+	var selected []string
+	switch section {
+	case "", "default":
+		for _, name := range infoSections {
+			if defaultSections[name] {
+				selected = append(selected, name)
+			}
+		}
+	case "all", "everything":
+		selected = infoSections
+	default:
+		found := false
+		for _, name := range infoSections {
+			if name == section {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("unknown INFO section '%s'", section)
+		}
+		selected = []string{section}
+	}
+
+	var sb strings.Builder
+	for i, name := range selected {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		info.writeSection(&sb, name)
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+func (info *Info) writeSection(sb *strings.Builder, name string) {
+	switch name {
+	case "server":
+		sb.WriteString("# Server\n")
+		fmt.Fprintf(sb, "uptime_in_seconds:%d\n", info.UptimeSeconds)
+		fmt.Fprintf(sb, "shard_count:%d\n", len(info.Shards))
+	case "keyspace":
+		sb.WriteString("# Keyspace\n")
+		fmt.Fprintf(sb, "keys:%d\n", info.Keys)
+		fmt.Fprintf(sb, "expires:%d\n", info.Expires)
+		min, max, avg := info.ShardSkew()
+		fmt.Fprintf(sb, "shard_keys_min:%d\n", min)
+		fmt.Fprintf(sb, "shard_keys_max:%d\n", max)
+		fmt.Fprintf(sb, "shard_keys_avg:%.2f\n", avg)
+	case "memory":
+		sb.WriteString("# Memory\n")
+		fmt.Fprintf(sb, "used_memory_estimated:%d\n", info.MemoryBytes)
+	case "stats":
+		sb.WriteString("# Stats\n")
+		fmt.Fprintf(sb, "keyspace_hits:%d\n", info.Hits)
+		fmt.Fprintf(sb, "keyspace_misses:%d\n", info.Misses)
+		fmt.Fprintf(sb, "hit_rate:%.4f\n", info.HitRate())
+		fmt.Fprintf(sb, "expired_keys:%d\n", info.ExpiredKeys)
+	case "commandstats":
+		sb.WriteString("# Commandstats\n")
+		for _, c := range info.Commands {
+			fmt.Fprintf(sb, "cmdstat_%s:calls=%d,usec=%d,usec_per_call=%.2f\n",
+				c.Name, c.Calls, c.TotalUsec, c.UsecPerCall())
+		}
+	case "shards":
+		sb.WriteString("# Shards\n")
+		for _, s := range info.Shards {
+			fmt.Fprintf(sb, "shard%d:keys=%d,expires=%d,memory=%d\n",
+				s.ID, s.Keys, s.Expires, s.MemoryBytes)
+		}
+	}
+}
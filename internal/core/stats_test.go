@@ -0,0 +1,123 @@
+package core
+
+import (
+	"Flux-KV/internal/config"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestMemDB_Info 验证 Key 数、过期 Key 数、命中率和命令统计
+func TestMemDB_Info(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	db.Set("a", "1", 0)
+	db.Set("b", "22", time.Minute)
+	db.Set("c", "333", 0)
+	db.Del("c")
+
+	db.Get("a")       // hit
+	db.Get("missing") // miss
+
+	info := db.Info()
+	if info.Keys != 2 {
+		t.Errorf("keys: want 2, got %d", info.Keys)
+	}
+	if info.Expires != 1 {
+		t.Errorf("expires: want 1, got %d", info.Expires)
+	}
+	if info.Hits != 1 || info.Misses != 1 {
+		t.Errorf("hits/misses: want 1/1, got %d/%d", info.Hits, info.Misses)
+	}
+	if info.MemoryBytes <= 0 {
+		t.Errorf("memory should be estimated, got %d", info.MemoryBytes)
+	}
+
+	calls := make(map[string]uint64)
+	for _, c := range info.Commands {
+		calls[c.Name] = c.Calls
+	}
+	if calls["set"] != 3 || calls["get"] != 2 || calls["del"] != 1 {
+		t.Errorf("unexpected command stats: %v", calls)
+	}
+
+	// 分片 Key 数之和应等于总数
+	sum := 0
+	for _, s := range info.Shards {
+		sum += s.Keys
+	}
+	if sum != info.Keys {
+		t.Errorf("shard sum %d != keys %d", sum, info.Keys)
+	}
+}
+
+// TestMemDB_DelExists 多 Key 的 DEL / EXISTS 返回存在的 Key 个数，只记录 del / exists，不计入命中率
+// 并发删除同一个 Key 时只有一个调用计数
+func TestMemDB_DelExists(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	db.Set("a", "1", 0)
+	db.Set("b", "2", 0)
+
+	if n := db.Exists("a", "b", "missing", "a"); n != 3 {
+		t.Errorf("Exists: want 3, got %d", n)
+	}
+	if n := db.Del("a", "missing"); n != 1 {
+		t.Errorf("Del: want 1, got %d", n)
+	}
+
+	info := db.Info()
+	if info.Hits != 0 || info.Misses != 0 {
+		t.Errorf("hits/misses: want 0/0, got %d/%d", info.Hits, info.Misses)
+	}
+	calls := make(map[string]uint64)
+	for _, c := range info.Commands {
+		calls[c.Name] = c.Calls
+	}
+	if calls["get"] != 0 || calls["exists"] != 1 || calls["del"] != 1 {
+		t.Errorf("unexpected command stats: %v", calls)
+	}
+
+	var deleted atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			deleted.Add(int64(db.Del("b")))
+		}()
+	}
+	wg.Wait()
+	if deleted.Load() != 1 {
+		t.Errorf("concurrent Del: want 1 deletion, got %d", deleted.Load())
+	}
+}
+
+// TestInfo_Format 验证 section 过滤
+func TestInfo_Format(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	db.Set("k", "v", 0)
+
+	info := db.Info()
+
+	text, err := info.Format("")
+	if err != nil {
+		t.Fatalf("Format default failed: %v", err)
+	}
+	if !strings.Contains(text, "# Keyspace") || strings.Contains(text, "# Shards") {
+		t.Errorf("default sections mismatch:\n%s", text)
+	}
+
+	text, err = info.Format("commandstats")
+	if err != nil {
+		t.Fatalf("Format commandstats failed: %v", err)
+	}
+	if !strings.Contains(text, "cmdstat_set:calls=1") {
+		t.Errorf("commandstats mismatch:\n%s", text)
+	}
+
+	if _, err := info.Format("nope"); err == nil {
+		t.Error("unknown section should return error")
+	}
+}
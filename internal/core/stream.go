@@ -0,0 +1,1060 @@
+package core
+
+import (
+	"Flux-KV/internal/aof"
+	"Flux-KV/pkg/kverrors"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrWrongType Key 已存在，但保存的不是当前命令操作的类型
+	ErrWrongType = kverrors.New(kverrors.WrongType, "WRONGTYPE Operation against a key holding the wrong kind of value")
+	// ErrInvalidStreamID 消息 ID 格式错误
+	ErrInvalidStreamID = kverrors.New(kverrors.InvalidArgument, "invalid stream ID")
+	// ErrStreamIDTooSmall XADD 指定的 ID 不大于 Stream 当前最大 ID
+	ErrStreamIDTooSmall = kverrors.New(kverrors.InvalidArgument, "the ID specified in XADD is equal or smaller than the target stream top item")
+	// ErrStreamFields 字段必须成对出现
+	ErrStreamFields = kverrors.New(kverrors.InvalidArgument, "wrong number of fields, expected field value pairs")
+	// ErrNoSuchStream Stream 不存在
+	ErrNoSuchStream = kverrors.New(kverrors.NotFound, "no such stream")
+	// ErrNoSuchGroup 消费者组不存在
+	ErrNoSuchGroup = kverrors.WithPrefix(kverrors.NotFound, "NOGROUP", "NOGROUP no such key or consumer group")
+	// ErrGroupExists 消费者组已存在
+	ErrGroupExists = kverrors.WithPrefix(kverrors.Conflict, "BUSYGROUP", "BUSYGROUP consumer group name already exists")
+)
+
+// StreamID 消息 ID，由毫秒时间戳和同一毫秒内的序号组成，在一个 Stream 内严格递增
+type StreamID struct {
+	Ms  uint64
+	Seq uint64
+}
+
+var maxStreamID = StreamID{Ms: math.MaxUint64, Seq: math.MaxUint64}
+
+// String 输出 "毫秒-序号" 格式，例如 1700000000000-0
+func (id StreamID) String() string {
+	return strconv.FormatUint(id.Ms, 10) + "-" + strconv.FormatUint(id.Seq, 10)
+}
+
+// Less 比较两个 ID 的先后
+func (id StreamID) Less(o StreamID) bool {
+	if id.Ms != o.Ms {
+		return id.Ms < o.Ms
+	}
+	return id.Seq < o.Seq
+}
+
+// next 紧随其后的 ID
+func (id StreamID) next() StreamID {
+	if id.Seq == math.MaxUint64 {
+		return StreamID{Ms: id.Ms + 1}
+	}
+	return StreamID{Ms: id.Ms, Seq: id.Seq + 1}
+}
+
+// prev 紧邻其前的 ID
+func (id StreamID) prev() StreamID {
+	if id.Seq == 0 {
+		return StreamID{Ms: id.Ms - 1, Seq: math.MaxUint64}
+	}
+	return StreamID{Ms: id.Ms, Seq: id.Seq - 1}
+}
+
+// ParseStreamID 解析 "毫秒-序号" 或 "毫秒" 格式的 ID，省略序号时取 0
+func ParseStreamID(s string) (StreamID, error) {
+	return parseStreamID(s, 0)
+}
+
+func parseStreamID(s string, defaultSeq uint64) (StreamID, error) {
+	msPart, seqPart, hasSeq := strings.Cut(s, "-")
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return StreamID{}, ErrInvalidStreamID
+	}
+	if !hasSeq {
+		return StreamID{Ms: ms, Seq: defaultSeq}, nil
+	}
+	seq, err := strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return StreamID{}, ErrInvalidStreamID
+	}
+	return StreamID{Ms: ms, Seq: seq}, nil
+}
+
+// parseRangeStart 解析区间起点："-" 表示最小，"(" 前缀表示不包含
+func parseRangeStart(s string) (StreamID, error) {
+	switch s {
+	case "-":
+		return StreamID{}, nil
+	case "+":
+		return maxStreamID, nil
+	}
+	if strings.HasPrefix(s, "(") {
+		id, err := parseStreamID(s[1:], 0)
+		if err != nil || id == maxStreamID {
+			return StreamID{}, ErrInvalidStreamID
+		}
+		return id.next(), nil
+	}
+	return parseStreamID(s, 0)
+}
+
+// parseRangeEnd 解析区间终点："+" 表示最大，省略序号时包含该毫秒内的全部消息
+func parseRangeEnd(s string) (StreamID, error) {
+	switch s {
+	case "-":
+		return StreamID{}, nil
+	case "+":
+		return maxStreamID, nil
+	}
+	if strings.HasPrefix(s, "(") {
+		id, err := parseStreamID(s[1:], math.MaxUint64)
+		if err != nil || id == (StreamID{}) {
+			return StreamID{}, ErrInvalidStreamID
+		}
+		return id.prev(), nil
+	}
+	return parseStreamID(s, math.MaxUint64)
+}
+
+// StreamEntry 一条消息，Fields 为 field1, value1, field2, value2... 依次排列
+// 通过 XREADGROUP / XCLAIM 读取已被裁剪掉的消息时 Fields 为 nil
+type StreamEntry struct {
+	ID     StreamID
+	Fields []string
+}
+
+// StreamResult 一个 Stream 上读取到的消息
+type StreamResult struct {
+	Key     string
+	Entries []StreamEntry
+}
+
+// PendingEntry 已投递但尚未确认的消息
+type PendingEntry struct {
+	ID         StreamID
+	Consumer   string
+	Idle       time.Duration // 距最近一次投递的时间
+	Deliveries int64         // 投递次数
+}
+
+// PendingSummary 消费者组待确认消息概况
+type PendingSummary struct {
+	Count     int64
+	MinID     StreamID
+	MaxID     StreamID
+	Consumers map[string]int64 // 消费者 -> 待确认数量
+}
+
+// Stream 只追加的消息日志，作为 Item.Val 保存在分片中，所有访问都在分片锁内进行
+type Stream struct {
+	entries []StreamEntry // 按 ID 升序
+	lastID  StreamID      // 曾经分配过的最大 ID，裁剪后也不回退
+	size    int64         // 字段总字节数
+	groups  map[string]*consumerGroup
+}
+
+// consumerGroup 消费者组：记录组内已投递的位置和待确认列表（PEL）
+type consumerGroup struct {
+	lastID    StreamID
+	pending   map[StreamID]*pendingState
+	consumers map[string]*streamConsumer
+}
+
+type pendingState struct {
+	consumer    string
+	deliveredAt time.Time
+	deliveries  int64
+}
+
+type streamConsumer struct {
+	seenAt  time.Time
+	pending int
+}
+
+func newStream() *Stream {
+	return &Stream{
+		groups: make(map[string]*consumerGroup),
+	}
+}
+
+// MemSize 估算占用的内存
+func (st *Stream) MemSize() int64 {
+	return st.size + int64(len(st.entries))*32
+}
+
+// search 返回第一个 ID >= id 的位置
+func (st *Stream) search(id StreamID) int {
+	return sort.Search(len(st.entries), func(i int) bool {
+		return !st.entries[i].ID.Less(id)
+	})
+}
+
+// get 按 ID 查找消息
+func (st *Stream) get(id StreamID) (StreamEntry, bool) {
+	i := st.search(id)
+	if i < len(st.entries) && st.entries[i].ID == id {
+		return st.entries[i], true
+	}
+	return StreamEntry{}, false
+}
+
+// rangeEntries 返回 [start, end] 区间内的消息，count <= 0 表示不限
+func (st *Stream) rangeEntries(start, end StreamID, count int, reverse bool) []StreamEntry {
+	if end.Less(start) {
+		return nil
+	}
+	lo, hi := st.search(start), st.search(end.next())
+	if end == maxStreamID {
+		hi = len(st.entries)
+	}
+	n := hi - lo
+	if count > 0 && count < n {
+		n = count
+	}
+	result := make([]StreamEntry, 0, n)
+	for i := 0; i < n; i++ {
+		if reverse {
+			result = append(result, st.entries[hi-1-i])
+		} else {
+			result = append(result, st.entries[lo+i])
+		}
+	}
+	return result
+}
+
+// nextAddID 根据 XADD 传入的 ID 计算新消息的 ID
+// "*" 自动生成，"毫秒-*" 指定毫秒自动生成序号，其余必须大于当前最大 ID
+func (st *Stream) nextAddID(id string, now time.Time) (StreamID, error) {
+	last := st.lastID
+	if id == "*" {
+		ms := uint64(now.UnixMilli())
+		if ms > last.Ms {
+			return StreamID{Ms: ms}, nil
+		}
+		return last.next(), nil
+	}
+
+	var newID StreamID
+	if msPart, ok := strings.CutSuffix(id, "-*"); ok {
+		ms, err := strconv.ParseUint(msPart, 10, 64)
+		if err != nil {
+			return StreamID{}, ErrInvalidStreamID
+		}
+		newID = StreamID{Ms: ms}
+		if ms == last.Ms {
+			newID = last.next()
+		}
+	} else {
+		var err error
+		if newID, err = ParseStreamID(id); err != nil {
+			return StreamID{}, err
+		}
+	}
+	if newID == (StreamID{}) || !last.Less(newID) {
+		return StreamID{}, ErrStreamIDTooSmall
+	}
+	return newID, nil
+}
+
+// add 追加一条消息，调用方保证 id 大于 lastID
+func (st *Stream) add(id StreamID, fields []string) {
+	st.entries = append(st.entries, StreamEntry{ID: id, Fields: fields})
+	st.lastID = id
+	for _, f := range fields {
+		st.size += int64(len(f))
+	}
+}
+
+// trimFront 删除最旧的 n 条消息
+func (st *Stream) trimFront(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	for _, e := range st.entries[:n] {
+		for _, f := range e.Fields {
+			st.size -= int64(len(f))
+		}
+	}
+	// 清空被裁剪的位置，让字段可以被回收；底层数组在下次扩容时整体释放
+	clear(st.entries[:n])
+	st.entries = st.entries[n:]
+	return n
+}
+
+// trimMaxLen 裁剪到最多 maxLen 条
+func (st *Stream) trimMaxLen(maxLen int) int {
+	return st.trimFront(len(st.entries) - maxLen)
+}
+
+// trimMinID 删除 ID 小于 minID 的消息
+func (st *Stream) trimMinID(minID StreamID) int {
+	return st.trimFront(st.search(minID))
+}
+
+// group 返回消费者组，不存在时返回 ErrNoSuchGroup
+func (st *Stream) group(name string) (*consumerGroup, error) {
+	g, ok := st.groups[name]
+	if !ok {
+		return nil, ErrNoSuchGroup
+	}
+	return g, nil
+}
+
+// consumer 返回（必要时创建）消费者
+func (g *consumerGroup) consumer(name string, now time.Time) *streamConsumer {
+	c, ok := g.consumers[name]
+	if !ok {
+		c = &streamConsumer{}
+		g.consumers[name] = c
+	}
+	c.seenAt = now
+	return c
+}
+
+// deliver 记录一次投递：新消息推进 lastID，已在 PEL 中的消息转移给 consumer 并增加投递次数
+func (g *consumerGroup) deliver(consumer string, id StreamID, noAck bool, now time.Time) {
+	if g.lastID.Less(id) {
+		g.lastID = id
+	}
+	c := g.consumer(consumer, now)
+	if noAck {
+		return
+	}
+	p, ok := g.pending[id]
+	if !ok {
+		p = &pendingState{consumer: consumer}
+		g.pending[id] = p
+		c.pending++
+	} else if p.consumer != consumer {
+		if old, ok := g.consumers[p.consumer]; ok {
+			old.pending--
+		}
+		p.consumer = consumer
+		c.pending++
+	}
+	p.deliveredAt = now
+	p.deliveries++
+}
+
+// ack 从 PEL 中移除消息
+func (g *consumerGroup) ack(id StreamID) bool {
+	p, ok := g.pending[id]
+	if !ok {
+		return false
+	}
+	if c, ok := g.consumers[p.consumer]; ok {
+		c.pending--
+	}
+	delete(g.pending, id)
+	return true
+}
+
+// sortedPending 按 ID 升序返回 PEL 中的 ID
+func (g *consumerGroup) sortedPending() []StreamID {
+	ids := make([]StreamID, 0, len(g.pending))
+	for id := range g.pending {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Less(ids[j]) })
+	return ids
+}
+
+// streamWaiters 阻塞读取的等待队列：XADD 后唤醒该 Key 上的所有等待者
+type streamWaiters struct {
+	mu sync.Mutex
+	m  map[string]chan struct{}
+}
+
+func newStreamWaiters() *streamWaiters {
+	return &streamWaiters{
+		m: make(map[string]chan struct{}),
+	}
+}
+
+// wait 返回一个在 Key 有新消息时被关闭的通道
+func (w *streamWaiters) wait(key string) <-chan struct{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	ch, ok := w.m[key]
+	if !ok {
+		ch = make(chan struct{})
+		w.m[key] = ch
+	}
+	return ch
+}
+
+// wake 唤醒 Key 上的所有等待者
+func (w *streamWaiters) wake(key string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if ch, ok := w.m[key]; ok {
+		close(ch)
+		delete(w.m, key)
+	}
+}
+
+// lookupStream 在持有分片锁时查找 Stream
+// 不存在（或已过期）时 create 为 true 则创建，否则返回 nil
+func (db *MemDB) lookupStream(s *shard, key string, create bool) (*Stream, error) {
+	st, found, err := lookupValue[*Stream](s, key)
+	if err != nil || found || !create {
+		return st, err
+	}
+	st = newStream()
+	s.data[key] = &Item{Val: st}
+	return st, nil
+}
+
+// XAdd 向 Stream 追加一条消息，Stream 不存在时自动创建
+// id 为 "*" 时自动生成；maxLen > 0 时追加后裁剪到最多 maxLen 条
+func (db *MemDB) XAdd(key, id string, fields []string, maxLen int) (StreamID, error) {
+	defer db.stats.record("xadd", time.Now())
+	if len(fields) == 0 || len(fields)%2 != 0 {
+		return StreamID{}, ErrStreamFields
+	}
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	st, err := db.lookupStream(s, key, true)
+	if err != nil {
+		s.mu.Unlock()
+		return StreamID{}, err
+	}
+	newID, err := st.nextAddID(id, time.Now())
+	if err != nil {
+		s.mu.Unlock()
+		return StreamID{}, err
+	}
+	st.add(newID, append([]string(nil), fields...))
+	if maxLen > 0 {
+		st.trimMaxLen(maxLen)
+	}
+	db.notify(WatchPut, key, newID.String())
+
+	// Stream 的重放依赖顺序，AOF 必须在分片锁内写入
+	db.writeAof(aof.Cmd{
+		Type: "xadd",
+		Key:  key,
+		Args: append([]string{newID.String(), strconv.Itoa(maxLen)}, fields...),
+	})
+	s.mu.Unlock()
+
+	db.hotKeys.touch(key)
+	db.streamWaiters.wake(key)
+	return newID, nil
+}
+
+// XLen 返回 Stream 中的消息数，不存在时为 0
+func (db *MemDB) XLen(key string) (int, error) {
+	defer db.stats.record("xlen", time.Now())
+
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	st, err := db.lookupStream(s, key, false)
+	if err != nil || st == nil {
+		return 0, err
+	}
+	return len(st.entries), nil
+}
+
+// XRange 返回 [start, end] 区间内的消息，支持 "-"、"+" 和 "(" 前缀的开区间；count <= 0 表示不限
+func (db *MemDB) XRange(key, start, end string, count int) ([]StreamEntry, error) {
+	defer db.stats.record("xrange", time.Now())
+	return db.xrange(key, start, end, count, false)
+}
+
+// XRevRange 与 XRange 相同，但按 ID 从大到小返回（参数顺序为 end, start）
+func (db *MemDB) XRevRange(key, end, start string, count int) ([]StreamEntry, error) {
+	defer db.stats.record("xrevrange", time.Now())
+	return db.xrange(key, start, end, count, true)
+}
+
+func (db *MemDB) xrange(key, start, end string, count int, reverse bool) ([]StreamEntry, error) {
+	startID, err := parseRangeStart(start)
+	if err != nil {
+		return nil, err
+	}
+	endID, err := parseRangeEnd(end)
+	if err != nil {
+		return nil, err
+	}
+
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	st, err := db.lookupStream(s, key, false)
+	if err != nil || st == nil {
+		return nil, err
+	}
+	db.hotKeys.touch(key)
+	return st.rangeEntries(startID, endID, count, reverse), nil
+}
+
+// XTrimMaxLen 裁剪 Stream 到最多 maxLen 条，返回删除的条数
+func (db *MemDB) XTrimMaxLen(key string, maxLen int) (int, error) {
+	defer db.stats.record("xtrim", time.Now())
+	if maxLen < 0 {
+		return 0, errors.New("MAXLEN must be non-negative")
+	}
+	return db.xtrim(key, "MAXLEN", strconv.Itoa(maxLen), func(st *Stream) int {
+		return st.trimMaxLen(maxLen)
+	})
+}
+
+// XTrimMinID 删除 ID 小于 minID 的消息，返回删除的条数
+func (db *MemDB) XTrimMinID(key, minID string) (int, error) {
+	defer db.stats.record("xtrim", time.Now())
+	id, err := ParseStreamID(minID)
+	if err != nil {
+		return 0, err
+	}
+	return db.xtrim(key, "MINID", id.String(), func(st *Stream) int {
+		return st.trimMinID(id)
+	})
+}
+
+func (db *MemDB) xtrim(key, strategy, threshold string, trim func(*Stream) int) (int, error) {
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, err := db.lookupStream(s, key, false)
+	if err != nil || st == nil {
+		return 0, err
+	}
+	removed := trim(st)
+	if removed > 0 {
+		db.writeAof(aof.Cmd{Type: "xtrim", Key: key, Args: []string{strategy, threshold}})
+	}
+	return removed, nil
+}
+
+// XRead 从多个 Stream 读取 ID 大于 ids[i] 的消息，ids[i] 为 "$" 表示只读调用之后的新消息
+// block 为 true 且没有消息时阻塞等待，直到有新消息或 ctx 结束；超时返回 nil, nil
+func (db *MemDB) XRead(ctx context.Context, keys, ids []string, count int, block bool) ([]StreamResult, error) {
+	defer db.stats.record("xread", time.Now())
+	if len(keys) != len(ids) {
+		return nil, errors.New("unbalanced XREAD list of streams: for each stream key an ID must be specified")
+	}
+
+	// 1. 解析起始 ID，"$" 在调用时确定
+	after := make([]StreamID, len(keys))
+	for i, key := range keys {
+		if ids[i] != "$" {
+			id, err := ParseStreamID(ids[i])
+			if err != nil {
+				return nil, err
+			}
+			after[i] = id
+			continue
+		}
+		s := db.getShard(key)
+		s.mu.RLock()
+		st, err := db.lookupStream(s, key, false)
+		if st != nil {
+			after[i] = st.lastID
+		}
+		s.mu.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. 读取，没有消息时等待唤醒后重试
+	return db.blockingRead(ctx, keys, block, func() ([]StreamResult, error) {
+		var results []StreamResult
+		for i, key := range keys {
+			s := db.getShard(key)
+			s.mu.RLock()
+			st, err := db.lookupStream(s, key, false)
+			var entries []StreamEntry
+			if st != nil && after[i] != maxStreamID {
+				entries = st.rangeEntries(after[i].next(), maxStreamID, count, false)
+			}
+			s.mu.RUnlock()
+			if err != nil {
+				return nil, err
+			}
+			if len(entries) > 0 {
+				results = append(results, StreamResult{Key: key, Entries: entries})
+			}
+		}
+		return results, nil
+	})
+}
+
+// blockingRead 执行 read，没有结果且 block 为 true 时等待任一 Key 的新消息后重试
+// 先注册等待再读取，保证读取之后追加的消息一定能唤醒等待者
+func (db *MemDB) blockingRead(ctx context.Context, keys []string, block bool, read func() ([]StreamResult, error)) ([]StreamResult, error) {
+	for {
+		var wakeups []<-chan struct{}
+		if block {
+			for _, key := range keys {
+				wakeups = append(wakeups, db.streamWaiters.wait(key))
+			}
+		}
+
+		results, err := read()
+		if err != nil || len(results) > 0 || !block {
+			return results, err
+		}
+
+		if err := waitAny(ctx, wakeups); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+}
+
+// waitAny 等待任一通道被关闭
+func waitAny(ctx context.Context, chs []<-chan struct{}) error {
+	if len(chs) == 1 {
+		select {
+		case <-chs[0]:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	woken := make(chan struct{}, 1)
+	stop := make(chan struct{})
+	defer close(stop)
+	for _, ch := range chs {
+		go func(ch <-chan struct{}) {
+			select {
+			case <-ch:
+				select {
+				case woken <- struct{}{}:
+				default:
+				}
+			case <-stop:
+			}
+		}(ch)
+	}
+	select {
+	case <-woken:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// XGroupCreate 创建消费者组，id 为组的起始位置（"$" 表示只消费之后的新消息）
+// mkStream 为 true 时 Stream 不存在则自动创建
+func (db *MemDB) XGroupCreate(key, group, id string, mkStream bool) error {
+	defer db.stats.record("xgroup", time.Now())
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, err := db.lookupStream(s, key, false)
+	if err != nil {
+		return err
+	}
+	if st == nil {
+		if !mkStream {
+			return ErrNoSuchStream
+		}
+		st, _ = db.lookupStream(s, key, true)
+	}
+	if _, ok := st.groups[group]; ok {
+		return ErrGroupExists
+	}
+
+	startID := st.lastID
+	if id != "$" {
+		if startID, err = ParseStreamID(id); err != nil {
+			return err
+		}
+	}
+	st.groups[group] = &consumerGroup{
+		lastID:    startID,
+		pending:   make(map[StreamID]*pendingState),
+		consumers: make(map[string]*streamConsumer),
+	}
+	db.writeAof(aof.Cmd{Type: "xgroup-create", Key: key, Args: []string{group, startID.String()}})
+	return nil
+}
+
+// XGroupDestroy 删除消费者组及其待确认列表，返回组是否存在
+func (db *MemDB) XGroupDestroy(key, group string) (bool, error) {
+	defer db.stats.record("xgroup", time.Now())
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, err := db.lookupStream(s, key, false)
+	if err != nil || st == nil {
+		return false, err
+	}
+	if _, ok := st.groups[group]; !ok {
+		return false, nil
+	}
+	delete(st.groups, group)
+	db.writeAof(aof.Cmd{Type: "xgroup-destroy", Key: key, Args: []string{group}})
+	return true, nil
+}
+
+// XReadGroup 以消费者组的身份读取消息
+// ids[i] 为 ">" 时读取组内从未投递过的新消息，并记入 consumer 的待确认列表（noAck 为 true 时不记录）；
+// 其他 ID 表示重新读取 consumer 自己待确认列表中大于该 ID 的消息。只有全部为 ">" 时 block 才生效
+func (db *MemDB) XReadGroup(ctx context.Context, group, consumer string, keys, ids []string, count int, block, noAck bool) ([]StreamResult, error) {
+	defer db.stats.record("xreadgroup", time.Now())
+	if len(keys) != len(ids) {
+		return nil, errors.New("unbalanced XREADGROUP list of streams: for each stream key an ID must be specified")
+	}
+
+	history := make([]StreamID, len(keys))
+	for i, id := range ids {
+		if id == ">" {
+			continue
+		}
+		parsed, err := ParseStreamID(id)
+		if err != nil {
+			return nil, err
+		}
+		history[i] = parsed
+		block = false
+	}
+
+	return db.blockingRead(ctx, keys, block, func() ([]StreamResult, error) {
+		var results []StreamResult
+		for i, key := range keys {
+			entries, err := db.readGroup(key, group, consumer, ids[i] == ">", history[i], count, noAck)
+			if err != nil {
+				return nil, err
+			}
+			if len(entries) > 0 {
+				results = append(results, StreamResult{Key: key, Entries: entries})
+			}
+		}
+		return results, nil
+	})
+}
+
+// readGroup 读取单个 Stream，并把投递记录写入 AOF
+func (db *MemDB) readGroup(key, group, consumer string, fresh bool, after StreamID, count int, noAck bool) ([]StreamEntry, error) {
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, err := db.lookupStream(s, key, false)
+	if err != nil {
+		return nil, err
+	}
+	if st == nil {
+		return nil, ErrNoSuchGroup
+	}
+	g, err := st.group(group)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	g.consumer(consumer, now)
+
+	// 1. 选出要投递的消息
+	var entries []StreamEntry
+	if fresh {
+		if g.lastID != maxStreamID {
+			entries = st.rangeEntries(g.lastID.next(), maxStreamID, count, false)
+		}
+	} else {
+		for _, id := range g.sortedPending() {
+			if count > 0 && len(entries) >= count {
+				break
+			}
+			if !after.Less(id) || g.pending[id].consumer != consumer {
+				continue
+			}
+			// 已被裁剪的消息返回空字段，调用方应直接确认
+			e, _ := st.get(id)
+			e.ID = id
+			entries = append(entries, e)
+		}
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	// 2. 更新消费者组状态
+	args := make([]string, 0, len(entries)+3)
+	args = append(args, group, consumer, strconv.FormatBool(fresh && noAck))
+	for _, e := range entries {
+		g.deliver(consumer, e.ID, fresh && noAck, now)
+		args = append(args, e.ID.String())
+	}
+	db.writeAof(aof.Cmd{Type: "xdeliver", Key: key, Args: args})
+	db.hotKeys.touch(key)
+	return entries, nil
+}
+
+// XAck 确认消息，从消费者组的待确认列表中移除，返回实际确认的条数
+func (db *MemDB) XAck(key, group string, ids ...string) (int, error) {
+	defer db.stats.record("xack", time.Now())
+
+	parsed := make([]StreamID, len(ids))
+	for i, id := range ids {
+		var err error
+		if parsed[i], err = ParseStreamID(id); err != nil {
+			return 0, err
+		}
+	}
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, err := db.lookupStream(s, key, false)
+	if err != nil || st == nil {
+		return 0, err
+	}
+	g, ok := st.groups[group]
+	if !ok {
+		return 0, nil
+	}
+
+	acked := 0
+	args := []string{group}
+	for _, id := range parsed {
+		if g.ack(id) {
+			acked++
+			args = append(args, id.String())
+		}
+	}
+	if acked > 0 {
+		db.writeAof(aof.Cmd{Type: "xack", Key: key, Args: args})
+	}
+	return acked, nil
+}
+
+// XPending 返回消费者组待确认消息的概况
+func (db *MemDB) XPending(key, group string) (*PendingSummary, error) {
+	defer db.stats.record("xpending", time.Now())
+
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	g, err := db.lookupGroup(s, key, group)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &PendingSummary{
+		Count:     int64(len(g.pending)),
+		Consumers: make(map[string]int64),
+	}
+	ids := g.sortedPending()
+	if len(ids) > 0 {
+		summary.MinID, summary.MaxID = ids[0], ids[len(ids)-1]
+	}
+	for _, p := range g.pending {
+		summary.Consumers[p.consumer]++
+	}
+	return summary, nil
+}
+
+// XPendingRange 返回 [start, end] 区间内的待确认消息详情
+// consumer 不为空时只返回该消费者的消息，minIdle > 0 时只返回空闲超过 minIdle 的消息
+func (db *MemDB) XPendingRange(key, group, start, end string, count int, consumer string, minIdle time.Duration) ([]PendingEntry, error) {
+	defer db.stats.record("xpending", time.Now())
+	startID, err := parseRangeStart(start)
+	if err != nil {
+		return nil, err
+	}
+	endID, err := parseRangeEnd(end)
+	if err != nil {
+		return nil, err
+	}
+
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	g, err := db.lookupGroup(s, key, group)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var result []PendingEntry
+	for _, id := range g.sortedPending() {
+		if count > 0 && len(result) >= count {
+			break
+		}
+		if id.Less(startID) || endID.Less(id) {
+			continue
+		}
+		p := g.pending[id]
+		idle := now.Sub(p.deliveredAt)
+		if (consumer != "" && p.consumer != consumer) || idle < minIdle {
+			continue
+		}
+		result = append(result, PendingEntry{
+			ID:         id,
+			Consumer:   p.consumer,
+			Idle:       idle,
+			Deliveries: p.deliveries,
+		})
+	}
+	return result, nil
+}
+
+// XClaim 把空闲超过 minIdle 的待确认消息转移给 consumer，返回转移成功的消息
+// 已被裁剪掉的消息会直接从待确认列表中移除
+func (db *MemDB) XClaim(key, group, consumer string, minIdle time.Duration, ids []string) ([]StreamEntry, error) {
+	defer db.stats.record("xclaim", time.Now())
+
+	parsed := make([]StreamID, len(ids))
+	for i, id := range ids {
+		var err error
+		if parsed[i], err = ParseStreamID(id); err != nil {
+			return nil, err
+		}
+	}
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, err := db.lookupGroup(s, key, group)
+	if err != nil {
+		return nil, err
+	}
+	st := s.data[key].Val.(*Stream)
+
+	now := time.Now()
+	var claimed []StreamEntry
+	var claimedArgs, ackedArgs []string
+	for _, id := range parsed {
+		p, ok := g.pending[id]
+		if !ok || now.Sub(p.deliveredAt) < minIdle {
+			continue
+		}
+		e, exists := st.get(id)
+		if !exists {
+			g.ack(id)
+			ackedArgs = append(ackedArgs, id.String())
+			continue
+		}
+		g.deliver(consumer, id, false, now)
+		claimed = append(claimed, e)
+		claimedArgs = append(claimedArgs, id.String())
+	}
+
+	if len(ackedArgs) > 0 {
+		db.writeAof(aof.Cmd{Type: "xack", Key: key, Args: append([]string{group}, ackedArgs...)})
+	}
+	if len(claimedArgs) > 0 {
+		db.writeAof(aof.Cmd{Type: "xdeliver", Key: key, Args: append([]string{group, consumer, "false"}, claimedArgs...)})
+	}
+	return claimed, nil
+}
+
+// lookupGroup 在持有分片锁时查找消费者组
+func (db *MemDB) lookupGroup(s *shard, key, group string) (*consumerGroup, error) {
+	st, err := db.lookupStream(s, key, false)
+	if err != nil {
+		return nil, err
+	}
+	if st == nil {
+		return nil, ErrNoSuchGroup
+	}
+	return st.group(group)
+}
+
+// replayStream 重放 AOF 中的 Stream 命令，调用方持有分片写锁
+func (db *MemDB) replayStream(s *shard, cmd aof.Cmd) error {
+	st, err := db.lookupStream(s, cmd.Key, cmd.Type == "xadd" || cmd.Type == "xgroup-create")
+	if err != nil || st == nil {
+		return err
+	}
+	args := cmd.Args
+	now := time.Now()
+
+	switch cmd.Type {
+	case "xadd":
+		// args: id maxLen field value ...
+		if len(args) < 4 {
+			return ErrStreamFields
+		}
+		id, err := ParseStreamID(args[0])
+		if err != nil {
+			return err
+		}
+		if !st.lastID.Less(id) {
+			return ErrStreamIDTooSmall
+		}
+		st.add(id, args[2:])
+		if maxLen, _ := strconv.Atoi(args[1]); maxLen > 0 {
+			st.trimMaxLen(maxLen)
+		}
+	case "xtrim":
+		// args: MAXLEN|MINID threshold
+		if len(args) != 2 {
+			return fmt.Errorf("bad xtrim args %v", args)
+		}
+		if args[0] == "MAXLEN" {
+			n, _ := strconv.Atoi(args[1])
+			st.trimMaxLen(n)
+		} else if id, err := ParseStreamID(args[1]); err == nil {
+			st.trimMinID(id)
+		}
+	case "xgroup-create":
+		// args: group lastID
+		if len(args) != 2 {
+			return fmt.Errorf("bad xgroup-create args %v", args)
+		}
+		id, err := ParseStreamID(args[1])
+		if err != nil {
+			return err
+		}
+		st.groups[args[0]] = &consumerGroup{
+			lastID:    id,
+			pending:   make(map[StreamID]*pendingState),
+			consumers: make(map[string]*streamConsumer),
+		}
+	case "xgroup-destroy":
+		if len(args) == 1 {
+			delete(st.groups, args[0])
+		}
+	case "xdeliver":
+		// args: group consumer noAck id ...
+		if len(args) < 4 {
+			return fmt.Errorf("bad xdeliver args %v", args)
+		}
+		g, err := st.group(args[0])
+		if err != nil {
+			return err
+		}
+		noAck := args[2] == "true"
+		for _, raw := range args[3:] {
+			if id, err := ParseStreamID(raw); err == nil {
+				g.deliver(args[1], id, noAck, now)
+			}
+		}
+	case "xack":
+		// args: group id ...
+		if len(args) < 2 {
+			return fmt.Errorf("bad xack args %v", args)
+		}
+		g, err := st.group(args[0])
+		if err != nil {
+			return err
+		}
+		for _, raw := range args[1:] {
+			if id, err := ParseStreamID(raw); err == nil {
+				g.ack(id)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package core
+
+import (
+	"Flux-KV/internal/config"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestStream_AddRangeTrim 验证 ID 生成、区间查询和裁剪
+func TestStream_AddRangeTrim(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	for _, id := range []string{"1-1", "1-2", "2-*", "3"} {
+		if _, err := db.XAdd("s", id, []string{"f", id}, 0); err != nil {
+			t.Fatalf("XAdd %s failed: %v", id, err)
+		}
+	}
+	if _, err := db.XAdd("s", "2-0", []string{"f", "v"}, 0); !errors.Is(err, ErrStreamIDTooSmall) {
+		t.Fatalf("want ErrStreamIDTooSmall, got %v", err)
+	}
+	if _, err := db.XAdd("s", "*", []string{"f"}, 0); !errors.Is(err, ErrStreamFields) {
+		t.Fatalf("want ErrStreamFields, got %v", err)
+	}
+
+	entries, _ := db.XRange("s", "-", "+", 0)
+	want := []string{"1-1", "1-2", "2-0", "3-0"}
+	if len(entries) != len(want) {
+		t.Fatalf("want %d entries, got %d", len(want), len(entries))
+	}
+	for i, e := range entries {
+		if e.ID.String() != want[i] {
+			t.Fatalf("entry %d: want %s, got %s", i, want[i], e.ID)
+		}
+	}
+
+	// 省略序号的终点包含整个毫秒，"(" 表示开区间
+	if entries, _ := db.XRange("s", "(1-1", "1", 0); len(entries) != 1 || entries[0].ID.String() != "1-2" {
+		t.Fatalf("unexpected exclusive range: %+v", entries)
+	}
+	if entries, _ := db.XRevRange("s", "+", "-", 1); len(entries) != 1 || entries[0].ID.String() != "3-0" {
+		t.Fatalf("unexpected reverse range: %+v", entries)
+	}
+
+	if n, _ := db.XTrimMaxLen("s", 2); n != 2 {
+		t.Fatalf("want 2 trimmed, got %d", n)
+	}
+	if n, _ := db.XLen("s"); n != 2 {
+		t.Fatalf("want len 2, got %d", n)
+	}
+	// 裁剪后 ID 仍然不能回退
+	if _, err := db.XAdd("s", "1-5", []string{"f", "v"}, 0); !errors.Is(err, ErrStreamIDTooSmall) {
+		t.Fatalf("want ErrStreamIDTooSmall after trim, got %v", err)
+	}
+
+	db.Set("str", "v", 0)
+	if _, err := db.XAdd("str", "*", []string{"f", "v"}, 0); !errors.Is(err, ErrWrongType) {
+		t.Fatalf("want ErrWrongType, got %v", err)
+	}
+}
+
+// TestStream_BlockingRead 阻塞读取在有新消息时被唤醒，超时返回空
+func TestStream_BlockingRead(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		db.XAdd("s", "*", []string{"f", "v"}, 0)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	results, err := db.XRead(ctx, []string{"other", "s"}, []string{"$", "$"}, 0, true)
+	if err != nil {
+		t.Fatalf("XRead failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Key != "s" || len(results[0].Entries) != 1 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel2()
+	results, err = db.XRead(ctx2, []string{"s"}, []string{"$"}, 0, true)
+	if err != nil || results != nil {
+		t.Fatalf("want timeout with nil results, got %+v, %v", results, err)
+	}
+}
+
+// TestStream_ConsumerGroup 验证投递、确认、待确认列表和认领
+func TestStream_ConsumerGroup(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	ctx := context.Background()
+
+	if err := db.XGroupCreate("s", "g", "$", false); !errors.Is(err, ErrNoSuchStream) {
+		t.Fatalf("want ErrNoSuchStream, got %v", err)
+	}
+	if err := db.XGroupCreate("s", "g", "$", true); err != nil {
+		t.Fatalf("XGroupCreate failed: %v", err)
+	}
+	if err := db.XGroupCreate("s", "g", "$", true); !errors.Is(err, ErrGroupExists) {
+		t.Fatalf("want ErrGroupExists, got %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		db.XAdd("s", "*", []string{"n", "x"}, 0)
+	}
+
+	// 1. alice 读 2 条，bob 读剩下的 1 条
+	res, _ := db.XReadGroup(ctx, "g", "alice", []string{"s"}, []string{">"}, 2, false, false)
+	if len(res) != 1 || len(res[0].Entries) != 2 {
+		t.Fatalf("alice: unexpected results %+v", res)
+	}
+	first := res[0].Entries[0].ID
+	res, _ = db.XReadGroup(ctx, "g", "bob", []string{"s"}, []string{">"}, 0, false, false)
+	if len(res) != 1 || len(res[0].Entries) != 1 {
+		t.Fatalf("bob: unexpected results %+v", res)
+	}
+
+	summary, _ := db.XPending("s", "g")
+	if summary.Count != 3 || summary.Consumers["alice"] != 2 || summary.Consumers["bob"] != 1 {
+		t.Fatalf("unexpected pending summary: %+v", summary)
+	}
+
+	// 2. alice 确认第一条
+	if n, _ := db.XAck("s", "g", first.String()); n != 1 {
+		t.Fatalf("want 1 acked, got %d", n)
+	}
+
+	// 3. bob 认领 alice 剩下的那条
+	pending, _ := db.XPendingRange("s", "g", "-", "+", 10, "alice", 0)
+	if len(pending) != 1 {
+		t.Fatalf("want 1 pending for alice, got %+v", pending)
+	}
+	claimed, _ := db.XClaim("s", "g", "bob", 0, []string{pending[0].ID.String()})
+	if len(claimed) != 1 {
+		t.Fatalf("want 1 claimed, got %+v", claimed)
+	}
+	pending, _ = db.XPendingRange("s", "g", "-", "+", 10, "bob", 0)
+	if len(pending) != 2 || pending[0].Deliveries != 2 {
+		t.Fatalf("unexpected pending for bob: %+v", pending)
+	}
+
+	// 4. 重新读取 bob 自己的待确认消息
+	res, _ = db.XReadGroup(ctx, "g", "bob", []string{"s"}, []string{"0"}, 0, false, false)
+	if len(res) != 1 || len(res[0].Entries) != 2 {
+		t.Fatalf("bob history: unexpected results %+v", res)
+	}
+}
+
+// TestStream_AofReplay Stream 和消费者组状态可以从 AOF 恢复
+func TestStream_AofReplay(t *testing.T) {
+	cfg := &config.Config{
+		AOF: config.AOFConfig{Filename: filepath.Join(t.TempDir(), "stream.aof")},
+	}
+	db, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("NewMemDB failed: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		db.XAdd("s", "*", []string{"i", "v"}, 4)
+	}
+	db.XGroupCreate("s", "g", "0", false)
+	res, _ := db.XReadGroup(context.Background(), "g", "c", []string{"s"}, []string{">"}, 2, false, false)
+	db.XAck("s", "g", res[0].Entries[0].ID.String())
+	db.Close()
+
+	db2, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	defer db2.Close()
+
+	if n, _ := db2.XLen("s"); n != 4 {
+		t.Fatalf("want len 4 after replay, got %d", n)
+	}
+	summary, err := db2.XPending("s", "g")
+	if err != nil || summary.Count != 1 || summary.MinID != res[0].Entries[1].ID {
+		t.Fatalf("unexpected pending after replay: %+v, %v", summary, err)
+	}
+	// 组的位置已经恢复，只剩 2 条新消息
+	res2, _ := db2.XReadGroup(context.Background(), "g", "c", []string{"s"}, []string{">"}, 0, false, false)
+	if len(res2) != 1 || len(res2[0].Entries) != 2 {
+		t.Fatalf("unexpected entries after replay: %+v", res2)
+	}
+}
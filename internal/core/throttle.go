@@ -0,0 +1,110 @@
+package core
+
+import (
+	"Flux-KV/internal/aof"
+	"Flux-KV/pkg/kverrors"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrThrottleArgs rate 和 burst 必须大于 0，cost 不能为负数
+	ErrThrottleArgs = kverrors.New(kverrors.InvalidArgument, "throttle rate and burst must be positive and cost must not be negative")
+	// ErrThrottleCost 单次请求的消耗超过了桶容量，永远不会被放行
+	ErrThrottleCost = kverrors.New(kverrors.InvalidArgument, "throttle cost exceeds burst")
+)
+
+// ThrottleState 限流器的状态：GCRA 的理论到达时间（TAT，纳秒时间戳）
+// TAT 之后桶就满了，因此把它同时作为 Key 的过期时间，空闲的限流 Key 会被自动清理
+type ThrottleState struct {
+	TAT int64
+}
+
+// MemSize 估算占用的内存
+func (t *ThrottleState) MemSize() int64 {
+	return 8
+}
+
+// ThrottleResult 限流判定结果
+type ThrottleResult struct {
+	Allowed    bool
+	Limit      int64         // 桶容量，即 burst
+	Remaining  int64         // 本次判定后还能立即放行的请求数
+	RetryAfter time.Duration // 被拒绝时多久后重试可以放行，放行时为 0
+	ResetAfter time.Duration // 多久后桶恢复满
+}
+
+// Throttle 原子地判定一次请求是否放行（GCRA，等价于容量为 burst、每秒补充 rate 个令牌的令牌桶）
+// cost 为本次请求消耗的令牌数，0 表示只查询不消耗；只有放行时才更新状态
+func (db *MemDB) Throttle(key string, rate float64, burst, cost int64) (ThrottleResult, error) {
+	defer db.stats.record("throttle", time.Now())
+
+	if rate <= 0 || burst <= 0 || cost < 0 {
+		return ThrottleResult{}, ErrThrottleArgs
+	}
+	if cost > burst {
+		return ThrottleResult{}, ErrThrottleCost
+	}
+
+	// 1. 每个令牌的补充间隔，桶从空到满需要 burst 个间隔
+	emission := time.Duration(float64(time.Second) / rate)
+	if emission <= 0 {
+		emission = 1
+	}
+	tolerance := emission * time.Duration(burst)
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, found, err := lookupValue[*ThrottleState](s, key)
+	if err != nil {
+		return ThrottleResult{}, err
+	}
+
+	// 2. TAT 早于当前时间说明桶已满
+	now := time.Now().UnixNano()
+	tat := now
+	if found && state.TAT > now {
+		tat = state.TAT
+	}
+	newTAT := tat + int64(emission)*cost
+	allowAt := newTAT - int64(tolerance)
+
+	res := ThrottleResult{Limit: burst}
+	if now < allowAt {
+		// 3. 拒绝：状态不变
+		res.RetryAfter = time.Duration(allowAt - now)
+		res.Remaining = (now - (tat - int64(tolerance))) / int64(emission)
+		res.ResetAfter = time.Duration(tat - now)
+		return res, nil
+	}
+
+	// 4. 放行：推进 TAT
+	res.Allowed = true
+	res.Remaining = (now - allowAt) / int64(emission)
+	res.ResetAfter = time.Duration(newTAT - now)
+	if cost > 0 {
+		s.data[key] = &Item{Val: &ThrottleState{TAT: newTAT}, ExpireAt: newTAT}
+		db.writeAof(aof.Cmd{Type: "throttle", Key: key, Args: []string{strconv.FormatInt(newTAT, 10)}})
+	}
+	return res, nil
+}
+
+// replayThrottle 重放限流状态，调用方持有分片写锁
+// 已经过了 TAT 的状态等价于桶已满，直接丢弃
+func (db *MemDB) replayThrottle(s *shard, cmd aof.Cmd) error {
+	if len(cmd.Args) != 1 {
+		return ErrThrottleArgs
+	}
+	tat, err := strconv.ParseInt(cmd.Args[0], 10, 64)
+	if err != nil {
+		return err
+	}
+	if tat <= time.Now().UnixNano() {
+		delete(s.data, cmd.Key)
+		return nil
+	}
+	s.data[cmd.Key] = &Item{Val: &ThrottleState{TAT: tat}, ExpireAt: tat}
+	return nil
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"Flux-KV/internal/config"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestThrottle_Burst 桶满时可以连续放行 burst 次，之后按速率恢复
+func TestThrottle_Burst(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	// 每秒 10 个令牌，容量 3
+	for i := int64(0); i < 3; i++ {
+		r, err := db.Throttle("api:u1", 10, 3, 1)
+		if err != nil || !r.Allowed || r.Remaining != 2-i || r.Limit != 3 {
+			t.Fatalf("request %d: %+v %v", i, r, err)
+		}
+	}
+	r, _ := db.Throttle("api:u1", 10, 3, 1)
+	if r.Allowed || r.Remaining != 0 || r.RetryAfter <= 0 || r.RetryAfter > 100*time.Millisecond {
+		t.Fatalf("4th request should be denied with retry-after <= 100ms: %+v", r)
+	}
+
+	time.Sleep(r.RetryAfter + 5*time.Millisecond)
+	if r, _ := db.Throttle("api:u1", 10, 3, 1); !r.Allowed {
+		t.Fatalf("should allow after retry-after: %+v", r)
+	}
+
+	// cost 为 0 只查询，不消耗
+	if r, _ := db.Throttle("api:u2", 10, 3, 0); !r.Allowed || r.Remaining != 3 {
+		t.Fatalf("query only: %+v", r)
+	}
+	if _, err := db.Throttle("api:u2", 10, 3, 4); err != ErrThrottleCost {
+		t.Fatalf("want ErrThrottleCost, got %v", err)
+	}
+	if _, err := db.Throttle("api:u2", 0, 3, 1); err != ErrThrottleArgs {
+		t.Fatalf("want ErrThrottleArgs, got %v", err)
+	}
+	db.Set("plain", "x", 0)
+	if _, err := db.Throttle("plain", 10, 3, 1); err != ErrWrongType {
+		t.Fatalf("want ErrWrongType, got %v", err)
+	}
+}
+
+// TestThrottle_Concurrent 并发请求下放行的次数不超过容量
+func TestThrottle_Concurrent(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	var allowed atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if r, _ := db.Throttle("hot", 1, 50, 1); r.Allowed {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := allowed.Load(); n != 50 {
+		t.Fatalf("want exactly 50 allowed, got %d", n)
+	}
+}
+
+// TestThrottle_AofReplay 重启后限流状态不会被重置
+func TestThrottle_AofReplay(t *testing.T) {
+	cfg := &config.Config{
+		AOF: config.AOFConfig{Filename: filepath.Join(t.TempDir(), "throttle.aof")},
+	}
+	db, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("NewMemDB failed: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		db.Throttle("login:ip", 0.1, 5, 1)
+	}
+	db.Close()
+
+	db2, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	defer db2.Close()
+	if r, _ := db2.Throttle("login:ip", 0.1, 5, 1); r.Allowed {
+		t.Fatalf("bucket should still be empty after replay: %+v", r)
+	}
+}
@@ -0,0 +1,754 @@
+package core
+
+import (
+	"Flux-KV/internal/aof"
+	"Flux-KV/pkg/kverrors"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrTSNoSuchSeries Key 不存在
+	ErrTSNoSuchSeries = kverrors.New(kverrors.NotFound, "TSDB: the key does not exist")
+	// ErrTSTimestamp 时间戳不大于最后一个样本（chunk 只支持追加）
+	ErrTSTimestamp = kverrors.New(kverrors.InvalidArgument, "TSDB: timestamp must be greater than the latest sample")
+	// ErrTSAggregation 聚合方式或时间桶不合法
+	ErrTSAggregation = kverrors.New(kverrors.InvalidArgument, "TSDB: unknown aggregation type or non-positive bucket duration")
+	// ErrTSFilter MRANGE 的过滤条件不合法
+	ErrTSFilter = kverrors.New(kverrors.InvalidArgument, "TSDB: filters must contain at least one label=value matcher")
+	// ErrTSRuleExists 已经存在到同一目标的规则
+	ErrTSRuleExists = kverrors.New(kverrors.Conflict, "TSDB: compaction rule already exists")
+	// ErrTSNoSuchRule 规则不存在
+	ErrTSNoSuchRule = kverrors.New(kverrors.NotFound, "TSDB: compaction rule does not exist")
+)
+
+// TSSample 一个样本，时间戳为毫秒
+type TSSample struct {
+	Timestamp int64
+	Value     float64
+}
+
+// TSOptions 创建时间序列的参数
+type TSOptions struct {
+	Retention time.Duration     // 0 表示永久保留
+	Labels    map[string]string // 用于 TS.MRANGE 过滤
+}
+
+// TSAggregation 聚合方式和时间桶（毫秒）
+type TSAggregation struct {
+	Type   string // avg / sum / min / max / count / first / last
+	Bucket int64
+}
+
+func (a TSAggregation) validate() error {
+	switch a.Type {
+	case "avg", "sum", "min", "max", "count", "first", "last":
+	default:
+		return ErrTSAggregation
+	}
+	if a.Bucket <= 0 {
+		return ErrTSAggregation
+	}
+	return nil
+}
+
+// bucketStart 向下取整到桶的起点（负时间戳同样向下取整）
+func (a TSAggregation) bucketStart(ts int64) int64 {
+	r := ts % a.Bucket
+	if r < 0 {
+		r += a.Bucket
+	}
+	return ts - r
+}
+
+// tsAccumulator 一个时间桶内的聚合状态
+type tsAccumulator struct {
+	count         int
+	sum, min, max float64
+	first, last   float64
+}
+
+func (acc *tsAccumulator) add(v float64) {
+	if acc.count == 0 {
+		acc.min, acc.max, acc.first = v, v, v
+	}
+	acc.count++
+	acc.sum += v
+	acc.min = math.Min(acc.min, v)
+	acc.max = math.Max(acc.max, v)
+	acc.last = v
+}
+
+func (acc *tsAccumulator) value(typ string) float64 {
+	switch typ {
+	case "avg":
+		return acc.sum / float64(acc.count)
+	case "sum":
+		return acc.sum
+	case "min":
+		return acc.min
+	case "max":
+		return acc.max
+	case "count":
+		return float64(acc.count)
+	case "first":
+		return acc.first
+	default:
+		return acc.last
+	}
+}
+
+// tsRule 降采样规则：源序列每写满一个时间桶，就把聚合值写入目标序列
+type tsRule struct {
+	dest   string
+	agg    TSAggregation
+	bucket int64 // 当前桶的起点
+	acc    tsAccumulator
+}
+
+// tsCompaction 一条待写入目标序列的聚合样本
+type tsCompaction struct {
+	dest   string
+	sample TSSample
+}
+
+// TimeSeries 时间序列：按时间顺序排列的 Gorilla 压缩 chunk
+type TimeSeries struct {
+	chunks    []*tsChunk
+	retention time.Duration
+	labels    map[string]string
+	rules     []*tsRule
+}
+
+func newTimeSeries(opts TSOptions) *TimeSeries {
+	labels := make(map[string]string, len(opts.Labels))
+	for k, v := range opts.Labels {
+		labels[k] = v
+	}
+	return &TimeSeries{retention: opts.Retention, labels: labels}
+}
+
+// MemSize 估算占用的内存
+func (ts *TimeSeries) MemSize() int64 {
+	var size int64
+	for _, c := range ts.chunks {
+		size += c.memSize()
+	}
+	return size
+}
+
+func (ts *TimeSeries) count() int {
+	n := 0
+	for _, c := range ts.chunks {
+		n += c.count
+	}
+	return n
+}
+
+func (ts *TimeSeries) last() (TSSample, bool) {
+	if len(ts.chunks) == 0 {
+		return TSSample{}, false
+	}
+	c := ts.chunks[len(ts.chunks)-1]
+	return TSSample{Timestamp: c.lastTs, Value: c.lastVal}, true
+}
+
+// add 追加样本，返回因时间桶结束而需要写入目标序列的聚合样本
+func (ts *TimeSeries) add(t int64, v float64) ([]tsCompaction, error) {
+	if last, ok := ts.last(); ok && t <= last.Timestamp {
+		return nil, ErrTSTimestamp
+	}
+	if len(ts.chunks) == 0 || ts.chunks[len(ts.chunks)-1].full() {
+		ts.chunks = append(ts.chunks, &tsChunk{})
+	}
+	ts.chunks[len(ts.chunks)-1].append(t, v)
+
+	var out []tsCompaction
+	for _, r := range ts.rules {
+		start := r.agg.bucketStart(t)
+		if r.acc.count > 0 && start != r.bucket {
+			out = append(out, tsCompaction{dest: r.dest, sample: TSSample{r.bucket, r.acc.value(r.agg.Type)}})
+			r.acc = tsAccumulator{}
+		}
+		r.bucket = start
+		r.acc.add(v)
+	}
+	return out, nil
+}
+
+// cutoff 早于该时间戳的样本已经过期，未设置保留时间时返回 MinInt64
+func (ts *TimeSeries) cutoff(now time.Time) int64 {
+	if ts.retention <= 0 {
+		return math.MinInt64
+	}
+	return now.Add(-ts.retention).UnixMilli()
+}
+
+// trim 删除所有样本都已过期的 chunk，返回删除的样本数
+// 部分过期的 chunk 保留，查询时按 cutoff 过滤
+func (ts *TimeSeries) trim(now time.Time) int {
+	cutoff := ts.cutoff(now)
+	n, removed := 0, 0
+	for n < len(ts.chunks) && ts.chunks[n].lastTs < cutoff {
+		removed += ts.chunks[n].count
+		n++
+	}
+	if n > 0 {
+		clear(ts.chunks[:n])
+		ts.chunks = ts.chunks[n:]
+	}
+	return removed
+}
+
+// needsTrim 最早的 chunk 是否已经整体过期
+func (ts *TimeSeries) needsTrim(now time.Time) bool {
+	return len(ts.chunks) > 0 && ts.chunks[0].lastTs < ts.cutoff(now)
+}
+
+// rangeRaw 遍历 [from, to] 内未过期的样本
+func (ts *TimeSeries) rangeRaw(from, to int64, now time.Time, fn func(s TSSample) bool) {
+	from = max(from, ts.cutoff(now))
+	// 跳过整体早于 from 的 chunk
+	i := sort.Search(len(ts.chunks), func(i int) bool { return ts.chunks[i].lastTs >= from })
+	for ; i < len(ts.chunks) && ts.chunks[i].firstTs <= to; i++ {
+		stop := false
+		ts.chunks[i].iterate(func(t int64, v float64) bool {
+			if t < from {
+				return true
+			}
+			if t > to || !fn(TSSample{t, v}) {
+				stop = true
+				return false
+			}
+			return true
+		})
+		if stop {
+			return
+		}
+	}
+}
+
+// query 查询区间内的样本，agg 为 nil 时返回原始数据；count > 0 时最多返回 count 条
+func (ts *TimeSeries) query(from, to int64, agg *TSAggregation, count int, now time.Time) []TSSample {
+	var out []TSSample
+	if agg == nil {
+		ts.rangeRaw(from, to, now, func(s TSSample) bool {
+			out = append(out, s)
+			return count <= 0 || len(out) < count
+		})
+		return out
+	}
+
+	var acc tsAccumulator
+	var bucket int64
+	done := false
+	ts.rangeRaw(from, to, now, func(s TSSample) bool {
+		start := agg.bucketStart(s.Timestamp)
+		if acc.count > 0 && start != bucket {
+			out = append(out, TSSample{bucket, acc.value(agg.Type)})
+			acc = tsAccumulator{}
+			if count > 0 && len(out) >= count {
+				done = true
+				return false
+			}
+		}
+		bucket = start
+		acc.add(s.Value)
+		return true
+	})
+	if !done && acc.count > 0 {
+		out = append(out, TSSample{bucket, acc.value(agg.Type)})
+	}
+	return out
+}
+
+// tsLabelIndex label=value 到 Key 的倒排索引，供 TS.MRANGE 使用
+// 删除或覆盖的 Key 不会立即从索引中移除，查询时校验并顺带清理
+type tsLabelIndex struct {
+	mu   sync.RWMutex
+	keys map[string]map[string]struct{}
+}
+
+func newTSLabelIndex() *tsLabelIndex {
+	return &tsLabelIndex{keys: make(map[string]map[string]struct{})}
+}
+
+func (idx *tsLabelIndex) add(key string, labels map[string]string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for k, v := range labels {
+		pair := k + "=" + v
+		if idx.keys[pair] == nil {
+			idx.keys[pair] = make(map[string]struct{})
+		}
+		idx.keys[pair][key] = struct{}{}
+	}
+}
+
+func (idx *tsLabelIndex) remove(key, pair string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	delete(idx.keys[pair], key)
+	if len(idx.keys[pair]) == 0 {
+		delete(idx.keys, pair)
+	}
+}
+
+func (idx *tsLabelIndex) lookup(pair string) []string {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	keys := make([]string, 0, len(idx.keys[pair]))
+	for k := range idx.keys[pair] {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// tsFilter 一个标签过滤条件
+//
+//	label=value   标签等于 value
+//	label!=value  标签不等于 value（或不存在）
+//	label=        不存在该标签
+//	label!=       存在该标签
+type tsFilter struct {
+	label, value string
+	negate       bool
+}
+
+func parseTSFilters(filters []string) ([]tsFilter, error) {
+	out := make([]tsFilter, 0, len(filters))
+	hasMatcher := false
+	for _, f := range filters {
+		var tf tsFilter
+		if i := strings.Index(f, "!="); i > 0 {
+			tf = tsFilter{label: f[:i], value: f[i+2:], negate: true}
+		} else if i := strings.Index(f, "="); i > 0 {
+			tf = tsFilter{label: f[:i], value: f[i+1:]}
+		} else {
+			return nil, fmt.Errorf("TSDB: invalid filter %q", f)
+		}
+		if !tf.negate && tf.value != "" {
+			hasMatcher = true
+		}
+		out = append(out, tf)
+	}
+	if !hasMatcher {
+		return nil, ErrTSFilter
+	}
+	return out, nil
+}
+
+func (f tsFilter) match(labels map[string]string) bool {
+	v, ok := labels[f.label]
+	if f.value == "" {
+		return ok == f.negate
+	}
+	return (ok && v == f.value) != f.negate
+}
+
+// TSInfo 时间序列的元信息
+type TSInfo struct {
+	TotalSamples   int
+	MemoryUsage    int64
+	ChunkCount     int
+	FirstTimestamp int64
+	LastTimestamp  int64
+	Retention      time.Duration
+	Labels         map[string]string
+	Rules          []TSRuleInfo
+}
+
+// TSRuleInfo 降采样规则
+type TSRuleInfo struct {
+	Dest        string
+	Aggregation TSAggregation
+}
+
+// TSSeries TS.MRANGE 的一个结果
+type TSSeries struct {
+	Key     string
+	Labels  map[string]string
+	Samples []TSSample
+}
+
+// TSCreate 创建时间序列，Key 已存在时返回 ErrKeyExists
+func (db *MemDB) TSCreate(key string, opts TSOptions) error {
+	defer db.stats.record("ts.create", time.Now())
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, found, _ := lookupValue[any](s, key); found {
+		return ErrKeyExists
+	}
+	db.createSeries(s, key, opts)
+	return nil
+}
+
+// createSeries 创建并记录 AOF，调用方持有分片写锁
+func (db *MemDB) createSeries(s *shard, key string, opts TSOptions) *TimeSeries {
+	series := newTimeSeries(opts)
+	s.data[key] = &Item{Val: series}
+	db.tsIndex.add(key, series.labels)
+
+	args := []string{strconv.FormatInt(opts.Retention.Milliseconds(), 10)}
+	for k, v := range series.labels {
+		args = append(args, k, v)
+	}
+	db.writeAof(aof.Cmd{Type: "ts.create", Key: key, Args: args})
+	return series
+}
+
+// TSAdd 追加样本（时间戳为毫秒），Key 不存在时按 opts 创建
+// 源序列上的降采样规则在时间桶结束时把聚合值写入目标序列
+func (db *MemDB) TSAdd(key string, ts int64, value float64, opts TSOptions) error {
+	defer db.stats.record("ts.add", time.Now())
+
+	s := db.getShard(key)
+	s.mu.Lock()
+	series, found, err := lookupValue[*TimeSeries](s, key)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	if !found {
+		series = db.createSeries(s, key, opts)
+	}
+	compactions, err := series.add(ts, value)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	db.notify(WatchPut, key, nil)
+	db.writeAof(aof.Cmd{
+		Type: "ts.add",
+		Key:  key,
+		Args: []string{strconv.FormatInt(ts, 10), strconv.FormatFloat(value, 'g', -1, 64)},
+	})
+	s.mu.Unlock()
+
+	// 目标序列可能在其他分片（甚至同一分片），释放源分片锁之后再写
+	db.applyCompactions(compactions)
+	db.hotKeys.touch(key)
+	return nil
+}
+
+// applyCompactions 把聚合样本写入目标序列（目标序列上的规则会继续级联）
+// 聚合样本由源序列的 AOF 重放推导出来，不单独记录 AOF
+func (db *MemDB) applyCompactions(pending []tsCompaction) {
+	for len(pending) > 0 {
+		c := pending[0]
+		pending = pending[1:]
+
+		s := db.getShard(c.dest)
+		s.mu.Lock()
+		series, found, err := lookupValue[*TimeSeries](s, c.dest)
+		if err == nil && found {
+			more, err := series.add(c.sample.Timestamp, c.sample.Value)
+			if err == nil {
+				pending = append(pending, more...)
+				db.notify(WatchPut, c.dest, nil)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// TSGet 返回最后一个样本
+func (db *MemDB) TSGet(key string) (TSSample, bool, error) {
+	defer db.stats.record("ts.get", time.Now())
+
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	series, found, err := lookupValue[*TimeSeries](s, key)
+	if err != nil {
+		return TSSample{}, false, err
+	}
+	if !found {
+		return TSSample{}, false, ErrTSNoSuchSeries
+	}
+	last, ok := series.last()
+	if ok && last.Timestamp < series.cutoff(time.Now()) {
+		ok = false
+	}
+	return last, ok, nil
+}
+
+// TSRange 查询 [from, to] 内的样本，agg 不为 nil 时按时间桶聚合
+func (db *MemDB) TSRange(key string, from, to int64, agg *TSAggregation, count int) ([]TSSample, error) {
+	defer db.stats.record("ts.range", time.Now())
+
+	if agg != nil {
+		if err := agg.validate(); err != nil {
+			return nil, err
+		}
+	}
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	series, found, err := lookupValue[*TimeSeries](s, key)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrTSNoSuchSeries
+	}
+	db.hotKeys.touch(key)
+	return series.query(from, to, agg, count, time.Now()), nil
+}
+
+// TSMRange 按标签过滤查询多个序列，结果按 Key 排序
+func (db *MemDB) TSMRange(from, to int64, filters []string, agg *TSAggregation, count int) ([]TSSeries, error) {
+	defer db.stats.record("ts.mrange", time.Now())
+
+	parsed, err := parseTSFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+	if agg != nil {
+		if err := agg.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// 1. 用第一个等值条件从索引中取候选 Key
+	var first tsFilter
+	for _, f := range parsed {
+		if !f.negate && f.value != "" {
+			first = f
+			break
+		}
+	}
+	pair := first.label + "=" + first.value
+	candidates := db.tsIndex.lookup(pair)
+	sort.Strings(candidates)
+
+	// 2. 逐个校验类型和全部条件
+	now := time.Now()
+	var out []TSSeries
+	for _, key := range candidates {
+		s := db.getShard(key)
+		s.mu.RLock()
+		series, found, _ := lookupValue[*TimeSeries](s, key)
+		if !found || !first.match(series.labels) {
+			// Key 已被删除或重建，索引过期
+			s.mu.RUnlock()
+			db.tsIndex.remove(key, pair)
+			continue
+		}
+		matched := true
+		for _, f := range parsed {
+			if !f.match(series.labels) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			labels := make(map[string]string, len(series.labels))
+			for k, v := range series.labels {
+				labels[k] = v
+			}
+			out = append(out, TSSeries{Key: key, Labels: labels, Samples: series.query(from, to, agg, count, now)})
+		}
+		s.mu.RUnlock()
+	}
+	return out, nil
+}
+
+// TSCreateRule 创建降采样规则，源和目标都必须是已存在的时间序列
+func (db *MemDB) TSCreateRule(src, dest string, agg TSAggregation) error {
+	defer db.stats.record("ts.createrule", time.Now())
+
+	if err := agg.validate(); err != nil {
+		return err
+	}
+	if src == dest {
+		return errors.New("TSDB: source and destination key cannot be the same")
+	}
+	if err := db.checkSeries(dest); err != nil {
+		return err
+	}
+
+	s := db.getShard(src)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	series, found, err := lookupValue[*TimeSeries](s, src)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrTSNoSuchSeries
+	}
+	if err := series.addRule(dest, agg); err != nil {
+		return err
+	}
+	db.writeAof(aof.Cmd{
+		Type: "ts.createrule",
+		Key:  src,
+		Args: []string{dest, agg.Type, strconv.FormatInt(agg.Bucket, 10)},
+	})
+	return nil
+}
+
+// TSDeleteRule 删除降采样规则，当前未结束的时间桶直接丢弃
+func (db *MemDB) TSDeleteRule(src, dest string) error {
+	defer db.stats.record("ts.deleterule", time.Now())
+
+	s := db.getShard(src)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	series, found, err := lookupValue[*TimeSeries](s, src)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrTSNoSuchSeries
+	}
+	if !series.deleteRule(dest) {
+		return ErrTSNoSuchRule
+	}
+	db.writeAof(aof.Cmd{Type: "ts.deleterule", Key: src, Args: []string{dest}})
+	return nil
+}
+
+// TSInfo 返回时间序列的元信息
+func (db *MemDB) TSInfo(key string) (*TSInfo, error) {
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	series, found, err := lookupValue[*TimeSeries](s, key)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrTSNoSuchSeries
+	}
+
+	info := &TSInfo{
+		TotalSamples: series.count(),
+		MemoryUsage:  series.MemSize(),
+		ChunkCount:   len(series.chunks),
+		Retention:    series.retention,
+		Labels:       make(map[string]string, len(series.labels)),
+	}
+	if len(series.chunks) > 0 {
+		info.FirstTimestamp = series.chunks[0].firstTs
+		info.LastTimestamp = series.chunks[len(series.chunks)-1].lastTs
+	}
+	for k, v := range series.labels {
+		info.Labels[k] = v
+	}
+	for _, r := range series.rules {
+		info.Rules = append(info.Rules, TSRuleInfo{Dest: r.dest, Aggregation: r.agg})
+	}
+	return info, nil
+}
+
+func (db *MemDB) checkSeries(key string) error {
+	s := db.getShard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, found, err := lookupValue[*TimeSeries](s, key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrTSNoSuchSeries
+	}
+	return nil
+}
+
+func (ts *TimeSeries) addRule(dest string, agg TSAggregation) error {
+	for _, r := range ts.rules {
+		if r.dest == dest {
+			return ErrTSRuleExists
+		}
+	}
+	ts.rules = append(ts.rules, &tsRule{dest: dest, agg: agg})
+	return nil
+}
+
+func (ts *TimeSeries) deleteRule(dest string) bool {
+	for i, r := range ts.rules {
+		if r.dest == dest {
+			ts.rules = append(ts.rules[:i], ts.rules[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// trimSeries 由后台清理调用：删除整体过期的 chunk
+func (db *MemDB) trimSeries(s *shard, keys []string, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		if series, found, _ := lookupValue[*TimeSeries](s, key); found {
+			series.trim(now)
+		}
+	}
+}
+
+// replayTS 重放 AOF 中的时间序列命令，调用方持有分片写锁
+// 返回的聚合样本由调用方在释放锁之后写入目标序列
+func (db *MemDB) replayTS(s *shard, cmd aof.Cmd) ([]tsCompaction, error) {
+	switch cmd.Type {
+	case "ts.create":
+		if len(cmd.Args) < 1 || len(cmd.Args)%2 != 1 {
+			return nil, errors.New("invalid ts.create args")
+		}
+		retention, err := strconv.ParseInt(cmd.Args[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		opts := TSOptions{Retention: time.Duration(retention) * time.Millisecond, Labels: map[string]string{}}
+		for i := 1; i < len(cmd.Args); i += 2 {
+			opts.Labels[cmd.Args[i]] = cmd.Args[i+1]
+		}
+		series := newTimeSeries(opts)
+		s.data[cmd.Key] = &Item{Val: series}
+		db.tsIndex.add(cmd.Key, series.labels)
+		return nil, nil
+	}
+
+	series, found, err := lookupValue[*TimeSeries](s, cmd.Key)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrTSNoSuchSeries
+	}
+	switch cmd.Type {
+	case "ts.add":
+		if len(cmd.Args) != 2 {
+			return nil, errors.New("invalid ts.add args")
+		}
+		ts, err1 := strconv.ParseInt(cmd.Args[0], 10, 64)
+		v, err2 := strconv.ParseFloat(cmd.Args[1], 64)
+		if err1 != nil || err2 != nil {
+			return nil, errors.New("invalid ts.add args")
+		}
+		return series.add(ts, v)
+	case "ts.createrule":
+		if len(cmd.Args) != 3 {
+			return nil, errors.New("invalid ts.createrule args")
+		}
+		bucket, err := strconv.ParseInt(cmd.Args[2], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return nil, series.addRule(cmd.Args[0], TSAggregation{Type: cmd.Args[1], Bucket: bucket})
+	case "ts.deleterule":
+		if len(cmd.Args) != 1 || !series.deleteRule(cmd.Args[0]) {
+			return nil, ErrTSNoSuchRule
+		}
+	}
+	return nil, nil
+}
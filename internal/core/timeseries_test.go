@@ -0,0 +1,209 @@
+package core
+
+import (
+	"Flux-KV/internal/config"
+	"errors"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestTSChunk_RoundTrip 压缩后解码出的样本与写入的完全一致
+func TestTSChunk_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var c tsChunk
+	var want []TSSample
+	ts := int64(1_700_000_000_000)
+	v := 100.0
+	for i := 0; i < tsChunkSamples; i++ {
+		// 混合等间隔、抖动、大跳跃和各种数值变化
+		switch rng.Intn(4) {
+		case 0:
+			ts += 1000
+		case 1:
+			ts += 1000 + int64(rng.Intn(100))
+		case 2:
+			ts += int64(rng.Intn(1 << 20))
+		default:
+			ts += 1 + int64(rng.Intn(3))
+		}
+		switch rng.Intn(3) {
+		case 1:
+			v += rng.NormFloat64()
+		case 2:
+			v = math.Float64frombits(rng.Uint64())
+		}
+		c.append(ts, v)
+		want = append(want, TSSample{ts, v})
+	}
+
+	i := 0
+	c.iterate(func(ts int64, v float64) bool {
+		if ts != want[i].Timestamp || math.Float64bits(v) != math.Float64bits(want[i].Value) {
+			t.Fatalf("sample %d: want %+v, got {%d %v}", i, want[i], ts, v)
+		}
+		i++
+		return true
+	})
+	if i != len(want) {
+		t.Fatalf("want %d samples, got %d", len(want), i)
+	}
+
+	// 等间隔的常量序列每个样本只需 2 位
+	var flat tsChunk
+	for i := 0; i < tsChunkSamples; i++ {
+		flat.append(int64(i)*1000, 42)
+	}
+	if size := flat.memSize(); size > 100 {
+		t.Fatalf("flat chunk too large: %d bytes", size)
+	}
+}
+
+// TestTimeSeries_RangeAggregation 原始查询和按时间桶聚合
+func TestTimeSeries_RangeAggregation(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	for i := int64(0); i < 1000; i++ {
+		if err := db.TSAdd("cpu", i*10, float64(i%10), TSOptions{}); err != nil {
+			t.Fatalf("TSAdd failed: %v", err)
+		}
+	}
+	if err := db.TSAdd("cpu", 500, 1, TSOptions{}); !errors.Is(err, ErrTSTimestamp) {
+		t.Fatalf("want ErrTSTimestamp, got %v", err)
+	}
+
+	raw, _ := db.TSRange("cpu", 100, 150, nil, 0)
+	if len(raw) != 6 || raw[0] != (TSSample{100, 0}) || raw[5] != (TSSample{150, 5}) {
+		t.Fatalf("unexpected raw range: %+v", raw)
+	}
+
+	// 每 100ms 一个桶，包含 0..9 十个值
+	for _, tc := range []struct {
+		agg  string
+		want float64
+	}{
+		{"avg", 4.5}, {"sum", 45}, {"min", 0}, {"max", 9}, {"count", 10}, {"first", 0}, {"last", 9},
+	} {
+		got, err := db.TSRange("cpu", 0, 9999, &TSAggregation{Type: tc.agg, Bucket: 100}, 0)
+		if err != nil || len(got) != 100 {
+			t.Fatalf("%s: want 100 buckets, got %d, %v", tc.agg, len(got), err)
+		}
+		if got[3].Timestamp != 300 || got[3].Value != tc.want {
+			t.Fatalf("%s: unexpected bucket %+v", tc.agg, got[3])
+		}
+	}
+
+	if got, _ := db.TSRange("cpu", 0, 9999, &TSAggregation{Type: "sum", Bucket: 100}, 3); len(got) != 3 {
+		t.Fatalf("want 3 buckets with COUNT, got %d", len(got))
+	}
+	if _, err := db.TSRange("cpu", 0, 1, &TSAggregation{Type: "median", Bucket: 100}, 0); !errors.Is(err, ErrTSAggregation) {
+		t.Fatalf("want ErrTSAggregation, got %v", err)
+	}
+}
+
+// TestTimeSeries_MRangeAndRules 标签过滤和降采样规则
+func TestTimeSeries_MRangeAndRules(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+
+	db.TSCreate("cpu:1", TSOptions{Labels: map[string]string{"metric": "cpu", "host": "a"}})
+	db.TSCreate("cpu:2", TSOptions{Labels: map[string]string{"metric": "cpu", "host": "b"}})
+	db.TSCreate("mem:1", TSOptions{Labels: map[string]string{"metric": "mem", "host": "a"}})
+	db.TSCreate("cpu:1:avg", TSOptions{Labels: map[string]string{"metric": "cpu_avg"}})
+	if err := db.TSCreateRule("cpu:1", "cpu:1:avg", TSAggregation{Type: "avg", Bucket: 1000}); err != nil {
+		t.Fatalf("TSCreateRule failed: %v", err)
+	}
+	if err := db.TSCreateRule("cpu:1", "missing", TSAggregation{Type: "avg", Bucket: 1000}); !errors.Is(err, ErrTSNoSuchSeries) {
+		t.Fatalf("want ErrTSNoSuchSeries, got %v", err)
+	}
+
+	for i := int64(0); i < 30; i++ {
+		db.TSAdd("cpu:1", i*100, float64(i), TSOptions{})
+		db.TSAdd("cpu:2", i*100, float64(i), TSOptions{})
+	}
+
+	// 删除后重建为字符串，索引中的条目应被忽略
+	db.Del("cpu:2")
+	db.Set("cpu:2", "v", 0)
+
+	series, err := db.TSMRange(0, math.MaxInt64, []string{"metric=cpu", "host!=b"}, nil, 0)
+	if err != nil || len(series) != 1 || series[0].Key != "cpu:1" || len(series[0].Samples) != 30 {
+		t.Fatalf("unexpected MRANGE result: %+v, %v", series, err)
+	}
+	if _, err := db.TSMRange(0, 1, []string{"host!=b"}, nil, 0); !errors.Is(err, ErrTSFilter) {
+		t.Fatalf("want ErrTSFilter, got %v", err)
+	}
+
+	// 前两个桶已经结束，第三个桶仍在累积
+	compacted, _ := db.TSRange("cpu:1:avg", 0, math.MaxInt64, nil, 0)
+	if len(compacted) != 2 || compacted[0] != (TSSample{0, 4.5}) || compacted[1] != (TSSample{1000, 14.5}) {
+		t.Fatalf("unexpected compacted samples: %+v", compacted)
+	}
+}
+
+// TestTimeSeries_Retention 后台清理删除整体过期的 chunk，查询过滤部分过期的样本
+func TestTimeSeries_Retention(t *testing.T) {
+	db, _ := NewMemDB(&config.Config{})
+	now := time.Now().UnixMilli()
+
+	opts := TSOptions{Retention: time.Hour}
+	for i := int64(0); i < tsChunkSamples*2; i++ {
+		// 前 256 个样本在两小时前，后面的都在保留期内
+		ts := now - 2*time.Hour.Milliseconds() + i
+		if i >= tsChunkSamples {
+			ts = now - time.Minute.Milliseconds() + i
+		}
+		db.TSAdd("m", ts, 1, opts)
+	}
+
+	if got, _ := db.TSRange("m", 0, math.MaxInt64, nil, 0); len(got) != tsChunkSamples {
+		t.Fatalf("want %d live samples, got %d", tsChunkSamples, len(got))
+	}
+	db.activeCleanup()
+	info, _ := db.TSInfo("m")
+	if info.ChunkCount != 1 || info.TotalSamples != tsChunkSamples {
+		t.Fatalf("want expired chunk trimmed, got %+v", info)
+	}
+}
+
+// TestTimeSeries_AofReplay 样本、规则和降采样结果都可以从 AOF 恢复
+func TestTimeSeries_AofReplay(t *testing.T) {
+	cfg := &config.Config{
+		AOF: config.AOFConfig{Filename: filepath.Join(t.TempDir(), "ts.aof")},
+	}
+	db, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("NewMemDB failed: %v", err)
+	}
+	db.TSAdd("temp", 0, 20, TSOptions{Labels: map[string]string{"room": "kitchen"}})
+	db.TSCreate("temp:max", TSOptions{})
+	db.TSCreateRule("temp", "temp:max", TSAggregation{Type: "max", Bucket: 60_000})
+	for i := int64(1); i <= 180; i++ {
+		db.TSAdd("temp", i*1000, 20+float64(i%60), TSOptions{})
+	}
+	db.Close()
+
+	db2, err := NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	defer db2.Close()
+
+	if got, _ := db2.TSRange("temp", 0, math.MaxInt64, nil, 0); len(got) != 181 {
+		t.Fatalf("want 181 samples after replay, got %d", len(got))
+	}
+	compacted, _ := db2.TSRange("temp:max", 0, math.MaxInt64, nil, 0)
+	if len(compacted) != 3 || compacted[0].Value != 79 {
+		t.Fatalf("unexpected compacted samples after replay: %+v", compacted)
+	}
+	series, _ := db2.TSMRange(0, 0, []string{"room=kitchen"}, nil, 0)
+	if len(series) != 1 || series[0].Key != "temp" {
+		t.Fatalf("label index not rebuilt: %+v", series)
+	}
+	// 规则的累积状态也已恢复，下一个桶开始时输出第 4 个聚合值
+	db2.TSAdd("temp", 240_000, 0, TSOptions{})
+	if compacted, _ = db2.TSRange("temp:max", 0, math.MaxInt64, nil, 0); len(compacted) != 4 || compacted[3].Value != 20 {
+		t.Fatalf("unexpected compacted samples after new bucket: %+v", compacted)
+	}
+}
@@ -0,0 +1,214 @@
+package core
+
+import (
+	"math"
+	"math/bits"
+)
+
+// 每个 chunk 最多保存的样本数，写满后新开一个
+// chunk 是保留策略的最小删除单位，太大会让过期数据留存更久
+const tsChunkSamples = 256
+
+// bitWriter 按位追加写入
+type bitWriter struct {
+	buf  []byte
+	free uint8 // 最后一个字节还剩多少位可写
+}
+
+func (w *bitWriter) writeBit(bit bool) {
+	if w.free == 0 {
+		w.buf = append(w.buf, 0)
+		w.free = 8
+	}
+	w.free--
+	if bit {
+		w.buf[len(w.buf)-1] |= 1 << w.free
+	}
+}
+
+// writeBits 写入 v 的低 n 位（高位在前）
+func (w *bitWriter) writeBits(v uint64, n int) {
+	for n > 0 {
+		if w.free == 0 {
+			w.buf = append(w.buf, 0)
+			w.free = 8
+		}
+		take := min(n, int(w.free))
+		chunk := byte(v>>(n-take)) & (1<<take - 1)
+		w.free -= uint8(take)
+		w.buf[len(w.buf)-1] |= chunk << w.free
+		n -= take
+	}
+}
+
+// bitReader 按位顺序读取
+type bitReader struct {
+	buf []byte
+	pos int // 已读的位数
+}
+
+func (r *bitReader) readBit() bool {
+	b := r.buf[r.pos/8]&(1<<(7-r.pos%8)) != 0
+	r.pos++
+	return b
+}
+
+func (r *bitReader) readBits(n int) uint64 {
+	var v uint64
+	for n > 0 {
+		off := r.pos % 8
+		take := min(n, 8-off)
+		chunk := uint64(r.buf[r.pos/8]>>(8-off-take)) & (1<<take - 1)
+		v = v<<take | chunk
+		r.pos += take
+		n -= take
+	}
+	return v
+}
+
+// tsChunk Gorilla 压缩的样本块（Pelkonen et al., VLDB 2015）
+//   - 时间戳：第一个样本存原值，之后存二阶差分 (t_n - t_{n-1}) - (t_{n-1} - t_{n-2})，
+//     等间隔采集时每个样本只占 1 位
+//   - 数值：与前一个值异或，相同时占 1 位；否则只存中间的有效位，
+//     前导零和尾随零与上一次相同时可以复用窗口
+type tsChunk struct {
+	w     bitWriter
+	count int
+
+	firstTs   int64
+	lastTs    int64
+	lastDelta int64
+	lastVal   float64
+	leading   uint8 // 上一次写入的有效位窗口
+	trailing  uint8
+}
+
+// 二阶差分的编码档位：前缀 + 定长补码
+var tsDodBuckets = []struct {
+	prefix, prefixBits uint64
+	bits               int
+}{
+	{0b10, 2, 7},
+	{0b110, 3, 9},
+	{0b1110, 4, 12},
+}
+
+func (c *tsChunk) full() bool {
+	return c.count >= tsChunkSamples
+}
+
+// append 追加样本，调用方保证 ts 严格递增
+func (c *tsChunk) append(ts int64, v float64) {
+	if c.count == 0 {
+		c.w.writeBits(uint64(ts), 64)
+		c.w.writeBits(math.Float64bits(v), 64)
+		c.firstTs, c.lastTs, c.lastVal = ts, ts, v
+		c.count = 1
+		return
+	}
+
+	// 1. 时间戳
+	delta := ts - c.lastTs
+	dod := delta - c.lastDelta
+	if dod == 0 {
+		c.w.writeBit(false)
+	} else {
+		written := false
+		for _, b := range tsDodBuckets {
+			lo, hi := -(int64(1) << (b.bits - 1)), int64(1)<<(b.bits-1)-1
+			if dod >= lo && dod <= hi {
+				c.w.writeBits(b.prefix, int(b.prefixBits))
+				c.w.writeBits(uint64(dod), b.bits)
+				written = true
+				break
+			}
+		}
+		if !written {
+			c.w.writeBits(0b1111, 4)
+			c.w.writeBits(uint64(dod), 64)
+		}
+	}
+
+	// 2. 数值
+	xor := math.Float64bits(v) ^ math.Float64bits(c.lastVal)
+	if xor == 0 {
+		c.w.writeBit(false)
+	} else {
+		c.w.writeBit(true)
+		leading := uint8(bits.LeadingZeros64(xor))
+		trailing := uint8(bits.TrailingZeros64(xor))
+		// 前导零用 5 位存，最多 31
+		if leading > 31 {
+			leading = 31
+		}
+		if c.count > 1 && c.leading <= leading && c.trailing <= trailing {
+			// 落在上一次的窗口内，直接复用
+			c.w.writeBit(false)
+			c.w.writeBits(xor>>c.trailing, 64-int(c.leading)-int(c.trailing))
+		} else {
+			sig := 64 - leading - trailing
+			c.w.writeBit(true)
+			c.w.writeBits(uint64(leading), 5)
+			// 有效位数为 1~64，存 sig-1 用 6 位
+			c.w.writeBits(uint64(sig-1), 6)
+			c.w.writeBits(xor>>trailing, int(sig))
+			c.leading, c.trailing = leading, trailing
+		}
+	}
+
+	c.lastDelta = delta
+	c.lastTs, c.lastVal = ts, v
+	c.count++
+}
+
+// iterate 按时间顺序解码样本，fn 返回 false 时停止
+func (c *tsChunk) iterate(fn func(ts int64, v float64) bool) {
+	if c.count == 0 {
+		return
+	}
+	r := bitReader{buf: c.w.buf}
+	ts := int64(r.readBits(64))
+	val := r.readBits(64)
+	if !fn(ts, math.Float64frombits(val)) {
+		return
+	}
+
+	var delta int64
+	var leading, trailing int
+	for i := 1; i < c.count; i++ {
+		// 1. 时间戳
+		var dod int64
+		if r.readBit() {
+			n := 64
+			for _, b := range tsDodBuckets {
+				if !r.readBit() {
+					n = b.bits
+					break
+				}
+			}
+			raw := r.readBits(n)
+			// 符号扩展
+			dod = int64(raw<<(64-n)) >> (64 - n)
+		}
+		delta += dod
+		ts += delta
+
+		// 2. 数值
+		if r.readBit() {
+			if r.readBit() {
+				leading = int(r.readBits(5))
+				sig := int(r.readBits(6)) + 1
+				trailing = 64 - leading - sig
+			}
+			val ^= r.readBits(64-leading-trailing) << trailing
+		}
+		if !fn(ts, math.Float64frombits(val)) {
+			return
+		}
+	}
+}
+
+// memSize 压缩后的字节数
+func (c *tsChunk) memSize() int64 {
+	return int64(len(c.w.buf))
+}
@@ -0,0 +1,244 @@
+package core
+
+import (
+	"Flux-KV/pkg/kverrors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 默认每个 Watcher 的缓冲区大小
+const defaultWatchBuffer = 256
+
+var (
+	// ErrWatchDisabled 未开启 Key 变更通知（watch.history_size = 0）
+	ErrWatchDisabled = kverrors.New(kverrors.FailedPrecondition, "watch is disabled")
+	// ErrCompacted 请求的起始 revision 已被移出历史窗口
+	ErrCompacted = kverrors.New(kverrors.OutOfRange, "required revision has been compacted")
+	// ErrWatchLagged Watcher 消费过慢被服务端断开，调用方应从最后收到的 revision + 1 重新订阅
+	ErrWatchLagged = kverrors.New(kverrors.Unavailable, "watcher is too slow and has been closed")
+)
+
+// WatchEventType Key 变更事件类型
+type WatchEventType int
+
+const (
+	WatchPut    WatchEventType = iota // 写入/更新
+	WatchDelete                       // 主动删除
+	WatchExpire                       // 过期删除
+	WatchEvict                        // 被淘汰（预留给内存淘汰策略）
+)
+
+func (t WatchEventType) String() string {
+	switch t {
+	case WatchPut:
+		return "put"
+	case WatchDelete:
+		return "delete"
+	case WatchExpire:
+		return "expire"
+	case WatchEvict:
+		return "evict"
+	default:
+		return "unknown"
+	}
+}
+
+// WatchEvent 一次 Key 变更
+type WatchEvent struct {
+	Type     WatchEventType
+	Key      string
+	Value    any // 仅 put 事件有值
+	Revision uint64
+	Time     time.Time
+}
+
+// String 输出单行文本，例如: 12 put "user:1" "naato"
+func (e WatchEvent) String() string {
+	line := fmt.Sprintf("%d %s %s", e.Revision, e.Type, strconv.Quote(e.Key))
+	if e.Value != nil {
+		line += " " + strconv.Quote(fmt.Sprintf("%v", e.Value))
+	}
+	return line
+}
+
+// Watcher 一个 Key 或前缀的订阅
+// 调用方从 C 读取事件，C 被关闭后可通过 Err 判断原因；用完必须调用 MemDB.Unwatch
+type Watcher struct {
+	C <-chan WatchEvent
+
+	id     uint64
+	key    string
+	prefix bool
+	ch     chan WatchEvent
+	err    error // 仅在 C 关闭后读取
+}
+
+// Err 返回 C 被关闭的原因，正常注销时为 nil
+func (w *Watcher) Err() error {
+	return w.err
+}
+
+func (w *Watcher) match(key string) bool {
+	if w.prefix {
+		return strings.HasPrefix(key, w.key)
+	}
+	return key == w.key
+}
+
+// watchHub 负责分配 revision、保存有限的历史事件并分发给 Watcher
+type watchHub struct {
+	mu       sync.Mutex
+	revision uint64       // 最新已分配的 revision
+	history  []WatchEvent // 环形缓冲区
+	start    int          // 最旧事件的位置
+	size     int          // 历史事件数
+	watchers map[uint64]*Watcher
+	nextID   uint64
+}
+
+func newWatchHub(historySize int) *watchHub {
+	if historySize <= 0 {
+		return nil
+	}
+	return &watchHub{
+		history:  make([]WatchEvent, historySize),
+		watchers: make(map[uint64]*Watcher),
+	}
+}
+
+// publish 分配 revision、写入历史并分发给所有匹配的 Watcher
+// 必须在持有 Key 所在分片写锁时调用，保证同一 Key 的事件顺序与实际修改顺序一致
+func (h *watchHub) publish(t WatchEventType, key string, val any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.revision++
+	e := WatchEvent{
+		Type:     t,
+		Key:      key,
+		Value:    val,
+		Revision: h.revision,
+		Time:     time.Now(),
+	}
+
+	// 1. 写入历史，满了覆盖最旧的
+	idx := (h.start + h.size) % len(h.history)
+	h.history[idx] = e
+	if h.size < len(h.history) {
+		h.size++
+	} else {
+		h.start = (h.start + 1) % len(h.history)
+	}
+
+	// 2. 分发，跟不上的 Watcher 直接断开（不阻塞写路径）
+	for id, w := range h.watchers {
+		if !w.match(key) {
+			continue
+		}
+		select {
+		case w.ch <- e:
+		default:
+			w.err = ErrWatchLagged
+			delete(h.watchers, id)
+			close(w.ch)
+		}
+	}
+}
+
+// notify 在开启 Watch 时发布事件
+func (db *MemDB) notify(t WatchEventType, key string, val any) {
+	if db.watches != nil {
+		db.watches.publish(t, key, val)
+	}
+}
+
+// Revision 返回当前最新的 revision，未开启 Watch 时为 0
+func (db *MemDB) Revision() uint64 {
+	h := db.watches
+	if h == nil {
+		return 0
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.revision
+}
+
+// Watch 订阅某个 Key（prefix 为 true 时订阅前缀）的变更
+// fromRev > 0 时先回放历史中 revision >= fromRev 的事件，再推送新事件；
+// 若 fromRev 早于历史窗口则返回 ErrCompacted
+func (db *MemDB) Watch(key string, prefix bool, fromRev uint64) (*Watcher, error) {
+	h := db.watches
+	if h == nil {
+		return nil, ErrWatchDisabled
+	}
+
+	w := &Watcher{
+		key:    key,
+		prefix: prefix,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// 1. 收集需要回放的历史事件（与注册在同一把锁内，避免遗漏或重复）
+	var replay []WatchEvent
+	if fromRev > 0 && fromRev <= h.revision {
+		oldest := h.revision + 1
+		if h.size > 0 {
+			oldest = h.history[h.start].Revision
+		}
+		if fromRev < oldest {
+			return nil, ErrCompacted
+		}
+		for i := 0; i < h.size; i++ {
+			e := h.history[(h.start+i)%len(h.history)]
+			if e.Revision < fromRev {
+				continue
+			}
+			if w.match(e.Key) {
+				replay = append(replay, e)
+			}
+		}
+	}
+
+	// 2. 创建 Watcher，缓冲区要能装下全部回放事件
+	ch := make(chan WatchEvent, len(replay)+defaultWatchBuffer)
+	for _, e := range replay {
+		ch <- e
+	}
+	h.nextID++
+	w.id = h.nextID
+	w.C, w.ch = ch, ch
+	h.watchers[w.id] = w
+
+	return w, nil
+}
+
+// Unwatch 注销 Watcher
+func (db *MemDB) Unwatch(w *Watcher) {
+	h := db.watches
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.watchers[w.id]; ok {
+		delete(h.watchers, w.id)
+		close(w.ch)
+	}
+}
+
+// closeAll 关闭所有 Watcher（数据库关闭时调用）
+func (h *watchHub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for id, w := range h.watchers {
+		delete(h.watchers, id)
+		close(w.ch)
+	}
+}
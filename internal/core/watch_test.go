@@ -0,0 +1,127 @@
+package core
+
+import (
+	"Flux-KV/internal/config"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func newWatchDB(historySize int) *MemDB {
+	db, _ := NewMemDB(&config.Config{
+		Watch: config.WatchConfig{HistorySize: historySize},
+	})
+	return db
+}
+
+// recv 从 Watcher 读取一个事件，超时视为失败
+func recv(t *testing.T, w *Watcher) WatchEvent {
+	t.Helper()
+	select {
+	case e, ok := <-w.C:
+		if !ok {
+			t.Fatalf("watcher closed: %v", w.Err())
+		}
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for watch event")
+	}
+	return WatchEvent{}
+}
+
+// TestWatch_Events 验证 put / delete / expire 事件及前缀过滤
+func TestWatch_Events(t *testing.T) {
+	db := newWatchDB(100)
+
+	w, err := db.Watch("user:", true, 0)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer db.Unwatch(w)
+
+	db.Set("order:1", "ignored", 0)
+	db.Set("user:1", "naato", 0)
+	db.Del("user:1")
+	db.Del("user:404") // 不存在的 Key 不产生事件
+	db.Set("user:2", "tmp", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	db.Get("user:2") // 触发惰性删除
+
+	want := []struct {
+		typ WatchEventType
+		key string
+	}{
+		{WatchPut, "user:1"},
+		{WatchDelete, "user:1"},
+		{WatchPut, "user:2"},
+		{WatchExpire, "user:2"},
+	}
+	var lastRev uint64
+	for _, ww := range want {
+		e := recv(t, w)
+		if e.Type != ww.typ || e.Key != ww.key {
+			t.Fatalf("want %s %s, got %s %s", ww.typ, ww.key, e.Type, e.Key)
+		}
+		if e.Revision <= lastRev {
+			t.Fatalf("revision should increase: %d <= %d", e.Revision, lastRev)
+		}
+		lastRev = e.Revision
+	}
+}
+
+// TestWatch_Resume 验证从指定 revision 回放历史，以及历史窗口外返回 ErrCompacted
+func TestWatch_Resume(t *testing.T) {
+	db := newWatchDB(5)
+
+	for i := 1; i <= 8; i++ {
+		db.Set("k", fmt.Sprintf("v%d", i), 0)
+	}
+	// 历史只保留 revision 4~8
+
+	if _, err := db.Watch("k", false, 2); !errors.Is(err, ErrCompacted) {
+		t.Fatalf("want ErrCompacted, got %v", err)
+	}
+
+	w, err := db.Watch("k", false, 6)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer db.Unwatch(w)
+
+	for rev := uint64(6); rev <= 8; rev++ {
+		if e := recv(t, w); e.Revision != rev {
+			t.Fatalf("want revision %d, got %d", rev, e.Revision)
+		}
+	}
+
+	// 回放之后继续接收新事件
+	db.Set("k", "v9", 0)
+	if e := recv(t, w); e.Revision != 9 || e.Value != "v9" {
+		t.Fatalf("unexpected live event: %+v", e)
+	}
+}
+
+// TestWatch_Lagged 消费过慢的 Watcher 会被关闭且不阻塞写入
+func TestWatch_Lagged(t *testing.T) {
+	db := newWatchDB(10)
+
+	w, _ := db.Watch("k", false, 0)
+	for i := 0; i < defaultWatchBuffer+1; i++ {
+		db.Set("k", "v", 0)
+	}
+
+	for range w.C {
+	}
+	if !errors.Is(w.Err(), ErrWatchLagged) {
+		t.Fatalf("want ErrWatchLagged, got %v", w.Err())
+	}
+}
+
+// TestWatch_Disabled 未配置历史大小时 Watch 不可用
+func TestWatch_Disabled(t *testing.T) {
+	db := newWatchDB(0)
+	if _, err := db.Watch("k", false, 0); !errors.Is(err, ErrWatchDisabled) {
+		t.Fatalf("want ErrWatchDisabled, got %v", err)
+	}
+}
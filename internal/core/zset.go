@@ -0,0 +1,120 @@
+package core
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+)
+
+// 二进制编码的头部，便于下游区分类型
+const zsetMagic = "ZST1"
+
+// zsetEntry 有序集合中的一个成员
+type zsetEntry struct {
+	score  float64
+	member string
+}
+
+func (e zsetEntry) less(o zsetEntry) bool {
+	if e.score != o.score {
+		return e.score < o.score
+	}
+	return e.member < o.member
+}
+
+// SortedSet 有序集合：按 (score, member) 排序的切片 + member 索引
+// 插入和删除需要移动切片元素，按分数区间查询是二分查找，适合读多写少、规模在十万以内的场景
+type SortedSet struct {
+	entries []zsetEntry
+	scores  map[string]float64
+}
+
+func newSortedSet() *SortedSet {
+	return &SortedSet{scores: make(map[string]float64)}
+}
+
+// Len 成员个数
+func (z *SortedSet) Len() int {
+	return len(z.entries)
+}
+
+// MemSize 估算占用的内存
+func (z *SortedSet) MemSize() int64 {
+	var size int64
+	for _, e := range z.entries {
+		// 切片和 map 各存一份 member
+		size += int64(2*len(e.member)) + 16
+	}
+	return size
+}
+
+// Score 返回成员的分数
+func (z *SortedSet) Score(member string) (float64, bool) {
+	score, ok := z.scores[member]
+	return score, ok
+}
+
+// Add 添加或更新成员，返回是否为新成员
+func (z *SortedSet) Add(member string, score float64) bool {
+	old, exists := z.scores[member]
+	if exists {
+		if old == score {
+			return false
+		}
+		z.removeEntry(zsetEntry{score: old, member: member})
+	}
+
+	e := zsetEntry{score: score, member: member}
+	i := z.search(e)
+	z.entries = append(z.entries, zsetEntry{})
+	copy(z.entries[i+1:], z.entries[i:])
+	z.entries[i] = e
+	z.scores[member] = score
+	return !exists
+}
+
+// Remove 删除成员，返回是否存在
+func (z *SortedSet) Remove(member string) bool {
+	score, ok := z.scores[member]
+	if !ok {
+		return false
+	}
+	z.removeEntry(zsetEntry{score: score, member: member})
+	delete(z.scores, member)
+	return true
+}
+
+// RangeByScore 按分数升序遍历 [min, max) 区间内的成员，fn 返回 false 时停止
+func (z *SortedSet) RangeByScore(min, max float64, fn func(member string, score float64) bool) {
+	i := sort.Search(len(z.entries), func(i int) bool { return z.entries[i].score >= min })
+	for ; i < len(z.entries) && z.entries[i].score < max; i++ {
+		if !fn(z.entries[i].member, z.entries[i].score) {
+			return
+		}
+	}
+}
+
+// search 返回 e 应插入的位置
+func (z *SortedSet) search(e zsetEntry) int {
+	return sort.Search(len(z.entries), func(i int) bool { return !z.entries[i].less(e) })
+}
+
+func (z *SortedSet) removeEntry(e zsetEntry) {
+	i := z.search(e)
+	if i < len(z.entries) && z.entries[i] == e {
+		z.entries = append(z.entries[:i], z.entries[i+1:]...)
+	}
+}
+
+// MarshalBinary 编码为 "ZST1" + 成员数 + (score, member 长度, member) 列表
+func (z *SortedSet) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, len(zsetMagic)+4+int(z.MemSize()))
+	buf = append(buf, zsetMagic...)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(z.entries)))
+	for _, e := range z.entries {
+		buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(e.score))
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(e.member)))
+		buf = append(buf, e.member...)
+	}
+	return buf, nil
+}
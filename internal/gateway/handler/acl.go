@@ -0,0 +1,167 @@
+package handler
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/pkg/acl"
+	"Flux-KV/pkg/client"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ACLHandler 运行时修改 ACL 用户：同时更新网关自己的用户表，并广播到所有节点
+type ACLHandler struct {
+	cli *client.Client
+	acl *acl.ACL // 网关的用户表，未开启 ACL 时为 nil
+}
+
+func NewACLHandler(cli *client.Client, a *acl.ACL) *ACLHandler {
+	return &ACLHandler{
+		cli: cli,
+		acl: a,
+	}
+}
+
+// HandleList 返回网关与各节点的用户（不包含密码和令牌）
+// GET /api/v1/admin/acl/users
+func (h *ACLHandler) HandleList(c *gin.Context) {
+	gateway := make([]gin.H, 0)
+	if h.acl != nil {
+		for _, u := range h.acl.Users() {
+			gateway = append(gateway, gin.H{
+				"name":       u.Name,
+				"nopass":     u.NoPass,
+				"passwords":  u.Passwords,
+				"tokens":     u.Tokens,
+				"categories": u.Categories,
+				"keys":       u.Keys,
+			})
+		}
+	}
+
+	results, err := h.cli.AclListAll()
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "获取 ACL 用户失败: " + err.Error()})
+		return
+	}
+	nodes := gin.H{}
+	nodeErrors := gin.H{}
+	for _, r := range results {
+		if r.Err != nil {
+			nodeErrors[r.Addr] = r.Err.Error()
+			continue
+		}
+		users := make([]gin.H, 0, len(r.Resp.Users))
+		for _, u := range r.Resp.Users {
+			users = append(users, gin.H{
+				"name":       u.Name,
+				"nopass":     u.Nopass,
+				"passwords":  u.PasswordCount,
+				"tokens":     u.TokenCount,
+				"categories": u.Categories,
+				"keys":       u.Keys,
+			})
+		}
+		nodes[r.Addr] = users
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"gateway": gateway,
+		"nodes":   nodes,
+		"errors":  nodeErrors,
+	})
+}
+
+// HandleSetUser 创建或整体替换用户
+// PUT /api/v1/admin/acl/users
+// Body: {"name": "app", "passwords": ["..."], "tokens": ["..."], "categories": ["read", "write"], "keys": ["app:*"]}
+func (h *ACLHandler) HandleSetUser(c *gin.Context) {
+	var req struct {
+		Name       string   `json:"name" binding:"required"`
+		Passwords  []string `json:"passwords"`
+		Tokens     []string `json:"tokens"`
+		NoPass     bool     `json:"nopass"`
+		Categories []string `json:"categories"`
+		Keys       []string `json:"keys"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	// 1. 先更新网关，定义不合法时直接返回，不广播
+	if h.acl != nil {
+		err := h.acl.SetUser(acl.UserSpec{
+			Name:       req.Name,
+			Passwords:  req.Passwords,
+			Tokens:     req.Tokens,
+			NoPass:     req.NoPass,
+			Categories: req.Categories,
+			Keys:       req.Keys,
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	// 2. 广播到所有节点
+	results, err := h.cli.AclSetUserAll(&pb.AclUser{
+		Name:       req.Name,
+		Passwords:  req.Passwords,
+		Tokens:     req.Tokens,
+		Nopass:     req.NoPass,
+		Categories: req.Categories,
+		Keys:       req.Keys,
+	})
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "更新节点 ACL 失败: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "updated",
+		"nodes":   len(results),
+		"errors":  broadcastErrors(results),
+	})
+}
+
+// HandleDelUser 删除用户
+// DELETE /api/v1/admin/acl/users?name=app
+func (h *ACLHandler) HandleDelUser(c *gin.Context) {
+	name := c.Query("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name 不能为空"})
+		return
+	}
+
+	deleted := false
+	if h.acl != nil {
+		deleted = h.acl.DelUser(name)
+	}
+	results, err := h.cli.AclDelUserAll(name)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "更新节点 ACL 失败: " + err.Error()})
+		return
+	}
+	for _, r := range results {
+		if r.Err == nil && r.Resp.Deleted {
+			deleted = true
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"deleted": deleted,
+		"nodes":   len(results),
+		"errors":  broadcastErrors(results),
+	})
+}
+
+// broadcastErrors 收集广播中失败的节点
+func broadcastErrors[T any](results []client.NodeResult[T]) gin.H {
+	errs := gin.H{}
+	for _, r := range results {
+		if r.Err != nil {
+			errs[r.Addr] = r.Err.Error()
+		}
+	}
+	return errs
+}
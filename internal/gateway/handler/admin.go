@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"Flux-KV/pkg/client"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler 处理运维管理类请求（汇总所有节点）
+type AdminHandler struct {
+	cli *client.Client
+}
+
+func NewAdminHandler(cli *client.Client) *AdminHandler {
+	return &AdminHandler{
+		cli: cli,
+	}
+}
+
+// HandleInfo 汇总集群中所有节点的统计信息
+// GET /api/v1/admin/info?section=all
+func (h *AdminHandler) HandleInfo(c *gin.Context) {
+	results, err := h.cli.InfoAll(c.Query("section"))
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "获取节点信息失败: " + err.Error()})
+		return
+	}
+
+	var (
+		totalKeys, totalExpires, totalMemory int64
+		totalHits, totalMisses, totalExpired uint64
+		healthy                              int
+	)
+	commands := make(map[string]gin.H)
+	nodes := make([]gin.H, 0, len(results))
+
+	for _, r := range results {
+		if r.Err != nil {
+			nodes = append(nodes, gin.H{"addr": r.Addr, "error": r.Err.Error()})
+			continue
+		}
+		healthy++
+		info := r.Resp
+
+		// 1. 累加全局计数
+		totalKeys += info.Keys
+		totalExpires += info.Expires
+		totalMemory += info.MemoryBytes
+		totalHits += info.Hits
+		totalMisses += info.Misses
+		totalExpired += info.ExpiredKeys
+
+		// 2. 合并命令统计
+		for _, cmd := range info.Commands {
+			agg, ok := commands[cmd.Name]
+			if !ok {
+				agg = gin.H{"calls": uint64(0), "usec": uint64(0)}
+				commands[cmd.Name] = agg
+			}
+			agg["calls"] = agg["calls"].(uint64) + cmd.Calls
+			agg["usec"] = agg["usec"].(uint64) + cmd.TotalUsec
+		}
+
+		// 3. 计算节点内分片倾斜
+		var shardMin, shardMax int64
+		for i, sh := range info.Shards {
+			if i == 0 || sh.Keys < shardMin {
+				shardMin = sh.Keys
+			}
+			if sh.Keys > shardMax {
+				shardMax = sh.Keys
+			}
+		}
+
+		node := gin.H{
+			"addr":           r.Addr,
+			"uptime_seconds": info.UptimeSeconds,
+			"keys":           info.Keys,
+			"expires":        info.Expires,
+			"memory_bytes":   info.MemoryBytes,
+			"hits":           info.Hits,
+			"misses":         info.Misses,
+			"expired_keys":   info.ExpiredKeys,
+			"shard_keys_min": shardMin,
+			"shard_keys_max": shardMax,
+		}
+		if c.Query("section") != "" {
+			node["text"] = info.Text
+		}
+		nodes = append(nodes, node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i]["addr"].(string) < nodes[j]["addr"].(string)
+	})
+
+	hitRate := 0.0
+	if totalHits+totalMisses > 0 {
+		hitRate = float64(totalHits) / float64(totalHits+totalMisses)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"nodes_total":   len(results),
+		"nodes_healthy": healthy,
+		"total": gin.H{
+			"keys":         totalKeys,
+			"expires":      totalExpires,
+			"memory_bytes": totalMemory,
+			"hits":         totalHits,
+			"misses":       totalMisses,
+			"hit_rate":     hitRate,
+			"expired_keys": totalExpired,
+		},
+		"commands": commands,
+		"nodes":    nodes,
+	})
+}
+
+// HandleSlowLog 汇总所有节点的慢日志，按时间倒序返回
+// GET /api/v1/admin/slowlog?count=10
+func (h *AdminHandler) HandleSlowLog(c *gin.Context) {
+	count, err := strconv.Atoi(c.DefaultQuery("count", "10"))
+	if err != nil || count < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "count 参数必须是非负整数"})
+		return
+	}
+
+	results, err := h.cli.SlowLogAll(count)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "获取慢日志失败: " + err.Error()})
+		return
+	}
+
+	entries := make([]gin.H, 0)
+	nodeErrors := gin.H{}
+	var total int64
+	for _, r := range results {
+		if r.Err != nil {
+			nodeErrors[r.Addr] = r.Err.Error()
+			continue
+		}
+		total += r.Resp.Len
+		for _, e := range r.Resp.Entries {
+			entries = append(entries, gin.H{
+				"node":          r.Addr,
+				"id":            e.Id,
+				"timestamp_ms":  e.TimestampUnixMs,
+				"duration_usec": e.DurationUsec,
+				"command":       e.Command,
+				"key":           e.Key,
+				"client_addr":   e.ClientAddr,
+			})
+		}
+	}
+
+	// 合并后按时间倒序，只保留 count 条
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i]["timestamp_ms"].(int64) > entries[j]["timestamp_ms"].(int64)
+	})
+	if count > 0 && len(entries) > count {
+		entries = entries[:count]
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"len":     total,
+		"entries": entries,
+		"errors":  nodeErrors,
+	})
+}
+
+// HandleSlowLogReset 清空所有节点的慢日志
+// DELETE /api/v1/admin/slowlog
+func (h *AdminHandler) HandleSlowLogReset(c *gin.Context) {
+	results, err := h.cli.SlowLogResetAll()
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "清空慢日志失败: " + err.Error()})
+		return
+	}
+
+	nodeErrors := gin.H{}
+	for _, r := range results {
+		if r.Err != nil {
+			nodeErrors[r.Addr] = r.Err.Error()
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "reset",
+		"nodes":   len(results),
+		"errors":  nodeErrors,
+	})
+}
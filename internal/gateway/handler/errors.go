@@ -0,0 +1,13 @@
+package handler
+
+import (
+	"Flux-KV/pkg/kverrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// abortWithRPCError 按 kverrors 的错误码返回 HTTP 状态码，响应体带 code 字段；没有错误码的错误按 500 处理
+func abortWithRPCError(c *gin.Context, msg string, err error) {
+	e := kverrors.From(err, kverrors.Internal)
+	c.JSON(e.Code.HTTPStatus(), gin.H{"error": msg + ": " + e.Message, "code": e.Code})
+}
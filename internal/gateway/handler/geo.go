@@ -0,0 +1,153 @@
+package handler
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/pkg/client"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GeoHandler 处理地理位置请求
+type GeoHandler struct {
+	cli *client.Client
+}
+
+func NewGeoHandler(cli *client.Client) *GeoHandler {
+	return &GeoHandler{
+		cli: cli,
+	}
+}
+
+// HandleGeoAdd 添加或更新成员坐标
+// POST /api/v1/geo/add
+// Body: {"key": "couriers", "locations": [{"member": "c1", "longitude": 116.40, "latitude": 39.90}]}
+func (h *GeoHandler) HandleGeoAdd(c *gin.Context) {
+	var req struct {
+		Key       string `json:"key" binding:"required"`
+		Locations []struct {
+			Member    string  `json:"member" binding:"required"`
+			Longitude float64 `json:"longitude"`
+			Latitude  float64 `json:"latitude"`
+		} `json:"locations" binding:"required,min=1,dive"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	locations := make([]*pb.GeoLocation, len(req.Locations))
+	for i, l := range req.Locations {
+		locations[i] = &pb.GeoLocation{Member: l.Member, Longitude: l.Longitude, Latitude: l.Latitude}
+	}
+	added, err := h.cli.GeoAdd(req.Key, locations...)
+	if err != nil {
+		abortWithRPCError(c, "写入失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": req.Key, "added": added})
+}
+
+// HandleGeoPos 查询成员坐标，不存在的成员为 null
+// GET /api/v1/geo/pos?key=couriers&member=c1&member=c2
+func (h *GeoHandler) HandleGeoPos(c *gin.Context) {
+	key, members := c.Query("key"), c.QueryArray("member")
+	if key == "" || len(members) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 key 或 member 参数"})
+		return
+	}
+
+	positions, err := h.cli.GeoPos(key, members...)
+	if err != nil {
+		abortWithRPCError(c, "查询失败", err)
+		return
+	}
+	result := make(map[string]gin.H, len(members))
+	for i, m := range members {
+		result[m] = nil
+		if positions[i].Exists {
+			result[m] = gin.H{"longitude": positions[i].Longitude, "latitude": positions[i].Latitude}
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"key": key, "positions": result})
+}
+
+// HandleGeoDist 计算两个成员之间的距离
+// GET /api/v1/geo/dist?key=couriers&from=c1&to=c2&unit=km
+func (h *GeoHandler) HandleGeoDist(c *gin.Context) {
+	key, from, to := c.Query("key"), c.Query("from"), c.Query("to")
+	if key == "" || from == "" || to == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 key、from 或 to 参数"})
+		return
+	}
+	unit := c.DefaultQuery("unit", "m")
+
+	dist, ok, err := h.cli.GeoDist(key, from, to, unit)
+	if err != nil {
+		abortWithRPCError(c, "查询失败", err)
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "成员不存在"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": key, "distance": dist, "unit": unit})
+}
+
+// HandleGeoSearch 按圆形或矩形区域搜索成员
+// GET /api/v1/geo/search?key=couriers&longitude=116.40&latitude=39.90&radius=3&unit=km&sort=asc&count=10
+// 中心点用 member 或 longitude/latitude 指定；区域用 radius 或 width/height 指定
+func (h *GeoHandler) HandleGeoSearch(c *gin.Context) {
+	var req struct {
+		Key       string   `form:"key" binding:"required"`
+		Member    string   `form:"member"`
+		Longitude *float64 `form:"longitude"`
+		Latitude  *float64 `form:"latitude"`
+		Radius    float64  `form:"radius"`
+		Width     float64  `form:"width"`
+		Height    float64  `form:"height"`
+		Unit      string   `form:"unit"`
+		Sort      string   `form:"sort"`
+		Count     int64    `form:"count"`
+		Any       bool     `form:"any"`
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+	if req.Member == "" && (req.Longitude == nil || req.Latitude == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "需要 member 或 longitude/latitude 参数"})
+		return
+	}
+
+	search := &pb.GeoSearchRequest{
+		Key:        req.Key,
+		FromMember: req.Member,
+		Radius:     req.Radius,
+		Width:      req.Width,
+		Height:     req.Height,
+		Unit:       req.Unit,
+		Sort:       req.Sort,
+		Count:      req.Count,
+		Any:        req.Any,
+	}
+	if req.Member == "" {
+		search.Longitude, search.Latitude = *req.Longitude, *req.Latitude
+	}
+	results, err := h.cli.GeoSearch(search)
+	if err != nil {
+		abortWithRPCError(c, "查询失败", err)
+		return
+	}
+
+	items := make([]gin.H, len(results))
+	for i, r := range results {
+		items[i] = gin.H{
+			"member":    r.Member,
+			"distance":  r.Distance,
+			"longitude": r.Longitude,
+			"latitude":  r.Latitude,
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"key": req.Key, "results": items})
+}
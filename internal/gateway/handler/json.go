@@ -0,0 +1,221 @@
+package handler
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/pkg/client"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JSONHandler 处理 JSON 文档和二级索引查询请求
+type JSONHandler struct {
+	cli *client.Client
+}
+
+func NewJSONHandler(cli *client.Client) *JSONHandler {
+	return &JSONHandler{
+		cli: cli,
+	}
+}
+
+// HandleSet 写入文档或路径，path 省略时写入整个文档
+// POST /api/v1/json
+// Body: {"key": "user:42", "path": "$.profile.city", "value": "hz", "nx": false, "xx": false}
+func (h *JSONHandler) HandleSet(c *gin.Context) {
+	var req struct {
+		Key   string          `json:"key" binding:"required"`
+		Path  string          `json:"path"`
+		Value json.RawMessage `json:"value" binding:"required"`
+		NX    bool            `json:"nx"`
+		XX    bool            `json:"xx"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	ok, err := h.cli.JSONSet(req.Key, req.Path, string(req.Value), req.NX, req.XX)
+	if err != nil {
+		abortWithRPCError(c, "写入失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": req.Key, "updated": ok})
+}
+
+// HandleGet 读取一个或多个路径，多个路径时返回 {path: value} 对象
+// GET /api/v1/json?key=user:42&path=$.name&path=$.age
+func (h *JSONHandler) HandleGet(c *gin.Context) {
+	key := c.Query("key")
+	if key == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 key 参数"})
+		return
+	}
+
+	value, found, err := h.cli.JSONGet(key, c.QueryArray("path")...)
+	if err != nil {
+		abortWithRPCError(c, "查询失败", err)
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "key not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": key, "value": json.RawMessage(value)})
+}
+
+// HandleDel 删除路径上的值，path 省略时删除整个文档
+// DELETE /api/v1/json?key=user:42&path=$.tags[0]
+func (h *JSONHandler) HandleDel(c *gin.Context) {
+	key := c.Query("key")
+	if key == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 key 参数"})
+		return
+	}
+
+	n, err := h.cli.JSONDel(key, c.Query("path"))
+	if err != nil {
+		abortWithRPCError(c, "删除失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": key, "deleted": n})
+}
+
+// HandleArrAppend 向数组末尾追加元素
+// POST /api/v1/json/arrappend
+// Body: {"key": "user:42", "path": "$.tags", "values": ["vip", {"since": 2024}]}
+func (h *JSONHandler) HandleArrAppend(c *gin.Context) {
+	var req struct {
+		Key    string            `json:"key" binding:"required"`
+		Path   string            `json:"path" binding:"required"`
+		Values []json.RawMessage `json:"values" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	values := make([]string, len(req.Values))
+	for i, v := range req.Values {
+		values[i] = string(v)
+	}
+	n, err := h.cli.JSONArrAppend(req.Key, req.Path, values...)
+	if err != nil {
+		abortWithRPCError(c, "写入失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": req.Key, "length": n})
+}
+
+// HandleNumIncrBy 数值自增
+// POST /api/v1/json/numincrby
+// Body: {"key": "user:42", "path": "$.visits", "delta": 1}
+func (h *JSONHandler) HandleNumIncrBy(c *gin.Context) {
+	var req struct {
+		Key   string      `json:"key" binding:"required"`
+		Path  string      `json:"path" binding:"required"`
+		Delta json.Number `json:"delta" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	v, err := h.cli.JSONNumIncrBy(req.Key, req.Path, req.Delta.String())
+	if err != nil {
+		abortWithRPCError(c, "写入失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": req.Key, "value": json.RawMessage(v)})
+}
+
+// HandleCreateIndex 在命名空间的字段上建二级索引
+// POST /api/v1/json/indexes
+// Body: {"namespace": "user", "field": "$.age", "type": "numeric"}
+func (h *JSONHandler) HandleCreateIndex(c *gin.Context) {
+	var req struct {
+		Namespace string `json:"namespace" binding:"required"`
+		Field     string `json:"field" binding:"required"`
+		Type      string `json:"type" binding:"required,oneof=tag numeric"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	if err := h.cli.JSONCreateIndex(req.Namespace, req.Field, req.Type); err != nil {
+		abortWithRPCError(c, "创建失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "success"})
+}
+
+// HandleDropIndex 删除二级索引
+// DELETE /api/v1/json/indexes?namespace=user&field=$.age
+func (h *JSONHandler) HandleDropIndex(c *gin.Context) {
+	namespace, field := c.Query("namespace"), c.Query("field")
+	if namespace == "" || field == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 namespace 或 field 参数"})
+		return
+	}
+
+	ok, err := h.cli.JSONDropIndex(namespace, field)
+	if err != nil {
+		abortWithRPCError(c, "删除失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"dropped": ok})
+}
+
+// HandleListIndexes 列出二级索引
+// GET /api/v1/json/indexes?namespace=user
+func (h *JSONHandler) HandleListIndexes(c *gin.Context) {
+	indexes, err := h.cli.JSONListIndexes(c.Query("namespace"))
+	if err != nil {
+		abortWithRPCError(c, "查询失败", err)
+		return
+	}
+	result := make([]gin.H, len(indexes))
+	for i, idx := range indexes {
+		result[i] = gin.H{"namespace": idx.Namespace, "field": idx.Field, "type": idx.Type, "entries": idx.Entries}
+	}
+	c.JSON(http.StatusOK, gin.H{"indexes": result})
+}
+
+// HandleFind 按二级索引查询命名空间下的文档
+// GET /api/v1/find?namespace=user&field=$.age&op=>=&value=18&limit=20
+// GET /api/v1/find?namespace=user&field=$.age&op=between&value=18&max=30
+func (h *JSONHandler) HandleFind(c *gin.Context) {
+	req := &pb.FindRequest{
+		Namespace: c.Query("namespace"),
+		Field:     c.Query("field"),
+		Op:        c.DefaultQuery("op", "="),
+		Value:     c.Query("value"),
+		Max:       c.Query("max"),
+	}
+	if req.Namespace == "" || req.Field == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 namespace 或 field 参数"})
+		return
+	}
+	if s := c.Query("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit 必须是非负整数"})
+			return
+		}
+		req.Limit = int32(n)
+	}
+
+	matches, err := h.cli.Find(req)
+	if err != nil {
+		abortWithRPCError(c, "查询失败", err)
+		return
+	}
+	result := make([]gin.H, len(matches))
+	for i, m := range matches {
+		result[i] = gin.H{"key": m.Key, "doc": json.RawMessage(m.Doc)}
+	}
+	c.JSON(http.StatusOK, gin.H{"matches": result})
+}
@@ -39,8 +39,7 @@ func (h *KVHandler) HandleSet(c *gin.Context) {
 	// 2. 调用 gRPC 客户端
 	err := h.cli.Set(req.Key, req.Value)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "存储失败: " + err.Error()})
+		abortWithRPCError(c, "存储失败", err)
 		return
 	}
 
@@ -68,8 +67,9 @@ func (h *KVHandler) HandleGet(c *gin.Context) {
 		return h.cli.Get(key)
 	})
 
+	// Key 不存在返回 404，其余错误按错误码返回
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "查询失败或 Key 不存在：" + err.Error()})
+		abortWithRPCError(c, "查询失败", err)
 		return
 	}
 
@@ -94,7 +94,7 @@ func (h *KVHandler) HandleDel(c *gin.Context) {
 
 	err := h.cli.Del(key)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "删除失败: " + err.Error()})
+		abortWithRPCError(c, "删除失败", err)
 		return
 	}
 
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"Flux-KV/pkg/client"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PubSubHandler 处理发布/订阅请求
+type PubSubHandler struct {
+	cli *client.Client
+}
+
+func NewPubSubHandler(cli *client.Client) *PubSubHandler {
+	return &PubSubHandler{
+		cli: cli,
+	}
+}
+
+// HandlePublish 向频道发布消息
+// POST /api/v1/pubsub/publish
+// Body: {"channel": "news", "message": "hello"}
+func (h *PubSubHandler) HandlePublish(c *gin.Context) {
+	var req struct {
+		Channel string `json:"channel" binding:"required"`
+		Message string `json:"message"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	receivers, err := h.cli.Publish(req.Channel, req.Message)
+	if err != nil && receivers == 0 {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "发布失败: " + err.Error()})
+		return
+	}
+
+	resp := gin.H{
+		"channel":   req.Channel,
+		"receivers": receivers,
+	}
+	if err != nil {
+		// 部分节点失败，消息可能没有送达连接在这些节点上的订阅者
+		resp["warning"] = err.Error()
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// HandleSubscribe 以 Server-Sent Events 推送订阅到的消息，客户端断开即取消订阅
+// GET /api/v1/pubsub/subscribe?channel=news&channel=alerts&pattern=user.*
+func (h *PubSubHandler) HandleSubscribe(c *gin.Context) {
+	channels := c.QueryArray("channel")
+	patterns := c.QueryArray("pattern")
+	if len(channels) == 0 && len(patterns) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "至少需要一个 channel 或 pattern 参数"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	msgs, err := h.cli.Subscribe(ctx, channels, patterns)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "订阅失败: " + err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Stream(func(w io.Writer) bool {
+		msg, ok := <-msgs
+		if !ok {
+			return false
+		}
+		c.SSEvent(msg.Kind, gin.H{
+			"channel": msg.Channel,
+			"pattern": msg.Pattern,
+			"payload": msg.Payload,
+		})
+		return true
+	})
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"Flux-KV/pkg/client"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScriptHandler 处理脚本请求
+type ScriptHandler struct {
+	cli *client.Client
+}
+
+func NewScriptHandler(cli *client.Client) *ScriptHandler {
+	return &ScriptHandler{
+		cli: cli,
+	}
+}
+
+// HandleEval 执行脚本
+// POST /api/v1/eval
+// Body: {"script": "return kv.call('INCRBY', KEYS[1], ARGV[1])", "keys": ["counter"], "args": ["1"]}
+func (h *ScriptHandler) HandleEval(c *gin.Context) {
+	var req struct {
+		Script string   `json:"script" binding:"required"`
+		Keys   []string `json:"keys"`
+		Args   []string `json:"args"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	result, err := h.cli.Eval(req.Script, req.Keys, req.Args)
+	if err != nil {
+		abortWithRPCError(c, "执行失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"result": client.ScriptValue(result)})
+}
+
+// HandleEvalSha 执行已缓存的脚本，未缓存时返回 404
+// POST /api/v1/evalsha
+// Body: {"sha": "...", "keys": ["counter"], "args": ["1"]}
+func (h *ScriptHandler) HandleEvalSha(c *gin.Context) {
+	var req struct {
+		Sha  string   `json:"sha" binding:"required"`
+		Keys []string `json:"keys"`
+		Args []string `json:"args"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	result, err := h.cli.EvalSha(req.Sha, req.Keys, req.Args)
+	if err != nil {
+		abortWithRPCError(c, "执行失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"result": client.ScriptValue(result)})
+}
+
+// HandleScriptLoad 把脚本缓存到所有节点
+// POST /api/v1/script/load
+// Body: {"script": "..."}
+func (h *ScriptHandler) HandleScriptLoad(c *gin.Context) {
+	var req struct {
+		Script string `json:"script" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	sha, err := h.cli.ScriptLoad(req.Script)
+	if err != nil {
+		abortWithRPCError(c, "加载失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sha": sha})
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"Flux-KV/pkg/client"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SketchHandler 处理 HyperLogLog / 布隆过滤器 / Count-Min Sketch 请求
+type SketchHandler struct {
+	cli *client.Client
+}
+
+func NewSketchHandler(cli *client.Client) *SketchHandler {
+	return &SketchHandler{
+		cli: cli,
+	}
+}
+
+// HandlePFAdd 向 HyperLogLog 添加元素
+// POST /api/v1/pf/add
+// Body: {"key": "uv", "elements": ["alice", "bob"]}
+func (h *SketchHandler) HandlePFAdd(c *gin.Context) {
+	var req struct {
+		Key      string   `json:"key" binding:"required"`
+		Elements []string `json:"elements"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	changed, err := h.cli.PFAdd(req.Key, req.Elements...)
+	if err != nil {
+		abortWithRPCError(c, "写入失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": req.Key, "changed": changed})
+}
+
+// HandlePFCount 估算基数，多个 key 时返回并集的基数
+// GET /api/v1/pf/count?key=uv&key=uv2
+func (h *SketchHandler) HandlePFCount(c *gin.Context) {
+	keys := c.QueryArray("key")
+	if len(keys) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 key 参数"})
+		return
+	}
+
+	count, err := h.cli.PFCount(keys...)
+	if err != nil {
+		abortWithRPCError(c, "查询失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"keys": keys, "count": count})
+}
+
+// HandlePFMerge 合并多个 HyperLogLog
+// POST /api/v1/pf/merge
+// Body: {"dest": "uv:week", "sources": ["uv:mon", "uv:tue"]}
+func (h *SketchHandler) HandlePFMerge(c *gin.Context) {
+	var req struct {
+		Dest    string   `json:"dest" binding:"required"`
+		Sources []string `json:"sources" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	if err := h.cli.PFMerge(req.Dest, req.Sources...); err != nil {
+		abortWithRPCError(c, "合并失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "success", "dest": req.Dest})
+}
+
+// HandleBFReserve 创建布隆过滤器
+// POST /api/v1/bf/reserve
+// Body: {"key": "seen", "error_rate": 0.001, "capacity": 100000}
+func (h *SketchHandler) HandleBFReserve(c *gin.Context) {
+	var req struct {
+		Key       string  `json:"key" binding:"required"`
+		ErrorRate float64 `json:"error_rate" binding:"required"`
+		Capacity  uint64  `json:"capacity" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	if err := h.cli.BFReserve(req.Key, req.ErrorRate, req.Capacity); err != nil {
+		abortWithRPCError(c, "创建失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "success", "key": req.Key})
+}
+
+// HandleBFAdd 向布隆过滤器添加元素，key 不存在时按默认参数创建
+// POST /api/v1/bf/add
+// Body: {"key": "seen", "items": ["a", "b"]}
+func (h *SketchHandler) HandleBFAdd(c *gin.Context) {
+	var req struct {
+		Key   string   `json:"key" binding:"required"`
+		Items []string `json:"items" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	added, err := h.cli.BFAdd(req.Key, req.Items...)
+	if err != nil {
+		abortWithRPCError(c, "写入失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": req.Key, "added": zipItems(req.Items, added)})
+}
+
+// HandleBFExists 判断元素是否可能存在
+// GET /api/v1/bf/exists?key=seen&item=a&item=b
+func (h *SketchHandler) HandleBFExists(c *gin.Context) {
+	key, items := c.Query("key"), c.QueryArray("item")
+	if key == "" || len(items) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 key 或 item 参数"})
+		return
+	}
+
+	exists, err := h.cli.BFExists(key, items...)
+	if err != nil {
+		abortWithRPCError(c, "查询失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": key, "exists": zipItems(items, exists)})
+}
+
+// HandleCMSInit 创建 Count-Min Sketch，width/depth 与 error_rate/probability 二选一
+// POST /api/v1/cms/init
+// Body: {"key": "clicks", "width": 2000, "depth": 5} 或 {"key": "clicks", "error_rate": 0.001, "probability": 0.01}
+func (h *SketchHandler) HandleCMSInit(c *gin.Context) {
+	var req struct {
+		Key         string  `json:"key" binding:"required"`
+		Width       uint32  `json:"width"`
+		Depth       uint32  `json:"depth"`
+		ErrorRate   float64 `json:"error_rate"`
+		Probability float64 `json:"probability"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	width, depth := req.Width, req.Depth
+	var err error
+	if width == 0 && depth == 0 {
+		width, depth, err = h.cli.CMSInitByProb(req.Key, req.ErrorRate, req.Probability)
+	} else {
+		err = h.cli.CMSInitByDim(req.Key, width, depth)
+	}
+	if err != nil {
+		abortWithRPCError(c, "创建失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": req.Key, "width": width, "depth": depth})
+}
+
+// HandleCMSIncrBy 增加计数
+// POST /api/v1/cms/incrby
+// Body: {"key": "clicks", "increments": {"home": 3, "about": 1}}
+func (h *SketchHandler) HandleCMSIncrBy(c *gin.Context) {
+	var req struct {
+		Key        string            `json:"key" binding:"required"`
+		Increments map[string]uint32 `json:"increments" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	counts, err := h.cli.CMSIncrBy(req.Key, req.Increments)
+	if err != nil {
+		abortWithRPCError(c, "写入失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": req.Key, "counts": counts})
+}
+
+// HandleCMSQuery 查询估算计数
+// GET /api/v1/cms/query?key=clicks&item=home&item=about
+func (h *SketchHandler) HandleCMSQuery(c *gin.Context) {
+	key, items := c.Query("key"), c.QueryArray("item")
+	if key == "" || len(items) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 key 或 item 参数"})
+		return
+	}
+
+	counts, err := h.cli.CMSQuery(key, items...)
+	if err != nil {
+		abortWithRPCError(c, "查询失败", err)
+		return
+	}
+	result := make(map[string]uint32, len(items))
+	for i, item := range items {
+		result[item] = counts[i]
+	}
+	c.JSON(http.StatusOK, gin.H{"key": key, "counts": result})
+}
+
+func zipItems(items []string, values []bool) map[string]bool {
+	result := make(map[string]bool, len(items))
+	for i, item := range items {
+		result[item] = values[i]
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"Flux-KV/pkg/client"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ThrottleHandler 处理限流判定请求
+type ThrottleHandler struct {
+	cli *client.Client
+}
+
+func NewThrottleHandler(cli *client.Client) *ThrottleHandler {
+	return &ThrottleHandler{
+		cli: cli,
+	}
+}
+
+// HandleThrottle 判定一次请求是否放行，被拒绝时同样返回 200，由调用方根据 allowed 决定如何处理
+// POST /api/v1/throttle
+// Body: {"key": "api:user:1", "rate": 10, "burst": 20, "cost": 1}，cost 省略时为 1
+func (h *ThrottleHandler) HandleThrottle(c *gin.Context) {
+	var req struct {
+		Key   string  `json:"key" binding:"required"`
+		Rate  float64 `json:"rate" binding:"required,gt=0"`
+		Burst int64   `json:"burst" binding:"required,gt=0"`
+		Cost  *int64  `json:"cost" binding:"omitempty,gte=0"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+	cost := int64(1)
+	if req.Cost != nil {
+		cost = *req.Cost
+	}
+
+	resp, err := h.cli.Throttle(req.Key, req.Rate, req.Burst, cost)
+	if err != nil {
+		abortWithRPCError(c, "限流判定失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"key":            req.Key,
+		"allowed":        resp.Allowed,
+		"limit":          resp.Limit,
+		"remaining":      resp.Remaining,
+		"retry_after_ms": resp.RetryAfterMs,
+		"reset_after_ms": resp.ResetAfterMs,
+	})
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/pkg/client"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimeSeriesHandler 处理时间序列请求
+type TimeSeriesHandler struct {
+	cli *client.Client
+}
+
+func NewTimeSeriesHandler(cli *client.Client) *TimeSeriesHandler {
+	return &TimeSeriesHandler{
+		cli: cli,
+	}
+}
+
+// tsRangeQuery TS.RANGE / TS.MRANGE 共用的查询参数
+type tsRangeQuery struct {
+	From        int64  `form:"from"`
+	To          int64  `form:"to"` // 0 表示不限
+	Aggregation string `form:"aggregation"`
+	BucketMs    int64  `form:"bucket_ms"`
+	Count       int64  `form:"count"`
+}
+
+func (q *tsRangeQuery) aggregation() *pb.TSAggregation {
+	if q.Aggregation == "" {
+		return nil
+	}
+	return &pb.TSAggregation{Type: q.Aggregation, BucketMs: q.BucketMs}
+}
+
+// HandleCreate 创建时间序列
+// POST /api/v1/ts/create
+// Body: {"key": "cpu:web1", "retention_ms": 86400000, "labels": {"metric": "cpu", "host": "web1"}}
+func (h *TimeSeriesHandler) HandleCreate(c *gin.Context) {
+	var req struct {
+		Key         string            `json:"key" binding:"required"`
+		RetentionMs int64             `json:"retention_ms"`
+		Labels      map[string]string `json:"labels"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	if err := h.cli.TSCreate(req.Key, time.Duration(req.RetentionMs)*time.Millisecond, req.Labels); err != nil {
+		abortWithRPCError(c, "创建失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "success", "key": req.Key})
+}
+
+// HandleAdd 追加样本，timestamp 省略时使用服务端时间
+// POST /api/v1/ts/add
+// Body: {"key": "cpu:web1", "timestamp": 1700000000000, "value": 0.42}
+func (h *TimeSeriesHandler) HandleAdd(c *gin.Context) {
+	var req struct {
+		Key       string   `json:"key" binding:"required"`
+		Timestamp int64    `json:"timestamp"`
+		Value     *float64 `json:"value" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	ts, err := h.cli.TSAdd(req.Key, req.Timestamp, *req.Value)
+	if err != nil {
+		abortWithRPCError(c, "写入失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": req.Key, "timestamp": ts})
+}
+
+// HandleGet 返回最后一个样本
+// GET /api/v1/ts/get?key=cpu:web1
+func (h *TimeSeriesHandler) HandleGet(c *gin.Context) {
+	key := c.Query("key")
+	if key == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 key 参数"})
+		return
+	}
+
+	sample, err := h.cli.TSGet(key)
+	if err != nil {
+		abortWithRPCError(c, "查询失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": key, "sample": toSampleJSON(sample)})
+}
+
+// HandleRange 查询单个序列
+// GET /api/v1/ts/range?key=cpu:web1&from=0&to=0&aggregation=avg&bucket_ms=60000&count=100
+func (h *TimeSeriesHandler) HandleRange(c *gin.Context) {
+	key := c.Query("key")
+	var q tsRangeQuery
+	if err := c.ShouldBindQuery(&q); err != nil || key == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 key 参数或参数格式错误"})
+		return
+	}
+
+	samples, err := h.cli.TSRange(&pb.TSRangeRequest{
+		Key: key, From: q.From, To: q.To, Aggregation: q.aggregation(), Count: q.Count,
+	})
+	if err != nil {
+		abortWithRPCError(c, "查询失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"key": key, "samples": toSamplesJSON(samples)})
+}
+
+// HandleMRange 按标签过滤查询多个序列
+// GET /api/v1/ts/mrange?filter=metric=cpu&filter=host!=web2&aggregation=max&bucket_ms=60000
+func (h *TimeSeriesHandler) HandleMRange(c *gin.Context) {
+	filters := c.QueryArray("filter")
+	var q tsRangeQuery
+	if err := c.ShouldBindQuery(&q); err != nil || len(filters) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 filter 参数或参数格式错误"})
+		return
+	}
+
+	series, err := h.cli.TSMRange(&pb.TSMRangeRequest{
+		From: q.From, To: q.To, Filters: filters, Aggregation: q.aggregation(), Count: q.Count,
+	})
+	if err != nil {
+		abortWithRPCError(c, "查询失败", err)
+		return
+	}
+	result := make([]gin.H, len(series))
+	for i, s := range series {
+		result[i] = gin.H{"key": s.Key, "labels": s.Labels, "samples": toSamplesJSON(s.Samples)}
+	}
+	c.JSON(http.StatusOK, gin.H{"series": result})
+}
+
+// HandleCreateRule 创建降采样规则
+// POST /api/v1/ts/rules
+// Body: {"source": "cpu:web1", "dest": "cpu:web1:1m", "aggregation": "avg", "bucket_ms": 60000}
+func (h *TimeSeriesHandler) HandleCreateRule(c *gin.Context) {
+	var req struct {
+		Source      string `json:"source" binding:"required"`
+		Dest        string `json:"dest" binding:"required"`
+		Aggregation string `json:"aggregation" binding:"required"`
+		BucketMs    int64  `json:"bucket_ms" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数错误: " + err.Error()})
+		return
+	}
+
+	err := h.cli.TSCreateRule(req.Source, req.Dest, req.Aggregation, time.Duration(req.BucketMs)*time.Millisecond)
+	if err != nil {
+		abortWithRPCError(c, "创建失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "success", "source": req.Source, "dest": req.Dest})
+}
+
+// HandleDeleteRule 删除降采样规则
+// DELETE /api/v1/ts/rules?source=cpu:web1&dest=cpu:web1:1m
+func (h *TimeSeriesHandler) HandleDeleteRule(c *gin.Context) {
+	source, dest := c.Query("source"), c.Query("dest")
+	if source == "" || dest == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 source 或 dest 参数"})
+		return
+	}
+
+	if err := h.cli.TSDeleteRule(source, dest); err != nil {
+		abortWithRPCError(c, "删除失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "success"})
+}
+
+// HandleInfo 返回序列的元信息
+// GET /api/v1/ts/info?key=cpu:web1
+func (h *TimeSeriesHandler) HandleInfo(c *gin.Context) {
+	key := c.Query("key")
+	if key == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 key 参数"})
+		return
+	}
+
+	info, err := h.cli.TSInfo(key)
+	if err != nil {
+		abortWithRPCError(c, "查询失败", err)
+		return
+	}
+	rules := make([]gin.H, len(info.Rules))
+	for i, r := range info.Rules {
+		rules[i] = gin.H{"dest": r.Dest, "aggregation": r.Aggregation.Type, "bucket_ms": r.Aggregation.BucketMs}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"key":             key,
+		"total_samples":   info.TotalSamples,
+		"memory_usage":    info.MemoryUsage,
+		"chunk_count":     info.ChunkCount,
+		"first_timestamp": info.FirstTimestamp,
+		"last_timestamp":  info.LastTimestamp,
+		"retention_ms":    info.RetentionMs,
+		"labels":          info.Labels,
+		"rules":           rules,
+	})
+}
+
+func toSampleJSON(s *pb.TSSample) gin.H {
+	if s == nil {
+		return nil
+	}
+	return gin.H{"timestamp": s.Timestamp, "value": s.Value}
+}
+
+func toSamplesJSON(samples []*pb.TSSample) []gin.H {
+	out := make([]gin.H, len(samples))
+	for i, s := range samples {
+		out[i] = toSampleJSON(s)
+	}
+	return out
+}
@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"Flux-KV/pkg/acl"
+	"Flux-KV/pkg/kverrors"
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/gin-gonic/gin"
+)
+
+// routeCategories 每个路由所属的命令类别，键为 "方法 路由模板"；未登记的路由按 admin 处理
+var routeCategories = map[string]acl.Category{
+	"GET /api/v1/kv":    acl.Read,
+	"POST /api/v1/kv":   acl.Write,
+	"DELETE /api/v1/kv": acl.Write,
+
+	"GET /api/v1/pf/count":    acl.Read,
+	"POST /api/v1/pf/add":     acl.Write,
+	"POST /api/v1/pf/merge":   acl.Write,
+	"GET /api/v1/bf/exists":   acl.Read,
+	"POST /api/v1/bf/reserve": acl.Write,
+	"POST /api/v1/bf/add":     acl.Write,
+	"GET /api/v1/cms/query":   acl.Read,
+	"POST /api/v1/cms/init":   acl.Write,
+	"POST /api/v1/cms/incrby": acl.Write,
+
+	"GET /api/v1/geo/pos":    acl.Read,
+	"GET /api/v1/geo/dist":   acl.Read,
+	"GET /api/v1/geo/search": acl.Read,
+	"POST /api/v1/geo/add":   acl.Write,
+
+	"GET /api/v1/ts/get":      acl.Read,
+	"GET /api/v1/ts/range":    acl.Read,
+	"GET /api/v1/ts/mrange":   acl.Read,
+	"GET /api/v1/ts/info":     acl.Read,
+	"POST /api/v1/ts/create":  acl.Write,
+	"POST /api/v1/ts/add":     acl.Write,
+	"POST /api/v1/ts/rules":   acl.Write,
+	"DELETE /api/v1/ts/rules": acl.Write,
+
+	"GET /api/v1/json":            acl.Read,
+	"GET /api/v1/json/indexes":    acl.Read,
+	"GET /api/v1/find":            acl.Read,
+	"POST /api/v1/json":           acl.Write,
+	"DELETE /api/v1/json":         acl.Write,
+	"POST /api/v1/json/arrappend": acl.Write,
+	"POST /api/v1/json/numincrby": acl.Write,
+	"POST /api/v1/json/indexes":   acl.Write,
+	"DELETE /api/v1/json/indexes": acl.Write,
+
+	"POST /api/v1/throttle":    acl.Write,
+	"POST /api/v1/eval":        acl.Write,
+	"POST /api/v1/evalsha":     acl.Write,
+	"POST /api/v1/script/load": acl.Write,
+
+	"POST /api/v1/pubsub/publish":  acl.Write,
+	"GET /api/v1/pubsub/subscribe": acl.Read,
+}
+
+// routePrefixes 按前缀访问 Key 的路由，由请求中的命名空间得到要检查的前缀，与 TCP / gRPC 的规则一致
+// FIND 和 JSON 索引访问命名空间下的所有 Key（ns:...），TS.MRANGE 按标签匹配任意序列
+var routePrefixes = map[string]func(ns string) []string{
+	"GET /api/v1/find":            namespacePrefix,
+	"GET /api/v1/json/indexes":    listIndexesPrefix,
+	"POST /api/v1/json/indexes":   namespacePrefix,
+	"DELETE /api/v1/json/indexes": namespacePrefix,
+	"GET /api/v1/ts/mrange":       allKeys,
+}
+
+func namespacePrefix(ns string) []string {
+	return []string{ns + ":"}
+}
+
+// listIndexesPrefix 不带命名空间时列出全部索引
+func listIndexesPrefix(ns string) []string {
+	if ns == "" {
+		return []string{""}
+	}
+	return namespacePrefix(ns)
+}
+
+// allKeys 可能访问任意 Key，只有 allkeys 的用户可以访问
+func allKeys(string) []string {
+	return []string{""}
+}
+
+// keyBody 请求体中表示 Key 的字段
+type keyBody struct {
+	Key       string   `json:"key"`
+	Keys      []string `json:"keys"`
+	Source    string   `json:"source"`
+	Sources   []string `json:"sources"`
+	Dest      string   `json:"dest"`
+	Namespace string   `json:"namespace"`
+}
+
+// Auth ACL 中间件：按 Authorization 头（Basic / Bearer）认证，再按路由的类别和请求中的 Key 授权
+// a 为 nil 表示未开启 ACL，直接放行
+func Auth(a *acl.ACL) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if a == nil {
+			c.Next()
+			return
+		}
+
+		// 1. 认证：没有 Authorization 头时按 default 用户处理
+		name, err := a.AuthenticateHeader(c.GetHeader("Authorization"))
+		if err != nil {
+			abortACL(c, err)
+			return
+		}
+
+		// 2. 授权
+		// 管理接口的请求体（例如 ACL 用户的 keys）不是要访问的 Key，只检查类别
+		route := c.Request.Method + " " + c.FullPath()
+		cat, ok := routeCategories[route]
+		var keys, prefixes []string
+		if ok {
+			var ns string
+			keys, ns = requestKeys(c)
+			if prefix := routePrefixes[route]; prefix != nil {
+				prefixes = prefix(ns)
+			}
+		} else {
+			cat = acl.Admin
+		}
+		if err := a.Check(name, cat, keys...); err != nil {
+			abortACL(c, err)
+			return
+		}
+		if err := a.CheckPrefix(name, cat, prefixes...); err != nil {
+			abortACL(c, err)
+			return
+		}
+
+		c.Set("acl_user", name)
+		c.Next()
+	}
+}
+
+// requestKeys 收集查询参数和 JSON 请求体中的 Key 和命名空间，读取后恢复请求体供 Handler 绑定
+func requestKeys(c *gin.Context) (keys []string, ns string) {
+	keys = append(c.QueryArray("key"), c.QueryArray("source")...)
+	keys = append(keys, c.QueryArray("dest")...)
+	ns = c.Query("namespace")
+
+	// Handler 用 ShouldBindJSON 绑定，不看 Content-Type，这里也不看
+	if c.Request.Body == nil {
+		return keys, ns
+	}
+	raw, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(raw))
+	if err != nil || len(raw) == 0 {
+		return keys, ns
+	}
+	var body keyBody
+	if json.Unmarshal(raw, &body) != nil {
+		// 请求体格式错误时交给 Handler 返回 400
+		return keys, ns
+	}
+	for _, k := range append([]string{body.Key, body.Source, body.Dest}, append(body.Keys, body.Sources...)...) {
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	if body.Namespace != "" {
+		ns = body.Namespace
+	}
+	return keys, ns
+}
+
+// abortACL 未认证返回 401，没有权限返回 403
+func abortACL(c *gin.Context, err error) {
+	code := kverrors.From(err, kverrors.PermissionDenied).Code
+	if code == kverrors.Unauthorized {
+		c.Header("WWW-Authenticate", `Basic realm="flux-kv"`)
+	}
+	c.AbortWithStatusJSON(code.HTTPStatus(), gin.H{"error": err.Error(), "code": code})
+}
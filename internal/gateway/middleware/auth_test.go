@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"Flux-KV/pkg/acl"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestAuth_PrefixRoutes 按命名空间或任意 Key 访问的路由按前缀检查，受限用户不能越过自己的 Key 模式
+func TestAuth_PrefixRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	a, err := acl.New(
+		acl.UserSpec{Name: "app", Passwords: []string{"pw"}, Categories: []string{"read", "write"}, Keys: []string{"user:*"}},
+		acl.UserSpec{Name: "ops", Passwords: []string{"pw"}, Categories: []string{"read", "write"}, Keys: []string{"*"}},
+	)
+	if err != nil {
+		t.Fatalf("acl.New failed: %v", err)
+	}
+
+	r := gin.New()
+	r.Use(Auth(a))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/api/v1/kv", ok)
+	r.GET("/api/v1/find", ok)
+	r.GET("/api/v1/json/indexes", ok)
+	r.POST("/api/v1/json/indexes", ok)
+	r.DELETE("/api/v1/json/indexes", ok)
+	r.GET("/api/v1/ts/mrange", ok)
+
+	tests := []struct {
+		user, method, target, body string
+		code                       int
+	}{
+		{"app", "GET", "/api/v1/kv?key=user:1", "", http.StatusOK},
+		{"app", "GET", "/api/v1/find?namespace=user&field=$.age", "", http.StatusOK},
+		{"app", "GET", "/api/v1/find?namespace=other&field=$.age", "", http.StatusForbidden},
+		{"app", "GET", "/api/v1/json/indexes?namespace=user", "", http.StatusOK},
+		{"app", "GET", "/api/v1/json/indexes", "", http.StatusForbidden},
+		{"app", "POST", "/api/v1/json/indexes", `{"namespace":"other","field":"$.age","type":"numeric"}`, http.StatusForbidden},
+		{"app", "DELETE", "/api/v1/json/indexes?namespace=other&field=$.age", "", http.StatusForbidden},
+		{"app", "GET", "/api/v1/ts/mrange?filter=a=1", "", http.StatusForbidden},
+		{"ops", "GET", "/api/v1/find?namespace=other&field=$.age", "", http.StatusOK},
+		{"ops", "GET", "/api/v1/ts/mrange?filter=a=1", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+		req.SetBasicAuth(tt.user, "pw")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tt.code {
+			t.Errorf("%s %s %s: expected %d, got %d (%s)", tt.user, tt.method, tt.target, tt.code, w.Code, w.Body.String())
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"Flux-KV/pkg/kverrors"
 	"fmt"
 	"net/http"
 
@@ -36,6 +37,7 @@ func CircuitBreaker(commandName string) gin.HandlerFunc {
 			if !c.Writer.Written() {
 				c.JSON(http.StatusServiceUnavailable, gin.H{
 					"error":  "Service Unavailable (Circuit Breaker Triggered)",
+					"code":   kverrors.Unavailable,
 					"detail": err.Error(),
 				})
 				c.Abort()
@@ -1,32 +1,69 @@
 package middleware
 
 import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/pkg/kverrors"
+	"Flux-KV/pkg/logger"
+	"context"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 )
 
+// Throttler 集群限流的判定接口，由 client.Client 实现
+type Throttler interface {
+	ThrottleContext(ctx context.Context, key string, rate float64, burst, cost int64) (*pb.ThrottleResponse, error)
+}
+
+// clusterTimeout 集群限流判定的超时，每个请求都要等待它，超时后退回本地限流
+const clusterTimeout = 50 * time.Millisecond
+
 // GlobalRateLimiter 定义全局限流中间件
 // qps: 每秒产生的令牌数
 // burst: 令牌桶最大容量
-func GlobalRateLimiter(qps float64, burst int) gin.HandlerFunc {
+// cluster: 不为 nil 时使用存放在 Flux-KV 中的集群级限流（所有网关实例共享 key 对应的令牌桶），
+// 调用失败或超过 clusterTimeout 时退回本地限流，避免 KV 集群不可用或变慢时网关完全不限流、全部拒绝或拖慢所有请求
+func GlobalRateLimiter(qps float64, burst int, cluster Throttler, key string) gin.HandlerFunc {
 	// 创建全局限流器
 	limiter := rate.NewLimiter(rate.Limit(qps), burst)
 
 	return func(c *gin.Context) {
+		if cluster != nil {
+			ctx, cancel := context.WithTimeout(c.Request.Context(), clusterTimeout)
+			resp, err := cluster.ThrottleContext(ctx, key, qps, int64(burst), 1)
+			cancel()
+			if err == nil {
+				if !resp.Allowed {
+					c.Header("Retry-After", strconv.FormatInt((resp.RetryAfterMs+999)/1000, 10))
+					abortLimited(c)
+					return
+				}
+				c.Next()
+				return
+			}
+			logger.Log.Warn("⚠️ Cluster rate limit unavailable, fallback to local limiter", zap.Error(err))
+		}
+
 		// 非阻塞尝试获取一个令牌
 		if !limiter.Allow() {
-			// 终止请求链，返回 429 JSON 相应
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"error": "request limited",
-				"msg": "服务器繁忙，请稍后再试",
-				"ts": time.Now().Unix(),
-			})
+			abortLimited(c)
 			return
 		}
 
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// abortLimited 终止请求链，返回 429 JSON 相应
+func abortLimited(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+		"error": "request limited",
+		"code":  kverrors.Throttled,
+		"msg":   "服务器繁忙，请稍后再试",
+		"ts":    time.Now().Unix(),
+	})
+}
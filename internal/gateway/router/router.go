@@ -8,8 +8,23 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
+// Handlers 路由用到的所有 Handler
+type Handlers struct {
+	KV         *handler.KVHandler
+	Health     *handler.HealthHandler
+	Admin      *handler.AdminHandler
+	PubSub     *handler.PubSubHandler
+	Sketch     *handler.SketchHandler
+	Geo        *handler.GeoHandler
+	TimeSeries *handler.TimeSeriesHandler
+	JSON       *handler.JSONHandler
+	Throttle   *handler.ThrottleHandler
+	Script     *handler.ScriptHandler
+	ACL        *handler.ACLHandler
+}
+
 // NewRouter 初始化 Gin 引擎并注册所有路由
-func NewRouter(kvHandler *handler.KVHandler, healthHandler *handler.HealthHandler) *gin.Engine {
+func NewRouter(h Handlers, rateLimiter, auth gin.HandlerFunc) *gin.Engine {
 	// 使用 New() 而不是 Default()，因为后者自带了同步的 Logger 和 Recovery
 	r := gin.New()
 
@@ -18,21 +33,90 @@ func NewRouter(kvHandler *handler.KVHandler, healthHandler *handler.HealthHandle
 	r.Use(otelgin.Middleware("gateway-service"))
 	// 异步访问日志中间件
 	r.Use(middleware.AccessLog())
-	// 全局限流中间件
-	r.Use(middleware.GlobalRateLimiter(1000, 2000))
 
-	// 2. 系统路由
-	r.GET("/health", healthHandler.Ping)
+	// 2. 系统路由，不经过限流：集群限流需要访问存储节点，节点变慢时不能拖慢健康检查
+	r.GET("/health", h.Health.Ping)
 
 	// 2. 业务路由
 	v1 := r.Group("api/v1")
 
+	// 限流中间件（本地或集群模式，由 main 根据配置创建）
+	v1.Use(rateLimiter)
+	// ACL 中间件（未开启时直接放行），先于熔断器，拒绝的请求不计入熔断统计
+	v1.Use(auth)
 	// 熔断器中间件
 	v1.Use(middleware.CircuitBreaker("kv-service"))
 	{
-		v1.POST("/kv", kvHandler.HandleSet)
-		v1.GET("/kv", kvHandler.HandleGet)
-		v1.DELETE("/kv", kvHandler.HandleDel)
+		v1.POST("/kv", h.KV.HandleSet)
+		v1.GET("/kv", h.KV.HandleGet)
+		v1.DELETE("/kv", h.KV.HandleDel)
+
+		// 概率数据结构
+		v1.POST("/pf/add", h.Sketch.HandlePFAdd)
+		v1.GET("/pf/count", h.Sketch.HandlePFCount)
+		v1.POST("/pf/merge", h.Sketch.HandlePFMerge)
+		v1.POST("/bf/reserve", h.Sketch.HandleBFReserve)
+		v1.POST("/bf/add", h.Sketch.HandleBFAdd)
+		v1.GET("/bf/exists", h.Sketch.HandleBFExists)
+		v1.POST("/cms/init", h.Sketch.HandleCMSInit)
+		v1.POST("/cms/incrby", h.Sketch.HandleCMSIncrBy)
+		v1.GET("/cms/query", h.Sketch.HandleCMSQuery)
+
+		// 地理位置
+		v1.POST("/geo/add", h.Geo.HandleGeoAdd)
+		v1.GET("/geo/pos", h.Geo.HandleGeoPos)
+		v1.GET("/geo/dist", h.Geo.HandleGeoDist)
+		v1.GET("/geo/search", h.Geo.HandleGeoSearch)
+
+		// 时间序列
+		v1.POST("/ts/create", h.TimeSeries.HandleCreate)
+		v1.POST("/ts/add", h.TimeSeries.HandleAdd)
+		v1.GET("/ts/get", h.TimeSeries.HandleGet)
+		v1.GET("/ts/range", h.TimeSeries.HandleRange)
+		v1.GET("/ts/mrange", h.TimeSeries.HandleMRange)
+		v1.GET("/ts/info", h.TimeSeries.HandleInfo)
+		v1.POST("/ts/rules", h.TimeSeries.HandleCreateRule)
+		v1.DELETE("/ts/rules", h.TimeSeries.HandleDeleteRule)
+
+		// JSON 文档与二级索引
+		v1.POST("/json", h.JSON.HandleSet)
+		v1.GET("/json", h.JSON.HandleGet)
+		v1.DELETE("/json", h.JSON.HandleDel)
+		v1.POST("/json/arrappend", h.JSON.HandleArrAppend)
+		v1.POST("/json/numincrby", h.JSON.HandleNumIncrBy)
+		v1.GET("/json/indexes", h.JSON.HandleListIndexes)
+		v1.POST("/json/indexes", h.JSON.HandleCreateIndex)
+		v1.DELETE("/json/indexes", h.JSON.HandleDropIndex)
+		v1.GET("/find", h.JSON.HandleFind)
+
+		// 限流判定
+		v1.POST("/throttle", h.Throttle.HandleThrottle)
+
+		// 脚本
+		v1.POST("/eval", h.Script.HandleEval)
+		v1.POST("/evalsha", h.Script.HandleEvalSha)
+		v1.POST("/script/load", h.Script.HandleScriptLoad)
+	}
+
+	// 3. 运维管理路由（汇总所有节点）
+	admin := v1.Group("/admin")
+	{
+		admin.GET("/info", h.Admin.HandleInfo)
+		admin.GET("/slowlog", h.Admin.HandleSlowLog)
+		admin.DELETE("/slowlog", h.Admin.HandleSlowLogReset)
+		admin.GET("/acl/users", h.ACL.HandleList)
+		admin.PUT("/acl/users", h.ACL.HandleSetUser)
+		admin.DELETE("/acl/users", h.ACL.HandleDelUser)
+	}
+
+	// 4. 发布/订阅路由
+	// 订阅是长连接，不经过熔断器，避免长时间占用的请求被统计为慢调用
+	pubsub := r.Group("api/v1/pubsub")
+	pubsub.Use(rateLimiter)
+	pubsub.Use(auth)
+	{
+		pubsub.POST("/publish", h.PubSub.HandlePublish)
+		pubsub.GET("/subscribe", h.PubSub.HandleSubscribe)
 	}
 
 	return r
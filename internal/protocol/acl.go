@@ -0,0 +1,341 @@
+package protocol
+
+import (
+	"Flux-KV/pkg/acl"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// commandRule 命令所属的类别，以及从参数中取出要访问的 Key 和 Key 前缀
+type commandRule struct {
+	cat  acl.Category
+	keys func(args []string) (keys, prefixes []string) // args[0] 为命令名，nil 表示不涉及 Key
+}
+
+// noAuthCommands 不检查权限的连接级命令
+var noAuthCommands = map[string]bool{
+	"AUTH": true, "HELLO": true, "QUIT": true, "PING": true, "ECHO": true, "SELECT": true, "COMMAND": true,
+}
+
+// adminSubcommands 需要 admin 权限的子命令，所在命令的其他子命令只作用于当前连接或只读
+var adminSubcommands = map[string]bool{
+	"CLIENT LIST": true, "CLIENT KILL": true,
+	"ACL LIST": true, "ACL USERS": true, "ACL SETUSER": true, "ACL DELUSER": true,
+	"SCRIPT FLUSH": true,
+}
+
+// commandRules 新增命令需要在这里登记，未登记的命令按 admin 处理
+var commandRules = map[string]commandRule{
+	// 只读
+	"GET":          {acl.Read, firstKey},
+	"EXISTS":       {acl.Read, allKeys},
+	"XLEN":         {acl.Read, firstKey},
+	"XRANGE":       {acl.Read, firstKey},
+	"XREVRANGE":    {acl.Read, firstKey},
+	"XREAD":        {acl.Read, streamKeys},
+	"XPENDING":     {acl.Read, firstKey},
+	"PFCOUNT":      {acl.Read, allKeys},
+	"BF.EXISTS":    {acl.Read, firstKey},
+	"BF.MEXISTS":   {acl.Read, firstKey},
+	"CMS.QUERY":    {acl.Read, firstKey},
+	"GEOPOS":       {acl.Read, firstKey},
+	"GEODIST":      {acl.Read, firstKey},
+	"GEOSEARCH":    {acl.Read, firstKey},
+	"TS.GET":       {acl.Read, firstKey},
+	"TS.RANGE":     {acl.Read, firstKey},
+	"TS.INFO":      {acl.Read, firstKey},
+	"TS.MRANGE":    {acl.Read, anyKey}, // 按标签匹配任意序列
+	"JSON.GET":     {acl.Read, firstKey},
+	"FIND":         {acl.Read, findKeys},
+	"WATCH":        {acl.Read, watchKeys},
+	"SUBSCRIBE":    {acl.Read, nil},
+	"PSUBSCRIBE":   {acl.Read, nil},
+	"UNSUBSCRIBE":  {acl.Read, nil},
+	"PUNSUBSCRIBE": {acl.Read, nil},
+
+	// 写入
+	"SET":            {acl.Write, firstKey},
+	"DEL":            {acl.Write, allKeys},
+	"XADD":           {acl.Write, firstKey},
+	"XTRIM":          {acl.Write, firstKey},
+	"XGROUP":         {acl.Write, secondKey},
+	"XACK":           {acl.Write, firstKey},
+	"XCLAIM":         {acl.Write, firstKey},
+	"XREADGROUP":     {acl.Write, streamKeys},
+	"PFADD":          {acl.Write, firstKey},
+	"PFMERGE":        {acl.Write, allKeys},
+	"BF.RESERVE":     {acl.Write, firstKey},
+	"BF.ADD":         {acl.Write, firstKey},
+	"BF.MADD":        {acl.Write, firstKey},
+	"CMS.INITBYDIM":  {acl.Write, firstKey},
+	"CMS.INITBYPROB": {acl.Write, firstKey},
+	"CMS.INCRBY":     {acl.Write, firstKey},
+	"GEOADD":         {acl.Write, firstKey},
+	"TS.CREATE":      {acl.Write, firstKey},
+	"TS.ADD":         {acl.Write, firstKey},
+	"TS.CREATERULE":  {acl.Write, twoKeys},
+	"TS.DELETERULE":  {acl.Write, twoKeys},
+	"JSON.SET":       {acl.Write, firstKey},
+	"JSON.DEL":       {acl.Write, firstKey},
+	"JSON.ARRAPPEND": {acl.Write, firstKey},
+	"JSON.NUMINCRBY": {acl.Write, firstKey},
+	"JSON.INDEX":     {acl.Write, indexKeys},
+	"THROTTLE":       {acl.Write, firstKey},
+	"PUBLISH":        {acl.Write, nil},
+	"EVAL":           {acl.Write, scriptKeys},
+	"EVALSHA":        {acl.Write, scriptKeys},
+	"SCRIPT":         {acl.Write, nil},
+}
+
+func firstKey(args []string) ([]string, []string) {
+	if len(args) < 2 {
+		return nil, nil
+	}
+	return args[1:2], nil
+}
+
+func allKeys(args []string) ([]string, []string) {
+	return args[1:], nil
+}
+
+// secondKey XGROUP CREATE key group ...
+func secondKey(args []string) ([]string, []string) {
+	if len(args) < 3 {
+		return nil, nil
+	}
+	return args[2:3], nil
+}
+
+// twoKeys TS.CREATERULE source dest ...
+func twoKeys(args []string) ([]string, []string) {
+	return args[1:min(3, len(args))], nil
+}
+
+// streamKeys XREAD ... STREAMS k1 k2 id1 id2，STREAMS 之后前一半为 Key
+func streamKeys(args []string) ([]string, []string) {
+	for i, arg := range args {
+		if strings.EqualFold(arg, "STREAMS") {
+			rest := args[i+1:]
+			return rest[:len(rest)/2], nil
+		}
+	}
+	return nil, nil
+}
+
+// scriptKeys EVAL script numkeys k1 k2 ... arg1 arg2 ...
+func scriptKeys(args []string) ([]string, []string) {
+	if len(args) < 3 {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(args[2])
+	if err != nil || n < 0 {
+		return nil, nil
+	}
+	return args[3:min(3+n, len(args))], nil
+}
+
+// anyKey 可能访问任意 Key，只有 allkeys 的用户可以执行
+func anyKey(_ []string) ([]string, []string) {
+	return nil, []string{""}
+}
+
+// watchKeys WATCH key [PREFIX] [FROM revision]，前缀订阅按前缀检查，空前缀表示所有 Key
+func watchKeys(args []string) ([]string, []string) {
+	key, prefix, _, err := parseWatchArgs(args[1:])
+	switch {
+	case err != nil:
+		return nil, nil
+	case prefix:
+		return nil, []string{key}
+	}
+	return []string{key}, nil
+}
+
+// findKeys FIND namespace WHERE ...，访问命名空间下的所有 Key（ns:...）
+func findKeys(args []string) ([]string, []string) {
+	if len(args) < 2 {
+		return nil, nil
+	}
+	return nil, []string{args[1] + ":"}
+}
+
+// indexKeys JSON.INDEX CREATE|DROP namespace field / JSON.INDEX LIST [namespace]，LIST 不带命名空间时为全部
+func indexKeys(args []string) ([]string, []string) {
+	if len(args) < 3 {
+		if len(args) == 2 && strings.EqualFold(args[1], "LIST") {
+			return nil, []string{""}
+		}
+		return nil, nil
+	}
+	return nil, []string{args[2] + ":"}
+}
+
+// authorize 检查连接当前的用户能否执行该命令，未开启 ACL 时直接通过
+func (s *Server) authorize(cli *clientConn, args []string) error {
+	users := s.store.ACL()
+	if users == nil || len(args) == 0 {
+		return nil
+	}
+	cmd := strings.ToUpper(args[0])
+	if noAuthCommands[cmd] {
+		return nil
+	}
+
+	rule, ok := commandRules[cmd]
+	switch {
+	case cmd == "CLIENT" || cmd == "ACL":
+		// 只作用于当前连接的子命令（CLIENT SETNAME / ACL WHOAMI 等）不需要权限
+		if len(args) < 2 || !adminSubcommands[cmd+" "+strings.ToUpper(args[1])] {
+			return nil
+		}
+		rule = commandRule{cat: acl.Admin}
+	case len(args) > 1 && adminSubcommands[cmd+" "+strings.ToUpper(args[1])]:
+		rule = commandRule{cat: acl.Admin}
+	case !ok:
+		rule = commandRule{cat: acl.Admin}
+	}
+
+	var keys, prefixes []string
+	if rule.keys != nil {
+		keys, prefixes = rule.keys(args)
+	}
+	if err := users.Check(cli.getUser(), rule.cat, keys...); err != nil {
+		return err
+	}
+	return users.CheckPrefix(cli.getUser(), rule.cat, prefixes...)
+}
+
+// authCommand AUTH [username] password
+// 只有密码时先按 default 用户的密码认证，再按令牌认证
+func (s *Server) authCommand(cli *clientConn, args []string) Reply {
+	users := s.store.ACL()
+	switch len(args) {
+	case 1:
+		if users == nil {
+			return ErrorReply("ERR AUTH called without any password configured for the default user.")
+		}
+		if users.Authenticate(acl.DefaultUser, args[0]) == nil {
+			cli.setUser(acl.DefaultUser)
+			return StatusReply("OK")
+		}
+		name, err := users.AuthenticateToken(args[0])
+		if err != nil {
+			return errReply(err)
+		}
+		cli.setUser(name)
+		return StatusReply("OK")
+	case 2:
+		if users == nil {
+			return ErrorReply("ERR AUTH called without any password configured for the default user.")
+		}
+		if err := users.Authenticate(args[0], args[1]); err != nil {
+			return errReply(err)
+		}
+		cli.setUser(args[0])
+		return StatusReply("OK")
+	}
+	return wrongArgsReply("AUTH")
+}
+
+// aclCommand ACL WHOAMI | LIST | USERS | SETUSER name [rule ...] | DELUSER name [name ...]
+func (s *Server) aclCommand(cli *clientConn, args []string) Reply {
+	if len(args) == 0 {
+		return wrongArgsReply("ACL")
+	}
+	users := s.store.ACL()
+	sub := strings.ToUpper(args[0])
+	if users == nil && sub != "WHOAMI" {
+		return ErrorReply("ERR ACL is not enabled")
+	}
+
+	switch sub {
+	case "WHOAMI":
+		if name := cli.getUser(); name != "" {
+			return BulkReply(name)
+		}
+		return BulkReply(acl.DefaultUser)
+	case "USERS":
+		list := users.Users()
+		elems := make([]Reply, len(list))
+		for i, u := range list {
+			elems[i] = BulkReply(u.Name)
+		}
+		return ArrayReply(elems...)
+	case "LIST":
+		list := users.Users()
+		elems := make([]Reply, len(list))
+		for i, u := range list {
+			elems[i] = BulkReply(formatACLUser(u))
+		}
+		return ArrayReply(elems...)
+	case "SETUSER":
+		if len(args) < 2 {
+			return wrongArgsReply("ACL|SETUSER")
+		}
+		spec, err := parseACLRules(args[1], args[2:])
+		if err != nil {
+			return errReply(err)
+		}
+		if err := users.SetUser(spec); err != nil {
+			return errReply(err)
+		}
+		return StatusReply("OK")
+	case "DELUSER":
+		if len(args) < 2 {
+			return wrongArgsReply("ACL|DELUSER")
+		}
+		var n int64
+		for _, name := range args[1:] {
+			if users.DelUser(name) {
+				n++
+			}
+		}
+		return IntegerReply(n)
+	}
+	return ErrorReply(fmt.Sprintf("ERR Unknown ACL subcommand '%s'", args[0]))
+}
+
+// parseACLRules 解析 ACL SETUSER 的规则，整体替换原有定义：
+// >password  #<sha256 hex>  $token  nopass  +@read / +@write / +@admin / +@all  ~pattern  allkeys
+func parseACLRules(name string, rules []string) (acl.UserSpec, error) {
+	spec := acl.UserSpec{Name: name}
+	for _, r := range rules {
+		switch {
+		case strings.HasPrefix(r, ">"):
+			spec.Passwords = append(spec.Passwords, r[1:])
+		case strings.HasPrefix(r, "#"):
+			spec.Passwords = append(spec.Passwords, "sha256:"+r[1:])
+		case strings.HasPrefix(r, "$"):
+			spec.Tokens = append(spec.Tokens, r[1:])
+		case strings.HasPrefix(r, "+@"):
+			spec.Categories = append(spec.Categories, r[2:])
+		case strings.HasPrefix(r, "~"):
+			spec.Keys = append(spec.Keys, r[1:])
+		case strings.EqualFold(r, "nopass"):
+			spec.NoPass = true
+		case strings.EqualFold(r, "allkeys"):
+			spec.Keys = append(spec.Keys, "*")
+		default:
+			return spec, fmt.Errorf("Error in ACL SETUSER modifier '%s': Syntax error", r)
+		}
+	}
+	return spec, nil
+}
+
+// formatACLUser ACL LIST 中的一行，不展示密码和令牌
+func formatACLUser(u acl.UserInfo) string {
+	var b strings.Builder
+	b.WriteString("user " + u.Name)
+	if u.NoPass {
+		b.WriteString(" nopass")
+	}
+	fmt.Fprintf(&b, " passwords=%d tokens=%d", u.Passwords, u.Tokens)
+	for _, c := range u.Categories {
+		b.WriteString(" +@" + string(c))
+	}
+	for _, k := range u.Keys {
+		b.WriteString(" ~" + k)
+	}
+	return b.String()
+}
@@ -1,9 +1,9 @@
 package protocol
 
 import (
+	"Flux-KV/pkg/wire"
 	"encoding/binary"
 	"io"
-	"fmt"
 )
 
 // Encode 打包
@@ -18,10 +18,6 @@ func Encode(message string) ([]byte, error) {
 	// 3. 将长度值写入前4字节（大端序，符合网络传输标准）
 	binary.BigEndian.PutUint32(pkg[:4], length)
 
-	// 🔍 观察点 3: 看看 Header 里装的是什么
-    // 这里的 header 应该是 4个字节，代表了 body 的长度
-    fmt.Printf("  -> [Protocol] 正在封包: Body长度=%d, Header字节=%v\n", length, pkg[:4])
-
 	// 4. 把原始消息复制到长度头之后的位置
 	copy(pkg[4:], []byte(message))
 
@@ -30,6 +26,11 @@ func Encode(message string) ([]byte, error) {
 
 // Decode 拆包
 func Decode(reader io.Reader) (string, error) {
+	return decodeLimit(reader, 0)
+}
+
+// decodeLimit 拆包，消息长度超过 limit 时返回 ErrFrameTooLarge，limit 为 0 表示不限制
+func decodeLimit(reader io.Reader, limit int) (string, error) {
 	// 1. 先读取前4字节的长度头
 	headerBuf := make([]byte, 4)
 	// io.ReadFull 保证读满4字节，否则阻塞等待
@@ -39,12 +40,15 @@ func Decode(reader io.Reader) (string, error) {
 
 	// 2. 解析长度头，得到消息内容的长度
 	length := binary.BigEndian.Uint32(headerBuf)
+	if limit > 0 && int64(length) > int64(limit) {
+		return "", ErrFrameTooLarge
+	}
 
 	// 3. 根据解析出的长度，读取消息内容
-	bodyBuf := make([]byte, length)
-	if _, err := io.ReadFull(reader, bodyBuf); err != nil {
+	bodyBuf, err := wire.ReadFull(reader, int(length))
+	if err != nil {
 		return "", err
 	}
 
 	return string(bodyBuf), nil
-}
\ No newline at end of file
+}
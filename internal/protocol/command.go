@@ -0,0 +1,337 @@
+package protocol
+
+import (
+	"Flux-KV/internal/core"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// replyConn 使用带类型回复的连接（RESP、v2 二进制帧），推送模式通过它读写
+type replyConn interface {
+	// ReadCommand 读取一条命令，空命令返回 nil
+	ReadCommand() ([]string, error)
+	// WriteReply 写一条回复并立即发送，可以被多个协程并发调用
+	WriteReply(r Reply) error
+}
+
+// execute 执行一条 RESP / v2 命令，返回带类型的回复
+// 字符串、脚本等命令按 Redis 的语义执行（与文本协议的参数和回复不同），其余交给 dispatch
+func (s *Server) execute(cli *clientConn, args []string) Reply {
+	clientAddr := cli.addr
+	cmd := strings.ToUpper(args[0])
+	switch cmd {
+	case "CLIENT":
+		return s.clientCommand(cli, args[1:])
+	case "AUTH":
+		return s.authCommand(cli, args[1:])
+	case "ACL":
+		return s.aclCommand(cli, args[1:])
+	case "PING":
+		if len(args) > 1 {
+			return BulkReply(args[1])
+		}
+		return StatusReply("PONG")
+	case "ECHO":
+		if len(args) != 2 {
+			return wrongArgsReply(cmd)
+		}
+		return BulkReply(args[1])
+	case "GET", "SET", "DEL", "EXISTS", "EVAL", "EVALSHA", "SCRIPT":
+		defer s.trace(clientAddr, args)()
+	default:
+		return s.dispatch(clientAddr, args)
+	}
+
+	switch cmd {
+	case "GET":
+		if len(args) != 2 {
+			return wrongArgsReply(cmd)
+		}
+		val, found := s.store.Get(args[1])
+		if !found {
+			return NilReply
+		}
+		str, ok := val.(string)
+		if !ok {
+			return errReply(core.ErrWrongType)
+		}
+		return BulkReply(str)
+	case "SET":
+		// SET key value [EX seconds | PX milliseconds]
+		if len(args) != 3 && len(args) != 5 {
+			return ErrorReply("ERR syntax error")
+		}
+		var ttl time.Duration
+		if len(args) == 5 {
+			n, err := strconv.ParseInt(args[4], 10, 64)
+			if err != nil || n <= 0 {
+				return ErrorReply("ERR invalid expire time in 'set' command")
+			}
+			switch strings.ToUpper(args[3]) {
+			case "EX":
+				ttl = time.Duration(n) * time.Second
+			case "PX":
+				ttl = time.Duration(n) * time.Millisecond
+			default:
+				return ErrorReply("ERR syntax error")
+			}
+		}
+		s.store.Set(args[1], args[2], ttl)
+		return StatusReply("OK")
+	case "DEL", "EXISTS":
+		// 返回存在（被删除）的 Key 个数
+		if len(args) < 2 {
+			return wrongArgsReply(cmd)
+		}
+		if cmd == "DEL" {
+			return IntegerReply(int64(s.store.Del(args[1:]...)))
+		}
+		return IntegerReply(int64(s.store.Exists(args[1:]...)))
+	case "EVAL", "EVALSHA":
+		// EVAL script numkeys [key ...] [arg ...]，参数可以包含空白，脚本正文作为一个参数传入
+		if len(args) < 3 {
+			return wrongArgsReply(cmd)
+		}
+		numKeys, err := strconv.Atoi(args[2])
+		if err != nil || numKeys < 0 || numKeys > len(args)-3 {
+			return ErrorReply("ERR Number of keys can't be greater than number of args")
+		}
+		keys, argv := args[3:3+numKeys], args[3+numKeys:]
+		var ret any
+		if cmd == "EVAL" {
+			ret, err = s.store.Eval(args[1], keys, argv)
+		} else {
+			ret, err = s.store.EvalSha(args[1], keys, argv)
+		}
+		if err != nil {
+			return errReply(err)
+		}
+		return scriptReply(ret)
+	default:
+		// SCRIPT LOAD|EXISTS|FLUSH
+		return s.executeScript(args[1:])
+	}
+}
+
+// executeScript SCRIPT LOAD|EXISTS|FLUSH
+func (s *Server) executeScript(args []string) Reply {
+	if len(args) == 0 {
+		return wrongArgsReply("SCRIPT")
+	}
+	switch strings.ToUpper(args[0]) {
+	case "LOAD":
+		if len(args) != 2 {
+			return wrongArgsReply("SCRIPT|LOAD")
+		}
+		sha, err := s.store.ScriptLoad(args[1])
+		if err != nil {
+			return errReply(err)
+		}
+		return BulkReply(sha)
+	case "EXISTS":
+		if len(args) < 2 {
+			return wrongArgsReply("SCRIPT|EXISTS")
+		}
+		exists := s.store.ScriptExists(args[1:]...)
+		elems := make([]Reply, len(exists))
+		for i, ok := range exists {
+			elems[i] = IntegerReply(0)
+			if ok {
+				elems[i] = IntegerReply(1)
+			}
+		}
+		return ArrayReply(elems...)
+	case "FLUSH":
+		s.store.ScriptFlush()
+		return StatusReply("OK")
+	}
+	return ErrorReply(fmt.Sprintf("ERR Unknown SCRIPT subcommand '%s'", args[0]))
+}
+
+// replyMonitor 推送模式：持续推送 MemDB 执行的命令，event 决定每条命令的回复格式
+func (s *Server) replyMonitor(c replyConn, clientAddr, pattern string, event func(string) Reply) {
+	m := s.store.Monitor(pattern, 0)
+	defer func() {
+		s.store.Unmonitor(m)
+		log.Printf("Client %s left MONITOR mode (dropped %d events)", clientAddr, m.Dropped())
+	}()
+
+	if c.WriteReply(StatusReply("OK")) != nil {
+		return
+	}
+	log.Printf("Client %s entered MONITOR mode (pattern=%q)", clientAddr, pattern)
+
+	replyPushLoop(c, m.C, event)
+}
+
+// replyWatch 推送模式：持续推送 Key 变更，event 决定每个事件的回复格式
+// 用法: WATCH <key> [PREFIX] [FROM <revision>]
+func (s *Server) replyWatch(c replyConn, clientAddr string, args []string, event func(string) Reply) {
+	key, prefix, fromRev, err := parseWatchArgs(args)
+	if err != nil {
+		c.WriteReply(ErrorReply("ERR " + err.Error()))
+		return
+	}
+	w, err := s.store.Watch(key, prefix, fromRev)
+	if err != nil {
+		c.WriteReply(errReply(err))
+		return
+	}
+	defer s.store.Unwatch(w)
+
+	if c.WriteReply(StatusReply(fmt.Sprintf("OK revision=%d", s.store.Revision()))) != nil {
+		return
+	}
+	log.Printf("Client %s entered WATCH mode (key=%q prefix=%v from=%d)", clientAddr, key, prefix, fromRev)
+
+	// Watcher 因消费过慢被关闭时告知客户端
+	if replyPushLoop(c, w.C, event) && w.Err() != nil {
+		c.WriteReply(errReply(w.Err()))
+	}
+}
+
+// pushEvent 事件作为只有一个元素的 push 发送，用于 v2 帧
+func pushEvent(e string) Reply {
+	return PushReply(BulkReply(e))
+}
+
+// replyPushLoop 把 events 中的事件逐条推送给客户端
+// 客户端断开或发送 QUIT 时返回 false；events 被关闭时返回 true
+func replyPushLoop[T fmt.Stringer](c replyConn, events <-chan T, event func(string) Reply) bool {
+	// 1. 读协程：检测客户端断开
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			args, err := c.ReadCommand()
+			if err != nil || (len(args) > 0 && strings.EqualFold(args[0], "QUIT")) {
+				return
+			}
+		}
+	}()
+
+	// 2. 写循环：推送事件
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return true
+			}
+			if err := c.WriteReply(event(e.String())); err != nil {
+				return false
+			}
+		case <-done:
+			return false
+		}
+	}
+}
+
+// replyPubSub 发布/订阅模式，订阅确认和消息都以 push 回复
+// 返回 true 表示订阅数已归零，连接回到普通命令模式；false 表示连接应关闭
+func (s *Server) replyPubSub(c replyConn, clientAddr string, first []string) bool {
+	sub := s.store.NewSubscriber(0)
+	defer sub.Close()
+
+	if count, ok := replyPubSubCommand(c, sub, first); !ok || count == 0 {
+		return ok
+	}
+	log.Printf("Client %s entered PUBSUB mode", clientAddr)
+
+	// 1. 读协程：处理订阅变更，订阅数归零时退出推送模式
+	backToNormal := make(chan bool, 1)
+	go func() {
+		for {
+			args, err := c.ReadCommand()
+			if err != nil {
+				backToNormal <- false
+				return
+			}
+			if len(args) == 0 {
+				continue
+			}
+			count, ok := replyPubSubCommand(c, sub, args)
+			if !ok {
+				backToNormal <- false
+				return
+			}
+			if count == 0 {
+				backToNormal <- true
+				return
+			}
+		}
+	}()
+
+	// 2. 写循环：推送消息
+	for {
+		select {
+		case m, ok := <-sub.C:
+			if !ok {
+				// 消费过慢被断开
+				if err := sub.Err(); err != nil {
+					c.WriteReply(errReply(err))
+				}
+				return false
+			}
+			var r Reply
+			if m.Pattern != "" {
+				r = PushReply(BulkReply("pmessage"), BulkReply(m.Pattern), BulkReply(m.Channel), BulkReply(m.Payload))
+			} else {
+				r = PushReply(BulkReply("message"), BulkReply(m.Channel), BulkReply(m.Payload))
+			}
+			if c.WriteReply(r) != nil {
+				return false
+			}
+		case normal := <-backToNormal:
+			return normal
+		}
+	}
+}
+
+// replyPubSubCommand 执行推送模式下允许的命令，返回执行后的订阅总数
+// ok 为 false 表示连接应关闭（QUIT 或写失败）
+func replyPubSubCommand(c replyConn, sub *core.Subscriber, args []string) (count int, ok bool) {
+	cmd := strings.ToLower(args[0])
+	names := args[1:]
+	count = len(sub.Channels()) + len(sub.Patterns())
+	switch cmd {
+	case "subscribe", "psubscribe", "unsubscribe", "punsubscribe":
+		if len(names) == 0 {
+			switch cmd {
+			case "unsubscribe":
+				names = sub.Channels()
+			case "punsubscribe":
+				names = sub.Patterns()
+			default:
+				return count, c.WriteReply(wrongArgsReply(cmd)) == nil
+			}
+		}
+		// 与 Redis 一致，每个频道回复一条确认
+		for _, name := range names {
+			switch cmd {
+			case "subscribe":
+				count = sub.Subscribe(name)
+			case "psubscribe":
+				count = sub.PSubscribe(name)
+			case "unsubscribe":
+				count = sub.Unsubscribe(name)
+			default:
+				count = sub.PUnsubscribe(name)
+			}
+			if c.WriteReply(PushReply(BulkReply(cmd), BulkReply(name), IntegerReply(int64(count)))) != nil {
+				return count, false
+			}
+		}
+		return count, true
+	case "ping":
+		return count, c.WriteReply(StatusReply("PONG")) == nil
+	case "quit":
+		c.WriteReply(StatusReply("OK"))
+		return 0, false
+	default:
+		return count, c.WriteReply(ErrorReply(fmt.Sprintf(
+			"ERR Can't execute '%s': only (P)SUBSCRIBE / (P)UNSUBSCRIBE / PING / QUIT are allowed in this context", args[0]))) == nil
+	}
+}
@@ -0,0 +1,426 @@
+package protocol
+
+import (
+	"Flux-KV/pkg/tlsutil"
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrServerClosed 调用 Shutdown 之后 Start / StartRESP 返回该错误
+var ErrServerClosed = errors.New("protocol: server closed")
+
+// errMaxClients 连接数达到 tcp.max_conns
+var errMaxClients = errors.New("max number of clients reached")
+
+// clientConn 一个客户端连接，记录 CLIENT LIST 展示的信息
+// 写操作带有写超时，读超时由 awaitRequest 在每个请求开始前设置
+type clientConn struct {
+	net.Conn
+	s       *Server
+	id      int64
+	addr    string
+	created time.Time
+
+	mu    sync.Mutex
+	name  string
+	user  string    // AUTH 认证后的 ACL 用户，为空表示按 default 用户处理
+	proto string    // text / v2 / resp2 / resp3 / memcache
+	cmd   string    // 最近执行的命令
+	last  time.Time // 最近一次执行命令的时间
+
+	killed atomic.Bool // 被 CLIENT KILL 关闭
+}
+
+// Write 每次写之前设置写超时
+func (c *clientConn) Write(p []byte) (int, error) {
+	if t := c.s.cfg.WriteTimeout; t > 0 {
+		c.SetWriteDeadline(time.Now().Add(t))
+	}
+	return c.Conn.Write(p)
+}
+
+// awaitRequest 等待下一个请求的第一个字节，空闲超时后返回错误
+// 收到后把读超时改为 read_timeout，限制读完整个请求的时间；服务关闭或连接被 KILL 时直接返回错误
+func (c *clientConn) awaitRequest(r *bufio.Reader) error {
+	return c.await(r, c.s.cfg.IdleTimeout)
+}
+
+// await 等待请求的第一个字节，idle 为 0 表示不限制等待时间（推送模式）
+func (c *clientConn) await(r *bufio.Reader, idle time.Duration) error {
+	var deadline time.Time
+	if idle > 0 {
+		deadline = time.Now().Add(idle)
+	}
+	c.SetReadDeadline(deadline)
+	// 先设置超时再检查状态，与 Shutdown / kill 的顺序相反，保证不会错过唤醒
+	if c.s.closing.Load() || c.killed.Load() {
+		return ErrServerClosed
+	}
+	if _, err := r.Peek(1); err != nil {
+		// 被 Shutdown / kill 唤醒时不是读错误，v2 连接不应回复协议错误
+		if c.s.closing.Load() || c.killed.Load() {
+			return ErrServerClosed
+		}
+		return err
+	}
+
+	deadline = time.Time{}
+	if t := c.s.cfg.ReadTimeout; t > 0 {
+		deadline = time.Now().Add(t)
+	}
+	c.SetReadDeadline(deadline)
+	return nil
+}
+
+// enterPushMode 推送模式下客户端可以长时间不发送请求，取消读超时
+func (c *clientConn) enterPushMode() bool {
+	c.SetReadDeadline(time.Time{})
+	return !c.s.closing.Load() && !c.killed.Load()
+}
+
+// touch 记录正在执行的命令
+func (c *clientConn) touch(cmd string) {
+	c.mu.Lock()
+	c.cmd = strings.ToLower(cmd)
+	c.last = time.Now()
+	c.mu.Unlock()
+}
+
+func (c *clientConn) setProto(proto string) {
+	c.mu.Lock()
+	c.proto = proto
+	c.mu.Unlock()
+}
+
+func (c *clientConn) setName(name string) {
+	c.mu.Lock()
+	c.name = name
+	c.mu.Unlock()
+}
+
+func (c *clientConn) getName() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.name
+}
+
+func (c *clientConn) setUser(user string) {
+	c.mu.Lock()
+	c.user = user
+	c.mu.Unlock()
+}
+
+func (c *clientConn) getUser() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.user
+}
+
+// kill 关闭连接：其他连接立即关闭；当前连接先写完本次回复，在读取下一个请求前退出
+func (c *clientConn) kill(self bool) {
+	c.killed.Store(true)
+	if self {
+		c.SetReadDeadline(time.Now())
+		return
+	}
+	c.Close()
+}
+
+// String CLIENT LIST 中的一行，已认证的连接额外展示用户，mTLS 连接额外展示客户端证书的身份
+func (c *clientConn) String() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	line := fmt.Sprintf("id=%d addr=%s name=%s age=%d idle=%d proto=%s cmd=%s",
+		c.id, c.addr, c.name, int(now.Sub(c.created).Seconds()), int(now.Sub(c.last).Seconds()), c.proto, c.cmd)
+	if c.user != "" {
+		line += " user=" + c.user
+	}
+	if cert := tlsutil.ConnIdentity(c.Conn); cert != "" {
+		line += " cert=" + cert
+	}
+	return line
+}
+
+// listen 监听 addr，开启 TLS 时包装为 TLS 监听，证书文件变化后新连接自动使用新证书
+func (s *Server) listen(addr string) (net.Listener, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil || !s.tlsCfg.Enabled {
+		return listener, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tls == nil {
+		r, err := s.tlsCfg.NewReloader()
+		if err != nil {
+			listener.Close()
+			return nil, err
+		}
+		s.tls = r
+	}
+	return tls.NewListener(listener, s.tls.ServerConfig()), nil
+}
+
+// serve 接受连接的循环，Start 与 StartRESP 共用
+// 超过最大连接数时调用 reject 告知客户端后关闭连接
+func (s *Server) serve(listener net.Listener, handle func(*clientConn), reject func(net.Conn)) error {
+	return s.acceptLoop(listener, func(conn net.Conn) {
+		cli, err := s.admit(conn, reject)
+		if err != nil {
+			return
+		}
+		// 并发处理：每个连接启动独立Goroutine
+		go func() {
+			defer s.unregister(cli)
+			handle(cli)
+		}()
+	})
+}
+
+// acceptLoop 循环接受连接并交给 handle，Shutdown 之后返回 ErrServerClosed
+func (s *Server) acceptLoop(listener net.Listener, handle func(net.Conn)) error {
+	if !s.trackListener(listener) {
+		listener.Close()
+		return ErrServerClosed
+	}
+	defer s.untrackListener(listener)
+
+	for {
+		conn, err := listener.Accept() // 阻塞等待新连接
+		if err != nil {
+			if s.closing.Load() {
+				return ErrServerClosed
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return err
+			}
+			log.Printf("Accept error: %v", err)
+			continue
+		}
+		handle(conn)
+	}
+}
+
+// admit 登记新连接，超过最大连接数时调用 reject 告知客户端，失败时关闭连接
+func (s *Server) admit(conn net.Conn, reject func(net.Conn)) (*clientConn, error) {
+	cli, err := s.register(conn)
+	if err != nil {
+		if err == errMaxClients {
+			log.Printf("⚠️ Reject connection from %s: %v", conn.RemoteAddr(), err)
+			// TLS 连接写之前要先握手，限制时间避免阻塞接受循环
+			conn.SetDeadline(time.Now().Add(time.Second))
+			reject(conn)
+		}
+		conn.Close()
+		return nil, err
+	}
+	return cli, nil
+}
+
+func (s *Server) trackListener(l net.Listener) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closing.Load() {
+		return false
+	}
+	s.listeners[l] = struct{}{}
+	return true
+}
+
+func (s *Server) untrackListener(l net.Listener) {
+	s.mu.Lock()
+	delete(s.listeners, l)
+	s.mu.Unlock()
+	l.Close()
+}
+
+// register 登记新连接，检查最大连接数
+func (s *Server) register(conn net.Conn) (*clientConn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closing.Load() {
+		return nil, ErrServerClosed
+	}
+	if s.cfg.MaxConns > 0 && len(s.clients) >= s.cfg.MaxConns {
+		return nil, errMaxClients
+	}
+	now := time.Now()
+	cli := &clientConn{
+		Conn:    conn,
+		s:       s,
+		id:      s.nextClientID.Add(1),
+		addr:    conn.RemoteAddr().String(),
+		created: now,
+		last:    now,
+		proto:   "text",
+	}
+	s.clients[cli.id] = cli
+	return cli, nil
+}
+
+func (s *Server) unregister(cli *clientConn) {
+	s.mu.Lock()
+	delete(s.clients, cli.id)
+	s.mu.Unlock()
+	cli.Close()
+}
+
+// NumClients 当前连接数
+func (s *Server) NumClients() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.clients)
+}
+
+// Shutdown 优雅关闭：停止接受新连接，空闲连接立即关闭，正在执行的命令执行完并写回回复后关闭
+// ctx 到期时强制关闭剩余连接并返回 ctx.Err()
+func (s *Server) Shutdown(ctx context.Context) error {
+	// 1. 设置关闭标记后再唤醒阻塞在读上的连接，见 awaitRequest
+	s.closing.Store(true)
+	s.mu.Lock()
+	for l := range s.listeners {
+		l.Close()
+	}
+	for _, cli := range s.clients {
+		cli.SetReadDeadline(time.Now())
+	}
+	if s.tls != nil {
+		s.tls.Close()
+	}
+	s.mu.Unlock()
+	log.Printf("🛑 TCP Server shutting down, draining connections...")
+
+	// 2. 等待所有连接退出
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if s.NumClients() == 0 {
+			log.Printf("✅ TCP Server stopped")
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			s.mu.Lock()
+			for _, cli := range s.clients {
+				cli.Close()
+			}
+			s.mu.Unlock()
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// clientCommand CLIENT LIST | KILL | SETNAME | GETNAME | ID | SETINFO
+func (s *Server) clientCommand(c *clientConn, args []string) Reply {
+	if len(args) == 0 {
+		return wrongArgsReply("CLIENT")
+	}
+	switch strings.ToUpper(args[0]) {
+	case "LIST":
+		s.mu.Lock()
+		clients := make([]*clientConn, 0, len(s.clients))
+		for _, cli := range s.clients {
+			clients = append(clients, cli)
+		}
+		s.mu.Unlock()
+		sort.Slice(clients, func(i, j int) bool { return clients[i].id < clients[j].id })
+
+		var b strings.Builder
+		for _, cli := range clients {
+			b.WriteString(cli.String())
+			b.WriteByte('\n')
+		}
+		return BulkReply(b.String())
+	case "KILL":
+		return s.clientKill(c, args[1:])
+	case "SETNAME":
+		if len(args) != 2 {
+			return wrongArgsReply("CLIENT|SETNAME")
+		}
+		if strings.ContainsAny(args[1], " \r\n") {
+			return ErrorReply("ERR Client names cannot contain spaces, newlines or special characters.")
+		}
+		c.setName(args[1])
+		return StatusReply("OK")
+	case "GETNAME":
+		if name := c.getName(); name != "" {
+			return BulkReply(name)
+		}
+		return NilReply
+	case "ID":
+		return IntegerReply(c.id)
+	case "SETINFO":
+		// 客户端库建立连接时上报库名和版本，不需要记录
+		return StatusReply("OK")
+	}
+	return ErrorReply(fmt.Sprintf("ERR Unknown CLIENT subcommand '%s'", args[0]))
+}
+
+// clientKill CLIENT KILL addr，或 CLIENT KILL ID id / ADDR addr（可组合，返回关闭的连接数）
+func (s *Server) clientKill(c *clientConn, args []string) Reply {
+	if len(args) == 0 {
+		return wrongArgsReply("CLIENT|KILL")
+	}
+
+	// 1. 旧格式：只有一个地址参数
+	if len(args) == 1 {
+		found := s.matchClients(0, args[0])
+		if len(found) == 0 {
+			return ErrorReply("ERR No such client")
+		}
+		found[0].kill(found[0] == c)
+		return StatusReply("OK")
+	}
+
+	// 2. 过滤条件
+	if len(args)%2 != 0 {
+		return ErrorReply("ERR syntax error")
+	}
+	var id int64
+	addr := ""
+	for i := 0; i < len(args); i += 2 {
+		switch strings.ToUpper(args[i]) {
+		case "ID":
+			n, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil || n <= 0 {
+				return ErrorReply("ERR client-id should be greater than 0")
+			}
+			id = n
+		case "ADDR":
+			addr = args[i+1]
+		default:
+			return ErrorReply("ERR syntax error")
+		}
+	}
+	killed := s.matchClients(id, addr)
+	for _, cli := range killed {
+		cli.kill(cli == c)
+	}
+	return IntegerReply(int64(len(killed)))
+}
+
+// matchClients 按 ID 和地址查找连接，零值表示不限制
+func (s *Server) matchClients(id int64, addr string) []*clientConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []*clientConn
+	for _, cli := range s.clients {
+		if (id == 0 || cli.id == id) && (addr == "" || cli.addr == addr) {
+			out = append(out, cli)
+		}
+	}
+	return out
+}
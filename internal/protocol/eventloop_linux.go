@@ -0,0 +1,592 @@
+//go:build linux
+
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// 事件循环：一个 epoll 实例等待所有连接的可读 / 可写事件，固定数量的 Worker 读取请求、执行命令并写回回复
+// 连接空闲时既不占用 Goroutine 也不持有读写缓冲，适合大量长连接但请求稀疏的场景（例如 IoT 设备）
+// 版本协商、推送模式（HELLO / MONITOR / WATCH / SUBSCRIBE）和阻塞读取（XREAD BLOCK）会长时间占用连接，
+// 这些连接转交给独立 Goroutine，按原来的方式处理
+
+const (
+	pollInterval    = 100 * time.Millisecond // epoll_wait 的超时，用于检查连接超时和退出
+	pollBatch       = 256                    // 一次 epoll_wait 最多返回的事件数
+	bufferSize      = 4096                   // 读写缓冲的初始大小
+	maxPooledBuffer = 64 * 1024              // 超过该大小的缓冲用完后直接丢弃，不放回池中
+)
+
+// bufferPool 连接的读写缓冲，连接空闲时归还
+var bufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, bufferSize)
+		return &buf
+	},
+}
+
+func getBuffer() *[]byte {
+	return bufferPool.Get().(*[]byte)
+}
+
+func putBuffer(buf *[]byte) {
+	if cap(*buf) > maxPooledBuffer {
+		return
+	}
+	*buf = (*buf)[:0]
+	bufferPool.Put(buf)
+}
+
+// errEventLoopConn 连接由事件循环读写，不能直接读写
+var errEventLoopConn = errors.New("protocol: connection is served by the event loop")
+
+// eventConn 接入事件循环的连接
+// 接入之前和转交给 Goroutine 之后读写都委托给 nc；接入期间由 Worker 直接读写 fd，
+// 其他 Goroutine 的 Close / SetReadDeadline 通过 shutdown 唤醒 Worker，由 Worker 释放连接
+type eventConn struct {
+	local, remote net.Addr
+
+	mu   sync.Mutex
+	fd   int      // 接入事件循环后的文件描述符，-1 表示未接入、已转交或已释放
+	nc   net.Conn // 未接入或已转交给 Goroutine 时的连接
+	shut bool     // 已调用 shutdown，等待 Worker 释放
+
+	// 以下字段只由当前处理该连接的 Worker 访问，EPOLLONESHOT 保证同一时刻只有一个 Worker；
+	// serving 让前后两个 Worker 之间的交接在 Go 内存模型中可见（epoll 的同步 race detector 看不到）
+	serving sync.Mutex
+	cli     *clientConn
+	in, out *[]byte // 读写缓冲，为空时归还到池中
+	closing bool    // 写完积压的回复后释放连接
+
+	// 超时检查用到的时间（纳秒），0 表示没有
+	active     atomic.Int64 // 最近一次处理完请求
+	readSince  atomic.Int64 // 开始收到不完整的请求
+	writeSince atomic.Int64 // 开始有写不出去的回复
+}
+
+func newEventConn(nc net.Conn) *eventConn {
+	c := &eventConn{local: nc.LocalAddr(), remote: nc.RemoteAddr(), nc: nc, fd: -1}
+	c.active.Store(time.Now().UnixNano())
+	return c
+}
+
+func (c *eventConn) delegate() net.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.nc
+}
+
+func (c *eventConn) Read(p []byte) (int, error) {
+	if nc := c.delegate(); nc != nil {
+		return nc.Read(p)
+	}
+	return 0, errEventLoopConn
+}
+
+func (c *eventConn) Write(p []byte) (int, error) {
+	if nc := c.delegate(); nc != nil {
+		return nc.Write(p)
+	}
+	return 0, errEventLoopConn
+}
+
+// Close 关闭连接：接入期间只 shutdown 读写两端，fd 由 Worker 收到事件后释放
+func (c *eventConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.nc != nil {
+		return c.nc.Close()
+	}
+	if c.fd >= 0 && !c.shut {
+		c.shut = true
+		syscall.Shutdown(c.fd, syscall.SHUT_RDWR)
+	}
+	return nil
+}
+
+func (c *eventConn) LocalAddr() net.Addr  { return c.local }
+func (c *eventConn) RemoteAddr() net.Addr { return c.remote }
+
+func (c *eventConn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.SetWriteDeadline(t)
+}
+
+// SetReadDeadline 接入期间只支持立即到期（Shutdown 与 CLIENT KILL 用来唤醒连接）：
+// shutdown 读端后 Worker 写完积压的回复再释放连接；其他超时由事件循环定期检查
+func (c *eventConn) SetReadDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.nc != nil {
+		return c.nc.SetReadDeadline(t)
+	}
+	if c.fd >= 0 && !c.shut && !t.IsZero() && !t.After(time.Now()) {
+		syscall.Shutdown(c.fd, syscall.SHUT_RD)
+	}
+	return nil
+}
+
+func (c *eventConn) SetWriteDeadline(t time.Time) error {
+	if nc := c.delegate(); nc != nil {
+		return nc.SetWriteDeadline(t)
+	}
+	return nil
+}
+
+// fill 从 fd 读一次数据追加到读缓冲，缓冲不够时按已收到的数据扩容
+func (c *eventConn) fill() (int, error) {
+	if c.in == nil {
+		c.in = getBuffer()
+	}
+	buf := *c.in
+	if cap(buf)-len(buf) < bufferSize/2 {
+		grown := make([]byte, len(buf), 2*cap(buf)+bufferSize)
+		copy(grown, buf)
+		buf = grown
+	}
+	for {
+		n, err := syscall.Read(c.fd, buf[len(buf):cap(buf)])
+		if err == syscall.EINTR {
+			continue
+		}
+		if n > 0 {
+			buf = buf[:len(buf)+n]
+		}
+		*c.in = buf
+		return n, err
+	}
+}
+
+// flush 把写缓冲中的回复写到 fd，返回是否全部写完
+func (c *eventConn) flush() (bool, error) {
+	if c.out == nil || len(*c.out) == 0 {
+		return true, nil
+	}
+	pending := *c.out
+	for len(pending) > 0 {
+		n, err := syscall.Write(c.fd, pending)
+		switch {
+		case err == syscall.EINTR:
+			continue
+		case err == syscall.EAGAIN:
+			// 内核发送缓冲区已满，剩余部分移到缓冲开头，等待可写
+			*c.out = (*c.out)[:copy(*c.out, pending)]
+			c.writeSince.CompareAndSwap(0, time.Now().UnixNano())
+			return false, nil
+		case err != nil:
+			return false, err
+		case n <= 0:
+			return false, io.ErrShortWrite
+		}
+		pending = pending[n:]
+	}
+	*c.out = (*c.out)[:0]
+	c.writeSince.Store(0)
+	return true, nil
+}
+
+// releaseBuffers 归还空的读写缓冲，空闲连接只保留连接本身
+func (c *eventConn) releaseBuffers(force bool) {
+	if c.in != nil && (force || len(*c.in) == 0) {
+		putBuffer(c.in)
+		c.in = nil
+	}
+	if c.out != nil && (force || len(*c.out) == 0) {
+		putBuffer(c.out)
+		c.out = nil
+	}
+}
+
+// eventLoop 一个监听对应的 epoll 实例与 Worker 池
+type eventLoop struct {
+	s     *Server
+	epfd  int
+	tasks chan *eventConn // 有事件的连接，由 Worker 处理
+
+	mu       sync.Mutex
+	conns    map[int]*eventConn // fd -> 连接
+	stopping atomic.Bool        // 监听已关闭，所有连接释放后退出
+}
+
+// serveEventLoop 用事件循环接受并处理文本协议连接，Shutdown 之后返回 ErrServerClosed
+func (s *Server) serveEventLoop(listener net.Listener, reject func(net.Conn)) error {
+	if s.tlsCfg.Enabled {
+		listener.Close()
+		return errors.New("protocol: event loop does not support TLS")
+	}
+	epfd, err := syscall.EpollCreate1(syscall.EPOLL_CLOEXEC)
+	if err != nil {
+		listener.Close()
+		return os.NewSyscallError("epoll_create1", err)
+	}
+
+	// 1. 启动 Worker 池与 epoll 等待循环
+	workers := s.cfg.EventLoopWorkers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	l := &eventLoop{
+		s:     s,
+		epfd:  epfd,
+		tasks: make(chan *eventConn, pollBatch),
+		conns: make(map[int]*eventConn),
+	}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for c := range l.tasks {
+				l.serve(c)
+			}
+		}()
+	}
+	go l.poll()
+	log.Printf("🔁 Event loop started with %d workers", workers)
+
+	// 2. 接受连接，登记后接入 epoll
+	defer l.stopping.Store(true)
+	return s.acceptLoop(listener, func(conn net.Conn) {
+		c := newEventConn(conn)
+		cli, err := s.admit(c, reject)
+		if err != nil {
+			return
+		}
+		c.cli = cli
+		if err := l.attach(c); err != nil {
+			log.Printf("Attach %s to event loop failed: %v", cli.addr, err)
+			s.unregister(cli)
+			return
+		}
+		log.Printf("New connection from: %s", cli.addr)
+	})
+}
+
+// attach 复制连接的 fd 交给 epoll，原连接关闭，之后由 Worker 直接读写 fd
+func (l *eventLoop) attach(c *eventConn) error {
+	sc, ok := c.nc.(syscall.Conn)
+	if !ok {
+		return errors.New("connection does not expose a file descriptor")
+	}
+	raw, err := sc.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	// 1. 复制 fd：原连接关闭时 Go 运行时会把原 fd 从自己的 netpoller 中移除，不影响复制出的 fd
+	c.mu.Lock()
+	fd, dupErr := -1, error(nil)
+	if err := raw.Control(func(s uintptr) { fd, dupErr = syscall.Dup(int(s)) }); err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	if dupErr != nil {
+		c.mu.Unlock()
+		return os.NewSyscallError("dup", dupErr)
+	}
+	syscall.CloseOnExec(fd)
+	syscall.SetNonblock(fd, true)
+	c.nc.Close()
+	c.nc, c.fd = nil, fd
+	c.mu.Unlock()
+
+	// 2. 登记后再加入 epoll，保证收到事件时能找到连接
+	l.mu.Lock()
+	l.conns[fd] = c
+	l.mu.Unlock()
+	ev := syscall.EpollEvent{Events: syscall.EPOLLIN | syscall.EPOLLRDHUP | syscall.EPOLLONESHOT, Fd: int32(fd)}
+	if err := syscall.EpollCtl(l.epfd, syscall.EPOLL_CTL_ADD, fd, &ev); err != nil {
+		l.mu.Lock()
+		delete(l.conns, fd)
+		l.mu.Unlock()
+		c.mu.Lock()
+		syscall.Close(fd)
+		c.fd = -1
+		c.mu.Unlock()
+		return os.NewSyscallError("epoll_ctl", err)
+	}
+
+	// 3. 接入前服务已开始关闭或连接已被 KILL 时，唤醒连接让 Worker 释放
+	if l.s.closing.Load() || c.cli.killed.Load() {
+		c.SetReadDeadline(time.Now())
+	}
+	return nil
+}
+
+// poll 等待 epoll 事件并分发给 Worker，同时定期检查连接超时
+// 监听关闭且所有连接都释放后关闭 Worker 池并退出
+func (l *eventLoop) poll() {
+	events := make([]syscall.EpollEvent, pollBatch)
+	lastSweep := time.Now()
+	for {
+		n, err := syscall.EpollWait(l.epfd, events, int(pollInterval/time.Millisecond))
+		if err != nil && err != syscall.EINTR {
+			log.Printf("epoll_wait error: %v", err)
+		}
+		for i := 0; i < n; i++ {
+			l.mu.Lock()
+			c := l.conns[int(events[i].Fd)]
+			l.mu.Unlock()
+			if c != nil {
+				l.tasks <- c
+			}
+		}
+
+		if time.Since(lastSweep) >= time.Second {
+			l.sweep()
+			lastSweep = time.Now()
+		}
+		if l.stopping.Load() && l.count() == 0 {
+			close(l.tasks)
+			syscall.Close(l.epfd)
+			return
+		}
+	}
+}
+
+func (l *eventLoop) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.conns)
+}
+
+// sweep 关闭超过 idle_timeout / read_timeout / write_timeout 的连接
+func (l *eventLoop) sweep() {
+	cfg := l.s.cfg
+	if cfg.IdleTimeout <= 0 && cfg.ReadTimeout <= 0 && cfg.WriteTimeout <= 0 {
+		return
+	}
+	now := time.Now().UnixNano()
+	expired := func(since int64, limit time.Duration) bool {
+		return limit > 0 && since > 0 && now-since > int64(limit)
+	}
+
+	l.mu.Lock()
+	conns := make([]*eventConn, 0, len(l.conns))
+	for _, c := range l.conns {
+		conns = append(conns, c)
+	}
+	l.mu.Unlock()
+
+	for _, c := range conns {
+		readSince, writeSince := c.readSince.Load(), c.writeSince.Load()
+		switch {
+		case expired(readSince, cfg.ReadTimeout), expired(writeSince, cfg.WriteTimeout):
+			c.Close()
+		case readSince == 0 && writeSince == 0 && expired(c.active.Load(), cfg.IdleTimeout):
+			c.Close()
+		}
+	}
+}
+
+// arm 处理完后重新关注连接的事件（EPOLLONESHOT 每次触发后需要重新设置）
+func (l *eventLoop) arm(c *eventConn, events uint32) {
+	ev := syscall.EpollEvent{Events: events | syscall.EPOLLRDHUP | syscall.EPOLLONESHOT, Fd: int32(c.fd)}
+	if err := syscall.EpollCtl(l.epfd, syscall.EPOLL_CTL_MOD, c.fd, &ev); err != nil {
+		log.Printf("epoll_ctl error for %s: %v", c.cli.addr, err)
+		l.release(c)
+	}
+}
+
+// serve Worker 处理一个有事件的连接：写出积压的回复，读取并执行所有完整的请求，直到没有数据可读
+func (l *eventLoop) serve(c *eventConn) {
+	c.serving.Lock()
+	defer c.serving.Unlock()
+	for {
+		// 1. 先写出积压的回复；写不完时等待可写，暂不读取新请求
+		done, err := c.flush()
+		if err != nil {
+			l.release(c)
+			return
+		}
+		if !done {
+			l.arm(c, syscall.EPOLLOUT)
+			return
+		}
+		if c.closing {
+			l.release(c)
+			return
+		}
+
+		// 2. 读取数据：没有数据时归还空缓冲，等待下一次可读
+		n, err := c.fill()
+		if err == syscall.EAGAIN {
+			c.releaseBuffers(false)
+			l.arm(c, syscall.EPOLLIN)
+			return
+		}
+		if err != nil || n == 0 {
+			// 客户端断开、连接被 shutdown 或读错误：写完之前的回复后释放
+			if err != nil {
+				log.Printf("Read error from %s: %v", c.cli.addr, err)
+			}
+			c.closing = true
+			continue
+		}
+
+		// 3. 执行读缓冲中所有完整的请求，连接转交给 Goroutine 后不再处理
+		if l.process(c) {
+			return
+		}
+	}
+}
+
+// process 执行读缓冲中所有完整的请求，回复追加到写缓冲；返回 true 表示连接已转交给 Goroutine
+func (l *eventLoop) process(c *eventConn) bool {
+	s, cli := l.s, c.cli
+	buf := *c.in
+	off := 0
+	for {
+		// 与 awaitRequest 一致：服务关闭或连接被 KILL 后不再处理新请求
+		if s.closing.Load() || cli.killed.Load() {
+			c.closing = true
+			break
+		}
+
+		// 1. 拆包：不足一个完整请求时等待更多数据，超过 max_frame_size 时断开
+		if len(buf)-off < 4 {
+			break
+		}
+		length := binary.BigEndian.Uint32(buf[off:])
+		if int64(length) > int64(s.cfg.MaxFrameSize) {
+			log.Printf("Read error from %s: %v", cli.addr, ErrFrameTooLarge)
+			c.closing = true
+			break
+		}
+		if uint64(len(buf)-off-4) < uint64(length) {
+			break
+		}
+		request := string(buf[off+4 : off+4+int(length)])
+		fields := strings.Fields(request)
+		if len(fields) > 0 {
+			cli.touch(fields[0])
+		}
+		denied := s.authorize(cli, fields)
+
+		// 2. 会长时间占用连接的命令：连同之后的数据一起转交给 Goroutine 重新处理
+		if denied == nil && len(fields) > 0 && (takesOverConn(fields[0]) || blocksConn(fields)) {
+			l.detach(c, buf[off:])
+			return true
+		}
+
+		// 3. 执行命令，回复打包到写缓冲
+		off += 4 + int(length)
+		if c.out == nil {
+			c.out = getBuffer()
+		}
+		*c.out = appendFrame(*c.out, s.textReply(cli, request, fields, denied))
+	}
+
+	// 4. 未处理完的数据移到缓冲开头，记录开始收到不完整请求的时间
+	rest := copy(buf, buf[off:])
+	*c.in = buf[:rest]
+	now := time.Now().UnixNano()
+	if rest == 0 {
+		c.readSince.Store(0)
+	} else if off > 0 || c.readSince.Load() == 0 {
+		c.readSince.Store(now)
+	}
+	c.active.Store(now)
+	return false
+}
+
+// release 关闭 fd 并注销连接
+func (l *eventLoop) release(c *eventConn) {
+	fd := c.fd
+	c.mu.Lock()
+	syscall.EpollCtl(l.epfd, syscall.EPOLL_CTL_DEL, fd, nil)
+	syscall.Close(fd)
+	c.fd = -1
+	c.mu.Unlock()
+	// 从 epoll 移除后再删除登记，poll 看到连接数为 0 时才会关闭 epoll
+	l.mu.Lock()
+	delete(l.conns, fd)
+	l.mu.Unlock()
+
+	c.releaseBuffers(true)
+	l.s.unregister(c.cli)
+	log.Printf("Client %s disconnected", c.cli.addr)
+}
+
+// detach 把连接从事件循环转交给独立 Goroutine：fd 包装为 net.Conn，
+// 先写出已攒下的回复，再从 pending（尚未处理的请求）开始按 serveText 继续处理
+func (l *eventLoop) detach(c *eventConn, pending []byte) {
+	fd := c.fd
+	rest := append([]byte(nil), pending...)
+	var out []byte
+	if c.out != nil {
+		out = append(out, *c.out...)
+	}
+	c.releaseBuffers(true)
+
+	// 1. 移出 epoll，复制 fd 为 net.Conn 后关闭原 fd
+	syscall.EpollCtl(l.epfd, syscall.EPOLL_CTL_DEL, fd, nil)
+	f := os.NewFile(uintptr(fd), "")
+	nc, err := net.FileConn(f)
+	c.mu.Lock()
+	f.Close()
+	c.fd = -1
+	c.nc = nc
+	shut := c.shut
+	c.mu.Unlock()
+	l.mu.Lock()
+	delete(l.conns, fd)
+	l.mu.Unlock()
+
+	if err != nil {
+		log.Printf("Detach %s from event loop failed: %v", c.cli.addr, err)
+		l.s.unregister(c.cli)
+		return
+	}
+	if shut {
+		// 转交前已被关闭
+		nc.Close()
+	}
+
+	// 2. 独立 Goroutine 继续处理
+	go func() {
+		defer l.s.unregister(c.cli)
+		if len(out) > 0 {
+			if _, err := c.cli.Write(out); err != nil {
+				log.Printf("Write error: %v", err)
+				return
+			}
+		}
+		l.s.serveText(c.cli, bufio.NewReader(io.MultiReader(bytes.NewReader(rest), c.cli)))
+	}()
+}
+
+// blocksConn XREAD / XREADGROUP 带 BLOCK 时可能长时间等待，不能占用 Worker
+func blocksConn(fields []string) bool {
+	if !strings.EqualFold(fields[0], "XREAD") && !strings.EqualFold(fields[0], "XREADGROUP") {
+		return false
+	}
+	for _, arg := range fields[1:] {
+		if strings.EqualFold(arg, "STREAMS") {
+			return false
+		}
+		if strings.EqualFold(arg, "BLOCK") {
+			return true
+		}
+	}
+	return false
+}
+
+// appendFrame 把一条消息按长度前缀格式追加到 buf，与 Encode 的格式相同但不额外分配
+func appendFrame(buf []byte, msg string) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(msg)))
+	return append(buf, msg...)
+}
@@ -0,0 +1,218 @@
+//go:build linux
+
+package protocol
+
+import (
+	"Flux-KV/internal/config"
+	"Flux-KV/internal/core"
+	"Flux-KV/pkg/wire"
+	"context"
+	"fmt"
+	"net"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestServer_EventLoop 事件循环模式：流水线与拆包、转交给 Goroutine 的推送命令、CLIENT KILL 与优雅关闭
+func TestServer_EventLoop(t *testing.T) {
+	cfg := &config.Config{TCP: config.TCPConfig{EventLoop: true, EventLoopWorkers: 2, ReadTimeout: time.Second}}
+	db, _ := core.NewMemDB(cfg)
+	addr := "localhost:9099"
+	server := NewServer(addr, db, cfg)
+	stopped := make(chan error, 1)
+	go func() { stopped <- server.Start() }()
+	time.Sleep(100 * time.Millisecond)
+
+	dial := func() net.Conn {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			t.Fatalf("Client failed to connect: %v", err)
+		}
+		conn.SetDeadline(time.Now().Add(2 * time.Second))
+		return conn
+	}
+	expect := func(conn net.Conn, expected ...string) {
+		t.Helper()
+		for _, want := range expected {
+			if resp, err := Decode(conn); err != nil || resp != want {
+				t.Fatalf("expected %q, got %q (%v)", want, resp, err)
+			}
+		}
+	}
+
+	// 1. 一次写入多个请求，最后一个请求拆成两次发送
+	conn := dial()
+	defer conn.Close()
+	var batch []byte
+	for _, cmd := range []string{"SET a 1", "GET a", "GET b", "SET b 2"} {
+		frame, _ := Encode(cmd)
+		batch = append(batch, frame...)
+	}
+	conn.Write(batch[:len(batch)-3])
+	time.Sleep(20 * time.Millisecond)
+	conn.Write(batch[len(batch)-3:])
+	expect(conn, "OK", "1", "(nil)", "OK")
+
+	// 2. 超过读缓冲初始大小的请求
+	big := strings.Repeat("x", 3*bufferSize)
+	writeMessage(conn, "SET big "+big)
+	writeMessage(conn, "GET big")
+	expect(conn, "OK", big)
+
+	// 3. MONITOR 转交给独立 Goroutine，之后仍能收到其他连接的命令
+	mon := dial()
+	defer mon.Close()
+	writeMessage(mon, "MONITOR user:*")
+	expect(mon, "OK")
+	writeMessage(conn, "SET user:1 naato")
+	expect(conn, "OK")
+	if event, err := Decode(mon); err != nil || !strings.Contains(event, `"set" "user:1" "naato"`) {
+		t.Fatalf("unexpected monitor event: %q (%v)", event, err)
+	}
+
+	// 4. HELLO 之前流水线中的请求先回复，之后按 v2 协议继续处理
+	v2 := dial()
+	defer v2.Close()
+	batch = batch[:0]
+	for _, cmd := range []string{"GET a", "HELLO 2"} {
+		frame, _ := Encode(cmd)
+		batch = append(batch, frame...)
+	}
+	batch = append(batch, wire.EncodeCommand("GET", "b")...)
+	v2.Write(batch)
+	expect(v2, "1", "OK version=2")
+	if got, err := wire.ReadReply(v2); err != nil || got.Str != "2" {
+		t.Fatalf("v2 GET b: %+v (%v)", got, err)
+	}
+
+	// 5. CLIENT KILL 关闭事件循环中的空闲连接
+	idle := dial()
+	defer idle.Close()
+	writeMessage(idle, "CLIENT ID")
+	id, err := Decode(idle)
+	if err != nil {
+		t.Fatalf("CLIENT ID: %v", err)
+	}
+	writeMessage(conn, "CLIENT KILL ID "+id)
+	expect(conn, "1")
+	if _, err := Decode(idle); err == nil {
+		t.Fatal("killed connection should be closed")
+	}
+
+	// 6. Shutdown 关闭所有连接，Start 返回 ErrServerClosed
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if err := <-stopped; err != ErrServerClosed {
+		t.Fatalf("Start returned %v", err)
+	}
+	if _, err := Decode(conn); err == nil {
+		t.Fatal("connection should be closed after Shutdown")
+	}
+}
+
+// startBenchServer 启动基准测试用的服务，eventLoop 选择事件循环或每连接一个 Goroutine
+func startBenchServer(b *testing.B, addr string, eventLoop bool) *Server {
+	cfg := &config.Config{TCP: config.TCPConfig{EventLoop: eventLoop}}
+	db, _ := core.NewMemDB(cfg)
+	server := NewServer(addr, db, cfg)
+	go server.Start()
+	time.Sleep(100 * time.Millisecond)
+	b.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	})
+	return server
+}
+
+// roundTrip 发送一条请求并等待回复
+func roundTrip(conn net.Conn, cmd string) error {
+	if err := writeMessage(conn, cmd); err != nil {
+		return err
+	}
+	_, err := Decode(conn)
+	return err
+}
+
+// BenchmarkServer_IdleConns 对比两种模式下大量空闲连接占用的内存
+// bytes/conn 为建立连接前后堆与 Goroutine 栈的增量均摊到每个连接（包含同进程内客户端连接的开销，两种模式相同）
+// 计时部分是在这些空闲连接之间轮流发送 GET 的延迟
+func BenchmarkServer_IdleConns(b *testing.B) {
+	const idleConns = 2000
+	for i, mode := range []string{"goroutine", "eventloop"} {
+		b.Run(mode, func(b *testing.B) {
+			addr := fmt.Sprintf("localhost:%d", 9110+i)
+			startBenchServer(b, addr, mode == "eventloop")
+
+			var before, after runtime.MemStats
+			runtime.GC()
+			runtime.ReadMemStats(&before)
+
+			// 每个连接执行一次请求，确保服务端已接受并处理过该连接
+			conns := make([]net.Conn, 0, idleConns)
+			defer func() {
+				for _, conn := range conns {
+					conn.Close()
+				}
+			}()
+			for j := 0; j < idleConns; j++ {
+				conn, err := net.Dial("tcp", addr)
+				if err != nil {
+					b.Fatalf("Dial failed: %v", err)
+				}
+				conns = append(conns, conn)
+				if err := roundTrip(conn, "GET k"); err != nil {
+					b.Fatalf("GET failed: %v", err)
+				}
+			}
+
+			runtime.GC()
+			runtime.ReadMemStats(&after)
+			used := int64(after.HeapInuse+after.StackInuse) - int64(before.HeapInuse+before.StackInuse)
+			goroutines := runtime.NumGoroutine()
+
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				if err := roundTrip(conns[j%idleConns], "GET k"); err != nil {
+					b.Fatalf("GET failed: %v", err)
+				}
+			}
+			// ResetTimer 会清空自定义指标，计时结束后再上报
+			b.ReportMetric(float64(used)/idleConns, "bytes/conn")
+			b.ReportMetric(float64(goroutines), "goroutines")
+		})
+	}
+}
+
+// BenchmarkServer_Throughput 对比两种模式下多个活跃连接并发 SET / GET 的吞吐
+func BenchmarkServer_Throughput(b *testing.B) {
+	for i, mode := range []string{"goroutine", "eventloop"} {
+		b.Run(mode, func(b *testing.B) {
+			addr := fmt.Sprintf("localhost:%d", 9120+i)
+			startBenchServer(b, addr, mode == "eventloop")
+
+			b.SetParallelism(4)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				conn, err := net.Dial("tcp", addr)
+				if err != nil {
+					b.Errorf("Dial failed: %v", err)
+					return
+				}
+				defer conn.Close()
+				cmds := []string{"SET bench value", "GET bench"}
+				for n := 0; pb.Next(); n++ {
+					if err := roundTrip(conn, cmds[n%2]); err != nil {
+						b.Errorf("Round trip failed: %v", err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package protocol
+
+import (
+	"errors"
+	"net"
+)
+
+// serveEventLoop 事件循环依赖 epoll，仅 Linux 支持
+func (s *Server) serveEventLoop(listener net.Listener, reject func(net.Conn)) error {
+	listener.Close()
+	return errors.New("protocol: event loop is only supported on linux")
+}
@@ -0,0 +1,205 @@
+package protocol
+
+import (
+	"Flux-KV/pkg/wire"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+)
+
+// v2 二进制帧的格式与编解码见 pkg/wire，客户端库 pkg/tcpclient 共用同一份实现
+
+// ProtocolVersion v2 帧的版本号，用于 HELLO 握手
+const ProtocolVersion = wire.ProtocolVersion
+
+// MaxFrameSize 请求帧默认的最大字节数，服务端可通过 tcp.max_frame_size 调整
+const MaxFrameSize = wire.MaxFrameSize
+
+// ErrFrameTooLarge 帧长度超过限制
+var ErrFrameTooLarge = wire.ErrFrameTooLarge
+
+// v2MaxInflight 每个连接同时执行的带 ID 请求数上限，超过后暂停读取新请求
+const v2MaxInflight = 128
+
+// errConnClosed 写协程已退出，连接不可再写
+var errConnClosed = errors.New("connection closed")
+
+// v2Conn 握手成功后的 v2 连接
+// 所有回复都交给写协程，连续到达的回复合并成一次写出
+type v2Conn struct {
+	cli      *clientConn
+	r        *bufio.Reader
+	out      chan []byte   // 待发送的帧
+	quit     chan struct{} // 通知写协程发送完剩余的帧后退出
+	done     chan struct{} // 写协程退出时关闭
+	inflight sync.WaitGroup
+	sem      chan struct{} // 限制并发执行的带 ID 请求数
+}
+
+// ReadCommand 实现 replyConn
+func (c *v2Conn) ReadCommand() ([]string, error) {
+	req, err := wire.ReadRequest(c.r, c.cli.s.cfg.MaxFrameSize)
+	return req.Args, err
+}
+
+// WriteReply 实现 replyConn
+func (c *v2Conn) WriteReply(r Reply) error {
+	return c.send(wire.EncodeReply(r))
+}
+
+func (c *v2Conn) send(frame []byte) error {
+	select {
+	case c.out <- frame:
+		return nil
+	case <-c.done:
+		return errConnClosed
+	}
+}
+
+// writeLoop 写协程：没有待发送的帧时才刷出缓冲区
+func (c *v2Conn) writeLoop(w *bufio.Writer) {
+	defer close(c.done)
+	write := func(frame []byte) error {
+		if _, err := w.Write(frame); err != nil {
+			return err
+		}
+		if len(c.out) == 0 {
+			return w.Flush()
+		}
+		return nil
+	}
+	for {
+		select {
+		case frame := <-c.out:
+			if err := write(frame); err != nil {
+				log.Printf("Write error: %v", err)
+				return
+			}
+		case <-c.quit:
+			for {
+				select {
+				case frame := <-c.out:
+					if write(frame) != nil {
+						return
+					}
+				default:
+					w.Flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// serveV2 用 v2 帧处理连接上后续的所有命令，r 为握手时使用的读缓冲
+func (s *Server) serveV2(cli *clientConn, r *bufio.Reader) {
+	clientAddr := cli.addr
+	cli.setProto("v2")
+	c := &v2Conn{
+		cli:  cli,
+		r:    r,
+		out:  make(chan []byte, v2MaxInflight),
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+		sem:  make(chan struct{}, v2MaxInflight),
+	}
+	go c.writeLoop(bufio.NewWriter(cli))
+	defer func() {
+		// 等待并发执行的请求回复完，再让写协程发完剩余的帧
+		c.inflight.Wait()
+		close(c.quit)
+		<-c.done
+	}()
+	log.Printf("Client %s switched to protocol v%d", clientAddr, ProtocolVersion)
+
+	for {
+		// 1. 读取一帧请求，超过 max_frame_size 时断开
+		err := cli.awaitRequest(c.r)
+		var req wire.Request
+		if err == nil {
+			req, err = wire.ReadRequest(c.r, s.cfg.MaxFrameSize)
+		}
+		if err != nil {
+			if err != io.EOF && err != ErrServerClosed {
+				log.Printf("Read error: %v", err)
+				// 帧格式错误时告知客户端后断开
+				c.WriteReply(ErrorReply("ERR Protocol error: " + err.Error()))
+			}
+			log.Printf("Client %s disconnected", clientAddr)
+			return
+		}
+		args := req.Args
+		if len(args) == 0 {
+			c.reply(req, ErrorReply("ERR Empty command"))
+			continue
+		}
+		cli.touch(args[0])
+		if err := s.authorize(cli, args); err != nil {
+			c.reply(req, errReply(err))
+			continue
+		}
+
+		// 2. 推送模式命令会接管连接，不能与其他请求并发
+		switch cmd := strings.ToUpper(args[0]); cmd {
+		case "QUIT", "MONITOR", "WATCH", "SUBSCRIBE", "PSUBSCRIBE":
+			if req.Tagged {
+				c.reply(req, ErrorReply(fmt.Sprintf("ERR '%s' cannot be sent with a request ID", cmd)))
+				continue
+			}
+			// 先让并发执行中的请求回复完
+			c.inflight.Wait()
+			if cmd != "QUIT" && !cli.enterPushMode() {
+				return
+			}
+			switch cmd {
+			case "QUIT":
+				c.WriteReply(StatusReply("OK"))
+				return
+			case "MONITOR":
+				pattern := ""
+				if len(args) > 1 {
+					pattern = args[1]
+				}
+				s.replyMonitor(c, clientAddr, pattern, pushEvent)
+				return
+			case "WATCH":
+				s.replyWatch(c, clientAddr, args[1:], pushEvent)
+				return
+			default:
+				if !s.replyPubSub(c, clientAddr, args) {
+					return
+				}
+				continue
+			}
+		}
+
+		// 3. 不带 ID 的请求按顺序执行；带 ID 的请求并发执行，各分片上的命令可以并行
+		if !req.Tagged {
+			if c.reply(req, s.execute(cli, args)) != nil {
+				return
+			}
+			continue
+		}
+		c.sem <- struct{}{}
+		c.inflight.Add(1)
+		go func() {
+			defer func() {
+				<-c.sem
+				c.inflight.Done()
+			}()
+			c.reply(req, s.execute(cli, args))
+		}()
+	}
+}
+
+// reply 按请求是否带 ID 选择回复帧
+func (c *v2Conn) reply(req wire.Request, r Reply) error {
+	if req.Tagged {
+		return c.send(wire.EncodeReplyID(req.ID, r))
+	}
+	return c.WriteReply(r)
+}
@@ -0,0 +1,222 @@
+package protocol
+
+import (
+	"Flux-KV/internal/core"
+	"strconv"
+	"strings"
+)
+
+// geoCommand 处理地理位置命令
+func (s *Server) geoCommand(cmd string, args []string) Reply {
+	switch cmd {
+	case "GEOADD":
+		// GEOADD key longitude latitude member [longitude latitude member ...]
+		if len(args) < 4 || (len(args)-1)%3 != 0 {
+			return ErrorReply("ERR GEOADD requires key and longitude latitude member triples")
+		}
+		points := make([]core.GeoPoint, 0, len(args)/3)
+		for i := 1; i < len(args); i += 3 {
+			lon, err1 := strconv.ParseFloat(args[i], 64)
+			lat, err2 := strconv.ParseFloat(args[i+1], 64)
+			if err1 != nil || err2 != nil {
+				return ErrorReply("ERR longitude and latitude must be numbers")
+			}
+			points = append(points, core.GeoPoint{Member: args[i+2], Longitude: lon, Latitude: lat})
+		}
+		added, err := s.store.GeoAdd(args[0], points)
+		if err != nil {
+			return errReply(err)
+		}
+		return IntegerReply(int64(added))
+	case "GEOPOS":
+		// GEOPOS key member [member ...]，每个成员为 [longitude, latitude]，不存在时为 nil
+		if len(args) < 2 {
+			return ErrorReply("ERR GEOPOS requires key and at least one member")
+		}
+		points, err := s.store.GeoPos(args[0], args[1:]...)
+		if err != nil {
+			return errReply(err)
+		}
+		elems := make([]Reply, len(points))
+		lines := make([]string, len(points))
+		for i, p := range points {
+			elems[i], lines[i] = NilReply, "(nil)"
+			if p != nil {
+				elems[i], lines[i] = coordReply(p.Longitude, p.Latitude), formatCoord(p.Longitude, p.Latitude)
+			}
+		}
+		return withText(ArrayReply(elems...), strings.Join(lines, "\n"))
+	case "GEODIST":
+		// GEODIST key member1 member2 [m|km|mi|ft]
+		if len(args) != 3 && len(args) != 4 {
+			return ErrorReply("ERR GEODIST requires key, member1 and member2")
+		}
+		unit := "m"
+		if len(args) == 4 {
+			unit = args[3]
+		}
+		meters, err := core.GeoUnitToMeters(unit)
+		if err != nil {
+			return errReply(err)
+		}
+		dist, ok, err := s.store.GeoDist(args[0], args[1], args[2])
+		if err != nil {
+			return errReply(err)
+		}
+		if !ok {
+			return NilReply
+		}
+		return BulkReply(strconv.FormatFloat(dist/meters, 'f', 4, 64))
+	case "GEOSEARCH":
+		return s.geoSearch(args)
+	default:
+		return errorf("Unknown command '%s'", cmd)
+	}
+}
+
+// geoSearch 解析并执行
+//
+//	GEOSEARCH key FROMMEMBER member | FROMLONLAT longitude latitude
+//	  BYRADIUS radius unit | BYBOX width height unit
+//	  [ASC|DESC] [COUNT n [ANY]] [WITHCOORD] [WITHDIST] [WITHHASH]
+//
+// 与 Redis 一致，不带 WITH 选项时每个结果为成员名，否则为 [member, distance, hash, [longitude, latitude]] 中选中的项；
+// 文本协议每个结果一行: member [distance] [longitude latitude] [hash]
+func (s *Server) geoSearch(args []string) Reply {
+	if len(args) < 1 {
+		return ErrorReply("ERR GEOSEARCH requires key")
+	}
+	key := args[0]
+	var q core.GeoSearchQuery
+	unit := 1.0
+	hasFrom, hasBy := false, false
+	withCoord, withDist, withHash := false, false, false
+
+	// 1. 解析选项
+	for i := 1; i < len(args); i++ {
+		opt := strings.ToUpper(args[i])
+		// need 检查 opt 之后是否还有 n 个参数
+		need := func(n int) bool { return i+n < len(args) }
+		switch opt {
+		case "FROMMEMBER":
+			if !need(1) {
+				return ErrorReply("ERR FROMMEMBER requires member")
+			}
+			q.Member, hasFrom = args[i+1], true
+			i++
+		case "FROMLONLAT":
+			if !need(2) {
+				return ErrorReply("ERR FROMLONLAT requires longitude and latitude")
+			}
+			lon, err1 := strconv.ParseFloat(args[i+1], 64)
+			lat, err2 := strconv.ParseFloat(args[i+2], 64)
+			if err1 != nil || err2 != nil {
+				return ErrorReply("ERR longitude and latitude must be numbers")
+			}
+			q.Longitude, q.Latitude, hasFrom = lon, lat, true
+			i += 2
+		case "BYRADIUS":
+			if !need(2) {
+				return ErrorReply("ERR BYRADIUS requires radius and unit")
+			}
+			r, err := strconv.ParseFloat(args[i+1], 64)
+			if err != nil || r <= 0 {
+				return ErrorReply("ERR radius must be a positive number")
+			}
+			if unit, err = core.GeoUnitToMeters(args[i+2]); err != nil {
+				return errReply(err)
+			}
+			q.Radius, hasBy = r*unit, true
+			i += 2
+		case "BYBOX":
+			if !need(3) {
+				return ErrorReply("ERR BYBOX requires width, height and unit")
+			}
+			w, err1 := strconv.ParseFloat(args[i+1], 64)
+			h, err2 := strconv.ParseFloat(args[i+2], 64)
+			if err1 != nil || err2 != nil || w <= 0 || h <= 0 {
+				return ErrorReply("ERR width and height must be positive numbers")
+			}
+			var err error
+			if unit, err = core.GeoUnitToMeters(args[i+3]); err != nil {
+				return errReply(err)
+			}
+			q.Width, q.Height, hasBy = w*unit, h*unit, true
+			i += 3
+		case "ASC":
+			q.Sort = core.GeoSortAsc
+		case "DESC":
+			q.Sort = core.GeoSortDesc
+		case "COUNT":
+			if !need(1) {
+				return ErrorReply("ERR COUNT requires a number")
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n <= 0 {
+				return ErrorReply("ERR COUNT must be a positive integer")
+			}
+			q.Count = n
+			i++
+			if need(1) && strings.EqualFold(args[i+1], "ANY") {
+				q.Any = true
+				i++
+			}
+		case "WITHCOORD":
+			withCoord = true
+		case "WITHDIST":
+			withDist = true
+		case "WITHHASH":
+			withHash = true
+		default:
+			return errorf("Unknown GEOSEARCH option '%s'", args[i])
+		}
+	}
+	if !hasFrom || !hasBy {
+		return ErrorReply("ERR GEOSEARCH requires FROMMEMBER or FROMLONLAT and BYRADIUS or BYBOX")
+	}
+
+	// 2. 执行并格式化
+	results, err := s.store.GeoSearch(key, q)
+	if err != nil {
+		return errReply(err)
+	}
+	if len(results) == 0 {
+		return ArrayReply()
+	}
+	elems := make([]Reply, len(results))
+	lines := make([]string, len(results))
+	for i, r := range results {
+		line := r.Member
+		fields := []Reply{BulkReply(r.Member)}
+		if withDist {
+			dist := strconv.FormatFloat(r.Dist/unit, 'f', 4, 64)
+			line += " " + dist
+			fields = append(fields, BulkReply(dist))
+		}
+		if withHash {
+			fields = append(fields, IntegerReply(int64(r.Hash)))
+		}
+		if withCoord {
+			line += " " + formatCoord(r.Longitude, r.Latitude)
+			fields = append(fields, coordReply(r.Longitude, r.Latitude))
+		}
+		if withHash {
+			line += " " + strconv.FormatUint(r.Hash, 10)
+		}
+		elems[i], lines[i] = fields[0], line
+		if len(fields) > 1 {
+			elems[i] = ArrayReply(fields...)
+		}
+	}
+	return withText(ArrayReply(elems...), strings.Join(lines, "\n"))
+}
+
+// coordReply 坐标回复 [longitude, latitude]，与 Redis 一样为字符串
+func coordReply(lon, lat float64) Reply {
+	return ArrayReply(BulkReply(strconv.FormatFloat(lon, 'f', 6, 64)), BulkReply(strconv.FormatFloat(lat, 'f', 6, 64)))
+}
+
+// formatCoord 文本协议中的坐标 "longitude latitude"
+func formatCoord(lon, lat float64) string {
+	return strconv.FormatFloat(lon, 'f', 6, 64) + " " + strconv.FormatFloat(lat, 'f', 6, 64)
+}
@@ -0,0 +1,172 @@
+package protocol
+
+import (
+	"Flux-KV/internal/core"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonCommand 处理 JSON 文档命令和 FIND 查询
+// 命令按空白切分，JSON 值里不要带空格（字符串中的连续空格会被合并）
+func (s *Server) jsonCommand(cmd string, args []string) Reply {
+	switch cmd {
+	case "JSON.SET":
+		// JSON.SET key path value [NX|XX]
+		if len(args) < 3 {
+			return ErrorReply("ERR JSON.SET requires key, path and value")
+		}
+		var nx, xx bool
+		if n := len(args); n > 3 {
+			switch strings.ToUpper(args[n-1]) {
+			case "NX":
+				nx, args = true, args[:n-1]
+			case "XX":
+				xx, args = true, args[:n-1]
+			}
+		}
+		ok, err := s.store.JSONSet(args[0], args[1], strings.Join(args[2:], " "), nx, xx)
+		if err != nil {
+			return errReply(err)
+		}
+		if !ok {
+			return NilReply
+		}
+		return StatusReply("OK")
+	case "JSON.GET":
+		// JSON.GET key [path ...]
+		if len(args) < 1 {
+			return ErrorReply("ERR JSON.GET requires key")
+		}
+		v, found, err := s.store.JSONGet(args[0], args[1:]...)
+		if err != nil {
+			return errReply(err)
+		}
+		if !found {
+			return NilReply
+		}
+		return BulkReply(v)
+	case "JSON.DEL":
+		// JSON.DEL key [path]
+		if len(args) < 1 || len(args) > 2 {
+			return ErrorReply("ERR JSON.DEL requires key and optional path")
+		}
+		path := "$"
+		if len(args) == 2 {
+			path = args[1]
+		}
+		n, err := s.store.JSONDel(args[0], path)
+		if err != nil {
+			return errReply(err)
+		}
+		return IntegerReply(int64(n))
+	case "JSON.ARRAPPEND":
+		// JSON.ARRAPPEND key path value [value ...]
+		if len(args) < 3 {
+			return ErrorReply("ERR JSON.ARRAPPEND requires key, path and value")
+		}
+		n, err := s.store.JSONArrAppend(args[0], args[1], args[2:]...)
+		if err != nil {
+			return errReply(err)
+		}
+		return IntegerReply(int64(n))
+	case "JSON.NUMINCRBY":
+		// JSON.NUMINCRBY key path delta
+		if len(args) != 3 {
+			return ErrorReply("ERR JSON.NUMINCRBY requires key, path and delta")
+		}
+		v, err := s.store.JSONNumIncrBy(args[0], args[1], args[2])
+		if err != nil {
+			return errReply(err)
+		}
+		return BulkReply(v)
+	case "JSON.INDEX":
+		return s.jsonIndex(args)
+	case "FIND":
+		return s.jsonFind(args)
+	}
+	return errorf("Unknown command '%s'", cmd)
+}
+
+// jsonIndex JSON.INDEX CREATE namespace field TAG|NUMERIC / JSON.INDEX DROP namespace field / JSON.INDEX LIST [namespace]
+func (s *Server) jsonIndex(args []string) Reply {
+	if len(args) < 1 {
+		return ErrorReply("ERR JSON.INDEX requires CREATE, DROP or LIST")
+	}
+	switch strings.ToUpper(args[0]) {
+	case "CREATE":
+		if len(args) != 4 {
+			return ErrorReply("ERR JSON.INDEX CREATE requires namespace, field and TAG|NUMERIC")
+		}
+		if err := s.store.JSONCreateIndex(args[1], args[2], args[3]); err != nil {
+			return errReply(err)
+		}
+		return StatusReply("OK")
+	case "DROP":
+		if len(args) != 3 {
+			return ErrorReply("ERR JSON.INDEX DROP requires namespace and field")
+		}
+		return IntegerReply(boolInt(s.store.JSONDropIndex(args[1], args[2])))
+	case "LIST":
+		namespace := ""
+		if len(args) > 1 {
+			namespace = args[1]
+		}
+		infos := s.store.JSONIndexes(namespace)
+		// 每个索引为 [namespace, field, type, entries]
+		if len(infos) == 0 {
+			return ArrayReply()
+		}
+		elems := make([]Reply, len(infos))
+		lines := make([]string, len(infos))
+		for i, info := range infos {
+			elems[i] = ArrayReply(BulkReply(info.Namespace), BulkReply(info.Field), BulkReply(info.Type), IntegerReply(int64(info.Entries)))
+			lines[i] = fmt.Sprintf("%d) %s %s %s entries=%d", i+1, info.Namespace, info.Field, info.Type, info.Entries)
+		}
+		return withText(ArrayReply(elems...), strings.Join(lines, "\n"))
+	default:
+		return ErrorReply("ERR JSON.INDEX requires CREATE, DROP or LIST")
+	}
+}
+
+// jsonFind FIND namespace WHERE field op value [LIMIT n]
+// op 为 = < <= > >=，或 BETWEEN min max
+func (s *Server) jsonFind(args []string) Reply {
+	if len(args) < 5 || !strings.EqualFold(args[1], "WHERE") {
+		return ErrorReply("ERR FIND requires namespace WHERE field op value")
+	}
+	q := core.JSONQuery{Field: args[2], Op: strings.ToLower(args[3]), Value: args[4]}
+	rest := args[5:]
+	if q.Op == "between" {
+		if len(rest) < 1 {
+			return ErrorReply("ERR BETWEEN requires min and max")
+		}
+		q.Max, rest = rest[0], rest[1:]
+	}
+	if len(rest) > 0 {
+		if len(rest) != 2 || !strings.EqualFold(rest[0], "LIMIT") {
+			return ErrorReply("ERR syntax error, expected LIMIT n")
+		}
+		n, err := strconv.Atoi(rest[1])
+		if err != nil || n < 0 {
+			return ErrorReply("ERR LIMIT must be a non-negative integer")
+		}
+		q.Limit = n
+	}
+
+	matches, err := s.store.JSONFind(args[0], q)
+	if err != nil {
+		return errReply(err)
+	}
+	// 每个结果为 [key, document]
+	if len(matches) == 0 {
+		return ArrayReply()
+	}
+	elems := make([]Reply, len(matches))
+	lines := make([]string, len(matches))
+	for i, m := range matches {
+		elems[i] = ArrayReply(BulkReply(m.Key), BulkReply(m.Doc))
+		lines[i] = fmt.Sprintf("%d) %s %s", i+1, m.Key, m.Doc)
+	}
+	return withText(ArrayReply(elems...), strings.Join(lines, "\n"))
+}
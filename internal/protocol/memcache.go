@@ -0,0 +1,331 @@
+package protocol
+
+import (
+	"Flux-KV/internal/core"
+	"Flux-KV/pkg/acl"
+	"bufio"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// memcached 文本协议：get / gets / set / add / replace / cas / delete / incr / decr / touch
+// 与其他协议共享同一个 MemDB，CAS 值为 Key 的版本号，其他协议写入后 CAS 同样会变化
+
+const (
+	mcMaxLine       = 2048              // 命令行的最大长度
+	mcMaxKeyLen     = 250               // Key 的最大长度
+	mcRelativeLimit = 60 * 60 * 24 * 30 // exptime 不超过 30 天时为相对秒数，否则为 Unix 时间戳
+	mcVersion       = "1.6.0"
+)
+
+// mcStoreModes 存储命令对应的写入条件
+var mcStoreModes = map[string]core.StoreMode{
+	"set": core.StoreSet, "add": core.StoreAdd, "replace": core.StoreReplace, "cas": core.StoreCAS,
+}
+
+// errMemcacheLine 命令行过长，回复后关闭连接
+var errMemcacheLine = errors.New("line too long")
+
+// StartMemcache 在 addr 上启动 memcached 文本协议监听，与 Start 的文本协议共享同一个 MemDB 和连接限制
+// Shutdown 之后返回 ErrServerClosed
+func (s *Server) StartMemcache(addr string) error {
+	// 1. 启动TCP监听
+	listener, err := s.listen(addr)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("🚀 Memcached Server listening on %s", addr)
+
+	// 2. 接受连接，每个连接独立 Goroutine 处理
+	return s.serve(listener, s.handleMemcacheConnection, func(conn net.Conn) {
+		conn.Write([]byte("SERVER_ERROR " + errMaxClients.Error() + "\r\n"))
+	})
+}
+
+func (s *Server) handleMemcacheConnection(cli *clientConn) {
+	r := bufio.NewReader(cli)
+	w := bufio.NewWriter(cli)
+	defer w.Flush()
+	cli.setProto("memcache")
+	log.Printf("New memcached connection from: %s", cli.addr)
+
+	for {
+		// 1. 读取命令行
+		err := cli.awaitRequest(r)
+		var line string
+		if err == nil {
+			line, err = readMemcacheLine(r)
+		}
+		if err != nil {
+			if err == errMemcacheLine {
+				w.WriteString("CLIENT_ERROR " + err.Error() + "\r\n")
+			} else if err != io.EOF && err != ErrServerClosed {
+				log.Printf("Read error from %s: %v", cli.addr, err)
+			}
+			log.Printf("Memcached client %s disconnected", cli.addr)
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			w.WriteString("ERROR\r\n")
+			continue
+		}
+		cli.touch(fields[0])
+
+		// 2. 执行命令，数据块格式错误或 quit 时关闭连接
+		if !s.memcacheCommand(cli, r, w, fields) {
+			return
+		}
+
+		// 3. 流水线中后面还有命令时先不刷出，攒批写回
+		if r.Buffered() == 0 {
+			if err := w.Flush(); err != nil {
+				log.Printf("Write error: %v", err)
+				return
+			}
+		}
+	}
+}
+
+// readMemcacheLine 读取一行，兼容只以 \n 结尾的客户端
+func readMemcacheLine(r *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		chunk, err := r.ReadSlice('\n')
+		line = append(line, chunk...)
+		if len(line) > mcMaxLine {
+			return "", errMemcacheLine
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(strings.TrimSuffix(string(line), "\n"), "\r"), nil
+	}
+}
+
+// memcacheCommand 执行一条命令并写回复，返回 false 表示关闭连接
+func (s *Server) memcacheCommand(cli *clientConn, r *bufio.Reader, w *bufio.Writer, fields []string) bool {
+	cmd := strings.ToLower(fields[0])
+	args := fields[1:]
+
+	// noreply 时不写回复（命令行格式错误除外）
+	noreply := false
+	if n := len(args); n > 0 && args[n-1] == "noreply" {
+		switch cmd {
+		case "set", "add", "replace", "cas", "delete", "incr", "decr", "touch":
+			noreply = true
+			args = args[:n-1]
+		}
+	}
+	reply := func(msg string) {
+		if !noreply {
+			w.WriteString(msg + "\r\n")
+		}
+	}
+
+	switch cmd {
+	case "get", "gets":
+		if len(args) == 0 || !validKeys(args) {
+			w.WriteString("CLIENT_ERROR bad command line format\r\n")
+			return true
+		}
+		if err := s.memcacheAuthorize(cli, acl.Read, args...); err != nil {
+			w.WriteString("CLIENT_ERROR " + err.Error() + "\r\n")
+			return true
+		}
+		defer s.trace(cli.addr, fields)()
+		for _, key := range args {
+			// 其他类型的值按不存在处理
+			item, found, _ := s.store.CacheGet(key)
+			if !found {
+				continue
+			}
+			w.WriteString("VALUE " + key + " " + strconv.FormatUint(uint64(item.Flags), 10) + " " + strconv.Itoa(len(item.Value)))
+			if cmd == "gets" {
+				w.WriteString(" " + strconv.FormatUint(item.CAS, 10))
+			}
+			w.WriteString("\r\n" + item.Value + "\r\n")
+		}
+		w.WriteString("END\r\n")
+		return true
+
+	case "set", "add", "replace", "cas":
+		// <cmd> <key> <flags> <exptime> <bytes> [<cas unique>] [noreply]，之后是数据块
+		want := 4
+		if cmd == "cas" {
+			want = 5
+		}
+		if len(args) != want {
+			w.WriteString("ERROR\r\n")
+			return true
+		}
+		flags, errFlags := strconv.ParseUint(args[1], 10, 32)
+		exptime, errExp := strconv.ParseInt(args[2], 10, 64)
+		size, errSize := strconv.Atoi(args[3])
+		var cas uint64
+		var errCAS error
+		if cmd == "cas" {
+			cas, errCAS = strconv.ParseUint(args[4], 10, 64)
+		}
+		if !validKeys(args[:1]) || errFlags != nil || errExp != nil || errSize != nil || size < 0 || errCAS != nil {
+			w.WriteString("CLIENT_ERROR bad command line format\r\n")
+			return false
+		}
+
+		// 数据块过大时丢弃，连接可以继续使用
+		if limit := s.cfg.MaxFrameSize; limit > 0 && size > limit {
+			if _, err := r.Discard(size + 2); err != nil {
+				return false
+			}
+			reply("SERVER_ERROR object too large for cache")
+			return true
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return false
+		}
+		if data[size] != '\r' || data[size+1] != '\n' {
+			w.WriteString("CLIENT_ERROR bad data chunk\r\n")
+			return false
+		}
+		if err := s.memcacheAuthorize(cli, acl.Write, args[0]); err != nil {
+			reply("CLIENT_ERROR " + err.Error())
+			return true
+		}
+
+		value := string(data[:size])
+		defer s.trace(cli.addr, []string{cmd, args[0], value})()
+		_, err := s.store.CacheStore(mcStoreModes[cmd], args[0], value, uint32(flags), memcacheExpireAt(exptime), cas)
+		switch {
+		case err == nil:
+			reply("STORED")
+		case errors.Is(err, core.ErrNotStored):
+			reply("NOT_STORED")
+		case errors.Is(err, core.ErrCASMismatch):
+			reply("EXISTS")
+		case errors.Is(err, core.ErrCacheMiss):
+			reply("NOT_FOUND")
+		default:
+			reply("CLIENT_ERROR " + err.Error())
+		}
+		return true
+
+	case "delete":
+		if len(args) != 1 || !validKeys(args) {
+			w.WriteString("CLIENT_ERROR bad command line format\r\n")
+			return true
+		}
+		if err := s.memcacheAuthorize(cli, acl.Write, args[0]); err != nil {
+			reply("CLIENT_ERROR " + err.Error())
+			return true
+		}
+		defer s.trace(cli.addr, fields)()
+		if s.store.CacheDelete(args[0]) {
+			reply("DELETED")
+		} else {
+			reply("NOT_FOUND")
+		}
+		return true
+
+	case "incr", "decr":
+		if len(args) != 2 || !validKeys(args[:1]) {
+			w.WriteString("ERROR\r\n")
+			return true
+		}
+		delta, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			w.WriteString("CLIENT_ERROR invalid numeric delta argument\r\n")
+			return true
+		}
+		if err := s.memcacheAuthorize(cli, acl.Write, args[0]); err != nil {
+			reply("CLIENT_ERROR " + err.Error())
+			return true
+		}
+		defer s.trace(cli.addr, fields)()
+		n, err := s.store.CacheIncr(args[0], delta, cmd == "decr")
+		switch {
+		case err == nil:
+			reply(strconv.FormatUint(n, 10))
+		case errors.Is(err, core.ErrCacheMiss):
+			reply("NOT_FOUND")
+		default:
+			reply("CLIENT_ERROR " + err.Error())
+		}
+		return true
+
+	case "touch":
+		if len(args) != 2 || !validKeys(args[:1]) {
+			w.WriteString("ERROR\r\n")
+			return true
+		}
+		exptime, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			w.WriteString("CLIENT_ERROR invalid exptime argument\r\n")
+			return true
+		}
+		if err := s.memcacheAuthorize(cli, acl.Write, args[0]); err != nil {
+			reply("CLIENT_ERROR " + err.Error())
+			return true
+		}
+		defer s.trace(cli.addr, fields)()
+		if ok, _ := s.store.CacheTouch(args[0], memcacheExpireAt(exptime)); ok {
+			reply("TOUCHED")
+		} else {
+			reply("NOT_FOUND")
+		}
+		return true
+
+	case "version":
+		w.WriteString("VERSION " + mcVersion + "\r\n")
+		return true
+	case "quit":
+		return false
+	}
+	w.WriteString("ERROR\r\n")
+	return true
+}
+
+// memcacheAuthorize memcached 文本协议没有认证命令，开启 ACL 时按 default 用户授权
+func (s *Server) memcacheAuthorize(cli *clientConn, cat acl.Category, keys ...string) error {
+	return s.store.ACL().Check(cli.getUser(), cat, keys...)
+}
+
+// validKeys Key 不能超过 250 字节，也不能包含控制字符
+func validKeys(keys []string) bool {
+	for _, key := range keys {
+		if len(key) > mcMaxKeyLen {
+			return false
+		}
+		for i := 0; i < len(key); i++ {
+			if key[i] < 0x21 || key[i] == 0x7f {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// memcacheExpireAt 把 exptime 转换为过期的纳秒时间戳：0 表示永不过期，负数表示立即过期，
+// 不超过 30 天时为相对秒数，否则为 Unix 时间戳
+func memcacheExpireAt(exptime int64) int64 {
+	now := time.Now()
+	switch {
+	case exptime == 0:
+		return 0
+	case exptime < 0:
+		return now.UnixNano() - 1
+	case exptime <= mcRelativeLimit:
+		return now.Add(time.Duration(exptime) * time.Second).UnixNano()
+	default:
+		return time.Unix(exptime, 0).UnixNano()
+	}
+}
@@ -0,0 +1,167 @@
+package protocol
+
+import (
+	"Flux-KV/pkg/kverrors"
+	"Flux-KV/pkg/wire"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ReplyKind 回复的类型
+type ReplyKind = wire.Kind
+
+const (
+	ReplyStatus  = wire.KindStatus  // 状态，例如 OK
+	ReplyError   = wire.KindError   // 错误，Str 以 Redis 风格的错误码开头，例如 ERR / WRONGTYPE / NOSCRIPT
+	ReplyBulk    = wire.KindBulk    // 二进制安全的字符串
+	ReplyNil     = wire.KindNil     // 空值
+	ReplyInteger = wire.KindInteger // 64 位有符号整数
+	ReplyArray   = wire.KindArray   // 数组，元素可以是任意类型
+	ReplyDouble  = wire.KindDouble  // 浮点数，仅 RESP3 原样传输，其余协议转换为字符串
+	ReplyBool    = wire.KindBool    // 布尔值，仅 RESP3 原样传输，其余协议转换为整数
+	ReplyPush    = wire.KindPush    // 服务端主动推送的数组，例如订阅消息
+)
+
+// Reply 带类型的命令回复，所有协议共用：RESP 与 v2 二进制帧按类型编码，文本协议由 formatReply 生成
+// Text 为文本协议中的格式，为空时 formatReply 按类型生成
+type Reply = wire.Reply
+
+// NilReply 空值回复
+var NilReply = Reply{Kind: ReplyNil}
+
+func StatusReply(s string) Reply { return Reply{Kind: ReplyStatus, Str: s} }
+
+func ErrorReply(msg string) Reply { return Reply{Kind: ReplyError, Str: msg} }
+
+func BulkReply(s string) Reply { return Reply{Kind: ReplyBulk, Str: s} }
+
+func IntegerReply(n int64) Reply { return Reply{Kind: ReplyInteger, Int: n} }
+
+func ArrayReply(elems ...Reply) Reply { return Reply{Kind: ReplyArray, Elems: elems} }
+
+func PushReply(elems ...Reply) Reply { return Reply{Kind: ReplyPush, Elems: elems} }
+
+func boolReply(b bool) Reply {
+	if b {
+		return Reply{Kind: ReplyBool, Int: 1}
+	}
+	return Reply{Kind: ReplyBool}
+}
+
+func wrongArgsReply(cmd string) Reply {
+	return ErrorReply("ERR wrong number of arguments for '" + strings.ToLower(cmd) + "' command")
+}
+
+// errReply 按 kverrors 的错误码加上 TCP 错误前缀，客户端库依赖 NOSCRIPT 回退到 EVAL
+func errReply(err error) Reply {
+	return ErrorReply(kverrors.TCPMessage(err))
+}
+
+// errorf 参数或语法错误，文本协议中显示为 "ERROR: ..."
+func errorf(format string, a ...any) Reply {
+	return ErrorReply("ERR " + fmt.Sprintf(format, a...))
+}
+
+// withText 结构化回复在文本协议中沿用原有的单行或多行格式
+func withText(r Reply, text string) Reply {
+	r.Text = text
+	return r
+}
+
+// unnumbered 文本协议中数组每个元素一行，不带序号
+func unnumbered(r Reply) Reply {
+	if len(r.Elems) == 0 {
+		return r
+	}
+	text := make([]string, len(r.Elems))
+	for i, e := range r.Elems {
+		text[i] = formatReply(e)
+	}
+	return withText(r, strings.Join(text, "\n"))
+}
+
+// doubleReply RESP3 浮点数，RESP2 中为字符串
+func doubleReply(f float64) Reply { return Reply{Kind: ReplyDouble, Float: f} }
+
+// intsReply 整数数组
+func intsReply[T int | int64 | uint32 | uint64](ns []T) Reply {
+	elems := make([]Reply, len(ns))
+	for i, n := range ns {
+		elems[i] = IntegerReply(int64(n))
+	}
+	return ArrayReply(elems...)
+}
+
+// boolsReply 与 Redis 一致，布尔值数组以 1 / 0 表示
+func boolsReply(bs []bool) Reply {
+	elems := make([]Reply, len(bs))
+	for i, b := range bs {
+		elems[i] = IntegerReply(boolInt(b))
+	}
+	return ArrayReply(elems...)
+}
+
+// boolInt 布尔值转为 1 / 0
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// scriptReply 转换脚本返回值：nil / 布尔 / 整数 / 浮点 / 字符串 / 数组
+func scriptReply(v any) Reply {
+	switch x := v.(type) {
+	case nil:
+		return NilReply
+	case bool:
+		return boolReply(x)
+	case int64:
+		return IntegerReply(x)
+	case float64:
+		return doubleReply(x)
+	case string:
+		return BulkReply(x)
+	case []any:
+		elems := make([]Reply, len(x))
+		for i, item := range x {
+			elems[i] = scriptReply(item)
+		}
+		return ArrayReply(elems...)
+	}
+	return BulkReply(fmt.Sprint(v))
+}
+
+// formatReply 把带类型的回复格式化为文本协议的回复，数组每个元素一行，嵌套的数组按层级缩进
+func formatReply(r Reply) string {
+	return formatIndented(r, "")
+}
+
+func formatIndented(r Reply, indent string) string {
+	if r.Text != "" {
+		return r.Text
+	}
+	switch r.Kind {
+	case ReplyError:
+		return "ERROR: " + strings.TrimPrefix(r.Str, "ERR ")
+	case ReplyNil:
+		return "(nil)"
+	case ReplyInteger:
+		return strconv.FormatInt(r.Int, 10)
+	case ReplyBool:
+		return strconv.FormatInt(r.Int, 10)
+	case ReplyDouble:
+		return strconv.FormatFloat(r.Float, 'g', -1, 64)
+	case ReplyArray, ReplyPush:
+		if len(r.Elems) == 0 {
+			return "(empty list)"
+		}
+		lines := make([]string, len(r.Elems))
+		for i, e := range r.Elems {
+			lines[i] = fmt.Sprintf("%s%d) %s", indent, i+1, strings.TrimLeft(formatIndented(e, indent+"   "), " "))
+		}
+		return strings.Join(lines, "\n")
+	}
+	return r.Str
+}
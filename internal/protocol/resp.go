@@ -0,0 +1,416 @@
+package protocol
+
+import (
+	"Flux-KV/pkg/wire"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RESP 协议监听：兼容 redis-cli、go-redis 等 Redis 客户端
+// 请求为 bulk string 数组（也接受 telnet 风格的 inline 命令），回复按类型编码；
+// 默认 RESP2，客户端发送 HELLO 3 后切换到 RESP3。命令与文本协议共用 dispatch 分发
+
+const (
+	respMaxInline    = 64 * 1024   // inline 命令最大长度
+	respMaxMultiBulk = 1024 * 1024 // 单条命令最多的参数个数
+)
+
+// respProtocolError 请求格式错误，回复后关闭连接
+type respProtocolError string
+
+func (e respProtocolError) Error() string {
+	return "Protocol error: " + string(e)
+}
+
+// StartRESP 在 addr 上启动 RESP 监听，与 Start 的文本协议共享同一个 MemDB 和连接限制
+// Shutdown 之后返回 ErrServerClosed
+func (s *Server) StartRESP(addr string) error {
+	// 1. 启动TCP监听
+	listener, err := s.listen(addr)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("🚀 RESP Server listening on %s", addr)
+
+	// 2. 接受连接，每个连接独立 Goroutine 处理
+	return s.serve(listener, s.handleRESPConnection, func(conn net.Conn) {
+		conn.Write([]byte("-ERR " + errMaxClients.Error() + "\r\n"))
+	})
+}
+
+// respConn 一个 RESP 连接的状态
+type respConn struct {
+	cli *clientConn
+	r   *bufio.Reader
+	w   *respWriter
+
+	wmu sync.Mutex // 推送模式下读写两个协程都会写连接
+}
+
+// ReadCommand 实现 replyConn
+func (c *respConn) ReadCommand() ([]string, error) {
+	return readRESPCommand(c.r, c.cli.s.cfg.MaxFrameSize)
+}
+
+// WriteReply 实现 replyConn
+func (c *respConn) WriteReply(r Reply) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	c.w.reply(r)
+	return c.w.Flush()
+}
+
+func (s *Server) handleRESPConnection(cli *clientConn) {
+	c := &respConn{
+		cli: cli,
+		r:   bufio.NewReader(cli),
+		w:   &respWriter{Writer: bufio.NewWriter(cli), proto: 2},
+	}
+	defer c.w.Flush()
+	cli.setProto("resp2")
+	log.Printf("New RESP connection from: %s", cli.addr)
+
+	for {
+		// 1. 读取一条完整的命令，单个参数超过 max_frame_size 时断开
+		err := cli.awaitRequest(c.r)
+		var args []string
+		if err == nil {
+			args, err = readRESPCommand(c.r, s.cfg.MaxFrameSize)
+		}
+		if err != nil {
+			var perr respProtocolError
+			if errors.As(err, &perr) {
+				c.w.error("ERR " + perr.Error())
+			} else if err != io.EOF && err != ErrServerClosed {
+				log.Printf("Read error from %s: %v", cli.addr, err)
+			}
+			log.Printf("RESP client %s disconnected", cli.addr)
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		cli.touch(args[0])
+		if err := s.authorize(cli, args); err != nil {
+			c.w.reply(errReply(err))
+			if c.r.Buffered() == 0 && c.w.Flush() != nil {
+				return
+			}
+			continue
+		}
+
+		// 2. 推送模式命令会接管连接
+		switch strings.ToUpper(args[0]) {
+		case "QUIT":
+			c.w.simple("OK")
+			return
+		case "MONITOR":
+			// 与 Redis 一致，每条命令作为一个 simple string 推送；MONITOR [pattern] 只推送 Key 匹配的命令
+			pattern := ""
+			if len(args) > 1 {
+				pattern = args[1]
+			}
+			if c.w.Flush() == nil && cli.enterPushMode() {
+				s.replyMonitor(c, cli.addr, pattern, StatusReply)
+			}
+			return
+		case "SUBSCRIBE", "PSUBSCRIBE":
+			if c.w.Flush() != nil || !cli.enterPushMode() || !s.replyPubSub(c, cli.addr, args) {
+				return
+			}
+			continue
+		}
+
+		// 3. 执行命令
+		s.respCommand(c, args)
+
+		// 4. 流水线中后面还有命令时先不刷出，攒批写回
+		if c.r.Buffered() == 0 {
+			if err := c.w.Flush(); err != nil {
+				log.Printf("Write error: %v", err)
+				return
+			}
+		}
+	}
+}
+
+// readRESPCommand 读取一条命令：*<n>\r\n 开头为 bulk string 数组，否则按 inline 命令处理
+// 空行返回 nil
+// 单个参数超过 limit 字节时返回协议错误
+func readRESPCommand(r *bufio.Reader, limit int) ([]string, error) {
+	line, err := readRESPLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, nil
+	}
+	if line[0] != '*' {
+		return strings.Fields(line), nil
+	}
+
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n > respMaxMultiBulk {
+		return nil, respProtocolError("invalid multibulk length")
+	}
+	args := make([]string, 0, max(n, 0))
+	for i := 0; i < n; i++ {
+		line, err := readRESPLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 || line[0] != '$' {
+			return nil, respProtocolError(fmt.Sprintf("expected '$', got %q", line))
+		}
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 || size > limit {
+			return nil, respProtocolError("invalid bulk length")
+		}
+		buf, err := wire.ReadFull(r, size+2)
+		if err != nil {
+			return nil, err
+		}
+		if buf[size] != '\r' || buf[size+1] != '\n' {
+			return nil, respProtocolError("bulk string is not terminated by CRLF")
+		}
+		args = append(args, string(buf[:size]))
+	}
+	return args, nil
+}
+
+// readRESPLine 读取一行，去掉结尾的 \r\n
+func readRESPLine(r *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		chunk, err := r.ReadSlice('\n')
+		line = append(line, chunk...)
+		if len(line) > respMaxInline {
+			return "", respProtocolError("too big inline request")
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(strings.TrimSuffix(string(line), "\n"), "\r"), nil
+	}
+}
+
+// respCommand 执行一条命令并写回复
+// 连接相关的命令在这里处理，其余交给 execute
+func (s *Server) respCommand(c *respConn, args []string) {
+	cmd := strings.ToUpper(args[0])
+	w := c.w
+
+	switch cmd {
+	case "HELLO":
+		s.respHello(c, args[1:])
+	case "SELECT":
+		// 只有一个库
+		if len(args) != 2 {
+			w.wrongArgs(cmd)
+		} else if args[1] != "0" {
+			w.error("ERR DB index is out of range")
+		} else {
+			w.simple("OK")
+		}
+	case "COMMAND":
+		// redis-cli 启动时会查询 COMMAND DOCS 做命令提示，这里不提供命令表
+		w.array(0)
+	case "WATCH":
+		// Redis 的 WATCH 是事务命令，与本服务的 Key 变更推送语义不同
+		w.error("ERR WATCH is only available on the native protocol")
+	default:
+		w.reply(s.execute(c.cli, args))
+	}
+}
+
+// respHello HELLO [protover [AUTH username password] [SETNAME clientname]]
+// 协商协议版本，回复服务端信息
+func (s *Server) respHello(c *respConn, args []string) {
+	w := c.w
+	proto := w.proto
+	if len(args) > 0 {
+		v, err := strconv.Atoi(args[0])
+		if err != nil {
+			w.error("ERR Protocol version is not an integer or out of range")
+			return
+		}
+		if v != 2 && v != 3 {
+			w.error("NOPROTO unsupported protocol version")
+			return
+		}
+		proto = v
+		for i := 1; i < len(args); i++ {
+			switch strings.ToUpper(args[i]) {
+			case "AUTH":
+				if i+2 >= len(args) {
+					w.error("ERR Syntax error in HELLO option 'AUTH'")
+					return
+				}
+				// 未开启 ACL 时忽略用户名和密码；认证失败时不切换协议
+				if users := s.store.ACL(); users != nil {
+					if err := users.Authenticate(args[i+1], args[i+2]); err != nil {
+						w.error(err.Error())
+						return
+					}
+					c.cli.setUser(args[i+1])
+				}
+				i += 2
+			case "SETNAME":
+				if i+1 >= len(args) {
+					w.error("ERR Syntax error in HELLO option 'SETNAME'")
+					return
+				}
+				c.cli.setName(args[i+1])
+				i++
+			default:
+				w.error(fmt.Sprintf("ERR Syntax error in HELLO option '%s'", args[i]))
+				return
+			}
+		}
+	}
+
+	// 切换后立刻按新协议回复
+	w.proto = proto
+	c.cli.setProto("resp" + strconv.Itoa(proto))
+	w.mapLen(7)
+	w.bulk("server")
+	w.bulk("flux-kv")
+	w.bulk("version")
+	w.bulk("7.0.0")
+	w.bulk("proto")
+	w.integer(int64(proto))
+	w.bulk("id")
+	w.integer(c.cli.id)
+	w.bulk("mode")
+	w.bulk("standalone")
+	w.bulk("role")
+	w.bulk("master")
+	w.bulk("modules")
+	w.array(0)
+}
+
+// respWriter 按协议版本编码回复，RESP2 下 RESP3 独有的类型降级为等价的 RESP2 类型
+type respWriter struct {
+	*bufio.Writer
+	proto int
+}
+
+func (w *respWriter) simple(s string) {
+	w.WriteString("+" + s + "\r\n")
+}
+
+func (w *respWriter) error(msg string) {
+	w.WriteString("-" + msg + "\r\n")
+}
+
+func (w *respWriter) wrongArgs(cmd string) {
+	w.error(fmt.Sprintf("ERR wrong number of arguments for '%s' command", strings.ToLower(cmd)))
+}
+
+func (w *respWriter) integer(n int64) {
+	w.WriteString(":" + strconv.FormatInt(n, 10) + "\r\n")
+}
+
+func (w *respWriter) bulk(s string) {
+	w.WriteString("$" + strconv.Itoa(len(s)) + "\r\n")
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
+
+func (w *respWriter) null() {
+	if w.proto == 3 {
+		w.WriteString("_\r\n")
+	} else {
+		w.WriteString("$-1\r\n")
+	}
+}
+
+func (w *respWriter) array(n int) {
+	w.WriteString("*" + strconv.Itoa(n) + "\r\n")
+}
+
+// mapLen RESP2 下 map 展开为 key、value 交替的数组
+func (w *respWriter) mapLen(n int) {
+	if w.proto == 3 {
+		w.WriteString("%" + strconv.Itoa(n) + "\r\n")
+	} else {
+		w.array(2 * n)
+	}
+}
+
+// push 推送消息，RESP2 下为普通数组
+func (w *respWriter) push(n int) {
+	if w.proto == 3 {
+		w.WriteString(">" + strconv.Itoa(n) + "\r\n")
+	} else {
+		w.array(n)
+	}
+}
+
+func (w *respWriter) double(f float64) {
+	if w.proto != 3 {
+		w.bulk(strconv.FormatFloat(f, 'g', -1, 64))
+		return
+	}
+	switch {
+	case math.IsInf(f, 1):
+		w.WriteString(",inf\r\n")
+	case math.IsInf(f, -1):
+		w.WriteString(",-inf\r\n")
+	case math.IsNaN(f):
+		w.WriteString(",nan\r\n")
+	default:
+		w.WriteString("," + strconv.FormatFloat(f, 'g', -1, 64) + "\r\n")
+	}
+}
+
+// reply 编码带类型的回复，RESP2 下布尔值与 Redis 的 Lua 转换规则一致：true 为 1、false 为 nil
+func (w *respWriter) reply(r Reply) {
+	switch r.Kind {
+	case ReplyStatus:
+		// simple string 不能包含换行
+		w.simple(strings.NewReplacer("\r", " ", "\n", " ").Replace(r.Str))
+	case ReplyError:
+		w.error(strings.NewReplacer("\r", " ", "\n", " ").Replace(r.Str))
+	case ReplyBulk:
+		w.bulk(r.Str)
+	case ReplyNil:
+		w.null()
+	case ReplyInteger:
+		w.integer(r.Int)
+	case ReplyDouble:
+		w.double(r.Float)
+	case ReplyBool:
+		switch {
+		case w.proto == 3 && r.Int == 1:
+			w.WriteString("#t\r\n")
+		case w.proto == 3:
+			w.WriteString("#f\r\n")
+		case r.Int == 1:
+			w.integer(1)
+		default:
+			w.null()
+		}
+	case ReplyArray, ReplyPush:
+		if r.Kind == ReplyPush {
+			w.push(len(r.Elems))
+		} else {
+			w.array(len(r.Elems))
+		}
+		for _, e := range r.Elems {
+			w.reply(e)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package protocol
+
+import (
+	"strconv"
+	"strings"
+)
+
+// scriptCommand 处理脚本命令
+// 命令按空白切分，无法在一行中同时携带脚本正文和 Key，因此文本协议只支持先 SCRIPT LOAD 再 EVALSHA；
+// SCRIPT LOAD 之后的整行都是脚本（连续空白会被合并成一个空格，脚本中不要使用 -- 注释）
+func (s *Server) scriptCommand(cmd string, args []string) Reply {
+	switch cmd {
+	case "EVALSHA":
+		// EVALSHA sha numkeys [key ...] [arg ...]
+		if len(args) < 2 {
+			return ErrorReply("ERR EVALSHA requires sha and numkeys")
+		}
+		numKeys, err := strconv.Atoi(args[1])
+		if err != nil || numKeys < 0 || numKeys > len(args)-2 {
+			return ErrorReply("ERR numkeys must be between 0 and the number of arguments")
+		}
+		keys, argv := args[2:2+numKeys], args[2+numKeys:]
+		ret, err := s.store.EvalSha(args[0], keys, argv)
+		if err != nil {
+			return errReply(err)
+		}
+		return scriptReply(ret)
+	case "SCRIPT":
+		if len(args) == 0 {
+			return ErrorReply("ERR SCRIPT requires a subcommand")
+		}
+		switch strings.ToUpper(args[0]) {
+		case "LOAD":
+			// SCRIPT LOAD script...
+			if len(args) < 2 {
+				return ErrorReply("ERR SCRIPT LOAD requires script")
+			}
+			sha, err := s.store.ScriptLoad(strings.Join(args[1:], " "))
+			if err != nil {
+				return errReply(err)
+			}
+			return BulkReply(sha)
+		case "EXISTS":
+			// SCRIPT EXISTS sha [sha ...]，每个 sha 一行
+			if len(args) < 2 {
+				return ErrorReply("ERR SCRIPT EXISTS requires at least one sha")
+			}
+			return unnumbered(boolsReply(s.store.ScriptExists(args[1:]...)))
+		case "FLUSH":
+			s.store.ScriptFlush()
+			return StatusReply("OK")
+		}
+		return errorf("Unknown SCRIPT subcommand '%s'", args[0])
+	default:
+		return errorf("Unknown command '%s'", cmd)
+	}
+}
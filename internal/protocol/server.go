@@ -1,128 +1,627 @@
 package protocol
 
 import (
+	"Flux-KV/internal/config"
 	"Flux-KV/internal/core"
+	"Flux-KV/pkg/tlsutil"
+	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Server struct {
 	addr string
 	store *core.MemDB	// 关联内存数据库实例
+	cfg config.TCPConfig	// 连接数、帧大小与超时限制
+	tlsCfg config.TLSConfig	// 开启后所有监听都使用 TLS
+	tls *tlsutil.Reloader	// 第一次监听时加载证书，受 mu 保护
+
+	mu           sync.Mutex
+	listeners    map[net.Listener]struct{}
+	clients      map[int64]*clientConn // 当前所有连接（文本协议与 RESP），用于 CLIENT LIST / KILL 和 Shutdown
+	closing      atomic.Bool
+	nextClientID atomic.Int64
 }
 
-func NewServer(addr string, store *core.MemDB) *Server {
-	return &Server{
+// NewServer 创建服务，cfg 为 nil 或未设置的限制项使用默认值
+func NewServer(addr string, store *core.MemDB, cfg *config.Config) *Server {
+	s := &Server{
 		addr: addr,
 		store: store,
+		listeners: make(map[net.Listener]struct{}),
+		clients: make(map[int64]*clientConn),
+	}
+	if cfg != nil {
+		s.cfg = cfg.TCP
+		s.tlsCfg = cfg.TLS
+	}
+	if s.cfg.MaxFrameSize <= 0 {
+		s.cfg.MaxFrameSize = MaxFrameSize
 	}
+	return s
 }
 
-// Start 启动服务
+// Start 启动服务，Shutdown 之后返回 ErrServerClosed
 func (s *Server) Start() error {
 	// 1. 启动TCP监听
-	listener, err := net.Listen("tcp", s.addr)
+	listener, err := s.listen(s.addr)
 	if err != nil {
 		return err
 	}
-	defer listener.Close()
 
 	log.Printf("🚀 TCP Server listening on %s", s.addr)
 
-	// 2. 死循环接受客户端连接（核心）
-	for {
-		conn, err := listener.Accept()	// 阻塞等待新连接
-		if err != nil {
-			log.Printf("Accept error: %v", err)
-			continue
-		}
-		// 3. 并发处理：每个连接启动独立Goroutine
-		go s.handleConnection(conn)
+	reject := func(conn net.Conn) {
+		writeMessage(conn, "ERROR: "+errMaxClients.Error())
+	}
+	// 2. 开启 event_loop 时由 epoll 事件循环处理连接，见 eventloop_linux.go
+	if s.cfg.EventLoop {
+		return s.serveEventLoop(listener, reject)
 	}
+
+	// 3. 循环接受客户端连接（核心），每个连接启动独立Goroutine
+	return s.serve(listener, s.handleConnection, reject)
+}
+
+func (s *Server) handleConnection(cli *clientConn) {
+	log.Printf("New connection from: %s", cli.addr)
+	s.serveText(cli, bufio.NewReader(cli))
 }
 
-func (s *Server) handleConnection(conn net.Conn) {
-	defer conn.Close()	// 连接处理完后关闭，释放资源
+// serveText 文本协议的请求循环，reader 中可以带有事件循环已读入但未处理的数据
+func (s *Server) serveText(cli *clientConn, reader *bufio.Reader) {
+	clientAddr := cli.addr
 
-	clientAddr := conn.RemoteAddr().String()
-	log.Printf("New connection from: %s", clientAddr)
+	// 读写都经过缓冲：客户端可以连续发送多个请求（流水线），回复按请求顺序攒批写回
+	writer := bufio.NewWriter(cli)
+	defer writer.Flush()
+	// 推送模式直接读写连接，读取时需要先消费缓冲区中已读入的数据
+	bc := &bufferedConn{clientConn: cli, r: reader}
 
 	for {
-		// 1. 拆包：读取完整请求（解决TCP粘包）
-		request, err := Decode(conn)
+		// 1. 拆包：读取完整请求（解决TCP粘包），超过 max_frame_size 时断开
+		err := cli.awaitRequest(reader)
+		var request string
+		if err == nil {
+			request, err = decodeLimit(reader, s.cfg.MaxFrameSize)
+		}
 		if err != nil {
-			if err == io.EOF {
-				// 客户端主动断开连接
+			if err == io.EOF || err == ErrServerClosed {
+				// 客户端主动断开连接，或服务正在关闭
 				log.Printf("Client %s disconnected", clientAddr)
 			} else {
-				log.Printf("Read error: %v", err)
+				log.Printf("Read error from %s: %v", clientAddr, err)
 			}
 			return	// 退出循环，结束当前连接的处理
 		}
 
-		// 🔍 观察点 4: 服务端收到了完整的数据包
-        fmt.Printf("[Server] 3. 收到并拆包成功: %q\n", request)
+		fields := strings.Fields(request)
+		if len(fields) > 0 {
+			cli.touch(fields[0])
+		}
+		// 开启 ACL 时先检查权限，未通过的命令不执行
+		denied := s.authorize(cli, fields)
+
+		// 推送模式命令会接管连接，先把流水线中已执行命令的回复发出去
+		if len(fields) > 0 && denied == nil && takesOverConn(fields[0]) {
+			if err := writer.Flush(); err != nil {
+				log.Printf("Write error: %v", err)
+				return
+			}
+			switch strings.ToUpper(fields[0]) {
+			case "HELLO":
+				// 版本协商：HELLO 2 之后双方改用 v2 二进制帧，不发送 HELLO 的旧客户端继续使用文本协议
+				version := "1"
+				if len(fields) > 1 {
+					version = fields[1]
+				}
+				switch version {
+				case "1":
+					writeMessage(bc, "OK version=1")
+				case strconv.Itoa(ProtocolVersion):
+					if writeMessage(bc, "OK version="+version) == nil {
+						s.serveV2(cli, reader)
+					}
+					return
+				default:
+					writeMessage(bc, "ERROR: unsupported protocol version "+version)
+				}
+				continue
+			case "MONITOR":
+				// 持续推送执行的命令，直到客户端断开
+				pattern := ""
+				if len(fields) > 1 {
+					pattern = fields[1]
+				}
+				if cli.enterPushMode() {
+					s.replyMonitor(&textReplyConn{bufferedConn: bc}, clientAddr, pattern, BulkReply)
+				}
+				return
+			case "WATCH":
+				// 持续推送 Key 变更，直到客户端断开
+				if cli.enterPushMode() {
+					s.replyWatch(&textReplyConn{bufferedConn: bc}, clientAddr, fields[1:], BulkReply)
+				}
+				return
+			case "SUBSCRIBE", "PSUBSCRIBE":
+				// 订阅数归零后回到普通模式
+				if !cli.enterPushMode() || !s.pubSubMode(bc, clientAddr, fields) {
+					return
+				}
+				continue
+			}
+		}
 
 		// 2. 执行命令：解析并操作数据库
-		response := s.executeCommand(request)
+		response := s.textReply(cli, request, fields, denied)
 
-		// 🔍 观察点 5: 数据库操作完成，准备回复
-        fmt.Printf("[Server] 4. 执行完毕，结果: %q. 准备发回客户端...\n", response)
-		
-		// 3. 打包+发送响应
-		responseData, err := Encode(response)
-		if err != nil {
-			log.Printf("Encode error: %v", err)
+		// 3. 打包+写入缓冲区，流水线中后面还有请求时先不刷出
+		if err := writeMessage(writer, response); err != nil {
+			log.Printf("Write error: %v", err)
 			return
 		}
+		if reader.Buffered() == 0 {
+			if err := writer.Flush(); err != nil {
+				log.Printf("Write error: %v", err)
+				return
+			}
+		}
+	}
+}
 
-		_, err = conn.Write(responseData)
-		if err != nil {
-			log.Printf("Write error: %v", err)
-			return
+// takesOverConn 版本协商与推送模式命令，执行后接管整个连接
+func takesOverConn(cmd string) bool {
+	switch strings.ToUpper(cmd) {
+	case "HELLO", "MONITOR", "WATCH", "SUBSCRIBE", "PSUBSCRIBE":
+		return true
+	}
+	return false
+}
+
+// textReply 执行一条文本协议请求并返回回复，denied 为 ACL 检查的结果
+// CLIENT / AUTH / ACL 命令需要连接信息，其余命令交给 dispatch
+func (s *Server) textReply(cli *clientConn, request string, fields []string, denied error) string {
+	switch {
+	case denied != nil:
+		return formatReply(errReply(denied))
+	case len(fields) > 0 && (strings.EqualFold(fields[0], "CLIENT") || strings.EqualFold(fields[0], "AUTH") || strings.EqualFold(fields[0], "ACL")):
+		return formatReply(s.execute(cli, fields))
+	default:
+		return s.executeCommand(cli.addr, request)
+	}
+}
+
+// bufferedConn 读取时优先消费 bufio.Reader 中已缓冲的数据
+type bufferedConn struct {
+	*clientConn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+// readRequest 推送模式下读取客户端的请求：等待时不受空闲超时限制，收到后与普通模式一样受 read_timeout 和 max_frame_size 限制
+func (c *bufferedConn) readRequest() (string, error) {
+	if err := c.await(c.r, 0); err != nil {
+		return "", err
+	}
+	return decodeLimit(c.r, c.s.cfg.MaxFrameSize)
+}
+
+// textReplyConn 推送模式下的文本协议连接，与 RESP / v2 共用 replyMonitor / replyWatch
+// 回复由 formatReply 转换为文本格式，请求按空白切分
+type textReplyConn struct {
+	*bufferedConn
+	mu sync.Mutex
+}
+
+// ReadCommand 实现 replyConn
+func (c *textReplyConn) ReadCommand() ([]string, error) {
+	msg, err := c.readRequest()
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(msg), nil
+}
+
+// WriteReply 实现 replyConn
+func (c *textReplyConn) WriteReply(r Reply) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return writeMessage(c.bufferedConn, formatReply(r))
+}
+
+// parseWatchArgs 解析 WATCH 的参数: <key> [PREFIX] [FROM <revision>]
+func parseWatchArgs(args []string) (key string, prefix bool, fromRev uint64, err error) {
+	if len(args) == 0 {
+		return "", false, 0, errors.New("WATCH requires key")
+	}
+	key = args[0]
+	for i := 1; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "PREFIX":
+			prefix = true
+		case "FROM":
+			if i+1 >= len(args) {
+				return "", false, 0, errors.New("FROM requires revision")
+			}
+			rev, err := strconv.ParseUint(args[i+1], 10, 64)
+			if err != nil {
+				return "", false, 0, errors.New("revision must be a non-negative integer")
+			}
+			fromRev = rev
+			i++
+		default:
+			return "", false, 0, fmt.Errorf("Unknown WATCH option '%s'", args[i])
+		}
+	}
+	return key, prefix, fromRev, nil
+}
+
+// pubSubMode 发布/订阅模式：推送频道消息，同时处理订阅相关的命令
+// 返回 true 表示订阅数已归零，连接回到普通命令模式；false 表示连接应关闭
+func (s *Server) pubSubMode(conn *bufferedConn, clientAddr string, first []string) bool {
+	sub := s.store.NewSubscriber(0)
+	defer sub.Close()
+
+	// 读写两个协程都会写连接，需要加锁
+	var wmu sync.Mutex
+	write := func(msg string) error {
+		wmu.Lock()
+		defer wmu.Unlock()
+		return writeMessage(conn, msg)
+	}
+
+	if count, ok := pubSubCommand(sub, first, write); !ok || count == 0 {
+		return ok
+	}
+	log.Printf("Client %s entered PUBSUB mode", clientAddr)
+
+	// 1. 读协程：处理订阅变更，订阅数归零时退出推送模式
+	backToNormal := make(chan bool, 1)
+	go func() {
+		for {
+			msg, err := conn.readRequest()
+			if err != nil {
+				backToNormal <- false
+				return
+			}
+			count, ok := pubSubCommand(sub, strings.Fields(msg), write)
+			if !ok {
+				backToNormal <- false
+				return
+			}
+			if count == 0 {
+				backToNormal <- true
+				return
+			}
+		}
+	}()
+
+	// 2. 写循环：推送消息
+	for {
+		select {
+		case m, ok := <-sub.C:
+			if !ok {
+				// 消费过慢被断开
+				if err := sub.Err(); err != nil {
+					write(formatReply(errReply(err)))
+				}
+				return false
+			}
+			if err := write(m.String()); err != nil {
+				return false
+			}
+		case normal := <-backToNormal:
+			return normal
+		}
+	}
+}
+
+// pubSubCommand 执行推送模式下允许的命令，返回执行后的订阅总数
+// ok 为 false 表示连接应关闭（QUIT 或写失败）
+func pubSubCommand(sub *core.Subscriber, parts []string, write func(string) error) (count int, ok bool) {
+	if len(parts) == 0 {
+		return len(sub.Channels()) + len(sub.Patterns()), write("ERROR: Empty command") == nil
+	}
+
+	cmd := strings.ToLower(parts[0])
+	args := parts[1:]
+	switch cmd {
+	case "subscribe", "psubscribe":
+		if len(args) == 0 {
+			return len(sub.Channels()) + len(sub.Patterns()), write(fmt.Sprintf("ERROR: %s requires at least one channel", strings.ToUpper(cmd))) == nil
+		}
+		// 与 Redis 一致，每个频道回复一条确认
+		for _, name := range args {
+			if cmd == "subscribe" {
+				count = sub.Subscribe(name)
+			} else {
+				count = sub.PSubscribe(name)
+			}
+			if write(fmt.Sprintf("%s %s %d", cmd, name, count)) != nil {
+				return count, false
+			}
 		}
+		return count, true
+	case "unsubscribe", "punsubscribe":
+		if len(args) == 0 {
+			if cmd == "unsubscribe" {
+				args = sub.Channels()
+			} else {
+				args = sub.Patterns()
+			}
+		}
+		count = len(sub.Channels()) + len(sub.Patterns())
+		for _, name := range args {
+			if cmd == "unsubscribe" {
+				count = sub.Unsubscribe(name)
+			} else {
+				count = sub.PUnsubscribe(name)
+			}
+			if write(fmt.Sprintf("%s %s %d", cmd, name, count)) != nil {
+				return count, false
+			}
+		}
+		return count, true
+	case "ping":
+		return len(sub.Channels()) + len(sub.Patterns()), write("pong") == nil
+	case "quit":
+		write("OK")
+		return 0, false
+	default:
+		return len(sub.Channels()) + len(sub.Patterns()),
+			write(fmt.Sprintf("ERROR: '%s' is not allowed in PUBSUB mode", parts[0])) == nil
 	}
 }
 
-// executeCommand 解析简单的文本协议
-func (s *Server) executeCommand(cmdStr string) string {
+// writeMessage 打包并发送一条消息
+func writeMessage(w io.Writer, msg string) error {
+	data, err := Encode(msg)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// executeCommand 解析简单的文本协议，回复由 formatReply 转换为文本格式
+// clientAddr 为客户端地址，用于慢日志
+func (s *Server) executeCommand(clientAddr, cmdStr string) string {
 	// 清理空格并按空格分割命令
-	parts := strings.Fields(strings.TrimSpace(cmdStr))
+	return formatReply(s.dispatch(clientAddr, strings.Fields(strings.TrimSpace(cmdStr))))
+}
+
+// dispatch 命令分发器，所有协议共用
+// parts 为已切分好的命令和参数，返回带类型的回复
+func (s *Server) dispatch(clientAddr string, parts []string) Reply {
 	if len(parts) == 0 {
-		return "ERROR: Empty command"
+		return ErrorReply("ERR Empty command")
 	}
 
 	cmd := strings.ToUpper(parts[0])
+	defer s.trace(clientAddr, parts)()
 
 	switch cmd {
 	case "SET":
 		if len(parts) < 3 {
 			// 参数校验：SET需要key+value
-			return "ERROR: SET requires key and value"
+			return ErrorReply("ERR SET requires key and value")
 		}
 		s.store.Set(parts[1], parts[2], 0)
-		return "OK"
+		return StatusReply("OK")
 	case "GET":
 		if len(parts) < 2 {
 			// 参数校验：GET需要key
-			return "ERROR: GET requires key"
+			return ErrorReply("ERR GET requires key")
 		}
 		val, found := s.store.Get(parts[1])
 		if !found {
-			return "(nil)"	// 模仿Redis的返回格式
+			return NilReply // 文本协议中为 (nil)，模仿Redis的返回格式
 		}
-		return fmt.Sprintf("%v", val)
+		// 其他类型的值在读取时不持有分片锁，不能直接格式化
+		str, ok := val.(string)
+		if !ok {
+			return errReply(core.ErrWrongType)
+		}
+		return BulkReply(str)
 	case "DEL":
 		if len(parts) < 2 {
 			// 参数校验：DEL需要key
-			return "ERROR: DEL requires key"
+			return ErrorReply("ERR DEL requires key")
+		}
+		s.store.Del(parts[1:]...)
+		return StatusReply("OK")
+	case "INFO":
+		// INFO [section]
+		section := ""
+		if len(parts) > 1 {
+			section = parts[1]
 		}
-		s.store.Del(parts[1])
-		return "OK"
+		text, err := s.store.Info().Format(section)
+		if err != nil {
+			return errReply(err)
+		}
+		return BulkReply(text)
+	case "PUBLISH":
+		// PUBLISH channel message，返回收到消息的订阅者数量
+		if len(parts) < 3 {
+			return ErrorReply("ERR PUBLISH requires channel and message")
+		}
+		return IntegerReply(int64(s.store.Publish(parts[1], strings.Join(parts[2:], " "))))
+	case "HOTKEYS", "BIGKEYS":
+		// HOTKEYS [count] / BIGKEYS [count]
+		count := 10
+		if len(parts) > 1 {
+			n, err := strconv.Atoi(parts[1])
+			if err != nil || n < 0 {
+				return ErrorReply("ERR count must be a non-negative integer")
+			}
+			count = n
+		}
+		if cmd == "HOTKEYS" {
+			return keyStatsReply(s.store.HotKeys(count), false)
+		}
+		return keyStatsReply(s.store.BigKeys(count), true)
+	case "SLOWLOG":
+		// SLOWLOG GET [count] / SLOWLOG LEN / SLOWLOG RESET
+		return s.slowLogCommand(parts[1:])
+	case "LATENCY":
+		// LATENCY HISTOGRAM [event ...] / LATENCY RESET
+		return s.latencyCommand(parts[1:])
+	case "XADD", "XLEN", "XRANGE", "XREVRANGE", "XTRIM", "XREAD", "XREADGROUP",
+		"XGROUP", "XACK", "XPENDING", "XCLAIM":
+		// Stream 命令，见 stream.go
+		return s.streamCommand(cmd, parts[1:])
+	case "PFADD", "PFCOUNT", "PFMERGE", "BF.RESERVE", "BF.ADD", "BF.MADD", "BF.EXISTS", "BF.MEXISTS",
+		"CMS.INITBYDIM", "CMS.INITBYPROB", "CMS.INCRBY", "CMS.QUERY":
+		// 概率数据结构，见 sketch.go
+		return s.sketchCommand(cmd, parts[1:])
+	case "GEOADD", "GEOPOS", "GEODIST", "GEOSEARCH":
+		// 地理位置，见 geo.go
+		return s.geoCommand(cmd, parts[1:])
+	case "TS.CREATE", "TS.ADD", "TS.GET", "TS.RANGE", "TS.MRANGE", "TS.CREATERULE", "TS.DELETERULE", "TS.INFO":
+		// 时间序列，见 timeseries.go
+		return s.tsCommand(cmd, parts[1:])
+	case "JSON.SET", "JSON.GET", "JSON.DEL", "JSON.ARRAPPEND", "JSON.NUMINCRBY", "JSON.INDEX", "FIND":
+		// JSON 文档与二级索引查询，见 json.go
+		return s.jsonCommand(cmd, parts[1:])
+	case "THROTTLE":
+		// 限流，见 throttle.go
+		return s.throttleCommand(parts[1:])
+	case "EVALSHA", "SCRIPT":
+		// 脚本，见 script.go
+		return s.scriptCommand(cmd, parts[1:])
 	default:
-		return fmt.Sprintf("ERROR: Unknown command '%s'", cmd)
+		return errorf("Unknown command '%s'", cmd)
 	}
-}
\ No newline at end of file
+}
+
+// trace 把命令广播给 MONITOR 订阅者，返回的函数在命令执行完后调用，用于记录慢日志
+func (s *Server) trace(clientAddr string, parts []string) func() {
+	cmd := strings.ToLower(parts[0])
+
+	// 记录慢日志（Key 取第一个参数）
+	key := ""
+	if len(parts) > 1 {
+		key = parts[1]
+	}
+	start := time.Now()
+
+	// 广播给 MONITOR 订阅者
+	var value any
+	if len(parts) > 2 {
+		value = strings.Join(parts[2:], " ")
+	}
+	s.store.FeedMonitor(clientAddr, cmd, key, value)
+
+	return func() {
+		s.store.SlowLog().Observe(cmd, key, clientAddr, start)
+	}
+}
+
+// keyStatsReply 热点/大 Key 报告，每个 Key 为 [key, type, count|size]，文本协议为带序号的多行文本
+func keyStatsReply(stats []core.KeyStat, bySize bool) Reply {
+	if len(stats) == 0 {
+		return ArrayReply()
+	}
+	elems := make([]Reply, len(stats))
+	lines := make([]string, len(stats))
+	for i, ks := range stats {
+		if bySize {
+			elems[i] = ArrayReply(BulkReply(ks.Key), BulkReply(ks.Type), IntegerReply(ks.Size))
+			lines[i] = fmt.Sprintf("%d) %s type=%s size=%d", i+1, ks.Key, ks.Type, ks.Size)
+		} else {
+			elems[i] = ArrayReply(BulkReply(ks.Key), BulkReply(ks.Type), IntegerReply(int64(ks.Count)))
+			lines[i] = fmt.Sprintf("%d) %s type=%s count~%d", i+1, ks.Key, ks.Type, ks.Count)
+		}
+	}
+	return withText(ArrayReply(elems...), strings.Join(lines, "\n"))
+}
+
+// slowLogCommand 处理 SLOWLOG 子命令
+func (s *Server) slowLogCommand(args []string) Reply {
+	if len(args) == 0 {
+		return ErrorReply("ERR SLOWLOG requires subcommand GET|LEN|RESET")
+	}
+
+	slowLog := s.store.SlowLog()
+	switch strings.ToUpper(args[0]) {
+	case "GET":
+		count := 10
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil {
+				return ErrorReply("ERR count must be an integer")
+			}
+			count = n
+		}
+		// 与 Redis 一致，每条为 [id, unix 时间戳, 耗时微秒, [命令, Key], 客户端地址, 客户端名]
+		entries := slowLog.Get(count)
+		if len(entries) == 0 {
+			return ArrayReply()
+		}
+		elems := make([]Reply, len(entries))
+		lines := make([]string, len(entries))
+		for i, e := range entries {
+			elems[i] = ArrayReply(IntegerReply(int64(e.ID)), IntegerReply(e.Time.Unix()), IntegerReply(e.Duration.Microseconds()),
+				ArrayReply(BulkReply(e.Command), BulkReply(e.Key)), BulkReply(e.ClientAddr), BulkReply(""))
+			lines[i] = fmt.Sprintf("%d) id=%d time=%s duration=%dus cmd=%s key=%s client=%s",
+				i+1, e.ID, e.Time.Format(time.RFC3339), e.Duration.Microseconds(), e.Command, e.Key, e.ClientAddr)
+		}
+		return withText(ArrayReply(elems...), strings.Join(lines, "\n"))
+	case "LEN":
+		return IntegerReply(int64(slowLog.Len()))
+	case "RESET":
+		slowLog.Reset()
+		return StatusReply("OK")
+	default:
+		return errorf("Unknown SLOWLOG subcommand '%s'", args[0])
+	}
+}
+
+// latencyCommand 处理 LATENCY 子命令
+func (s *Server) latencyCommand(args []string) Reply {
+	if len(args) == 0 {
+		return ErrorReply("ERR LATENCY requires subcommand HISTOGRAM|RESET")
+	}
+
+	switch strings.ToUpper(args[0]) {
+	case "HISTOGRAM":
+		stats := s.store.Latency(args[1:]...)
+		if len(stats) == 0 {
+			return ArrayReply()
+		}
+		// 与 INFO 一样是给人看的报告，作为一个字符串返回
+		var sb strings.Builder
+		for i, st := range stats {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			fmt.Fprintf(&sb, "%s: count=%d avg=%.2fus p50<=%dus p99<=%dus max=%dus",
+				st.Event, st.Count, st.AvgUsec, st.P50Usec, st.P99Usec, st.MaxUsec)
+			for _, b := range st.Buckets {
+				if b.UpperUsec == 0 {
+					fmt.Fprintf(&sb, "\n  >%dus: %d", core.LatencyOverflowUsec, b.Count)
+				} else {
+					fmt.Fprintf(&sb, "\n  <=%dus: %d", b.UpperUsec, b.Count)
+				}
+			}
+		}
+		return BulkReply(sb.String())
+	case "RESET":
+		s.store.ResetLatency()
+		return StatusReply("OK")
+	default:
+		return errorf("Unknown LATENCY subcommand '%s'", args[0])
+	}
+}
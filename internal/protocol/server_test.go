@@ -3,7 +3,16 @@ package protocol
 import (
 	"Flux-KV/internal/config"
 	"Flux-KV/internal/core"
+	"Flux-KV/pkg/acl"
+	"Flux-KV/pkg/wire"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
 	"net"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -18,7 +27,7 @@ func TestServer_EndToEnd(t *testing.T) {
 
 	// 2. 创建 Server
 	addr := "localhost:9090"
-	server := NewServer(addr, db)
+	server := NewServer(addr, db, cfg)
 
 	// 3. 在独立的 Goroutine 中启动服务
 	go func() {
@@ -81,3 +90,805 @@ func TestServer_EndToEnd(t *testing.T) {
 		})
 	}
 }
+
+// TestServer_Monitor 验证 MONITOR 连接能收到其他连接执行的命令，并支持 Key 过滤
+func TestServer_Monitor(t *testing.T) {
+	db, _ := core.NewMemDB(&config.Config{})
+	addr := "localhost:9091"
+	server := NewServer(addr, db, nil)
+	go server.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	// 1. 监视器连接：只关心 user:* 前缀
+	monConn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Monitor failed to connect: %v", err)
+	}
+	defer monConn.Close()
+	if err := writeMessage(monConn, "MONITOR user:*"); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if resp, err := Decode(monConn); err != nil || resp != "OK" {
+		t.Fatalf("MONITOR handshake failed: %q, %v", resp, err)
+	}
+
+	// 2. 普通连接执行命令
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Client failed to connect: %v", err)
+	}
+	defer conn.Close()
+	for _, cmd := range []string{"SET order:1 x", "SET user:1 naato"} {
+		writeMessage(conn, cmd)
+		if _, err := Decode(conn); err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+	}
+
+	// 3. 监视器只应收到 user:1 的命令
+	monConn.SetReadDeadline(time.Now().Add(time.Second))
+	event, err := Decode(monConn)
+	if err != nil {
+		t.Fatalf("Monitor read failed: %v", err)
+	}
+	if !strings.Contains(event, `"set" "user:1" "naato"`) {
+		t.Errorf("unexpected monitor event: %q", event)
+	}
+}
+
+// TestServer_Watch 文本协议的 WATCH 与 v2 共用推送逻辑，事件和错误按文本格式发送
+func TestServer_Watch(t *testing.T) {
+	db, _ := core.NewMemDB(&config.Config{Watch: config.WatchConfig{HistorySize: 16}})
+	addr := "localhost:9088"
+	server := NewServer(addr, db, nil)
+	go server.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	dial := func() net.Conn {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			t.Fatalf("Client failed to connect: %v", err)
+		}
+		conn.SetDeadline(time.Now().Add(time.Second))
+		return conn
+	}
+
+	bad := dial()
+	defer bad.Close()
+	writeMessage(bad, "WATCH k BOGUS")
+	if resp, err := Decode(bad); err != nil || resp != "ERROR: Unknown WATCH option 'BOGUS'" {
+		t.Fatalf("unexpected WATCH error: %q (%v)", resp, err)
+	}
+
+	w := dial()
+	defer w.Close()
+	writeMessage(w, "WATCH user: PREFIX")
+	if resp, err := Decode(w); err != nil || resp != "OK revision=0" {
+		t.Fatalf("WATCH handshake failed: %q (%v)", resp, err)
+	}
+	conn := dial()
+	defer conn.Close()
+	writeMessage(conn, "SET user:1 x")
+	Decode(conn)
+	if event, err := Decode(w); err != nil || event != `1 put "user:1" "x"` {
+		t.Errorf("unexpected watch event: %q (%v)", event, err)
+	}
+}
+
+// TestServer_RESPMonitor RESP 监听下的 MONITOR [pattern] 同样只推送 Key 匹配的命令
+func TestServer_RESPMonitor(t *testing.T) {
+	db, _ := core.NewMemDB(&config.Config{})
+	addr := "localhost:9089"
+	server := NewServer("", db, nil)
+	go server.StartRESP(addr)
+	time.Sleep(100 * time.Millisecond)
+
+	monConn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Monitor failed to connect: %v", err)
+	}
+	defer monConn.Close()
+	monConn.SetReadDeadline(time.Now().Add(time.Second))
+	mon := bufio.NewReader(monConn)
+	monConn.Write([]byte("*2\r\n$7\r\nMONITOR\r\n$6\r\nuser:*\r\n"))
+	if line, err := mon.ReadString('\n'); err != nil || line != "+OK\r\n" {
+		t.Fatalf("MONITOR handshake failed: %q, %v", line, err)
+	}
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Client failed to connect: %v", err)
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for _, key := range []string{"order:1", "user:1"} {
+		fmt.Fprintf(conn, "*3\r\n$3\r\nSET\r\n$%d\r\n%s\r\n$1\r\nx\r\n", len(key), key)
+		if _, err := r.ReadString('\n'); err != nil {
+			t.Fatalf("SET %s failed: %v", key, err)
+		}
+	}
+
+	// 只应收到 user:1 的命令
+	event, err := mon.ReadString('\n')
+	if err != nil {
+		t.Fatalf("Monitor read failed: %v", err)
+	}
+	if !strings.HasPrefix(event, "+") || !strings.Contains(event, `"set" "user:1" "x"`) {
+		t.Errorf("unexpected monitor event: %q", event)
+	}
+}
+
+// TestServer_StreamCommands 验证 Stream 命令的文本协议（直接调用 executeCommand，不经过网络）
+func TestServer_StreamCommands(t *testing.T) {
+	db, _ := core.NewMemDB(&config.Config{})
+	server := NewServer("", db, nil)
+
+	tests := []struct {
+		cmd      string
+		expected string
+	}{
+		{"XADD orders 1-1 item apple", "1-1"},
+		{"XADD orders MAXLEN 2 1-2 item pear", "1-2"},
+		{"XADD orders 1-1 item late", "ERROR: " + core.ErrStreamIDTooSmall.Error()},
+		{"XLEN orders", "2"},
+		{"XRANGE orders - + COUNT 1", "1-1 item apple"},
+		{"XREVRANGE orders + - COUNT 1", "1-2 item pear"},
+		{"XREAD COUNT 1 STREAMS orders 1-1", "orders 1-2 item pear"},
+		{"XREAD BLOCK 10 STREAMS orders $", "(nil)"},
+		{"XGROUP CREATE orders g 0", "OK"},
+		{"XREADGROUP GROUP g alice COUNT 1 STREAMS orders >", "orders 1-1 item apple"},
+		{"XPENDING orders g", "count=1 min=1-1 max=1-1 consumers=alice:1"},
+		{"XCLAIM orders g bob 0 1-1", "1-1 item apple"},
+		{"XACK orders g 1-1", "1"},
+		{"XPENDING orders g", "count=0"},
+		{"XTRIM orders MAXLEN 1", "1"},
+		{"XGROUP DESTROY orders g", "1"},
+	}
+	for _, tt := range tests {
+		if got := server.executeCommand("test", tt.cmd); got != tt.expected {
+			t.Errorf("Command: %q, Expected: %q, Got: %q", tt.cmd, tt.expected, got)
+		}
+	}
+}
+
+// TestServer_SketchCommands 验证概率数据结构命令的文本协议
+func TestServer_SketchCommands(t *testing.T) {
+	db, _ := core.NewMemDB(&config.Config{})
+	server := NewServer("", db, nil)
+
+	tests := []struct {
+		cmd      string
+		expected string
+	}{
+		{"PFADD visitors alice bob", "1"},
+		{"PFADD visitors alice", "0"},
+		{"PFADD others carol", "1"},
+		{"PFCOUNT visitors others", "3"},
+		{"PFMERGE all visitors others", "OK"},
+		{"PFCOUNT all", "3"},
+		{"BF.RESERVE seen 0.01 100", "OK"},
+		{"BF.RESERVE seen 0.01 100", "ERROR: CONFLICT " + core.ErrKeyExists.Error()},
+		{"BF.MADD seen a b a", "1\n1\n0"},
+		{"BF.EXISTS seen a", "1"},
+		{"CMS.INITBYDIM clicks 1000 5", "OK"},
+		{"CMS.INCRBY clicks home 3 about 1", "3\n1"},
+		{"CMS.QUERY clicks home missing", "3\n0"},
+		{"PFADD clicks x", "ERROR: " + core.ErrWrongType.Error()},
+		{"GET clicks", "ERROR: " + core.ErrWrongType.Error()},
+	}
+	for _, tt := range tests {
+		if got := server.executeCommand("test", tt.cmd); got != tt.expected {
+			t.Errorf("Command: %q, Expected: %q, Got: %q", tt.cmd, tt.expected, got)
+		}
+	}
+}
+
+// TestServer_GeoCommands 验证地理位置命令的文本协议
+func TestServer_GeoCommands(t *testing.T) {
+	db, _ := core.NewMemDB(&config.Config{})
+	server := NewServer("", db, nil)
+
+	tests := []struct {
+		cmd      string
+		expected string
+	}{
+		{"GEOADD sicily 13.361389 38.115556 palermo 15.087269 37.502669 catania", "2"},
+		{"GEOADD sicily 13.361389 38.115556", "ERROR: GEOADD requires key and longitude latitude member triples"},
+		{"GEOPOS sicily palermo nobody", "13.361389 38.115556\n(nil)"},
+		{"GEODIST sicily palermo catania km", "166.2742"},
+		{"GEODIST sicily palermo nobody", "(nil)"},
+		{"GEOSEARCH sicily FROMLONLAT 15 37 BYRADIUS 200 km ASC WITHDIST", "catania 56.4413\npalermo 190.4424"},
+		{"GEOSEARCH sicily FROMMEMBER palermo BYBOX 100 100 km", "palermo"},
+		{"GEOSEARCH sicily FROMLONLAT 15 37 BYRADIUS 200 km COUNT 1", "catania"},
+		{"GEOSEARCH sicily BYRADIUS 200 km", "ERROR: GEOSEARCH requires FROMMEMBER or FROMLONLAT and BYRADIUS or BYBOX"},
+	}
+	for _, tt := range tests {
+		if got := server.executeCommand("test", tt.cmd); got != tt.expected {
+			t.Errorf("Command: %q, Expected: %q, Got: %q", tt.cmd, tt.expected, got)
+		}
+	}
+}
+
+// TestServer_TimeSeriesCommands 验证时间序列命令的文本协议
+func TestServer_TimeSeriesCommands(t *testing.T) {
+	db, _ := core.NewMemDB(&config.Config{})
+	server := NewServer("", db, nil)
+
+	tests := []struct {
+		cmd      string
+		expected string
+	}{
+		{"TS.CREATE cpu:a LABELS metric cpu host a", "OK"},
+		{"TS.CREATE cpu:avg", "OK"},
+		{"TS.CREATERULE cpu:a cpu:avg AGGREGATION avg 100", "OK"},
+		{"TS.ADD cpu:a 10 1", "10"},
+		{"TS.ADD cpu:a 20 3", "20"},
+		{"TS.ADD cpu:a 110 5.5", "110"},
+		{"TS.ADD cpu:a 110 6", "ERROR: " + core.ErrTSTimestamp.Error()},
+		{"TS.ADD cpu:b 10 7 RETENTION 0 LABELS metric cpu host b", "10"},
+		{"TS.GET cpu:a", "110 5.5"},
+		{"TS.RANGE cpu:a - + COUNT 2", "10 1\n20 3"},
+		{"TS.RANGE cpu:a 0 200 AGGREGATION max 100", "0 3\n100 5.5"},
+		{"TS.RANGE cpu:avg - +", "0 2"},
+		{"TS.MRANGE - + AGGREGATION count 1000 FILTER metric=cpu", "cpu:a 0 3\ncpu:b 0 1"},
+		{"TS.MRANGE - + FILTER host!=a", "ERROR: " + core.ErrTSFilter.Error()},
+		{"TS.DELETERULE cpu:a cpu:avg", "OK"},
+		{"TS.RANGE missing - +", "ERROR: NOTFOUND " + core.ErrTSNoSuchSeries.Error()},
+	}
+	for _, tt := range tests {
+		if got := server.executeCommand("test", tt.cmd); got != tt.expected {
+			t.Errorf("Command: %q, Expected: %q, Got: %q", tt.cmd, tt.expected, got)
+		}
+	}
+}
+
+func TestServer_JSONCommands(t *testing.T) {
+	db, _ := core.NewMemDB(&config.Config{})
+	server := NewServer("", db, nil)
+
+	tests := []struct {
+		cmd      string
+		expected string
+	}{
+		{`JSON.SET user:1 $ {"name":"alice","age":30,"tags":[]}`, "OK"},
+		{`JSON.SET user:2 $ {"name":"bob","age":17}`, "OK"},
+		{`JSON.SET user:1 $.name "bob" NX`, "(nil)"},
+		{`JSON.SET user:1 $.city "hz"`, "OK"},
+		{"JSON.GET user:1 $.name", `"alice"`},
+		{"JSON.GET user:1 $.missing", "ERROR: NOTFOUND " + core.ErrJSONPath.Error()},
+		{"JSON.GET nothing", "(nil)"},
+		{`JSON.ARRAPPEND user:1 $.tags "a" "b"`, "2"},
+		{"JSON.NUMINCRBY user:1 $.age 1", "31"},
+		{"JSON.DEL user:1 $.tags", "1"},
+		{"JSON.GET user:1", `{"age":31,"city":"hz","name":"alice"}`},
+		{"JSON.INDEX CREATE user $.age NUMERIC", "OK"},
+		{"JSON.INDEX CREATE user $.name TAG", "OK"},
+		{"JSON.INDEX LIST", "1) user $.age numeric entries=2\n2) user $.name tag entries=2"},
+		{"FIND user WHERE $.age >= 18", `1) user:1 {"age":31,"city":"hz","name":"alice"}`},
+		{"FIND user WHERE $.age BETWEEN 0 100 LIMIT 1", `1) user:2 {"age":17,"name":"bob"}`},
+		{"FIND user WHERE $.name = bob", `1) user:2 {"age":17,"name":"bob"}`},
+		{"FIND user WHERE $.name = carol", "(empty list)"},
+		{"FIND user WHERE $.city = hz", "ERROR: NOTFOUND no index on field $.city in namespace user"},
+		{"JSON.INDEX DROP user $.name", "1"},
+	}
+	for _, tt := range tests {
+		if got := server.executeCommand("test", tt.cmd); got != tt.expected {
+			t.Errorf("Command: %q, Expected: %q, Got: %q", tt.cmd, tt.expected, got)
+		}
+	}
+}
+
+// TestServer_ThrottleCommand 容量用完后拒绝并给出重试时间
+func TestServer_ThrottleCommand(t *testing.T) {
+	db, _ := core.NewMemDB(&config.Config{})
+	server := NewServer("", db, nil)
+
+	tests := []struct {
+		cmd      string
+		expected string
+	}{
+		{"THROTTLE api 1 2", "allowed=1 limit=2 remaining=1 retry_after_ms=0 reset_after_ms=1000"},
+		{"THROTTLE api 1 2", "allowed=1 limit=2 remaining=0 retry_after_ms=0 reset_after_ms=2000"},
+		{"THROTTLE api 1 2 3", "ERROR: " + core.ErrThrottleCost.Error()},
+		{"THROTTLE api 0 2", "ERROR: " + core.ErrThrottleArgs.Error()},
+		{"THROTTLE api x 2", "ERROR: rate must be a number"},
+		{"THROTTLE api", "ERROR: THROTTLE requires key, rate and burst"},
+	}
+	for _, tt := range tests {
+		if got := server.executeCommand("test", tt.cmd); got != tt.expected {
+			t.Errorf("Command: %q, Expected: %q, Got: %q", tt.cmd, tt.expected, got)
+		}
+	}
+	if got := server.executeCommand("test", "THROTTLE api 1 2"); !strings.HasPrefix(got, "allowed=0 limit=2 remaining=0 retry_after_ms=") {
+		t.Errorf("third request should be denied, got %q", got)
+	}
+}
+
+// TestServer_ScriptCommands 先 SCRIPT LOAD 再 EVALSHA
+func TestServer_ScriptCommands(t *testing.T) {
+	db, _ := core.NewMemDB(&config.Config{})
+	server := NewServer("", db, nil)
+
+	sha := server.executeCommand("test", `SCRIPT LOAD kv.call("INCRBY", KEYS[1], ARGV[1]) return {kv.call("GET", KEYS[1]), {1, nil}}`)
+	if len(sha) != 40 {
+		t.Fatalf("SCRIPT LOAD should return sha1, got %q", sha)
+	}
+
+	tests := []struct {
+		cmd      string
+		expected string
+	}{
+		{"EVALSHA " + sha + " 1 counter 5", "1) 5\n2) 1) 1\n   2) (nil)"},
+		{"EVALSHA " + sha + " 1 counter 5", "1) 10\n2) 1) 1\n   2) (nil)"},
+		{"EVALSHA " + sha + " 2 counter", "ERROR: numkeys must be between 0 and the number of arguments"},
+		{"SCRIPT EXISTS " + sha + " 0000", "1\n0"},
+		{"SCRIPT LOAD return (", "ERROR: script:1: unexpected symbol near '<eof>'"},
+		{"SCRIPT FLUSH", "OK"},
+		{"EVALSHA " + sha + " 1 counter 5", "ERROR: NOSCRIPT " + core.ErrNoScript.Error()},
+	}
+	for _, tt := range tests {
+		if got := server.executeCommand("test", tt.cmd); got != tt.expected {
+			t.Errorf("Command: %q, Expected: %q, Got: %q", tt.cmd, tt.expected, got)
+		}
+	}
+}
+
+// TestServer_RESP 验证 RESP 监听：原始字节进、原始字节出，覆盖 RESP2/RESP3 以及共用分发器的命令
+func TestServer_RESP(t *testing.T) {
+	db, _ := core.NewMemDB(&config.Config{})
+	addr := "localhost:9092"
+	server := NewServer("", db, nil)
+	go server.StartRESP(addr)
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Client failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	tests := []struct {
+		req      string
+		expected string
+	}{
+		{"PING\r\n", "+PONG\r\n"},
+		{"*3\r\n$3\r\nSET\r\n$4\r\nname\r\n$11\r\nhello world\r\n", "+OK\r\n"},
+		{"*2\r\n$3\r\nGET\r\n$4\r\nname\r\n", "$11\r\nhello world\r\n"},
+		{"*2\r\n$3\r\nGET\r\n$7\r\nmissing\r\n", "$-1\r\n"},
+		// 回复类型由命令决定，不受值的内容影响
+		{"*3\r\n$3\r\nSET\r\n$1\r\nv\r\n$2\r\nOK\r\n", "+OK\r\n"},
+		{"*2\r\n$3\r\nGET\r\n$1\r\nv\r\n", "$2\r\nOK\r\n"},
+		{"*3\r\n$3\r\nSET\r\n$1\r\nv\r\n$5\r\n(nil)\r\n", "+OK\r\n"},
+		{"*2\r\n$3\r\nGET\r\n$1\r\nv\r\n", "$5\r\n(nil)\r\n"},
+		{"*3\r\n$6\r\nEXISTS\r\n$4\r\nname\r\n$7\r\nmissing\r\n", ":1\r\n"},
+		{"*3\r\n$5\r\nPFADD\r\n$2\r\nhl\r\n$1\r\na\r\n", ":1\r\n"},
+		{"*2\r\n$3\r\nGET\r\n$2\r\nhl\r\n", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		{"*4\r\n$7\r\nBF.MADD\r\n$2\r\nbf\r\n$1\r\na\r\n$1\r\na\r\n", "*2\r\n:1\r\n:0\r\n"},
+		{"*5\r\n$4\r\nEVAL\r\n$41\r\nreturn {KEYS[1], tonumber(ARGV[1]), true}\r\n$1\r\n1\r\n$1\r\nk\r\n$3\r\n1.5\r\n", "*3\r\n$1\r\nk\r\n$3\r\n1.5\r\n:1\r\n"},
+		{"*3\r\n$7\r\nEVALSHA\r\n$4\r\n0000\r\n$1\r\n0\r\n", "-NOSCRIPT " + core.ErrNoScript.Error() + "\r\n"},
+		{"*1\r\n$7\r\nUNKNOWN\r\n", "-ERR Unknown command 'UNKNOWN'\r\n"},
+		// 切换到 RESP3：HELLO 回复 map，nil、浮点、布尔使用 RESP3 类型
+		{"*2\r\n$5\r\nHELLO\r\n$1\r\n3\r\n", "%7\r\n$6\r\nserver\r\n$7\r\nflux-kv\r\n$7\r\nversion\r\n$5\r\n7.0.0\r\n$5\r\nproto\r\n:3\r\n$2\r\nid\r\n:1\r\n$4\r\nmode\r\n$10\r\nstandalone\r\n$4\r\nrole\r\n$6\r\nmaster\r\n$7\r\nmodules\r\n*0\r\n"},
+		{"*2\r\n$3\r\nGET\r\n$7\r\nmissing\r\n", "_\r\n"},
+		{"*3\r\n$4\r\nEVAL\r\n$22\r\nreturn {1.5, false, 2}\r\n$1\r\n0\r\n", "*3\r\n,1.5\r\n#f\r\n:2\r\n"},
+		{"*2\r\n$5\r\nHELLO\r\n$1\r\n4\r\n", "-NOPROTO unsupported protocol version\r\n"},
+	}
+
+	reader := bufio.NewReader(conn)
+	for _, tt := range tests {
+		if _, err := conn.Write([]byte(tt.req)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		got := make([]byte, len(tt.expected))
+		if _, err := io.ReadFull(reader, got); err != nil || string(got) != tt.expected {
+			t.Fatalf("Request: %q, Expected: %q, Got: %q (%v)", tt.req, tt.expected, got, err)
+		}
+	}
+
+	// 协议错误时回复错误并断开
+	conn.Write([]byte("*1\r\n#3\r\n"))
+	line, _ := reader.ReadString('\n')
+	if !strings.HasPrefix(line, "-ERR Protocol error") {
+		t.Errorf("expected protocol error, got %q", line)
+	}
+}
+
+// TestServer_ProtocolV2 验证 HELLO 2 握手后的二进制帧：参数可以包含空白和二进制数据，回复带类型
+func TestServer_ProtocolV2(t *testing.T) {
+	db, _ := core.NewMemDB(&config.Config{})
+	addr := "localhost:9093"
+	server := NewServer(addr, db, nil)
+	go server.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Client failed to connect: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+
+	// 1. 握手：不支持的版本保持 v1
+	for _, tt := range []struct{ cmd, expected string }{
+		{"HELLO 9", "ERROR: unsupported protocol version 9"},
+		{"HELLO 2", "OK version=2"},
+	} {
+		writeMessage(conn, tt.cmd)
+		if resp, err := Decode(conn); err != nil || resp != tt.expected {
+			t.Fatalf("%s: expected %q, got %q (%v)", tt.cmd, tt.expected, resp, err)
+		}
+	}
+
+	// 2. v2 帧
+	value := "hello world\n\x00\xff"
+	tests := []struct {
+		args     []string
+		expected Reply
+	}{
+		{[]string{"SET", "greeting", value}, StatusReply("OK")},
+		{[]string{"GET", "greeting"}, BulkReply(value)},
+		{[]string{"GET", "missing"}, NilReply},
+		{[]string{"DEL", "greeting", "missing"}, IntegerReply(1)},
+		{[]string{"PFADD", "hll", "a b"}, IntegerReply(1)},
+		{[]string{"EVAL", "return {ARGV[1], 1.5, true, nil}", "0", "x y"},
+			ArrayReply(BulkReply("x y"), BulkReply("1.5"), IntegerReply(1), NilReply)},
+		{[]string{"NOPE"}, ErrorReply("ERR Unknown command 'NOPE'")},
+	}
+	for _, tt := range tests {
+		if _, err := conn.Write(wire.EncodeCommand(tt.args...)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		got, err := wire.ReadReply(conn)
+		if err != nil || !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Command: %q, Expected: %+v, Got: %+v (%v)", tt.args, tt.expected, got, err)
+		}
+	}
+
+	// 3. 值原样返回，不会被当作状态、nil 或多行文本
+	for _, v := range []string{"OK", "(nil)", "a\nb", "\x00\xff"} {
+		conn.Write(wire.EncodeCommand("SET", "raw", v))
+		if got, err := wire.ReadReply(conn); err != nil || !reflect.DeepEqual(got, StatusReply("OK")) {
+			t.Fatalf("SET %q: got %+v (%v)", v, got, err)
+		}
+		conn.Write(wire.EncodeCommand("GET", "raw"))
+		if got, err := wire.ReadReply(conn); err != nil || !reflect.DeepEqual(got, BulkReply(v)) {
+			t.Errorf("GET %q: got %+v (%v)", v, got, err)
+		}
+	}
+
+	// 4. 订阅消息以 wire.OpPush 帧推送
+	conn.Write(wire.EncodeCommand("SUBSCRIBE", "news"))
+	if got, _ := wire.ReadReply(conn); !reflect.DeepEqual(got, PushReply(BulkReply("subscribe"), BulkReply("news"), IntegerReply(1))) {
+		t.Fatalf("unexpected subscribe confirmation: %+v", got)
+	}
+	db.Publish("news", "a b\r\nc")
+	if got, _ := wire.ReadReply(conn); !reflect.DeepEqual(got, PushReply(BulkReply("message"), BulkReply("news"), BulkReply("a b\r\nc"))) {
+		t.Fatalf("unexpected message: %+v", got)
+	}
+}
+
+// TestServer_Pipelining 一次写入多个请求，回复按顺序返回；带 ID 的请求按 ID 匹配回复
+func TestServer_Pipelining(t *testing.T) {
+	db, _ := core.NewMemDB(&config.Config{})
+	addr := "localhost:9094"
+	server := NewServer(addr, db, nil)
+	go server.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Client failed to connect: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+
+	// 1. v1 流水线
+	var batch []byte
+	for _, cmd := range []string{"SET a 1", "GET a", "GET b", "HELLO 2"} {
+		frame, _ := Encode(cmd)
+		batch = append(batch, frame...)
+	}
+	// v2 帧紧跟在 HELLO 2 之后发送
+	batch = append(batch, wire.EncodeCommand("SET", "b", "2")...)
+	for i := 0; i < 20; i++ {
+		batch = append(batch, wire.EncodeCommandID(uint64(i), "GET", "b")...)
+	}
+	batch = append(batch, wire.EncodeCommand("GET", "a")...)
+	conn.Write(batch)
+
+	for _, expected := range []string{"OK", "1", "(nil)", "OK version=2"} {
+		if resp, err := Decode(conn); err != nil || resp != expected {
+			t.Fatalf("expected %q, got %q (%v)", expected, resp, err)
+		}
+	}
+
+	// 2. v2：不带 ID 的回复按顺序，带 ID 的回复顺序不定
+	if got, _ := wire.ReadReply(conn); got.Str != "OK" {
+		t.Fatalf("SET b: %+v", got)
+	}
+	seen := map[uint64]bool{}
+	var untagged []Reply
+	for len(seen) < 20 || len(untagged) < 1 {
+		resp, err := wire.ReadResponse(conn)
+		if err != nil {
+			t.Fatalf("wire.ReadResponse failed: %v", err)
+		}
+		if !resp.Tagged {
+			untagged = append(untagged, resp.Reply)
+			continue
+		}
+		if seen[resp.ID] || resp.Reply.Str != "2" {
+			t.Fatalf("unexpected tagged reply: %+v", resp)
+		}
+		seen[resp.ID] = true
+	}
+	if untagged[0].Str != "1" {
+		t.Fatalf("GET a: %+v", untagged[0])
+	}
+
+	// 3. 推送模式命令不能带 ID
+	conn.Write(wire.EncodeCommandID(99, "SUBSCRIBE", "news"))
+	if resp, _ := wire.ReadResponse(conn); resp.ID != 99 || resp.Reply.Kind != ReplyError {
+		t.Fatalf("expected error for tagged SUBSCRIBE, got %+v", resp)
+	}
+}
+
+// TestServer_ShutdownAndLimits 连接数、帧大小限制，CLIENT 命令与优雅关闭
+func TestServer_ShutdownAndLimits(t *testing.T) {
+	cfg := &config.Config{TCP: config.TCPConfig{MaxConns: 2, MaxFrameSize: 1024, ReadTimeout: time.Second}}
+	db, _ := core.NewMemDB(cfg)
+	addr := "localhost:9095"
+	server := NewServer(addr, db, cfg)
+	stopped := make(chan error, 1)
+	go func() { stopped <- server.Start() }()
+	time.Sleep(100 * time.Millisecond)
+
+	send := func(conn net.Conn, cmd string) string {
+		frame, _ := Encode(cmd)
+		conn.Write(frame)
+		resp, err := Decode(conn)
+		if err != nil {
+			t.Fatalf("%s: %v", cmd, err)
+		}
+		return resp
+	}
+
+	c1, _ := net.Dial("tcp", addr)
+	defer c1.Close()
+	c2, _ := net.Dial("tcp", addr)
+	defer c2.Close()
+
+	// 1. CLIENT SETNAME / LIST
+	if resp := send(c1, "CLIENT SETNAME worker-1"); resp != "OK" {
+		t.Fatalf("SETNAME: %q", resp)
+	}
+	if resp := send(c2, "CLIENT LIST"); !strings.Contains(resp, "name=worker-1") || strings.Count(resp, "id=") != 2 {
+		t.Fatalf("CLIENT LIST: %q", resp)
+	}
+
+	// 2. 超过最大连接数
+	c3, _ := net.Dial("tcp", addr)
+	defer c3.Close()
+	if resp, _ := Decode(c3); resp != "ERROR: max number of clients reached" {
+		t.Fatalf("expected max clients error, got %q", resp)
+	}
+
+	// 3. CLIENT KILL ID
+	if resp := send(c2, "CLIENT ID"); resp != "2" {
+		t.Fatalf("CLIENT ID: %q", resp)
+	}
+	if resp := send(c2, "CLIENT KILL ID 1"); resp != "1" {
+		t.Fatalf("CLIENT KILL: %q", resp)
+	}
+	c1.SetDeadline(time.Now().Add(time.Second))
+	if _, err := Decode(c1); err == nil {
+		t.Fatal("killed connection should be closed")
+	}
+
+	// 4. 超过 max_frame_size 的帧断开连接
+	frame, _ := Encode("SET big " + strings.Repeat("x", 2048))
+	c2.Write(frame)
+	c2.SetDeadline(time.Now().Add(time.Second))
+	if _, err := Decode(c2); err == nil {
+		t.Fatal("oversized frame should close the connection")
+	}
+
+	// 推送模式下客户端发来的帧同样受 max_frame_size 限制
+	c5, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial after oversized frame: %v", err)
+	}
+	defer c5.Close()
+	if resp := send(c5, "SUBSCRIBE news"); resp != "subscribe news 1" {
+		t.Fatalf("SUBSCRIBE: %q", resp)
+	}
+	frame, _ = Encode("SUBSCRIBE " + strings.Repeat("x", 2048))
+	c5.Write(frame)
+	c5.SetDeadline(time.Now().Add(time.Second))
+	if _, err := Decode(c5); err == nil {
+		t.Fatal("oversized frame in PUBSUB mode should close the connection")
+	}
+
+	// 5. Shutdown 关闭空闲连接，Start 返回 ErrServerClosed
+	c4, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial after kill: %v", err)
+	}
+	defer c4.Close()
+	if resp := send(c4, "SET k v"); resp != "OK" {
+		t.Fatalf("SET: %q", resp)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if err := <-stopped; err != ErrServerClosed {
+		t.Fatalf("Start returned %v", err)
+	}
+	if _, err := net.Dial("tcp", addr); err == nil {
+		t.Fatal("listener should be closed")
+	}
+}
+
+// TestServer_ACL 验证 AUTH 认证、命令类别和 Key 模式的检查，以及 ACL SETUSER 运行时生效
+func TestServer_ACL(t *testing.T) {
+	cfg := &config.Config{ACL: config.ACLConfig{Enabled: true, Users: []config.ACLUserConfig{
+		{Name: "admin", Passwords: []string{"s3cret"}, Categories: []string{"all"}, Keys: []string{"*"}},
+		{Name: "app", Tokens: []string{"app-token"}, Categories: []string{"read", "write"}, Keys: []string{"app:*"}},
+	}}}
+	db, err := core.NewMemDB(cfg)
+	if err != nil {
+		t.Fatalf("NewMemDB: %v", err)
+	}
+	addr := "localhost:9096"
+	server := NewServer(addr, db, cfg)
+	go server.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Client failed to connect: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+
+	tests := []struct {
+		cmd      string
+		expected string
+	}{
+		// 1. 没有 default 用户，未认证的连接只能执行连接级命令
+		{"SET app:1 v", "ERROR: " + acl.ErrAuthRequired.Error()},
+		{"ACL WHOAMI", "default"},
+		{"AUTH app wrong", "ERROR: " + acl.ErrWrongPass.Error()},
+		// 2. 令牌认证后按 app 的权限检查
+		{"AUTH app-token", "OK"},
+		{"SET app:1 v", "OK"},
+		{"GET app:1", "v"},
+		{"SET other v", "ERROR: NOPERM User app has no permissions to access the 'other' key"},
+		{"DEL app:1 other", "ERROR: NOPERM User app has no permissions to access the 'other' key"},
+		{"INFO", "ERROR: NOPERM User app has no permissions to run admin commands"},
+		{"ACL LIST", "ERROR: NOPERM User app has no permissions to run admin commands"},
+		// 按前缀或命名空间访问 Key 的命令，前缀要被某个 "literal*" 模式完整覆盖
+		{"WATCH app PREFIX", "ERROR: NOPERM User app has no permissions to access the 'app*' key"},
+		{"FIND other WHERE f = 1", "ERROR: NOPERM User app has no permissions to access the 'other:*' key"},
+		{"JSON.INDEX CREATE other f TAG", "ERROR: NOPERM User app has no permissions to access the 'other:*' key"},
+		{"JSON.INDEX LIST", "ERROR: NOPERM User app has no permissions to access the '*' key"},
+		{"JSON.INDEX CREATE app f TAG", "OK"},
+		{"TS.MRANGE 0 0 FILTER a=b", "ERROR: NOPERM User app has no permissions to access the '*' key"},
+		// 3. 切换为 admin 后修改 app 的权限，立即生效
+		{"AUTH admin s3cret", "OK"},
+		{"ACL SETUSER app $app-token +@read ~app:*", "OK"},
+		{"ACL USERS", "1) admin\n2) app"},
+		{"AUTH app-token", "OK"},
+		{"ACL WHOAMI", "app"},
+		{"GET app:1", "v"},
+		{"SET app:1 v2", "ERROR: NOPERM User app has no permissions to run write commands"},
+		// 4. 删除用户后已认证的连接被拒绝
+		{"AUTH admin s3cret", "OK"},
+		{"ACL DELUSER app", "1"},
+		{"AUTH app-token", "ERROR: " + acl.ErrWrongPass.Error()},
+	}
+	for _, tt := range tests {
+		writeMessage(conn, tt.cmd)
+		if resp, err := Decode(conn); err != nil || resp != tt.expected {
+			t.Fatalf("%s: expected %q, got %q (%v)", tt.cmd, tt.expected, resp, err)
+		}
+	}
+
+	// 5. RESP 的 HELLO AUTH 认证失败时不切换协议
+	respAddr := "localhost:9097"
+	go server.StartRESP(respAddr)
+	time.Sleep(100 * time.Millisecond)
+	rc, err := net.Dial("tcp", respAddr)
+	if err != nil {
+		t.Fatalf("Client failed to connect: %v", err)
+	}
+	defer rc.Close()
+	rc.SetDeadline(time.Now().Add(2 * time.Second))
+	reader := bufio.NewReader(rc)
+	rc.Write([]byte("HELLO 3 AUTH admin wrong\r\nGET app:1\r\nHELLO 2 AUTH admin s3cret\r\nGET app:1\r\n"))
+	for _, expected := range []string{
+		"-" + acl.ErrWrongPass.Error() + "\r\n",
+		"-" + acl.ErrAuthRequired.Error() + "\r\n",
+	} {
+		if line, _ := reader.ReadString('\n'); line != expected {
+			t.Fatalf("expected %q, got %q", expected, line)
+		}
+	}
+	// 跳过 HELLO 的 map 回复（RESP2 下为 14 个元素的数组）
+	if line, _ := reader.ReadString('\n'); line != "*14\r\n" {
+		t.Fatalf("unexpected HELLO reply %q", line)
+	}
+	for i := 0; i < 14; i++ {
+		line, _ := reader.ReadString('\n')
+		if strings.HasPrefix(line, "$") {
+			reader.ReadString('\n')
+		}
+	}
+	if line, _ := reader.ReadString('\n'); line != "$1\r\n" {
+		t.Fatalf("expected GET after HELLO AUTH to succeed, got %q", line)
+	}
+}
+
+// TestServer_Memcache 验证 memcached 文本协议的存储命令、CAS、incr / decr、touch 和 noreply
+func TestServer_Memcache(t *testing.T) {
+	db, _ := core.NewMemDB(&config.Config{})
+	addr := "localhost:9098"
+	server := NewServer("", db, nil)
+	go server.StartMemcache(addr)
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Client failed to connect: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+	reader := bufio.NewReader(conn)
+
+	exchange := func(req, expected string) {
+		t.Helper()
+		conn.Write([]byte(req))
+		got := make([]byte, len(expected))
+		if _, err := io.ReadFull(reader, got); err != nil || string(got) != expected {
+			t.Fatalf("Request: %q, Expected: %q, Got: %q (%v)", req, expected, got, err)
+		}
+	}
+
+	exchange("set greeting 5 0 12\r\nhello\r\nworld\r\n", "STORED\r\n")
+	exchange("get greeting missing\r\n", "VALUE greeting 5 12\r\nhello\r\nworld\r\nEND\r\n")
+	exchange("add greeting 0 0 1\r\nx\r\n", "NOT_STORED\r\n")
+	exchange("replace missing 0 0 1\r\nx\r\n", "NOT_STORED\r\n")
+
+	// gets 返回的 CAS 只能使用一次
+	item, _, _ := db.CacheGet("greeting")
+	cas := strconv.FormatUint(item.CAS, 10)
+	exchange("gets greeting\r\n", "VALUE greeting 5 12 "+cas+"\r\nhello\r\nworld\r\nEND\r\n")
+	exchange("cas greeting 1 0 2 "+cas+"\r\nhi\r\n", "STORED\r\n")
+	exchange("cas greeting 1 0 2 "+cas+"\r\nyo\r\n", "EXISTS\r\n")
+	exchange("cas missing 0 0 1 1\r\nx\r\n", "NOT_FOUND\r\n")
+
+	// 其他协议读取同一个值
+	if v, _ := db.Get("greeting"); v != "hi" {
+		t.Fatalf("native GET after cas: %v", v)
+	}
+
+	exchange("set counter 0 0 2\r\n10\r\n", "STORED\r\n")
+	exchange("incr counter 5\r\n", "15\r\n")
+	exchange("decr counter 100\r\n", "0\r\n")
+	exchange("incr greeting 1\r\n", "CLIENT_ERROR "+core.ErrNotNumber.Error()+"\r\n")
+	exchange("incr missing 1\r\n", "NOT_FOUND\r\n")
+	exchange("touch counter 100\r\n", "TOUCHED\r\n")
+	exchange("touch missing 100\r\n", "NOT_FOUND\r\n")
+
+	// noreply 的命令没有回复，流水线中后面的命令正常回复
+	exchange("set quiet 0 0 1 noreply\r\nq\r\ndelete counter noreply\r\nget quiet counter\r\n", "VALUE quiet 0 1\r\nq\r\nEND\r\n")
+	exchange("delete quiet\r\ndelete quiet\r\n", "DELETED\r\nNOT_FOUND\r\n")
+
+	// 负的 exptime 立即过期
+	exchange("set gone 0 -1 1\r\nx\r\nget gone\r\n", "STORED\r\nEND\r\n")
+	exchange("bogus\r\n", "ERROR\r\n")
+	exchange("version\r\n", "VERSION "+mcVersion+"\r\n")
+}
@@ -0,0 +1,146 @@
+package protocol
+
+import (
+	"Flux-KV/internal/core"
+	"strconv"
+	"strings"
+)
+
+// sketchCommand 处理 HyperLogLog / 布隆过滤器 / Count-Min Sketch 命令
+func (s *Server) sketchCommand(cmd string, args []string) Reply {
+	switch cmd {
+	case "PFADD":
+		// PFADD key element [element ...]，返回 1 表示估算值可能变化
+		if len(args) < 1 {
+			return ErrorReply("ERR PFADD requires key")
+		}
+		changed, err := s.store.PFAdd(args[0], args[1:]...)
+		if err != nil {
+			return errReply(err)
+		}
+		return IntegerReply(boolInt(changed))
+	case "PFCOUNT":
+		// PFCOUNT key [key ...]
+		if len(args) < 1 {
+			return ErrorReply("ERR PFCOUNT requires at least one key")
+		}
+		n, err := s.store.PFCount(args...)
+		if err != nil {
+			return errReply(err)
+		}
+		return IntegerReply(int64(n))
+	case "PFMERGE":
+		// PFMERGE dest source [source ...]
+		if len(args) < 2 {
+			return ErrorReply("ERR PFMERGE requires dest and at least one source")
+		}
+		if err := s.store.PFMerge(args[0], args[1:]...); err != nil {
+			return errReply(err)
+		}
+		return StatusReply("OK")
+	case "BF.RESERVE":
+		// BF.RESERVE key error_rate capacity
+		if len(args) != 3 {
+			return ErrorReply("ERR BF.RESERVE requires key, error_rate and capacity")
+		}
+		errorRate, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			return ErrorReply("ERR error_rate must be a number")
+		}
+		capacity, err := strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			return ErrorReply("ERR capacity must be a positive integer")
+		}
+		if err := s.store.BFReserve(args[0], errorRate, capacity); err != nil {
+			return errReply(err)
+		}
+		return StatusReply("OK")
+	case "BF.ADD", "BF.MADD", "BF.EXISTS", "BF.MEXISTS":
+		// BF.ADD key item / BF.MADD key item [item ...]，EXISTS 同理
+		single := cmd == "BF.ADD" || cmd == "BF.EXISTS"
+		if len(args) < 2 || (single && len(args) != 2) {
+			return errorf("%s requires key and item", cmd)
+		}
+		var results []bool
+		var err error
+		if strings.HasSuffix(cmd, "ADD") {
+			results, err = s.store.BFAdd(args[0], args[1:]...)
+		} else {
+			results, err = s.store.BFExists(args[0], args[1:]...)
+		}
+		if err != nil {
+			return errReply(err)
+		}
+		// 单个元素的命令回复整数，批量命令回复整数数组
+		if single {
+			return IntegerReply(boolInt(results[0]))
+		}
+		return unnumbered(boolsReply(results))
+	case "CMS.INITBYDIM", "CMS.INITBYPROB":
+		return s.cmsInit(cmd, args)
+	case "CMS.INCRBY":
+		// CMS.INCRBY key item increment [item increment ...]
+		if len(args) < 3 || len(args)%2 != 1 {
+			return ErrorReply("ERR CMS.INCRBY requires key and item increment pairs")
+		}
+		items := make([]string, 0, len(args)/2)
+		incrs := make([]uint32, 0, len(args)/2)
+		for i := 1; i < len(args); i += 2 {
+			n, err := strconv.ParseUint(args[i+1], 10, 32)
+			if err != nil {
+				return ErrorReply("ERR increment must be a non-negative integer")
+			}
+			items = append(items, args[i])
+			incrs = append(incrs, uint32(n))
+		}
+		counts, err := s.store.CMSIncrBy(args[0], items, incrs)
+		if err != nil {
+			return errReply(err)
+		}
+		return unnumbered(intsReply(counts))
+	case "CMS.QUERY":
+		// CMS.QUERY key item [item ...]
+		if len(args) < 2 {
+			return ErrorReply("ERR CMS.QUERY requires key and at least one item")
+		}
+		counts, err := s.store.CMSQuery(args[0], args[1:]...)
+		if err != nil {
+			return errReply(err)
+		}
+		return unnumbered(intsReply(counts))
+	default:
+		return errorf("Unknown command '%s'", cmd)
+	}
+}
+
+// cmsInit CMS.INITBYDIM key width depth / CMS.INITBYPROB key error probability
+func (s *Server) cmsInit(cmd string, args []string) Reply {
+	if len(args) != 3 {
+		return errorf("%s requires key and two parameters", cmd)
+	}
+
+	var width, depth uint32
+	if cmd == "CMS.INITBYDIM" {
+		w, err1 := strconv.ParseUint(args[1], 10, 32)
+		d, err2 := strconv.ParseUint(args[2], 10, 32)
+		if err1 != nil || err2 != nil {
+			return ErrorReply("ERR width and depth must be positive integers")
+		}
+		width, depth = uint32(w), uint32(d)
+	} else {
+		errorRate, err1 := strconv.ParseFloat(args[1], 64)
+		prob, err2 := strconv.ParseFloat(args[2], 64)
+		if err1 != nil || err2 != nil {
+			return ErrorReply("ERR error and probability must be numbers")
+		}
+		var err error
+		if width, depth, err = core.CMSDimsByProb(errorRate, prob); err != nil {
+			return errReply(err)
+		}
+	}
+
+	if err := s.store.CMSInit(args[0], width, depth); err != nil {
+		return errReply(err)
+	}
+	return StatusReply("OK")
+}
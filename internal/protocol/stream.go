@@ -0,0 +1,384 @@
+package protocol
+
+import (
+	"Flux-KV/internal/core"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// streamCommand 处理 Stream 相关命令，cmd 为大写命令名，args 不含命令名
+func (s *Server) streamCommand(cmd string, args []string) Reply {
+	switch cmd {
+	case "XADD":
+		return s.xadd(args)
+	case "XLEN":
+		// XLEN key
+		if len(args) != 1 {
+			return ErrorReply("ERR XLEN requires key")
+		}
+		n, err := s.store.XLen(args[0])
+		if err != nil {
+			return errReply(err)
+		}
+		return IntegerReply(int64(n))
+	case "XRANGE", "XREVRANGE":
+		return s.xrange(cmd, args)
+	case "XTRIM":
+		return s.xtrim(args)
+	case "XREAD":
+		return s.xread(args, false)
+	case "XREADGROUP":
+		return s.xread(args, true)
+	case "XGROUP":
+		return s.xgroup(args)
+	case "XACK":
+		// XACK key group id [id ...]
+		if len(args) < 3 {
+			return ErrorReply("ERR XACK requires key, group and at least one id")
+		}
+		n, err := s.store.XAck(args[0], args[1], args[2:]...)
+		if err != nil {
+			return errReply(err)
+		}
+		return IntegerReply(int64(n))
+	case "XPENDING":
+		return s.xpending(args)
+	case "XCLAIM":
+		// XCLAIM key group consumer min-idle-ms id [id ...]
+		if len(args) < 5 {
+			return ErrorReply("ERR XCLAIM requires key, group, consumer, min-idle-ms and at least one id")
+		}
+		minIdle, err := strconv.ParseInt(args[3], 10, 64)
+		if err != nil || minIdle < 0 {
+			return ErrorReply("ERR min-idle-ms must be a non-negative integer")
+		}
+		entries, err := s.store.XClaim(args[0], args[1], args[2], time.Duration(minIdle)*time.Millisecond, args[4:])
+		if err != nil {
+			return errReply(err)
+		}
+		return streamEntriesReply(entries)
+	default:
+		return errorf("Unknown command '%s'", cmd)
+	}
+}
+
+// xadd XADD key [MAXLEN n] id field value [field value ...]
+func (s *Server) xadd(args []string) Reply {
+	if len(args) < 4 {
+		return ErrorReply("ERR XADD requires key, id and field value pairs")
+	}
+	key, rest, maxLen := args[0], args[1:], 0
+	if strings.EqualFold(rest[0], "MAXLEN") {
+		if len(rest) < 2 {
+			return ErrorReply("ERR MAXLEN requires a number")
+		}
+		n, err := strconv.Atoi(rest[1])
+		if err != nil || n < 0 {
+			return ErrorReply("ERR MAXLEN must be a non-negative integer")
+		}
+		maxLen, rest = n, rest[2:]
+	}
+	if len(rest) < 3 {
+		return ErrorReply("ERR XADD requires id and field value pairs")
+	}
+
+	id, err := s.store.XAdd(key, rest[0], rest[1:], maxLen)
+	if err != nil {
+		return errReply(err)
+	}
+	return BulkReply(id.String())
+}
+
+// xrange XRANGE key start end [COUNT n] / XREVRANGE key end start [COUNT n]
+func (s *Server) xrange(cmd string, args []string) Reply {
+	if len(args) != 3 && len(args) != 5 {
+		return errorf("%s requires key, start and end", cmd)
+	}
+	count := 0
+	if len(args) == 5 {
+		if !strings.EqualFold(args[3], "COUNT") {
+			return errorf("Unknown %s option '%s'", cmd, args[3])
+		}
+		n, err := strconv.Atoi(args[4])
+		if err != nil {
+			return ErrorReply("ERR COUNT must be an integer")
+		}
+		count = n
+	}
+
+	var entries []core.StreamEntry
+	var err error
+	if cmd == "XRANGE" {
+		entries, err = s.store.XRange(args[0], args[1], args[2], count)
+	} else {
+		entries, err = s.store.XRevRange(args[0], args[1], args[2], count)
+	}
+	if err != nil {
+		return errReply(err)
+	}
+	return streamEntriesReply(entries)
+}
+
+// xtrim XTRIM key MAXLEN|MINID threshold
+func (s *Server) xtrim(args []string) Reply {
+	if len(args) != 3 {
+		return ErrorReply("ERR XTRIM requires key, MAXLEN|MINID and threshold")
+	}
+
+	var removed int
+	var err error
+	switch strings.ToUpper(args[1]) {
+	case "MAXLEN":
+		n, convErr := strconv.Atoi(args[2])
+		if convErr != nil {
+			return ErrorReply("ERR MAXLEN must be an integer")
+		}
+		removed, err = s.store.XTrimMaxLen(args[0], n)
+	case "MINID":
+		removed, err = s.store.XTrimMinID(args[0], args[2])
+	default:
+		return errorf("Unknown XTRIM strategy '%s'", args[1])
+	}
+	if err != nil {
+		return errReply(err)
+	}
+	return IntegerReply(int64(removed))
+}
+
+// xread 解析并执行
+//
+//	XREAD [COUNT n] [BLOCK ms] STREAMS key [key ...] id [id ...]
+//	XREADGROUP GROUP group consumer [COUNT n] [BLOCK ms] [NOACK] STREAMS key [key ...] id [id ...]
+//
+// BLOCK 0 表示一直等待，超时返回 (nil)
+func (s *Server) xread(args []string, withGroup bool) Reply {
+	cmd := "XREAD"
+	if withGroup {
+		cmd = "XREADGROUP"
+	}
+
+	var group, consumer string
+	count, block, noAck := 0, time.Duration(-1), false
+	i := 0
+	if withGroup {
+		if len(args) < 3 || !strings.EqualFold(args[0], "GROUP") {
+			return ErrorReply("ERR XREADGROUP requires GROUP group consumer")
+		}
+		group, consumer, i = args[1], args[2], 3
+	}
+
+	// 1. 解析选项，直到 STREAMS
+	for ; i < len(args) && !strings.EqualFold(args[i], "STREAMS"); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "COUNT", "BLOCK":
+			if i+1 >= len(args) {
+				return errorf("%s requires a number", strings.ToUpper(args[i]))
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n < 0 {
+				return errorf("%s must be a non-negative integer", strings.ToUpper(args[i]))
+			}
+			if strings.EqualFold(args[i], "COUNT") {
+				count = n
+			} else {
+				block = time.Duration(n) * time.Millisecond
+			}
+			i++
+		case "NOACK":
+			if !withGroup {
+				return ErrorReply("ERR NOACK is only valid for XREADGROUP")
+			}
+			noAck = true
+		default:
+			return errorf("Unknown %s option '%s'", cmd, args[i])
+		}
+	}
+
+	// 2. STREAMS 之后前一半是 Key，后一半是 ID
+	rest := args[min(i+1, len(args)):]
+	if i >= len(args) || len(rest) == 0 || len(rest)%2 != 0 {
+		return errorf("%s requires STREAMS followed by keys and the same number of ids", cmd)
+	}
+	keys, ids := rest[:len(rest)/2], rest[len(rest)/2:]
+
+	// 3. 阻塞时间由 context 控制
+	ctx := context.Background()
+	if block > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, block)
+		defer cancel()
+	}
+
+	var results []core.StreamResult
+	var err error
+	if withGroup {
+		results, err = s.store.XReadGroup(ctx, group, consumer, keys, ids, count, block >= 0, noAck)
+	} else {
+		results, err = s.store.XRead(ctx, keys, ids, count, block >= 0)
+	}
+	if err != nil {
+		return errReply(err)
+	}
+	if len(results) == 0 {
+		return NilReply
+	}
+
+	// 与 Redis 一致，每个 Stream 为 [key, entries]；文本协议每条消息一行并带上 Key
+	elems := make([]Reply, len(results))
+	text := make([]string, len(results))
+	for i, r := range results {
+		elems[i] = ArrayReply(BulkReply(r.Key), streamEntriesReply(r.Entries))
+		text[i] = formatStreamEntries(r.Entries, r.Key)
+	}
+	return withText(ArrayReply(elems...), strings.Join(text, "\n"))
+}
+
+// xgroup XGROUP CREATE key group id|$ [MKSTREAM] / XGROUP DESTROY key group
+func (s *Server) xgroup(args []string) Reply {
+	if len(args) == 0 {
+		return ErrorReply("ERR XGROUP requires subcommand CREATE|DESTROY")
+	}
+
+	switch strings.ToUpper(args[0]) {
+	case "CREATE":
+		if len(args) != 4 && len(args) != 5 {
+			return ErrorReply("ERR XGROUP CREATE requires key, group and id")
+		}
+		mkStream := len(args) == 5 && strings.EqualFold(args[4], "MKSTREAM")
+		if len(args) == 5 && !mkStream {
+			return errorf("Unknown XGROUP CREATE option '%s'", args[4])
+		}
+		if err := s.store.XGroupCreate(args[1], args[2], args[3], mkStream); err != nil {
+			return errReply(err)
+		}
+		return StatusReply("OK")
+	case "DESTROY":
+		if len(args) != 3 {
+			return ErrorReply("ERR XGROUP DESTROY requires key and group")
+		}
+		ok, err := s.store.XGroupDestroy(args[1], args[2])
+		if err != nil {
+			return errReply(err)
+		}
+		return IntegerReply(boolInt(ok))
+	default:
+		return errorf("Unknown XGROUP subcommand '%s'", args[0])
+	}
+}
+
+// xpending XPENDING key group [[IDLE ms] start end count [consumer]]
+func (s *Server) xpending(args []string) Reply {
+	if len(args) < 2 {
+		return ErrorReply("ERR XPENDING requires key and group")
+	}
+	key, group, rest := args[0], args[1], args[2:]
+
+	// 1. 只有 key 和 group 时返回概况
+	if len(rest) == 0 {
+		summary, err := s.store.XPending(key, group)
+		if err != nil {
+			return errReply(err)
+		}
+		// 与 Redis 一致: [count, min, max, [[consumer, count] ...]]，没有待确认消息时后三项为 nil
+		if summary.Count == 0 {
+			return withText(ArrayReply(IntegerReply(0), NilReply, NilReply, NilReply), "count=0")
+		}
+		names := make([]string, 0, len(summary.Consumers))
+		for name := range summary.Consumers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		consumers := make([]Reply, len(names))
+		parts := make([]string, len(names))
+		for i, name := range names {
+			n := summary.Consumers[name]
+			consumers[i] = ArrayReply(BulkReply(name), BulkReply(strconv.FormatInt(n, 10)))
+			parts[i] = fmt.Sprintf("%s:%d", name, n)
+		}
+		r := ArrayReply(IntegerReply(summary.Count), BulkReply(summary.MinID.String()), BulkReply(summary.MaxID.String()), ArrayReply(consumers...))
+		return withText(r, fmt.Sprintf("count=%d min=%s max=%s consumers=%s",
+			summary.Count, summary.MinID, summary.MaxID, strings.Join(parts, ",")))
+	}
+
+	// 2. 明细查询
+	var minIdle time.Duration
+	if strings.EqualFold(rest[0], "IDLE") {
+		if len(rest) < 2 {
+			return ErrorReply("ERR IDLE requires milliseconds")
+		}
+		ms, err := strconv.ParseInt(rest[1], 10, 64)
+		if err != nil || ms < 0 {
+			return ErrorReply("ERR IDLE must be a non-negative integer")
+		}
+		minIdle, rest = time.Duration(ms)*time.Millisecond, rest[2:]
+	}
+	if len(rest) != 3 && len(rest) != 4 {
+		return ErrorReply("ERR XPENDING requires start, end and count")
+	}
+	count, err := strconv.Atoi(rest[2])
+	if err != nil {
+		return ErrorReply("ERR count must be an integer")
+	}
+	consumer := ""
+	if len(rest) == 4 {
+		consumer = rest[3]
+	}
+
+	entries, err := s.store.XPendingRange(key, group, rest[0], rest[1], count, consumer, minIdle)
+	if err != nil {
+		return errReply(err)
+	}
+	// 每条为 [id, consumer, idle_ms, deliveries]
+	elems := make([]Reply, len(entries))
+	lines := make([]string, len(entries))
+	for i, e := range entries {
+		elems[i] = ArrayReply(BulkReply(e.ID.String()), BulkReply(e.Consumer), IntegerReply(e.Idle.Milliseconds()), IntegerReply(e.Deliveries))
+		lines[i] = fmt.Sprintf("%d) %s consumer=%s idle=%dms deliveries=%d",
+			i+1, e.ID, e.Consumer, e.Idle.Milliseconds(), e.Deliveries)
+	}
+	if len(entries) == 0 {
+		return ArrayReply()
+	}
+	return withText(ArrayReply(elems...), strings.Join(lines, "\n"))
+}
+
+// streamEntriesReply 与 Redis 一致，每条消息为 [id, [field, value, ...]]，已被裁剪的消息字段为 nil
+func streamEntriesReply(entries []core.StreamEntry) Reply {
+	elems := make([]Reply, len(entries))
+	for i, e := range entries {
+		fields := NilReply
+		if len(e.Fields) > 0 {
+			fs := make([]Reply, len(e.Fields))
+			for j, f := range e.Fields {
+				fs[j] = BulkReply(f)
+			}
+			fields = ArrayReply(fs...)
+		}
+		elems[i] = ArrayReply(BulkReply(e.ID.String()), fields)
+	}
+	return withText(ArrayReply(elems...), formatStreamEntries(entries, ""))
+}
+
+// formatStreamEntries 文本协议中每条消息一行: [key] id field value ...
+// 已被裁剪的消息只输出 ID
+func formatStreamEntries(entries []core.StreamEntry, key string) string {
+	if len(entries) == 0 {
+		return "(empty list)"
+	}
+	lines := make([]string, 0, len(entries))
+	for _, e := range entries {
+		line := e.ID.String()
+		if len(e.Fields) > 0 {
+			line += " " + strings.Join(e.Fields, " ")
+		}
+		if key != "" {
+			line = key + " " + line
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
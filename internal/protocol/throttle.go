@@ -0,0 +1,43 @@
+package protocol
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// throttleCommand THROTTLE key rate burst [cost]
+// 回复 [allowed, limit, remaining, retry_after_ms, reset_after_ms]，文本协议为 allowed=1|0 limit=N remaining=N retry_after_ms=N reset_after_ms=N
+func (s *Server) throttleCommand(args []string) Reply {
+	if len(args) != 3 && len(args) != 4 {
+		return ErrorReply("ERR THROTTLE requires key, rate and burst")
+	}
+	rate, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		return ErrorReply("ERR rate must be a number")
+	}
+	burst, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil {
+		return ErrorReply("ERR burst must be an integer")
+	}
+	cost := int64(1)
+	if len(args) == 4 {
+		if cost, err = strconv.ParseInt(args[3], 10, 64); err != nil {
+			return ErrorReply("ERR cost must be an integer")
+		}
+	}
+
+	res, err := s.store.Throttle(args[0], rate, burst, cost)
+	if err != nil {
+		return errReply(err)
+	}
+	allowed, retryAfter, resetAfter := boolInt(res.Allowed), ceilMillis(res.RetryAfter), ceilMillis(res.ResetAfter)
+	r := ArrayReply(IntegerReply(allowed), IntegerReply(res.Limit), IntegerReply(res.Remaining), IntegerReply(retryAfter), IntegerReply(resetAfter))
+	return withText(r, fmt.Sprintf("allowed=%d limit=%d remaining=%d retry_after_ms=%d reset_after_ms=%d",
+		allowed, res.Limit, res.Remaining, retryAfter, resetAfter))
+}
+
+// ceilMillis 向上取整到毫秒，按返回值等待后重试一定能放行
+func ceilMillis(d time.Duration) int64 {
+	return int64((d + time.Millisecond - 1) / time.Millisecond)
+}
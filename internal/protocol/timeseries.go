@@ -0,0 +1,314 @@
+package protocol
+
+import (
+	"Flux-KV/internal/core"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tsCommand 处理时间序列命令
+func (s *Server) tsCommand(cmd string, args []string) Reply {
+	switch cmd {
+	case "TS.CREATE":
+		// TS.CREATE key [RETENTION ms] [LABELS label value ...]
+		if len(args) < 1 {
+			return ErrorReply("ERR TS.CREATE requires key")
+		}
+		opts, err := parseTSOptions(args[1:])
+		if err != nil {
+			return errReply(err)
+		}
+		if err := s.store.TSCreate(args[0], opts); err != nil {
+			return errReply(err)
+		}
+		return StatusReply("OK")
+	case "TS.ADD":
+		// TS.ADD key timestamp|* value [RETENTION ms] [LABELS label value ...]
+		if len(args) < 3 {
+			return ErrorReply("ERR TS.ADD requires key, timestamp and value")
+		}
+		ts := time.Now().UnixMilli()
+		if args[1] != "*" {
+			n, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return ErrorReply("ERR timestamp must be an integer or *")
+			}
+			ts = n
+		}
+		value, err := strconv.ParseFloat(args[2], 64)
+		if err != nil {
+			return ErrorReply("ERR value must be a number")
+		}
+		opts, err := parseTSOptions(args[3:])
+		if err != nil {
+			return errReply(err)
+		}
+		if err := s.store.TSAdd(args[0], ts, value, opts); err != nil {
+			return errReply(err)
+		}
+		return IntegerReply(ts)
+	case "TS.GET":
+		// TS.GET key
+		if len(args) != 1 {
+			return ErrorReply("ERR TS.GET requires key")
+		}
+		sample, ok, err := s.store.TSGet(args[0])
+		if err != nil {
+			return errReply(err)
+		}
+		if !ok {
+			return NilReply
+		}
+		return tsSampleReply(sample)
+	case "TS.RANGE":
+		return s.tsRange(args)
+	case "TS.MRANGE":
+		return s.tsMRange(args)
+	case "TS.CREATERULE":
+		// TS.CREATERULE source dest AGGREGATION type bucket
+		if len(args) != 5 || !strings.EqualFold(args[2], "AGGREGATION") {
+			return ErrorReply("ERR TS.CREATERULE requires source, dest and AGGREGATION type bucket")
+		}
+		agg, err := parseTSAggregation(args[3], args[4])
+		if err != nil {
+			return errReply(err)
+		}
+		if err := s.store.TSCreateRule(args[0], args[1], *agg); err != nil {
+			return errReply(err)
+		}
+		return StatusReply("OK")
+	case "TS.DELETERULE":
+		// TS.DELETERULE source dest
+		if len(args) != 2 {
+			return ErrorReply("ERR TS.DELETERULE requires source and dest")
+		}
+		if err := s.store.TSDeleteRule(args[0], args[1]); err != nil {
+			return errReply(err)
+		}
+		return StatusReply("OK")
+	case "TS.INFO":
+		// TS.INFO key
+		if len(args) != 1 {
+			return ErrorReply("ERR TS.INFO requires key")
+		}
+		info, err := s.store.TSInfo(args[0])
+		if err != nil {
+			return errReply(err)
+		}
+		// 与 RedisTimeSeries 一致，为字段名和值交替的数组；文本协议为一行 name=value
+		labels := make([]Reply, 0, len(info.Labels))
+		for _, name := range sortedLabels(info.Labels) {
+			labels = append(labels, ArrayReply(BulkReply(name), BulkReply(info.Labels[name])))
+		}
+		rules := make([]Reply, len(info.Rules))
+		ruleText := make([]string, len(info.Rules))
+		for i, r := range info.Rules {
+			rules[i] = ArrayReply(BulkReply(r.Dest), IntegerReply(r.Aggregation.Bucket), BulkReply(r.Aggregation.Type))
+			ruleText[i] = fmt.Sprintf("%s:%s:%d", r.Dest, r.Aggregation.Type, r.Aggregation.Bucket)
+		}
+		reply := ArrayReply(
+			BulkReply("totalSamples"), IntegerReply(int64(info.TotalSamples)),
+			BulkReply("memoryUsage"), IntegerReply(info.MemoryUsage),
+			BulkReply("chunkCount"), IntegerReply(int64(info.ChunkCount)),
+			BulkReply("firstTimestamp"), IntegerReply(info.FirstTimestamp),
+			BulkReply("lastTimestamp"), IntegerReply(info.LastTimestamp),
+			BulkReply("retentionTime"), IntegerReply(info.Retention.Milliseconds()),
+			BulkReply("labels"), ArrayReply(labels...),
+			BulkReply("rules"), ArrayReply(rules...),
+		)
+		return withText(reply, fmt.Sprintf("totalSamples=%d memoryUsage=%d chunkCount=%d firstTimestamp=%d lastTimestamp=%d retention=%d labels=%s rules=%s",
+			info.TotalSamples, info.MemoryUsage, info.ChunkCount, info.FirstTimestamp, info.LastTimestamp,
+			info.Retention.Milliseconds(), formatLabels(info.Labels), strings.Join(ruleText, ",")))
+	default:
+		return errorf("Unknown command '%s'", cmd)
+	}
+}
+
+// tsRange TS.RANGE key from to [COUNT n] [AGGREGATION type bucket]
+// from / to 可以用 - / + 表示最早和最新
+func (s *Server) tsRange(args []string) Reply {
+	if len(args) < 3 {
+		return ErrorReply("ERR TS.RANGE requires key, from and to")
+	}
+	from, to, err := parseTSBounds(args[1], args[2])
+	if err != nil {
+		return errReply(err)
+	}
+	count, agg, rest, err := parseTSRangeOptions(args[3:])
+	if err != nil {
+		return errReply(err)
+	}
+	if len(rest) > 0 {
+		return errorf("Unknown TS.RANGE option '%s'", rest[0])
+	}
+
+	samples, err := s.store.TSRange(args[0], from, to, agg, count)
+	if err != nil {
+		return errReply(err)
+	}
+	return tsSamplesReply(samples)
+}
+
+// tsMRange TS.MRANGE from to [COUNT n] [AGGREGATION type bucket] FILTER filter ...
+// 与 RedisTimeSeries 一致，每个序列为 [key, labels, samples]（不返回标签）；文本协议每个样本一行: key timestamp value
+func (s *Server) tsMRange(args []string) Reply {
+	if len(args) < 2 {
+		return ErrorReply("ERR TS.MRANGE requires from and to")
+	}
+	from, to, err := parseTSBounds(args[0], args[1])
+	if err != nil {
+		return errReply(err)
+	}
+	count, agg, rest, err := parseTSRangeOptions(args[2:])
+	if err != nil {
+		return errReply(err)
+	}
+	if len(rest) < 2 || !strings.EqualFold(rest[0], "FILTER") {
+		return ErrorReply("ERR TS.MRANGE requires FILTER followed by at least one filter")
+	}
+
+	series, err := s.store.TSMRange(from, to, rest[1:], agg, count)
+	if err != nil {
+		return errReply(err)
+	}
+	elems := make([]Reply, len(series))
+	var lines []string
+	for i, ts := range series {
+		elems[i] = ArrayReply(BulkReply(ts.Key), ArrayReply(), tsSamplesReply(ts.Samples))
+		for _, sample := range ts.Samples {
+			lines = append(lines, ts.Key+" "+formatTSSample(sample))
+		}
+	}
+	if len(lines) == 0 {
+		return withText(ArrayReply(elems...), "(empty list)")
+	}
+	return withText(ArrayReply(elems...), strings.Join(lines, "\n"))
+}
+
+// parseTSOptions 解析 [RETENTION ms] [LABELS label value ...]，LABELS 必须在最后
+func parseTSOptions(args []string) (core.TSOptions, error) {
+	var opts core.TSOptions
+	for i := 0; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "RETENTION":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("RETENTION requires milliseconds")
+			}
+			ms, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil || ms < 0 {
+				return opts, fmt.Errorf("RETENTION must be a non-negative integer")
+			}
+			opts.Retention = time.Duration(ms) * time.Millisecond
+			i++
+		case "LABELS":
+			pairs := args[i+1:]
+			if len(pairs) == 0 || len(pairs)%2 != 0 {
+				return opts, fmt.Errorf("LABELS requires label value pairs")
+			}
+			opts.Labels = make(map[string]string, len(pairs)/2)
+			for j := 0; j < len(pairs); j += 2 {
+				opts.Labels[pairs[j]] = pairs[j+1]
+			}
+			return opts, nil
+		default:
+			return opts, fmt.Errorf("unknown option '%s'", args[i])
+		}
+	}
+	return opts, nil
+}
+
+// parseTSRangeOptions 解析 [COUNT n] [AGGREGATION type bucket]，返回剩余参数
+func parseTSRangeOptions(args []string) (count int, agg *core.TSAggregation, rest []string, err error) {
+	i := 0
+	for ; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "COUNT":
+			if i+1 >= len(args) {
+				return 0, nil, nil, fmt.Errorf("COUNT requires a number")
+			}
+			if count, err = strconv.Atoi(args[i+1]); err != nil || count < 0 {
+				return 0, nil, nil, fmt.Errorf("COUNT must be a non-negative integer")
+			}
+			i++
+		case "AGGREGATION":
+			if i+2 >= len(args) {
+				return 0, nil, nil, fmt.Errorf("AGGREGATION requires type and bucket")
+			}
+			if agg, err = parseTSAggregation(args[i+1], args[i+2]); err != nil {
+				return 0, nil, nil, err
+			}
+			i += 2
+		default:
+			return count, agg, args[i:], nil
+		}
+	}
+	return count, agg, nil, nil
+}
+
+func parseTSAggregation(typ, bucket string) (*core.TSAggregation, error) {
+	ms, err := strconv.ParseInt(bucket, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("bucket must be an integer")
+	}
+	return &core.TSAggregation{Type: strings.ToLower(typ), Bucket: ms}, nil
+}
+
+func parseTSBounds(from, to string) (int64, int64, error) {
+	parse := func(s string, dflt int64) (int64, error) {
+		if s == "-" || s == "+" {
+			return dflt, nil
+		}
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timestamp '%s'", s)
+		}
+		return n, nil
+	}
+	f, err := parse(from, math.MinInt64)
+	if err != nil {
+		return 0, 0, err
+	}
+	t, err := parse(to, math.MaxInt64)
+	return f, t, err
+}
+
+// tsSampleReply 样本回复 [timestamp, value]，文本协议为一行 "timestamp value"
+func tsSampleReply(sample core.TSSample) Reply {
+	return withText(ArrayReply(IntegerReply(sample.Timestamp), doubleReply(sample.Value)), formatTSSample(sample))
+}
+
+// tsSamplesReply 样本列表，文本协议每个样本一行
+func tsSamplesReply(samples []core.TSSample) Reply {
+	elems := make([]Reply, len(samples))
+	for i, sample := range samples {
+		elems[i] = tsSampleReply(sample)
+	}
+	return unnumbered(ArrayReply(elems...))
+}
+
+func formatTSSample(s core.TSSample) string {
+	return strconv.FormatInt(s.Timestamp, 10) + " " + strconv.FormatFloat(s.Value, 'g', -1, 64)
+}
+
+// sortedLabels 按名称排序的标签名
+func sortedLabels(labels map[string]string) []string {
+	names := make([]string, 0, len(labels))
+	for k := range labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatLabels 按标签名排序输出 a=1,b=2
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, k := range sortedLabels(labels) {
+		pairs = append(pairs, k+"="+labels[k])
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/internal/core"
+	"Flux-KV/pkg/kverrors"
+	"context"
+)
+
+// 管理类接口（INFO 等），供网关和运维工具调用
+
+// Info 返回节点统计信息
+func (s *KVService) Info(ctx context.Context, req *pb.InfoRequest) (*pb.InfoResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	info := s.db.Info()
+	text, err := info.Format(req.Section)
+	if err != nil {
+		return nil, kverrors.New(kverrors.InvalidArgument, err.Error())
+	}
+
+	resp := &pb.InfoResponse{
+		UptimeSeconds: info.UptimeSeconds,
+		Keys:          int64(info.Keys),
+		Expires:       int64(info.Expires),
+		MemoryBytes:   info.MemoryBytes,
+		Hits:          info.Hits,
+		Misses:        info.Misses,
+		ExpiredKeys:   info.ExpiredKeys,
+		Shards:        make([]*pb.ShardInfo, 0, len(info.Shards)),
+		Commands:      make([]*pb.CommandInfo, 0, len(info.Commands)),
+		Text:          text,
+	}
+	for _, sh := range info.Shards {
+		resp.Shards = append(resp.Shards, &pb.ShardInfo{
+			Id:          int32(sh.ID),
+			Keys:        int64(sh.Keys),
+			Expires:     int64(sh.Expires),
+			MemoryBytes: sh.MemoryBytes,
+		})
+	}
+	for _, c := range info.Commands {
+		resp.Commands = append(resp.Commands, &pb.CommandInfo{
+			Name:      c.Name,
+			Calls:     c.Calls,
+			TotalUsec: c.TotalUsec,
+		})
+	}
+	return resp, nil
+}
+
+// HotKeys 返回访问最频繁的 Key
+func (s *KVService) HotKeys(ctx context.Context, req *pb.KeyReportRequest) (*pb.KeyReportResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return toKeyReport(s.db.HotKeys(int(req.Count))), nil
+}
+
+// BigKeys 返回体积最大的 Key
+func (s *KVService) BigKeys(ctx context.Context, req *pb.KeyReportRequest) (*pb.KeyReportResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return toKeyReport(s.db.BigKeys(int(req.Count))), nil
+}
+
+func toKeyReport(stats []core.KeyStat) *pb.KeyReportResponse {
+	resp := &pb.KeyReportResponse{
+		Keys: make([]*pb.KeyStat, 0, len(stats)),
+	}
+	for _, ks := range stats {
+		resp.Keys = append(resp.Keys, &pb.KeyStat{
+			Key:       ks.Key,
+			Type:      ks.Type,
+			Count:     ks.Count,
+			SizeBytes: ks.Size,
+		})
+	}
+	return resp
+}
+
+// SlowLogGet 查询慢日志
+func (s *KVService) SlowLogGet(ctx context.Context, req *pb.SlowLogRequest) (*pb.SlowLogResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	slowLog := s.db.SlowLog()
+	entries := slowLog.Get(int(req.Count))
+	resp := &pb.SlowLogResponse{
+		Entries: make([]*pb.SlowLogEntry, 0, len(entries)),
+		Len:     int64(slowLog.Len()),
+	}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, &pb.SlowLogEntry{
+			Id:              e.ID,
+			TimestampUnixMs: e.Time.UnixMilli(),
+			DurationUsec:    e.Duration.Microseconds(),
+			Command:         e.Command,
+			Key:             e.Key,
+			ClientAddr:      e.ClientAddr,
+		})
+	}
+	return resp, nil
+}
+
+// SlowLogReset 清空慢日志
+func (s *KVService) SlowLogReset(ctx context.Context, req *pb.SlowLogResetRequest) (*pb.SlowLogResetResponse, error) {
+	s.db.SlowLog().Reset()
+	return &pb.SlowLogResetResponse{Success: true}, nil
+}
+
+// Latency 返回内部事件的耗时直方图
+func (s *KVService) Latency(ctx context.Context, req *pb.LatencyRequest) (*pb.LatencyResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	stats := s.db.Latency(req.Events...)
+	resp := &pb.LatencyResponse{
+		Events: make([]*pb.LatencyStats, 0, len(stats)),
+	}
+	for _, st := range stats {
+		item := &pb.LatencyStats{
+			Event:   st.Event,
+			Count:   st.Count,
+			AvgUsec: st.AvgUsec,
+			MaxUsec: st.MaxUsec,
+			P50Usec: st.P50Usec,
+			P99Usec: st.P99Usec,
+		}
+		for _, b := range st.Buckets {
+			item.Buckets = append(item.Buckets, &pb.LatencyBucket{UpperUsec: b.UpperUsec, Count: b.Count})
+		}
+		resp.Events = append(resp.Events, item)
+	}
+	return resp, nil
+}
+
+// Monitor 实时推送节点执行的命令，直到客户端取消
+func (s *KVService) Monitor(req *pb.MonitorRequest, stream pb.KVService_MonitorServer) error {
+	m := s.db.Monitor(req.Pattern, 0)
+	defer s.db.Unmonitor(m)
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-m.C:
+			if !ok {
+				// 数据库关闭
+				return kverrors.New(kverrors.Unavailable, "monitor closed")
+			}
+			err := stream.Send(&pb.MonitorEvent{
+				TimestampUnixUs: e.Time.UnixMicro(),
+				Client:          e.Client,
+				Command:         e.Command,
+				Key:             e.Key,
+				Value:           e.Value,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
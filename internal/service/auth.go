@@ -0,0 +1,238 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/pkg/acl"
+	"Flux-KV/pkg/kverrors"
+	"context"
+	"path"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// methodRule 一个 RPC 所属的命令类别，以及从请求中取出要访问的 Key 和 Key 前缀
+type methodRule struct {
+	cat  acl.Category
+	keys func(s *KVService, req any) (keys, prefixes []string) // 为 nil 表示不访问 Key
+}
+
+// methodRules 每个 RPC 都要显式声明访问哪些 Key，新增 RPC 需要在这里登记，未登记的按 admin 处理
+var methodRules = map[string]methodRule{
+	// 只读
+	"Get": {acl.Read, reqKey}, "Watch": {acl.Read, watchKeys}, "PubSub": {acl.Read, nil},
+	"XRange": {acl.Read, reqKey}, "XRead": {acl.Read, reqKeys}, "XPending": {acl.Read, reqKey},
+	"PFCount": {acl.Read, reqKeys}, "BFExists": {acl.Read, reqKey}, "CMSQuery": {acl.Read, reqKey},
+	"GeoPos": {acl.Read, reqKey}, "GeoDist": {acl.Read, reqKey}, "GeoSearch": {acl.Read, reqKey},
+	"TSGet": {acl.Read, reqKey}, "TSRange": {acl.Read, reqKey}, "TSInfo": {acl.Read, reqKey},
+	"TSMRange": {acl.Read, allKeys}, // 按标签匹配任意序列
+	"JSONGet":  {acl.Read, reqKey}, "JSONListIndexes": {acl.Read, namespacePrefix}, "Find": {acl.Read, namespacePrefix},
+	"LeaseTimeToLive": {acl.Read, leaseKeys}, "ScriptExists": {acl.Read, nil},
+
+	// 写入
+	"Set": {acl.Write, reqKey}, "Del": {acl.Write, reqKey}, "Publish": {acl.Write, nil},
+	"XAdd": {acl.Write, reqKey}, "XTrim": {acl.Write, reqKey}, "XGroupCreate": {acl.Write, reqKey}, "XGroupDestroy": {acl.Write, reqKey},
+	"XReadGroup": {acl.Write, reqKeys}, "XAck": {acl.Write, reqKey}, "XClaim": {acl.Write, reqKey},
+	"PFAdd": {acl.Write, reqKey}, "PFMerge": {acl.Write, mergeKeys}, "BFReserve": {acl.Write, reqKey}, "BFAdd": {acl.Write, reqKey},
+	"CMSInit": {acl.Write, reqKey}, "CMSIncrBy": {acl.Write, reqKey}, "GeoAdd": {acl.Write, reqKey},
+	"TSCreate": {acl.Write, reqKey}, "TSAdd": {acl.Write, reqKey}, "TSCreateRule": {acl.Write, ruleKeys}, "TSDeleteRule": {acl.Write, ruleKeys},
+	"JSONSet": {acl.Write, reqKey}, "JSONDel": {acl.Write, reqKey}, "JSONArrAppend": {acl.Write, reqKey}, "JSONNumIncrBy": {acl.Write, reqKey},
+	"JSONCreateIndex": {acl.Write, namespacePrefix}, "JSONDropIndex": {acl.Write, namespacePrefix},
+	"LockAcquire": {acl.Write, lockName}, "LockRenew": {acl.Write, lockName}, "LockRelease": {acl.Write, lockName},
+	"LeaseGrant": {acl.Write, nil}, "LeaseKeepAlive": {acl.Write, leaseKeys}, "LeaseRevoke": {acl.Write, leaseKeys},
+	"Throttle": {acl.Write, reqKey}, "Eval": {acl.Write, reqKeys}, "EvalSha": {acl.Write, reqKeys}, "ScriptLoad": {acl.Write, nil},
+}
+
+// reqKey 请求中的 key 字段，空 Key 也要检查
+func reqKey(_ *KVService, req any) ([]string, []string) {
+	return []string{req.(interface{ GetKey() string }).GetKey()}, nil
+}
+
+// reqKeys 请求中的 keys 字段
+func reqKeys(_ *KVService, req any) ([]string, []string) {
+	return req.(interface{ GetKeys() []string }).GetKeys(), nil
+}
+
+// watchKeys 前缀订阅按前缀检查，空前缀表示所有 Key
+func watchKeys(_ *KVService, req any) ([]string, []string) {
+	r := req.(*pb.WatchRequest)
+	if r.Prefix {
+		return nil, []string{r.Key}
+	}
+	return []string{r.Key}, nil
+}
+
+// namespacePrefix JSON 索引和查询访问命名空间下的所有 Key（ns:...），列出索引时命名空间为空表示全部
+func namespacePrefix(_ *KVService, req any) ([]string, []string) {
+	ns := req.(interface{ GetNamespace() string }).GetNamespace()
+	if _, ok := req.(*pb.JSONListIndexesRequest); ok && ns == "" {
+		return nil, []string{""}
+	}
+	return nil, []string{ns + ":"}
+}
+
+// allKeys 可能访问任意 Key，只有 allkeys 的用户可以执行
+func allKeys(_ *KVService, _ any) ([]string, []string) {
+	return nil, []string{""}
+}
+
+func mergeKeys(_ *KVService, req any) ([]string, []string) {
+	r := req.(*pb.PFMergeRequest)
+	return append([]string{r.Dest}, r.Sources...), nil
+}
+
+func ruleKeys(_ *KVService, req any) ([]string, []string) {
+	r := req.(*pb.TSRuleRequest)
+	return []string{r.Source, r.Dest}, nil
+}
+
+// lockName 锁以 name 为 Key 保存在数据库中
+func lockName(_ *KVService, req any) ([]string, []string) {
+	return []string{req.(interface{ GetName() string }).GetName()}, nil
+}
+
+// leaseKeys 租约当前绑定的 Key：撤销会删除它们，续约会延长它们的生命周期；租约不存在时由 RPC 本身返回错误
+func leaseKeys(s *KVService, req any) ([]string, []string) {
+	info, err := s.db.LeaseTimeToLive(req.(interface{ GetId() int64 }).GetId(), true)
+	if err != nil {
+		return nil, nil
+	}
+	return info.Keys, nil
+}
+
+// authorize 按 authorization 元数据认证调用方，再检查 RPC 的类别和请求中的 Key
+func (s *KVService) authorize(ctx context.Context, fullMethod string, req any) error {
+	users := s.db.ACL()
+	if users == nil {
+		return nil
+	}
+
+	// 1. 认证：没有 authorization 时按 default 用户处理
+	header := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("authorization"); len(v) > 0 {
+			header = v[0]
+		}
+	}
+	name, err := users.AuthenticateHeader(header)
+	if err != nil {
+		return aclError(err)
+	}
+
+	// 2. 授权
+	rule, ok := methodRules[path.Base(fullMethod)]
+	if !ok {
+		rule = methodRule{cat: acl.Admin}
+	}
+	var keys, prefixes []string
+	if req != nil && rule.keys != nil {
+		keys, prefixes = rule.keys(s, req)
+	}
+	if err := users.Check(name, rule.cat, keys...); err != nil {
+		return aclError(err)
+	}
+	return aclError(users.CheckPrefix(name, rule.cat, prefixes...))
+}
+
+// aclError 未认证映射为 Unauthenticated，没有权限映射为 PermissionDenied
+func aclError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return kverrors.From(err, kverrors.PermissionDenied)
+}
+
+// UnaryInterceptor 开启 ACL 时检查一元 RPC 的权限，注册服务时通过 grpc.ChainUnaryInterceptor 传入
+func (s *KVService) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := s.authorize(ctx, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor 开启 ACL 时检查流式 RPC 的权限：建立流时检查类别，之后客户端发来的每条消息再检查 Key
+func (s *KVService) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := s.authorize(ss.Context(), info.FullMethod, nil); err != nil {
+			return err
+		}
+		if s.db.ACL() == nil {
+			return handler(srv, ss)
+		}
+		return handler(srv, &authorizedStream{ServerStream: ss, s: s, method: info.FullMethod})
+	}
+}
+
+// authorizedStream 收到的每条消息都按请求中的 Key 检查权限
+type authorizedStream struct {
+	grpc.ServerStream
+	s      *KVService
+	method string
+}
+
+func (a *authorizedStream) RecvMsg(m any) error {
+	if err := a.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return a.s.authorize(a.Context(), a.method, m)
+}
+
+// AclSetUser 创建或整体替换用户，只作用于当前节点
+func (s *KVService) AclSetUser(ctx context.Context, req *pb.AclSetUserRequest) (*pb.AclSetUserResponse, error) {
+	users := s.db.ACL()
+	if users == nil {
+		return nil, kverrors.New(kverrors.FailedPrecondition, "ACL is not enabled")
+	}
+	u := req.GetUser()
+	if u == nil {
+		return nil, kverrors.New(kverrors.InvalidArgument, "user is required")
+	}
+	err := users.SetUser(acl.UserSpec{
+		Name:       u.Name,
+		Passwords:  u.Passwords,
+		Tokens:     u.Tokens,
+		NoPass:     u.Nopass,
+		Categories: u.Categories,
+		Keys:       u.Keys,
+	})
+	if err != nil {
+		return nil, kverrors.New(kverrors.InvalidArgument, err.Error())
+	}
+	return &pb.AclSetUserResponse{Success: true}, nil
+}
+
+// AclDelUser 删除用户，已认证为该用户的连接之后的请求都会被拒绝
+func (s *KVService) AclDelUser(ctx context.Context, req *pb.AclDelUserRequest) (*pb.AclDelUserResponse, error) {
+	users := s.db.ACL()
+	if users == nil {
+		return nil, kverrors.New(kverrors.FailedPrecondition, "ACL is not enabled")
+	}
+	return &pb.AclDelUserResponse{Deleted: users.DelUser(req.Name)}, nil
+}
+
+// AclList 返回所有用户，不包含密码和令牌
+func (s *KVService) AclList(ctx context.Context, req *pb.AclListRequest) (*pb.AclListResponse, error) {
+	users := s.db.ACL()
+	if users == nil {
+		return nil, kverrors.New(kverrors.FailedPrecondition, "ACL is not enabled")
+	}
+	list := users.Users()
+	resp := &pb.AclListResponse{Users: make([]*pb.AclUser, 0, len(list))}
+	for _, u := range list {
+		cats := make([]string, len(u.Categories))
+		for i, c := range u.Categories {
+			cats[i] = string(c)
+		}
+		resp.Users = append(resp.Users, &pb.AclUser{
+			Name:          u.Name,
+			Nopass:        u.NoPass,
+			Categories:    cats,
+			Keys:          u.Keys,
+			PasswordCount: int32(u.Passwords),
+			TokenCount:    int32(u.Tokens),
+		})
+	}
+	return resp, nil
+}
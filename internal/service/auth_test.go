@@ -0,0 +1,148 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/internal/config"
+	"Flux-KV/internal/core"
+	"Flux-KV/pkg/client"
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TestACLInterceptors 一元和流式 RPC 都按 authorization 元数据认证，按 RPC 类别和 Key 授权
+func TestACLInterceptors(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	db, err := core.NewMemDB(&config.Config{ACL: config.ACLConfig{Enabled: true, Users: []config.ACLUserConfig{
+		{Name: "admin", Passwords: []string{"s3cret"}, Categories: []string{"all"}, Keys: []string{"*"}},
+		{Name: "app", Tokens: []string{"app-token"}, Categories: []string{"read", "write"}, Keys: []string{"app:*"}},
+	}}})
+	if err != nil {
+		t.Fatalf("NewMemDB: %v", err)
+	}
+	svc := NewKVService(db)
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(svc.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(svc.StreamInterceptor()),
+	)
+	pb.RegisterKVServiceServer(s, svc)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	newClient := func(opts ...client.Option) *client.Client {
+		cli, err := client.NewDirectClient(lis.Addr().String(), opts...)
+		if err != nil {
+			t.Fatalf("NewDirectClient failed: %v", err)
+		}
+		t.Cleanup(func() { cli.Close() })
+		return cli
+	}
+	anonymous := newClient()
+	app := newClient(client.WithToken("app-token"))
+	admin := newClient(client.WithBasicAuth("admin", "s3cret"))
+
+	// 1. 一元 RPC
+	if err := anonymous.Set("app:1", "v"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("anonymous Set: %v", err)
+	}
+	if err := newClient(client.WithBasicAuth("admin", "wrong")).Set("app:1", "v"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("wrong password: %v", err)
+	}
+	if err := app.Set("app:1", "v"); err != nil {
+		t.Errorf("app Set: %v", err)
+	}
+	if err := app.Set("other", "v"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("app Set outside its keys: %v", err)
+	}
+	if results, _ := app.InfoAll(""); len(results) != 1 || status.Code(results[0].Err) != codes.PermissionDenied {
+		t.Errorf("app Info should be denied: %+v", results)
+	}
+
+	// 2. 流式 RPC：每条请求消息都检查 Key
+	conn, _ := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer app-token"), 2*time.Second)
+	defer cancel()
+	stream, err := pb.NewKVServiceClient(conn).Watch(ctx, &pb.WatchRequest{Key: "other"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("app Watch outside its keys: %v", err)
+	}
+
+	// 3. 不通过 key 字段访问 Key 的 RPC：锁名、租约绑定的 Key、命名空间和前缀
+	kv := pb.NewKVServiceClient(conn)
+	denied := func(what string, err error) {
+		t.Helper()
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("app %s should be denied: %v", what, err)
+		}
+	}
+	_, err = kv.LockAcquire(ctx, &pb.LockAcquireRequest{Name: "other", Owner: "o", TtlMs: 1000})
+	denied("LockAcquire outside its keys", err)
+	_, err = kv.LockRelease(ctx, &pb.LockReleaseRequest{Name: "other", Owner: "o"})
+	denied("LockRelease outside its keys", err)
+	if _, err := kv.LockAcquire(ctx, &pb.LockAcquireRequest{Name: "app:lock", Owner: "o", TtlMs: 1000}); err != nil {
+		t.Errorf("app LockAcquire: %v", err)
+	}
+
+	lease, err := admin.LeaseGrant(time.Minute)
+	if err != nil {
+		t.Fatalf("LeaseGrant: %v", err)
+	}
+	if err := admin.SetWithLease("secret", "v", lease); err != nil {
+		t.Fatalf("SetWithLease: %v", err)
+	}
+	_, err = app.LeaseRevoke(lease)
+	denied("LeaseRevoke with other keys", err)
+	_, err = app.LeaseTimeToLive(lease, true)
+	denied("LeaseTimeToLive with other keys", err)
+	if v, err := admin.Get("secret"); err != nil || v != "v" {
+		t.Errorf("leased key should survive: %q %v", v, err)
+	}
+
+	_, err = app.Find(&pb.FindRequest{Namespace: "other", Field: "f", Op: "=", Value: "1"})
+	denied("Find outside its keys", err)
+	_, err = app.JSONListIndexes("")
+	denied("JSONListIndexes of all namespaces", err)
+	err = app.JSONCreateIndex("other", "f", "tag")
+	denied("JSONCreateIndex outside its keys", err)
+	_, err = app.TSMRange(&pb.TSMRangeRequest{Filters: []string{"a=b"}})
+	denied("TSMRange", err)
+
+	for _, req := range []*pb.WatchRequest{{Key: "", Prefix: true}, {Key: "app", Prefix: true}, {Key: ""}} {
+		stream, err := kv.Watch(ctx, req)
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		denied(fmt.Sprintf("Watch %+v", req), err)
+	}
+
+	// 4. 管理员运行时收回 app 的写权限
+	results, err := admin.AclSetUserAll(&pb.AclUser{Name: "app", Tokens: []string{"app-token"}, Categories: []string{"read"}, Keys: []string{"app:*"}})
+	if err != nil || results[0].Err != nil {
+		t.Fatalf("AclSetUserAll: %v %+v", err, results)
+	}
+	if err := app.Set("app:1", "v2"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("app Set after revoke: %v", err)
+	}
+	if v, err := app.Get("app:1"); err != nil || v != "v" {
+		t.Errorf("app Get after revoke: %q %v", v, err)
+	}
+	list, _ := admin.AclListAll()
+	if len(list) != 1 || list[0].Err != nil || len(list[0].Resp.Users) != 2 || list[0].Resp.Users[1].TokenCount != 1 {
+		t.Errorf("AclListAll: %+v", list)
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/internal/core"
+	"Flux-KV/pkg/kverrors"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GeoAdd 添加或更新成员坐标
+func (s *KVService) GeoAdd(ctx context.Context, req *pb.GeoAddRequest) (*pb.GeoAddResponse, error) {
+	defer s.db.SlowLog().Observe("geoadd", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "geoadd", req.Key, nil)
+
+	points := make([]core.GeoPoint, len(req.Locations))
+	for i, l := range req.Locations {
+		points[i] = core.GeoPoint{Member: l.Member, Longitude: l.Longitude, Latitude: l.Latitude}
+	}
+	added, err := s.db.GeoAdd(req.Key, points)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.GeoAddResponse{Added: int64(added)}, nil
+}
+
+// GeoPos 查询成员坐标
+func (s *KVService) GeoPos(ctx context.Context, req *pb.GeoPosRequest) (*pb.GeoPosResponse, error) {
+	defer s.db.SlowLog().Observe("geopos", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "geopos", req.Key, req.Members)
+
+	points, err := s.db.GeoPos(req.Key, req.Members...)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	resp := &pb.GeoPosResponse{Positions: make([]*pb.GeoPosition, len(points))}
+	for i, p := range points {
+		resp.Positions[i] = &pb.GeoPosition{}
+		if p != nil {
+			resp.Positions[i] = &pb.GeoPosition{Exists: true, Longitude: p.Longitude, Latitude: p.Latitude}
+		}
+	}
+	return resp, nil
+}
+
+// GeoDist 计算两个成员之间的距离
+func (s *KVService) GeoDist(ctx context.Context, req *pb.GeoDistRequest) (*pb.GeoDistResponse, error) {
+	defer s.db.SlowLog().Observe("geodist", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "geodist", req.Key, nil)
+
+	unit, err := core.GeoUnitToMeters(req.Unit)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	dist, ok, err := s.db.GeoDist(req.Key, req.Member1, req.Member2)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.GeoDistResponse{Exists: ok, Distance: dist / unit}, nil
+}
+
+// GeoSearch 按圆形或矩形区域搜索成员，距离使用请求中的单位
+func (s *KVService) GeoSearch(ctx context.Context, req *pb.GeoSearchRequest) (*pb.GeoSearchResponse, error) {
+	defer s.db.SlowLog().Observe("geosearch", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "geosearch", req.Key, nil)
+
+	unit, err := core.GeoUnitToMeters(req.Unit)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	sortBy, err := parseGeoSort(req.Sort)
+	if err != nil {
+		return nil, kverrors.New(kverrors.InvalidArgument, err.Error())
+	}
+
+	results, err := s.db.GeoSearch(req.Key, core.GeoSearchQuery{
+		Member:    req.FromMember,
+		Longitude: req.Longitude,
+		Latitude:  req.Latitude,
+		Radius:    req.Radius * unit,
+		Width:     req.Width * unit,
+		Height:    req.Height * unit,
+		Sort:      sortBy,
+		Count:     int(req.Count),
+		Any:       req.Any,
+	})
+	if err != nil {
+		return nil, commandError(err)
+	}
+
+	resp := &pb.GeoSearchResponse{Results: make([]*pb.GeoSearchResult, len(results))}
+	for i, r := range results {
+		resp.Results[i] = &pb.GeoSearchResult{
+			Member:    r.Member,
+			Distance:  r.Dist / unit,
+			Longitude: r.Longitude,
+			Latitude:  r.Latitude,
+			Hash:      r.Hash,
+		}
+	}
+	return resp, nil
+}
+
+func parseGeoSort(s string) (core.GeoSort, error) {
+	switch strings.ToUpper(s) {
+	case "":
+		return core.GeoSortNone, nil
+	case "ASC":
+		return core.GeoSortAsc, nil
+	case "DESC":
+		return core.GeoSortDesc, nil
+	default:
+		return core.GeoSortNone, fmt.Errorf("unknown sort %q, expected ASC or DESC", s)
+	}
+}
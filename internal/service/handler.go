@@ -3,7 +3,11 @@ package service
 import (
 	pb "Flux-KV/api/proto"
 	"Flux-KV/internal/core"
+	"Flux-KV/pkg/kverrors"
 	"context"
+	"time"
+
+	"google.golang.org/grpc/peer"
 )
 
 // 定义服务结构体
@@ -26,12 +30,21 @@ func NewKVService(db *core.MemDB) *KVService {
 
 // 1. 实现 Set
 func (s *KVService) Set(ctx context.Context, req *pb.SetRequest) (*pb.SetResponse, error) {
+	defer s.db.SlowLog().Observe("set", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "set", req.Key, req.Value)
+
 	// Good Practice: Check context cancellation
 	if err := ctx.Err(); err != nil {
-		return nil, err
+		return nil, commandError(err)
 	}
 
 	// 核心逻辑：拿到请求里的 Key, Value，塞给数据库
+	if req.Lease != 0 {
+		if err := s.db.SetWithLease(req.Key, req.Value, req.Lease); err != nil {
+			return nil, commandError(err)
+		}
+		return &pb.SetResponse{Success: true}, nil
+	}
 	s.db.Set(req.Key, req.Value, 0)
 	return &pb.SetResponse{
 		Success: true,
@@ -40,8 +53,11 @@ func (s *KVService) Set(ctx context.Context, req *pb.SetRequest) (*pb.SetRespons
 
 // 2. Get 接口
 func (s *KVService) Get(ctx context.Context, req *pb.GetRequest) (*pb.GetResponse, error) {
+	defer s.db.SlowLog().Observe("get", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "get", req.Key, nil)
+
 	if err := ctx.Err(); err != nil {
-		return nil, err
+		return nil, commandError(err)
 	}
 
 	// 核心逻辑：去数据库查
@@ -54,7 +70,7 @@ func (s *KVService) Get(ctx context.Context, req *pb.GetRequest) (*pb.GetRespons
 	}
 	strVal, ok := val.(string)
 	if !ok {
-		strVal = ""
+		return nil, commandError(core.ErrWrongType)
 	}
 
 	return &pb.GetResponse{
@@ -65,8 +81,25 @@ func (s *KVService) Get(ctx context.Context, req *pb.GetRequest) (*pb.GetRespons
 
 // 3. Del 接口
 func (s *KVService) Del(ctx context.Context, req *pb.DelRequest) (*pb.DelResponse, error) {
+	defer s.db.SlowLog().Observe("del", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "del", req.Key, nil)
+
 	s.db.Del(req.Key)
 	return &pb.DelResponse{
 		Success: true,
 	}, nil
 }
+
+// clientAddr 从 gRPC 上下文中取出调用方地址，用于慢日志
+func clientAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// commandError 把 core 的错误转换为带错误码的错误，gRPC 通过其 GRPCStatus 得到状态码和 ErrorInfo 详情
+// 没有错误码的错误都是参数错误（ID 格式、字段个数、误判率范围等）
+func commandError(err error) error {
+	return kverrors.From(err, kverrors.InvalidArgument)
+}
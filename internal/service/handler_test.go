@@ -4,13 +4,19 @@ import (
 	pb "Flux-KV/api/proto"
 	"Flux-KV/internal/config"
 	"Flux-KV/internal/core"
+	"Flux-KV/pkg/client"
+	"Flux-KV/pkg/kverrors"
 	"context"
+	"errors"
 	"net"
+	"strings"
 	"testing"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 // TestKVServiceFlow 会模拟启动一个服务器，然后创建一个客户端去连接它
@@ -101,3 +107,64 @@ func TestKVServiceFlow(t *testing.T) {
 	}
 	t.Log("Del effect check passed: key not found")
 }
+
+// TestTypedErrors 服务端返回带错误码的 gRPC 状态，pkg/client 把它还原为 kverrors 错误
+func TestTypedErrors(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	db, err := core.NewMemDB(&config.Config{})
+	if err != nil {
+		t.Fatalf("failed to init db: %v", err)
+	}
+	s := grpc.NewServer()
+	pb.RegisterKVServiceServer(s, NewKVService(db))
+	go s.Serve(lis)
+	defer s.Stop()
+
+	cli, err := client.NewDirectClient(lis.Addr().String())
+	if err != nil {
+		t.Fatalf("NewDirectClient failed: %v", err)
+	}
+	defer cli.Close()
+
+	// 1. Key 不存在
+	if _, err := cli.Get("missing"); !errors.Is(err, client.ErrNotFound) || !kverrors.IsNotFound(err) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	// 2. 类型不符：gRPC 状态码为 FailedPrecondition，错误码为 WrongType
+	db.PFAdd("hll", "a")
+	_, err = cli.Get("hll")
+	if kverrors.CodeOf(err) != kverrors.WrongType || status.Code(err) != codes.FailedPrecondition || err.Error() != core.ErrWrongType.Error() {
+		t.Fatalf("expected WrongType, got %v (%v)", err, status.Code(err))
+	}
+
+	// 3. 已存在 / 脚本不存在
+	cli.BFReserve("bf", 0.01, 100)
+	if err := cli.BFReserve("bf", 0.01, 100); !kverrors.Is(err, kverrors.Conflict) {
+		t.Fatalf("expected Conflict, got %v", err)
+	}
+	if _, err := cli.EvalSha(strings.Repeat("0", 40), nil, nil); !kverrors.IsNotFound(err) {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+
+	// 4. Watch 未开启与断点已被压缩使用不同的错误码
+	conn, _ := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer conn.Close()
+	stream, err := pb.NewKVServiceClient(conn).Watch(context.Background(), &pb.WatchRequest{Key: "k"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if kverrors.CodeOf(err) != kverrors.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for disabled watch, got %v", err)
+	}
+	db2, _ := core.NewMemDB(&config.Config{Watch: config.WatchConfig{HistorySize: 1}})
+	db2.Set("k", "1", 0)
+	db2.Set("k", "2", 0)
+	err = NewKVService(db2).Watch(&pb.WatchRequest{Key: "k", StartRevision: 1}, nil)
+	if code := kverrors.CodeOf(kverrors.FromGRPC(status.Convert(err).Err())); code != kverrors.OutOfRange {
+		t.Fatalf("expected OutOfRange for compacted revision, got %s (%v)", code, err)
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/internal/core"
+	"context"
+	"time"
+)
+
+// JSONSet 把 JSON 文本写入文档的指定路径
+func (s *KVService) JSONSet(ctx context.Context, req *pb.JSONSetRequest) (*pb.JSONSetResponse, error) {
+	defer s.db.SlowLog().Observe("json.set", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "json.set", req.Key, req.Value)
+
+	ok, err := s.db.JSONSet(req.Key, req.Path, req.Value, req.Nx, req.Xx)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.JSONSetResponse{Success: ok}, nil
+}
+
+// JSONGet 读取一个或多个路径
+func (s *KVService) JSONGet(ctx context.Context, req *pb.JSONGetRequest) (*pb.JSONGetResponse, error) {
+	defer s.db.SlowLog().Observe("json.get", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "json.get", req.Key, req.Paths)
+
+	value, found, err := s.db.JSONGet(req.Key, req.Paths...)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.JSONGetResponse{Found: found, Value: value}, nil
+}
+
+// JSONDel 删除路径上的值，根路径删除整个文档
+func (s *KVService) JSONDel(ctx context.Context, req *pb.JSONDelRequest) (*pb.JSONDelResponse, error) {
+	defer s.db.SlowLog().Observe("json.del", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "json.del", req.Key, req.Path)
+
+	n, err := s.db.JSONDel(req.Key, req.Path)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.JSONDelResponse{Deleted: int64(n)}, nil
+}
+
+// JSONArrAppend 向数组末尾追加元素
+func (s *KVService) JSONArrAppend(ctx context.Context, req *pb.JSONArrAppendRequest) (*pb.JSONArrAppendResponse, error) {
+	defer s.db.SlowLog().Observe("json.arrappend", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "json.arrappend", req.Key, req.Values)
+
+	n, err := s.db.JSONArrAppend(req.Key, req.Path, req.Values...)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.JSONArrAppendResponse{Length: int64(n)}, nil
+}
+
+// JSONNumIncrBy 数值自增
+func (s *KVService) JSONNumIncrBy(ctx context.Context, req *pb.JSONNumIncrByRequest) (*pb.JSONNumIncrByResponse, error) {
+	defer s.db.SlowLog().Observe("json.numincrby", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "json.numincrby", req.Key, req.Delta)
+
+	v, err := s.db.JSONNumIncrBy(req.Key, req.Path, req.Delta)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.JSONNumIncrByResponse{Value: v}, nil
+}
+
+// JSONCreateIndex 在命名空间的字段上建二级索引
+func (s *KVService) JSONCreateIndex(ctx context.Context, req *pb.JSONIndexRequest) (*pb.JSONIndexResponse, error) {
+	defer s.db.SlowLog().Observe("json.index.create", req.Namespace, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "json.index.create", req.Namespace, []string{req.Field, req.Type})
+
+	if err := s.db.JSONCreateIndex(req.Namespace, req.Field, req.Type); err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.JSONIndexResponse{Success: true}, nil
+}
+
+// JSONDropIndex 删除二级索引
+func (s *KVService) JSONDropIndex(ctx context.Context, req *pb.JSONIndexRequest) (*pb.JSONIndexResponse, error) {
+	defer s.db.SlowLog().Observe("json.index.drop", req.Namespace, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "json.index.drop", req.Namespace, req.Field)
+
+	return &pb.JSONIndexResponse{Success: s.db.JSONDropIndex(req.Namespace, req.Field)}, nil
+}
+
+// JSONListIndexes 列出二级索引
+func (s *KVService) JSONListIndexes(ctx context.Context, req *pb.JSONListIndexesRequest) (*pb.JSONListIndexesResponse, error) {
+	infos := s.db.JSONIndexes(req.Namespace)
+	resp := &pb.JSONListIndexesResponse{Indexes: make([]*pb.JSONIndexInfo, len(infos))}
+	for i, info := range infos {
+		resp.Indexes[i] = &pb.JSONIndexInfo{
+			Namespace: info.Namespace,
+			Field:     info.Field,
+			Type:      info.Type,
+			Entries:   int64(info.Entries),
+		}
+	}
+	return resp, nil
+}
+
+// Find 按二级索引查询命名空间下的文档
+func (s *KVService) Find(ctx context.Context, req *pb.FindRequest) (*pb.FindResponse, error) {
+	defer s.db.SlowLog().Observe("find", req.Namespace, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "find", req.Namespace, []string{req.Field, req.Op, req.Value})
+
+	matches, err := s.db.JSONFind(req.Namespace, core.JSONQuery{
+		Field: req.Field,
+		Op:    req.Op,
+		Value: req.Value,
+		Max:   req.Max,
+		Limit: int(req.Limit),
+	})
+	if err != nil {
+		return nil, commandError(err)
+	}
+	resp := &pb.FindResponse{Matches: make([]*pb.JSONMatch, len(matches))}
+	for i, m := range matches {
+		resp.Matches[i] = &pb.JSONMatch{Key: m.Key, Doc: m.Doc}
+	}
+	return resp, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/internal/core"
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// LeaseGrant 创建租约
+func (s *KVService) LeaseGrant(ctx context.Context, req *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
+	defer s.db.SlowLog().Observe("lease.grant", "", clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "lease.grant", "", req.TtlMs)
+
+	id, err := s.db.LeaseGrant(req.Id, time.Duration(req.TtlMs)*time.Millisecond)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.LeaseGrantResponse{Id: id, TtlMs: req.TtlMs}, nil
+}
+
+// LeaseKeepAlive 双向流：客户端每发送一个租约 ID 就续期一次并返回 TTL
+// 租约不存在时返回 ttl_ms=0 而不是断开流，同一条流可以给多个租约续期
+func (s *KVService) LeaseKeepAlive(stream pb.KVService_LeaseKeepAliveServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp := &pb.LeaseKeepAliveResponse{Id: req.Id}
+		ttl, err := s.db.LeaseKeepAlive(req.Id)
+		switch {
+		case err == nil:
+			resp.TtlMs = ttl.Milliseconds()
+		case !errors.Is(err, core.ErrLeaseNotFound):
+			return commandError(err)
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// LeaseRevoke 撤销租约并删除绑定的 Key
+func (s *KVService) LeaseRevoke(ctx context.Context, req *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
+	defer s.db.SlowLog().Observe("lease.revoke", "", clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "lease.revoke", "", req.Id)
+
+	n, err := s.db.LeaseRevoke(req.Id)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.LeaseRevokeResponse{Deleted: int64(n)}, nil
+}
+
+// LeaseTimeToLive 查询租约的剩余时间
+func (s *KVService) LeaseTimeToLive(ctx context.Context, req *pb.LeaseTimeToLiveRequest) (*pb.LeaseTimeToLiveResponse, error) {
+	info, err := s.db.LeaseTimeToLive(req.Id, req.Keys)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.LeaseTimeToLiveResponse{
+		Id:           info.ID,
+		TtlMs:        info.TTL.Milliseconds(),
+		GrantedTtlMs: info.GrantedTTL.Milliseconds(),
+		Keys:         info.Keys,
+	}, nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/internal/core"
+	"context"
+	"time"
+)
+
+// LockAcquire 加锁，wait_ms 大于 0 时排队等待
+func (s *KVService) LockAcquire(ctx context.Context, req *pb.LockAcquireRequest) (*pb.LockAcquireResponse, error) {
+	s.db.FeedMonitor(clientAddr(ctx), "lock.acquire", req.Name, req.Owner)
+
+	ttl := time.Duration(req.TtlMs) * time.Millisecond
+	var res core.LockResult
+	var err error
+	if req.WaitMs > 0 {
+		// 阻塞等待的耗时不计入慢日志
+		waitCtx, cancel := context.WithTimeout(ctx, time.Duration(req.WaitMs)*time.Millisecond)
+		defer cancel()
+		res, err = s.db.LockAcquireWait(waitCtx, req.Name, req.Owner, ttl)
+		if err != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	} else {
+		defer s.db.SlowLog().Observe("lock.acquire", req.Name, clientAddr(ctx), time.Now())
+		res, err = s.db.LockAcquire(req.Name, req.Owner, ttl)
+	}
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.LockAcquireResponse{
+		Acquired: res.Acquired,
+		Token:    res.Token,
+		Holder:   res.Holder,
+		TtlMs:    res.TTL.Milliseconds(),
+	}, nil
+}
+
+// LockRenew 续期，只有持有者可以续期
+func (s *KVService) LockRenew(ctx context.Context, req *pb.LockRenewRequest) (*pb.LockRenewResponse, error) {
+	defer s.db.SlowLog().Observe("lock.renew", req.Name, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "lock.renew", req.Name, req.Owner)
+
+	ok, err := s.db.LockRenew(req.Name, req.Owner, time.Duration(req.TtlMs)*time.Millisecond)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.LockRenewResponse{Success: ok}, nil
+}
+
+// LockRelease 释放锁，只有持有者可以释放
+func (s *KVService) LockRelease(ctx context.Context, req *pb.LockReleaseRequest) (*pb.LockReleaseResponse, error) {
+	defer s.db.SlowLog().Observe("lock.release", req.Name, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "lock.release", req.Name, req.Owner)
+
+	ok, err := s.db.LockRelease(req.Name, req.Owner)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.LockReleaseResponse{Success: ok}, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/internal/config"
+	"Flux-KV/internal/core"
+	"Flux-KV/pkg/client"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// TestLockAutoRenew 客户端自动续期，锁被他人拿走后通过 Context 通知持有者
+func TestLockAutoRenew(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := grpc.NewServer()
+	db, _ := core.NewMemDB(&config.Config{})
+	pb.RegisterKVServiceServer(s, NewKVService(db))
+	go s.Serve(lis)
+	defer s.Stop()
+
+	cli, err := client.NewDirectClient(lis.Addr().String())
+	if err != nil {
+		t.Fatalf("NewDirectClient failed: %v", err)
+	}
+	defer cli.Close()
+
+	ctx := context.Background()
+	lock, err := cli.AcquireLock(ctx, "job", "a", 150*time.Millisecond, 0)
+	if err != nil {
+		t.Fatalf("AcquireLock failed: %v", err)
+	}
+
+	// 超过一个租期后仍然持有
+	time.Sleep(400 * time.Millisecond)
+	if _, err := cli.AcquireLock(ctx, "job", "b", time.Second, 0); !errors.Is(err, client.ErrLockNotAcquired) {
+		t.Fatalf("lock should still be held, got %v", err)
+	}
+	if lock.Context().Err() != nil {
+		t.Fatal("lock context cancelled while renewing")
+	}
+
+	// 排队等待的一方在释放后拿到更大的 token
+	got := make(chan *client.Lock, 1)
+	go func() {
+		l, err := cli.AcquireLock(ctx, "job", "b", 150*time.Millisecond, 2*time.Second)
+		if err != nil {
+			t.Errorf("waiting AcquireLock failed: %v", err)
+		}
+		got <- l
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if err := lock.Release(ctx); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if cause := context.Cause(lock.Context()); !errors.Is(cause, client.ErrLockReleased) {
+		t.Fatalf("want ErrLockReleased, got %v", cause)
+	}
+	second := <-got
+	if second == nil || second.Token() <= lock.Token() {
+		t.Fatalf("waiter should get a larger token: %v", second)
+	}
+
+	// 锁被强制删除后由他人拿走，持有者收到 ErrLockLost
+	db.Del("job")
+	if r, _ := db.LockAcquire("job", "c", time.Minute); !r.Acquired {
+		t.Fatal("steal failed")
+	}
+	select {
+	case <-second.Context().Done():
+	case <-time.After(time.Second):
+		t.Fatal("lost lock was not reported")
+	}
+	if cause := context.Cause(second.Context()); !errors.Is(cause, client.ErrLockLost) {
+		t.Fatalf("want ErrLockLost, got %v", cause)
+	}
+	if err := second.Release(ctx); !errors.Is(err, client.ErrLockLost) {
+		t.Fatalf("release of a lost lock: want ErrLockLost, got %v", err)
+	}
+}
+
+// TestLockLostBeforeExpiry 节点不可达时，持有者在服务端过期之前就收到 ErrLockLost
+func TestLockLostBeforeExpiry(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := grpc.NewServer()
+	db, _ := core.NewMemDB(&config.Config{})
+	pb.RegisterKVServiceServer(s, NewKVService(db))
+	go s.Serve(lis)
+
+	cli, err := client.NewDirectClient(lis.Addr().String())
+	if err != nil {
+		t.Fatalf("NewDirectClient failed: %v", err)
+	}
+	defer cli.Close()
+
+	ttl := 300 * time.Millisecond
+	lock, err := cli.AcquireLock(context.Background(), "job", "a", ttl, 0)
+	if err != nil {
+		t.Fatalf("AcquireLock failed: %v", err)
+	}
+	acquired := time.Now()
+	s.Stop()
+
+	select {
+	case <-lock.Context().Done():
+	case <-time.After(2 * ttl):
+		t.Fatal("lost lock was not reported")
+	}
+	if elapsed := time.Since(acquired); elapsed >= ttl {
+		t.Fatalf("lock reported lost after %v, server expires it at %v", elapsed, ttl)
+	}
+	if cause := context.Cause(lock.Context()); !errors.Is(cause, client.ErrLockLost) {
+		t.Fatalf("want ErrLockLost, got %v", cause)
+	}
+}
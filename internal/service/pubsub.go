@@ -0,0 +1,134 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/internal/core"
+	"Flux-KV/pkg/kverrors"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Publish 向频道发布一条消息
+func (s *KVService) Publish(ctx context.Context, req *pb.PublishRequest) (*pb.PublishResponse, error) {
+	defer s.db.SlowLog().Observe("publish", req.Channel, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "publish", req.Channel, req.Message)
+
+	if req.Channel == "" {
+		return nil, kverrors.New(kverrors.InvalidArgument, "channel is required")
+	}
+	return &pb.PublishResponse{
+		Receivers: int64(s.db.Publish(req.Channel, req.Message)),
+	}, nil
+}
+
+// PubSub 双向流：客户端在同一条流上发送订阅请求，服务端推送确认和消息
+// 客户端消费过慢时，服务端以 Unavailable（core.ErrSlowConsumer）结束流，而不是阻塞发布方
+func (s *KVService) PubSub(stream pb.KVService_PubSubServer) error {
+	sub := s.db.NewSubscriber(0)
+	defer sub.Close()
+
+	// stream.Send 不能并发调用
+	var mu sync.Mutex
+	send := func(m *pb.PubSubMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return stream.Send(m)
+	}
+
+	// 1. 读协程：处理客户端的订阅请求
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err == nil {
+				err = s.handlePubSubRequest(sub, req, send)
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+		}
+	}()
+
+	// 2. 写循环：推送消息
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-errCh:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case m, ok := <-sub.C:
+			if !ok {
+				if err := sub.Err(); err != nil {
+					return kverrors.From(err, kverrors.Unavailable)
+				}
+				return nil
+			}
+			kind := "message"
+			if m.Pattern != "" {
+				kind = "pmessage"
+			}
+			err := send(&pb.PubSubMessage{
+				Kind:    kind,
+				Channel: m.Channel,
+				Pattern: m.Pattern,
+				Payload: m.Payload,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handlePubSubRequest 执行一条订阅请求并回复确认
+func (s *KVService) handlePubSubRequest(sub *core.Subscriber, req *pb.PubSubRequest, send func(*pb.PubSubMessage) error) error {
+	switch req.Action {
+	case pb.PubSubRequest_SUBSCRIBE, pb.PubSubRequest_PSUBSCRIBE:
+		kind, subscribe := "subscribe", sub.Subscribe
+		if req.Action == pb.PubSubRequest_PSUBSCRIBE {
+			kind, subscribe = "psubscribe", sub.PSubscribe
+		}
+		for _, name := range req.Channels {
+			count := subscribe(name)
+			if err := send(&pb.PubSubMessage{Kind: kind, Channel: name, Count: int64(count)}); err != nil {
+				return err
+			}
+		}
+	case pb.PubSubRequest_UNSUBSCRIBE, pb.PubSubRequest_PUNSUBSCRIBE:
+		kind, names, unsubscribe := "unsubscribe", req.Channels, sub.Unsubscribe
+		if req.Action == pb.PubSubRequest_PUNSUBSCRIBE {
+			kind, unsubscribe = "punsubscribe", sub.PUnsubscribe
+			if len(names) == 0 {
+				names = sub.Patterns()
+			}
+		} else if len(names) == 0 {
+			names = sub.Channels()
+		}
+		for _, name := range names {
+			count := unsubscribe(name)
+			if err := send(&pb.PubSubMessage{Kind: kind, Channel: name, Count: int64(count)}); err != nil {
+				return err
+			}
+		}
+	case pb.PubSubRequest_PUBLISH:
+		if len(req.Channels) == 0 {
+			return kverrors.New(kverrors.InvalidArgument, "PUBLISH requires a channel")
+		}
+		receivers := s.db.Publish(req.Channels[0], req.Message)
+		return send(&pb.PubSubMessage{Kind: "publish", Channel: req.Channels[0], Count: int64(receivers)})
+	case pb.PubSubRequest_PING:
+		return send(&pb.PubSubMessage{Kind: "pong"})
+	default:
+		return kverrors.New(kverrors.InvalidArgument, fmt.Sprintf("unknown action %v", req.Action))
+	}
+	return nil
+}
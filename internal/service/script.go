@@ -0,0 +1,79 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"context"
+	"time"
+)
+
+// Eval 执行脚本，首个 Key 作为慢日志和 MONITOR 中的 Key
+func (s *KVService) Eval(ctx context.Context, req *pb.EvalRequest) (*pb.EvalResponse, error) {
+	defer s.db.SlowLog().Observe("eval", firstKey(req.Keys), clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "eval", firstKey(req.Keys), req.Args)
+
+	ret, err := s.db.Eval(req.Script, req.Keys, req.Args)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.EvalResponse{Result: toScriptValue(ret)}, nil
+}
+
+// EvalSha 执行缓存中的脚本，不存在时返回 NotFound，客户端应改用 Eval
+func (s *KVService) EvalSha(ctx context.Context, req *pb.EvalShaRequest) (*pb.EvalResponse, error) {
+	defer s.db.SlowLog().Observe("evalsha", firstKey(req.Keys), clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "evalsha", firstKey(req.Keys), req.Args)
+
+	ret, err := s.db.EvalSha(req.Sha, req.Keys, req.Args)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.EvalResponse{Result: toScriptValue(ret)}, nil
+}
+
+// ScriptLoad 编译并缓存脚本
+func (s *KVService) ScriptLoad(ctx context.Context, req *pb.ScriptLoadRequest) (*pb.ScriptLoadResponse, error) {
+	sha, err := s.db.ScriptLoad(req.Script)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.ScriptLoadResponse{Sha: sha}, nil
+}
+
+// ScriptExists 判断脚本是否已缓存
+func (s *KVService) ScriptExists(ctx context.Context, req *pb.ScriptExistsRequest) (*pb.ScriptExistsResponse, error) {
+	return &pb.ScriptExistsResponse{Exists: s.db.ScriptExists(req.Shas...)}, nil
+}
+
+// ScriptFlush 清空脚本缓存
+func (s *KVService) ScriptFlush(ctx context.Context, req *pb.ScriptFlushRequest) (*pb.ScriptFlushResponse, error) {
+	s.db.ScriptFlush()
+	return &pb.ScriptFlushResponse{}, nil
+}
+
+// toScriptValue 把脚本的返回值转换为 protobuf
+func toScriptValue(v any) *pb.ScriptValue {
+	switch x := v.(type) {
+	case int64:
+		return &pb.ScriptValue{Value: &pb.ScriptValue_Int{Int: x}}
+	case float64:
+		return &pb.ScriptValue{Value: &pb.ScriptValue_Float{Float: x}}
+	case string:
+		return &pb.ScriptValue{Value: &pb.ScriptValue_Str{Str: x}}
+	case bool:
+		return &pb.ScriptValue{Value: &pb.ScriptValue_Bool{Bool: x}}
+	case []any:
+		items := make([]*pb.ScriptValue, len(x))
+		for i, item := range x {
+			items[i] = toScriptValue(item)
+		}
+		return &pb.ScriptValue{Value: &pb.ScriptValue_List{List: &pb.ScriptList{Items: items}}}
+	}
+	return &pb.ScriptValue{}
+}
+
+func firstKey(keys []string) string {
+	if len(keys) == 0 {
+		return ""
+	}
+	return keys[0]
+}
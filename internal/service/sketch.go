@@ -0,0 +1,129 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/internal/core"
+	"Flux-KV/pkg/kverrors"
+	"context"
+	"time"
+)
+
+// PFAdd 向 HyperLogLog 添加元素
+func (s *KVService) PFAdd(ctx context.Context, req *pb.PFAddRequest) (*pb.PFAddResponse, error) {
+	defer s.db.SlowLog().Observe("pfadd", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "pfadd", req.Key, req.Elements)
+
+	changed, err := s.db.PFAdd(req.Key, req.Elements...)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.PFAddResponse{Changed: changed}, nil
+}
+
+// PFCount 估算一个或多个 HyperLogLog 并集的基数
+func (s *KVService) PFCount(ctx context.Context, req *pb.PFCountRequest) (*pb.PFCountResponse, error) {
+	if len(req.Keys) == 0 {
+		return nil, kverrors.New(kverrors.InvalidArgument, "at least one key is required")
+	}
+	defer s.db.SlowLog().Observe("pfcount", req.Keys[0], clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "pfcount", req.Keys[0], nil)
+
+	count, err := s.db.PFCount(req.Keys...)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.PFCountResponse{Count: count}, nil
+}
+
+// PFMerge 合并多个 HyperLogLog
+func (s *KVService) PFMerge(ctx context.Context, req *pb.PFMergeRequest) (*pb.PFMergeResponse, error) {
+	defer s.db.SlowLog().Observe("pfmerge", req.Dest, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "pfmerge", req.Dest, req.Sources)
+
+	if err := s.db.PFMerge(req.Dest, req.Sources...); err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.PFMergeResponse{Success: true}, nil
+}
+
+// BFReserve 创建布隆过滤器
+func (s *KVService) BFReserve(ctx context.Context, req *pb.BFReserveRequest) (*pb.BFReserveResponse, error) {
+	defer s.db.SlowLog().Observe("bf.reserve", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "bf.reserve", req.Key, nil)
+
+	if err := s.db.BFReserve(req.Key, req.ErrorRate, req.Capacity); err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.BFReserveResponse{Success: true}, nil
+}
+
+// BFAdd 向布隆过滤器添加元素
+func (s *KVService) BFAdd(ctx context.Context, req *pb.BFItemsRequest) (*pb.BFItemsResponse, error) {
+	defer s.db.SlowLog().Observe("bf.add", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "bf.add", req.Key, req.Items)
+
+	results, err := s.db.BFAdd(req.Key, req.Items...)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.BFItemsResponse{Results: results}, nil
+}
+
+// BFExists 判断元素是否可能存在
+func (s *KVService) BFExists(ctx context.Context, req *pb.BFItemsRequest) (*pb.BFItemsResponse, error) {
+	defer s.db.SlowLog().Observe("bf.exists", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "bf.exists", req.Key, nil)
+
+	results, err := s.db.BFExists(req.Key, req.Items...)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.BFItemsResponse{Results: results}, nil
+}
+
+// CMSInit 创建 Count-Min Sketch，未指定尺寸时按误差率和失败概率计算
+func (s *KVService) CMSInit(ctx context.Context, req *pb.CMSInitRequest) (*pb.CMSInitResponse, error) {
+	defer s.db.SlowLog().Observe("cms.init", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "cms.init", req.Key, nil)
+
+	width, depth := req.Width, req.Depth
+	if width == 0 && depth == 0 {
+		var err error
+		if width, depth, err = core.CMSDimsByProb(req.ErrorRate, req.Probability); err != nil {
+			return nil, kverrors.New(kverrors.InvalidArgument, err.Error())
+		}
+	}
+	if err := s.db.CMSInit(req.Key, width, depth); err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.CMSInitResponse{Width: width, Depth: depth}, nil
+}
+
+// CMSIncrBy 增加元素计数
+func (s *KVService) CMSIncrBy(ctx context.Context, req *pb.CMSIncrByRequest) (*pb.CMSCountsResponse, error) {
+	defer s.db.SlowLog().Observe("cms.incrby", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "cms.incrby", req.Key, nil)
+
+	items := make([]string, len(req.Increments))
+	incrs := make([]uint32, len(req.Increments))
+	for i, inc := range req.Increments {
+		items[i], incrs[i] = inc.Item, inc.Increment
+	}
+	counts, err := s.db.CMSIncrBy(req.Key, items, incrs)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.CMSCountsResponse{Counts: counts}, nil
+}
+
+// CMSQuery 查询元素计数的估算值
+func (s *KVService) CMSQuery(ctx context.Context, req *pb.CMSQueryRequest) (*pb.CMSCountsResponse, error) {
+	defer s.db.SlowLog().Observe("cms.query", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "cms.query", req.Key, nil)
+
+	counts, err := s.db.CMSQuery(req.Key, req.Items...)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.CMSCountsResponse{Counts: counts}, nil
+}
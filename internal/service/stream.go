@@ -0,0 +1,291 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/internal/core"
+	"Flux-KV/pkg/kverrors"
+	"context"
+	"time"
+)
+
+// 流式读取时每批默认读取的条数
+const defaultStreamBatch = 100
+
+// XAdd 追加一条消息
+func (s *KVService) XAdd(ctx context.Context, req *pb.XAddRequest) (*pb.XAddResponse, error) {
+	defer s.db.SlowLog().Observe("xadd", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "xadd", req.Key, req.Fields)
+
+	id := req.Id
+	if id == "" {
+		id = "*"
+	}
+	fields := make([]string, 0, len(req.Fields)*2)
+	for _, f := range req.Fields {
+		fields = append(fields, f.Name, f.Value)
+	}
+
+	newID, err := s.db.XAdd(req.Key, id, fields, int(req.MaxLen))
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.XAddResponse{Id: newID.String()}, nil
+}
+
+// XRange 按 ID 区间查询消息
+func (s *KVService) XRange(ctx context.Context, req *pb.XRangeRequest) (*pb.XRangeResponse, error) {
+	defer s.db.SlowLog().Observe("xrange", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "xrange", req.Key, nil)
+
+	start, end := req.Start, req.End
+	if start == "" {
+		start = "-"
+	}
+	if end == "" {
+		end = "+"
+	}
+
+	var entries []core.StreamEntry
+	var err error
+	if req.Reverse {
+		entries, err = s.db.XRevRange(req.Key, end, start, int(req.Count))
+	} else {
+		entries, err = s.db.XRange(req.Key, start, end, int(req.Count))
+	}
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.XRangeResponse{Entries: toStreamEntries(entries)}, nil
+}
+
+// XTrim 按条数或最小 ID 裁剪
+func (s *KVService) XTrim(ctx context.Context, req *pb.XTrimRequest) (*pb.XTrimResponse, error) {
+	defer s.db.SlowLog().Observe("xtrim", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "xtrim", req.Key, nil)
+
+	var removed int
+	var err error
+	if req.MinId != "" {
+		removed, err = s.db.XTrimMinID(req.Key, req.MinId)
+	} else {
+		removed, err = s.db.XTrimMaxLen(req.Key, int(req.MaxLen))
+	}
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.XTrimResponse{Removed: int64(removed)}, nil
+}
+
+// XRead 从指定位置开始持续推送新消息，直到客户端取消
+func (s *KVService) XRead(req *pb.XReadRequest, stream pb.KVService_XReadServer) error {
+	if len(req.Keys) == 0 {
+		return kverrors.New(kverrors.InvalidArgument, "at least one key is required")
+	}
+	if len(req.Ids) != 0 && len(req.Ids) != len(req.Keys) {
+		return kverrors.New(kverrors.InvalidArgument, "ids must match keys")
+	}
+	ids := make([]string, len(req.Keys))
+	for i := range ids {
+		ids[i] = "$"
+		if len(req.Ids) > 0 && req.Ids[i] != "" {
+			ids[i] = req.Ids[i]
+		}
+	}
+	count := int(req.Count)
+	if count <= 0 {
+		count = defaultStreamBatch
+	}
+
+	ctx := stream.Context()
+	s.db.FeedMonitor(clientAddr(ctx), "xread", req.Keys[0], nil)
+
+	// "$" 只在开始时确定一次，否则两批之间追加的消息会被跳过
+	for i, key := range req.Keys {
+		if ids[i] != "$" {
+			continue
+		}
+		last, err := s.db.XRevRange(key, "+", "-", 1)
+		if err != nil {
+			return commandError(err)
+		}
+		ids[i] = "0-0"
+		if len(last) > 0 {
+			ids[i] = last[0].ID.String()
+		}
+	}
+
+	// 每批读完后把位置推进到最后一条，继续阻塞等待
+	for {
+		results, err := s.db.XRead(ctx, req.Keys, ids, count, true)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return commandError(err)
+		}
+		for _, r := range results {
+			idx := indexOf(req.Keys, r.Key)
+			for _, e := range r.Entries {
+				if err := stream.Send(&pb.XReadResponse{Key: r.Key, Entry: toStreamEntry(e)}); err != nil {
+					return err
+				}
+				ids[idx] = e.ID.String()
+			}
+		}
+	}
+}
+
+// XGroupCreate 创建消费者组
+func (s *KVService) XGroupCreate(ctx context.Context, req *pb.XGroupRequest) (*pb.XGroupResponse, error) {
+	defer s.db.SlowLog().Observe("xgroup", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "xgroup", req.Key, req.Group)
+
+	id := req.Id
+	if id == "" {
+		id = "$"
+	}
+	if err := s.db.XGroupCreate(req.Key, req.Group, id, req.Mkstream); err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.XGroupResponse{Success: true}, nil
+}
+
+// XGroupDestroy 删除消费者组
+func (s *KVService) XGroupDestroy(ctx context.Context, req *pb.XGroupRequest) (*pb.XGroupResponse, error) {
+	defer s.db.SlowLog().Observe("xgroup", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "xgroup", req.Key, req.Group)
+
+	ok, err := s.db.XGroupDestroy(req.Key, req.Group)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.XGroupResponse{Success: ok}, nil
+}
+
+// XReadGroup 以消费者身份持续领取新消息，直到客户端取消
+// 已推送但未确认的消息留在待确认列表中，可通过 XPending / XClaim 处理
+func (s *KVService) XReadGroup(req *pb.XReadGroupRequest, stream pb.KVService_XReadGroupServer) error {
+	if req.Group == "" || req.Consumer == "" || len(req.Keys) == 0 {
+		return kverrors.New(kverrors.InvalidArgument, "group, consumer and keys are required")
+	}
+	ids := make([]string, len(req.Keys))
+	for i := range ids {
+		ids[i] = ">"
+	}
+	count := int(req.Count)
+	if count <= 0 {
+		count = defaultStreamBatch
+	}
+
+	ctx := stream.Context()
+	s.db.FeedMonitor(clientAddr(ctx), "xreadgroup", req.Keys[0], req.Group)
+
+	for {
+		results, err := s.db.XReadGroup(ctx, req.Group, req.Consumer, req.Keys, ids, count, true, req.NoAck)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return commandError(err)
+		}
+		for _, r := range results {
+			for _, e := range r.Entries {
+				if err := stream.Send(&pb.XReadResponse{Key: r.Key, Entry: toStreamEntry(e)}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// XAck 确认消息
+func (s *KVService) XAck(ctx context.Context, req *pb.XAckRequest) (*pb.XAckResponse, error) {
+	defer s.db.SlowLog().Observe("xack", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "xack", req.Key, req.Ids)
+
+	acked, err := s.db.XAck(req.Key, req.Group, req.Ids...)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.XAckResponse{Acked: int64(acked)}, nil
+}
+
+// XPending 查询待确认消息，count > 0 时附带明细
+func (s *KVService) XPending(ctx context.Context, req *pb.XPendingRequest) (*pb.XPendingResponse, error) {
+	defer s.db.SlowLog().Observe("xpending", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "xpending", req.Key, req.Group)
+
+	summary, err := s.db.XPending(req.Key, req.Group)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	resp := &pb.XPendingResponse{
+		Count:     summary.Count,
+		Consumers: summary.Consumers,
+	}
+	if summary.Count > 0 {
+		resp.MinId, resp.MaxId = summary.MinID.String(), summary.MaxID.String()
+	}
+
+	if req.Count > 0 {
+		start, end := req.Start, req.End
+		if start == "" {
+			start = "-"
+		}
+		if end == "" {
+			end = "+"
+		}
+		entries, err := s.db.XPendingRange(req.Key, req.Group, start, end, int(req.Count), req.Consumer,
+			time.Duration(req.MinIdleMs)*time.Millisecond)
+		if err != nil {
+			return nil, commandError(err)
+		}
+		for _, e := range entries {
+			resp.Entries = append(resp.Entries, &pb.PendingEntry{
+				Id:         e.ID.String(),
+				Consumer:   e.Consumer,
+				IdleMs:     e.Idle.Milliseconds(),
+				Deliveries: e.Deliveries,
+			})
+		}
+	}
+	return resp, nil
+}
+
+// XClaim 认领其他消费者长时间未确认的消息
+func (s *KVService) XClaim(ctx context.Context, req *pb.XClaimRequest) (*pb.XClaimResponse, error) {
+	defer s.db.SlowLog().Observe("xclaim", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "xclaim", req.Key, req.Ids)
+
+	entries, err := s.db.XClaim(req.Key, req.Group, req.Consumer,
+		time.Duration(req.MinIdleMs)*time.Millisecond, req.Ids)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.XClaimResponse{Entries: toStreamEntries(entries)}, nil
+}
+
+func toStreamEntry(e core.StreamEntry) *pb.StreamEntry {
+	entry := &pb.StreamEntry{Id: e.ID.String()}
+	for i := 0; i+1 < len(e.Fields); i += 2 {
+		entry.Fields = append(entry.Fields, &pb.StreamField{Name: e.Fields[i], Value: e.Fields[i+1]})
+	}
+	return entry
+}
+
+func toStreamEntries(entries []core.StreamEntry) []*pb.StreamEntry {
+	result := make([]*pb.StreamEntry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, toStreamEntry(e))
+	}
+	return result
+}
+
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
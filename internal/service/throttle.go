@@ -0,0 +1,30 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"context"
+	"time"
+)
+
+// Throttle 按 Key 判定请求是否放行
+func (s *KVService) Throttle(ctx context.Context, req *pb.ThrottleRequest) (*pb.ThrottleResponse, error) {
+	defer s.db.SlowLog().Observe("throttle", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "throttle", req.Key, req.Cost)
+
+	res, err := s.db.Throttle(req.Key, req.Rate, req.Burst, req.Cost)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.ThrottleResponse{
+		Allowed:      res.Allowed,
+		Limit:        res.Limit,
+		Remaining:    res.Remaining,
+		RetryAfterMs: ceilMillis(res.RetryAfter),
+		ResetAfterMs: ceilMillis(res.ResetAfter),
+	}, nil
+}
+
+// ceilMillis 向上取整到毫秒，客户端按返回值等待后重试一定能放行
+func ceilMillis(d time.Duration) int64 {
+	return int64((d + time.Millisecond - 1) / time.Millisecond)
+}
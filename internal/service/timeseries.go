@@ -0,0 +1,153 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/internal/core"
+	"context"
+	"math"
+	"time"
+)
+
+// TSCreate 创建时间序列
+func (s *KVService) TSCreate(ctx context.Context, req *pb.TSCreateRequest) (*pb.TSCreateResponse, error) {
+	defer s.db.SlowLog().Observe("ts.create", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "ts.create", req.Key, nil)
+
+	opts := core.TSOptions{Retention: time.Duration(req.RetentionMs) * time.Millisecond, Labels: req.Labels}
+	if err := s.db.TSCreate(req.Key, opts); err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.TSCreateResponse{Success: true}, nil
+}
+
+// TSAdd 追加样本，Key 不存在时按请求中的参数创建
+func (s *KVService) TSAdd(ctx context.Context, req *pb.TSAddRequest) (*pb.TSAddResponse, error) {
+	defer s.db.SlowLog().Observe("ts.add", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "ts.add", req.Key, req.Value)
+
+	ts := req.Timestamp
+	if ts == 0 {
+		ts = time.Now().UnixMilli()
+	}
+	opts := core.TSOptions{Retention: time.Duration(req.RetentionMs) * time.Millisecond, Labels: req.Labels}
+	if err := s.db.TSAdd(req.Key, ts, req.Value, opts); err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.TSAddResponse{Timestamp: ts}, nil
+}
+
+// TSGet 返回最后一个样本
+func (s *KVService) TSGet(ctx context.Context, req *pb.TSGetRequest) (*pb.TSGetResponse, error) {
+	defer s.db.SlowLog().Observe("ts.get", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "ts.get", req.Key, nil)
+
+	sample, ok, err := s.db.TSGet(req.Key)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	if !ok {
+		return &pb.TSGetResponse{}, nil
+	}
+	return &pb.TSGetResponse{Exists: true, Sample: &pb.TSSample{Timestamp: sample.Timestamp, Value: sample.Value}}, nil
+}
+
+// TSRange 查询单个序列
+func (s *KVService) TSRange(ctx context.Context, req *pb.TSRangeRequest) (*pb.TSRangeResponse, error) {
+	defer s.db.SlowLog().Observe("ts.range", req.Key, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "ts.range", req.Key, nil)
+
+	samples, err := s.db.TSRange(req.Key, req.From, rangeEnd(req.To), toTSAggregation(req.Aggregation), int(req.Count))
+	if err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.TSRangeResponse{Samples: toTSSamples(samples)}, nil
+}
+
+// TSMRange 按标签过滤查询多个序列
+func (s *KVService) TSMRange(ctx context.Context, req *pb.TSMRangeRequest) (*pb.TSMRangeResponse, error) {
+	defer s.db.SlowLog().Observe("ts.mrange", "", clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "ts.mrange", "", req.Filters)
+
+	series, err := s.db.TSMRange(req.From, rangeEnd(req.To), req.Filters, toTSAggregation(req.Aggregation), int(req.Count))
+	if err != nil {
+		return nil, commandError(err)
+	}
+	resp := &pb.TSMRangeResponse{Series: make([]*pb.TSSeries, len(series))}
+	for i, ts := range series {
+		resp.Series[i] = &pb.TSSeries{Key: ts.Key, Labels: ts.Labels, Samples: toTSSamples(ts.Samples)}
+	}
+	return resp, nil
+}
+
+// TSCreateRule 创建降采样规则
+func (s *KVService) TSCreateRule(ctx context.Context, req *pb.TSRuleRequest) (*pb.TSRuleResponse, error) {
+	defer s.db.SlowLog().Observe("ts.createrule", req.Source, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "ts.createrule", req.Source, req.Dest)
+
+	var agg core.TSAggregation
+	if req.Aggregation != nil {
+		agg = *toTSAggregation(req.Aggregation)
+	}
+	if err := s.db.TSCreateRule(req.Source, req.Dest, agg); err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.TSRuleResponse{Success: true}, nil
+}
+
+// TSDeleteRule 删除降采样规则
+func (s *KVService) TSDeleteRule(ctx context.Context, req *pb.TSRuleRequest) (*pb.TSRuleResponse, error) {
+	defer s.db.SlowLog().Observe("ts.deleterule", req.Source, clientAddr(ctx), time.Now())
+	s.db.FeedMonitor(clientAddr(ctx), "ts.deleterule", req.Source, req.Dest)
+
+	if err := s.db.TSDeleteRule(req.Source, req.Dest); err != nil {
+		return nil, commandError(err)
+	}
+	return &pb.TSRuleResponse{Success: true}, nil
+}
+
+// TSInfo 返回序列的元信息
+func (s *KVService) TSInfo(ctx context.Context, req *pb.TSInfoRequest) (*pb.TSInfoResponse, error) {
+	info, err := s.db.TSInfo(req.Key)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	resp := &pb.TSInfoResponse{
+		TotalSamples:   int64(info.TotalSamples),
+		MemoryUsage:    info.MemoryUsage,
+		ChunkCount:     int64(info.ChunkCount),
+		FirstTimestamp: info.FirstTimestamp,
+		LastTimestamp:  info.LastTimestamp,
+		RetentionMs:    info.Retention.Milliseconds(),
+		Labels:         info.Labels,
+	}
+	for _, r := range info.Rules {
+		resp.Rules = append(resp.Rules, &pb.TSRule{
+			Dest:        r.Dest,
+			Aggregation: &pb.TSAggregation{Type: r.Aggregation.Type, BucketMs: r.Aggregation.Bucket},
+		})
+	}
+	return resp, nil
+}
+
+// rangeEnd 请求中 to 为 0 表示不限
+func rangeEnd(to int64) int64 {
+	if to == 0 {
+		return math.MaxInt64
+	}
+	return to
+}
+
+func toTSAggregation(agg *pb.TSAggregation) *core.TSAggregation {
+	if agg == nil || agg.Type == "" {
+		return nil
+	}
+	return &core.TSAggregation{Type: agg.Type, Bucket: agg.BucketMs}
+}
+
+func toTSSamples(samples []core.TSSample) []*pb.TSSample {
+	out := make([]*pb.TSSample, len(samples))
+	for i, s := range samples {
+		out[i] = &pb.TSSample{Timestamp: s.Timestamp, Value: s.Value}
+	}
+	return out
+}
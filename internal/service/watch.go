@@ -0,0 +1,50 @@
+package service
+
+import (
+	pb "Flux-KV/api/proto"
+	"Flux-KV/internal/core"
+	"Flux-KV/pkg/kverrors"
+	"fmt"
+)
+
+// Watch 订阅 Key / 前缀的变更并持续推送，直到客户端取消
+func (s *KVService) Watch(req *pb.WatchRequest, stream pb.KVService_WatchServer) error {
+	// 未开启为 FailedPrecondition，断点已被压缩为 OutOfRange，客户端按错误码区分
+	w, err := s.db.Watch(req.Key, req.Prefix, req.StartRevision)
+	if err != nil {
+		return kverrors.From(err, kverrors.Internal)
+	}
+	defer s.db.Unwatch(w)
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-w.C:
+			if !ok {
+				// 消费过慢（ErrWatchLagged）或服务关闭，都是 Unavailable，客户端可以重连续订
+				if err := w.Err(); err != nil {
+					return kverrors.From(err, kverrors.Unavailable)
+				}
+				return kverrors.New(kverrors.Unavailable, "watch closed")
+			}
+			if err := stream.Send(toWatchEvent(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func toWatchEvent(e core.WatchEvent) *pb.WatchEvent {
+	ev := &pb.WatchEvent{
+		Type:            pb.WatchEventType(e.Type),
+		Key:             e.Key,
+		Revision:        e.Revision,
+		TimestampUnixMs: e.Time.UnixMilli(),
+	}
+	if e.Value != nil {
+		ev.Value = fmt.Sprintf("%v", e.Value)
+	}
+	return ev
+}
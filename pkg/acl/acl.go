@@ -0,0 +1,357 @@
+package acl
+
+import (
+	"Flux-KV/pkg/glob"
+	"Flux-KV/pkg/kverrors"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Category 命令类别，用户按类别授权
+type Category string
+
+const (
+	Read  Category = "read"  // 只读命令，例如 GET / XRANGE / JSON.GET
+	Write Category = "write" // 修改数据的命令，例如 SET / DEL / EVAL
+	Admin Category = "admin" // 运维命令，例如 INFO / SLOWLOG / MONITOR / ACL
+	All   Category = "all"   // 配置中的简写，等价于以上全部
+)
+
+// DefaultUser 未认证的连接以该用户的身份执行命令；不存在该用户时，未认证的连接不能执行任何命令
+const DefaultUser = "default"
+
+var (
+	// ErrAuthRequired 连接未认证，且没有 default 用户
+	ErrAuthRequired = kverrors.New(kverrors.Unauthorized, "NOAUTH Authentication required.")
+	// ErrWrongPass 用户不存在、密码或令牌错误
+	ErrWrongPass = kverrors.WithPrefix(kverrors.Unauthorized, "WRONGPASS", "WRONGPASS invalid username-password pair or user is disabled.")
+)
+
+// PermissionError 用户没有执行该类命令或访问该 Key 的权限
+type PermissionError struct {
+	User     string
+	Category Category
+	Key      string // 为空表示命令类别不允许
+}
+
+func (e *PermissionError) Error() string {
+	if e.Key != "" {
+		return fmt.Sprintf("NOPERM User %s has no permissions to access the '%s' key", e.User, e.Key)
+	}
+	return fmt.Sprintf("NOPERM User %s has no permissions to run %s commands", e.User, e.Category)
+}
+
+// ErrorCode 实现 kverrors.Coder
+func (e *PermissionError) ErrorCode() kverrors.Code {
+	return kverrors.PermissionDenied
+}
+
+// IsAuthError 认证失败（未认证或凭证错误），HTTP 对应 401
+func IsAuthError(err error) bool {
+	return errors.Is(err, ErrAuthRequired) || errors.Is(err, ErrWrongPass)
+}
+
+// IsPermissionError 已认证但没有权限，HTTP 对应 403
+func IsPermissionError(err error) bool {
+	var perm *PermissionError
+	return errors.As(err, &perm)
+}
+
+// UserSpec 用户定义，来自配置文件或管理接口
+// 密码和令牌可以是明文，也可以是 "sha256:<hex>" 形式的摘要；加载后只保存摘要
+type UserSpec struct {
+	Name       string
+	Passwords  []string
+	Tokens     []string // Bearer 令牌，单独使用即可认证为该用户
+	NoPass     bool     // 任意密码都能认证为该用户，通常用于 default 用户
+	Categories []string // read / write / admin / all
+	Keys       []string // 允许访问的 Key glob 模式，为空表示不能访问任何 Key
+}
+
+// UserInfo 用户的权限信息，不包含密码和令牌
+type UserInfo struct {
+	Name       string
+	Passwords  int
+	Tokens     int
+	NoPass     bool
+	Categories []Category
+	Keys       []string
+}
+
+type user struct {
+	name       string
+	passwords  map[[32]byte]bool
+	tokens     map[[32]byte]bool
+	nopass     bool
+	categories map[Category]bool
+	keys       []string
+}
+
+// ACL 用户表，可以被多个协程并发读写
+// nil 表示未开启访问控制，所有检查都直接通过
+type ACL struct {
+	mu     sync.RWMutex
+	users  map[string]*user
+	tokens map[[32]byte]string // 令牌摘要 -> 用户名
+}
+
+// New 创建用户表
+func New(specs ...UserSpec) (*ACL, error) {
+	a := &ACL{
+		users:  make(map[string]*user),
+		tokens: make(map[[32]byte]string),
+	}
+	for _, spec := range specs {
+		if err := a.SetUser(spec); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// SetUser 创建或整体替换用户，已认证的连接下一条命令开始按新权限检查
+func (a *ACL) SetUser(spec UserSpec) error {
+	u, err := newUser(spec)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for digest, owner := range a.tokens {
+		if owner != u.name && u.tokens[digest] {
+			return fmt.Errorf("token is already used by user '%s'", owner)
+		}
+	}
+	a.removeLocked(u.name)
+	a.users[u.name] = u
+	for digest := range u.tokens {
+		a.tokens[digest] = u.name
+	}
+	return nil
+}
+
+// DelUser 删除用户，已认证为该用户的连接之后的命令都会被拒绝
+func (a *ACL) DelUser(name string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.removeLocked(name)
+}
+
+func (a *ACL) removeLocked(name string) bool {
+	old, ok := a.users[name]
+	if !ok {
+		return false
+	}
+	for digest := range old.tokens {
+		delete(a.tokens, digest)
+	}
+	delete(a.users, name)
+	return true
+}
+
+// Users 按用户名排序返回所有用户
+func (a *ACL) Users() []UserInfo {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	out := make([]UserInfo, 0, len(a.users))
+	for _, u := range a.users {
+		info := UserInfo{
+			Name:      u.name,
+			Passwords: len(u.passwords),
+			Tokens:    len(u.tokens),
+			NoPass:    u.nopass,
+			Keys:      slices.Clone(u.keys),
+		}
+		for _, c := range []Category{Read, Write, Admin} {
+			if u.categories[c] {
+				info.Categories = append(info.Categories, c)
+			}
+		}
+		out = append(out, info)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Authenticate 用户名 + 密码认证
+func (a *ACL) Authenticate(name, password string) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	u, ok := a.users[name]
+	if !ok || !(u.nopass || u.passwords[sha256.Sum256([]byte(password))]) {
+		return ErrWrongPass
+	}
+	return nil
+}
+
+// AuthenticateToken 令牌认证，返回令牌所属的用户名
+func (a *ACL) AuthenticateToken(token string) (string, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	name, ok := a.tokens[sha256.Sum256([]byte(token))]
+	if !ok {
+		return "", ErrWrongPass
+	}
+	return name, nil
+}
+
+// AuthenticateHeader 解析 HTTP / gRPC 的 Authorization 头：
+// "Basic base64(user:password)" 或 "Bearer token"，返回认证后的用户名；头为空时返回空用户名（按 default 用户处理）
+func (a *ACL) AuthenticateHeader(header string) (string, error) {
+	if header == "" {
+		return "", nil
+	}
+	scheme, cred, _ := strings.Cut(header, " ")
+	switch strings.ToLower(scheme) {
+	case "bearer":
+		return a.AuthenticateToken(strings.TrimSpace(cred))
+	case "basic":
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(cred))
+		if err != nil {
+			return "", ErrWrongPass
+		}
+		name, password, ok := strings.Cut(string(raw), ":")
+		if !ok {
+			return "", ErrWrongPass
+		}
+		if err := a.Authenticate(name, password); err != nil {
+			return "", err
+		}
+		return name, nil
+	}
+	return "", ErrWrongPass
+}
+
+// Check 检查用户能否执行 cat 类命令并访问 keys，name 为空表示未认证的连接
+func (a *ACL) Check(name string, cat Category, keys ...string) error {
+	if a == nil {
+		return nil
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	u, err := a.userLocked(name, cat)
+	if err != nil {
+		return err
+	}
+
+	// 每个 Key 至少匹配一个模式
+	for _, key := range keys {
+		if !slices.ContainsFunc(u.keys, func(p string) bool { return glob.Match(p, key) }) {
+			return &PermissionError{User: u.name, Category: cat, Key: key}
+		}
+	}
+	return nil
+}
+
+// CheckPrefix 检查用户能否执行 cat 类命令并访问以 prefixes 开头的所有 Key，用于 WATCH 前缀、FIND 这类按前缀访问的命令
+// 只有 "abc*" 形式、且 abc 是前缀开头的模式才覆盖整个前缀；空前缀表示全部 Key，只有 allkeys（模式 "*"）的用户可以访问
+func (a *ACL) CheckPrefix(name string, cat Category, prefixes ...string) error {
+	if a == nil {
+		return nil
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	u, err := a.userLocked(name, cat)
+	if err != nil {
+		return err
+	}
+
+	for _, prefix := range prefixes {
+		if !slices.ContainsFunc(u.keys, func(p string) bool { return coversPrefix(p, prefix) }) {
+			return &PermissionError{User: u.name, Category: cat, Key: prefix + "*"}
+		}
+	}
+	return nil
+}
+
+// userLocked 查找用户并检查命令类别，未认证的连接（name 为空）使用 default 用户；调用方持有读锁
+func (a *ACL) userLocked(name string, cat Category) (*user, error) {
+	if name == "" {
+		name = DefaultUser
+	}
+	u, ok := a.users[name]
+	if !ok {
+		if name == DefaultUser {
+			return nil, ErrAuthRequired
+		}
+		// 用户已被删除
+		return nil, ErrWrongPass
+	}
+	if !u.categories[cat] {
+		return nil, &PermissionError{User: name, Category: cat}
+	}
+	return u, nil
+}
+
+// coversPrefix 模式是否匹配所有以 prefix 开头的 Key：模式为字面量加结尾的 *，且字面量是 prefix 的开头
+func coversPrefix(pattern, prefix string) bool {
+	lit := strings.TrimRight(pattern, "*")
+	if len(lit) == len(pattern) || strings.ContainsAny(lit, "*?[\\") {
+		return false
+	}
+	return strings.HasPrefix(prefix, lit)
+}
+
+func newUser(spec UserSpec) (*user, error) {
+	if spec.Name == "" || strings.ContainsAny(spec.Name, " \t\r\n:") {
+		return nil, fmt.Errorf("invalid user name '%s'", spec.Name)
+	}
+	u := &user{
+		name:       spec.Name,
+		passwords:  make(map[[32]byte]bool),
+		tokens:     make(map[[32]byte]bool),
+		nopass:     spec.NoPass,
+		categories: make(map[Category]bool),
+		keys:       slices.Clone(spec.Keys),
+	}
+	for _, p := range spec.Passwords {
+		digest, err := parseSecret(p)
+		if err != nil {
+			return nil, err
+		}
+		u.passwords[digest] = true
+	}
+	for _, t := range spec.Tokens {
+		digest, err := parseSecret(t)
+		if err != nil {
+			return nil, err
+		}
+		u.tokens[digest] = true
+	}
+	for _, c := range spec.Categories {
+		switch cat := Category(strings.ToLower(c)); cat {
+		case Read, Write, Admin:
+			u.categories[cat] = true
+		case All:
+			u.categories[Read], u.categories[Write], u.categories[Admin] = true, true, true
+		default:
+			return nil, fmt.Errorf("unknown command category '%s'", c)
+		}
+	}
+	return u, nil
+}
+
+// parseSecret 明文计算摘要，"sha256:<hex>" 直接使用给出的摘要
+func parseSecret(s string) ([32]byte, error) {
+	var digest [32]byte
+	if hexDigest, ok := strings.CutPrefix(s, "sha256:"); ok {
+		b, err := hex.DecodeString(hexDigest)
+		if err != nil || len(b) != len(digest) {
+			return digest, fmt.Errorf("invalid sha256 digest '%s'", s)
+		}
+		copy(digest[:], b)
+		return digest, nil
+	}
+	if s == "" {
+		return digest, errors.New("empty password or token")
+	}
+	return sha256.Sum256([]byte(s)), nil
+}
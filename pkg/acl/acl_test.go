@@ -0,0 +1,136 @@
+package acl
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+)
+
+func TestACL_Check(t *testing.T) {
+	digest := sha256.Sum256([]byte("s3cret"))
+	a, err := New(
+		UserSpec{Name: "admin", Passwords: []string{"sha256:" + hex.EncodeToString(digest[:])}, Categories: []string{"all"}, Keys: []string{"*"}},
+		UserSpec{Name: "app", Tokens: []string{"app-token"}, Categories: []string{"read", "write"}, Keys: []string{"app:*", "session:?"}},
+		UserSpec{Name: "default", NoPass: true, Categories: []string{"read"}, Keys: []string{"public:*"}},
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	// 1. 认证
+	if err := a.Authenticate("admin", "s3cret"); err != nil {
+		t.Errorf("admin password: %v", err)
+	}
+	if err := a.Authenticate("admin", "wrong"); err != ErrWrongPass {
+		t.Errorf("wrong password: %v", err)
+	}
+	if name, err := a.AuthenticateToken("app-token"); err != nil || name != "app" {
+		t.Errorf("token: %q %v", name, err)
+	}
+	basic := "Basic " + base64.StdEncoding.EncodeToString([]byte("admin:s3cret"))
+	if name, err := a.AuthenticateHeader(basic); err != nil || name != "admin" {
+		t.Errorf("basic header: %q %v", name, err)
+	}
+	if name, err := a.AuthenticateHeader("Bearer app-token"); err != nil || name != "app" {
+		t.Errorf("bearer header: %q %v", name, err)
+	}
+	if _, err := a.AuthenticateHeader("Bearer nope"); !IsAuthError(err) {
+		t.Errorf("bad bearer: %v", err)
+	}
+
+	// 2. 权限
+	tests := []struct {
+		user    string
+		cat     Category
+		keys    []string
+		allowed bool
+	}{
+		{"admin", Admin, nil, true},
+		{"admin", Write, []string{"anything"}, true},
+		{"app", Write, []string{"app:1", "session:a"}, true},
+		{"app", Write, []string{"app:1", "session:ab"}, false},
+		{"app", Admin, nil, false},
+		{"", Read, []string{"public:x"}, true},
+		{"", Write, []string{"public:x"}, false},
+		{"", Read, []string{"app:1"}, false},
+	}
+	for _, tt := range tests {
+		err := a.Check(tt.user, tt.cat, tt.keys...)
+		if (err == nil) != tt.allowed {
+			t.Errorf("Check(%q, %s, %v) = %v, allowed=%v", tt.user, tt.cat, tt.keys, err, tt.allowed)
+		}
+		if err != nil && !IsPermissionError(err) {
+			t.Errorf("Check(%q) should be a permission error: %v", tt.user, err)
+		}
+	}
+
+	// 3. 运行时修改：删除 default 后未认证的连接需要认证，删除用户后其令牌失效
+	a.DelUser("default")
+	if err := a.Check("", Read, "public:x"); err != ErrAuthRequired {
+		t.Errorf("without default user: %v", err)
+	}
+	if err := a.SetUser(UserSpec{Name: "app", Categories: []string{"read"}, Keys: []string{"*"}}); err != nil {
+		t.Fatalf("SetUser: %v", err)
+	}
+	if _, err := a.AuthenticateToken("app-token"); err != ErrWrongPass {
+		t.Errorf("replaced user should drop old tokens: %v", err)
+	}
+	if err := a.Check("app", Write, "app:1"); !IsPermissionError(err) {
+		t.Errorf("replaced user should lose write: %v", err)
+	}
+	a.DelUser("app")
+	if err := a.Check("app", Read, "app:1"); err != ErrWrongPass {
+		t.Errorf("deleted user: %v", err)
+	}
+
+	// 4. 令牌不能被两个用户共用，非法定义被拒绝
+	a.SetUser(UserSpec{Name: "a", Tokens: []string{"t"}})
+	if err := a.SetUser(UserSpec{Name: "b", Tokens: []string{"t"}}); err == nil {
+		t.Error("duplicate token should be rejected")
+	}
+	if err := a.SetUser(UserSpec{Name: "c", Categories: []string{"superuser"}}); err == nil {
+		t.Error("unknown category should be rejected")
+	}
+
+	// 5. nil 表示未开启
+	var disabled *ACL
+	if err := disabled.Check("", Admin, "x"); err != nil {
+		t.Errorf("nil ACL should allow everything: %v", err)
+	}
+}
+
+// TestACL_CheckPrefix 只有字面量加结尾 * 的模式覆盖整个前缀，空前缀需要 allkeys
+func TestACL_CheckPrefix(t *testing.T) {
+	a, err := New(
+		UserSpec{Name: "admin", NoPass: true, Categories: []string{"all"}, Keys: []string{"*"}},
+		UserSpec{Name: "app", NoPass: true, Categories: []string{"read"}, Keys: []string{"app:*", "a", "s*x", "t?*"}},
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	tests := []struct {
+		user    string
+		prefix  string
+		allowed bool
+	}{
+		{"admin", "", true},
+		{"admin", "anything", true},
+		{"app", "app:", true},
+		{"app", "app:users:", true},
+		{"app", "app", false}, // 还包括 apple
+		{"app", "a", false},   // 模式 a 只匹配 Key a 本身
+		{"app", "s", false},   // s*x 不匹配 sa
+		{"app", "t1", false},
+		{"app", "", false},
+	}
+	for _, tt := range tests {
+		err := a.CheckPrefix(tt.user, Read, tt.prefix)
+		if (err == nil) != tt.allowed {
+			t.Errorf("CheckPrefix(%q, %q) = %v, allowed=%v", tt.user, tt.prefix, err, tt.allowed)
+		}
+	}
+	if err := a.CheckPrefix("app", Write, "app:"); !IsPermissionError(err) {
+		t.Errorf("category should be checked: %v", err)
+	}
+}
@@ -3,7 +3,9 @@ package client
 import (
 	pb "Flux-KV/api/proto"
 	"Flux-KV/pkg/discovery"
+	"Flux-KV/pkg/kverrors"
 	"context"
+	"encoding/base64"
 	"errors"
 	"log"
 	"sync"
@@ -12,6 +14,7 @@ import (
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
@@ -23,15 +26,76 @@ type Client struct {
 	addrs   []string                      // 节点地址列表（用于轮训索引）
 
 	seq uint64 // 轮询计数器
+
+	creds credentials.TransportCredentials // 连接节点使用的传输凭证，默认不加密
+	auth  credentials.PerRPCCredentials    // 节点开启 ACL 时每个请求携带的凭证，nil 表示按 default 用户访问
 }
 
-// NewClient 初始化客户端管理器，并开始监听服务节点变化
-func NewClient(d *discovery.Discovery, serviceName string) (*Client, error) {
+// Option 客户端选项
+type Option func(*Client)
+
+// WithTransportCredentials 使用指定的传输凭证连接节点，例如 tlsutil.Reloader.TransportCredentials() 开启 mTLS
+func WithTransportCredentials(creds credentials.TransportCredentials) Option {
+	return func(c *Client) {
+		c.creds = creds
+	}
+}
+
+// WithBasicAuth 以 ACL 用户名和密码访问节点
+func WithBasicAuth(user, password string) Option {
+	return func(c *Client) {
+		c.auth = authorization("Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+password)))
+	}
+}
+
+// WithToken 以 ACL 令牌访问节点
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.auth = authorization("Bearer " + token)
+	}
+}
+
+// authorization 每个请求在元数据中携带 authorization 头
+// 不强制要求 TLS：节点之间可能运行在可信网络中，是否加密由 WithTransportCredentials 决定
+type authorization string
+
+func (a authorization) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": string(a)}, nil
+}
+
+func (a authorization) RequireTransportSecurity() bool {
+	return false
+}
+
+var (
+	// ErrNotFound Get 的 Key 不存在，错误码为 kverrors.NotFound
+	ErrNotFound = kverrors.New(kverrors.NotFound, "key not found")
+
+	errNoNodes = kverrors.New(kverrors.Unavailable, "no available kv-service nodes")
+)
+
+// typedErrors 把一元 RPC 返回的 gRPC 状态转换为 *kverrors.Error，调用方可以用 kverrors.Is 判断错误码
+// 转换后的错误仍然实现 GRPCStatus，status.Code 的结果不变；流式 RPC 的错误保持原样
+func typedErrors(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return kverrors.FromGRPC(invoker(ctx, method, req, reply, cc, opts...))
+}
+
+func newClient(opts []Option) *Client {
 	c := &Client{
 		clients: make(map[string]pb.KVServiceClient),
 		conns:   make(map[string]*grpc.ClientConn),
 		addrs:   make([]string, 0),
+		creds:   insecure.NewCredentials(),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
+}
+
+// NewClient 初始化客户端管理器，并开始监听服务节点变化
+func NewClient(d *discovery.Discovery, serviceName string, opts ...Option) (*Client, error) {
+	c := newClient(opts)
 
 	// 启动监听 (回调函数会自动处理现有节点和未来节点的连接建立)
 	// 假设 Etcd 中注册的 Key 是 /services/kv-service/uuid
@@ -47,12 +111,8 @@ func NewClient(d *discovery.Discovery, serviceName string) (*Client, error) {
 
 // NewDirectClient 创建直连单个节点的客户端（不使用服务发现）
 // 适用于测试用例或手动路由场景
-func NewDirectClient(addr string) (*Client, error) {
-	c := &Client{
-		clients: make(map[string]pb.KVServiceClient),
-		conns:   make(map[string]*grpc.ClientConn),
-		addrs:   make([]string, 0),
-	}
+func NewDirectClient(addr string, opts ...Option) (*Client, error) {
+	c := newClient(opts)
 
 	// 直接添加节点
 	c.addNode("direct", addr)
@@ -91,10 +151,15 @@ func (c *Client) addNode(key, value string) {
 	}
 
 	// 建立 gRPC 连接
-	conn, err := grpc.NewClient(addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(c.creds),
 		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
-	)
+		grpc.WithChainUnaryInterceptor(typedErrors),
+	}
+	if c.auth != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(c.auth))
+	}
+	conn, err := grpc.NewClient(addr, dialOpts...)
 	if err != nil {
 		log.Printf("❌ [Client] 连接节点失败 %s: %v", addr, err)
 		return
@@ -142,7 +207,7 @@ func (c *Client) lb() (pb.KVServiceClient, error) {
 	defer c.mu.RUnlock()
 
 	if len(c.addrs) == 0 {
-		return nil, errors.New("no available kv-service nodes")
+		return nil, errNoNodes
 	}
 
 	// 核心：原子递增，实现 Round-Robin
@@ -169,7 +234,7 @@ func (c *Client) Set(key, value string) error {
 	return err
 }
 
-// Get 封装 Get 请求
+// Get 封装 Get 请求，Key 不存在时返回 ErrNotFound
 func (c *Client) Get(key string) (string, error) {
 	client, err := c.lb()
 	if err != nil {
@@ -183,6 +248,9 @@ func (c *Client) Get(key string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if !resp.Found {
+		return "", ErrNotFound
+	}
 	return resp.Value, nil
 }
 
@@ -199,3 +267,251 @@ func (c *Client) Del(key string) error {
 	_, err = client.Del(ctx, &pb.DelRequest{Key: key})
 	return err
 }
+
+// NodeResult 广播调用时单个节点的返回结果
+type NodeResult[T any] struct {
+	Addr string
+	Resp T
+	Err  error
+}
+
+// broadcast 并发地对所有节点执行 fn，用于 INFO 这类需要汇总全集群的管理接口
+func broadcast[T any](c *Client, timeout time.Duration, fn func(ctx context.Context, cli pb.KVServiceClient) (T, error)) ([]NodeResult[T], error) {
+	// 1. 复制一份节点快照，避免长时间持有读锁
+	c.mu.RLock()
+	addrs := make([]string, len(c.addrs))
+	copy(addrs, c.addrs)
+	clients := make([]pb.KVServiceClient, len(addrs))
+	for i, addr := range addrs {
+		clients[i] = c.clients[addr]
+	}
+	c.mu.RUnlock()
+
+	if len(addrs) == 0 {
+		return nil, errNoNodes
+	}
+
+	// 2. 并发请求
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	results := make([]NodeResult[T], len(addrs))
+	var wg sync.WaitGroup
+	for i := range addrs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := fn(ctx, clients[i])
+			results[i] = NodeResult[T]{Addr: addrs[i], Resp: resp, Err: err}
+		}(i)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// InfoAll 获取所有节点的统计信息
+func (c *Client) InfoAll(section string) ([]NodeResult[*pb.InfoResponse], error) {
+	return broadcast(c, 5*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.InfoResponse, error) {
+		return cli.Info(ctx, &pb.InfoRequest{Section: section})
+	})
+}
+
+// SlowLogAll 获取所有节点的慢日志
+func (c *Client) SlowLogAll(count int) ([]NodeResult[*pb.SlowLogResponse], error) {
+	return broadcast(c, 5*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.SlowLogResponse, error) {
+		return cli.SlowLogGet(ctx, &pb.SlowLogRequest{Count: int32(count)})
+	})
+}
+
+// SlowLogResetAll 清空所有节点的慢日志
+func (c *Client) SlowLogResetAll() ([]NodeResult[*pb.SlowLogResetResponse], error) {
+	return broadcast(c, 5*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.SlowLogResetResponse, error) {
+		return cli.SlowLogReset(ctx, &pb.SlowLogResetRequest{})
+	})
+}
+
+// AclSetUserAll 在所有节点上创建或整体替换 ACL 用户
+func (c *Client) AclSetUserAll(user *pb.AclUser) ([]NodeResult[*pb.AclSetUserResponse], error) {
+	return broadcast(c, 5*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.AclSetUserResponse, error) {
+		return cli.AclSetUser(ctx, &pb.AclSetUserRequest{User: user})
+	})
+}
+
+// AclDelUserAll 在所有节点上删除 ACL 用户
+func (c *Client) AclDelUserAll(name string) ([]NodeResult[*pb.AclDelUserResponse], error) {
+	return broadcast(c, 5*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.AclDelUserResponse, error) {
+		return cli.AclDelUser(ctx, &pb.AclDelUserRequest{Name: name})
+	})
+}
+
+// AclListAll 获取所有节点的 ACL 用户，可用于检查各节点的用户是否一致
+func (c *Client) AclListAll() ([]NodeResult[*pb.AclListResponse], error) {
+	return broadcast(c, 5*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.AclListResponse, error) {
+		return cli.AclList(ctx, &pb.AclListRequest{})
+	})
+}
+
+// WatchEvent 带来源节点的变更事件
+type WatchEvent struct {
+	Node string
+	*pb.WatchEvent
+}
+
+// Watch 在当前所有节点上订阅 Key / 前缀的变更，合并推送到返回的通道
+// 每个节点单独记录最后收到的 revision，连接中断后自动从断点续订；
+// ctx 取消后通道关闭。调用之后才上线的节点不会被订阅
+func (c *Client) Watch(ctx context.Context, key string, prefix bool) (<-chan WatchEvent, error) {
+	c.mu.RLock()
+	targets := make(map[string]pb.KVServiceClient, len(c.addrs))
+	for _, addr := range c.addrs {
+		targets[addr] = c.clients[addr]
+	}
+	c.mu.RUnlock()
+
+	if len(targets) == 0 {
+		return nil, errNoNodes
+	}
+
+	out := make(chan WatchEvent, 256)
+	var wg sync.WaitGroup
+	for addr, cli := range targets {
+		wg.Add(1)
+		go func(addr string, cli pb.KVServiceClient) {
+			defer wg.Done()
+			c.watchNode(ctx, addr, cli, key, prefix, out)
+		}(addr, cli)
+	}
+
+	// 所有节点的订阅都退出后关闭通道
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out, nil
+}
+
+// watchNode 订阅单个节点，断线后按最后的 revision 重连
+func (c *Client) watchNode(ctx context.Context, addr string, cli pb.KVServiceClient, key string, prefix bool, out chan<- WatchEvent) {
+	var lastRev uint64
+	for ctx.Err() == nil {
+		// 1. 从上次收到的 revision 之后续订
+		startRev := uint64(0)
+		if lastRev > 0 {
+			startRev = lastRev + 1
+		}
+		stream, err := cli.Watch(ctx, &pb.WatchRequest{Key: key, Prefix: prefix, StartRevision: startRev})
+
+		// 2. 持续接收
+		for err == nil {
+			var ev *pb.WatchEvent
+			if ev, err = stream.Recv(); err != nil {
+				break
+			}
+			lastRev = ev.Revision
+			select {
+			case out <- WatchEvent{Node: addr, WatchEvent: ev}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		// 3. 断点已被移出历史窗口，只能从最新位置重新开始
+		switch kverrors.CodeOf(err) {
+		case kverrors.OutOfRange:
+			log.Printf("⚠️ [Client] Watch %s 的断点已过期，从最新位置重新订阅", addr)
+			lastRev = 0
+		case kverrors.FailedPrecondition:
+			log.Printf("❌ [Client] 节点 %s 未开启 Watch: %v", addr, err)
+			return
+		default:
+			log.Printf("⚠️ [Client] Watch %s 中断: %v，1 秒后重连", addr, err)
+		}
+
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Publish 向集群中所有节点发布消息，返回收到消息的订阅者总数
+// 订阅者只连接在某一个节点上，因此发布需要广播；部分节点失败时返回已送达的数量和第一个错误
+func (c *Client) Publish(channel, message string) (int64, error) {
+	results, err := broadcast(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.PublishResponse, error) {
+		return cli.Publish(ctx, &pb.PublishRequest{Channel: channel, Message: message})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var receivers int64
+	var firstErr error
+	for _, r := range results {
+		if r.Err != nil {
+			if firstErr == nil {
+				firstErr = r.Err
+			}
+			continue
+		}
+		receivers += r.Resp.Receivers
+	}
+	return receivers, firstErr
+}
+
+// Subscribe 在一个节点上订阅频道和 glob 模式，返回只包含 message / pmessage 的消息通道
+// ctx 取消或连接中断时通道关闭，调用方需要自行重新订阅（期间的消息不会补发）
+func (c *Client) Subscribe(ctx context.Context, channels, patterns []string) (<-chan *pb.PubSubMessage, error) {
+	if len(channels) == 0 && len(patterns) == 0 {
+		return nil, errors.New("at least one channel or pattern is required")
+	}
+	cli, err := c.lb()
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. 建立双向流并发送订阅请求
+	stream, err := cli.PubSub(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(channels) > 0 {
+		err = stream.Send(&pb.PubSubRequest{Action: pb.PubSubRequest_SUBSCRIBE, Channels: channels})
+	}
+	if err == nil && len(patterns) > 0 {
+		err = stream.Send(&pb.PubSubRequest{Action: pb.PubSubRequest_PSUBSCRIBE, Channels: patterns})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 后台接收，过滤掉订阅确认
+	out := make(chan *pb.PubSubMessage, 256)
+	go func() {
+		defer close(out)
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("⚠️ [Client] 订阅中断: %v", err)
+				}
+				return
+			}
+			if msg.Kind != "message" && msg.Kind != "pmessage" {
+				continue
+			}
+			select {
+			case out <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	pb "Flux-KV/api/proto"
+	"context"
+	"time"
+)
+
+// GeoAdd 添加或更新成员坐标，返回新增的成员数
+func (c *Client) GeoAdd(key string, locations ...*pb.GeoLocation) (int64, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.GeoAddResponse, error) {
+		return cli.GeoAdd(ctx, &pb.GeoAddRequest{Key: key, Locations: locations})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Added, nil
+}
+
+// GeoPos 查询成员坐标，不存在的成员 Exists 为 false
+func (c *Client) GeoPos(key string, members ...string) ([]*pb.GeoPosition, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.GeoPosResponse, error) {
+		return cli.GeoPos(ctx, &pb.GeoPosRequest{Key: key, Members: members})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Positions, nil
+}
+
+// GeoDist 计算两个成员之间的距离，任一成员不存在时 ok 为 false
+func (c *Client) GeoDist(key, member1, member2, unit string) (dist float64, ok bool, err error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.GeoDistResponse, error) {
+		return cli.GeoDist(ctx, &pb.GeoDistRequest{Key: key, Member1: member1, Member2: member2, Unit: unit})
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	return resp.Distance, resp.Exists, nil
+}
+
+// GeoSearch 按圆形或矩形区域搜索成员
+func (c *Client) GeoSearch(req *pb.GeoSearchRequest) ([]*pb.GeoSearchResult, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.GeoSearchResponse, error) {
+		return cli.GeoSearch(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
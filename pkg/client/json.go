@@ -0,0 +1,104 @@
+package client
+
+import (
+	pb "Flux-KV/api/proto"
+	"context"
+	"time"
+)
+
+// JSONSet 把 JSON 文本写入文档的指定路径，nx / xx 条件不满足时返回 false
+func (c *Client) JSONSet(key, path, value string, nx, xx bool) (bool, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.JSONSetResponse, error) {
+		return cli.JSONSet(ctx, &pb.JSONSetRequest{Key: key, Path: path, Value: value, Nx: nx, Xx: xx})
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Success, nil
+}
+
+// JSONGet 读取一个或多个路径，Key 不存在时 found 为 false
+func (c *Client) JSONGet(key string, paths ...string) (value string, found bool, err error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.JSONGetResponse, error) {
+		return cli.JSONGet(ctx, &pb.JSONGetRequest{Key: key, Paths: paths})
+	})
+	if err != nil {
+		return "", false, err
+	}
+	return resp.Value, resp.Found, nil
+}
+
+// JSONDel 删除路径上的值，返回删除的个数
+func (c *Client) JSONDel(key, path string) (int64, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.JSONDelResponse, error) {
+		return cli.JSONDel(ctx, &pb.JSONDelRequest{Key: key, Path: path})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Deleted, nil
+}
+
+// JSONArrAppend 向数组末尾追加元素（每个都是 JSON 文本），返回追加后的长度
+func (c *Client) JSONArrAppend(key, path string, values ...string) (int64, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.JSONArrAppendResponse, error) {
+		return cli.JSONArrAppend(ctx, &pb.JSONArrAppendRequest{Key: key, Path: path, Values: values})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Length, nil
+}
+
+// JSONNumIncrBy 数值自增，返回新值
+func (c *Client) JSONNumIncrBy(key, path, delta string) (string, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.JSONNumIncrByResponse, error) {
+		return cli.JSONNumIncrBy(ctx, &pb.JSONNumIncrByRequest{Key: key, Path: path, Delta: delta})
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Value, nil
+}
+
+// JSONCreateIndex 在命名空间的字段上建二级索引，indexType 为 tag 或 numeric
+func (c *Client) JSONCreateIndex(namespace, field, indexType string) error {
+	_, err := call(c, 10*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.JSONIndexResponse, error) {
+		return cli.JSONCreateIndex(ctx, &pb.JSONIndexRequest{Namespace: namespace, Field: field, Type: indexType})
+	})
+	return err
+}
+
+// JSONDropIndex 删除二级索引，返回是否存在
+func (c *Client) JSONDropIndex(namespace, field string) (bool, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.JSONIndexResponse, error) {
+		return cli.JSONDropIndex(ctx, &pb.JSONIndexRequest{Namespace: namespace, Field: field})
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Success, nil
+}
+
+// JSONListIndexes 列出二级索引，namespace 为空时返回全部
+func (c *Client) JSONListIndexes(namespace string) ([]*pb.JSONIndexInfo, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.JSONListIndexesResponse, error) {
+		return cli.JSONListIndexes(ctx, &pb.JSONListIndexesRequest{Namespace: namespace})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Indexes, nil
+}
+
+// Find 按二级索引查询命名空间下的文档
+// 与 TSMRange 一样只查询轮询选中的节点
+func (c *Client) Find(req *pb.FindRequest) ([]*pb.JSONMatch, error) {
+	resp, err := call(c, 5*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.FindResponse, error) {
+		return cli.Find(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Matches, nil
+}
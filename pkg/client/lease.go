@@ -0,0 +1,119 @@
+package client
+
+import (
+	pb "Flux-KV/api/proto"
+	"context"
+	"strconv"
+	"time"
+)
+
+// leaseNode 租约及绑定的 Key 必须在同一个节点上，按租约 ID 固定路由
+func (c *Client) leaseNode(id int64) (pb.KVServiceClient, error) {
+	return c.pick("lease:" + strconv.FormatInt(id, 10))
+}
+
+// LeaseGrant 创建租约，返回租约 ID
+// ID 由客户端生成，这样在请求发出前就能确定路由到哪个节点
+func (c *Client) LeaseGrant(ttl time.Duration) (int64, error) {
+	id := time.Now().UnixNano()
+	cli, err := c.leaseNode(id)
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	resp, err := cli.LeaseGrant(ctx, &pb.LeaseGrantRequest{Id: id, TtlMs: ttl.Milliseconds()})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Id, nil
+}
+
+// SetWithLease 写入 Key 并绑定到租约
+func (c *Client) SetWithLease(key, value string, lease int64) error {
+	cli, err := c.leaseNode(lease)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = cli.Set(ctx, &pb.SetRequest{Key: key, Value: value, Lease: lease})
+	return err
+}
+
+// LeaseRevoke 撤销租约，返回被删除的 Key 数
+func (c *Client) LeaseRevoke(id int64) (int64, error) {
+	cli, err := c.leaseNode(id)
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	resp, err := cli.LeaseRevoke(ctx, &pb.LeaseRevokeRequest{Id: id})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Deleted, nil
+}
+
+// LeaseTimeToLive 查询租约的剩余时间，keys 为 true 时返回绑定的 Key
+func (c *Client) LeaseTimeToLive(id int64, keys bool) (*pb.LeaseTimeToLiveResponse, error) {
+	cli, err := c.leaseNode(id)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return cli.LeaseTimeToLive(ctx, &pb.LeaseTimeToLiveRequest{Id: id, Keys: keys})
+}
+
+// LeaseKeepAlive 在后台按 TTL/3 的间隔持续续期，返回每次续期的结果
+// ctx 结束、租约不存在或连接断开时停止续期并关闭通道
+func (c *Client) LeaseKeepAlive(ctx context.Context, id int64) (<-chan *pb.LeaseKeepAliveResponse, error) {
+	info, err := c.LeaseTimeToLive(id, false)
+	if err != nil {
+		return nil, err
+	}
+	cli, err := c.leaseNode(id)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := cli.LeaseKeepAlive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *pb.LeaseKeepAliveResponse, 1)
+	go func() {
+		defer close(out)
+		defer stream.CloseSend()
+
+		interval := time.Duration(info.GrantedTtlMs) * time.Millisecond / 3
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := stream.Send(&pb.LeaseKeepAliveRequest{Id: id}); err != nil {
+				return
+			}
+			resp, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case out <- resp:
+			default:
+				// 调用方没有及时读取时丢弃旧结果，不阻塞续期
+			}
+			if resp.TtlMs == 0 {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return out, nil
+}
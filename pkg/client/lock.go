@@ -0,0 +1,167 @@
+package client
+
+import (
+	pb "Flux-KV/api/proto"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrLockNotAcquired 在等待时间内没有拿到锁
+	ErrLockNotAcquired = errors.New("lock not acquired")
+	// ErrLockLost 续期失败（锁已过期被他人拿走，或节点在租期内不可达），作为 Lock.Context() 的取消原因
+	ErrLockLost = errors.New("lock lost")
+	// ErrLockReleased 主动释放后 Lock.Context() 的取消原因
+	ErrLockReleased = errors.New("lock released")
+)
+
+// lockSafetyMargin 客户端判定锁丢失的时间比服务端过期提前 ttl/lockSafetyMargin，抵消网络延迟和时钟误差
+const lockSafetyMargin = 10
+
+// pick 按 Key 选择固定的节点（rendezvous hashing）
+// 锁的状态只存在于单个节点上，不能像普通读写一样轮询；节点增减时只有落在该节点上的 Key 会迁移
+func (c *Client) pick(key string) (pb.KVServiceClient, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if len(c.addrs) == 0 {
+		return nil, errNoNodes
+	}
+	var best string
+	var bestScore uint64
+	for _, addr := range c.addrs {
+		h := fnv.New64a()
+		h.Write([]byte(addr))
+		h.Write([]byte{0})
+		h.Write([]byte(key))
+		if score := h.Sum64(); best == "" || score > bestScore {
+			best, bestScore = addr, score
+		}
+	}
+	return c.clients[best], nil
+}
+
+// Lock 持有中的分布式锁，后台按 ttl/3 的间隔自动续期
+type Lock struct {
+	cli   pb.KVServiceClient // 加锁的节点，续期和释放都发往同一个节点
+	name  string
+	owner string
+	token uint64
+	ttl   time.Duration
+
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+// AcquireLock 获取分布式锁，wait 大于 0 时最多排队等待 wait
+// owner 为空时随机生成；拿到锁后返回的 Lock 会自动续期，直到 Release 或锁丢失
+// 锁丢失时 Lock.Context() 被取消，context.Cause 为 ErrLockLost，持锁执行的任务应监听它并停止写入
+// ctx 被取消时同样停止续期，锁在租期结束后由服务端自动释放
+func (c *Client) AcquireLock(ctx context.Context, name, owner string, ttl, wait time.Duration) (*Lock, error) {
+	if owner == "" {
+		owner = randomOwner()
+	}
+	cli, err := c.pick(name)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcCtx, cancel := context.WithTimeout(ctx, wait+2*time.Second)
+	defer cancel()
+	resp, err := cli.LockAcquire(rpcCtx, &pb.LockAcquireRequest{
+		Name: name, Owner: owner, TtlMs: ttl.Milliseconds(), WaitMs: wait.Milliseconds(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Acquired {
+		return nil, ErrLockNotAcquired
+	}
+
+	l := &Lock{cli: cli, name: name, owner: owner, token: resp.Token, ttl: ttl, done: make(chan struct{})}
+	l.ctx, l.cancel = context.WithCancelCause(ctx)
+	go l.keepAlive()
+	return l, nil
+}
+
+// Token 加锁时分配的 fencing token，写下游存储时带上它，下游拒绝比已见过的更小的 token
+func (l *Lock) Token() uint64 {
+	return l.token
+}
+
+// Owner 持有者标识
+func (l *Lock) Owner() string {
+	return l.owner
+}
+
+// Context 锁丢失或释放时被取消
+func (l *Lock) Context() context.Context {
+	return l.ctx
+}
+
+// Release 停止续期并释放锁；锁已丢失时返回 ErrLockLost
+func (l *Lock) Release(ctx context.Context) error {
+	l.stop(ErrLockReleased)
+	<-l.done
+
+	resp, err := l.cli.LockRelease(ctx, &pb.LockReleaseRequest{Name: l.name, Owner: l.owner})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return ErrLockLost
+	}
+	return nil
+}
+
+func (l *Lock) stop(cause error) {
+	l.once.Do(func() { l.cancel(cause) })
+}
+
+// keepAlive 每 ttl/3 续期一次
+// 续期被拒绝说明锁已被他人拿走，立即判定丢失；网络错误则持续重试
+// 另有一个定时器在租期结束前 lockSafetyMargin 触发，到点仍未续期成功就立即判定丢失，不等下一次续期
+func (l *Lock) keepAlive() {
+	defer close(l.done)
+
+	interval := l.ttl / 3
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	lease := l.ttl - l.ttl/lockSafetyMargin
+	lost := time.AfterFunc(lease, func() { l.stop(ErrLockLost) })
+	defer lost.Stop()
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(l.ctx, interval)
+		start := time.Now()
+		resp, err := l.cli.LockRenew(ctx, &pb.LockRenewRequest{Name: l.name, Owner: l.owner, TtlMs: l.ttl.Milliseconds()})
+		cancel()
+		switch {
+		case err == nil && resp.Success:
+			// 以发出请求的时间计算新的租期，定时器已触发时 stop 只生效一次，锁仍按丢失处理
+			lost.Reset(time.Until(start.Add(lease)))
+		case err == nil:
+			l.stop(ErrLockLost)
+			return
+		}
+	}
+}
+
+func randomOwner() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	pb "Flux-KV/api/proto"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scriptNode 脚本访问的 Key 必须在同一个节点上，按第一个 Key 路由；没有 Key 时轮询
+func (c *Client) scriptNode(keys []string) (pb.KVServiceClient, error) {
+	if len(keys) == 0 {
+		return c.lb()
+	}
+	return c.pick(keys[0])
+}
+
+// Eval 执行脚本，先按 SHA1 调用 EvalSha，节点上没有缓存时再发送完整脚本
+func (c *Client) Eval(script string, keys, args []string) (*pb.ScriptValue, error) {
+	cli, err := c.scriptNode(keys)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sum := sha1.Sum([]byte(script))
+	resp, err := cli.EvalSha(ctx, &pb.EvalShaRequest{Sha: hex.EncodeToString(sum[:]), Keys: keys, Args: args})
+	if status.Code(err) == codes.NotFound {
+		resp, err = cli.Eval(ctx, &pb.EvalRequest{Script: script, Keys: keys, Args: args})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
+
+// EvalSha 执行已缓存的脚本，节点上没有缓存时返回 NotFound
+func (c *Client) EvalSha(sha string, keys, args []string) (*pb.ScriptValue, error) {
+	cli, err := c.scriptNode(keys)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := cli.EvalSha(ctx, &pb.EvalShaRequest{Sha: sha, Keys: keys, Args: args})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
+
+// ScriptLoad 把脚本缓存到所有节点，返回 SHA1
+func (c *Client) ScriptLoad(script string) (string, error) {
+	results, err := broadcast(c, 5*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.ScriptLoadResponse, error) {
+		return cli.ScriptLoad(ctx, &pb.ScriptLoadRequest{Script: script})
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			return "", r.Err
+		}
+	}
+	return results[0].Resp.Sha, nil
+}
+
+// ScriptValue 把脚本返回值转换为 Go 的值：nil / int64 / float64 / string / bool / []any
+func ScriptValue(v *pb.ScriptValue) any {
+	switch x := v.GetValue().(type) {
+	case *pb.ScriptValue_Int:
+		return x.Int
+	case *pb.ScriptValue_Float:
+		return x.Float
+	case *pb.ScriptValue_Str:
+		return x.Str
+	case *pb.ScriptValue_Bool:
+		return x.Bool
+	case *pb.ScriptValue_List:
+		out := make([]any, len(x.List.Items))
+		for i, item := range x.List.Items {
+			out[i] = ScriptValue(item)
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	pb "Flux-KV/api/proto"
+	"context"
+	"time"
+)
+
+// call 轮询选择一个节点执行单次 RPC
+func call[T any](c *Client, timeout time.Duration, fn func(ctx context.Context, cli pb.KVServiceClient) (T, error)) (T, error) {
+	var zero T
+	cli, err := c.lb()
+	if err != nil {
+		return zero, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return fn(ctx, cli)
+}
+
+// PFAdd 向 HyperLogLog 添加元素，返回估算值是否可能变化
+func (c *Client) PFAdd(key string, elements ...string) (bool, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.PFAddResponse, error) {
+		return cli.PFAdd(ctx, &pb.PFAddRequest{Key: key, Elements: elements})
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Changed, nil
+}
+
+// PFCount 估算一个或多个 HyperLogLog 并集的基数
+func (c *Client) PFCount(keys ...string) (uint64, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.PFCountResponse, error) {
+		return cli.PFCount(ctx, &pb.PFCountRequest{Keys: keys})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Count, nil
+}
+
+// PFMerge 把 sources 合并到 dest
+func (c *Client) PFMerge(dest string, sources ...string) error {
+	_, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.PFMergeResponse, error) {
+		return cli.PFMerge(ctx, &pb.PFMergeRequest{Dest: dest, Sources: sources})
+	})
+	return err
+}
+
+// BFReserve 按误判率和容量创建布隆过滤器
+func (c *Client) BFReserve(key string, errorRate float64, capacity uint64) error {
+	_, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.BFReserveResponse, error) {
+		return cli.BFReserve(ctx, &pb.BFReserveRequest{Key: key, ErrorRate: errorRate, Capacity: capacity})
+	})
+	return err
+}
+
+// BFAdd 向布隆过滤器添加元素，返回每个元素此前是否不存在
+func (c *Client) BFAdd(key string, items ...string) ([]bool, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.BFItemsResponse, error) {
+		return cli.BFAdd(ctx, &pb.BFItemsRequest{Key: key, Items: items})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// BFExists 判断元素是否可能存在
+func (c *Client) BFExists(key string, items ...string) ([]bool, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.BFItemsResponse, error) {
+		return cli.BFExists(ctx, &pb.BFItemsRequest{Key: key, Items: items})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// CMSInitByDim 按宽度和深度创建 Count-Min Sketch
+func (c *Client) CMSInitByDim(key string, width, depth uint32) error {
+	_, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.CMSInitResponse, error) {
+		return cli.CMSInit(ctx, &pb.CMSInitRequest{Key: key, Width: width, Depth: depth})
+	})
+	return err
+}
+
+// CMSInitByProb 按误差和置信度创建 Count-Min Sketch，返回实际的宽度和深度
+func (c *Client) CMSInitByProb(key string, errorRate, probability float64) (width, depth uint32, err error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.CMSInitResponse, error) {
+		return cli.CMSInit(ctx, &pb.CMSInitRequest{Key: key, ErrorRate: errorRate, Probability: probability})
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return resp.Width, resp.Depth, nil
+}
+
+// CMSIncrBy 增加计数，返回增加后各元素的估算值
+func (c *Client) CMSIncrBy(key string, increments map[string]uint32) (map[string]uint32, error) {
+	req := &pb.CMSIncrByRequest{Key: key}
+	for item, n := range increments {
+		req.Increments = append(req.Increments, &pb.CMSIncrement{Item: item, Increment: n})
+	}
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.CMSCountsResponse, error) {
+		return cli.CMSIncrBy(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]uint32, len(req.Increments))
+	for i, inc := range req.Increments {
+		counts[inc.Item] = resp.Counts[i]
+	}
+	return counts, nil
+}
+
+// CMSQuery 查询元素的估算计数
+func (c *Client) CMSQuery(key string, items ...string) ([]uint32, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.CMSCountsResponse, error) {
+		return cli.CMSQuery(ctx, &pb.CMSQueryRequest{Key: key, Items: items})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Counts, nil
+}
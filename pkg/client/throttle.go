@@ -0,0 +1,24 @@
+package client
+
+import (
+	pb "Flux-KV/api/proto"
+	"context"
+	"time"
+)
+
+// Throttle 按 Key 判定请求是否放行
+// 限流状态只存在于单个节点上，同一个 Key 固定发往同一个节点，否则每个节点各算各的
+func (c *Client) Throttle(key string, rate float64, burst, cost int64) (*pb.ThrottleResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return c.ThrottleContext(ctx, key, rate, burst, cost)
+}
+
+// ThrottleContext 同 Throttle，超时由 ctx 控制；网关限流中间件用较短的超时，节点变慢时尽快退回本地限流
+func (c *Client) ThrottleContext(ctx context.Context, key string, rate float64, burst, cost int64) (*pb.ThrottleResponse, error) {
+	cli, err := c.pick(key)
+	if err != nil {
+		return nil, err
+	}
+	return cli.Throttle(ctx, &pb.ThrottleRequest{Key: key, Rate: rate, Burst: burst, Cost: cost})
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	pb "Flux-KV/api/proto"
+	"context"
+	"time"
+)
+
+// TSCreate 创建时间序列，retention 为 0 表示永久保留
+func (c *Client) TSCreate(key string, retention time.Duration, labels map[string]string) error {
+	_, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.TSCreateResponse, error) {
+		return cli.TSCreate(ctx, &pb.TSCreateRequest{Key: key, RetentionMs: retention.Milliseconds(), Labels: labels})
+	})
+	return err
+}
+
+// TSAdd 追加样本，timestamp 为毫秒，0 表示使用服务端时间；返回实际写入的时间戳
+func (c *Client) TSAdd(key string, timestamp int64, value float64) (int64, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.TSAddResponse, error) {
+		return cli.TSAdd(ctx, &pb.TSAddRequest{Key: key, Timestamp: timestamp, Value: value})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Timestamp, nil
+}
+
+// TSGet 返回最后一个样本，序列为空时返回 nil
+func (c *Client) TSGet(key string) (*pb.TSSample, error) {
+	resp, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.TSGetResponse, error) {
+		return cli.TSGet(ctx, &pb.TSGetRequest{Key: key})
+	})
+	if err != nil || !resp.Exists {
+		return nil, err
+	}
+	return resp.Sample, nil
+}
+
+// TSRange 查询单个序列
+func (c *Client) TSRange(req *pb.TSRangeRequest) ([]*pb.TSSample, error) {
+	resp, err := call(c, 5*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.TSRangeResponse, error) {
+		return cli.TSRange(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Samples, nil
+}
+
+// TSMRange 按标签过滤查询多个序列
+// 序列分布在哪个节点取决于写入时的路由，这里与 Get 一样只查询轮询选中的节点
+func (c *Client) TSMRange(req *pb.TSMRangeRequest) ([]*pb.TSSeries, error) {
+	resp, err := call(c, 5*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.TSMRangeResponse, error) {
+		return cli.TSMRange(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Series, nil
+}
+
+// TSCreateRule 创建降采样规则
+func (c *Client) TSCreateRule(source, dest, aggType string, bucket time.Duration) error {
+	_, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.TSRuleResponse, error) {
+		return cli.TSCreateRule(ctx, &pb.TSRuleRequest{
+			Source:      source,
+			Dest:        dest,
+			Aggregation: &pb.TSAggregation{Type: aggType, BucketMs: bucket.Milliseconds()},
+		})
+	})
+	return err
+}
+
+// TSDeleteRule 删除降采样规则
+func (c *Client) TSDeleteRule(source, dest string) error {
+	_, err := call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.TSRuleResponse, error) {
+		return cli.TSDeleteRule(ctx, &pb.TSRuleRequest{Source: source, Dest: dest})
+	})
+	return err
+}
+
+// TSInfo 返回序列的元信息
+func (c *Client) TSInfo(key string) (*pb.TSInfoResponse, error) {
+	return call(c, 2*time.Second, func(ctx context.Context, cli pb.KVServiceClient) (*pb.TSInfoResponse, error) {
+		return cli.TSInfo(ctx, &pb.TSInfoRequest{Key: key})
+	})
+}
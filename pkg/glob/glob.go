@@ -0,0 +1,105 @@
+package glob
+
+// Match 判断 str 是否匹配 Redis 风格的 glob 模式
+// 支持的语法：
+//
+//   - "*" 匹配任意长度（含空）的字符序列
+//   - "?" 匹配任意单个字符
+//   - "[abc]" 匹配括号内任一字符，支持范围 [a-z] 和取反 [^a]
+//   - "\x" 转义，按字面量匹配 x
+//
+// 与 path.Match 不同，这里的 * 可以匹配 '/'，更适合 Key 这类扁平的字符串
+func Match(pattern, str string) bool {
+	p, s := 0, 0
+	// 记录最近一个 * 的位置，用于回溯
+	starP, starS := -1, 0
+
+	for s < len(str) {
+		if p < len(pattern) {
+			switch pattern[p] {
+			case '*':
+				// 合并连续的 *
+				for p < len(pattern) && pattern[p] == '*' {
+					p++
+				}
+				if p == len(pattern) {
+					return true
+				}
+				starP, starS = p, s
+				continue
+			case '?':
+				p++
+				s++
+				continue
+			case '[':
+				if next, ok := matchClass(pattern, p, str[s]); ok {
+					p = next
+					s++
+					continue
+				}
+			case '\\':
+				if p+1 < len(pattern) && pattern[p+1] == str[s] {
+					p += 2
+					s++
+					continue
+				}
+			default:
+				if pattern[p] == str[s] {
+					p++
+					s++
+					continue
+				}
+			}
+		}
+
+		// 当前字符匹配失败：回溯到上一个 *，让它多吞一个字符
+		if starP < 0 {
+			return false
+		}
+		starS++
+		p, s = starP, starS
+	}
+
+	// str 已耗尽，pattern 剩余部分只能是 *
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// matchClass 匹配从 pattern[start] 开始的字符集 [...]
+// 返回字符集结束后的位置，以及 c 是否命中
+func matchClass(pattern string, start int, c byte) (int, bool) {
+	i := start + 1
+	negate := false
+	if i < len(pattern) && pattern[i] == '^' {
+		negate = true
+		i++
+	}
+
+	matched := false
+	for i < len(pattern) && pattern[i] != ']' {
+		lo := pattern[i]
+		if lo == '\\' && i+1 < len(pattern) {
+			i++
+			lo = pattern[i]
+		}
+		hi := lo
+		if i+2 < len(pattern) && pattern[i+1] == '-' && pattern[i+2] != ']' {
+			hi = pattern[i+2]
+			i += 2
+		}
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		if c >= lo && c <= hi {
+			matched = true
+		}
+		i++
+	}
+	if i >= len(pattern) {
+		// 没有闭合的 ]，按字面量 '[' 处理
+		return start + 1, c == '['
+	}
+	return i + 1, matched != negate
+}
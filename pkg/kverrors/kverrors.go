@@ -0,0 +1,279 @@
+// Package kverrors gRPC、TCP 协议与 HTTP 网关共用的错误模型
+//
+// 每个错误带一个稳定的错误码，错误码到 gRPC 状态码、TCP 错误前缀和 HTTP 状态码的映射只在这里定义：
+//   - gRPC：*Error 实现了 GRPCStatus，直接作为 RPC 的返回值；状态中附带 ErrorInfo 详情（Reason 为错误码），
+//     多个错误码对应同一个 gRPC 状态码时客户端据此还原
+//   - TCP：错误回复以前缀开头，例如 "NOTFOUND TSDB: the key does not exist"，文本协议为 "ERROR: NOTFOUND ..."
+//   - HTTP：网关按错误码返回状态码，响应体带 code 字段
+package kverrors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Code 稳定的错误码，客户端可以依赖其取值；只能新增，不能修改已有的值
+type Code string
+
+const (
+	Unknown            Code = "UNKNOWN"             // 未分类的错误
+	InvalidArgument    Code = "INVALID_ARGUMENT"    // 参数或语法错误
+	NotFound           Code = "NOT_FOUND"           // Key、成员、脚本、租约等不存在
+	WrongType          Code = "WRONG_TYPE"          // Key 上的值类型与命令不符
+	OutOfMemory        Code = "OUT_OF_MEMORY"       // 内存达到上限，拒绝写入
+	ReadOnly           Code = "READ_ONLY"           // 节点只读，拒绝写入
+	Unauthorized       Code = "UNAUTHORIZED"        // 未认证或凭证错误
+	PermissionDenied   Code = "PERMISSION_DENIED"   // 已认证但没有权限
+	Throttled          Code = "THROTTLED"           // 请求被限流
+	Conflict           Code = "CONFLICT"            // 已存在，或与并发修改冲突（例如 CAS 不匹配）
+	Timeout            Code = "TIMEOUT"             // 执行超时
+	FailedPrecondition Code = "FAILED_PRECONDITION" // 当前状态不允许该操作，例如功能未开启
+	OutOfRange         Code = "OUT_OF_RANGE"        // 请求的位置已不可用，例如 Watch 的起始 revision 已被压缩
+	Unavailable        Code = "UNAVAILABLE"         // 节点不可用，可以稍后重试
+	Internal           Code = "INTERNAL"            // 服务端内部错误
+)
+
+// Domain gRPC ErrorInfo 详情中的 Domain
+const Domain = "flux-kv"
+
+// mapping 一个错误码在三种协议中的表示
+type mapping struct {
+	grpc codes.Code
+	tcp  string
+	http int
+}
+
+var mappings = map[Code]mapping{
+	Unknown:            {codes.Unknown, "ERR", http.StatusInternalServerError},
+	InvalidArgument:    {codes.InvalidArgument, "ERR", http.StatusBadRequest},
+	NotFound:           {codes.NotFound, "NOTFOUND", http.StatusNotFound},
+	WrongType:          {codes.FailedPrecondition, "WRONGTYPE", http.StatusConflict},
+	OutOfMemory:        {codes.ResourceExhausted, "OOM", http.StatusInsufficientStorage},
+	ReadOnly:           {codes.FailedPrecondition, "READONLY", http.StatusConflict},
+	Unauthorized:       {codes.Unauthenticated, "NOAUTH", http.StatusUnauthorized},
+	PermissionDenied:   {codes.PermissionDenied, "NOPERM", http.StatusForbidden},
+	Throttled:          {codes.ResourceExhausted, "THROTTLED", http.StatusTooManyRequests},
+	Conflict:           {codes.AlreadyExists, "CONFLICT", http.StatusConflict},
+	Timeout:            {codes.DeadlineExceeded, "TIMEOUT", http.StatusGatewayTimeout},
+	FailedPrecondition: {codes.FailedPrecondition, "ERR", http.StatusPreconditionFailed},
+	OutOfRange:         {codes.OutOfRange, "ERR", http.StatusBadRequest},
+	Unavailable:        {codes.Unavailable, "UNAVAILABLE", http.StatusServiceUnavailable},
+	Internal:           {codes.Internal, "ERR", http.StatusInternalServerError},
+}
+
+// tcpPrefixes TCP 错误前缀到错误码，除各错误码的默认前缀外还包括兼容 Redis 客户端的前缀；
+// ERR 是通用前缀，绝大多数是参数错误，按 InvalidArgument 处理
+var tcpPrefixes = map[string]Code{
+	"ERR":         InvalidArgument,
+	"NOTFOUND":    NotFound,
+	"NOSCRIPT":    NotFound,
+	"NOGROUP":     NotFound,
+	"WRONGTYPE":   WrongType,
+	"OOM":         OutOfMemory,
+	"READONLY":    ReadOnly,
+	"NOAUTH":      Unauthorized,
+	"WRONGPASS":   Unauthorized,
+	"NOPERM":      PermissionDenied,
+	"THROTTLED":   Throttled,
+	"CONFLICT":    Conflict,
+	"BUSYGROUP":   Conflict,
+	"TIMEOUT":     Timeout,
+	"UNAVAILABLE": Unavailable,
+}
+
+// GRPCCode 错误码对应的 gRPC 状态码
+func (c Code) GRPCCode() codes.Code {
+	if m, ok := mappings[c]; ok {
+		return m.grpc
+	}
+	return codes.Unknown
+}
+
+// HTTPStatus 错误码对应的 HTTP 状态码
+func (c Code) HTTPStatus() int {
+	if m, ok := mappings[c]; ok {
+		return m.http
+	}
+	return http.StatusInternalServerError
+}
+
+// TCPPrefix 错误码对应的 TCP 错误前缀
+func (c Code) TCPPrefix() string {
+	if m, ok := mappings[c]; ok {
+		return m.tcp
+	}
+	return "ERR"
+}
+
+// Error 带错误码的错误
+type Error struct {
+	Code    Code
+	Message string // Error() 的返回值，可以已经以 TCP 前缀开头，例如 "WRONGTYPE Operation against ..."
+	Prefix  string // TCP 错误前缀，为空时使用错误码的默认前缀；用于兼容 Redis 客户端识别的 NOSCRIPT / NOGROUP 等
+
+	status *status.Status // FromGRPC 转换前的原始状态
+}
+
+// New 创建带错误码的错误，通常作为包级别的哨兵错误，用 errors.Is 比较
+func New(code Code, msg string) *Error {
+	return &Error{Code: code, Message: msg}
+}
+
+// WithPrefix 创建使用指定 TCP 前缀的错误
+func WithPrefix(code Code, prefix, msg string) *Error {
+	return &Error{Code: code, Message: msg, Prefix: prefix}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ErrorCode 实现 Coder
+func (e *Error) ErrorCode() Code {
+	return e.Code
+}
+
+// GRPCStatus gRPC 通过该方法把返回的错误转换为状态，附带 ErrorInfo 详情；FromGRPC 得到的错误返回原始状态
+func (e *Error) GRPCStatus() *status.Status {
+	if e.status != nil {
+		return e.status
+	}
+	st := status.New(e.Code.GRPCCode(), e.Message)
+	if withInfo, err := st.WithDetails(&errdetails.ErrorInfo{Reason: string(e.Code), Domain: Domain}); err == nil {
+		return withInfo
+	}
+	return st
+}
+
+// Coder 其他错误类型实现该接口即可声明自己的错误码，例如 acl.PermissionError
+type Coder interface {
+	ErrorCode() Code
+}
+
+// CodeOf 取出错误码：实现了 Coder 的错误、上下文错误，以及客户端收到的 gRPC 状态；其余为 Unknown，nil 返回空字符串
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var coder Coder
+	if errors.As(err, &coder) {
+		return coder.ErrorCode()
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return Timeout
+	case errors.Is(err, context.Canceled):
+		return Unavailable
+	}
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown {
+		return fromStatus(st)
+	}
+	return Unknown
+}
+
+// Is 错误是否带有指定的错误码
+func Is(err error, code Code) bool {
+	return err != nil && CodeOf(err) == code
+}
+
+// IsNotFound 错误是否为 NotFound
+func IsNotFound(err error) bool {
+	return Is(err, NotFound)
+}
+
+// From 把任意错误转换为 *Error，没有错误码的错误使用 fallback；nil 返回 nil
+func From(err error, fallback Code) *Error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	code := CodeOf(err)
+	if code == Unknown {
+		code = fallback
+	}
+	return &Error{Code: code, Message: err.Error()}
+}
+
+// FromGRPC 把客户端收到的 gRPC 错误转换为 *Error，Message 为状态中的消息；不是 gRPC 状态的错误原样返回
+func FromGRPC(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return &Error{Code: fromStatus(st), Message: st.Message(), status: st}
+}
+
+// fromStatus 优先使用 ErrorInfo 详情中的错误码，没有详情时按 gRPC 状态码推断
+func fromStatus(st *status.Status) Code {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.Domain == Domain {
+			return Code(info.Reason)
+		}
+	}
+	switch st.Code() {
+	case codes.InvalidArgument:
+		return InvalidArgument
+	case codes.OutOfRange:
+		return OutOfRange
+	case codes.NotFound:
+		return NotFound
+	case codes.FailedPrecondition:
+		return FailedPrecondition
+	case codes.AlreadyExists, codes.Aborted:
+		return Conflict
+	case codes.Unauthenticated:
+		return Unauthorized
+	case codes.PermissionDenied:
+		return PermissionDenied
+	case codes.ResourceExhausted:
+		return Throttled
+	case codes.DeadlineExceeded:
+		return Timeout
+	case codes.Unavailable, codes.Canceled:
+		return Unavailable
+	case codes.Internal, codes.DataLoss, codes.Unimplemented:
+		return Internal
+	}
+	return Unknown
+}
+
+// TCPMessage 错误在 TCP 协议中的回复内容：前缀 + 空格 + 消息；消息已经以该前缀开头时不重复添加
+func TCPMessage(err error) string {
+	msg := err.Error()
+	prefix := CodeOf(err).TCPPrefix()
+	if e := (*Error)(nil); errors.As(err, &e) && e.Prefix != "" {
+		prefix = e.Prefix
+	}
+	if strings.HasPrefix(msg, prefix+" ") {
+		return msg
+	}
+	return prefix + " " + msg
+}
+
+// ParseTCP 识别 TCP 错误回复的前缀，返回错误码；不是已知前缀时 ok 为 false
+func ParseTCP(msg string) (code Code, ok bool) {
+	prefix, _, _ := strings.Cut(msg, " ")
+	code, ok = tcpPrefixes[prefix]
+	return code, ok
+}
+
+// FromTCP 把 TCP 错误回复转换为 *Error，无法识别前缀时为 Unknown
+func FromTCP(msg string) *Error {
+	code, ok := ParseTCP(msg)
+	if !ok {
+		code = Unknown
+	}
+	return &Error{Code: code, Message: msg}
+}
@@ -0,0 +1,117 @@
+package kverrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// coded 其他包中实现 Coder 的错误类型
+type coded struct{}
+
+func (coded) Error() string   { return "NOPERM no permissions" }
+func (coded) ErrorCode() Code { return PermissionDenied }
+
+// TestCodeOf 错误码来自 *Error、Coder、上下文错误和 gRPC 状态，包装后仍然可以识别
+func TestCodeOf(t *testing.T) {
+	errNoSuchKey := New(NotFound, "no such key")
+	tests := []struct {
+		err  error
+		want Code
+	}{
+		{nil, ""},
+		{errNoSuchKey, NotFound},
+		{fmt.Errorf("get: %w", errNoSuchKey), NotFound},
+		{coded{}, PermissionDenied},
+		{context.DeadlineExceeded, Timeout},
+		{status.Error(codes.Unauthenticated, "who are you"), Unauthorized},
+		{status.Error(codes.Unknown, "boom"), Unknown},
+		{errors.New("plain"), Unknown},
+	}
+	for _, tt := range tests {
+		if got := CodeOf(tt.err); got != tt.want {
+			t.Errorf("CodeOf(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+
+	// 同一个错误码的不同哨兵错误互不相等
+	if errors.Is(errNoSuchKey, New(NotFound, "no such key")) {
+		t.Fatal("distinct sentinels should not match")
+	}
+	if e := From(errors.New("bad id"), InvalidArgument); e.Code != InvalidArgument || e.Message != "bad id" {
+		t.Fatalf("From fallback: %+v", e)
+	}
+}
+
+// TestGRPCRoundTrip 服务端返回的错误码经过 gRPC 状态后在客户端还原，多个错误码共用一个 gRPC 状态码时靠 ErrorInfo 区分
+func TestGRPCRoundTrip(t *testing.T) {
+	for code, m := range mappings {
+		st := status.Convert(New(code, "msg"))
+		if st.Code() != m.grpc || st.Message() != "msg" {
+			t.Fatalf("%s: status %v", code, st)
+		}
+		err := FromGRPC(st.Err())
+		if CodeOf(err) != code || err.Error() != "msg" {
+			t.Fatalf("%s: FromGRPC = %q %v", code, CodeOf(err), err)
+		}
+		// 转换后的错误保留原始状态
+		if status.Code(err) != m.grpc {
+			t.Fatalf("%s: status.Code = %v", code, status.Code(err))
+		}
+	}
+
+	// 没有详情的状态按 gRPC 状态码推断
+	if code := CodeOf(FromGRPC(status.Error(codes.FailedPrecondition, "watch is disabled"))); code != FailedPrecondition {
+		t.Fatalf("FailedPrecondition without details: %s", code)
+	}
+	if err := errors.New("dial failed"); FromGRPC(err) != err {
+		t.Fatal("non-status errors should be returned as is")
+	}
+}
+
+// TestTCPMessage 前缀不重复添加，Prefix 覆盖默认前缀，前缀可以解析回错误码
+func TestTCPMessage(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{New(NotFound, "TSDB: the key does not exist"), "NOTFOUND TSDB: the key does not exist"},
+		{New(WrongType, "WRONGTYPE Operation against a key holding the wrong kind of value"), "WRONGTYPE Operation against a key holding the wrong kind of value"},
+		{WithPrefix(NotFound, "NOSCRIPT", "no matching script, use EVAL"), "NOSCRIPT no matching script, use EVAL"},
+		{coded{}, "NOPERM no permissions"},
+		{errors.New("Unknown command"), "ERR Unknown command"},
+	}
+	for _, tt := range tests {
+		got := TCPMessage(tt.err)
+		if got != tt.want {
+			t.Errorf("TCPMessage(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+		if FromTCP(got).Code != From(tt.err, InvalidArgument).Code {
+			t.Errorf("FromTCP(%q) = %s", got, FromTCP(got).Code)
+		}
+	}
+	if _, ok := ParseTCP("TSDB: the key does not exist"); ok {
+		t.Fatal("message without prefix should not be recognized")
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	tests := map[Code]int{
+		NotFound:     http.StatusNotFound,
+		WrongType:    http.StatusConflict,
+		Unauthorized: http.StatusUnauthorized,
+		Throttled:    http.StatusTooManyRequests,
+		OutOfMemory:  http.StatusInsufficientStorage,
+		Code("NEW"):  http.StatusInternalServerError,
+	}
+	for code, want := range tests {
+		if got := code.HTTPStatus(); got != want {
+			t.Errorf("%s: HTTPStatus = %d, want %d", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package script
+
+import "strings"
+
+// builtins 所有脚本都可以使用的全局函数
+var builtins = map[string]Value{
+	// tonumber(v) 转换失败时返回 nil
+	"tonumber": Func(func(args []Value) (Value, error) {
+		if len(args) == 0 {
+			return nil, &Error{Msg: "bad argument #1 to 'tonumber' (value expected)"}
+		}
+		n, ok := toNumber(args[0])
+		if !ok {
+			return nil, nil
+		}
+		return n, nil
+	}),
+	"tostring": Func(func(args []Value) (Value, error) {
+		if len(args) == 0 {
+			return nil, &Error{Msg: "bad argument #1 to 'tostring' (value expected)"}
+		}
+		if s, ok := ToString(args[0]); ok {
+			return s, nil
+		}
+		switch v := args[0].(type) {
+		case nil:
+			return "nil", nil
+		case bool:
+			if v {
+				return "true", nil
+			}
+			return "false", nil
+		}
+		return TypeName(args[0]), nil
+	}),
+	"type": Func(func(args []Value) (Value, error) {
+		if len(args) == 0 {
+			return nil, &Error{Msg: "bad argument #1 to 'type' (value expected)"}
+		}
+		return TypeName(args[0]), nil
+	}),
+	// error(msg) 终止脚本，msg 作为错误信息返回给客户端
+	"error": Func(func(args []Value) (Value, error) {
+		msg := "error"
+		if len(args) > 0 {
+			if s, ok := ToString(args[0]); ok {
+				msg = s
+			}
+		}
+		return nil, &Error{Msg: msg}
+	}),
+	// string.* 常用的字符串函数
+	"string": Module{
+		"sub": Func(strSub),
+		"upper": Func(func(args []Value) (Value, error) {
+			s, err := strArg("upper", args, 0)
+			return strings.ToUpper(s), err
+		}),
+		"lower": Func(func(args []Value) (Value, error) {
+			s, err := strArg("lower", args, 0)
+			return strings.ToLower(s), err
+		}),
+		"find": Func(func(args []Value) (Value, error) {
+			s, err := strArg("find", args, 0)
+			if err != nil {
+				return nil, err
+			}
+			sub, err := strArg("find", args, 1)
+			if err != nil {
+				return nil, err
+			}
+			// 纯文本查找，返回从 1 开始的位置，找不到返回 nil
+			if i := strings.Index(s, sub); i >= 0 {
+				return int64(i + 1), nil
+			}
+			return nil, nil
+		}),
+	},
+}
+
+// strSub string.sub(s, i [, j])，下标从 1 开始，负数表示从末尾数
+func strSub(args []Value) (Value, error) {
+	s, err := strArg("sub", args, 0)
+	if err != nil {
+		return nil, err
+	}
+	n := int64(len(s))
+	pos := func(idx int, def int64) (int64, error) {
+		if idx >= len(args) {
+			return def, nil
+		}
+		v, ok := toNumber(args[idx])
+		i, ok2 := toInteger(v)
+		if !ok || !ok2 {
+			return 0, &Error{Msg: "bad argument to 'sub' (number expected)"}
+		}
+		if i < 0 {
+			i = n + i + 1
+		}
+		return i, nil
+	}
+	i, err := pos(1, 1)
+	if err != nil {
+		return nil, err
+	}
+	j, err := pos(2, n)
+	if err != nil {
+		return nil, err
+	}
+	i = max(i, 1)
+	j = min(j, n)
+	if i > j {
+		return "", nil
+	}
+	return s[i-1 : j], nil
+}
+
+func strArg(fn string, args []Value, i int) (string, error) {
+	if i < len(args) {
+		if s, ok := ToString(args[i]); ok {
+			return s, nil
+		}
+	}
+	return "", &Error{Msg: "bad argument #" + string(rune('1'+i)) + " to '" + fn + "' (string expected)"}
+}
@@ -0,0 +1,588 @@
+package script
+
+import (
+	"Flux-KV/pkg/kverrors"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrTimeout 脚本执行超过了时间限制
+	ErrTimeout = kverrors.New(kverrors.Timeout, "script execution timed out")
+)
+
+// MaxStringLen 单个字符串的最大长度，避免脚本通过反复拼接耗尽内存
+const MaxStringLen = 64 << 20
+
+// Error 脚本的编译错误或运行错误，Line 为出错的行号
+// 由宿主函数返回的错误保存在 Err 中，可以用 errors.Is 判断
+type Error struct {
+	Line int
+	Msg  string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Line == 0 {
+		return "script: " + e.Msg
+	}
+	return fmt.Sprintf("script:%d: %s", e.Line, e.Msg)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Program 编译后的脚本，可以被多个协程并发执行
+type Program struct {
+	body []stmt
+}
+
+// Compile 编译脚本
+//
+// 语法是 Lua 的一个子集：
+//
+//   - local 变量、赋值、if / elseif / else、while、数值 for、do ... end、break、return
+//   - nil / true / false、整数、浮点数、字符串、数组 {a, b, c}（下标从 1 开始）
+//   - 运算符 + - * / // % .. == ~= < <= > >= and or not #
+//
+// 不支持函数定义、全局变量赋值和哈希表，脚本只能通过宿主提供的全局函数与外界交互
+func Compile(src string) (*Program, error) {
+	body, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{body: body}, nil
+}
+
+// Run 执行脚本，globals 为只读的全局变量；deadline 为零值时不限制执行时间
+func (p *Program) Run(globals map[string]Value, deadline time.Time) (Value, error) {
+	in := &interp{globals: globals, deadline: deadline}
+	_, ret, err := in.block(p.body, &scope{})
+	if err != nil {
+		return nil, err
+	}
+	if replyDepth(ret, make(map[*Table]int)) > maxReplyDepth {
+		return nil, &Error{Msg: "reply nesting too deep"}
+	}
+	return Export(ret), nil
+}
+
+// maxReplyDepth 返回值最多嵌套的层数
+const maxReplyDepth = 32
+
+// replyDepth 计算数组的嵌套层数，memo 记录已算过的数组；引用了自身的数组视为无限深
+func replyDepth(v Value, memo map[*Table]int) int {
+	t, ok := v.(*Table)
+	if !ok {
+		return 0
+	}
+	if d, ok := memo[t]; ok {
+		return d
+	}
+	memo[t] = maxReplyDepth + 1 // 计算中，再次遇到说明有环
+	d := 1
+	for _, item := range t.items {
+		d = max(d, replyDepth(item, memo)+1)
+	}
+	memo[t] = d
+	return d
+}
+
+type control int
+
+const (
+	ctlNone control = iota
+	ctlBreak
+	ctlReturn
+)
+
+// scope 词法作用域，每个语句块一层
+type scope struct {
+	vars   map[string]Value
+	parent *scope
+}
+
+func (s *scope) lookup(name string) (*scope, bool) {
+	for cur := s; cur != nil; cur = cur.parent {
+		if _, ok := cur.vars[name]; ok {
+			return cur, true
+		}
+	}
+	return nil, false
+}
+
+func (s *scope) define(name string, v Value) {
+	if s.vars == nil {
+		s.vars = make(map[string]Value)
+	}
+	s.vars[name] = v
+}
+
+type interp struct {
+	globals  map[string]Value
+	deadline time.Time
+	steps    int
+}
+
+// tick 每执行一条语句计数一次，每 1024 步检查一次是否超时
+func (in *interp) tick() error {
+	in.steps++
+	if in.steps&1023 == 0 && !in.deadline.IsZero() && time.Now().After(in.deadline) {
+		return ErrTimeout
+	}
+	return nil
+}
+
+func (in *interp) block(body []stmt, parent *scope) (control, Value, error) {
+	sc := &scope{parent: parent}
+	for _, s := range body {
+		ctl, v, err := in.exec(s, sc)
+		if err != nil || ctl != ctlNone {
+			return ctl, v, err
+		}
+	}
+	return ctlNone, nil, nil
+}
+
+func (in *interp) exec(s stmt, sc *scope) (control, Value, error) {
+	if err := in.tick(); err != nil {
+		return ctlNone, nil, err
+	}
+
+	switch s := s.(type) {
+	case *localStmt:
+		var v Value
+		if s.x != nil {
+			var err error
+			if v, err = in.eval(s.x, sc); err != nil {
+				return ctlNone, nil, err
+			}
+		}
+		sc.define(s.name, v)
+	case *assignStmt:
+		v, err := in.eval(s.x, sc)
+		if err != nil {
+			return ctlNone, nil, err
+		}
+		return ctlNone, nil, in.assign(s, v, sc)
+	case *callStmt:
+		_, err := in.eval(s.call, sc)
+		return ctlNone, nil, err
+	case *ifStmt:
+		for i, cond := range s.conds {
+			v, err := in.eval(cond, sc)
+			if err != nil {
+				return ctlNone, nil, err
+			}
+			if truthy(v) {
+				return in.block(s.blocks[i], sc)
+			}
+		}
+		if s.els != nil {
+			return in.block(s.els, sc)
+		}
+	case *whileStmt:
+		for {
+			v, err := in.eval(s.cond, sc)
+			if err != nil || !truthy(v) {
+				return ctlNone, nil, err
+			}
+			ctl, ret, err := in.block(s.body, sc)
+			if err != nil || ctl == ctlReturn {
+				return ctl, ret, err
+			}
+			if ctl == ctlBreak {
+				return ctlNone, nil, nil
+			}
+			if err := in.tick(); err != nil {
+				return ctlNone, nil, err
+			}
+		}
+	case *forStmt:
+		return in.forLoop(s, sc)
+	case *doStmt:
+		return in.block(s.body, sc)
+	case *returnStmt:
+		if s.x == nil {
+			return ctlReturn, nil, nil
+		}
+		v, err := in.eval(s.x, sc)
+		return ctlReturn, v, err
+	case *breakStmt:
+		return ctlBreak, nil, nil
+	}
+	return ctlNone, nil, nil
+}
+
+// assign 只允许给 local 变量和数组元素赋值
+func (in *interp) assign(s *assignStmt, v Value, sc *scope) error {
+	switch t := s.target.(type) {
+	case *nameExpr:
+		owner, ok := sc.lookup(t.name)
+		if !ok {
+			return &Error{Line: s.line, Msg: fmt.Sprintf("assignment to undeclared variable '%s' (use local)", t.name)}
+		}
+		owner.vars[t.name] = v
+	case *indexExpr:
+		obj, err := in.eval(t.obj, sc)
+		if err != nil {
+			return err
+		}
+		key, err := in.eval(t.key, sc)
+		if err != nil {
+			return err
+		}
+		tbl, ok := obj.(*Table)
+		if !ok {
+			return &Error{Line: t.line, Msg: "attempt to index " + describe(obj)}
+		}
+		i, ok := toInteger(key)
+		switch {
+		case !ok || i < 1 || i > int64(len(tbl.items))+1:
+			return &Error{Line: t.line, Msg: "array index out of range"}
+		case i == int64(len(tbl.items))+1:
+			tbl.items = append(tbl.items, v)
+		default:
+			tbl.items[i-1] = v
+		}
+	}
+	return nil
+}
+
+func (in *interp) forLoop(s *forStmt, sc *scope) (control, Value, error) {
+	var bounds [3]Value
+	exprs := [3]expr{s.start, s.stop, s.step}
+	bounds[2] = int64(1)
+	for i, x := range exprs {
+		if x == nil {
+			continue
+		}
+		v, err := in.eval(x, sc)
+		if err != nil {
+			return ctlNone, nil, err
+		}
+		n, ok := toNumber(v)
+		if !ok {
+			return ctlNone, nil, &Error{Line: s.line, Msg: "'for' bounds must be numbers"}
+		}
+		bounds[i] = n
+	}
+
+	body := func(v Value) (control, Value, error) {
+		loop := &scope{parent: sc}
+		loop.define(s.name, v)
+		ctl, ret, err := in.block(s.body, loop)
+		if err == nil {
+			err = in.tick()
+		}
+		return ctl, ret, err
+	}
+
+	start, ok1 := bounds[0].(int64)
+	stop, ok2 := bounds[1].(int64)
+	step, ok3 := bounds[2].(int64)
+	if ok1 && ok2 && ok3 {
+		if step == 0 {
+			return ctlNone, nil, &Error{Line: s.line, Msg: "'for' step is zero"}
+		}
+		for i := start; (step > 0 && i <= stop) || (step < 0 && i >= stop); i += step {
+			ctl, ret, err := body(i)
+			if err != nil || ctl == ctlReturn {
+				return ctl, ret, err
+			}
+			if ctl == ctlBreak {
+				break
+			}
+			// 防止 i += step 溢出后重新满足条件
+			if (step > 0 && i > math.MaxInt64-step) || (step < 0 && i < math.MinInt64-step) {
+				break
+			}
+		}
+		return ctlNone, nil, nil
+	}
+
+	fstart, fstop, fstep := toFloat(bounds[0]), toFloat(bounds[1]), toFloat(bounds[2])
+	if fstep == 0 {
+		return ctlNone, nil, &Error{Line: s.line, Msg: "'for' step is zero"}
+	}
+	for f := fstart; (fstep > 0 && f <= fstop) || (fstep < 0 && f >= fstop); f += fstep {
+		ctl, ret, err := body(f)
+		if err != nil || ctl == ctlReturn {
+			return ctl, ret, err
+		}
+		if ctl == ctlBreak {
+			break
+		}
+	}
+	return ctlNone, nil, nil
+}
+
+func (in *interp) eval(x expr, sc *scope) (Value, error) {
+	switch x := x.(type) {
+	case *constExpr:
+		return x.v, nil
+	case *nameExpr:
+		if owner, ok := sc.lookup(x.name); ok {
+			return owner.vars[x.name], nil
+		}
+		if v, ok := in.globals[x.name]; ok {
+			return v, nil
+		}
+		if v, ok := builtins[x.name]; ok {
+			return v, nil
+		}
+		return nil, &Error{Line: x.line, Msg: fmt.Sprintf("undefined variable '%s'", x.name)}
+	case *indexExpr:
+		obj, err := in.eval(x.obj, sc)
+		if err != nil {
+			return nil, err
+		}
+		key, err := in.eval(x.key, sc)
+		if err != nil {
+			return nil, err
+		}
+		switch o := obj.(type) {
+		case *Table:
+			if i, ok := toInteger(key); ok && i >= 1 && i <= int64(len(o.items)) {
+				return o.items[i-1], nil
+			}
+			return nil, nil
+		case Module:
+			name, _ := key.(string)
+			return o[name], nil
+		}
+		return nil, &Error{Line: x.line, Msg: "attempt to index " + describe(obj)}
+	case *callExpr:
+		return in.call(x, sc)
+	case *tableExpr:
+		items := make([]Value, len(x.items))
+		for i, item := range x.items {
+			v, err := in.eval(item, sc)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = v
+		}
+		return NewTable(items...), nil
+	case *unaryExpr:
+		v, err := in.eval(x.x, sc)
+		if err != nil {
+			return nil, err
+		}
+		return unary(x, v)
+	case *binaryExpr:
+		// and / or 短路求值，返回决定结果的那个操作数
+		l, err := in.eval(x.l, sc)
+		if err != nil {
+			return nil, err
+		}
+		switch x.op {
+		case "and":
+			if !truthy(l) {
+				return l, nil
+			}
+			return in.eval(x.r, sc)
+		case "or":
+			if truthy(l) {
+				return l, nil
+			}
+			return in.eval(x.r, sc)
+		}
+		r, err := in.eval(x.r, sc)
+		if err != nil {
+			return nil, err
+		}
+		return binary(x, l, r)
+	}
+	return nil, nil
+}
+
+func (in *interp) call(x *callExpr, sc *scope) (Value, error) {
+	fv, err := in.eval(x.fn, sc)
+	if err != nil {
+		return nil, err
+	}
+	fn, ok := fv.(Func)
+	if !ok {
+		return nil, &Error{Line: x.line, Msg: "attempt to call " + describe(fv)}
+	}
+	args := make([]Value, len(x.args))
+	for i, a := range x.args {
+		if args[i], err = in.eval(a, sc); err != nil {
+			return nil, err
+		}
+	}
+	v, err := fn(args)
+	if err != nil {
+		var se *Error
+		if errors.Is(err, ErrTimeout) || errors.As(err, &se) && se.Line > 0 {
+			return nil, err
+		}
+		if se != nil {
+			// error() 抛出的错误补上行号
+			return nil, &Error{Line: x.line, Msg: se.Msg, Err: se.Err}
+		}
+		return nil, &Error{Line: x.line, Msg: err.Error(), Err: err}
+	}
+	return Import(v), nil
+}
+
+func unary(x *unaryExpr, v Value) (Value, error) {
+	switch x.op {
+	case "not":
+		return !truthy(v), nil
+	case "#":
+		switch o := v.(type) {
+		case string:
+			return int64(len(o)), nil
+		case *Table:
+			return int64(len(o.items)), nil
+		}
+		return nil, &Error{Line: x.line, Msg: "attempt to get length of " + describe(v)}
+	default: // "-"
+		n, ok := toNumber(v)
+		if !ok {
+			return nil, &Error{Line: x.line, Msg: "attempt to perform arithmetic on " + describe(v)}
+		}
+		if i, ok := n.(int64); ok {
+			return -i, nil
+		}
+		return -n.(float64), nil
+	}
+}
+
+func binary(x *binaryExpr, l, r Value) (Value, error) {
+	switch x.op {
+	case "==":
+		return equal(l, r), nil
+	case "~=":
+		return !equal(l, r), nil
+	case "<", "<=", ">", ">=":
+		return compare(x, l, r)
+	case "..":
+		ls, ok1 := ToString(l)
+		rs, ok2 := ToString(r)
+		if !ok1 || !ok2 {
+			bad := l
+			if ok1 {
+				bad = r
+			}
+			return nil, &Error{Line: x.line, Msg: "attempt to concatenate " + describe(bad)}
+		}
+		if len(ls)+len(rs) > MaxStringLen {
+			return nil, &Error{Line: x.line, Msg: "string length overflow"}
+		}
+		return ls + rs, nil
+	}
+	return arith(x, l, r)
+}
+
+func compare(x *binaryExpr, l, r Value) (Value, error) {
+	var c int
+	ls, lstr := l.(string)
+	rs, rstr := r.(string)
+	_, lnum := l.(int64)
+	_, rnum := r.(int64)
+	if _, ok := l.(float64); ok {
+		lnum = true
+	}
+	if _, ok := r.(float64); ok {
+		rnum = true
+	}
+	switch {
+	case lstr && rstr:
+		c = strings.Compare(ls, rs)
+	case lnum && rnum:
+		li, ok1 := l.(int64)
+		ri, ok2 := r.(int64)
+		if ok1 && ok2 {
+			c = cmpOrdered(li, ri)
+		} else {
+			c = cmpOrdered(toFloat(l), toFloat(r))
+		}
+	default:
+		return nil, &Error{Line: x.line, Msg: fmt.Sprintf("attempt to compare %s with %s", TypeName(l), TypeName(r))}
+	}
+	switch x.op {
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	default:
+		return c >= 0, nil
+	}
+}
+
+func cmpOrdered[T int64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// arith 两个整数做 + - * // % 时结果仍是整数，其余情况按浮点数计算；数字形式的字符串自动转换
+func arith(x *binaryExpr, l, r Value) (Value, error) {
+	ln, ok1 := toNumber(l)
+	rn, ok2 := toNumber(r)
+	if !ok1 || !ok2 {
+		bad := l
+		if ok1 {
+			bad = r
+		}
+		return nil, &Error{Line: x.line, Msg: "attempt to perform arithmetic on " + describe(bad)}
+	}
+
+	li, ok1 := ln.(int64)
+	ri, ok2 := rn.(int64)
+	if ok1 && ok2 && x.op != "/" {
+		switch x.op {
+		case "+":
+			return li + ri, nil
+		case "-":
+			return li - ri, nil
+		case "*":
+			return li * ri, nil
+		}
+		if ri == 0 {
+			return nil, &Error{Line: x.line, Msg: fmt.Sprintf("attempt to perform 'n%s0'", x.op)}
+		}
+		q := li / ri
+		// 向负无穷取整，余数与除数同号
+		if (li%ri != 0) && ((li < 0) != (ri < 0)) {
+			q--
+		}
+		if x.op == "//" {
+			return q, nil
+		}
+		return li - q*ri, nil
+	}
+
+	a, b := toFloat(ln), toFloat(rn)
+	switch x.op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		return a / b, nil
+	case "//":
+		return math.Floor(a / b), nil
+	default: // "%"
+		m := math.Mod(a, b)
+		if m != 0 && (m < 0) != (b < 0) {
+			m += b
+		}
+		return m, nil
+	}
+}
@@ -0,0 +1,154 @@
+package script
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokName
+	tokNumber
+	tokString
+	tokKeyword
+	tokOp
+)
+
+// token 词法单元，数字字面量在词法阶段就解析成 int64 / float64
+type token struct {
+	kind tokenKind
+	text string
+	num  Value
+	line int
+}
+
+var keywords = map[string]bool{
+	"and": true, "break": true, "do": true, "else": true, "elseif": true, "end": true,
+	"false": true, "for": true, "if": true, "local": true, "nil": true, "not": true,
+	"or": true, "return": true, "then": true, "true": true, "while": true,
+}
+
+// 多字符运算符放在前面，保证最长匹配
+var operators = []string{
+	"..", "==", "~=", "<=", ">=", "//",
+	"+", "-", "*", "/", "%", "#", "<", ">", "=", "(", ")", "[", "]", "{", "}", ",", ".", ";",
+}
+
+// lex 把源码切分成词法单元
+func lex(src string) ([]token, error) {
+	var toks []token
+	line := 1
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "--"):
+			// 注释到行尾
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case isLetter(c):
+			start := i
+			for i < len(src) && (isLetter(src[i]) || isDigit(src[i])) {
+				i++
+			}
+			word := src[start:i]
+			kind := tokName
+			if keywords[word] {
+				kind = tokKeyword
+			}
+			toks = append(toks, token{kind: kind, text: word, line: line})
+		case isDigit(c) || (c == '.' && i+1 < len(src) && isDigit(src[i+1])):
+			start := i
+			for i < len(src) && (isDigit(src[i]) || src[i] == '.' || src[i] == 'e' || src[i] == 'E' ||
+				((src[i] == '+' || src[i] == '-') && (src[i-1] == 'e' || src[i-1] == 'E'))) {
+				i++
+			}
+			num, ok := parseNumber(src[start:i])
+			if !ok {
+				return nil, &Error{Line: line, Msg: fmt.Sprintf("malformed number near '%s'", src[start:i])}
+			}
+			toks = append(toks, token{kind: tokNumber, text: src[start:i], num: num, line: line})
+		case c == '"' || c == '\'':
+			s, n, err := lexString(src[i:], line)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokString, text: s, line: line})
+			i += n
+		default:
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(src[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, &Error{Line: line, Msg: fmt.Sprintf("unexpected symbol near '%c'", c)}
+			}
+			toks = append(toks, token{kind: tokOp, text: op, line: line})
+			i += len(op)
+		}
+	}
+	return append(toks, token{kind: tokEOF, text: "<eof>", line: line}), nil
+}
+
+// lexString 解析带引号的字符串，返回内容和消耗的字节数
+func lexString(src string, line int) (string, int, error) {
+	quote := src[0]
+	var sb strings.Builder
+	for i := 1; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == quote:
+			return sb.String(), i + 1, nil
+		case c == '\n':
+			return "", 0, &Error{Line: line, Msg: "unfinished string"}
+		case c == '\\' && i+1 < len(src):
+			i++
+			switch src[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case '\\', '"', '\'':
+				sb.WriteByte(src[i])
+			default:
+				return "", 0, &Error{Line: line, Msg: fmt.Sprintf("invalid escape sequence '\\%c'", src[i])}
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", 0, &Error{Line: line, Msg: "unfinished string"}
+}
+
+// parseNumber 整数字面量解析为 int64，带小数点或指数的解析为 float64
+func parseNumber(s string) (Value, bool) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, true
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, true
+	}
+	return nil, false
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
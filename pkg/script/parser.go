@@ -0,0 +1,485 @@
+package script
+
+import "fmt"
+
+// ===== 语法树 =====
+
+type expr interface{}
+
+type (
+	constExpr struct{ v Value }
+	nameExpr  struct {
+		name string
+		line int
+	}
+	indexExpr struct {
+		obj, key expr
+		line     int
+	}
+	callExpr struct {
+		fn   expr
+		args []expr
+		line int
+	}
+	binaryExpr struct {
+		op   string
+		l, r expr
+		line int
+	}
+	unaryExpr struct {
+		op   string
+		x    expr
+		line int
+	}
+	tableExpr struct{ items []expr }
+)
+
+type stmt interface{}
+
+type (
+	localStmt struct {
+		name string
+		x    expr // 可以为 nil
+	}
+	assignStmt struct {
+		target expr // nameExpr 或 indexExpr
+		x      expr
+		line   int
+	}
+	callStmt struct{ call *callExpr }
+	ifStmt   struct {
+		conds  []expr
+		blocks [][]stmt
+		els    []stmt
+	}
+	whileStmt struct {
+		cond expr
+		body []stmt
+	}
+	forStmt struct {
+		name              string
+		start, stop, step expr // step 可以为 nil
+		body              []stmt
+		line              int
+	}
+	returnStmt struct{ x expr }
+	breakStmt  struct{}
+	doStmt     struct{ body []stmt }
+)
+
+// ===== 语法分析（递归下降） =====
+
+type parser struct {
+	toks  []token
+	pos   int
+	depth int // 当前嵌套的表达式和语句块层数
+}
+
+// maxNesting 表达式和语句块最多嵌套的层数，避免深层嵌套的脚本耗尽解析器的栈（Go 运行时无法从栈溢出中恢复）
+const maxNesting = 200
+
+// enter 进入一层表达式或语句块，超过 maxNesting 时返回编译错误；成功时调用方需要在返回前调用 leave
+func (p *parser) enter(what string) error {
+	p.depth++
+	if p.depth > maxNesting {
+		p.depth--
+		return p.errorf(p.peek(), "%s nesting too deep", what)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+// 二元运算符优先级，数值越大越先结合；.. 为右结合
+var binaryPriority = map[string]int{
+	"or": 1, "and": 2,
+	"<": 3, ">": 3, "<=": 3, ">=": 3, "~=": 3, "==": 3,
+	"..": 4,
+	"+":  5, "-": 5,
+	"*": 6, "/": 6, "//": 6, "%": 6,
+}
+
+const unaryPriority = 7
+
+func parse(src string) ([]stmt, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	body, err := p.block()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorf(t, "'<eof>' expected near '%s'", t.text)
+	}
+	return body, nil
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// is 判断当前单元是否为指定的关键字或运算符
+func (p *parser) is(text string) bool {
+	t := p.peek()
+	return (t.kind == tokKeyword || t.kind == tokOp) && t.text == text
+}
+
+func (p *parser) accept(text string) bool {
+	if p.is(text) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if !p.accept(text) {
+		t := p.peek()
+		return p.errorf(t, "'%s' expected near '%s'", text, t.text)
+	}
+	return nil
+}
+
+func (p *parser) name() (string, error) {
+	t := p.peek()
+	if t.kind != tokName {
+		return "", p.errorf(t, "<name> expected near '%s'", t.text)
+	}
+	p.pos++
+	return t.text, nil
+}
+
+func (p *parser) errorf(t token, format string, args ...any) error {
+	return &Error{Line: t.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+// blockEnd 当前单元是否结束一个语句块
+func (p *parser) blockEnd() bool {
+	return p.peek().kind == tokEOF || p.is("end") || p.is("else") || p.is("elseif")
+}
+
+func (p *parser) block() ([]stmt, error) {
+	// if / while / for / do 的语句块都经过这里
+	if err := p.enter("block"); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	var body []stmt
+	for !p.blockEnd() {
+		if p.accept(";") {
+			continue
+		}
+		s, err := p.statement()
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, s)
+		// return 必须是语句块的最后一条语句
+		if _, ok := s.(*returnStmt); ok {
+			p.accept(";")
+			if !p.blockEnd() {
+				t := p.peek()
+				return nil, p.errorf(t, "'end' expected near '%s'", t.text)
+			}
+		}
+	}
+	return body, nil
+}
+
+func (p *parser) statement() (stmt, error) {
+	t := p.peek()
+	switch {
+	case p.accept("local"):
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		s := &localStmt{name: name}
+		if p.accept("=") {
+			if s.x, err = p.expr(0); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	case p.accept("if"):
+		return p.ifStatement()
+	case p.accept("while"):
+		cond, err := p.expr(0)
+		if err != nil {
+			return nil, err
+		}
+		body, err := p.doBlock()
+		if err != nil {
+			return nil, err
+		}
+		return &whileStmt{cond: cond, body: body}, nil
+	case p.accept("for"):
+		return p.forStatement(t.line)
+	case p.accept("do"):
+		body, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("end"); err != nil {
+			return nil, err
+		}
+		return &doStmt{body: body}, nil
+	case p.accept("return"):
+		s := &returnStmt{}
+		if !p.blockEnd() && !p.is(";") {
+			x, err := p.expr(0)
+			if err != nil {
+				return nil, err
+			}
+			s.x = x
+		}
+		return s, nil
+	case p.accept("break"):
+		return &breakStmt{}, nil
+	}
+
+	// 赋值或函数调用
+	x, err := p.suffixExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.accept("=") {
+		switch x.(type) {
+		case *nameExpr, *indexExpr:
+		default:
+			return nil, p.errorf(t, "cannot assign to this expression")
+		}
+		val, err := p.expr(0)
+		if err != nil {
+			return nil, err
+		}
+		return &assignStmt{target: x, x: val, line: t.line}, nil
+	}
+	call, ok := x.(*callExpr)
+	if !ok {
+		return nil, p.errorf(t, "syntax error near '%s'", p.peek().text)
+	}
+	return &callStmt{call: call}, nil
+}
+
+func (p *parser) doBlock() ([]stmt, error) {
+	if err := p.expect("do"); err != nil {
+		return nil, err
+	}
+	body, err := p.block()
+	if err != nil {
+		return nil, err
+	}
+	return body, p.expect("end")
+}
+
+func (p *parser) ifStatement() (stmt, error) {
+	s := &ifStmt{}
+	for {
+		cond, err := p.expr(0)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("then"); err != nil {
+			return nil, err
+		}
+		body, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+		s.conds = append(s.conds, cond)
+		s.blocks = append(s.blocks, body)
+		if !p.accept("elseif") {
+			break
+		}
+	}
+	if p.accept("else") {
+		body, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+		s.els = body
+	}
+	return s, p.expect("end")
+}
+
+// forStatement for i = start, stop [, step] do ... end
+func (p *parser) forStatement(line int) (stmt, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	s := &forStmt{name: name, line: line}
+	if err := p.expect("="); err != nil {
+		return nil, err
+	}
+	if s.start, err = p.expr(0); err != nil {
+		return nil, err
+	}
+	if err := p.expect(","); err != nil {
+		return nil, err
+	}
+	if s.stop, err = p.expr(0); err != nil {
+		return nil, err
+	}
+	if p.accept(",") {
+		if s.step, err = p.expr(0); err != nil {
+			return nil, err
+		}
+	}
+	if s.body, err = p.doBlock(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// expr 按优先级爬升解析二元表达式，只结合优先级大于 limit 的运算符
+// 括号、表构造、一元运算、右结合的 .. 以及下标和调用参数中的子表达式都经过这里
+func (p *parser) expr(limit int) (expr, error) {
+	if err := p.enter("expression"); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	var left expr
+	t := p.peek()
+	if (t.kind == tokKeyword && t.text == "not") || (t.kind == tokOp && (t.text == "-" || t.text == "#")) {
+		p.pos++
+		x, err := p.expr(unaryPriority)
+		if err != nil {
+			return nil, err
+		}
+		left = &unaryExpr{op: t.text, x: x, line: t.line}
+	} else {
+		x, err := p.simpleExpr()
+		if err != nil {
+			return nil, err
+		}
+		left = x
+	}
+
+	for {
+		t := p.peek()
+		prio, ok := binaryPriority[t.text]
+		if !ok || (t.kind != tokOp && t.kind != tokKeyword) || prio <= limit {
+			return left, nil
+		}
+		p.pos++
+		next := prio
+		if t.text == ".." {
+			next-- // 右结合
+		}
+		right, err := p.expr(next)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: t.text, l: left, r: right, line: t.line}
+	}
+}
+
+func (p *parser) simpleExpr() (expr, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokNumber:
+		p.pos++
+		return &constExpr{v: t.num}, nil
+	case t.kind == tokString:
+		p.pos++
+		return &constExpr{v: t.text}, nil
+	case p.accept("nil"):
+		return &constExpr{v: nil}, nil
+	case p.accept("true"):
+		return &constExpr{v: true}, nil
+	case p.accept("false"):
+		return &constExpr{v: false}, nil
+	case p.accept("{"):
+		tbl := &tableExpr{}
+		for !p.is("}") {
+			x, err := p.expr(0)
+			if err != nil {
+				return nil, err
+			}
+			tbl.items = append(tbl.items, x)
+			if !p.accept(",") && !p.accept(";") {
+				break
+			}
+		}
+		return tbl, p.expect("}")
+	}
+	return p.suffixExpr()
+}
+
+// suffixExpr 变量或括号表达式，后面可以跟任意个 .name / [key] / (args)
+func (p *parser) suffixExpr() (expr, error) {
+	var x expr
+	t := p.peek()
+	switch {
+	case t.kind == tokName:
+		p.pos++
+		x = &nameExpr{name: t.text, line: t.line}
+	case p.accept("("):
+		inner, err := p.expr(0)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		x = inner
+	default:
+		return nil, p.errorf(t, "unexpected symbol near '%s'", t.text)
+	}
+
+	for {
+		t := p.peek()
+		switch {
+		case p.accept("."):
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			x = &indexExpr{obj: x, key: &constExpr{v: name}, line: t.line}
+		case p.accept("["):
+			key, err := p.expr(0)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			x = &indexExpr{obj: x, key: key, line: t.line}
+		case p.accept("("):
+			call := &callExpr{fn: x, line: t.line}
+			for !p.is(")") {
+				arg, err := p.expr(0)
+				if err != nil {
+					return nil, err
+				}
+				call.args = append(call.args, arg)
+				if !p.accept(",") {
+					break
+				}
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			x = call
+		default:
+			return x, nil
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package script
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func run(t *testing.T, src string, globals map[string]Value) (Value, error) {
+	t.Helper()
+	p, err := Compile(src)
+	if err != nil {
+		return nil, err
+	}
+	return p.Run(globals, time.Now().Add(time.Second))
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		src  string
+		want Value
+	}{
+		{"return 1 + 2 * 3", int64(7)},
+		{"return 7 // 2", int64(3)},
+		{"return -7 // 2", int64(-4)},
+		{"return -7 % 3", int64(2)},
+		{"return 7 / 2", 3.5},
+		{"return '10' + 1", int64(11)},
+		{"return 'a' .. 1 .. 'b'", "a1b"},
+		{"return 1 == 1.0", true},
+		{"return nil or false", false},
+		{"return 0 and 'x'", "x"},
+		{"return not nil", true},
+		{"return #'abc' + #{1, 2}", int64(5)},
+		{"local s = 0 for i = 1, 10 do s = s + i end return s", int64(55)},
+		{"local s = 0 for i = 10, 1, -3 do s = s + i end return s", int64(22)},
+		{"local n = 0 while true do n = n + 1 if n >= 3 then break end end return n", int64(3)},
+		{"local t = {} for i = 1, 3 do t[#t + 1] = i * i end return t", []Value{int64(1), int64(4), int64(9)}},
+		{"local x = 1 do local x = 2 end return x", int64(1)},
+		{"if 1 > 2 then return 'a' elseif 2 > 1 then return 'b' else return 'c' end", "b"},
+		{"return {1, {'a', nil}, true}", []Value{int64(1), []Value{"a", nil}, true}},
+		{"return tonumber('3.5') + 1", 4.5},
+		{"return tonumber('x')", nil},
+		{"return tostring(12) .. type({}) .. type(nil)", "12tablenil"},
+		{"return string.sub('hello', 2, -2) .. string.upper('x')", "ellX"},
+		{"return string.find('hello', 'll')", int64(3)},
+		{"-- 注释\nreturn 'ok'", "ok"},
+	}
+	for _, tt := range tests {
+		got, err := run(t, tt.src, nil)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: want %#v, got %#v %v", tt.src, tt.want, got, err)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		src string
+		msg string
+	}{
+		{"return 1 +", "script:1: unexpected symbol near '<eof>'"},
+		{"x = 1", "script:1: assignment to undeclared variable 'x' (use local)"},
+		{"return y", "script:1: undefined variable 'y'"},
+		{"\nreturn {} .. 'a'", "script:2: attempt to concatenate a table value"},
+		{"return 1 < 'a'", "script:1: attempt to compare number with string"},
+		{"return 1 // 0", "script:1: attempt to perform 'n//0'"},
+		{"local t = {} t[3] = 1", "script:1: array index out of range"},
+		{"return 1, 2", "script:1: 'end' expected near ','"},
+		{"local t = nil return t.x", "script:1: attempt to index a nil value"},
+		{"error('boom')", "script:1: boom"},
+		{"return 'unterminated", "script:1: unfinished string"},
+		{"local t = {} t[1] = t return t", "script: reply nesting too deep"},
+	}
+	for _, tt := range tests {
+		_, err := run(t, tt.src, nil)
+		if err == nil || err.Error() != tt.msg {
+			t.Errorf("%q: want error %q, got %v", tt.src, tt.msg, err)
+		}
+	}
+}
+
+// TestNestingLimit 深层嵌套的脚本编译失败，而不是让解析器栈溢出
+func TestNestingLimit(t *testing.T) {
+	const n = 10000
+	tests := []struct {
+		src string
+		msg string
+	}{
+		// 约 6MB，小于默认的 max_frame_size
+		{"return " + strings.Repeat("(", 3_000_000) + "1" + strings.Repeat(")", 3_000_000), "expression nesting too deep"},
+		{"return " + strings.Repeat("{", n) + strings.Repeat("}", n), "expression nesting too deep"},
+		{"return " + strings.Repeat("not ", n) + "1", "expression nesting too deep"},
+		{"return " + strings.Repeat("'a' .. ", n) + "'a'", "expression nesting too deep"},
+		{strings.Repeat("do ", n) + strings.Repeat("end ", n), "block nesting too deep"},
+		{strings.Repeat("while true do ", n) + strings.Repeat("end ", n), "nesting too deep"},
+	}
+	for _, tt := range tests {
+		if _, err := Compile(tt.src); err == nil || !strings.Contains(err.Error(), tt.msg) {
+			t.Errorf("%.20q...: want error %q, got %v", tt.src, tt.msg, err)
+		}
+	}
+
+	// 限制以内的嵌套仍然可以执行
+	v, err := run(t, "return "+strings.Repeat("(", 100)+"1"+strings.Repeat(")", 100), nil)
+	if err != nil || !reflect.DeepEqual(v, int64(1)) {
+		t.Fatalf("want 1, got %v (%v)", v, err)
+	}
+}
+
+func TestHostCall(t *testing.T) {
+	errWrong := errors.New("wrong type")
+	store := map[string]Value{"a": "1"}
+	kv := Module{
+		"get": Func(func(args []Value) (Value, error) {
+			return store[args[0].(string)], nil
+		}),
+		"fail": Func(func(args []Value) (Value, error) {
+			return nil, errWrong
+		}),
+		"list": Func(func(args []Value) (Value, error) {
+			return []Value{"x", int64(1)}, nil
+		}),
+	}
+	globals := map[string]Value{"kv": kv, "KEYS": Import([]string{"a"})}
+
+	got, err := run(t, "return kv.get(KEYS[1]) + 1", globals)
+	if err != nil || got != int64(2) {
+		t.Fatalf("want 2, got %v %v", got, err)
+	}
+	if got, _ := run(t, "local l = kv.list() return l[1] .. #l", globals); got != "x2" {
+		t.Fatalf("host list should become a table, got %v", got)
+	}
+
+	_, err = run(t, "\n\nkv.fail()", globals)
+	var se *Error
+	if !errors.Is(err, errWrong) || !errors.As(err, &se) || se.Line != 3 {
+		t.Fatalf("host error should be wrapped with line number: %v", err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	p, err := Compile("local n = 0 while true do n = n + 1 end")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if _, err := p.Run(nil, time.Now().Add(20*time.Millisecond)); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("want ErrTimeout, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("timeout was not enforced promptly")
+	}
+}
@@ -0,0 +1,166 @@
+package script
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Value 脚本中的值：nil / bool / int64 / float64 / string / *Table / Func / Module
+type Value any
+
+// Table 从 1 开始编号的数组，t[#t+1] = v 追加元素
+type Table struct {
+	items []Value
+}
+
+// NewTable 用给定的元素创建数组
+func NewTable(items ...Value) *Table {
+	return &Table{items: items}
+}
+
+// Func 宿主提供给脚本调用的函数
+type Func func(args []Value) (Value, error)
+
+// Module 只读的函数集合，脚本中通过 name.field 访问
+type Module map[string]Value
+
+// TypeName 返回值的类型名（与 Lua 的 type() 一致）
+func TypeName(v Value) string {
+	switch v.(type) {
+	case nil:
+		return "nil"
+	case bool:
+		return "boolean"
+	case int64, float64:
+		return "number"
+	case string:
+		return "string"
+	case *Table, Module:
+		return "table"
+	case Func:
+		return "function"
+	default:
+		return "userdata"
+	}
+}
+
+// ToString 把值转换成字符串，数字按十进制格式化
+func ToString(v Value) (string, bool) {
+	switch x := v.(type) {
+	case string:
+		return x, true
+	case int64:
+		return strconv.FormatInt(x, 10), true
+	case float64:
+		return formatFloat(x), true
+	}
+	return "", false
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	case math.IsNaN(f):
+		return "nan"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// toNumber 数字原样返回，数字形式的字符串自动转换
+func toNumber(v Value) (Value, bool) {
+	switch x := v.(type) {
+	case int64, float64:
+		return x, true
+	case string:
+		return parseNumber(strings.TrimSpace(x))
+	}
+	return nil, false
+}
+
+// toInteger 整数或值为整数的浮点数
+func toInteger(v Value) (int64, bool) {
+	switch x := v.(type) {
+	case int64:
+		return x, true
+	case float64:
+		if x == math.Trunc(x) && x >= math.MinInt64 && x < math.MaxInt64 {
+			return int64(x), true
+		}
+	}
+	return 0, false
+}
+
+func toFloat(v Value) float64 {
+	if n, ok := v.(int64); ok {
+		return float64(n)
+	}
+	return v.(float64)
+}
+
+func truthy(v Value) bool {
+	return v != nil && v != false
+}
+
+// equal 数字按数值比较，表按引用比较
+func equal(a, b Value) bool {
+	an, aok := a.(int64)
+	bf, bok := b.(float64)
+	if aok && bok {
+		return float64(an) == bf
+	}
+	af, aok := a.(float64)
+	bn, bok := b.(int64)
+	if aok && bok {
+		return af == float64(bn)
+	}
+	switch a.(type) {
+	case Func, Module:
+		return false
+	}
+	return a == b
+}
+
+// Export 把脚本的返回值转换为宿主使用的类型：*Table 转成 []Value，函数等无法导出的值转成 nil
+func Export(v Value) Value {
+	switch x := v.(type) {
+	case nil, bool, int64, float64, string:
+		return x
+	case *Table:
+		out := make([]Value, len(x.items))
+		for i, item := range x.items {
+			out[i] = Export(item)
+		}
+		return out
+	}
+	return nil
+}
+
+// Import 把宿主的值转换为脚本中的值，[]Value 转成 *Table
+func Import(v Value) Value {
+	switch x := v.(type) {
+	case []Value:
+		items := make([]Value, len(x))
+		for i, item := range x {
+			items[i] = Import(item)
+		}
+		return NewTable(items...)
+	case []string:
+		items := make([]Value, len(x))
+		for i, item := range x {
+			items[i] = item
+		}
+		return NewTable(items...)
+	case int:
+		return int64(x)
+	}
+	return v
+}
+
+func describe(v Value) string {
+	return fmt.Sprintf("a %s value", TypeName(v))
+}
@@ -0,0 +1,550 @@
+// Package tcpclient Flux-KV TCP 协议的 Go 客户端
+//
+// 与 pkg/client（gRPC）不同，tcpclient 直连单个节点的 TCP 监听，使用 v2 二进制帧，
+// 参数和值都是二进制安全的，回复带类型，可以区分空值和空字符串。
+// Client 内部维护连接池，可以被多个 Goroutine 并发使用
+package tcpclient
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrClosed 客户端已关闭
+var ErrClosed = errors.New("tcpclient: client is closed")
+
+// Client 连接池与命令执行
+type Client struct {
+	addr string
+	opts options
+
+	sem chan struct{} // 同时借出的连接数不超过 poolSize
+
+	mu     sync.Mutex
+	idle   []*conn // 空闲连接，后进先出，idle[0] 最久未使用
+	open   int     // 已建立的连接数（空闲 + 借出 + 正在健康检查）
+	closed bool
+
+	quit chan struct{} // 通知后台维护协程退出
+	done chan struct{} // 后台维护协程退出时关闭
+
+	hits, misses, timeouts atomic.Uint64
+}
+
+type options struct {
+	poolSize            int
+	minIdle             int
+	maxIdle             int
+	maxLifetime         time.Duration
+	maxIdleTime         time.Duration
+	healthCheckInterval time.Duration
+	dialTimeout         time.Duration
+	maxRetries          int
+	tlsConfig           *tls.Config
+	auth                []string // 握手后发送的 AUTH 参数，nil 表示按 default 用户访问
+}
+
+// Option 客户端选项
+type Option func(*options)
+
+// WithPoolSize 最多同时借出的连接数，超过后 Get 等命令等待其他请求归还连接，默认 10
+func WithPoolSize(n int) Option {
+	return func(o *options) {
+		o.poolSize = n
+	}
+}
+
+// WithMinIdleConns 后台维护的最少空闲连接数，默认 0
+func WithMinIdleConns(n int) Option {
+	return func(o *options) {
+		o.minIdle = n
+	}
+}
+
+// WithMaxIdleConns 最多保留的空闲连接数，归还时超出的连接直接关闭，默认与 poolSize 相同
+func WithMaxIdleConns(n int) Option {
+	return func(o *options) {
+		o.maxIdle = n
+	}
+}
+
+// WithMaxConnLifetime 连接建立超过该时间后不再复用，0 表示不限制
+func WithMaxConnLifetime(d time.Duration) Option {
+	return func(o *options) {
+		o.maxLifetime = d
+	}
+}
+
+// WithMaxIdleTime 连接空闲超过该时间后关闭，0 表示不限制，默认 5 分钟
+func WithMaxIdleTime(d time.Duration) Option {
+	return func(o *options) {
+		o.maxIdleTime = d
+	}
+}
+
+// WithHealthCheckInterval 后台检查空闲连接的间隔：PING 空闲超过该间隔的连接、关闭过期连接、补足最少空闲连接
+// 0 表示不检查，默认 30 秒
+func WithHealthCheckInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.healthCheckInterval = d
+	}
+}
+
+// WithDialTimeout 建立连接（包括 TLS 与版本握手）的超时，默认 5 秒
+func WithDialTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.dialTimeout = d
+	}
+}
+
+// WithMaxRetries 建立连接失败时的最大重试次数，默认 3，0 表示不重试
+// 只有请求写出之前的失败才会重试；写出之后连接中断时服务端可能已经执行了命令，直接返回错误
+func WithMaxRetries(n int) Option {
+	return func(o *options) {
+		o.maxRetries = n
+	}
+}
+
+// WithTLSConfig 使用 TLS 连接，例如 tlsutil.Reloader.ClientConfig() 开启 mTLS；ServerName 为空时使用地址中的主机名
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = cfg
+	}
+}
+
+// WithBasicAuth 以 ACL 用户名和密码访问节点
+func WithBasicAuth(user, password string) Option {
+	return func(o *options) {
+		o.auth = []string{"AUTH", user, password}
+	}
+}
+
+// WithToken 以 ACL 令牌访问节点
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.auth = []string{"AUTH", token}
+	}
+}
+
+// New 创建直连 addr 的客户端，立即建立 max(1, minIdle) 个连接以尽早发现地址、证书或凭证错误
+func New(addr string, opts ...Option) (*Client, error) {
+	o := options{
+		poolSize:            10,
+		maxIdle:             -1,
+		maxIdleTime:         5 * time.Minute,
+		healthCheckInterval: 30 * time.Second,
+		dialTimeout:         5 * time.Second,
+		maxRetries:          3,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.poolSize <= 0 {
+		o.poolSize = 1
+	}
+	if o.maxIdle < 0 || o.maxIdle > o.poolSize {
+		o.maxIdle = o.poolSize
+	}
+	if o.minIdle > o.maxIdle {
+		o.minIdle = o.maxIdle
+	}
+
+	c := &Client{
+		addr: addr,
+		opts: o,
+		sem:  make(chan struct{}, o.poolSize),
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	// 1. 建立初始连接
+	cn, err := c.dial(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	c.open = 1
+	c.idle = append(c.idle, cn)
+	c.fill()
+
+	// 2. 后台维护空闲连接
+	go c.maintain()
+	return c, nil
+}
+
+// Close 关闭所有空闲连接，借出的连接归还时关闭
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	idle := c.idle
+	c.idle = nil
+	c.open -= len(idle)
+	c.mu.Unlock()
+
+	for _, cn := range idle {
+		cn.nc.Close()
+	}
+	close(c.quit)
+	<-c.done
+	return nil
+}
+
+// PoolStats 连接池统计
+type PoolStats struct {
+	Hits       uint64 // 复用空闲连接的次数
+	Misses     uint64 // 没有空闲连接、新建连接的次数
+	Timeouts   uint64 // 等待连接时 ctx 到期的次数
+	TotalConns int    // 当前已建立的连接数
+	IdleConns  int    // 当前空闲连接数
+}
+
+// Stats 返回连接池统计
+func (c *Client) Stats() PoolStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return PoolStats{
+		Hits:       c.hits.Load(),
+		Misses:     c.misses.Load(),
+		Timeouts:   c.timeouts.Load(),
+		TotalConns: c.open,
+		IdleConns:  len(c.idle),
+	}
+}
+
+// conn 池中的一个连接，握手完成后只使用 v2 帧
+type conn struct {
+	nc        net.Conn
+	r         *bufio.Reader
+	w         *bufio.Writer
+	createdAt time.Time
+	usedAt    time.Time
+}
+
+// dial 建立连接并完成 TLS、HELLO 2 握手与认证
+func (c *Client) dial(ctx context.Context) (*conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.dialTimeout)
+	defer cancel()
+
+	// 1. TCP 与 TLS
+	d := net.Dialer{}
+	nc, err := d.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, err
+	}
+	if c.opts.tlsConfig != nil {
+		cfg := c.opts.tlsConfig
+		if cfg.ServerName == "" {
+			cfg = cfg.Clone()
+			cfg.ServerName, _, _ = net.SplitHostPort(c.addr)
+		}
+		tc := tls.Client(nc, cfg)
+		if err := tc.HandshakeContext(ctx); err != nil {
+			nc.Close()
+			return nil, err
+		}
+		nc = tc
+	}
+
+	now := time.Now()
+	cn := &conn{nc: nc, r: bufio.NewReader(nc), w: bufio.NewWriter(nc), createdAt: now, usedAt: now}
+	fail := func(err error) (*conn, error) {
+		nc.Close()
+		return nil, err
+	}
+
+	// 2. 版本握手：按 v1 文本帧发送 HELLO 2
+	deadline, _ := ctx.Deadline()
+	nc.SetDeadline(deadline)
+	if err := writeText(cn.w, "HELLO 2"); err != nil {
+		return fail(err)
+	}
+	if err := cn.w.Flush(); err != nil {
+		return fail(err)
+	}
+	resp, err := readText(cn.r)
+	if err != nil {
+		return fail(err)
+	}
+	if resp != "OK version=2" {
+		return fail(fmt.Errorf("tcpclient: handshake with %s failed: %s", c.addr, resp))
+	}
+
+	// 3. 认证，凭证错误不重试
+	if c.opts.auth != nil {
+		replies, err := cn.roundTrip(ctx, [][]string{c.opts.auth})
+		if err != nil {
+			return fail(err)
+		}
+		if err := replies[0].Err(); err != nil {
+			return fail(err)
+		}
+	}
+	return cn, nil
+}
+
+// roundTrip 一次写出所有命令，再按顺序读取回复；ctx 的截止时间和取消都会中断读写
+func (cn *conn) roundTrip(ctx context.Context, cmds [][]string) ([]Reply, error) {
+	deadline, _ := ctx.Deadline()
+	cn.nc.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() {
+		cn.nc.SetDeadline(time.Unix(1, 0))
+	})
+
+	replies, err := cn.exchange(cmds)
+	if !stop() {
+		// 取消回调已经执行，连接的截止时间被改过，不能再复用
+		if err == nil {
+			return replies, errInterrupted
+		}
+		return nil, ctx.Err()
+	}
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return replies, err
+}
+
+// errInterrupted 回复已完整读取，但 ctx 在读写结束时被取消，连接不能复用
+var errInterrupted = errors.New("tcpclient: connection interrupted by context")
+
+func (cn *conn) exchange(cmds [][]string) ([]Reply, error) {
+	for _, args := range cmds {
+		if err := writeCommand(cn.w, args); err != nil {
+			return nil, err
+		}
+	}
+	if err := cn.w.Flush(); err != nil {
+		return nil, err
+	}
+	replies := make([]Reply, len(cmds))
+	for i := range replies {
+		r, err := readReply(cn.r)
+		if err != nil {
+			return nil, err
+		}
+		replies[i] = r
+	}
+	return replies, nil
+}
+
+// get 借出一个连接：优先复用空闲连接，没有时新建；借出的连接数达到 poolSize 时等待归还
+func (c *Client) get(ctx context.Context) (*conn, error) {
+	select {
+	case c.sem <- struct{}{}:
+	case <-ctx.Done():
+		c.timeouts.Add(1)
+		return nil, ctx.Err()
+	}
+
+	for {
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			<-c.sem
+			return nil, ErrClosed
+		}
+		n := len(c.idle)
+		if n == 0 {
+			c.open++
+			c.mu.Unlock()
+			break
+		}
+		cn := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		if c.expired(cn, time.Now()) {
+			c.open--
+			c.mu.Unlock()
+			cn.nc.Close()
+			continue
+		}
+		c.mu.Unlock()
+		if cn.stale() {
+			c.mu.Lock()
+			c.open--
+			c.mu.Unlock()
+			cn.nc.Close()
+			continue
+		}
+		c.hits.Add(1)
+		return cn, nil
+	}
+
+	c.misses.Add(1)
+	cn, err := c.dial(ctx)
+	if err != nil {
+		c.mu.Lock()
+		c.open--
+		c.mu.Unlock()
+		<-c.sem
+		return nil, err
+	}
+	return cn, nil
+}
+
+// put 归还连接，出错、过期或空闲连接已满时关闭
+func (c *Client) put(cn *conn, broken bool) {
+	defer func() { <-c.sem }()
+	now := time.Now()
+	c.mu.Lock()
+	if broken || c.closed || len(c.idle) >= c.opts.maxIdle || c.expired(cn, now) {
+		c.open--
+		c.mu.Unlock()
+		cn.nc.Close()
+		return
+	}
+	cn.usedAt = now
+	c.idle = append(c.idle, cn)
+	c.mu.Unlock()
+}
+
+// expired 连接超过最长存活时间或空闲时间
+func (c *Client) expired(cn *conn, now time.Time) bool {
+	return (c.opts.maxLifetime > 0 && now.Sub(cn.createdAt) > c.opts.maxLifetime) ||
+		(c.opts.maxIdleTime > 0 && now.Sub(cn.usedAt) > c.opts.maxIdleTime)
+}
+
+// maintain 定期检查空闲连接并补足最少空闲连接
+func (c *Client) maintain() {
+	defer close(c.done)
+	if c.opts.healthCheckInterval <= 0 {
+		<-c.quit
+		return
+	}
+	ticker := time.NewTicker(c.opts.healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.check()
+			c.fill()
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// check 关闭过期的空闲连接，PING 空闲超过检查间隔的连接，失败的连接关闭
+// 最近用过的连接不需要检查；检查期间这些连接不在池中，不会被借出
+func (c *Client) check() {
+	now := time.Now()
+	c.mu.Lock()
+	var stale, keep []*conn
+	for _, cn := range c.idle {
+		switch {
+		case c.expired(cn, now):
+			c.open--
+			cn.nc.Close()
+		case now.Sub(cn.usedAt) >= c.opts.healthCheckInterval:
+			stale = append(stale, cn)
+		default:
+			keep = append(keep, cn)
+		}
+	}
+	c.idle = keep
+	c.mu.Unlock()
+
+	for _, cn := range stale {
+		ctx, cancel := context.WithTimeout(context.Background(), c.opts.dialTimeout)
+		replies, err := cn.roundTrip(ctx, [][]string{{"PING"}})
+		cancel()
+		if err == nil {
+			err = replies[0].Err()
+		}
+
+		c.mu.Lock()
+		if err != nil || c.closed || len(c.idle) >= c.opts.maxIdle {
+			c.open--
+			c.mu.Unlock()
+			if err != nil {
+				log.Printf("⚠️ [TCPClient] 连接 %s 健康检查失败: %v", c.addr, err)
+			}
+			cn.nc.Close()
+			continue
+		}
+		// 检查过的连接放在最久未使用的一端，保持 idle 按使用时间排序
+		c.idle = append([]*conn{cn}, c.idle...)
+		c.mu.Unlock()
+	}
+}
+
+// fill 补足最少空闲连接，总连接数不超过 poolSize
+func (c *Client) fill() {
+	for {
+		c.mu.Lock()
+		if c.closed || len(c.idle) >= c.opts.minIdle || c.open >= c.opts.poolSize {
+			c.mu.Unlock()
+			return
+		}
+		c.open++
+		c.mu.Unlock()
+
+		cn, err := c.dial(context.Background())
+		c.mu.Lock()
+		if err != nil || c.closed {
+			c.open--
+			c.mu.Unlock()
+			if err != nil {
+				log.Printf("⚠️ [TCPClient] 预建连接 %s 失败: %v", c.addr, err)
+			} else {
+				cn.nc.Close()
+			}
+			return
+		}
+		c.idle = append(c.idle, cn)
+		c.mu.Unlock()
+	}
+}
+
+// process 借出连接执行一批命令，建立连接失败时重试；借出时已发现断开的空闲连接在 get 中丢弃，不计入重试
+// 请求写出之后连接中断不重试，避免命令被执行多次；服务端的错误回复不重试，作为 KindError 回复返回
+func (c *Client) process(ctx context.Context, cmds [][]string) ([]Reply, error) {
+	var lastErr error
+	for attempt := 0; attempt <= c.opts.maxRetries; attempt++ {
+		if attempt > 0 {
+			// 退避：8ms、16ms、32ms ... 最多 512ms
+			backoff := min(8*time.Millisecond<<(attempt-1), 512*time.Millisecond)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		cn, err := c.get(ctx)
+		if err != nil {
+			if ctx.Err() != nil || err == ErrClosed || isServerError(err) {
+				return nil, err
+			}
+			lastErr = err
+			continue
+		}
+
+		replies, err := cn.roundTrip(ctx, cmds)
+		c.put(cn, err != nil)
+		switch {
+		case err == nil, err == errInterrupted:
+			return replies, nil
+		case ctx.Err() != nil:
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	return nil, lastErr
+}
+
+// isServerError 握手时认证失败等服务端拒绝，重试没有意义
+func isServerError(err error) bool {
+	var se *ServerError
+	return errors.As(err, &se)
+}
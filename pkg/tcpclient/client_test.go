@@ -0,0 +1,271 @@
+package tcpclient
+
+import (
+	"Flux-KV/internal/config"
+	"Flux-KV/internal/core"
+	"Flux-KV/internal/protocol"
+	"Flux-KV/pkg/kverrors"
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// startServer 启动测试用的 TCP 服务，测试结束时关闭
+func startServer(t *testing.T, addr string, cfg *config.Config) *protocol.Server {
+	t.Helper()
+	db, _ := core.NewMemDB(cfg)
+	server := protocol.NewServer(addr, db, cfg)
+	go server.Start()
+	time.Sleep(100 * time.Millisecond)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	})
+	return server
+}
+
+// TestClient_Commands 类型化命令：空值与空字符串、二进制安全、TTL、错误回复
+func TestClient_Commands(t *testing.T) {
+	addr := "localhost:9130"
+	startServer(t, addr, &config.Config{})
+	c, err := New(addr)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+	ctx := context.Background()
+
+	// 1. 不存在的 Key 与空字符串
+	if _, ok, err := c.Get(ctx, "missing"); ok || err != nil {
+		t.Fatalf("GET missing: ok=%v err=%v", ok, err)
+	}
+	c.Set(ctx, "empty", "", 0)
+	if val, ok, err := c.Get(ctx, "empty"); !ok || val != "" || err != nil {
+		t.Fatalf("GET empty: %q ok=%v err=%v", val, ok, err)
+	}
+
+	// 2. 值中可以包含空白和换行
+	value := "hello world\r\nsecond line"
+	if err := c.Set(ctx, "k", value, 0); err != nil {
+		t.Fatalf("SET: %v", err)
+	}
+	if val, _, _ := c.Get(ctx, "k"); val != value {
+		t.Fatalf("GET k: %q", val)
+	}
+
+	// 3. TTL
+	c.Set(ctx, "ttl", "v", 50*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	if _, ok, _ := c.Get(ctx, "ttl"); ok {
+		t.Fatal("key with ttl should expire")
+	}
+
+	// 4. DEL / EXISTS
+	if n, err := c.Exists(ctx, "k", "empty", "missing"); n != 2 || err != nil {
+		t.Fatalf("EXISTS: %d %v", n, err)
+	}
+	if n, err := c.Del(ctx, "k", "missing"); n != 1 || err != nil {
+		t.Fatalf("DEL: %d %v", n, err)
+	}
+
+	// 5. 脚本与错误回复
+	sha, err := c.ScriptLoad(ctx, "return 1 + 2")
+	if err != nil {
+		t.Fatalf("SCRIPT LOAD: %v", err)
+	}
+	if r, err := c.EvalSha(ctx, sha, nil); err != nil || r.Int != 3 {
+		t.Fatalf("EVALSHA: %+v %v", r, err)
+	}
+	_, err = c.EvalSha(ctx, strings.Repeat("0", 40), nil)
+	var se *ServerError
+	if !errors.As(err, &se) || se.Code() != "NOSCRIPT" || !kverrors.IsNotFound(err) {
+		t.Fatalf("expected NOSCRIPT, got %v", err)
+	}
+	// 错误回复之后连接仍然可用
+	if err := c.Ping(ctx); err != nil {
+		t.Fatalf("PING: %v", err)
+	}
+}
+
+// TestClient_Pipeline 流水线按顺序返回回复，单条命令出错不影响其他命令
+func TestClient_Pipeline(t *testing.T) {
+	addr := "localhost:9131"
+	startServer(t, addr, &config.Config{})
+	c, err := New(addr)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+
+	p := c.Pipeline()
+	for i := 0; i < 100; i++ {
+		p.Set("p"+strconv.Itoa(i), strconv.Itoa(i), 0)
+	}
+	p.Do("EVALSHA", "nope", "0")
+	for i := 0; i < 100; i++ {
+		p.Get("p" + strconv.Itoa(i))
+	}
+	p.Get("missing")
+	replies, err := p.Exec(context.Background())
+	if err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+	if len(replies) != 202 || p.Len() != 0 {
+		t.Fatalf("expected 202 replies, got %d", len(replies))
+	}
+	if replies[100].Err() == nil {
+		t.Fatalf("EVALSHA should fail: %+v", replies[100])
+	}
+	for i := 0; i < 100; i++ {
+		if r := replies[101+i]; r.Kind != KindBulk || r.Str != strconv.Itoa(i) {
+			t.Fatalf("GET p%d: %+v", i, r)
+		}
+	}
+	if !replies[201].IsNil() {
+		t.Fatalf("GET missing: %+v", replies[201])
+	}
+}
+
+// TestClient_Pool 连接数上限、最长存活时间与最少空闲连接
+func TestClient_Pool(t *testing.T) {
+	addr := "localhost:9132"
+	startServer(t, addr, &config.Config{})
+	c, err := New(addr, WithPoolSize(2), WithMinIdleConns(2), WithMaxConnLifetime(200*time.Millisecond), WithHealthCheckInterval(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+	if s := c.Stats(); s.IdleConns != 2 {
+		t.Fatalf("expected 2 idle conns after New, got %+v", s)
+	}
+
+	// 1. 连接全部借出时等待，ctx 到期返回
+	ctx := context.Background()
+	cn1, _ := c.get(ctx)
+	cn2, _ := c.get(ctx)
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := c.Ping(waitCtx); err != context.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if s := c.Stats(); s.Timeouts != 1 {
+		t.Fatalf("expected 1 timeout, got %+v", s)
+	}
+	c.put(cn1, false)
+	c.put(cn2, false)
+
+	// 2. 过期连接被关闭，健康检查补足空闲连接
+	before := time.Now()
+	time.Sleep(300 * time.Millisecond)
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if s := c.Stats(); s.IdleConns == 2 && s.TotalConns == 2 {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("expected pool to be refilled, got %+v", s)
+		}
+	}
+	cn, err := c.get(ctx)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if !cn.createdAt.After(before) {
+		t.Fatal("expired connection should have been replaced")
+	}
+	c.put(cn, false)
+}
+
+// TestClient_Reconnect 服务重启后自动重连；ACL 认证
+func TestClient_Reconnect(t *testing.T) {
+	addr := "localhost:9133"
+	cfg := &config.Config{ACL: config.ACLConfig{Enabled: true, Users: []config.ACLUserConfig{
+		{Name: "app", Passwords: []string{"secret"}, Categories: []string{"read", "write"}, Keys: []string{"app:*"}},
+	}}}
+	server := startServer(t, addr, cfg)
+
+	// 1. 凭证错误时 New 失败
+	if _, err := New(addr, WithBasicAuth("app", "wrong")); err == nil {
+		t.Fatal("New with wrong password should fail")
+	}
+	c, err := New(addr, WithBasicAuth("app", "secret"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+	ctx := context.Background()
+	if err := c.Set(ctx, "app:1", "v", 0); err != nil {
+		t.Fatalf("SET: %v", err)
+	}
+	var se *ServerError
+	if err := c.Set(ctx, "other", "v", 0); !errors.As(err, &se) || se.Code() != "NOPERM" {
+		t.Fatalf("expected NOPERM, got %v", err)
+	}
+
+	// 2. 重启服务，池中的连接已断开，命令换新连接重试并重新认证
+	shutdownCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	server.Shutdown(shutdownCtx)
+	startServer(t, addr, cfg)
+	if err := c.Set(ctx, "app:2", "v", 0); err != nil {
+		t.Fatalf("SET after restart: %v", err)
+	}
+	if val, ok, err := c.Get(ctx, "app:2"); !ok || val != "v" || err != nil {
+		t.Fatalf("GET after restart: %q %v %v", val, ok, err)
+	}
+}
+
+// TestClient_NoRetryAfterWrite 请求写出之后连接中断时不重试，命令只发送一次
+func TestClient_NoRetryAfterWrite(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer lis.Close()
+
+	// 完成 HELLO 2 握手，读到一条命令后不回复直接断开
+	var received atomic.Int32
+	go func() {
+		for {
+			nc, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer nc.Close()
+				r, w := bufio.NewReader(nc), bufio.NewWriter(nc)
+				if _, err := readText(r); err != nil {
+					return
+				}
+				writeText(w, "OK version=2")
+				w.Flush()
+				var head [4]byte
+				if _, err := io.ReadFull(r, head[:]); err != nil {
+					return
+				}
+				if _, err := io.CopyN(io.Discard, r, int64(binary.BigEndian.Uint32(head[:]))); err == nil {
+					received.Add(1)
+				}
+			}()
+		}
+	}()
+
+	c, err := New(lis.Addr().String())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+	if err := c.Set(context.Background(), "k", "v", 0); err == nil {
+		t.Fatal("SET should fail when the connection drops")
+	}
+	if n := received.Load(); n != 1 {
+		t.Fatalf("command sent %d times, want 1", n)
+	}
+}
@@ -0,0 +1,96 @@
+package tcpclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Do 执行任意命令，返回带类型的回复；错误回复同时以 *ServerError 返回
+func (c *Client) Do(ctx context.Context, args ...string) (Reply, error) {
+	replies, err := c.process(ctx, [][]string{args})
+	if err != nil {
+		return Reply{}, err
+	}
+	return replies[0], replies[0].Err()
+}
+
+// Ping 检查节点是否可用
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.Do(ctx, "PING")
+	return err
+}
+
+// Get 读取字符串值，Key 不存在时 ok 为 false；值为空字符串时 ok 为 true
+func (c *Client) Get(ctx context.Context, key string) (val string, ok bool, err error) {
+	r, err := c.Do(ctx, "GET", key)
+	if err != nil || r.IsNil() {
+		return "", false, err
+	}
+	return r.Str, true, nil
+}
+
+// Set 写入字符串值，ttl 为 0 表示不过期
+func (c *Client) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	_, err := c.Do(ctx, setArgs(key, value, ttl)...)
+	return err
+}
+
+// Del 删除 Key，返回实际删除的个数
+func (c *Client) Del(ctx context.Context, keys ...string) (int64, error) {
+	return c.doInt(ctx, append([]string{"DEL"}, keys...))
+}
+
+// Exists 返回存在的 Key 个数，同一个 Key 出现多次时重复计数
+func (c *Client) Exists(ctx context.Context, keys ...string) (int64, error) {
+	return c.doInt(ctx, append([]string{"EXISTS"}, keys...))
+}
+
+// Publish 向当前节点上的频道发布消息，返回收到消息的订阅者数
+func (c *Client) Publish(ctx context.Context, channel, message string) (int64, error) {
+	return c.doInt(ctx, []string{"PUBLISH", channel, message})
+}
+
+// Eval 执行脚本，返回值按脚本的返回类型转换为回复
+func (c *Client) Eval(ctx context.Context, script string, keys []string, args ...string) (Reply, error) {
+	return c.Do(ctx, evalArgs("EVAL", script, keys, args)...)
+}
+
+// EvalSha 按 SHA1 执行已加载的脚本，脚本不存在时返回 Code 为 NOSCRIPT 的 *ServerError，其错误码为 kverrors.NotFound
+func (c *Client) EvalSha(ctx context.Context, sha string, keys []string, args ...string) (Reply, error) {
+	return c.Do(ctx, evalArgs("EVALSHA", sha, keys, args)...)
+}
+
+// ScriptLoad 加载脚本，返回 SHA1
+func (c *Client) ScriptLoad(ctx context.Context, script string) (string, error) {
+	r, err := c.Do(ctx, "SCRIPT", "LOAD", script)
+	return r.Str, err
+}
+
+func (c *Client) doInt(ctx context.Context, args []string) (int64, error) {
+	r, err := c.Do(ctx, args...)
+	if err != nil {
+		return 0, err
+	}
+	if r.Kind != KindInteger {
+		return 0, fmt.Errorf("tcpclient: %s returned non-integer reply %q", args[0], r.Str)
+	}
+	return r.Int, nil
+}
+
+// setArgs SET key value [PX milliseconds]，不足 1 毫秒的 ttl 按 1 毫秒处理
+func setArgs(key, value string, ttl time.Duration) []string {
+	if ttl <= 0 {
+		return []string{"SET", key, value}
+	}
+	ms := max(ttl.Milliseconds(), 1)
+	return []string{"SET", key, value, "PX", strconv.FormatInt(ms, 10)}
+}
+
+func evalArgs(cmd, script string, keys, args []string) []string {
+	out := make([]string, 0, 3+len(keys)+len(args))
+	out = append(out, cmd, script, strconv.Itoa(len(keys)))
+	out = append(out, keys...)
+	return append(out, args...)
+}
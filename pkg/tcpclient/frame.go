@@ -0,0 +1,74 @@
+package tcpclient
+
+import (
+	"Flux-KV/pkg/wire"
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// 客户端只使用 v2 二进制帧（格式与编解码见 pkg/wire，与服务端共用）：
+// 连接建立后先按 v1 文本帧发送 HELLO 2，收到 "OK version=2" 后改用 v2 帧。
+// 请求不带 ID，按顺序回复，流水线一次写出多个请求再按顺序读取回复
+
+// Kind 回复的类型，取值与 v2 帧中的类型字节相同
+type Kind = wire.Kind
+
+const (
+	KindStatus  = wire.KindStatus  // 状态，例如 OK
+	KindError   = wire.KindError   // 错误，Str 以错误码开头，例如 ERR / WRONGTYPE / NOPERM
+	KindBulk    = wire.KindBulk    // 二进制安全的字符串，可以为空字符串
+	KindNil     = wire.KindNil     // 空值，例如 GET 不存在的 Key
+	KindInteger = wire.KindInteger // 64 位有符号整数
+	KindArray   = wire.KindArray   // 数组
+)
+
+// Reply 带类型的回复，KindNil 与空字符串的 KindBulk 是两种不同的回复；Err 把错误回复转换为 *ServerError
+type Reply = wire.Reply
+
+// ServerError 服务端返回的错误回复，连接本身仍然可用
+type ServerError = wire.ServerError
+
+// writeCommand 把命令按 OpCommand 帧写入 w
+func writeCommand(w *bufio.Writer, args []string) error {
+	_, err := w.Write(wire.EncodeCommand(args...))
+	return err
+}
+
+// writeText 按 v1 文本帧写入一条消息，只用于 HELLO 握手
+func writeText(w *bufio.Writer, msg string) error {
+	var head [4]byte
+	binary.BigEndian.PutUint32(head[:], uint32(len(msg)))
+	w.Write(head[:])
+	_, err := w.WriteString(msg)
+	return err
+}
+
+// readText 读取一条 v1 文本帧
+func readText(r *bufio.Reader) (string, error) {
+	var head [4]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return "", err
+	}
+	body := make([]byte, binary.BigEndian.Uint32(head[:]))
+	if _, err := io.ReadFull(r, body); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return "", err
+	}
+	return string(body), nil
+}
+
+// readReply 读取一个 OpReply 帧；连接上不会订阅，也不发送带 ID 的请求，收到推送帧或带 ID 的回复视为协议错误
+func readReply(r *bufio.Reader) (Reply, error) {
+	resp, err := wire.ReadResponse(r)
+	if err != nil {
+		return Reply{}, err
+	}
+	if resp.Tagged || resp.Reply.Kind == wire.KindPush {
+		return Reply{}, fmt.Errorf("%w: unexpected push or tagged reply", wire.ErrMalformed)
+	}
+	return resp.Reply, nil
+}
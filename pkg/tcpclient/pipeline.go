@@ -0,0 +1,55 @@
+package tcpclient
+
+import (
+	"context"
+	"time"
+)
+
+// Pipeline 流水线：攒下多条命令，在同一个连接上一次写出，再按顺序读取所有回复
+// 流水线中的命令不是事务，其他连接的命令可能穿插执行；Pipeline 不能并发使用
+type Pipeline struct {
+	c    *Client
+	cmds [][]string
+}
+
+// Pipeline 创建一个空的流水线
+func (c *Client) Pipeline() *Pipeline {
+	return &Pipeline{c: c}
+}
+
+// Do 追加任意命令
+func (p *Pipeline) Do(args ...string) *Pipeline {
+	p.cmds = append(p.cmds, args)
+	return p
+}
+
+// Get 追加 GET，Key 不存在时对应的回复为 KindNil
+func (p *Pipeline) Get(key string) *Pipeline {
+	return p.Do("GET", key)
+}
+
+// Set 追加 SET，ttl 为 0 表示不过期
+func (p *Pipeline) Set(key, value string, ttl time.Duration) *Pipeline {
+	return p.Do(setArgs(key, value, ttl)...)
+}
+
+// Del 追加 DEL
+func (p *Pipeline) Del(keys ...string) *Pipeline {
+	return p.Do(append([]string{"DEL"}, keys...)...)
+}
+
+// Len 已追加的命令数
+func (p *Pipeline) Len() int {
+	return len(p.cmds)
+}
+
+// Exec 发送所有命令并返回与命令一一对应的回复，之后流水线清空可以继续使用
+// 单条命令的错误回复不影响其他命令，通过 Reply.Err 检查；返回的 error 只表示连接或上下文错误
+func (p *Pipeline) Exec(ctx context.Context) ([]Reply, error) {
+	cmds := p.cmds
+	p.cmds = nil
+	if len(cmds) == 0 {
+		return nil, nil
+	}
+	return p.c.process(ctx, cmds)
+}
@@ -0,0 +1,38 @@
+//go:build linux
+
+package tcpclient
+
+import (
+	"crypto/tls"
+	"syscall"
+)
+
+// stale 空闲连接上不应有可读的数据：服务端关闭连接（EOF）或在关闭前发来错误回复时，连接不能复用
+// 用 MSG_PEEK | MSG_DONTWAIT 非阻塞地查看 socket，不消费数据也不修改连接的截止时间；
+// 截止时间设为当前时间的 Read 会在检查 socket 之前直接超时，发现不了已关闭的连接
+func (cn *conn) stale() bool {
+	if cn.r.Buffered() > 0 {
+		return true
+	}
+	nc := cn.nc
+	if tc, ok := nc.(*tls.Conn); ok {
+		nc = tc.NetConn()
+	}
+	sc, ok := nc.(syscall.Conn)
+	if !ok {
+		return false
+	}
+	raw, err := sc.SyscallConn()
+	if err != nil {
+		return true
+	}
+	var stale bool
+	err = raw.Read(func(fd uintptr) bool {
+		var b [1]byte
+		_, _, err := syscall.Recvfrom(int(fd), b[:], syscall.MSG_PEEK|syscall.MSG_DONTWAIT)
+		// EAGAIN 表示没有数据，连接正常；读到数据、EOF（n == 0）或其他错误都不能复用
+		stale = err != syscall.EAGAIN
+		return true
+	})
+	return err != nil || stale
+}
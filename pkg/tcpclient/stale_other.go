@@ -0,0 +1,21 @@
+//go:build !linux
+
+package tcpclient
+
+import (
+	"errors"
+	"net"
+	"time"
+)
+
+// stale 空闲连接上不应有可读的数据：服务端关闭连接（EOF）或在关闭前发来错误回复时，连接不能复用
+// 截止时间设为当前时间的 Read 会在检查 socket 之前直接超时，这里等待 1ms，借出连接时多出这部分延迟
+func (cn *conn) stale() bool {
+	if cn.r.Buffered() > 0 {
+		return true
+	}
+	cn.nc.SetReadDeadline(time.Now().Add(time.Millisecond))
+	_, err := cn.r.Peek(1)
+	var ne net.Error
+	return !errors.As(err, &ne) || !ne.Timeout()
+}
@@ -0,0 +1,266 @@
+package tlsutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"google.golang.org/grpc/credentials"
+)
+
+// reloadDelay 文件变化后等待的时间，证书和私钥通常先后写入，合并为一次加载
+const reloadDelay = 200 * time.Millisecond
+
+// Config 证书文件与校验规则
+type Config struct {
+	CertFile     string   // 本端证书（PEM），作为服务端证书和 mTLS 的客户端证书
+	KeyFile      string   // 本端私钥（PEM）
+	CAFile       string   // 校验对端证书的 CA（PEM），客户端为空时使用系统 CA
+	ClientAuth   bool     // 服务端要求客户端出示由 CAFile 签发的证书（mTLS）
+	ServerName   string   // 客户端校验服务端证书时使用的名称，为空时使用连接地址
+	AllowedNames []string // 允许的对端身份（证书 CN 或 DNS SAN），为空时接受 CA 签发的任意证书
+}
+
+// Reloader 持有当前的证书和 CA，文件变化时自动重新加载，已建立的连接不受影响
+// 加载失败时继续使用旧证书
+type Reloader struct {
+	cfg     Config
+	cert    atomic.Pointer[tls.Certificate]
+	pool    atomic.Pointer[x509.CertPool]
+	watcher *fsnotify.Watcher
+}
+
+// NewReloader 加载证书并监听文件变化
+func NewReloader(cfg Config) (*Reloader, error) {
+	if cfg.ClientAuth && cfg.CAFile == "" {
+		return nil, errors.New("tls: client_auth requires ca_file")
+	}
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, errors.New("tls: cert_file and key_file must be set together")
+	}
+
+	// 1. 首次加载
+	r := &Reloader{cfg: cfg}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	// 2. 监听文件所在目录：Kubernetes 等通过替换符号链接更新证书，直接监听文件会丢失事件
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	dirs := make(map[string]bool)
+	for _, f := range []string{cfg.CertFile, cfg.KeyFile, cfg.CAFile} {
+		if f == "" || dirs[filepath.Dir(f)] {
+			continue
+		}
+		dirs[filepath.Dir(f)] = true
+		if err := watcher.Add(filepath.Dir(f)); err != nil {
+			watcher.Close()
+			return nil, fmt.Errorf("tls: watch %s: %w", filepath.Dir(f), err)
+		}
+	}
+	r.watcher = watcher
+	go r.watch()
+
+	return r, nil
+}
+
+// Reload 重新读取证书、私钥和 CA，全部成功后才替换
+func (r *Reloader) Reload() error {
+	var cert *tls.Certificate
+	if r.cfg.CertFile != "" {
+		c, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+		if err != nil {
+			return fmt.Errorf("tls: load key pair: %w", err)
+		}
+		cert = &c
+	}
+
+	var pool *x509.CertPool
+	if r.cfg.CAFile != "" {
+		pem, err := os.ReadFile(r.cfg.CAFile)
+		if err != nil {
+			return fmt.Errorf("tls: read ca: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("tls: no certificate found in %s", r.cfg.CAFile)
+		}
+	}
+
+	r.cert.Store(cert)
+	r.pool.Store(pool)
+	return nil
+}
+
+// Close 停止监听文件变化
+func (r *Reloader) Close() error {
+	return r.watcher.Close()
+}
+
+func (r *Reloader) watch() {
+	timer := time.NewTimer(reloadDelay)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case _, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			timer.Reset(reloadDelay)
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("⚠️ [TLS] 监听证书文件出错: %v", err)
+		case <-timer.C:
+			if err := r.Reload(); err != nil {
+				log.Printf("❌ [TLS] 重新加载证书失败，继续使用旧证书: %v", err)
+				continue
+			}
+			log.Printf("🔐 [TLS] 证书已重新加载 (cert=%q ca=%q)", r.cfg.CertFile, r.cfg.CAFile)
+		}
+	}
+}
+
+// ServerConfig 服务端使用的 tls.Config，每次握手使用最新的证书和 CA
+// 可以直接用于 tls.NewListener、http.Server 和 credentials.NewTLS
+func (r *Reloader) ServerConfig() *tls.Config {
+	c := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			if cert := r.cert.Load(); cert != nil {
+				return cert, nil
+			}
+			return nil, errors.New("tls: no server certificate configured")
+		},
+	}
+	if r.cfg.ClientAuth {
+		// CA 会被重新加载，不能固定在 ClientCAs 中，握手时按当前的 CA 校验
+		c.ClientAuth = tls.RequireAnyClientCert
+		c.VerifyConnection = func(cs tls.ConnectionState) error {
+			return r.verifyPeer(cs.PeerCertificates)
+		}
+	}
+	return c
+}
+
+// ClientConfig 客户端使用的 tls.Config，CA 为调用时的快照，新建连接时应重新获取
+func (r *Reloader) ClientConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: r.cfg.ServerName,
+		RootCAs:    r.pool.Load(),
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if cert := r.cert.Load(); cert != nil {
+				return cert, nil
+			}
+			// 没有配置证书时不出示客户端证书
+			return &tls.Certificate{}, nil
+		},
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			return r.checkAllowed(cs.PeerCertificates)
+		},
+	}
+}
+
+// verifyPeer 按当前的 CA 校验客户端证书链，再检查身份白名单
+func (r *Reloader) verifyPeer(certs []*x509.Certificate) error {
+	if len(certs) == 0 {
+		return errors.New("tls: client certificate required")
+	}
+	opts := x509.VerifyOptions{
+		Roots:         r.pool.Load(),
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, c := range certs[1:] {
+		opts.Intermediates.AddCert(c)
+	}
+	if _, err := certs[0].Verify(opts); err != nil {
+		return fmt.Errorf("tls: verify client certificate: %w", err)
+	}
+	return r.checkAllowed(certs)
+}
+
+// checkAllowed 对端身份必须在 AllowedNames 中
+func (r *Reloader) checkAllowed(certs []*x509.Certificate) error {
+	if len(r.cfg.AllowedNames) == 0 || len(certs) == 0 {
+		return nil
+	}
+	leaf := certs[0]
+	for _, name := range append([]string{leaf.Subject.CommonName}, leaf.DNSNames...) {
+		if name != "" && slices.Contains(r.cfg.AllowedNames, name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("tls: peer %q is not allowed", Identity(leaf))
+}
+
+// TransportCredentials gRPC 的传输凭证，每次握手按最新的证书和 CA 建立 TLS
+func (r *Reloader) TransportCredentials() credentials.TransportCredentials {
+	return &reloadingCredentials{r: r, serverName: r.cfg.ServerName}
+}
+
+// reloadingCredentials 握手时才创建 credentials.NewTLS，使客户端也能用上重新加载的 CA
+type reloadingCredentials struct {
+	r          *Reloader
+	serverName string
+}
+
+func (c *reloadingCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	cfg := c.r.ClientConfig()
+	cfg.ServerName = c.serverName
+	return credentials.NewTLS(cfg).ClientHandshake(ctx, authority, conn)
+}
+
+func (c *reloadingCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(c.r.ServerConfig()).ServerHandshake(conn)
+}
+
+func (c *reloadingCredentials) Info() credentials.ProtocolInfo {
+	return credentials.NewTLS(nil).Info()
+}
+
+func (c *reloadingCredentials) Clone() credentials.TransportCredentials {
+	return &reloadingCredentials{r: c.r, serverName: c.serverName}
+}
+
+func (c *reloadingCredentials) OverrideServerName(name string) error {
+	c.serverName = name
+	return nil
+}
+
+// Identity 证书代表的身份：优先使用 CN，没有时使用第一个 DNS SAN
+func Identity(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" || len(cert.DNSNames) == 0 {
+		return cert.Subject.CommonName
+	}
+	return cert.DNSNames[0]
+}
+
+// ConnIdentity TLS 连接对端证书的身份，握手未完成或对端没有出示证书时返回空
+func ConnIdentity(conn net.Conn) string {
+	tc, ok := conn.(*tls.Conn)
+	if !ok {
+		return ""
+	}
+	if certs := tc.ConnectionState().PeerCertificates; len(certs) > 0 {
+		return Identity(certs[0])
+	}
+	return ""
+}
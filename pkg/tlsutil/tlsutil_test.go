@@ -0,0 +1,263 @@
+package tlsutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+)
+
+// testCA 测试用的自签名 CA
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "flux-test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue 签发同时可用于服务端和客户端的证书，写入 dir/name.crt 与 dir/name.key
+func (ca *testCA) issue(t *testing.T, dir, name, cn string) (certFile, keyFile string) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	certFile, keyFile = filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	// 先写到临时文件再重命名，避免重新加载时读到一半
+	writeAtomic(t, keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	writeAtomic(t, certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	return certFile, keyFile
+}
+
+func writeAtomic(t *testing.T, path string, data []byte) {
+	if err := os.WriteFile(path+".tmp", data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// handshake 建立一次 TLS 连接，返回服务端看到的客户端身份和客户端看到的服务端身份
+func handshake(t *testing.T, server, client *tls.Config) (clientID, serverID string, err error) {
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	type result struct {
+		id  string
+		err error
+	}
+	accepted := make(chan result, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			accepted <- result{err: err}
+			return
+		}
+		defer conn.Close()
+		err = conn.(*tls.Conn).Handshake()
+		accepted <- result{ConnIdentity(conn), err}
+	}()
+
+	conn, err := tls.Dial("tcp", ln.Addr().String(), client)
+	res := <-accepted
+	if err != nil {
+		return "", "", err
+	}
+	defer conn.Close()
+	if res.err != nil {
+		return "", "", res.err
+	}
+	return res.id, ConnIdentity(conn), nil
+}
+
+func TestReloader_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	caFile := filepath.Join(dir, "ca.crt")
+	os.WriteFile(caFile, ca.pem, 0o600)
+	nodeCert, nodeKey := ca.issue(t, dir, "node", "flux-node")
+	gwCert, gwKey := ca.issue(t, dir, "gateway", "flux-gateway")
+
+	node, err := NewReloader(Config{CertFile: nodeCert, KeyFile: nodeKey, CAFile: caFile, ClientAuth: true, AllowedNames: []string{"flux-gateway"}})
+	if err != nil {
+		t.Fatalf("NewReloader: %v", err)
+	}
+	defer node.Close()
+	gateway, err := NewReloader(Config{CertFile: gwCert, KeyFile: gwKey, CAFile: caFile})
+	if err != nil {
+		t.Fatalf("NewReloader: %v", err)
+	}
+	defer gateway.Close()
+
+	// 1. 双向认证成功，双方都能拿到对端身份
+	clientID, serverID, err := handshake(t, node.ServerConfig(), gateway.ClientConfig())
+	if err != nil || clientID != "flux-gateway" || serverID != "flux-node" {
+		t.Fatalf("mTLS handshake: client=%q server=%q err=%v", clientID, serverID, err)
+	}
+
+	// 2. 不出示证书的客户端被拒绝
+	anonymous, _ := NewReloader(Config{CAFile: caFile})
+	defer anonymous.Close()
+	if _, _, err := handshake(t, node.ServerConfig(), anonymous.ClientConfig()); err == nil {
+		t.Fatal("client without certificate should be rejected")
+	}
+
+	// 3. CA 签发但不在白名单中的身份被拒绝
+	otherCert, otherKey := ca.issue(t, t.TempDir(), "other", "someone-else")
+	other, _ := NewReloader(Config{CertFile: otherCert, KeyFile: otherKey, CAFile: caFile})
+	defer other.Close()
+	if _, _, err := handshake(t, node.ServerConfig(), other.ClientConfig()); err == nil {
+		t.Fatal("client not in allowed_names should be rejected")
+	}
+
+	// 4. 其他 CA 签发的证书被拒绝
+	rogueCert, rogueKey := newTestCA(t).issue(t, t.TempDir(), "rogue", "flux-gateway")
+	rogue, _ := NewReloader(Config{CertFile: rogueCert, KeyFile: rogueKey, CAFile: caFile})
+	defer rogue.Close()
+	if _, _, err := handshake(t, node.ServerConfig(), rogue.ClientConfig()); err == nil {
+		t.Fatal("certificate from unknown CA should be rejected")
+	}
+}
+
+func TestReloader_HotReload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	caFile := filepath.Join(dir, "ca.crt")
+	os.WriteFile(caFile, ca.pem, 0o600)
+	nodeCert, nodeKey := ca.issue(t, dir, "node", "flux-node-v1")
+
+	node, err := NewReloader(Config{CertFile: nodeCert, KeyFile: nodeKey})
+	if err != nil {
+		t.Fatalf("NewReloader: %v", err)
+	}
+	defer node.Close()
+	client, _ := NewReloader(Config{CAFile: caFile})
+	defer client.Close()
+
+	// 同一个 ServerConfig 在证书文件替换后使用新证书，不需要重启监听
+	server := node.ServerConfig()
+	ca.issue(t, dir, "node", "flux-node-v2")
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		_, serverID, err := handshake(t, server, client.ClientConfig())
+		if err == nil && serverID == "flux-node-v2" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("certificate was not reloaded: server=%q err=%v", serverID, err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// 写入无效内容时保留旧证书
+	os.WriteFile(nodeCert, []byte("garbage"), 0o600)
+	time.Sleep(2 * reloadDelay)
+	if _, serverID, err := handshake(t, server, client.ClientConfig()); err != nil || serverID != "flux-node-v2" {
+		t.Fatalf("invalid certificate should keep the old one: server=%q err=%v", serverID, err)
+	}
+}
+
+// TestReloader_GRPC 网关与存储节点之间的 gRPC mTLS，服务端能拿到客户端证书的身份
+func TestReloader_GRPC(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	caFile := filepath.Join(dir, "ca.crt")
+	os.WriteFile(caFile, ca.pem, 0o600)
+	nodeCert, nodeKey := ca.issue(t, dir, "node", "flux-node")
+	gwCert, gwKey := ca.issue(t, dir, "gateway", "flux-gateway")
+
+	node, _ := NewReloader(Config{CertFile: nodeCert, KeyFile: nodeKey, CAFile: caFile, ClientAuth: true})
+	defer node.Close()
+	gateway, _ := NewReloader(Config{CertFile: gwCert, KeyFile: gwKey, CAFile: caFile})
+	defer gateway.Close()
+
+	identity := make(chan string, 1)
+	srv := grpc.NewServer(
+		grpc.Creds(node.TransportCredentials()),
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if p, ok := peer.FromContext(ctx); ok {
+				if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.PeerCertificates) > 0 {
+					identity <- Identity(info.State.PeerCertificates[0])
+					return handler(ctx, req)
+				}
+			}
+			identity <- ""
+			return handler(ctx, req)
+		}),
+	)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	lis, _ := net.Listen("tcp", "127.0.0.1:0")
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(gateway.TransportCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient: %v", err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check over mTLS: %v", err)
+	}
+	if id := <-identity; id != "flux-gateway" {
+		t.Fatalf("expected peer identity flux-gateway, got %q", id)
+	}
+
+	// 不带证书的客户端无法建立连接
+	plain, _ := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer plain.Close()
+	if _, err := healthpb.NewHealthClient(plain).Check(ctx, &healthpb.HealthCheckRequest{}); err == nil {
+		t.Fatal("plaintext client should be rejected")
+	}
+}
@@ -0,0 +1,333 @@
+// Package wire v2 二进制帧的编解码，服务端 internal/protocol 与客户端 pkg/tcpclient 共用
+package wire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// v2 二进制帧，整数均为大端序:
+//
+//	| length uint32 | opcode uint8 | body |    length 为 opcode + body 的字节数
+//
+// OpCommand 的 body: | argc uint32 | arglen uint32 | arg | ... |，第一个参数为命令名
+// OpReply / OpPush 的 body: 一个带类型的值 | kind uint8 | payload |
+// OpCommandID / OpReplyID 的 body 在上述内容前多一个 | id uint64 |，用于多路复用
+//   - Status / Error / Bulk: | len uint32 | bytes |
+//   - Nil: 无 payload
+//   - Integer: | int64 |
+//   - Array: | n uint32 | 值 ... |
+//
+// 所有参数和字符串都带长度前缀，可以包含空白、换行和任意二进制数据。
+// 客户端在 v1 文本协议下发送 HELLO 2 并收到 "OK version=2" 后，双方改用 v2 帧；不握手的旧客户端继续使用 v1。
+//
+// 不带 ID 的请求按顺序执行、按顺序回复，客户端可以不等回复连续发送（流水线）；
+// 带 ID 的请求并发执行，回复完成即返回，顺序不定，客户端按 ID 匹配
+const (
+	OpCommand   byte = 0x01 // 请求
+	OpReply     byte = 0x02 // 请求的回复
+	OpPush      byte = 0x03 // 服务端主动推送（订阅消息、MONITOR、WATCH 事件），body 为数组
+	OpCommandID byte = 0x04 // 带请求 ID 的请求
+	OpReplyID   byte = 0x05 // 带请求 ID 的回复，ID 与请求相同
+)
+
+// ProtocolVersion v2 帧的版本号，用于 HELLO 握手
+const ProtocolVersion = 2
+
+// MaxFrameSize 请求帧默认的最大字节数，服务端可通过 tcp.max_frame_size 调整
+const MaxFrameSize = 64 * 1024 * 1024
+
+// maxReplyDepth 数组最大嵌套层数
+const maxReplyDepth = 64
+
+var (
+	// ErrFrameTooLarge 帧长度超过限制
+	ErrFrameTooLarge = errors.New("frame too large")
+	// ErrMalformed 帧格式错误，连接不能再使用；具体原因包装在返回的错误中
+	ErrMalformed = errors.New("malformed frame")
+)
+
+// Request 一个请求帧，Tagged 为 true 时带请求 ID
+type Request struct {
+	ID     uint64
+	Tagged bool
+	Args   []string
+}
+
+// Response 一个回复帧，Tagged 为 true 时 ID 为对应请求的 ID；推送帧的 Reply.Kind 为 KindPush
+type Response struct {
+	ID     uint64
+	Tagged bool
+	Reply  Reply
+}
+
+// EncodeCommand 把命令和参数打包成 OpCommand 帧
+func EncodeCommand(args ...string) []byte {
+	return finishFrame(appendCommand(append(make([]byte, 4, 64), OpCommand), args))
+}
+
+// EncodeCommandID 把命令和参数打包成带请求 ID 的 OpCommandID 帧
+func EncodeCommandID(id uint64, args ...string) []byte {
+	buf := binary.BigEndian.AppendUint64(append(make([]byte, 4, 64), OpCommandID), id)
+	return finishFrame(appendCommand(buf, args))
+}
+
+func appendCommand(buf []byte, args []string) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(args)))
+	for _, arg := range args {
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(arg)))
+		buf = append(buf, arg...)
+	}
+	return buf
+}
+
+// finishFrame 回填帧头中的长度
+func finishFrame(buf []byte) []byte {
+	binary.BigEndian.PutUint32(buf, uint32(len(buf)-4))
+	return buf
+}
+
+// ReadCommand 读取一个请求帧，只返回命令和参数
+func ReadCommand(r io.Reader) ([]string, error) {
+	req, err := ReadRequest(r, MaxFrameSize)
+	return req.Args, err
+}
+
+// ReadRequest 读取一个 OpCommand 或 OpCommandID 帧，帧长度超过 limit 时返回 ErrFrameTooLarge，limit 为 0 表示不限制
+func ReadRequest(r io.Reader, limit int) (Request, error) {
+	op, body, err := readFrame(r, limit)
+	if err != nil {
+		return Request{}, err
+	}
+	d := decoder{buf: body}
+	var req Request
+	switch op {
+	case OpCommand:
+	case OpCommandID:
+		req.ID, req.Tagged = d.uint64(), true
+	default:
+		return Request{}, fmt.Errorf("%w: unexpected opcode 0x%02x", ErrMalformed, op)
+	}
+	argc := d.uint32()
+	// 每个参数至少占 4 字节长度头，提前检查避免按恶意的 argc 分配内存
+	if d.err == nil && uint64(argc)*4 > uint64(len(d.buf)) {
+		return Request{}, fmt.Errorf("%w: argument count exceeds frame size", ErrMalformed)
+	}
+	req.Args = make([]string, 0, argc)
+	for i := uint32(0); i < argc && d.err == nil; i++ {
+		req.Args = append(req.Args, d.bytes())
+	}
+	if err := d.finish(); err != nil {
+		return Request{}, err
+	}
+	return req, nil
+}
+
+// EncodeReply 把回复打包成帧，KindPush 为 OpPush 帧，其余为 OpReply 帧
+func EncodeReply(r Reply) []byte {
+	op := OpReply
+	if r.Kind == KindPush {
+		op = OpPush
+	}
+	return finishFrame(appendReply(append(make([]byte, 4, 64), op), r))
+}
+
+// EncodeReplyID 把回复打包成带请求 ID 的 OpReplyID 帧
+func EncodeReplyID(id uint64, r Reply) []byte {
+	buf := binary.BigEndian.AppendUint64(append(make([]byte, 4, 64), OpReplyID), id)
+	return finishFrame(appendReply(buf, r))
+}
+
+// appendReply 编码一个值；v2 没有 Double / Bool 类型，分别转换为 Bulk 和 Integer
+func appendReply(buf []byte, r Reply) []byte {
+	switch r.Kind {
+	case KindDouble:
+		return appendReply(buf, Reply{Kind: KindBulk, Str: strconv.FormatFloat(r.Float, 'g', -1, 64)})
+	case KindBool:
+		return appendReply(buf, Reply{Kind: KindInteger, Int: r.Int})
+	case KindPush:
+		return appendReply(buf, Reply{Kind: KindArray, Elems: r.Elems})
+	}
+
+	buf = append(buf, byte(r.Kind))
+	switch r.Kind {
+	case KindStatus, KindError, KindBulk:
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(r.Str)))
+		buf = append(buf, r.Str...)
+	case KindInteger:
+		buf = binary.BigEndian.AppendUint64(buf, uint64(r.Int))
+	case KindArray:
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(r.Elems)))
+		for _, e := range r.Elems {
+			buf = appendReply(buf, e)
+		}
+	}
+	return buf
+}
+
+// ReadReply 读取一个回复帧，只返回回复内容
+func ReadReply(r io.Reader) (Reply, error) {
+	resp, err := ReadResponse(r)
+	return resp.Reply, err
+}
+
+// ReadResponse 读取一个 OpReply、OpReplyID 或 OpPush 帧
+func ReadResponse(r io.Reader) (Response, error) {
+	op, body, err := readFrame(r, 0)
+	if err != nil {
+		return Response{}, err
+	}
+	d := decoder{buf: body}
+	var resp Response
+	switch op {
+	case OpReply, OpPush:
+	case OpReplyID:
+		resp.ID, resp.Tagged = d.uint64(), true
+	default:
+		return Response{}, fmt.Errorf("%w: unexpected opcode 0x%02x", ErrMalformed, op)
+	}
+	resp.Reply = d.reply(0)
+	if err := d.finish(); err != nil {
+		return Response{}, err
+	}
+	if op == OpPush {
+		resp.Reply.Kind = KindPush
+	}
+	return resp, nil
+}
+
+// readFrame 读取一帧，返回 opcode 和 body；limit 为 0 表示不限制长度
+func readFrame(r io.Reader, limit int) (byte, []byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	length := binary.BigEndian.Uint32(header[:])
+	if length == 0 {
+		return 0, nil, fmt.Errorf("%w: missing opcode", ErrMalformed)
+	}
+	if limit > 0 && int64(length) > int64(limit) {
+		return 0, nil, ErrFrameTooLarge
+	}
+	frame, err := ReadFull(r, int(length))
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, nil, err
+	}
+	return frame[0], frame[1:], nil
+}
+
+// ReadFull 读满 n 字节
+// n 较大时按块读取，内存随实际收到的数据增长，避免按帧头中声明的长度一次性分配
+func ReadFull(r io.Reader, n int) ([]byte, error) {
+	const chunk = 64 * 1024
+	if n <= chunk {
+		buf := make([]byte, n)
+		_, err := io.ReadFull(r, buf)
+		return buf, err
+	}
+	var buf bytes.Buffer
+	buf.Grow(chunk)
+	if _, err := io.CopyN(&buf, r, int64(n)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decoder 顺序读取 body，出错后后续读取都返回零值
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) take(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n > len(d.buf) {
+		d.fail()
+		return nil
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *decoder) fail() {
+	if d.err == nil {
+		d.err = fmt.Errorf("%w: truncated body", ErrMalformed)
+	}
+}
+
+func (d *decoder) uint32() uint32 {
+	if b := d.take(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (d *decoder) uint64() uint64 {
+	if b := d.take(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (d *decoder) bytes() string {
+	n := d.uint32()
+	if uint64(n) > uint64(len(d.buf)) {
+		d.fail()
+		return ""
+	}
+	return string(d.take(int(n)))
+}
+
+func (d *decoder) reply(depth int) Reply {
+	kind := d.take(1)
+	if kind == nil {
+		return Reply{}
+	}
+	r := Reply{Kind: Kind(kind[0])}
+	switch r.Kind {
+	case KindStatus, KindError, KindBulk:
+		r.Str = d.bytes()
+	case KindNil:
+	case KindInteger:
+		r.Int = int64(d.uint64())
+	case KindArray:
+		n := d.uint32()
+		if depth >= maxReplyDepth {
+			d.err = fmt.Errorf("%w: array nesting too deep", ErrMalformed)
+			return Reply{}
+		}
+		// 每个元素至少占 1 字节
+		if uint64(n) > uint64(len(d.buf)) {
+			d.fail()
+			return Reply{}
+		}
+		r.Elems = make([]Reply, 0, n)
+		for i := uint32(0); i < n && d.err == nil; i++ {
+			r.Elems = append(r.Elems, d.reply(depth+1))
+		}
+	default:
+		d.err = fmt.Errorf("%w: unknown reply kind 0x%02x", ErrMalformed, kind[0])
+	}
+	return r
+}
+
+// finish 检查 body 是否恰好读完
+func (d *decoder) finish() error {
+	if d.err == nil && len(d.buf) > 0 {
+		d.err = fmt.Errorf("%w: trailing bytes", ErrMalformed)
+	}
+	return d.err
+}
@@ -0,0 +1,25 @@
+package wire
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestFrame_Malformed 验证格式错误的帧被拒绝
+func TestFrame_Malformed(t *testing.T) {
+	frame := EncodeCommand("SET", "k", "v")
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"Truncated", frame[:len(frame)-1]},
+		{"BadArgLength", append(append([]byte{}, frame[:13]...), 0xff, 0xff, 0xff, 0xff)},
+		{"WrongOpcode", EncodeReply(Reply{Kind: KindStatus, Str: "OK"})},
+		{"TooLarge", []byte{0xff, 0xff, 0xff, 0xff}},
+	}
+	for _, tt := range tests {
+		if _, err := ReadCommand(strings.NewReader(string(tt.data))); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
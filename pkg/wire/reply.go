@@ -0,0 +1,64 @@
+package wire
+
+import (
+	"Flux-KV/pkg/kverrors"
+	"strings"
+)
+
+// Kind 回复的类型，Status 到 Array 的取值与 v2 帧中的类型字节相同
+type Kind byte
+
+const (
+	KindStatus  Kind = iota + 1 // 状态，例如 OK
+	KindError                   // 错误，Str 以 Redis 风格的错误码开头，例如 ERR / WRONGTYPE / NOSCRIPT
+	KindBulk                    // 二进制安全的字符串，可以为空字符串
+	KindNil                     // 空值，例如 GET 不存在的 Key
+	KindInteger                 // 64 位有符号整数
+	KindArray                   // 数组，元素可以是任意类型
+	KindDouble                  // 浮点数，仅 RESP3 原样传输，v2 帧中转换为字符串
+	KindBool                    // 布尔值，仅 RESP3 原样传输，v2 帧中转换为整数
+	KindPush                    // 服务端主动推送的数组，例如订阅消息；v2 帧中以 OpPush 帧发送
+)
+
+// Reply 带类型的回复，服务端与客户端共用；KindNil 与空字符串的 KindBulk 是两种不同的回复
+type Reply struct {
+	Kind  Kind
+	Str   string  // Status / Error / Bulk
+	Int   int64   // Integer；Bool 时 1 为 true
+	Float float64 // Double
+	Elems []Reply // Array / Push
+	Text  string  // 服务端 v1 文本协议中的格式，不参与编码，解码得到的回复中为空
+}
+
+// IsNil 回复是否为空值
+func (r Reply) IsNil() bool {
+	return r.Kind == KindNil
+}
+
+// Err 错误回复转换为 *ServerError，其余回复返回 nil
+func (r Reply) Err() error {
+	if r.Kind == KindError {
+		return &ServerError{Msg: r.Str}
+	}
+	return nil
+}
+
+// ServerError 服务端返回的错误回复，连接本身仍然可用
+type ServerError struct {
+	Msg string // 完整的错误信息，例如 "WRONGTYPE Operation against a key holding the wrong kind of value"
+}
+
+func (e *ServerError) Error() string {
+	return e.Msg
+}
+
+// Code 错误信息的第一个单词，例如 ERR / WRONGTYPE / NOSCRIPT / NOAUTH / NOPERM
+func (e *ServerError) Code() string {
+	code, _, _ := strings.Cut(e.Msg, " ")
+	return code
+}
+
+// ErrorCode 按错误前缀识别的 kverrors 错误码，可以用 kverrors.Is 判断，例如 kverrors.Is(err, kverrors.NotFound)
+func (e *ServerError) ErrorCode() kverrors.Code {
+	return kverrors.FromTCP(e.Msg).Code
+}